@@ -1,23 +1,45 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"syscall"
 	"text/tabwriter"
 	"time"
 
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	ghpkg "github.com/mgreau/zen/internal/github"
 	"github.com/mgreau/zen/internal/reconciler"
 	"github.com/mgreau/zen/internal/session"
 	"github.com/mgreau/zen/internal/ui"
 	"github.com/mgreau/zen/internal/worktree"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
 	agentRunning bool
 	agentFull    bool
+
+	agentPromptModel string
+	agentPromptNew   bool
+
+	agentTailLines   int
+	agentTailSession string
+
+	agentStatsDays int
+
+	agentReportSession string
+	agentReportOutput  string
+	agentReportNotes   bool
 )
 
 var agentCmd = &cobra.Command{
@@ -33,11 +55,98 @@ including token usage, running status, and last activity time.`,
 	RunE: runAgentStatus,
 }
 
+var agentPromptCmd = &cobra.Command{
+	Use:   "prompt <worktree> <message>",
+	Short: "Send a one-shot prompt to a worktree's Claude session",
+	Long: `Sends a prompt to the most recent Claude session of a worktree by running
+Claude headlessly (claude -p --resume) in that worktree, and prints the reply.
+
+The worktree can be given as a name, a path, or a PR number. Use --new to
+start a fresh session instead of resuming the latest one.
+
+Example:
+  zen agent prompt 42 "re-run the tests and summarize failures"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAgentPrompt,
+}
+
+var agentTailCmd = &cobra.Command{
+	Use:   "tail <worktree>",
+	Short: "Follow a worktree's Claude session live",
+	Long: `Follows the most recent Claude session of a worktree and prints new
+activity as it is written: assistant text, tool calls and their results,
+and token usage per message. Press Ctrl-C to stop.
+
+The worktree can be given as a name, a path, or a PR number. With --json,
+each event is printed as one JSON object per line.
+
+Example:
+  zen agent tail 42
+  zen agent tail mono-my-feature -n 50`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAgentTail,
+}
+
+var agentStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Break down Claude token usage by model and by repo",
+	Long: `Scans every Claude session of every worktree active in the period and
+sums token usage per model family (opus, sonnet, haiku) and per repo, with
+each repo's split across models -- to see where expensive models are used.
+
+Sessions that switched models are split by the model of each message.
+
+Example:
+  zen agent stats
+  zen agent stats --days 7`,
+	Args: cobra.NoArgs,
+	RunE: runAgentStats,
+}
+
+var agentReportCmd = &cobra.Command{
+	Use:   "report <worktree>",
+	Short: "Extract the findings of a finished Claude session as a report",
+	Long: `Reads the most recent Claude session of a worktree and extracts what it
+found: issues raised, suggestions, the files it flagged and read, and its
+final reply. Findings are recognized from Markdown lists under headings
+such as "Issues" or "Suggestions", severity markers (**High**, [nit]) and
+typical review wording, so the report is a best effort.
+
+Prints a Markdown report, or JSON with --json. With --output, the report
+is written to a file instead, e.g. to attach to the PR. With --notes, your
+own findings from the worktree's NOTES.zen.md are added under "Reviewer
+Notes".
+
+The worktree can be given as a name, a path, or a PR number.
+
+Example:
+  zen agent report 42
+  zen agent report 42 -o review-42.md
+  zen agent report 42 --notes -o review-42.md
+  zen agent report mono-my-feature --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAgentReport,
+}
+
 func init() {
+	agentStatsCmd.Flags().IntVarP(&agentStatsDays, "days", "d", 30, "Only count sessions active in the last N days")
 	agentStatusCmd.Flags().BoolVar(&agentRunning, "running", false, "Only show running sessions")
 	agentStatusCmd.Flags().BoolVar(&agentFull, "full", false, "Scan full session files for accurate token totals (slower)")
 
+	agentPromptCmd.Flags().StringVarP(&agentPromptModel, "model", "m", "", "Claude model to use (e.g., sonnet, opus, haiku)")
+	agentPromptCmd.Flags().BoolVar(&agentPromptNew, "new", false, "Start a new session instead of resuming the latest one")
+
 	agentCmd.AddCommand(agentStatusCmd)
+	agentTailCmd.Flags().IntVarP(&agentTailLines, "lines", "n", 10, "Number of past events to show before following (-1 for all)")
+	agentTailCmd.Flags().StringVarP(&agentTailSession, "session", "s", "", "Session to follow: position (1-based), name, or ID prefix (default: most recent)")
+
+	agentCmd.AddCommand(agentStatsCmd)
+	agentCmd.AddCommand(agentPromptCmd)
+	agentCmd.AddCommand(agentTailCmd)
+	agentReportCmd.Flags().StringVarP(&agentReportSession, "session", "s", "", "Session ID to report on (default: most recent)")
+	agentReportCmd.Flags().StringVarP(&agentReportOutput, "output", "o", "", "Write the report to this file")
+	agentReportCmd.Flags().BoolVar(&agentReportNotes, "notes", false, "Include the worktree's NOTES.zen.md")
+	agentCmd.AddCommand(agentReportCmd)
 	rootCmd.AddCommand(agentCmd)
 }
 
@@ -45,6 +154,7 @@ func init() {
 type agentStatusEntry struct {
 	Worktree        string `json:"worktree"`
 	SessionID       string `json:"session_id"`
+	Name            string `json:"name,omitempty"`
 	Status          string `json:"status"`
 	Size            string `json:"size"`
 	Model           string `json:"model"`
@@ -54,10 +164,19 @@ type agentStatusEntry struct {
 	lastActiveEpoch int64  // unexported, for sorting only
 }
 
+// sessionLabel is the session's name, or the start of its ID.
+func (e agentStatusEntry) sessionLabel() string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return session.ShortID(e.SessionID)
+}
+
 func runAgentStatus(cmd *cobra.Command, args []string) error {
 	home := homeDir()
 
 	var entries []agentStatusEntry
+	names := session.Names()
 	var totalRunning, totalWaiting, totalStopped int
 
 	// Try cached snapshot first (unless --full requests accurate totals)
@@ -82,6 +201,7 @@ func runAgentStatus(cmd *cobra.Command, args []string) error {
 			entries = append(entries, agentStatusEntry{
 				Worktree:        ui.ShortenHome(s.WorktreePath, home),
 				SessionID:       s.SessionID,
+				Name:            names[s.SessionID].Name,
 				Status:          s.Status,
 				Size:            s.Size,
 				Model:           s.Model,
@@ -134,6 +254,7 @@ func runAgentStatus(cmd *cobra.Command, args []string) error {
 			entries = append(entries, agentStatusEntry{
 				Worktree:        ui.ShortenHome(wt.Path, home),
 				SessionID:       s.ID,
+				Name:            names[s.ID].Name,
 				Status:          status,
 				Size:            s.SizeStr,
 				Model:           session.ShortenModel(model),
@@ -169,18 +290,21 @@ func runAgentStatus(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Compute max worktree name width for alignment
-	maxWT := len("WORKTREE")
+	maxWT, maxSess := len("WORKTREE"), len("SESSION")
 	for _, e := range entries {
 		name := worktreeDisplayName(e.Worktree)
 		if len(name) > maxWT {
 			maxWT = len(name)
 		}
+		if len(e.sessionLabel()) > maxSess {
+			maxSess = len(e.sessionLabel())
+		}
 	}
 
 	// Use tabwriter only for plain-text columns, then append colored status after
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "%-7s  %-*s  %-7s  %-6s  %-12s  %s\n", "STATUS", maxWT, "WORKTREE", "SIZE", "MODEL", "TOKENS(I/O)", "LAST ACTIVE")
-	fmt.Fprintf(w, "%-7s  %-*s  %-7s  %-6s  %-12s  %s\n", "───────", maxWT, strings.Repeat("─", maxWT), "───────", "──────", "────────────", "───────────")
+	fmt.Fprintf(w, "%-7s  %-*s  %-*s  %-7s  %-6s  %-12s  %s\n", "STATUS", maxWT, "WORKTREE", maxSess, "SESSION", "SIZE", "MODEL", "TOKENS(I/O)", "LAST ACTIVE")
+	fmt.Fprintf(w, "%-7s  %-*s  %-*s  %-7s  %-6s  %-12s  %s\n", "───────", maxWT, strings.Repeat("─", maxWT), maxSess, strings.Repeat("─", maxSess), "───────", "──────", "────────────", "───────────")
 
 	for _, e := range entries {
 		statusStr := fmt.Sprintf("%-7s", e.Status)
@@ -196,8 +320,13 @@ func runAgentStatus(cmd *cobra.Command, args []string) error {
 		tokenStr := fmt.Sprintf("%s/%s", e.InputTokens, e.OutputTokens)
 		name := worktreeDisplayName(e.Worktree)
 
-		fmt.Fprintf(w, "%s  %-*s  %-7s  %-6s  %-12s  %s\n",
-			statusStr, maxWT, name, e.Size, e.Model, tokenStr, ui.DimText(e.LastActive))
+		sess := fmt.Sprintf("%-*s", maxSess, e.sessionLabel())
+		if e.Name == "" {
+			sess = ui.DimText(sess)
+		}
+
+		fmt.Fprintf(w, "%s  %-*s  %s  %-7s  %-6s  %-12s  %s\n",
+			statusStr, maxWT, name, sess, e.Size, e.Model, tokenStr, ui.DimText(e.LastActive))
 	}
 	w.Flush()
 
@@ -224,6 +353,155 @@ func runAgentStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// usageStats is token usage and session count for one model or repo.
+type usageStats struct {
+	Name     string                        `json:"name"`
+	Sessions int                           `json:"sessions"`
+	Tokens   session.TokenUsage            `json:"tokens"`
+	ByModel  map[string]session.TokenUsage `json:"by_model,omitempty"` // repo rows only
+}
+
+// agentStatsResult is the JSON output of zen agent stats.
+type agentStatsResult struct {
+	Days    int          `json:"days"`
+	ByModel []usageStats `json:"by_model"`
+	ByRepo  []usageStats `json:"by_repo"`
+}
+
+func runAgentStats(cmd *cobra.Command, args []string) error {
+	wts, err := worktree.ListAll(cfg)
+	if err != nil {
+		return fmt.Errorf("listing worktrees: %w", err)
+	}
+	since := time.Now().AddDate(0, 0, -agentStatsDays).Unix()
+
+	// One slot per worktree: usage per session, keyed by model family
+	slots := make([][]map[string]session.TokenUsage, len(wts))
+	g := new(errgroup.Group)
+	g.SetLimit(5)
+	for i, wt := range wts {
+		g.Go(func() error {
+			sessions, _ := session.FindSessions(wt.Path)
+			for _, s := range sessions {
+				if s.Modified < since {
+					break // newest first
+				}
+				usage, err := session.UsageByModel(session.SessionFilePath(wt.Path, s.ID))
+				if err == nil && len(usage) > 0 {
+					slots[i] = append(slots[i], usage)
+				}
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	models := make(map[string]*usageStats)
+	repos := make(map[string]*usageStats)
+	for i, wt := range wts {
+		for _, usage := range slots[i] {
+			r, ok := repos[wt.Repo]
+			if !ok {
+				r = &usageStats{Name: wt.Repo, ByModel: make(map[string]session.TokenUsage)}
+				repos[wt.Repo] = r
+			}
+			r.Sessions++
+			for family, u := range usage {
+				m, ok := models[family]
+				if !ok {
+					m = &usageStats{Name: family}
+					models[family] = m
+				}
+				m.Sessions++
+				m.Tokens.Add(u)
+				r.Tokens.Add(u)
+				ru := r.ByModel[family]
+				ru.Add(u)
+				r.ByModel[family] = ru
+			}
+		}
+	}
+
+	result := agentStatsResult{Days: agentStatsDays, ByModel: sortUsage(models), ByRepo: sortUsage(repos)}
+	if jsonFlag {
+		printJSON(result)
+		return nil
+	}
+
+	if len(result.ByModel) == 0 {
+		fmt.Printf("No Claude sessions in the last %d days.\n", agentStatsDays)
+		return nil
+	}
+
+	fmt.Println()
+	ui.SectionHeader(fmt.Sprintf("Usage by Model (last %d days)", agentStatsDays))
+	fmt.Println()
+	fmt.Printf("  %-10s  %-8s  %-8s  %-8s  %-10s  %s\n", "MODEL", "SESSIONS", "INPUT", "OUTPUT", "CACHE READ", "CACHE WRITE")
+	fmt.Printf("  %-10s  %-8s  %-8s  %-8s  %-10s  %s\n", "──────────", "────────", "────────", "────────", "──────────", "───────────")
+	for _, m := range result.ByModel {
+		fmt.Printf("  %-10s  %-8d  %-8s  %-8s  %-10s  %s\n",
+			ui.Truncate(m.Name, 10),
+			m.Sessions,
+			session.FormatTokenCount(m.Tokens.InputTokens),
+			session.FormatTokenCount(m.Tokens.OutputTokens),
+			session.FormatTokenCount(m.Tokens.CacheReadInputTokens),
+			session.FormatTokenCount(m.Tokens.CacheCreationInputTokens))
+	}
+
+	fmt.Println()
+	ui.SectionHeader("Usage by Repo")
+	fmt.Println()
+	fmt.Printf("  %-20s  %-8s  %-14s  %s\n", "REPO", "SESSIONS", "TOKENS(I/O)", "OUTPUT BY MODEL")
+	fmt.Printf("  %-20s  %-8s  %-14s  %s\n", "────────────────────", "────────", "──────────────", "────────────────────────")
+	for _, r := range result.ByRepo {
+		fmt.Printf("  %-20s  %-8d  %-14s  %s\n",
+			ui.Truncate(r.Name, 20),
+			r.Sessions,
+			session.FormatTokenCount(r.Tokens.InputTokens)+"/"+session.FormatTokenCount(r.Tokens.OutputTokens),
+			modelShares(r))
+	}
+	fmt.Println()
+	ui.Hint("Sessions that used several models count once per model in the model table")
+	fmt.Println()
+	return nil
+}
+
+// sortUsage returns the stats ordered by output tokens, largest first.
+func sortUsage(m map[string]*usageStats) []usageStats {
+	out := make([]usageStats, 0, len(m))
+	for _, s := range m {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Tokens.OutputTokens != out[j].Tokens.OutputTokens {
+			return out[i].Tokens.OutputTokens > out[j].Tokens.OutputTokens
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// modelShares formats a repo's output tokens split by model, largest
+// first, e.g. "opus 80% · sonnet 20%".
+func modelShares(r usageStats) string {
+	if r.Tokens.OutputTokens == 0 {
+		return ""
+	}
+	families := make([]string, 0, len(r.ByModel))
+	for f := range r.ByModel {
+		families = append(families, f)
+	}
+	sort.Slice(families, func(i, j int) bool {
+		return r.ByModel[families[i]].OutputTokens > r.ByModel[families[j]].OutputTokens
+	})
+	parts := make([]string, 0, len(families))
+	for _, f := range families {
+		pct := r.ByModel[f].OutputTokens * 100 / r.Tokens.OutputTokens
+		parts = append(parts, fmt.Sprintf("%s %d%%", f, pct))
+	}
+	return strings.Join(parts, " · ")
+}
+
 // worktreeDisplayName extracts the last path component (worktree dir name) for display.
 func worktreeDisplayName(path string) string {
 	if parts := strings.Split(path, "/"); len(parts) > 0 {
@@ -231,3 +509,232 @@ func worktreeDisplayName(path string) string {
 	}
 	return path
 }
+
+func runAgentPrompt(cmd *cobra.Command, args []string) error {
+	w, err := resolveWorktree(args[0])
+	if err != nil {
+		return err
+	}
+	message := args[1]
+
+	claudeArgs := []string{"-p", message}
+	if agentPromptModel != "" {
+		claudeArgs = append(claudeArgs, "--model", agentPromptModel)
+	}
+	if jsonFlag {
+		claudeArgs = append(claudeArgs, "--output-format", "json")
+	}
+
+	if !agentPromptNew {
+		sessions, _ := session.FindSessions(w.Path)
+		if len(sessions) > 0 {
+			s := sessions[0]
+			if session.IsProcessRunning(s.ID) {
+				ui.LogWarn(fmt.Sprintf("Session %s is running in another terminal; the reply will not appear there", s.ID))
+			}
+			claudeArgs = append(claudeArgs, "--resume", s.ID)
+			ui.LogInfo(fmt.Sprintf("Prompting session %s in %s", s.ID, w.Name))
+		} else {
+			ui.LogInfo(fmt.Sprintf("No session yet in %s, starting a new one", w.Name))
+		}
+	} else {
+		ui.LogInfo(fmt.Sprintf("Starting a new session in %s", w.Name))
+	}
+
+	c := exec.CommandContext(context.Background(), cfg.ClaudeBin, claudeArgs...)
+	c.Dir = w.Path
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("%s -p: %w", cfg.ClaudeBin, err)
+	}
+	return nil
+}
+
+func runAgentTail(cmd *cobra.Command, args []string) error {
+	w, err := resolveWorktree(args[0])
+	if err != nil {
+		return err
+	}
+
+	sessions, _ := session.FindSessions(w.Path)
+	if len(sessions) == 0 {
+		return fmt.Errorf("no Claude sessions found in %s", w.Name)
+	}
+	session.ApplyNames(sessions)
+	s, err := session.Pick(sessions, agentTailSession)
+	if err != nil {
+		return fmt.Errorf("%w in %s", err, w.Name)
+	}
+	sessionID := s.ID
+	path := session.SessionFilePath(w.Path, sessionID)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if jsonFlag {
+		enc := json.NewEncoder(os.Stdout)
+		return session.FollowEvents(ctx, path, agentTailLines, func(ev session.Event) {
+			enc.Encode(ev)
+		})
+	}
+
+	ui.LogInfo(fmt.Sprintf("Following session %s in %s (Ctrl-C to stop)", sessionID, w.Name))
+	var total session.TokenUsage
+	return session.FollowEvents(ctx, path, agentTailLines, func(ev session.Event) {
+		if ev.Usage != nil {
+			total.InputTokens += ev.Usage.InputTokens
+			total.OutputTokens += ev.Usage.OutputTokens
+		}
+		printAgentEvent(ev, total)
+	})
+}
+
+// agentTailMaxLines caps how many lines of a single text event are shown.
+const agentTailMaxLines = 8
+
+// printAgentEvent renders one session event as a line (or a few) of the
+// live feed. total is the running token count including this event.
+func printAgentEvent(ev session.Event, total session.TokenUsage) {
+	ts := "        "
+	if !ev.Time.IsZero() {
+		ts = ev.Time.Local().Format("15:04:05")
+	}
+	prefix := ui.DimText(ts) + "  "
+
+	switch ev.Kind {
+	case session.EventPrompt:
+		printAgentText(prefix+ui.BoldText("❯ "), ev.Text)
+	case session.EventText:
+		printAgentText(prefix+ui.CyanText("● "), ev.Text)
+	case session.EventToolUse:
+		fmt.Printf("%s%s %s\n", prefix, ui.YellowText("→ "+ev.Tool), ui.Truncate(ev.Text, 100))
+	case session.EventToolResult:
+		lines := 0
+		if ev.Text != "" {
+			lines = strings.Count(ev.Text, "\n") + 1
+		}
+		if ev.IsError {
+			fmt.Printf("%s%s\n", prefix, ui.RedText("✗ "+ui.Truncate(strings.ReplaceAll(ev.Text, "\n", " "), 100)))
+		} else {
+			fmt.Printf("%s%s\n", prefix, ui.DimText(fmt.Sprintf("← %d lines", lines)))
+		}
+	}
+
+	if ev.Usage != nil {
+		fmt.Printf("          %s\n", ui.DimText(fmt.Sprintf("tokens +%s/+%s (total %s/%s)",
+			session.FormatTokenCount(ev.Usage.InputTokens),
+			session.FormatTokenCount(ev.Usage.OutputTokens),
+			session.FormatTokenCount(total.InputTokens),
+			session.FormatTokenCount(total.OutputTokens))))
+	}
+}
+
+// printAgentText prints a multi-line text event, indenting continuation
+// lines under the first and eliding anything past agentTailMaxLines.
+func printAgentText(prefix, text string) {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if i == agentTailMaxLines {
+			fmt.Printf("            %s\n", ui.DimText(fmt.Sprintf("… %d more lines", len(lines)-i)))
+			break
+		}
+		if i == 0 {
+			fmt.Printf("%s%s\n", prefix, line)
+		} else {
+			fmt.Printf("            %s\n", line)
+		}
+	}
+}
+
+func runAgentReport(cmd *cobra.Command, args []string) error {
+	w, err := resolveWorktree(args[0])
+	if err != nil {
+		return err
+	}
+
+	sessionID := agentReportSession
+	if sessionID == "" {
+		sessions, _ := session.FindSessions(w.Path)
+		if len(sessions) == 0 {
+			return fmt.Errorf("no Claude sessions found in %s", w.Name)
+		}
+		sessionID = sessions[0].ID
+	}
+	path := session.SessionFilePath(w.Path, sessionID)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("session %s not found in %s", sessionID, w.Name)
+	}
+
+	report, err := session.BuildReport(path, w.Path)
+	if err != nil {
+		return fmt.Errorf("reading session %s: %w", sessionID, err)
+	}
+	if agentReportNotes {
+		data, err := os.ReadFile(ctxpkg.NotesPath(w.Path))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("reading notes: %w", err)
+		}
+		if report.Notes = ctxpkg.NotesBody(string(data)); report.Notes == "" {
+			ui.LogWarn(fmt.Sprintf("No notes in %s", ctxpkg.NotesFile))
+		}
+	}
+
+	out := report.Markdown(fmt.Sprintf("Claude session report: %s", w.Name))
+	if w.Type == worktree.TypePRReview && w.PRNumber > 0 {
+		out = report.Markdown(fmt.Sprintf("Review of PR #%d", w.PRNumber))
+	}
+	if jsonFlag {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		out = string(data) + "\n"
+	}
+
+	if agentReportOutput == "" {
+		fmt.Print(out)
+		return nil
+	}
+	if err := os.WriteFile(agentReportOutput, []byte(out), 0o644); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	ui.LogSuccess(fmt.Sprintf("Wrote %s: %d issue(s), %d suggestion(s)", agentReportOutput, len(report.Issues), len(report.Suggestions)))
+	return nil
+}
+
+// resolveWorktree finds a worktree by exact name, path, PR number or PR URL.
+func resolveWorktree(target string) (*worktree.Worktree, error) {
+	wts, err := worktree.ListAll(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("listing worktrees: %w", err)
+	}
+
+	absTarget := target
+	if !filepath.IsAbs(absTarget) {
+		if abs, err := filepath.Abs(absTarget); err == nil {
+			absTarget = abs
+		}
+	}
+	prNumber, _ := strconv.Atoi(strings.TrimPrefix(target, "#"))
+	if _, _, ok := ghpkg.ParsePRURL(target); ok {
+		if prNumber, err = parsePRArg(target, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, w := range wts {
+		if w.Name == target || w.Path == absTarget {
+			return &w, nil
+		}
+	}
+	if prNumber > 0 {
+		for _, w := range wts {
+			if w.Type == worktree.TypePRReview && w.PRNumber == prNumber && (prArgRepo == "" || w.Repo == prArgRepo) {
+				return &w, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no worktree found matching %q", target)
+}
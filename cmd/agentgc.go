@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentGCOlder    string
+	agentGCOrphaned bool
+	agentGCForce    bool
+)
+
+var agentGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete old Claude sessions of deleted or inactive worktrees",
+	Long: `Finds Claude sessions (~/.claude/projects/*/*.jsonl) of directories under
+the configured base paths that can go, reports the space they take and
+deletes them after confirmation. Running sessions are never deleted.
+
+  zen agent gc                     Orphaned sessions, plus any inactive for 30 days
+  zen agent gc --orphaned          Sessions whose worktree no longer exists
+  zen agent gc --older-than 14d    Sessions inactive for 14+ days
+  zen agent gc --orphaned --older-than 7d
+                                   Orphaned sessions inactive for 7+ days
+
+Set watch.session_gc_after_days to have the daemon delete orphaned
+sessions inactive that long once a day.`,
+	Args: cobra.NoArgs,
+	RunE: runAgentGC,
+}
+
+func init() {
+	agentGCCmd.Flags().StringVar(&agentGCOlder, "older-than", "", "Select sessions inactive for this long (e.g., 30d, 2w)")
+	agentGCCmd.Flags().BoolVar(&agentGCOrphaned, "orphaned", false, "Select sessions whose worktree no longer exists")
+	agentGCCmd.Flags().BoolVarP(&agentGCForce, "force", "f", false, "Skip confirmation")
+	agentCmd.AddCommand(agentGCCmd)
+}
+
+// agentGCProject groups the selected sessions of one directory.
+type agentGCProject struct {
+	Dir        string                  `json:"dir"`
+	Orphaned   bool                    `json:"orphaned"`
+	Size       int64                   `json:"size"`
+	LastActive time.Time               `json:"last_active"`
+	Sessions   []session.StoredSession `json:"sessions"`
+}
+
+func runAgentGC(cmd *cobra.Command, args []string) error {
+	filter := session.GCFilter{Orphaned: agentGCOrphaned}
+	older := agentGCOlder
+	if older == "" && !agentGCOrphaned {
+		filter.Orphaned, older = true, "30d"
+	}
+	if older != "" {
+		cutoff, err := parsePeriod(older)
+		if err != nil {
+			return err
+		}
+		filter.Before = cutoff
+	}
+	filter.Both = agentGCOrphaned && agentGCOlder != ""
+
+	all, err := session.StoredSessions(cfg.AllBasePaths())
+	if err != nil {
+		return fmt.Errorf("listing Claude sessions: %w", err)
+	}
+	var projects []*agentGCProject
+	byProject := make(map[string]*agentGCProject)
+	var total int64
+	var count int
+	for _, s := range all {
+		if !filter.Match(s) || session.IsProcessRunning(s.ID) {
+			continue
+		}
+		p := byProject[s.Project]
+		if p == nil {
+			dir := s.Dir
+			if dir == "" {
+				dir = filepath.Base(s.Project)
+			}
+			p = &agentGCProject{Dir: dir, Orphaned: s.Orphaned}
+			byProject[s.Project] = p
+			projects = append(projects, p)
+		}
+		p.Sessions = append(p.Sessions, s)
+		p.Size += s.Size
+		if s.Modified.After(p.LastActive) {
+			p.LastActive = s.Modified
+		}
+		total += s.Size
+		count++
+	}
+
+	if jsonFlag && !agentGCForce {
+		if projects == nil {
+			projects = []*agentGCProject{}
+		}
+		printJSON(projects)
+		return nil
+	}
+
+	if count == 0 {
+		fmt.Println("No Claude sessions to delete.")
+		return nil
+	}
+
+	home := homeDir()
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("%d Claude session(s) to delete, %s reclaimable", count, session.FormatSize(total))))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	t := ui.NewTable([]ui.Column{
+		{Key: "dir", Header: "Directory", Flex: true, Min: 20},
+		{Key: "sessions", Header: "Sessions"},
+		{Key: "size", Header: "Size"},
+		{Key: "last", Header: "Last Active"},
+		{Key: "reason", Header: "Reason"},
+	}, nil)
+	for _, p := range projects {
+		reason := "inactive"
+		if p.Orphaned {
+			reason = "worktree deleted"
+		}
+		t.Row(ui.ShortenHome(p.Dir, home),
+			fmt.Sprintf("%d", len(p.Sessions)),
+			session.FormatSize(p.Size),
+			session.FormatAge(p.LastActive),
+			ui.DimText(reason))
+	}
+	t.Print()
+	fmt.Println()
+
+	if !agentGCForce {
+		fmt.Printf("  Delete %d session(s)? [y/N]: ", count)
+		var resp string
+		fmt.Scanln(&resp)
+		if resp != "y" && resp != "Y" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		fmt.Println()
+	}
+
+	var deleted int
+	var freed int64
+	for _, p := range projects {
+		for _, s := range p.Sessions {
+			if err := s.Delete(); err != nil {
+				ui.LogWarn(fmt.Sprintf("Deleting %s: %v", ui.ShortenHome(s.Path, home), err))
+				continue
+			}
+			deleted++
+			freed += s.Size
+		}
+	}
+	ui.LogSuccess(fmt.Sprintf("Deleted %d session(s), freed %s", deleted, session.FormatSize(freed)))
+	return nil
+}
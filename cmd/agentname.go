@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var agentNameClear bool
+
+var agentNameCmd = &cobra.Command{
+	Use:   "name <worktree> <session> [name]",
+	Short: "Give a Claude session a friendly name",
+	Long: `Names a Claude session of a worktree, so it can be told apart from the
+others in zen review/work resume --list and zen agent status, and resumed
+by name with --session.
+
+The worktree can be given as a name, a path, or a PR number. The session
+can be given as its position in resume --list (1 = most recent), its
+current name, or a unique prefix of its ID. Names are unique within a
+worktree and can't be plain numbers. Use --clear to remove a name.
+
+Example:
+  zen agent name 42 1 "refactor attempt 2"
+  zen review resume 42 --session "refactor attempt 2"
+  zen agent name 42 "refactor attempt 2" --clear`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if agentNameClear {
+			return cobra.ExactArgs(2)(cmd, args)
+		}
+		return cobra.ExactArgs(3)(cmd, args)
+	},
+	RunE: runAgentName,
+}
+
+func init() {
+	agentNameCmd.Flags().BoolVar(&agentNameClear, "clear", false, "Remove the session's name")
+	agentCmd.AddCommand(agentNameCmd)
+}
+
+// agentNameResult is the JSON output of zen agent name.
+type agentNameResult struct {
+	Worktree  string `json:"worktree"`
+	SessionID string `json:"session_id"`
+	Name      string `json:"name"`
+}
+
+func runAgentName(cmd *cobra.Command, args []string) error {
+	w, err := resolveWorktree(args[0])
+	if err != nil {
+		return err
+	}
+	sessions, _ := session.FindSessions(w.Path)
+	if len(sessions) == 0 {
+		return fmt.Errorf("no Claude sessions found in %s", w.Name)
+	}
+	session.ApplyNames(sessions)
+	s, err := session.Pick(sessions, args[1])
+	if err != nil {
+		return err
+	}
+
+	if agentNameClear {
+		had, err := session.ClearName(s.ID)
+		if err != nil {
+			return err
+		}
+		if jsonFlag {
+			printJSON(agentNameResult{Worktree: w.Path, SessionID: s.ID})
+		} else if had {
+			ui.LogSuccess(fmt.Sprintf("Removed the name of session %s (%q)", session.ShortID(s.ID), s.Name))
+		} else {
+			ui.LogInfo(fmt.Sprintf("Session %s has no name", session.ShortID(s.ID)))
+		}
+		return nil
+	}
+
+	name := strings.TrimSpace(args[2])
+	if err := session.SetName(w.Path, s.ID, name); err != nil {
+		return err
+	}
+	if jsonFlag {
+		printJSON(agentNameResult{Worktree: w.Path, SessionID: s.ID, Name: name})
+		return nil
+	}
+	ui.LogSuccess(fmt.Sprintf("Named session %s of %s %q", session.ShortID(s.ID), w.Name, name))
+	return nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mgreau/zen/internal/audit"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the worktrees and branches zen created or deleted, and who asked",
+	Long: `Lists the worktrees zen created and removed and the branches it deleted,
+with what triggered each: a CLI command, the watch daemon (e.g. background
+cleanup of merged PRs), an agent through the MCP server, or the local API.
+The log is append-only and kept in ~/.zen/state/audit.jsonl.
+
+Example:
+  zen audit
+  zen audit --since 30d
+  zen audit --since 2w --json`,
+	Args: cobra.NoArgs,
+	RunE: runAudit,
+}
+
+var auditSince string
+
+func init() {
+	auditCmd.Flags().StringVar(&auditSince, "since", "7d", "Show entries from this period (e.g., 1d, 7d, 2w, 3m)")
+	rootCmd.AddCommand(auditCmd)
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	since, err := parsePeriod(auditSince)
+	if err != nil {
+		return err
+	}
+	events, err := audit.Read(since)
+	if err != nil {
+		return fmt.Errorf("reading audit log: %w", err)
+	}
+
+	if jsonFlag {
+		if events == nil {
+			events = []audit.Event{}
+		}
+		printJSON(events)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("Audit Log (last %s)", auditSince)))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	if len(events) == 0 {
+		fmt.Println("  Nothing recorded.")
+		fmt.Println()
+		return nil
+	}
+
+	home := homeDir()
+	tbl := ui.NewTable([]ui.Column{
+		{Key: "time", Header: "Time"},
+		{Key: "action", Header: "Action"},
+		{Key: "by", Header: "By"},
+		{Key: "target", Header: "Target"},
+		{Key: "detail", Header: "Detail"},
+	}, nil)
+	for _, ev := range events {
+		action := strings.ReplaceAll(ev.Action, "_", " ")
+		if ev.Action == audit.WorktreeCreated {
+			action = ui.GreenText(action)
+		} else {
+			action = ui.YellowText(action)
+		}
+		by := ev.Source
+		if ev.Source == audit.SourceCLI && ev.Command != "" {
+			by = "zen " + ev.Command
+		}
+		target := ui.ShortenHome(ev.Target, home)
+		if ev.Action == audit.BranchDeleted && ev.Repo != "" {
+			target = ev.Repo + ": " + ev.Target
+		}
+		tbl.Row(ev.Time.Local().Format("2006-01-02 15:04"), action, by, target, ui.DimText(ev.Detail))
+	}
+	tbl.Print()
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/prcache"
+)
+
+// loadTeamAuthors resolves authors_from_team into cfg.TeamAuthors. Members
+// are cached for a day, so this only calls GitHub once a day. When GitHub
+// can't be reached, the last known members are used and the error is
+// returned for the caller to report.
+func loadTeamAuthors(ctx context.Context) error {
+	team := cfg.AuthorsTeam
+	if team == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	members, err := prcache.TeamMembers(ctx, team, func(ctx context.Context, team string) ([]string, error) {
+		client, err := ghpkg.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return client.TeamMembers(ctx, team)
+	})
+	cfg.TeamAuthors = members
+	if err != nil {
+		return fmt.Errorf("resolving authors_from_team %s: %w", team, err)
+	}
+	return nil
+}
+
+// authorsLabel describes an authors list for hints. The configured list,
+// with authors_from_team resolved, names the team instead of listing its
+// members.
+func authorsLabel(authors []string) string {
+	if cfg.AuthorsTeam == "" || !slices.Equal(authors, cfg.AllAuthors()) {
+		return strings.Join(authors, " ")
+	}
+	team := fmt.Sprintf("members of %s (%d)", cfg.AuthorsTeam, len(cfg.TeamAuthors))
+	if len(cfg.Authors) == 0 {
+		return team
+	}
+	return strings.Join(cfg.Authors, " ") + " + " + team
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/review"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchTrials int
+	benchPR     int
+	benchSparse bool
+	benchFull   bool
+	benchPool   bool
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench [repo...]",
+	Short: "Measure how long worktree setup takes per repo",
+	Long: `Sets up scratch worktrees of each repo the way PR review worktrees are set
+up, several times, and reports the median and 95th percentile of each
+phase: git fetch, adding and checking out the worktree, and (with --pr)
+injecting PR context. The worktrees are created under
+<base_path>/.zen-bench and removed after each trial.
+
+Without --pr, trials check out origin/main. Run again with --sparse, --pool
+or --full to see what sparse checkout, the worktree pool or the repo's
+fetch_depth and fetch_filter change.
+
+  zen bench                         All configured repos, 5 trials each
+  zen bench mono -n 10              10 trials of mono
+  zen bench mono --pr 42 --sparse   Sparse checkout of the dirs PR #42 touches
+  zen bench mono --pr 42 --pool     Checkout of PR #42 in a pooled worktree
+  zen bench @images --full          Fetch with complete history`,
+	RunE: runBench,
+}
+
+func init() {
+	benchCmd.Flags().IntVarP(&benchTrials, "trials", "n", 5, "Number of setups per repo")
+	benchCmd.Flags().IntVar(&benchPR, "pr", 0, "Check out this PR and inject its context (one repo only)")
+	benchCmd.Flags().BoolVar(&benchSparse, "sparse", false, "Sparse-checkout the PR's dirs plus the repo's sparse_include")
+	benchCmd.Flags().BoolVar(&benchFull, "full", false, "Ignore fetch_depth and fetch_filter and fetch full history")
+	benchCmd.Flags().BoolVar(&benchPool, "pool", false, "Time the checkout in a pooled worktree instead of git worktree add")
+	rootCmd.AddCommand(benchCmd)
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	if benchTrials < 1 {
+		return fmt.Errorf("--trials must be at least 1")
+	}
+	if benchSparse && benchPool {
+		return fmt.Errorf("--sparse and --pool can't be combined: sparse setups don't use the pool")
+	}
+	var repos []string
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	for _, arg := range args {
+		resolved, err := cfg.ResolveRepos(arg)
+		if err != nil {
+			return err
+		}
+		repos = append(repos, resolved...)
+	}
+	for _, repo := range repos {
+		if cfg.RepoBasePath(repo) == "" {
+			return fmt.Errorf("unknown repo %q -- check %s", repo, config.Path())
+		}
+	}
+	if benchPR > 0 && len(repos) != 1 {
+		return fmt.Errorf("--pr needs a single repo")
+	}
+
+	opts := review.BenchOptions{PR: benchPR, Trials: benchTrials, Sparse: benchSparse, Full: benchFull, Pool: benchPool}
+	results := []*review.BenchResult{}
+	for _, repo := range repos {
+		steps := ui.NewSteps()
+		if jsonFlag {
+			steps = ui.NewStepLogger(func(string) {})
+		}
+		res, err := review.Bench(cmd.Context(), cfg, repo, opts, steps)
+		if err != nil {
+			return fmt.Errorf("benchmarking %s: %w", repo, err)
+		}
+		results = append(results, res)
+	}
+
+	if jsonFlag {
+		printJSON(results)
+		return nil
+	}
+
+	for _, res := range results {
+		fmt.Println()
+		ui.SectionHeader(fmt.Sprintf("%s: %s", res.Repo, benchSetup(res)))
+		fmt.Println()
+		t := ui.NewTable([]ui.Column{
+			{Key: "phase", Header: "Phase", Flex: true, Min: 20},
+			{Key: "p50", Header: "Median"},
+			{Key: "p95", Header: "P95"},
+			{Key: "min", Header: "Min"},
+			{Key: "max", Header: "Max"},
+		}, nil)
+		for _, p := range res.Phases {
+			name := p.Name
+			if name == "total" {
+				name = ui.BoldText("Total")
+			}
+			t.Row(name,
+				formatMetricDuration(p.P50),
+				formatMetricDuration(p.P95),
+				formatMetricDuration(p.Min),
+				formatMetricDuration(p.Max))
+		}
+		t.Print()
+	}
+	fmt.Println()
+	return nil
+}
+
+// benchSetup describes what a bench result measured, e.g. "PR #42,
+// sparse, fetch depth 1, 5 trials".
+func benchSetup(res *review.BenchResult) string {
+	s := "origin/main"
+	if res.PR > 0 {
+		s = fmt.Sprintf("PR #%d", res.PR)
+	}
+	switch {
+	case res.Sparse:
+		s += ", sparse"
+	case res.Pool:
+		s += ", pooled worktree"
+	}
+	if res.FetchDepth > 0 {
+		s += fmt.Sprintf(", fetch depth %d", res.FetchDepth)
+	}
+	if res.FetchFilter != "" {
+		s += ", filter " + res.FetchFilter
+	}
+	return s + fmt.Sprintf(", %d trial(s)", res.Trials)
+}
@@ -3,13 +3,17 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
+	ctxpkg "github.com/mgreau/zen/internal/context"
 	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/prcache"
+	"github.com/mgreau/zen/internal/reconciler"
 	"github.com/mgreau/zen/internal/ui"
 	"github.com/mgreau/zen/internal/worktree"
 	"github.com/spf13/cobra"
@@ -21,14 +25,31 @@ var cleanupCmd = &cobra.Command{
 	RunE:  runCleanup,
 }
 
+var cleanupLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show what the watch daemon's background cleanup deleted or skipped",
+	Long: `Lists the worktrees the watch daemon removed for merged PRs, the ones it
+skipped (and why), and removals that failed. The daemon also sends a weekly
+notification summarizing this log.
+
+Example:
+  zen cleanup log
+  zen cleanup log --days 30`,
+	Args: cobra.NoArgs,
+	RunE: runCleanupLog,
+}
+
 var (
-	cleanupDays   int
-	cleanupDelete bool
+	cleanupDays    int
+	cleanupDelete  bool
+	cleanupLogDays int
 )
 
 func init() {
 	cleanupCmd.Flags().IntVarP(&cleanupDays, "days", "d", 30, "Consider worktrees older than N days as stale")
 	cleanupCmd.Flags().BoolVar(&cleanupDelete, "delete", false, "Delete stale worktrees (with confirmation)")
+	cleanupLogCmd.Flags().IntVarP(&cleanupLogDays, "days", "d", 7, "Show entries from the last N days")
+	cleanupCmd.AddCommand(cleanupLogCmd)
 	rootCmd.AddCommand(cleanupCmd)
 }
 
@@ -52,29 +73,39 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 	}
 
 	ghClient, clientErr := ghpkg.NewClient(ctx)
+	// PR review worktrees are looked up with one GraphQL request per repo
+	statuses, _ := reconciler.FetchPRStatuses(ctx, cfg, wts)
 
 	var staleList []staleWorktree
+	var held int
 	for _, wt := range wts {
 		isStale := false
 		reason := ""
 
-		if wt.Type == worktree.TypePRReview && wt.PRNumber > 0 && clientErr == nil {
-			fullRepo := cfg.RepoFullName(wt.Repo)
-			state, err := ghClient.GetPRState(ctx, fullRepo, wt.PRNumber)
-			if err == nil {
-				if state == "MERGED" {
-					isStale = true
-					reason = "PR merged"
-				} else if state == "CLOSED" {
-					isStale = true
-					reason = "PR closed (not merged)"
-				}
+		if wt.Type == worktree.TypePRReview && wt.PRNumber > 0 {
+			// A hold label keeps the worktree whatever its state and age
+			meta, _ := prcache.Get(wt.Repo, wt.PRNumber)
+			labels := meta.Labels
+			status, found := statuses[reconciler.MakePRKey(wt.Repo, wt.PRNumber)]
+			if found {
+				labels = status.Labels
+			}
+			if cfg.Labels.IsHold(labels.Names()) {
+				held++
+				continue
+			}
+			if status.State == "MERGED" {
+				isStale = true
+				reason = "PR merged"
+			} else if status.State == "CLOSED" {
+				isStale = true
+				reason = "PR closed (not merged)"
 			}
 		}
 
 		if !isStale && wt.Type == worktree.TypeFeature && wt.Branch != "" && clientErr == nil {
 			fullRepo := cfg.RepoFullName(wt.Repo)
-			state, prNum, err := ghClient.GetPRStateByBranch(ctx, fullRepo, wt.Branch)
+			state, prNum, err := ghClient.GetPRStateByBranch(ctx, fullRepo, headOwner(wt.Repo, wt.Path, wt.Branch), wt.Branch)
 			if err == nil {
 				if state == "MERGED" {
 					isStale = true
@@ -104,6 +135,10 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if held > 0 {
+		fmt.Printf("%s\n\n", ui.DimText(fmt.Sprintf("Kept %d PR worktree(s) whose PR has a hold label.", held)))
+	}
+
 	if len(staleList) == 0 {
 		fmt.Println("No stale worktrees found.")
 		fmt.Println()
@@ -196,13 +231,79 @@ func deleteWorktree(s staleWorktree) bool {
 		return false
 	}
 
-	removeCmd := exec.Command("git", "worktree", "remove", s.Path, "--force")
-	removeCmd.Dir = originPath
-	if err := removeCmd.Run(); err != nil {
+	if _, err := ctxpkg.ArchiveNotes(s.Path); err != nil {
+		fmt.Printf("    %s\n", ui.YellowText(fmt.Sprintf("Could not archive %s: %v", ctxpkg.NotesFile, err)))
+	}
+	if _, err := worktree.Remove(originPath, s.Path, "cleanup: "+s.Reason); err != nil {
 		fmt.Printf("    %s\n", ui.RedText("✗ Failed to remove"))
 		return false
 	}
+	ctxpkg.ForgetContext(s.Path)
 
 	fmt.Printf("    %s\n", ui.GreenText("✓ Removed worktree"))
+	switch deleted, err := removeBranch(originPath, s.Worktree); {
+	case errors.Is(err, worktree.ErrBranchUnmerged):
+		fmt.Printf("    %s\n", ui.YellowText(fmt.Sprintf("Kept branch %s: not merged", s.Branch)))
+	case err != nil:
+		fmt.Printf("    %s\n", ui.YellowText(fmt.Sprintf("Could not delete branch %s: %v", s.Branch, err)))
+	case deleted != "":
+		fmt.Printf("    %s\n", ui.GreenText("✓ Deleted branch "+deleted))
+	}
 	return true
 }
+
+func runCleanupLog(cmd *cobra.Command, args []string) error {
+	events, err := reconciler.ReadCleanupLog(time.Now().AddDate(0, 0, -cleanupLogDays))
+	if err != nil {
+		return fmt.Errorf("reading cleanup log: %w", err)
+	}
+
+	if jsonFlag {
+		if events == nil {
+			events = []reconciler.CleanupEvent{}
+		}
+		printJSON(events)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("Background Cleanup (last %d days)", cleanupLogDays)))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	if len(events) == 0 {
+		fmt.Println("  Nothing recorded.")
+		fmt.Println()
+		return nil
+	}
+
+	home := homeDir()
+	fmt.Printf("  %-16s  %-8s  %-32s  %s\n", "Time", "Action", "Worktree", "Reason")
+	fmt.Printf("  %-16s  %-8s  %-32s  %s\n", "────────────────", "────────", "────────────────────────────────", "────────────────────────")
+	for _, ev := range events {
+		action := fmt.Sprintf("%-8s", ev.Action)
+		switch ev.Action {
+		case reconciler.CleanupDeleted:
+			action = ui.GreenText(action)
+		case reconciler.CleanupFailed:
+			action = ui.RedText(action)
+		default:
+			action = ui.DimText(action)
+		}
+		fmt.Printf("  %-16s  %s  %-32s  %s\n",
+			ev.Time.Local().Format("2006-01-02 15:04"),
+			action,
+			ui.Truncate(ui.ShortenHome(ev.Path, home), 32),
+			ev.Reason)
+	}
+
+	deleted, skipped, failed := reconciler.CountCleanup(events)
+	fmt.Println()
+	ui.Separator()
+	fmt.Printf("Deleted: %s  Skipped: %s  Failed: %s\n",
+		ui.GreenText(fmt.Sprintf("%d", deleted)),
+		ui.DimText(fmt.Sprintf("%d", skipped)),
+		ui.RedText(fmt.Sprintf("%d", failed)))
+	fmt.Println()
+	return nil
+}
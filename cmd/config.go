@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Config file helpers",
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade the config file to the current schema",
+	Long: `Upgrades a config file written for an older zen to the current schema,
+recorded in its config_version field, in place. Comments are kept, but
+the file is reformatted: blank lines are dropped and indentation becomes
+two spaces. The original is saved next to it as config.yaml.v<N>.bak.
+
+zen offers to run this when it loads an older config. The file is left
+alone if the upgraded config would not load.
+
+Example:
+  zen config migrate
+  zen config migrate --dry-run    # Only list the changes`,
+	Args: cobra.NoArgs,
+	RunE: runConfigMigrate,
+}
+
+var configMigrateDryRun bool
+
+func init() {
+	configMigrateCmd.Flags().BoolVar(&configMigrateDryRun, "dry-run", false, "List the changes without writing them")
+	configCmd.AddCommand(configMigrateCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigMigrate(cmd *cobra.Command, args []string) error {
+	m, err := config.MigrateFile(config.Path(), configMigrateDryRun)
+	if err != nil {
+		return err
+	}
+	if jsonFlag {
+		if m.Changes == nil {
+			m.Changes = []string{}
+		}
+		printJSON(m)
+		return nil
+	}
+	printMigration(m, configMigrateDryRun)
+	return nil
+}
+
+func printMigration(m *config.Migration, dryRun bool) {
+	home := homeDir()
+	path := ui.ShortenHome(config.Path(), home)
+	if m.From == m.To {
+		ui.LogInfo(fmt.Sprintf("%s is up to date (config_version %d)", path, m.To))
+		return
+	}
+	for _, c := range m.Changes {
+		fmt.Println("  " + c)
+	}
+	fmt.Printf("  config_version: %d -> %d\n", m.From, m.To)
+	if dryRun {
+		ui.LogInfo(fmt.Sprintf("Would upgrade %s from config_version %d to %d (dry run)", path, m.From, m.To))
+		return
+	}
+	ui.LogSuccess(fmt.Sprintf("Upgraded %s from config_version %d to %d", path, m.From, m.To))
+	ui.Hint("Backup: " + ui.ShortenHome(m.Backup, home))
+}
+
+// offerConfigMigration asks whether to upgrade a config from an older zen,
+// when run interactively, and migrates it on yes. Otherwise, or on no, it
+// prints how to. It reports whether the config was migrated.
+func offerConfigMigration(command string) bool {
+	path := ui.ShortenHome(config.Path(), homeDir())
+	if jsonFlag || quietFlag || untimedCommands[command] || !isInteractive() {
+		ui.LogWarn(fmt.Sprintf("%s is from an older zen: run 'zen config migrate' to upgrade it", path))
+		return false
+	}
+	if m, err := config.MigrateFile(config.Path(), true); err == nil {
+		fmt.Fprintf(os.Stderr, "%s is from an older zen. Upgrading it would change:\n", path)
+		for _, c := range m.Changes {
+			fmt.Fprintln(os.Stderr, "  "+c)
+		}
+	}
+	fmt.Fprint(os.Stderr, "Upgrade it now? Comments are kept, formatting is normalized and a backup is saved. [Y/n]: ")
+	var resp string
+	fmt.Scanln(&resp)
+	if resp = strings.ToLower(strings.TrimSpace(resp)); resp == "n" || resp == "no" {
+		ui.Hint("Run 'zen config migrate' when ready")
+		return false
+	}
+	m, err := config.MigrateFile(config.Path(), false)
+	if err != nil {
+		ui.LogError(err.Error())
+		return false
+	}
+	printMigration(m, false)
+	return true
+}
+
+// isInteractive reports whether stdin and stderr are terminals, so zen
+// can ask questions.
+func isInteractive() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stderr} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
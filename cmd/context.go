@@ -4,13 +4,16 @@ import (
 	"fmt"
 
 	ctxpkg "github.com/mgreau/zen/internal/context"
+	ghpkg "github.com/mgreau/zen/internal/github"
 	"github.com/mgreau/zen/internal/ui"
+	"github.com/mgreau/zen/internal/worktree"
 	"github.com/spf13/cobra"
 )
 
 var (
-	contextPR   int
-	contextRepo string
+	contextPR           int
+	contextRepo         string
+	contextRefreshForce bool
 )
 
 var contextCmd = &cobra.Command{
@@ -27,13 +30,31 @@ in the specified worktree directory so Claude has immediate context.`,
 	RunE: runContextInject,
 }
 
+var contextRefreshCmd = &cobra.Command{
+	Use:   "refresh <worktree>",
+	Short: "Regenerate CLAUDE.local.md if the PR has new commits",
+	Long: `Checks whether the PR behind a review worktree received new commits since
+its context was injected. If so, rewrites CLAUDE.local.md with the current
+PR metadata and appends an update listing the files the new commits changed,
+so a long-running review session knows the code moved underneath it.
+
+The watch daemon does this automatically on every poll.
+
+The worktree can be given as a name, a path, or a PR number.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runContextRefresh,
+}
+
 func init() {
 	contextInjectCmd.Flags().IntVar(&contextPR, "pr", 0, "PR number (required)")
 	contextInjectCmd.Flags().StringVar(&contextRepo, "repo", "", "Repository short name (required)")
 	contextInjectCmd.MarkFlagRequired("pr")
 	contextInjectCmd.MarkFlagRequired("repo")
 
+	contextRefreshCmd.Flags().BoolVar(&contextRefreshForce, "force", false, "Rewrite CLAUDE.local.md even without new commits")
+
 	contextCmd.AddCommand(contextInjectCmd)
+	contextCmd.AddCommand(contextRefreshCmd)
 	rootCmd.AddCommand(contextCmd)
 }
 
@@ -50,3 +71,51 @@ func runContextInject(cmd *cobra.Command, args []string) error {
 	ui.LogSuccess(fmt.Sprintf("Wrote CLAUDE.local.md to %s", worktreePath))
 	return nil
 }
+
+func runContextRefresh(cmd *cobra.Command, args []string) error {
+	w, err := resolveWorktree(args[0])
+	if err != nil {
+		return err
+	}
+	if w.Type != worktree.TypePRReview {
+		return fmt.Errorf("%s is not a PR review worktree", w.Name)
+	}
+	fullRepo := cfg.RepoFullName(w.Repo)
+
+	client, err := ghpkg.NewClient(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("creating GitHub client: %w", err)
+	}
+	update, err := ctxpkg.RefreshPRContext(cmd.Context(), client, w.Path, fullRepo, w.PRNumber, contextRefreshForce)
+	if err != nil {
+		return fmt.Errorf("refreshing context: %w", err)
+	}
+
+	if update == nil {
+		if contextRefreshForce {
+			ui.LogSuccess(fmt.Sprintf("Rewrote CLAUDE.local.md for PR #%d", w.PRNumber))
+		} else {
+			ui.LogInfo(fmt.Sprintf("PR #%d has no new commits — context is up to date", w.PRNumber))
+		}
+		return nil
+	}
+
+	ui.LogSuccess(fmt.Sprintf("PR #%d moved %s → %s, CLAUDE.local.md updated", w.PRNumber, shortSHA(update.From), shortSHA(update.To)))
+	for _, f := range update.Files {
+		fmt.Printf("  %s %s\n", ui.DimText("~"), f)
+	}
+	for _, f := range update.Added {
+		fmt.Printf("  %s %s\n", ui.GreenText("+"), f)
+	}
+	for _, f := range update.Removed {
+		fmt.Printf("  %s %s\n", ui.RedText("-"), f)
+	}
+	return nil
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mgreau/zen/internal/config"
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/prcache"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var reviewDepsCmd = &cobra.Command{
+	Use:   "deps <pr-number>",
+	Short: "Show open PRs that touch the same files as a PR",
+	Long: `Lists other open PRs whose changed files overlap with the given PR.
+
+Overlapping PRs are likely to conflict at merge time. Use this to decide
+the order in which to review and land related changes.
+
+Open PRs whose files could not be fetched are counted and reported, since
+they may overlap too. With --json the output is an object with the
+overlapping "prs" and the "unscanned" PR numbers.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReviewDeps,
+}
+
+var (
+	reviewDepsRepo  string
+	reviewDepsLimit int
+)
+
+func init() {
+	reviewDepsCmd.Flags().StringVar(&reviewDepsRepo, "repo", "", "Repository short name or @group (auto-detected if omitted)")
+	reviewDepsCmd.Flags().IntVar(&reviewDepsLimit, "limit", 100, "Max open PRs to scan")
+	reviewCmd.AddCommand(reviewDepsCmd)
+}
+
+// DepPR holds an open PR that shares changed files with the target PR.
+type DepPR struct {
+	Number      int      `json:"number"`
+	Title       string   `json:"title"`
+	Author      string   `json:"author"`
+	URL         string   `json:"url,omitempty"`
+	SharedFiles []string `json:"shared_files"`
+	SharedCount int      `json:"shared_count"`
+	HasWorktree bool     `json:"has_worktree"`
+}
+
+// reviewDepsResult is the JSON output of zen review deps.
+type reviewDepsResult struct {
+	Repo string  `json:"repo"`
+	PR   int     `json:"pr"`
+	PRs  []DepPR `json:"prs"`
+	// Unscanned are open PRs whose files could not be fetched, so
+	// whether they overlap is unknown.
+	Unscanned []int `json:"unscanned"`
+}
+
+func runReviewDeps(cmd *cobra.Command, args []string) error {
+	prNumber, err := parsePRArg(args[0], &reviewDepsRepo)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	repo := reviewDepsRepo
+	if repo == "" || config.IsGroupRef(repo) {
+		detected, err := detectRepoForPR(ctx, prNumber, repo)
+		if err != nil {
+			return err
+		}
+		repo = detected
+	}
+	fullRepo := cfg.RepoFullName(repo)
+
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("creating GitHub client: %w", err)
+	}
+
+	targetFiles, err := client.GetPRFiles(ctx, fullRepo, prNumber)
+	if err != nil {
+		return fmt.Errorf("fetching files for PR #%d: %w", prNumber, err)
+	}
+
+	openPRs, err := ghpkg.ListOpenPRs(ctx, fullRepo, reviewDepsLimit)
+	if err != nil {
+		return fmt.Errorf("listing open PRs: %w", err)
+	}
+
+	deps, unscanned := findOverlappingPRs(ctx, client, fullRepo, prNumber, targetFiles, openPRs)
+
+	localPRs := getLocalPRNumbers(repo)
+	for i := range deps {
+		deps[i].HasWorktree = localPRs[deps[i].Number]
+	}
+
+	if jsonFlag {
+		if deps == nil {
+			deps = []DepPR{}
+		}
+		if unscanned == nil {
+			unscanned = []int{}
+		}
+		printJSON(reviewDepsResult{Repo: fullRepo, PR: prNumber, PRs: deps, Unscanned: unscanned})
+		return nil
+	}
+	if len(unscanned) > 0 {
+		ui.LogWarn(fmt.Sprintf("Could not fetch the files of %d of %d open PR(s), which may overlap too: %s",
+			len(unscanned), len(openPRs), prNumberList(unscanned)))
+	}
+
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("%d Open PRs overlapping with #%d — %s", len(deps), prNumber, ui.YellowText(repo))))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	if len(deps) == 0 {
+		if len(unscanned) > 0 {
+			fmt.Printf("None of the scanned open PRs touch the %d file(s) changed by #%d, but %d could not be scanned.\n", len(targetFiles), prNumber, len(unscanned))
+		} else {
+			fmt.Printf("No open PRs touch the %d file(s) changed by #%d.\n", len(targetFiles), prNumber)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("  %-2s  %-6s  %-20s  %-42s  %s\n", "W", "PR", "Author", "Title", "Shared")
+	fmt.Printf("  %-2s  %-6s  %-20s  %-42s  %s\n", "──", "──────", "────────────────────", "──────────────────────────────────────────", "──────────")
+
+	for _, d := range deps {
+		wCol := "  "
+		if d.HasWorktree {
+			wCol = ui.GreenText("* ")
+		}
+		fmt.Printf("  %s  %s  %-20s  %-42s  %s\n",
+			wCol,
+			ui.CyanText(fmt.Sprintf("#%-5d", d.Number)),
+			d.Author,
+			ui.Truncate(d.Title, 40),
+			ui.YellowText(fmt.Sprintf("%d file(s)", d.SharedCount)))
+		for i, f := range d.SharedFiles {
+			if i >= 3 {
+				fmt.Printf("            %s\n", ui.DimText(fmt.Sprintf("... and %d more", len(d.SharedFiles)-3)))
+				break
+			}
+			fmt.Printf("            %s\n", ui.DimText(f))
+		}
+	}
+	fmt.Println()
+	ui.Hint("PRs sharing the most files are listed first and are the most likely to conflict.")
+	fmt.Println()
+	return nil
+}
+
+// findOverlappingPRs fetches the changed files of each open PR concurrently
+// and returns those sharing at least one file with targetFiles, sorted by
+// the number of shared files (most first), and the PRs whose files could
+// not be fetched.
+func findOverlappingPRs(ctx context.Context, client *ghpkg.Client, fullRepo string, prNumber int, targetFiles []string, openPRs []ghpkg.ReviewRequest) ([]DepPR, []int) {
+	target := make(map[string]bool, len(targetFiles))
+	for _, f := range targetFiles {
+		target[f] = true
+	}
+
+	var candidates []ghpkg.ReviewRequest
+	for _, pr := range openPRs {
+		if pr.Number != prNumber {
+			candidates = append(candidates, pr)
+		}
+	}
+
+	if !jsonFlag {
+		fmt.Fprintf(os.Stderr, "  %s", ui.DimText(fmt.Sprintf("Scanning %d open PRs...", len(candidates))))
+	}
+
+	slots := make([]*DepPR, len(candidates))
+	failed := make([]bool, len(candidates))
+	fileCache := prcache.LoadFiles()
+	defer fileCache.Save()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(5)
+	for i, pr := range candidates {
+		g.Go(func() error {
+			files, err := cachedPRFiles(gctx, client, fileCache, fullRepo, pr)
+			if err != nil {
+				failed[i] = true
+				return nil
+			}
+			var shared []string
+			for _, f := range files {
+				if target[f] {
+					shared = append(shared, f)
+				}
+			}
+			if len(shared) > 0 {
+				slots[i] = &DepPR{
+					Number:      pr.Number,
+					Title:       pr.Title,
+					Author:      pr.Author.Login,
+					URL:         pr.URL,
+					SharedFiles: shared,
+					SharedCount: len(shared),
+				}
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if !jsonFlag {
+		fmt.Fprintf(os.Stderr, "\r%-60s\r", "")
+	}
+
+	var deps []DepPR
+	var unscanned []int
+	for i, s := range slots {
+		if s != nil {
+			deps = append(deps, *s)
+		}
+		if failed[i] {
+			unscanned = append(unscanned, candidates[i].Number)
+		}
+	}
+	sort.SliceStable(deps, func(i, j int) bool {
+		return deps[i].SharedCount > deps[j].SharedCount
+	})
+	return deps, unscanned
+}
+
+// prNumberList formats PR numbers as "#1, #2, #3", eliding past 10.
+func prNumberList(numbers []int) string {
+	var parts []string
+	for i, n := range numbers {
+		if i == 10 {
+			parts = append(parts, fmt.Sprintf("and %d more", len(numbers)-10))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("#%d", n))
+	}
+	return strings.Join(parts, ", ")
+}
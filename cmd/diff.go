@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var reviewDiffCmd = &cobra.Command{
+	Use:   "diff <pr-number>",
+	Short: "Show what changed in a PR since your last Claude session on it",
+	Long: `Compares the PR's current head on GitHub with the commit the review
+worktree was on when its most recent Claude session was last active, and
+prints only the changes in between: new commits, changed files and the diff.
+
+With --inject, the commits and files are also written to the worktree's
+CLAUDE.local.md under "What Changed Since Your Last Review", so a resumed
+session can focus the re-review on them.
+
+Example:
+  zen review diff 42
+  zen review diff 42 --stat --inject`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReviewDiff,
+}
+
+var (
+	reviewDiffRepo   string
+	reviewDiffStat   bool
+	reviewDiffInject bool
+)
+
+func init() {
+	reviewDiffCmd.Flags().StringVar(&reviewDiffRepo, "repo", "", "Repository short name or @group (auto-detected if omitted)")
+	reviewDiffCmd.Flags().BoolVar(&reviewDiffStat, "stat", false, "List commits and files only, without the full diff")
+	reviewDiffCmd.Flags().BoolVar(&reviewDiffInject, "inject", false, "Add the changes as a note in CLAUDE.local.md")
+	reviewCmd.AddCommand(reviewDiffCmd)
+}
+
+// ReviewDiff describes a PR's changes since the last Claude session.
+type ReviewDiff struct {
+	PRNumber      int       `json:"pr_number"`
+	WorktreePath  string    `json:"worktree_path"`
+	SessionID     string    `json:"session_id"`
+	SessionActive time.Time `json:"session_active_at"`
+	Base          string    `json:"base"`
+	Head          string    `json:"head"`
+	Commits       []string  `json:"commits"`
+	Files         []string  `json:"files"`
+	Injected      bool      `json:"injected"`
+}
+
+const reviewDiffNoteHeading = "What Changed Since Your Last Review"
+
+func runReviewDiff(cmd *cobra.Command, args []string) error {
+	prNumber, err := parsePRArg(args[0], &reviewDiffRepo)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	repo := reviewDiffRepo
+	if repo == "" || config.IsGroupRef(repo) {
+		detected, err := detectRepoForPR(ctx, prNumber, repo)
+		if err != nil {
+			return err
+		}
+		repo = detected
+	}
+
+	worktreePath := filepath.Join(cfg.RepoBasePath(repo), fmt.Sprintf("%s-pr-%d", repo, prNumber))
+	if _, err := os.Stat(worktreePath); err != nil {
+		return fmt.Errorf("no worktree for PR #%d -- create one with: zen review %d", prNumber, prNumber)
+	}
+
+	sessions, _ := session.FindSessions(worktreePath)
+	if len(sessions) == 0 {
+		return fmt.Errorf("no Claude session found for PR #%d -- nothing to compare against", prNumber)
+	}
+	last := sessions[0]
+	lastActive := time.Unix(last.Modified, 0)
+
+	base, err := wt.HeadAt(worktreePath, lastActive)
+	if err != nil {
+		return fmt.Errorf("finding the commit of your last session: %w", err)
+	}
+
+	steps := ui.NewSteps()
+	if jsonFlag {
+		steps = ui.NewStepLogger(func(string) {})
+	}
+	steps.Step("git fetch")
+	head, err := wt.FetchPRHead(worktreePath, cfg.RepoUpstream(repo), prNumber)
+	steps.Done(err)
+	if err != nil {
+		return err
+	}
+
+	res := ReviewDiff{
+		PRNumber:      prNumber,
+		WorktreePath:  worktreePath,
+		SessionID:     last.ID,
+		SessionActive: lastActive,
+		Base:          base,
+		Head:          head,
+		Commits:       []string{},
+		Files:         []string{},
+	}
+	if base != head {
+		if res.Commits, err = wt.CommitsBetween(worktreePath, base, head); err != nil {
+			return err
+		}
+		if res.Files, err = wt.FilesBetween(worktreePath, base, head); err != nil {
+			return err
+		}
+	}
+
+	if reviewDiffInject && base != head {
+		if err := ctxpkg.SetNote(worktreePath, reviewDiffNoteHeading, reviewDiffNote(res)); err != nil {
+			return fmt.Errorf("writing note: %w", err)
+		}
+		res.Injected = true
+	}
+
+	if jsonFlag {
+		printJSON(res)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("PR #%d — changes since your last session (%s)", prNumber, session.FormatAge(lastActive))))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	if base == head {
+		ui.LogSuccess(fmt.Sprintf("No new commits — the PR is still at %s", shortSHA(head)))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("  %s → %s\n\n", ui.DimText(shortSHA(base)), ui.CyanText(shortSHA(head)))
+	fmt.Println(ui.BoldText(fmt.Sprintf("  %d new commit(s)", len(res.Commits))))
+	for _, c := range res.Commits {
+		fmt.Printf("    %s\n", c)
+	}
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("  %d file(s) changed", len(res.Files))))
+	for _, f := range res.Files {
+		status, path, _ := strings.Cut(f, "\t")
+		fmt.Printf("    %s %s\n", ui.YellowText(fmt.Sprintf("%-4s", status)), path)
+	}
+	fmt.Println()
+
+	if !reviewDiffStat {
+		diffCmd := exec.Command("git", "diff", base, head)
+		diffCmd.Dir = worktreePath
+		diffCmd.Stdout = os.Stdout
+		diffCmd.Stderr = os.Stderr
+		if err := diffCmd.Run(); err != nil {
+			return fmt.Errorf("git diff: %w", err)
+		}
+		fmt.Println()
+	}
+
+	if res.Injected {
+		ui.LogSuccess("Added the changes to CLAUDE.local.md")
+	} else {
+		ui.Hint(fmt.Sprintf("Add them to the session context with: zen review diff %d --inject", prNumber))
+	}
+	fmt.Println()
+	return nil
+}
+
+// reviewDiffNote renders the CLAUDE.local.md note for a ReviewDiff.
+func reviewDiffNote(d ReviewDiff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Your last review session ended at `%s` (%s). The PR is now at `%s`.\n",
+		shortSHA(d.Base), d.SessionActive.Format("2006-01-02 15:04"), shortSHA(d.Head))
+	fmt.Fprintf(&b, "Focus this re-review on the changes below; run `git diff %s %s` to see them.\n", shortSHA(d.Base), shortSHA(d.Head))
+	if len(d.Commits) > 0 {
+		b.WriteString("\nNew commits:\n")
+		for _, c := range d.Commits {
+			fmt.Fprintf(&b, "- %s\n", c)
+		}
+	}
+	if len(d.Files) > 0 {
+		b.WriteString("\nChanged files:\n")
+		for _, f := range d.Files {
+			status, path, _ := strings.Cut(f, "\t")
+			fmt.Fprintf(&b, "- `%s` (%s)\n", path, status)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// ExitResults is the exit status of zen inbox and zen status with
+// --exit-code or --quiet when they found something to act on. Any error
+// exits with 1.
+const ExitResults = 3
+
+var (
+	exitCodeFlag bool
+	quietFlag    bool
+
+	// exitCode is the status zen exits with when the command succeeds.
+	exitCode int
+)
+
+// ExitCode returns the status zen should exit with once Execute succeeded:
+// 0, or ExitResults when --exit-code or --quiet asked for it.
+func ExitCode() int {
+	return exitCode
+}
+
+// addResultFlags adds --exit-code and --quiet to a command, for scripts
+// that branch on whether it found something. what completes "Exit with
+// status 3 when".
+func addResultFlags(cmd *cobra.Command, what string) {
+	cmd.Flags().BoolVar(&exitCodeFlag, "exit-code", false, fmt.Sprintf("Exit with status %d when %s", ExitResults, what))
+	cmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "Print nothing, only set the exit status (implies --exit-code)")
+}
+
+// setResults records whether the command found something to act on.
+func setResults(found bool) {
+	if found && (exitCodeFlag || quietFlag) {
+		exitCode = ExitResults
+	}
+}
+
+// silence discards what the command writes to stdout and stderr under
+// --quiet, until the returned function restores them. Errors returned by
+// the command are still printed.
+func silence() (restore func()) {
+	if !quietFlag {
+		return func() {}
+	}
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return func() {}
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = devNull, devNull
+	return func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		devNull.Close()
+	}
+}
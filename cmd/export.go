@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mgreau/zen/internal/backup"
+	"github.com/mgreau/zen/internal/config"
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOut   string
+	importForce bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Save the zen config and state to an archive",
+	Long: `Saves config.yaml and the state directory (PR cache, watched PRs, session
+names, worktree metadata, journal, ...) to a tar.gz archive, to move zen to
+a new machine with zen import.
+
+Files that only make sense on this machine (the daemon's PID files and log,
+the local API token) are left out. Worktrees and Claude sessions are not
+included, but the NOTES.zen.md of each PR review worktree is: zen archives
+it in the state directory first, and restores it when the PR is checked
+out again.`,
+	Example: `  zen export --out zen-backup.tar.gz`,
+	Args:    cobra.NoArgs,
+	RunE:    runExport,
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <archive>",
+	Short: "Restore the zen config and state from zen export",
+	Long: `Restores an archive written by zen export: config.yaml goes to the config
+path (~/.zen/config.yaml, $ZEN_CONFIG or --config) and the state files to
+the state directory.
+
+Nothing is written when a file to restore already exists, unless --force
+is given. State files that are not in the archive are kept. Stop the watch
+daemon before importing.`,
+	Example: `  zen import zen-backup.tar.gz`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runImport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportOut, "out", "o", "zen-backup.tar.gz", "Archive to write")
+	importCmd.Flags().BoolVarP(&importForce, "force", "f", false, "Overwrite the existing config and state files")
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+}
+
+// backupResult is the JSON output of zen export and zen import.
+type backupResult struct {
+	Archive string   `json:"archive"`
+	Files   []string `json:"files"`
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	archiveReviewNotes()
+
+	f, err := os.Create(exportOut)
+	if err != nil {
+		return err
+	}
+	files, err := backup.Export(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(exportOut)
+		return err
+	}
+
+	if jsonFlag {
+		printJSON(backupResult{Archive: exportOut, Files: files})
+		return nil
+	}
+	ui.LogSuccess(fmt.Sprintf("Exported config and %d state file(s) to %s", len(files)-1, exportOut))
+	ui.Hint(fmt.Sprintf("On the new machine: zen import %s", exportOut))
+	return nil
+}
+
+// archiveReviewNotes archives the notes of every PR review worktree, so
+// the export has their current findings.
+func archiveReviewNotes() {
+	wts, err := wt.ListAll(cfg)
+	if err != nil {
+		ui.LogWarn(fmt.Sprintf("Review notes not archived: listing worktrees: %v", err))
+		return
+	}
+	for _, w := range wts {
+		if w.Type != wt.TypePRReview {
+			continue
+		}
+		if _, err := ctxpkg.ArchiveNotes(w.Path); err != nil {
+			ui.LogWarn(fmt.Sprintf("%s: %v", w.Name, err))
+		}
+	}
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	if running, pid := watchIsRunning(); running {
+		return fmt.Errorf("the watch daemon is running (PID %d) -- stop it first with: zen watch stop", pid)
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	files, err := backup.Import(f, importForce)
+	if err != nil {
+		return err
+	}
+
+	if jsonFlag {
+		printJSON(backupResult{Archive: args[0], Files: files})
+		return nil
+	}
+	home := homeDir()
+	ui.LogSuccess(fmt.Sprintf("Imported %s and %d state file(s)", ui.ShortenHome(config.Path(), home), len(files)-1))
+	ui.Hint("Clone your repos into their configured base paths, then run 'zen status'.")
+	return nil
+}
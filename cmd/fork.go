@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/worktree"
+)
+
+// setupFork prepares branch in the checkout at path for contributor mode:
+// it finds (or creates) the repo's configured fork and makes it the
+// branch's push remote. It returns the fork's owner/name.
+func setupFork(ctx context.Context, client *ghpkg.Client, repo, path, branch string) (string, error) {
+	fullRepo := cfg.RepoFullName(repo)
+	fork, err := client.EnsureFork(ctx, fullRepo, cfg.RepoFork(repo))
+	if err != nil {
+		return "", err
+	}
+	originURL, err := worktree.RemoteURL(path, "origin")
+	if err != nil {
+		return "", fmt.Errorf("reading origin URL: %w", err)
+	}
+	if err := worktree.SetForkRemote(path, ghpkg.ForkURL(originURL, fork), branch); err != nil {
+		return "", fmt.Errorf("adding remote for fork %s: %w", fork, err)
+	}
+	return fork, nil
+}
+
+// headOwner returns the owner of the repo branch is pushed to when that
+// is a fork of repo: the fork remote in contributor mode, or origin when
+// origin is the user's fork (upstream: set). It is "" when branch is
+// pushed to the repo itself.
+func headOwner(repo, path, branch string) string {
+	remote := worktree.PushRemote(path, branch)
+	if remote == "origin" && cfg.RepoUpstream(repo) == "origin" {
+		return ""
+	}
+	url, err := worktree.RemoteURL(path, remote)
+	if err != nil {
+		return ""
+	}
+	owner := ghpkg.RemoteOwner(url)
+	if repoOwner, _, _ := strings.Cut(cfg.RepoFullName(repo), "/"); strings.EqualFold(owner, repoOwner) {
+		return ""
+	}
+	return owner
+}
@@ -1,8 +1,35 @@
 package cmd
 
-import "os"
+import (
+	"os"
+	"strings"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/spf13/cobra"
+)
 
 // homeDir returns the user's home directory.
 func homeDir() string {
 	return os.Getenv("HOME")
 }
+
+// columnsFlag overrides the configured columns of a command's tables.
+var columnsFlag []string
+
+// addColumnsFlag adds --columns to a command printing the named table
+// (a key of config.TableColumns).
+func addColumnsFlag(cmd *cobra.Command, table string) {
+	cmd.Flags().StringSliceVar(&columnsFlag, "columns", nil, "Columns to show, comma-separated: "+strings.Join(config.TableColumns[table], ", ")+" (default from config)")
+}
+
+// tableColumns returns the columns to show in table: --columns if given,
+// else the table's columns: config. Empty means all.
+func tableColumns(table string) ([]string, error) {
+	if len(columnsFlag) == 0 {
+		return cfg.Columns[table], nil
+	}
+	if err := config.ValidateColumns(table, columnsFlag); err != nil {
+		return nil, err
+	}
+	return columnsFlag, nil
+}
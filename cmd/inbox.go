@@ -4,9 +4,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"sort"
 	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
 
+	"github.com/mgreau/zen/internal/config"
 	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/prcache"
 	"github.com/mgreau/zen/internal/ui"
 	"github.com/mgreau/zen/internal/worktree"
 	"github.com/spf13/cobra"
@@ -25,83 +32,313 @@ var (
 	inboxAll        bool
 	inboxPathFilter string
 	inboxLimit      int
+	inboxCombined   bool
+	inboxByRepo     bool
+	inboxNotifs     bool
+	inboxMarkDone   bool
+	inboxOlderThan  string
+	inboxNewerThan  string
 )
 
+// inboxOpenedBefore and inboxOpenedAfter bound when listed PRs were
+// opened, from --older-than and --newer-than; zero means no bound.
+var inboxOpenedBefore, inboxOpenedAfter time.Time
+
 func init() {
-	inboxCmd.Flags().StringVarP(&inboxRepo, "repo", "r", "", "Repository to check (default: all)")
+	inboxCmd.Flags().StringVarP(&inboxRepo, "repo", "r", "", "Repository or @group to check (default: all)")
 	inboxCmd.Flags().StringVarP(&inboxAuthors, "authors", "a", "", "Override authors list")
 	inboxCmd.Flags().BoolVar(&inboxAll, "all", false, "Show from all authors")
 	inboxCmd.Flags().StringVarP(&inboxPathFilter, "path", "p", "", "List PRs touching files under DIR")
 	inboxCmd.Flags().IntVar(&inboxLimit, "limit", 100, "Max PRs to scan when using --path")
+	inboxCmd.Flags().BoolVar(&inboxCombined, "combined", false, "One table across repos, most urgent first (default with several repos)")
+	inboxCmd.Flags().BoolVar(&inboxByRepo, "by-repo", false, "Separate sections per repo (default with a single repo)")
+	inboxCmd.MarkFlagsMutuallyExclusive("combined", "by-repo")
+	inboxCmd.Flags().BoolVar(&inboxNotifs, "notifications", false, "List review requests, mentions and assignments from GitHub notifications")
+	addColumnsFlag(inboxCmd, "inbox")
+	addResultFlags(inboxCmd, "review requests (or PRs matching --path, or unhandled notifications) are listed")
+	inboxCmd.Flags().StringVar(&inboxOlderThan, "older-than", "", "Only PRs opened longer ago than this (e.g., 2d, 1w)")
+	inboxCmd.Flags().StringVar(&inboxNewerThan, "newer-than", "", "Only PRs opened within this period (e.g., 1d, 1w)")
+	inboxCmd.Flags().BoolVar(&inboxMarkDone, "mark-done", false, "With --notifications, mark those with a worktree or a submitted review as done")
 	rootCmd.AddCommand(inboxCmd)
 }
 
-// InboxPR holds a pending PR for display/JSON output.
+// InboxPR holds a pending PR for display.
 type InboxPR struct {
-	Number       int    `json:"number"`
-	Title        string `json:"title"`
-	Author       string `json:"author"`
-	URL          string `json:"url,omitempty"`
-	MatchedPaths string `json:"matched_paths,omitempty"`
-	MatchedCount int    `json:"matched_count,omitempty"`
+	Number       int
+	Title        string
+	Author       string
+	URL          string
+	CreatedAt    string
+	MatchedPaths []string // watched paths or --path prefix the PR touches
+	MatchedCount int      // files under --path
+	Labels       ghpkg.Labels
+	Linked       []ghpkg.PRRef // PRs paired with it by "Depends on" or "Companion"
+}
+
+// inboxSchemaVersion is bumped on any incompatible change to InboxJSON.
+const inboxSchemaVersion = 1
+
+// Inbox sections, as reported in InboxItem.Section.
+const (
+	sectionReviewRequests = "review_requests"
+	sectionTeamRequests   = "team_requests"
+	sectionApproved       = "approved_unmerged"
+	sectionPath           = "path"
+	sectionWatchedPaths   = "watched_paths"
+	sectionOtherRequests  = "other_review_requests"
+	sectionIssues         = "issues"
+	sectionDiscussions    = "discussions"
+)
+
+// Review states, as reported in InboxItem.ReviewState.
+const (
+	reviewStateRequested     = "review_requested"
+	reviewStateTeamRequested = "team_review_requested"
+	reviewStateApproved      = "approved"
+	reviewStateNone          = "none" // not requested from you
+)
+
+// InboxJSON is the document printed by zen inbox --json.
+type InboxJSON struct {
+	SchemaVersion int         `json:"schema_version"`
+	Items         []InboxItem `json:"items"`
+}
+
+// InboxItem is one PR in one inbox section. Every section emits the same
+// fields; a PR listed in two sections appears twice. Items in the issues
+// and discussions sections carry the issue or discussion number in PR.
+type InboxItem struct {
+	Section      string       `json:"section"`
+	Repo         string       `json:"repo"` // owner/name
+	PR           int          `json:"pr"`
+	Title        string       `json:"title"`
+	Author       string       `json:"author"`
+	URL          string       `json:"url"`
+	CreatedAt    string       `json:"created_at,omitempty"`
+	MatchedPaths []string     `json:"matched_paths"`
+	MatchedCount int          `json:"matched_count,omitempty"`
+	Team         string       `json:"team,omitempty"`
+	Labels       ghpkg.Labels `json:"labels,omitempty"`
+	HasWorktree  bool         `json:"has_worktree"`
+	ReviewState  string       `json:"review_state"`
+	Kind         string       `json:"kind,omitempty"`   // "issue" or "discussion"; empty for PRs
+	Reason       string       `json:"reason,omitempty"` // why an issue or discussion is listed
+
+	// Linked are the PRs its description pairs it with on "Depends on" or
+	// "Companion" lines, often in other repos
+	Linked []ghpkg.PRRef `json:"linked,omitempty"`
+}
+
+// inboxItems collects every section's items for --json output and the
+// combined table.
+var inboxItems []InboxItem
+
+// inboxCombinedView is set when the sections of all repos are rendered as
+// one table instead of per repo.
+var inboxCombinedView bool
+
+// inboxColumns are the columns shown in inbox tables, from --columns or
+// columns.inbox in the config; empty shows all.
+var inboxColumns []string
+
+// Inbox table columns. Each section's table shows those it has.
+var (
+	inboxColWorktree = ui.Column{Key: "worktree", Header: "W"}
+	inboxColRepo     = ui.Column{Key: "repo", Header: "Repo", Max: 20}
+	inboxColPR       = ui.Column{Key: "pr", Header: "PR"}
+	inboxColAuthor   = ui.Column{Key: "author", Header: "Author", Max: 20}
+	inboxColTitle    = ui.Column{Key: "title", Header: "Title", Flex: true, Min: 20}
+	inboxColLabels   = ui.Column{Key: "labels", Header: "Labels", Max: 30, Optional: true}
+	inboxColLink     = ui.Column{Key: "link", Header: "Link"}
+	inboxColAge      = ui.Column{Key: "age", Header: "Age"}
+)
+
+// inboxTable returns a table of the picked inbox columns among cols.
+func inboxTable(cols ...ui.Column) *ui.Table {
+	return ui.NewTable(cols, inboxColumns)
+}
+
+// worktreeMark is the W column cell: a star when the PR has a worktree.
+func worktreeMark(has bool) string {
+	if has {
+		return ui.GreenText("*")
+	}
+	return ""
+}
+
+// prCell formats a PR or issue number for a table.
+func prCell(n int) string {
+	return ui.CyanText(fmt.Sprintf("#%d", n))
+}
+
+// inboxRequests counts the review requests listed, and the PRs listed
+// with --path, for --exit-code.
+var inboxRequests int
+
+// inboxNotes are hints printed above the combined table, such as fetches
+// capped by search_limit.
+var inboxNotes []string
+
+// inboxCollecting reports whether display functions should collect items
+// into inboxItems instead of printing their section.
+func inboxCollecting() bool {
+	return jsonFlag || inboxCombinedView
+}
+
+// addInboxItem records pr under section for --json output.
+func addInboxItem(section, repo string, pr InboxPR, team string, localPRs map[int]bool, state string) {
+	paths := pr.MatchedPaths
+	if paths == nil {
+		paths = []string{}
+	}
+	inboxItems = append(inboxItems, InboxItem{
+		Section:      section,
+		Repo:         cfg.RepoFullName(repo),
+		PR:           pr.Number,
+		Title:        pr.Title,
+		Author:       pr.Author,
+		URL:          pr.URL,
+		CreatedAt:    pr.CreatedAt,
+		MatchedPaths: paths,
+		MatchedCount: pr.MatchedCount,
+		Team:         team,
+		Labels:       pr.Labels,
+		HasWorktree:  localPRs[pr.Number],
+		ReviewState:  state,
+		Linked:       pr.Linked,
+	})
+}
+
+// inboxPRFromRequest converts a GitHub search result for display.
+func inboxPRFromRequest(pr ghpkg.ReviewRequest) InboxPR {
+	return InboxPR{
+		Number:    pr.Number,
+		Title:     pr.Title,
+		Author:    pr.Author.Login,
+		URL:       pr.URL,
+		CreatedAt: pr.CreatedAt,
+		Labels:    pr.Labels,
+		Linked:    ghpkg.LinkedPRs(pr.Body, pr.Repository.NameWithOwner),
+	}
 }
 
 func runInbox(_ *cobra.Command, _ []string) error {
-	repos := []string{inboxRepo}
-	if inboxRepo == "" {
-		repos = cfg.RepoNames()
+	defer silence()()
+
+	repos, err := cfg.ResolveRepos(inboxRepo)
+	if err != nil {
+		return err
 	}
 
+	ctx := context.Background()
 	authors := cfg.Authors
-	if inboxAuthors != "" {
-		authors = strings.Fields(inboxAuthors)
-	}
-	if inboxAll {
+	switch {
+	case inboxAll:
 		authors = nil
+	case inboxAuthors != "":
+		authors = strings.Fields(inboxAuthors)
+	default:
+		if err := loadTeamAuthors(ctx); err != nil {
+			ui.LogWarn(err.Error())
+		}
+		authors = cfg.AllAuthors()
 	}
 
 	// Cache current user once for all repos.
-	ctx := context.Background()
 	currentUser, _ := ghpkg.GetCurrentUser(ctx)
 
+	if inboxMarkDone && !inboxNotifs {
+		return fmt.Errorf("--mark-done requires --notifications")
+	}
+	if inboxColumns, err = tableColumns("inbox"); err != nil {
+		return err
+	}
+	if inboxOlderThan != "" {
+		if inboxOpenedBefore, err = parsePeriod(inboxOlderThan); err != nil {
+			return fmt.Errorf("--older-than: %w", err)
+		}
+	}
+	if inboxNewerThan != "" {
+		if inboxOpenedAfter, err = parsePeriod(inboxNewerThan); err != nil {
+			return fmt.Errorf("--newer-than: %w", err)
+		}
+	}
+	if inboxAgeFiltered() && inboxNotifs {
+		return fmt.Errorf("--older-than and --newer-than can't be used with --notifications")
+	}
+	if inboxNotifs {
+		return runInboxNotifications(repos)
+	}
+
+	inboxCombinedView = inboxCombined || (!inboxByRepo && len(repos) > 1)
+
 	if !jsonFlag {
 		printWorktreeLegend()
 	}
 
+	// A --path scan can take a while: Ctrl-C stops it and shows the PRs
+	// matched so far
+	if inboxPathFilter != "" {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+	}
+
 	hasResults := false
-	for _, repo := range repos {
-		found, err := runInboxForRepo(repo, authors, currentUser)
+	for i, repo := range repos {
+		found, err := runInboxForRepo(ctx, repo, authors, currentUser)
 		if err != nil {
 			return err
 		}
 		if found {
 			hasResults = true
 		}
+		if ctx.Err() != nil {
+			if rest := repos[i+1:]; len(rest) > 0 {
+				ui.LogWarn("Not scanned: " + strings.Join(rest, ", "))
+			}
+			break
+		}
+	}
+	setResults(inboxRequests > 0)
+
+	if jsonFlag {
+		items := inboxItems
+		if items == nil {
+			items = []InboxItem{}
+		}
+		printJSON(InboxJSON{SchemaVersion: inboxSchemaVersion, Items: items})
+		return nil
+	}
+
+	if inboxCombinedView {
+		hasResults = len(inboxItems) > 0
+		if hasResults {
+			displayCombined(inboxItems, authors)
+		}
 	}
 
 	if !hasResults {
-		if jsonFlag {
-			fmt.Println("[]")
-		} else {
-			fmt.Println()
-			fmt.Println(ui.BoldText("No PRs found"))
-			if inboxPathFilter != "" {
-				repoLabel := strings.Join(repos, ", ")
-				ui.Hint(fmt.Sprintf("Path: %s in %s", inboxPathFilter, repoLabel))
-			}
-			if !inboxAll && len(authors) > 0 {
-				ui.Hint(fmt.Sprintf("Authors: %s", strings.Join(authors, " ")))
-				ui.Hint("Use --all to check all authors")
-			}
-			fmt.Println()
+		fmt.Println()
+		fmt.Println(ui.BoldText("No PRs found"))
+		if inboxPathFilter != "" {
+			repoLabel := strings.Join(repos, ", ")
+			ui.Hint(fmt.Sprintf("Path: %s in %s", inboxPathFilter, repoLabel))
+		}
+		if !inboxAll && len(authors) > 0 {
+			ui.Hint("Authors: " + authorsLabel(authors))
+			ui.Hint("Use --all to check all authors")
 		}
+		if inboxAgeFiltered() {
+			ui.Hint(fmt.Sprintf("Opened: %s", inboxAgeLabel()))
+		}
+		fmt.Println()
 	}
 
 	return nil
 }
 
-func runInboxForRepo(repo string, authors []string, currentUser string) (bool, error) {
-	ctx := context.Background()
+func runInboxForRepo(ctx context.Context, repo string, authors []string, currentUser string) (bool, error) {
 	fullRepo := cfg.RepoFullName(repo)
 	localPRs := getLocalPRNumbers(repo)
 	hasResults := false
@@ -111,47 +348,106 @@ func runInboxForRepo(repo string, authors []string, currentUser string) (bool, e
 		if err != nil {
 			return false, err
 		}
+		prs = filterByAge(prs, func(pr InboxPR) string { return pr.CreatedAt })
 		pending := filterLocalPRs(prs, localPRs)
 		if len(prs) > 0 {
 			hasResults = true
-			displayPathResults(pending, len(prs), repo)
+			displayPathResults(pending, len(prs), repo, localPRs)
 		}
 	} else {
-		// Fetch review requests and approved PRs concurrently.
-		var reviews []ghpkg.ReviewRequest
+		// Fetch review requests, approved PRs, issues and discussions
+		// concurrently. Review requests are always fetched since the other
+		// review requests section is filtered by them.
+		var reviews, teamReviews []ghpkg.ReviewRequest
 		var approved []ghpkg.ApprovedPR
-		var reviewsErr, approvedErr error
+		var issues, discussions []ghpkg.Thread
+		var reviewsTotal, teamTotal, approvedTotal int
+		var reviewsErr, teamErr, approvedErr, issuesErr, discussionsErr error
+		limit := cfg.GetSearchLimit()
+		since := time.Now().AddDate(0, 0, -cfg.Inbox.GetThreadDays())
 
 		g, gctx := errgroup.WithContext(ctx)
 		g.Go(func() error {
-			reviews, reviewsErr = ghpkg.GetReviewRequests(gctx, fullRepo)
-			return nil
-		})
-		g.Go(func() error {
-			approved, approvedErr = ghpkg.GetApprovedUnmerged(gctx, fullRepo)
+			reviews, reviewsTotal, reviewsErr = ghpkg.GetReviewRequests(gctx, fullRepo, limit)
 			return nil
 		})
+		if cfg.Inbox.Shows(sectionApproved) {
+			g.Go(func() error {
+				approved, approvedTotal, approvedErr = ghpkg.GetApprovedUnmerged(gctx, fullRepo, limit)
+				return nil
+			})
+		}
+		if len(cfg.Teams) > 0 && cfg.Inbox.Shows(sectionTeamRequests) {
+			g.Go(func() error {
+				teamReviews, teamTotal, teamErr = ghpkg.GetTeamReviewRequests(gctx, fullRepo, cfg.Teams, limit)
+				return nil
+			})
+		}
+		if cfg.Inbox.Shows(sectionIssues) {
+			g.Go(func() error {
+				issues, issuesErr = ghpkg.GetThreads(gctx, ghpkg.ThreadIssue, fullRepo, since, limit)
+				return nil
+			})
+		}
+		if cfg.Inbox.Shows(sectionDiscussions) {
+			g.Go(func() error {
+				discussions, discussionsErr = ghpkg.GetThreads(gctx, ghpkg.ThreadDiscussion, fullRepo, since, limit)
+				return nil
+			})
+		}
 		_ = g.Wait()
 
 		if reviewsErr != nil {
 			return false, fmt.Errorf("fetching review requests for %s: %w", repo, reviewsErr)
 		}
 
-		filtered := filterByAuthors(reviews, authors)
+		filtered := filterByAge(filterByAuthors(reviews, authors), reviewCreatedAt)
+		sortOldestFirst(filtered, reviewCreatedAt)
+
+		if len(filtered) > 0 && cfg.Inbox.Shows(sectionReviewRequests) {
+			hasResults = true
+			displayReviewResults(filtered, len(reviews), reviewsTotal, localPRs, repo)
+		}
 
-		if len(filtered) > 0 {
+		if teamErr != nil {
+			ui.LogWarn(fmt.Sprintf("fetching team review requests for %s: %v", repo, teamErr))
+		} else if team := filterByAge(teamOnlyRequests(filterByAuthors(teamReviews, authors), reviews), reviewCreatedAt); len(team) > 0 {
+			sortOldestFirst(team, reviewCreatedAt)
 			hasResults = true
-			displayReviewResults(filtered, localPRs, repo)
+			displayTeamRequests(team, len(teamReviews), teamTotal, localPRs, repo)
 		}
 
+		approved = filterByAge(approved, func(pr ghpkg.ApprovedPR) string { return pr.CreatedAt })
 		if approvedErr == nil && len(approved) > 0 {
 			hasResults = true
-			displayApprovedUnmerged(approved)
+			displayApprovedUnmerged(approved, approvedTotal, localPRs, repo)
+		}
+
+		// Issues and discussions have no creation time to filter on
+		if inboxAgeFiltered() {
+			issues, discussions = nil, nil
+		}
+
+		if issuesErr != nil {
+			ui.LogWarn(fmt.Sprintf("fetching issues for %s: %v", repo, issuesErr))
+		} else if len(issues) > 0 {
+			hasResults = true
+			displayThreads(sectionIssues, issues, repo)
 		}
 
-		if len(cfg.WatchPaths) > 0 {
+		if discussionsErr != nil {
+			ui.LogWarn(fmt.Sprintf("fetching discussions for %s: %v", repo, discussionsErr))
+		} else if len(discussions) > 0 {
+			hasResults = true
+			displayThreads(sectionDiscussions, discussions, repo)
+		}
+
+		if len(cfg.WatchPaths) > 0 && cfg.Inbox.Shows(sectionWatchedPaths) {
 			watched, others, err := fetchOpenPRs(ctx, fullRepo, currentUser)
 			if err == nil {
+				inboxCreatedAt := func(pr InboxPR) string { return pr.CreatedAt }
+				watched = filterByAge(watched, inboxCreatedAt)
+				others = filterByAge(others, inboxCreatedAt)
 				if len(watched) > 0 {
 					hasResults = true
 					displayWatchedPRs(watched, localPRs, repo)
@@ -206,6 +502,82 @@ func filterByAuthors(prs []ghpkg.ReviewRequest, authors []string) []ghpkg.Review
 	return filtered
 }
 
+// inboxAgeFiltered reports whether --older-than or --newer-than is set.
+func inboxAgeFiltered() bool {
+	return !inboxOpenedBefore.IsZero() || !inboxOpenedAfter.IsZero()
+}
+
+// inboxAgeLabel describes the --older-than and --newer-than bounds.
+func inboxAgeLabel() string {
+	var parts []string
+	if inboxOlderThan != "" {
+		parts = append(parts, "more than "+inboxOlderThan+" ago")
+	}
+	if inboxNewerThan != "" {
+		parts = append(parts, "less than "+inboxNewerThan+" ago")
+	}
+	return strings.Join(parts, " and ")
+}
+
+// filterByAge keeps the items opened within --older-than and --newer-than.
+// Items without a creation time are dropped while filtering.
+func filterByAge[T any](items []T, createdAt func(T) string) []T {
+	if !inboxAgeFiltered() {
+		return items
+	}
+	var kept []T
+	for _, it := range items {
+		ts, err := time.Parse(time.RFC3339, createdAt(it))
+		if err != nil {
+			continue
+		}
+		if !inboxOpenedBefore.IsZero() && ts.After(inboxOpenedBefore) {
+			continue
+		}
+		if !inboxOpenedAfter.IsZero() && ts.Before(inboxOpenedAfter) {
+			continue
+		}
+		kept = append(kept, it)
+	}
+	return kept
+}
+
+// sortOldestFirst orders items by creation time, oldest first, so the
+// requests waiting longest come first. RFC3339 timestamps sort
+// chronologically as strings.
+func sortOldestFirst[T any](items []T, createdAt func(T) string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return createdAt(items[i]) < createdAt(items[j])
+	})
+}
+
+func reviewCreatedAt(pr ghpkg.ReviewRequest) string { return pr.CreatedAt }
+
+// ageCell formats how long ago a PR was opened for the Age column.
+func ageCell(createdAt string) string {
+	ts, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return ""
+	}
+	return ui.FormatDuration(int(time.Since(ts).Seconds()))
+}
+
+// teamOnlyRequests drops team requests for PRs already listed as personal
+// review requests, so each PR shows up in one inbox section only.
+func teamOnlyRequests(team, personal []ghpkg.ReviewRequest) []ghpkg.ReviewRequest {
+	mine := make(map[int]bool, len(personal))
+	for _, pr := range personal {
+		mine[pr.Number] = true
+	}
+	var out []ghpkg.ReviewRequest
+	for _, pr := range team {
+		if !mine[pr.Number] {
+			out = append(out, pr)
+		}
+	}
+	return out
+}
+
 func filterLocalPRs(prs []InboxPR, local map[int]bool) []InboxPR {
 	var pending []InboxPR
 	for _, pr := range prs {
@@ -216,11 +588,18 @@ func filterLocalPRs(prs []InboxPR, local map[int]bool) []InboxPR {
 	return pending
 }
 
+// fetchPRsByPath returns the open PRs of fullRepo changing files under
+// pathPrefix. When ctx is canceled, e.g. by Ctrl-C, the scan stops and the
+// PRs matched so far are returned.
 func fetchPRsByPath(ctx context.Context, fullRepo, pathPrefix string, authors []string) ([]InboxPR, error) {
 	pathPrefix = strings.TrimSuffix(pathPrefix, "/")
 
 	prs, err := ghpkg.ListOpenPRs(ctx, fullRepo, inboxLimit)
 	if err != nil {
+		if ctx.Err() == context.Canceled {
+			ui.LogWarn(fmt.Sprintf("Interrupted before listing the PRs of %s", fullRepo))
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -232,9 +611,12 @@ func fetchPRsByPath(ctx context.Context, fullRepo, pathPrefix string, authors []
 	if err != nil {
 		return nil, err
 	}
+	fileCache := prcache.LoadFiles()
+	defer fileCache.Save()
 
+	var progress *ui.Counter
 	if !jsonFlag {
-		fmt.Fprintf(os.Stderr, "  Scanning %d PRs in %s for %s/...", len(prs), fullRepo, pathPrefix)
+		progress = ui.NewCounter(fmt.Sprintf("Scanning %d PRs in %s for %s/...", len(prs), fullRepo, pathPrefix), len(prs))
 	}
 
 	type prResult struct {
@@ -243,14 +625,27 @@ func fetchPRsByPath(ctx context.Context, fullRepo, pathPrefix string, authors []
 	}
 	slots := make([]prResult, len(prs))
 
+	// The file lists are fetched 5 at a time; canceling gctx stops the
+	// requests in flight and the ones not started yet
+	var scanned atomic.Int32
 	g, gctx := errgroup.WithContext(ctx)
 	g.SetLimit(5)
 	for i, pr := range prs {
+		if gctx.Err() != nil {
+			break
+		}
 		g.Go(func() error {
-			files, err := ghClient.GetPRFiles(gctx, fullRepo, pr.Number)
+			if gctx.Err() != nil {
+				return nil
+			}
+			files, err := cachedPRFiles(gctx, ghClient, fileCache, fullRepo, pr)
 			if err != nil {
 				return nil
 			}
+			scanned.Add(1)
+			if progress != nil {
+				progress.Add(1)
+			}
 			count := 0
 			for _, f := range files {
 				if strings.HasPrefix(f, pathPrefix+"/") {
@@ -258,24 +653,21 @@ func fetchPRsByPath(ctx context.Context, fullRepo, pathPrefix string, authors []
 				}
 			}
 			if count > 0 {
-				slots[i] = prResult{
-					entry: InboxPR{
-						Number:       pr.Number,
-						Title:        pr.Title,
-						Author:       pr.Author.Login,
-						URL:          pr.URL,
-						MatchedCount: count,
-					},
-					matched: true,
-				}
+				entry := inboxPRFromRequest(pr)
+				entry.MatchedPaths = []string{pathPrefix}
+				entry.MatchedCount = count
+				slots[i] = prResult{entry: entry, matched: true}
 			}
 			return nil
 		})
 	}
 	_ = g.Wait()
 
-	if !jsonFlag {
-		fmt.Fprintf(os.Stderr, "\r%-60s\r", "")
+	if progress != nil {
+		progress.Clear()
+	}
+	if ctx.Err() != nil {
+		ui.LogWarn(fmt.Sprintf("Interrupted: scanned %d/%d PRs in %s, results are partial", scanned.Load(), len(prs), fullRepo))
 	}
 
 	var results []InboxPR
@@ -287,6 +679,21 @@ func fetchPRsByPath(ctx context.Context, fullRepo, pathPrefix string, authors []
 	return results, nil
 }
 
+// cachedPRFiles returns the changed files of pr, from cache when its head
+// commit is the one they were fetched at, so repeated scans only hit the
+// API for PRs that got new commits.
+func cachedPRFiles(ctx context.Context, client *ghpkg.Client, cache *prcache.FileCache, fullRepo string, pr ghpkg.ReviewRequest) ([]string, error) {
+	if files, ok := cache.Get(fullRepo, pr.Number, pr.HeadSHA); ok {
+		return files, nil
+	}
+	files, err := client.GetPRFiles(ctx, fullRepo, pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	cache.Put(fullRepo, pr.Number, pr.HeadSHA, files)
+	return files, nil
+}
+
 // fetchOpenPRs splits recent open PRs into two groups: those touching watched
 // paths and all others. The current user's PRs are excluded from both.
 func fetchOpenPRs(ctx context.Context, fullRepo string, currentUser string) ([]InboxPR, []InboxPR, error) {
@@ -299,6 +706,8 @@ func fetchOpenPRs(ctx context.Context, fullRepo string, currentUser string) ([]I
 	if err != nil {
 		return nil, nil, err
 	}
+	fileCache := prcache.LoadFiles()
+	defer fileCache.Save()
 
 	// Filter out current user's PRs before scanning.
 	var candidates []ghpkg.ReviewRequest
@@ -324,7 +733,7 @@ func fetchOpenPRs(ctx context.Context, fullRepo string, currentUser string) ([]I
 	g.SetLimit(5)
 	for i, pr := range candidates {
 		g.Go(func() error {
-			files, err := ghClient.GetPRFiles(gctx, fullRepo, pr.Number)
+			files, err := cachedPRFiles(gctx, ghClient, fileCache, fullRepo, pr)
 			if err != nil {
 				return nil
 			}
@@ -332,25 +741,21 @@ func fetchOpenPRs(ctx context.Context, fullRepo string, currentUser string) ([]I
 			seen := make(map[string]bool)
 			for _, f := range files {
 				for _, wp := range cfg.WatchPaths {
-					if (strings.HasPrefix(f, wp+"/") || strings.HasPrefix(f, wp)) && !seen[wp] {
+					if ghpkg.MatchesWatchPath(f, wp) && !seen[wp] {
 						seen[wp] = true
 					}
 				}
 			}
 
-			entry := InboxPR{
-				Number: pr.Number,
-				Title:  pr.Title,
-				Author: pr.Author.Login,
-				URL:    pr.URL,
-			}
+			entry := inboxPRFromRequest(pr)
 
 			if len(seen) > 0 {
 				var paths []string
 				for p := range seen {
 					paths = append(paths, p)
 				}
-				entry.MatchedPaths = strings.Join(paths, ", ")
+				sort.Strings(paths)
+				entry.MatchedPaths = paths
 				slots[i] = prResult{entry: entry, watched: true, ok: true}
 			} else {
 				slots[i] = prResult{entry: entry, watched: false, ok: true}
@@ -378,18 +783,18 @@ func fetchOpenPRs(ctx context.Context, fullRepo string, currentUser string) ([]I
 	return watched, others, nil
 }
 
-func displayReviewResults(prs []ghpkg.ReviewRequest, localPRs map[int]bool, repo string) {
-	if jsonFlag {
-		var out []InboxPR
+// displayReviewResults renders the pending review table. fetched is the
+// number of review requests fetched before author filtering and total the
+// number GitHub reports, which is larger when search_limit capped the fetch.
+func displayReviewResults(prs []ghpkg.ReviewRequest, fetched, total int, localPRs map[int]bool, repo string) {
+	inboxRequests += len(prs)
+	if inboxCollecting() {
 		for _, pr := range prs {
-			out = append(out, InboxPR{
-				Number: pr.Number,
-				Title:  pr.Title,
-				Author: pr.Author.Login,
-				URL:    pr.URL,
-			})
+			addInboxItem(sectionReviewRequests, repo, inboxPRFromRequest(pr), "", localPRs, reviewStateRequested)
+		}
+		if total > fetched {
+			inboxNotes = append(inboxNotes, fmt.Sprintf("%s: only the first %d of %d review requests were fetched", repo, fetched, total))
 		}
-		printJSON(out)
 		return
 	}
 
@@ -398,33 +803,65 @@ func displayReviewResults(prs []ghpkg.ReviewRequest, localPRs map[int]bool, repo
 		fmt.Printf("%s %s\n", ui.BoldText(fmt.Sprintf("%d Pending PR Reviews — %s", len(prs), ui.YellowText(repo))), ui.DimText("(all authors)"))
 	} else {
 		fmt.Println(ui.BoldText(fmt.Sprintf("%d Pending PR Reviews — %s", len(prs), ui.YellowText(repo))))
-		ui.Hint(fmt.Sprintf("Authors: %s", strings.Join(cfg.Authors, " ")))
+		ui.Hint("Authors: " + authorsLabel(cfg.AllAuthors()))
+	}
+	if total > len(prs) {
+		ui.Hint(fmt.Sprintf("%d review requests in total", total))
+	}
+	if total > fetched {
+		ui.Hint(fmt.Sprintf("Only the first %d were fetched -- raise search_limit in %s to see more", fetched, config.Path()))
 	}
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
 
-	fmt.Printf("  %-2s  %-6s  %-20s  %-42s  %s\n", "W", "PR", "Author", "Title", "Link")
-	fmt.Printf("  %-2s  %-6s  %-20s  %-42s  %s\n", "──", "──────", "────────────────────", "──────────────────────────────────────────", "────────────────────────")
+	t := inboxTable(inboxColWorktree, inboxColPR, inboxColAge, inboxColAuthor, inboxColTitle, inboxColLabels, inboxColLink)
+	for _, pr := range prs {
+		title := linkedTitle(pr.Title, ghpkg.LinkedPRs(pr.Body, pr.Repository.NameWithOwner), pr.Repository.NameWithOwner)
+		t.Row(worktreeMark(localPRs[pr.Number]), prCell(pr.Number), ageCell(pr.CreatedAt), pr.Author.Login, title, labelChips(pr.Labels), ui.DimText(pr.URL))
+	}
+	t.Print()
+	fmt.Println()
+}
+
+// displayTeamRequests renders review requests routed to one of the
+// configured teams rather than to the user personally.
+func displayTeamRequests(prs []ghpkg.ReviewRequest, fetched, total int, localPRs map[int]bool, repo string) {
+	inboxRequests += len(prs)
+	if inboxCollecting() {
+		for _, pr := range prs {
+			addInboxItem(sectionTeamRequests, repo, inboxPRFromRequest(pr), pr.Team, localPRs, reviewStateTeamRequested)
+		}
+		if total > fetched {
+			inboxNotes = append(inboxNotes, fmt.Sprintf("%s: only the first %d of %d team requests were fetched", repo, fetched, total))
+		}
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("%d Team Requests — %s", len(prs), ui.YellowText(repo))))
+	ui.Hint(fmt.Sprintf("Teams: %s", strings.Join(cfg.Teams, " ")))
+	if total > fetched {
+		ui.Hint(fmt.Sprintf("Only the first %d of %d were fetched -- raise search_limit in %s to see more", fetched, total, config.Path()))
+	}
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
 
+	t := inboxTable(inboxColWorktree, inboxColPR, inboxColAge, inboxColAuthor, inboxColTitle, inboxColLabels,
+		ui.Column{Key: "team", Header: "Team", Max: 24}, inboxColLink)
 	for _, pr := range prs {
-		wtMarker := "  "
-		if localPRs[pr.Number] {
-			wtMarker = ui.GreenText("* ")
-		}
-		shortTitle := ui.Truncate(pr.Title, 40)
-		fmt.Printf("  %s  %s  %-20s  %-42s  %s\n",
-			wtMarker,
-			ui.CyanText(fmt.Sprintf("#%-5d", pr.Number)),
-			pr.Author.Login,
-			shortTitle,
-			ui.DimText(pr.URL))
+		title := linkedTitle(pr.Title, ghpkg.LinkedPRs(pr.Body, pr.Repository.NameWithOwner), pr.Repository.NameWithOwner)
+		t.Row(worktreeMark(localPRs[pr.Number]), prCell(pr.Number), ageCell(pr.CreatedAt), pr.Author.Login, title, labelChips(pr.Labels), pr.Team, ui.DimText(pr.URL))
 	}
+	t.Print()
 	fmt.Println()
 }
 
-func displayPathResults(pending []InboxPR, total int, repo string) {
-	if jsonFlag {
-		printJSON(pending)
+func displayPathResults(pending []InboxPR, total int, repo string, localPRs map[int]bool) {
+	inboxRequests += len(pending)
+	if inboxCollecting() {
+		for _, pr := range pending {
+			addInboxItem(sectionPath, repo, pr, "", localPRs, reviewStateNone)
+		}
 		return
 	}
 
@@ -439,52 +876,48 @@ func displayPathResults(pending []InboxPR, total int, repo string) {
 		return
 	}
 
-	fmt.Printf("  %-6s  %-20s  %-42s  %-10s  %s\n", "PR", "Author", "Title", "Files", "Link")
-	fmt.Printf("  %-6s  %-20s  %-42s  %-10s  %s\n", "──────", "────────────────────", "──────────────────────────────────────────", "──────────", "────────────────────────")
-
+	t := inboxTable(inboxColPR, inboxColAge, inboxColAuthor, inboxColTitle, ui.Column{Key: "files", Header: "Files"}, inboxColLink)
 	for _, pr := range pending {
-		shortTitle := ui.Truncate(pr.Title, 40)
 		files := ""
 		if pr.MatchedCount > 0 {
 			files = fmt.Sprintf("%d file(s)", pr.MatchedCount)
 		}
-		fmt.Printf("  %s  %-20s  %-42s  %-10s  %s\n",
-			ui.CyanText(fmt.Sprintf("#%-5d", pr.Number)),
-			pr.Author,
-			shortTitle,
-			ui.DimText(files),
-			ui.DimText(pr.URL))
+		t.Row(prCell(pr.Number), ageCell(pr.CreatedAt), pr.Author, pr.Title, ui.DimText(files), ui.DimText(pr.URL))
 	}
+	t.Print()
 	fmt.Println()
 }
 
-func displayApprovedUnmerged(prs []ghpkg.ApprovedPR) {
-	if jsonFlag {
-		printJSON(prs)
+func displayApprovedUnmerged(prs []ghpkg.ApprovedPR, total int, localPRs map[int]bool, repo string) {
+	if inboxCollecting() {
+		for _, pr := range prs {
+			entry := InboxPR{Number: pr.Number, Title: pr.Title, Author: pr.Author.Login, URL: pr.URL, CreatedAt: pr.CreatedAt}
+			addInboxItem(sectionApproved, repo, entry, "", localPRs, reviewStateApproved)
+		}
 		return
 	}
 
 	fmt.Println()
 	fmt.Println(ui.BoldText(fmt.Sprintf("%d Your PRs — Approved, Ready to Merge", len(prs))))
+	if total > len(prs) {
+		ui.Hint(fmt.Sprintf("Showing %d of %d -- raise search_limit in %s to see more", len(prs), total, config.Path()))
+	}
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
 
-	fmt.Printf("  %-6s  %-50s  %s\n", "PR", "Title", "Link")
-	fmt.Printf("  %-6s  %-50s  %s\n", "──────", "──────────────────────────────────────────────────", "────────────────────────")
-
+	t := inboxTable(inboxColPR, inboxColAge, inboxColTitle, inboxColLink)
 	for _, pr := range prs {
-		shortTitle := ui.Truncate(pr.Title, 48)
-		fmt.Printf("  %s  %-50s  %s\n",
-			ui.GreenText(fmt.Sprintf("#%-5d", pr.Number)),
-			shortTitle,
-			ui.DimText(pr.URL))
+		t.Row(ui.GreenText(fmt.Sprintf("#%d", pr.Number)), ageCell(pr.CreatedAt), pr.Title, ui.DimText(pr.URL))
 	}
+	t.Print()
 	fmt.Println()
 }
 
 func displayWatchedPRs(prs []InboxPR, localPRs map[int]bool, repo string) {
-	if jsonFlag {
-		printJSON(prs)
+	if inboxCollecting() {
+		for _, pr := range prs {
+			addInboxItem(sectionWatchedPaths, repo, pr, "", localPRs, reviewStateNone)
+		}
 		return
 	}
 
@@ -499,8 +932,10 @@ func displayWatchedPRs(prs []InboxPR, localPRs map[int]bool, repo string) {
 }
 
 func displayOtherPRs(prs []InboxPR, localPRs map[int]bool, repo string) {
-	if jsonFlag {
-		printJSON(prs)
+	if inboxCollecting() {
+		for _, pr := range prs {
+			addInboxItem(sectionOtherRequests, repo, pr, "", localPRs, reviewStateRequested)
+		}
 		return
 	}
 
@@ -513,24 +948,165 @@ func displayOtherPRs(prs []InboxPR, localPRs map[int]bool, repo string) {
 	fmt.Println()
 }
 
+// displayThreads renders the issues or discussions section: threads that
+// involve the user and were updated recently, most recent first.
+func displayThreads(section string, threads []ghpkg.Thread, repo string) {
+	if inboxCollecting() {
+		for _, t := range threads {
+			inboxItems = append(inboxItems, InboxItem{
+				Section:      section,
+				Repo:         t.Repository.NameWithOwner,
+				PR:           t.Number,
+				Title:        t.Title,
+				Author:       t.Author.Login,
+				URL:          t.URL,
+				MatchedPaths: []string{},
+				ReviewState:  reviewStateNone,
+				Kind:         t.Kind,
+				Reason:       t.Reason,
+			})
+		}
+		return
+	}
+
+	title := "Issues for You"
+	if section == sectionDiscussions {
+		title = "Discussions Involving You"
+	}
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("%d %s — %s", len(threads), title, ui.YellowText(repo))))
+	ui.Hint(fmt.Sprintf("Updated in the last %d days", cfg.Inbox.GetThreadDays()))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	tbl := inboxTable(ui.Column{Key: "pr", Header: "#"}, ui.Column{Key: "reason", Header: "Reason"},
+		ui.Column{Key: "updated", Header: "Updated"}, inboxColTitle, inboxColLink)
+	for _, t := range threads {
+		updated := ""
+		if ts, err := time.Parse(time.RFC3339, t.UpdatedAt); err == nil {
+			updated = ui.FormatDuration(int(time.Since(ts).Seconds())) + " ago"
+		}
+		tbl.Row(prCell(t.Number), t.Reason, updated, t.Title, ui.DimText(t.URL))
+	}
+	tbl.Print()
+	fmt.Println()
+}
+
+// inboxPriority orders sections in the combined table: review requests
+// first, then PRs you asked to see, then your own PRs, then threads.
+var inboxPriority = map[string]int{
+	sectionReviewRequests: 0,
+	sectionTeamRequests:   1,
+	sectionOtherRequests:  2,
+	sectionWatchedPaths:   3,
+	sectionPath:           4,
+	sectionApproved:       5,
+	sectionIssues:         6,
+	sectionDiscussions:    7,
+}
+
+// inboxReasons is the short label shown in the combined table's Why column.
+var inboxReasons = map[string]string{
+	sectionReviewRequests: "review",
+	sectionTeamRequests:   "team",
+	sectionOtherRequests:  "review",
+	sectionWatchedPaths:   "watched",
+	sectionPath:           "path",
+	sectionApproved:       "approved",
+	sectionIssues:         "issue",
+	sectionDiscussions:    "discussion",
+}
+
+// combineInboxItems drops repeats of a PR listed in several sections,
+// keeping its most urgent one, and sorts by section priority then age,
+// oldest first, across repos.
+func combineInboxItems(items []InboxItem) []InboxItem {
+	type key struct {
+		repo string
+		num  int
+		kind string
+	}
+	best := make(map[key]int)
+	var out []InboxItem
+	for _, it := range items {
+		k := key{it.Repo, it.PR, it.Kind}
+		if i, ok := best[k]; ok {
+			if inboxPriority[it.Section] < inboxPriority[out[i].Section] {
+				out[i] = it
+			}
+			continue
+		}
+		best[k] = len(out)
+		out = append(out, it)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		pi, pj := inboxPriority[out[i].Section], inboxPriority[out[j].Section]
+		if pi != pj {
+			return pi < pj
+		}
+		// RFC3339 timestamps sort chronologically; undated items go last
+		ci, cj := out[i].CreatedAt, out[j].CreatedAt
+		if ci != cj && ci != "" && cj != "" {
+			return ci < cj
+		}
+		if (ci == "") != (cj == "") {
+			return cj == ""
+		}
+		if out[i].Repo != out[j].Repo {
+			return out[i].Repo < out[j].Repo
+		}
+		return out[i].PR < out[j].PR
+	})
+	return out
+}
+
+// displayCombined renders the items of every repo and section as one table.
+func displayCombined(items []InboxItem, authors []string) {
+	rows, follows := groupLinkedItems(combineInboxItems(items))
+
+	fmt.Println()
+	if inboxPathFilter != "" {
+		fmt.Println(ui.BoldText(fmt.Sprintf("%d Open PRs touching %s", len(rows), ui.CyanText(inboxPathFilter))))
+	} else {
+		fmt.Println(ui.BoldText(fmt.Sprintf("%d Items Across Repos", len(rows))))
+	}
+	if inboxAll {
+		ui.Hint("All authors")
+	} else if len(authors) > 0 {
+		ui.Hint("Authors: " + authorsLabel(authors))
+	}
+	for _, note := range inboxNotes {
+		ui.Hint(note)
+	}
+	if len(inboxNotes) > 0 {
+		ui.Hint(fmt.Sprintf("Raise search_limit in %s to see more", config.Path()))
+	}
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	t := inboxTable(inboxColWorktree, inboxColRepo, ui.Column{Key: "pr", Header: "#"}, ui.Column{Key: "why", Header: "Why"},
+		inboxColAge, inboxColAuthor, inboxColTitle, inboxColLabels, inboxColLink)
+	for i, it := range rows {
+		title := linkedTitle(it.Title, it.Linked, it.Repo)
+		if follows[i] {
+			title = ui.DimText("↳ ") + title
+		}
+		t.Row(worktreeMark(it.HasWorktree), ui.YellowText(shortRepoName(it.Repo)), prCell(it.PR),
+			inboxReasons[it.Section], ageCell(it.CreatedAt), it.Author, title, labelChips(it.Labels), ui.DimText(it.URL))
+	}
+	t.Print()
+	fmt.Println()
+	ui.Hint("Use --by-repo for separate sections per repo")
+	fmt.Println()
+}
+
 // printPRTable renders a PR table with a W (worktree) column.
 func printPRTable(prs []InboxPR, localPRs map[int]bool) {
-	fmt.Printf("  %-2s  %-6s  %-20s  %-42s  %s\n", "W", "PR", "Author", "Title", "Link")
-	fmt.Printf("  %-2s  %-6s  %-20s  %-42s  %s\n", "──", "──────", "────────────────────", "──────────────────────────────────────────", "────────────────────────")
-
+	t := inboxTable(inboxColWorktree, inboxColPR, inboxColAge, inboxColAuthor, inboxColTitle, inboxColLabels, inboxColLink)
 	for _, pr := range prs {
-		shortTitle := ui.Truncate(pr.Title, 40)
-		wCol := "  "
-		if localPRs[pr.Number] {
-			wCol = ui.GreenText("* ")
-		}
-		fmt.Printf("  %s  %s  %-20s  %-42s  %s\n",
-			wCol,
-			ui.CyanText(fmt.Sprintf("#%-5d", pr.Number)),
-			pr.Author,
-			shortTitle,
-			ui.DimText(pr.URL))
+		t.Row(worktreeMark(localPRs[pr.Number]), prCell(pr.Number), ageCell(pr.CreatedAt), pr.Author, pr.Title, labelChips(pr.Labels), ui.DimText(pr.URL))
 	}
+	t.Print()
 }
 
 // printWorktreeLegend prints a legend explaining the W column and worktree indicators.
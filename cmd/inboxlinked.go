@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/ui"
+)
+
+// linkedRefs formats the PRs a PR is paired with for a table, with the
+// short repo name, or only #N for PRs of fullRepo.
+func linkedRefs(refs []ghpkg.PRRef, fullRepo string) string {
+	names := make([]string, len(refs))
+	for i, r := range refs {
+		if r.Repo == fullRepo {
+			names[i] = fmt.Sprintf("#%d", r.Number)
+		} else {
+			names[i] = fmt.Sprintf("%s#%d", cfg.RepoShortName(r.Repo), r.Number)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// linkedTitle appends the PRs a PR is paired with to its title.
+func linkedTitle(title string, refs []ghpkg.PRRef, fullRepo string) string {
+	if len(refs) == 0 {
+		return title
+	}
+	return title + " " + ui.DimText("⇄ "+linkedRefs(refs, fullRepo))
+}
+
+// groupLinkedItems moves PRs paired through "Depends on" or "Companion"
+// references next to the first of them, keeping the order otherwise.
+// follows reports the rows listed under an earlier PR of their set.
+func groupLinkedItems(rows []InboxItem) (out []InboxItem, follows []bool) {
+	index := make(map[ghpkg.PRRef]int, len(rows))
+	for i, it := range rows {
+		if it.Kind == "" {
+			index[ghpkg.PRRef{Repo: it.Repo, Number: it.PR}] = i
+		}
+	}
+
+	// Union-find over the rows, joined by the links between them
+	parent := make([]int, len(rows))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i, it := range rows {
+		for _, ref := range it.Linked {
+			if j, ok := index[ref]; ok {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := range rows {
+		members[find(i)] = append(members[find(i)], i)
+	}
+	done := make(map[int]bool)
+	for i := range rows {
+		root := find(i)
+		if done[root] {
+			continue
+		}
+		done[root] = true
+		for k, m := range members[root] {
+			out = append(out, rows[m])
+			follows = append(follows, k > 0)
+		}
+	}
+	return out, follows
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/ui"
+	"golang.org/x/sync/errgroup"
+)
+
+// notificationsSchemaVersion is bumped on any incompatible change to
+// NotificationsJSON.
+const notificationsSchemaVersion = 1
+
+// NotificationsJSON is the document printed by zen inbox --notifications
+// --json. It has the same envelope as InboxJSON.
+type NotificationsJSON struct {
+	SchemaVersion int                 `json:"schema_version"`
+	Items         []NotificationEntry `json:"items"`
+}
+
+// NotificationEntry is a GitHub notification correlated with local state,
+// as printed by zen inbox --notifications --json.
+type NotificationEntry struct {
+	ghpkg.Notification
+	HasWorktree bool `json:"has_worktree"`
+	Reviewed    bool `json:"reviewed"` // you submitted a review on the PR
+	MarkedDone  bool `json:"marked_done"`
+}
+
+// Handled reports whether the notification needs nothing more from you
+// in the GitHub inbox: its PR is checked out locally or already reviewed.
+func (e NotificationEntry) Handled() bool {
+	return e.HasWorktree || e.Reviewed
+}
+
+// runInboxNotifications lists review requests, mentions and assignments
+// from the GitHub notifications API for repos, and with --mark-done marks
+// the handled ones as done.
+func runInboxNotifications(repos []string) error {
+	ctx := context.Background()
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("creating GitHub client: %w", err)
+	}
+
+	fullNames := make([]string, len(repos))
+	shortName := make(map[string]string, len(repos))
+	for i, r := range repos {
+		fullNames[i] = cfg.RepoFullName(r)
+		shortName[fullNames[i]] = r
+	}
+	notifications, err := client.ListNotifications(ctx, fullNames, ghpkg.NotificationReasons)
+	if err != nil {
+		return err
+	}
+
+	localPRs := make(map[string]map[int]bool, len(repos))
+	for _, r := range repos {
+		localPRs[r] = getLocalPRNumbers(r)
+	}
+	currentUser, _ := ghpkg.GetCurrentUser(ctx)
+
+	entries := make([]NotificationEntry, len(notifications))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(5)
+	for i, n := range notifications {
+		entries[i] = NotificationEntry{Notification: n}
+		if !n.IsPR() {
+			continue
+		}
+		entries[i].HasWorktree = localPRs[shortName[n.Repo]][n.Number]
+		if currentUser == "" || entries[i].HasWorktree {
+			continue
+		}
+		g.Go(func() error {
+			reviewed, err := client.HasReviewed(gctx, n.Repo, n.Number, currentUser)
+			if err != nil {
+				ui.LogDebug(fmt.Sprintf("checking reviews of %s#%d: %v", n.Repo, n.Number, err))
+				return nil
+			}
+			entries[i].Reviewed = reviewed
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if inboxMarkDone {
+		for i, e := range entries {
+			if !e.Handled() {
+				continue
+			}
+			if err := client.MarkNotificationDone(ctx, e.ID); err != nil {
+				ui.LogWarn(err.Error())
+				continue
+			}
+			entries[i].MarkedDone = true
+		}
+	}
+	for _, e := range entries {
+		if !e.Handled() {
+			setResults(true)
+		}
+	}
+
+	if jsonFlag {
+		if entries == nil {
+			entries = []NotificationEntry{}
+		}
+		printJSON(NotificationsJSON{SchemaVersion: notificationsSchemaVersion, Items: entries})
+		return nil
+	}
+	displayNotifications(entries)
+	return nil
+}
+
+func displayNotifications(entries []NotificationEntry) {
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("%d GitHub Notifications", len(entries))))
+	ui.Hint("Review requests, mentions and assignments in configured repos")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	if len(entries) == 0 {
+		fmt.Println("Nothing in your GitHub inbox.")
+		fmt.Println()
+		return
+	}
+
+	t := inboxTable(inboxColWorktree, inboxColRepo, ui.Column{Key: "pr", Header: "#"}, ui.Column{Key: "reason", Header: "Reason"},
+		ui.Column{Key: "age", Header: "Age"}, ui.Column{Key: "state", Header: "State"}, inboxColTitle, inboxColLink)
+	handled, done := 0, 0
+	for _, e := range entries {
+		state := ""
+		switch {
+		case e.MarkedDone:
+			state = ui.GreenText("done")
+			done++
+		case e.Reviewed:
+			state = ui.GreenText("reviewed")
+			handled++
+		case e.HasWorktree:
+			state = "worktree"
+			handled++
+		case e.Unread:
+			state = ui.YellowText("unread")
+		}
+		num := fmt.Sprintf("#%d", e.Number)
+		if e.IsPR() {
+			num = ui.CyanText(num)
+		}
+		t.Row(worktreeMark(e.HasWorktree), shortRepoName(e.Repo), num, e.Reason,
+			ui.FormatDuration(int(time.Since(e.UpdatedAt).Seconds())), state, e.Title, ui.DimText(e.URL))
+	}
+	t.Print()
+	fmt.Println()
+	if done > 0 {
+		ui.LogSuccess(fmt.Sprintf("Marked %d notification(s) done on GitHub", done))
+	}
+	if handled > 0 {
+		ui.Hint(fmt.Sprintf("%d handled (worktree or review submitted) -- clear them with: zen inbox --notifications --mark-done", handled))
+	}
+	fmt.Println()
+}
+
+// shortRepoName returns the name part of owner/name.
+func shortRepoName(fullRepo string) string {
+	if i := strings.LastIndex(fullRepo, "/"); i >= 0 {
+		return fullRepo[i+1:]
+	}
+	return fullRepo
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"strings"
+
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/ui"
+)
+
+// labelChips renders PR labels for a table cell: colored chips, or a
+// comma-separated list without colors.
+func labelChips(labels ghpkg.Labels) string {
+	if !ui.ColorsEnabled() {
+		return strings.Join(labels.Names(), ", ")
+	}
+	chips := make([]string, len(labels))
+	for i, l := range labels {
+		chips[i] = ui.LabelChip(l.Name, l.Color)
+	}
+	return strings.Join(chips, " ")
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/mgreau/zen/internal/prcache"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var linkNoCopy bool
+
+var linkCmd = &cobra.Command{
+	Use:   "link [pr-number]",
+	Short: "Print links to a PR review: PR, worktree, session and resume command",
+	Long: `Prints what a teammate needs to pick up a review: the PR URL, the
+worktree path, the latest Claude session ID and the zen review resume
+command, formatted for pasting into Slack. The text is also copied to the
+clipboard (pbcopy, wl-copy, xclip or xsel) unless --no-copy is given.
+
+Without a PR number, links the PR review worktree of the current
+directory, e.g. from inside its Claude session.
+
+Example:
+  zen link 42
+  zen link 42 --name tests
+  zen link`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLink,
+}
+
+func init() {
+	linkCmd.Flags().StringVar(&reviewName, "name", "", "Link the <repo>-pr-N-<name> checkout")
+	linkCmd.Flags().BoolVar(&linkNoCopy, "no-copy", false, "Don't copy the links to the clipboard")
+	rootCmd.AddCommand(linkCmd)
+}
+
+// prLinks is the JSON output of zen link.
+type prLinks struct {
+	Repo     string `json:"repo"`
+	PR       int    `json:"pr"`
+	Title    string `json:"title,omitempty"`
+	URL      string `json:"url"`
+	Worktree string `json:"worktree"`
+	Session  string `json:"session,omitempty"`
+	Resume   string `json:"resume"`
+}
+
+func runLink(cmd *cobra.Command, args []string) error {
+	var w *worktree.Worktree
+	if len(args) == 1 {
+		prNumber, err := parsePRArg(args[0], nil)
+		if err != nil {
+			return err
+		}
+		if w, err = findWorktreeByPR(prNumber, reviewName); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if w, err = currentWorktree(); err != nil {
+			return errors.New("not inside a PR review worktree -- pass the PR number")
+		}
+		if w.Type != worktree.TypePRReview || w.PRNumber == 0 {
+			return fmt.Errorf("%s is not a PR review worktree -- pass the PR number", w.Name)
+		}
+	}
+
+	links := prLinks{
+		Repo:     w.Repo,
+		PR:       w.PRNumber,
+		URL:      fmt.Sprintf("https://github.com/%s/pull/%d", cfg.RepoFullName(w.Repo), w.PRNumber),
+		Worktree: w.Path,
+		Resume:   fmt.Sprintf("zen review resume %d%s", w.PRNumber, nameFlag(w.Suffix)),
+	}
+	if meta, ok := prcache.Get(w.Repo, w.PRNumber); ok {
+		links.Title = meta.Title
+	}
+	if sessions, _ := session.FindSessions(w.Path); len(sessions) > 0 {
+		links.Session = sessions[0].ID
+	}
+
+	if jsonFlag {
+		printJSON(links)
+		return nil
+	}
+
+	text := links.slack()
+	fmt.Println(text)
+	if linkNoCopy {
+		return nil
+	}
+	fmt.Println()
+	if err := copyToClipboard(text); err != nil {
+		ui.LogWarn(fmt.Sprintf("Not copied: %v", err))
+		return nil
+	}
+	ui.LogSuccess("Copied to the clipboard")
+	return nil
+}
+
+// slack formats the links as Slack message text. The worktree path uses
+// ~ so it reads the same on a teammate's machine with the same layout.
+func (l prLinks) slack() string {
+	var b strings.Builder
+	title := ""
+	if l.Title != "" {
+		title = " " + l.Title
+	}
+	fmt.Fprintf(&b, "*%s#%d*%s\n", cfg.RepoFullName(l.Repo), l.PR, title)
+	fmt.Fprintf(&b, "PR: %s\n", l.URL)
+	fmt.Fprintf(&b, "Worktree: `%s`\n", ui.ShortenHome(l.Worktree, homeDir()))
+	if l.Session != "" {
+		fmt.Fprintf(&b, "Session: `%s`\n", l.Session)
+	}
+	fmt.Fprintf(&b, "Resume: `%s`", l.Resume)
+	return b.String()
+}
+
+// clipboardCommands are tried in order to copy text to the clipboard.
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+}
+
+// copyToClipboard copies text to the system clipboard with the first
+// clipboard tool found.
+func copyToClipboard(text string) error {
+	for _, args := range clipboardCommands {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		c := exec.Command(args[0], args[1:]...)
+		c.Stdin = strings.NewReader(text)
+		c.Stderr = os.Stderr
+		return c.Run()
+	}
+	return errors.New("no clipboard tool found (pbcopy, wl-copy, xclip or xsel)")
+}
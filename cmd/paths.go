@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/prcache"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var pathsCmd = &cobra.Command{
+	Use:   "paths",
+	Short: "Watched paths helpers",
+}
+
+var pathsReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Show which watched paths PRs changed most, and by whom",
+	Long: `Tallies, over the PRs merged or updated in the last N days, how many
+changed each of your watch_paths, how many files they changed there, and
+their most frequent authors. Watched paths no PR touched are listed too, as
+candidates to drop from watch_paths.
+
+Below them are the busiest directories outside your watch_paths, cut to
+--depth levels, to spot hot areas of the codebase worth watching.
+
+Example:
+  zen paths report
+  zen paths report --days 90 --repo mono
+  zen paths report --depth 3 --limit 20 --json`,
+	Args: cobra.NoArgs,
+	RunE: runPathsReport,
+}
+
+var (
+	pathsRepo  string
+	pathsDays  int
+	pathsDepth int
+	pathsLimit int
+	pathsPRs   int
+)
+
+func init() {
+	pathsReportCmd.Flags().StringVarP(&pathsRepo, "repo", "r", "", "Repository or @group to report on (default: all)")
+	pathsReportCmd.Flags().IntVarP(&pathsDays, "days", "d", 30, "Only count PRs merged or updated in the last N days")
+	pathsReportCmd.Flags().IntVar(&pathsDepth, "depth", 2, "Directory levels to group unwatched changes by")
+	pathsReportCmd.Flags().IntVarP(&pathsLimit, "limit", "n", 10, "Number of unwatched directories to show")
+	pathsReportCmd.Flags().IntVar(&pathsPRs, "max-prs", 200, "Maximum open and merged PRs to scan per repository, each")
+	pathsCmd.AddCommand(pathsReportCmd)
+	rootCmd.AddCommand(pathsCmd)
+}
+
+// pathsReport is the JSON output of zen paths report, one per repo.
+type pathsReport struct {
+	Repo      string           `json:"repo"`
+	Days      int              `json:"days"`
+	PRs       int              `json:"prs"` // PRs scanned
+	Watched   []ghpkg.PathHeat `json:"watched"`
+	Unwatched []ghpkg.PathHeat `json:"unwatched"`
+}
+
+func runPathsReport(cmd *cobra.Command, args []string) error {
+	if pathsDays <= 0 || pathsDepth <= 0 {
+		return fmt.Errorf("--days and --depth must be positive")
+	}
+	repos, err := cfg.ResolveRepos(pathsRepo)
+	if err != nil {
+		return err
+	}
+	if len(cfg.WatchPaths) == 0 && !jsonFlag {
+		ui.LogWarn("No watch_paths in the config: only unwatched directories are listed")
+	}
+
+	ctx := context.Background()
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("creating GitHub client: %w", err)
+	}
+	fileCache := prcache.LoadFiles()
+	defer fileCache.Save()
+
+	since := time.Now().AddDate(0, 0, -pathsDays)
+	var reports []pathsReport
+	for _, repo := range repos {
+		fullRepo := cfg.RepoFullName(repo)
+		if !jsonFlag {
+			fmt.Fprintf(os.Stderr, "  %s", ui.DimText(fmt.Sprintf("Scanning %s PRs...", fullRepo)))
+		}
+		prs, err := recentPRFiles(ctx, client, fileCache, fullRepo, since)
+		if !jsonFlag {
+			fmt.Fprintf(os.Stderr, "\r%-60s\r", "")
+		}
+		if err != nil {
+			ui.LogWarn(fmt.Sprintf("listing PRs of %s: %v", fullRepo, err))
+			continue
+		}
+		watched, unwatched := ghpkg.PathHeatMap(prs, cfg.WatchPaths, pathsDepth)
+		if len(unwatched) > pathsLimit {
+			unwatched = unwatched[:pathsLimit]
+		}
+		if watched == nil {
+			watched = []ghpkg.PathHeat{}
+		}
+		if unwatched == nil {
+			unwatched = []ghpkg.PathHeat{}
+		}
+		reports = append(reports, pathsReport{Repo: fullRepo, Days: pathsDays, PRs: len(prs), Watched: watched, Unwatched: unwatched})
+	}
+
+	if jsonFlag {
+		if reports == nil {
+			reports = []pathsReport{}
+		}
+		printJSON(reports)
+		return nil
+	}
+	for _, r := range reports {
+		displayPathsReport(r)
+	}
+	return nil
+}
+
+// recentPRFiles returns the changed files of the PRs of fullRepo merged or
+// updated since the given time. PRs whose files can't be fetched are left
+// out.
+func recentPRFiles(ctx context.Context, client *ghpkg.Client, cache *prcache.FileCache, fullRepo string, since time.Time) ([]ghpkg.PRFiles, error) {
+	open, merged, err := ghpkg.ListRecentPRs(ctx, fullRepo, since, pathsPRs)
+	if err != nil {
+		return nil, err
+	}
+	all := append(open, merged...)
+	slots := make([]*ghpkg.PRFiles, len(all))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(5)
+	for i, pr := range all {
+		g.Go(func() error {
+			files, err := cachedPRFiles(gctx, client, cache, fullRepo, pr)
+			if err != nil {
+				return nil
+			}
+			slots[i] = &ghpkg.PRFiles{Number: pr.Number, Author: pr.Author.Login, Merged: i >= len(open), Files: files}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var prs []ghpkg.PRFiles
+	for _, s := range slots {
+		if s != nil {
+			prs = append(prs, *s)
+		}
+	}
+	return prs, nil
+}
+
+func displayPathsReport(r pathsReport) {
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("Path Activity — %s", ui.YellowText(r.Repo))))
+	ui.Hint(fmt.Sprintf("%d PRs merged or updated in the last %d days", r.PRs, r.Days))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+
+	if len(r.Watched) > 0 {
+		fmt.Println()
+		ui.SectionHeader("Watched paths")
+		fmt.Println()
+		printPathHeat(r.Watched)
+	}
+	if len(r.Unwatched) > 0 {
+		fmt.Println()
+		ui.SectionHeader("Busiest unwatched directories")
+		fmt.Println()
+		printPathHeat(r.Unwatched)
+	}
+	fmt.Println()
+}
+
+func printPathHeat(heat []ghpkg.PathHeat) {
+	tbl := ui.NewTable([]ui.Column{
+		{Key: "path", Header: "Path"},
+		{Key: "prs", Header: "PRs"},
+		{Key: "merged", Header: "Merged"},
+		{Key: "files", Header: "Files"},
+		{Key: "authors", Header: "Top authors"},
+	}, nil)
+	for _, h := range heat {
+		var authors []string
+		for i, a := range h.Authors {
+			if i == 3 {
+				authors = append(authors, fmt.Sprintf("+%d", len(h.Authors)-3))
+				break
+			}
+			authors = append(authors, fmt.Sprintf("@%s (%d)", a.Login, a.PRs))
+		}
+		path := strings.TrimSuffix(h.Path, "/") + "/"
+		if h.Path == "." {
+			path = "(root)"
+		}
+		if h.PRs == 0 {
+			tbl.Row(ui.DimText(path), ui.DimText("0"), "", "", ui.DimText("no changes"))
+			continue
+		}
+		tbl.Row(path, fmt.Sprintf("%d", h.PRs), fmt.Sprintf("%d", h.Merged), fmt.Sprintf("%d", h.Files), strings.Join(authors, ", "))
+	}
+	tbl.Print()
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/notify"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Pull request helpers",
+}
+
+var prChecksCmd = &cobra.Command{
+	Use:   "checks <pr-number>",
+	Short: "List CI checks for a PR, optionally waiting until they finish",
+	Long: `Lists the check runs and commit statuses on a PR's head commit with
+their state and duration.
+
+With --watch, polls until every check has finished, printing each one as
+it completes, then sends a notification. Exits non-zero if any check failed.
+
+Example:
+  zen pr checks 42
+  zen pr checks 42 --watch`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRChecks,
+}
+
+var (
+	prChecksRepo     string
+	prChecksWatch    bool
+	prChecksInterval time.Duration
+)
+
+func init() {
+	prChecksCmd.Flags().StringVar(&prChecksRepo, "repo", "", "Repository short name or @group (auto-detected if omitted)")
+	prChecksCmd.Flags().BoolVarP(&prChecksWatch, "watch", "w", false, "Wait until all checks finish and notify")
+	prChecksCmd.Flags().DurationVar(&prChecksInterval, "interval", 30*time.Second, "Poll interval with --watch")
+
+	prCmd.AddCommand(prChecksCmd)
+	rootCmd.AddCommand(prCmd)
+}
+
+// prChecksResult is the JSON output of zen pr checks.
+type prChecksResult struct {
+	Repo    string        `json:"repo"`
+	PR      int           `json:"pr"`
+	SHA     string        `json:"sha"`
+	Pending int           `json:"pending"`
+	Passed  int           `json:"passed"`
+	Failed  int           `json:"failed"`
+	Checks  []ghpkg.Check `json:"checks"`
+}
+
+func runPRChecks(cmd *cobra.Command, args []string) error {
+	prNumber, err := parsePRArg(args[0], &prChecksRepo)
+	if err != nil {
+		return err
+	}
+	if prChecksInterval < 5*time.Second {
+		return fmt.Errorf("--interval must be at least 5s")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	repo := prChecksRepo
+	if repo == "" || config.IsGroupRef(repo) {
+		detected, err := detectRepoForPR(ctx, prNumber, repo)
+		if err != nil {
+			return err
+		}
+		repo = detected
+	}
+	fullRepo := cfg.RepoFullName(repo)
+
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("creating GitHub client: %w", err)
+	}
+
+	sha, checks, err := client.GetPRChecks(ctx, fullRepo, prNumber)
+	if err != nil {
+		return err
+	}
+	sortChecks(checks)
+
+	if !jsonFlag {
+		displayPRChecks(fullRepo, prNumber, sha, checks)
+	}
+
+	if prChecksWatch {
+		pending, _, _ := ghpkg.SummarizeChecks(checks)
+		if pending > 0 && !jsonFlag {
+			ui.LogInfo(fmt.Sprintf("Waiting for %d check(s) (every %s, Ctrl-C to stop)...", pending, prChecksInterval))
+		}
+		for pending > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(prChecksInterval):
+			}
+
+			var next []ghpkg.Check
+			sha, next, err = client.GetPRChecks(ctx, fullRepo, prNumber)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				ui.LogWarn(fmt.Sprintf("Fetching checks: %v", err))
+				continue
+			}
+			sortChecks(next)
+			if !jsonFlag {
+				printFinishedChecks(checks, next)
+			}
+			checks = next
+			pending, _, _ = ghpkg.SummarizeChecks(checks)
+		}
+	}
+
+	pending, passed, failed := ghpkg.SummarizeChecks(checks)
+	if jsonFlag {
+		if checks == nil {
+			checks = []ghpkg.Check{}
+		}
+		printJSON(prChecksResult{Repo: fullRepo, PR: prNumber, SHA: sha, Pending: pending, Passed: passed, Failed: failed, Checks: checks})
+	}
+
+	if prChecksWatch {
+		if err := notify.ChecksFinished(prNumber, fullRepo, passed, failed); err != nil {
+			ui.LogDebug(fmt.Sprintf("notification failed: %v", err))
+		}
+		if !jsonFlag {
+			fmt.Println()
+			if failed > 0 {
+				ui.LogError(fmt.Sprintf("PR #%d: %d check(s) failed, %d passed", prNumber, failed, passed))
+			} else {
+				ui.LogSuccess(fmt.Sprintf("PR #%d: all %d check(s) passed", prNumber, passed))
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d check(s) failed", failed)
+		}
+	}
+	return nil
+}
+
+// sortChecks orders failed checks first, then pending, then passed, each
+// group by name.
+func sortChecks(checks []ghpkg.Check) {
+	rank := map[string]int{ghpkg.CheckFailed: 0, ghpkg.CheckPending: 1, ghpkg.CheckPassed: 2}
+	sort.SliceStable(checks, func(i, j int) bool {
+		if rank[checks[i].State] != rank[checks[j].State] {
+			return rank[checks[i].State] < rank[checks[j].State]
+		}
+		return checks[i].Name < checks[j].Name
+	})
+}
+
+// checkIcon returns the colored state marker for a check.
+func checkIcon(state string) string {
+	switch state {
+	case ghpkg.CheckPassed:
+		return ui.GreenText("✓")
+	case ghpkg.CheckFailed:
+		return ui.RedText("✗")
+	default:
+		return ui.YellowText("•")
+	}
+}
+
+func displayPRChecks(fullRepo string, prNumber int, sha string, checks []ghpkg.Check) {
+	pending, passed, failed := ghpkg.SummarizeChecks(checks)
+
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("Checks for PR #%d — %s", prNumber, ui.YellowText(fullRepo))))
+	ui.Hint(fmt.Sprintf("Head %s: %d passed, %d failed, %d pending", ui.Truncate(sha, 10), passed, failed, pending))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	if len(checks) == 0 {
+		fmt.Println("  No checks reported yet.")
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("  %-2s  %-44s  %-10s  %s\n", "", "Check", "Duration", "Link")
+	fmt.Printf("  %-2s  %-44s  %-10s  %s\n", "──", "────────────────────────────────────────────", "──────────", "────────────────────────")
+	for _, c := range checks {
+		dur := ""
+		if d := c.Duration(); d > 0 {
+			dur = ui.FormatElapsed(d.Round(time.Second))
+		}
+		fmt.Printf("  %s   %-44s  %-10s  %s\n", checkIcon(c.State), ui.Truncate(c.Name, 44), dur, ui.DimText(c.URL))
+	}
+	fmt.Println()
+}
+
+// printFinishedChecks prints the checks that were pending (or unknown) in
+// prev and have completed in next.
+func printFinishedChecks(prev, next []ghpkg.Check) {
+	was := make(map[string]string, len(prev))
+	for _, c := range prev {
+		was[c.Name] = c.State
+	}
+	for _, c := range next {
+		if c.State == ghpkg.CheckPending || was[c.Name] == c.State {
+			continue
+		}
+		fmt.Printf("  %s %s %s\n", checkIcon(c.State), c.Name, ui.DimText("("+ui.FormatElapsed(c.Duration().Round(time.Second))+")"))
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mgreau/zen/internal/config"
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/ui"
+)
+
+// prArgRepo is the configured repo of the last PR URL given as an argument.
+// It narrows prWorktrees to that repo.
+var prArgRepo string
+
+// parsePRArg parses a PR argument: a number, optionally prefixed with #,
+// or a GitHub PR URL. For a URL, the PR's repo is mapped to its short name
+// (see urlRepo) and stored in *repo, so no detection is needed; a --repo
+// naming another repo is an error. repo may be nil for commands without
+// --repo.
+func parsePRArg(arg string, repo *string) (int, error) {
+	fullRepo, number, ok := ghpkg.ParsePRURL(arg)
+	if !ok {
+		n, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid PR %q: expected a PR number or a GitHub PR URL", arg)
+		}
+		return n, nil
+	}
+
+	short, err := urlRepo(fullRepo)
+	if err != nil {
+		return 0, err
+	}
+	if repo != nil {
+		if *repo != "" && !config.IsGroupRef(*repo) && *repo != short {
+			return 0, fmt.Errorf("--repo %s conflicts with the PR URL, which is in %s (%s)", *repo, fullRepo, short)
+		}
+		*repo = short
+	}
+	prArgRepo = short
+	return number, nil
+}
+
+// urlRepo returns the short name of the configured repo with the given
+// owner/repo. When none is configured, a clone of it in a configured base
+// path (<base_path>/<repo>) is added to the config, as zen repo add would.
+func urlRepo(fullRepo string) (string, error) {
+	for name, r := range cfg.Repos {
+		if strings.EqualFold(r.FullName, fullRepo) {
+			return name, nil
+		}
+	}
+
+	_, short, _ := strings.Cut(fullRepo, "/")
+	var searched []string
+	for _, base := range cfg.AllBasePaths() {
+		if slices.Contains(searched, base) {
+			continue
+		}
+		searched = append(searched, base)
+		clonePath := filepath.Join(base, short)
+		if info, err := os.Stat(filepath.Join(clonePath, ".git")); err != nil || !info.IsDir() {
+			continue
+		}
+		if origin, err := cloneFullName(clonePath); err != nil || !strings.EqualFold(origin, fullRepo) {
+			continue
+		}
+		if _, taken := cfg.Repos[short]; taken {
+			return "", fmt.Errorf("%s is not configured and its short name %q is taken -- add it to %s under another name",
+				fullRepo, short, ui.ShortenHome(config.Path(), homeDir()))
+		}
+		if err := config.AddRepo(short, fullRepo, ui.ShortenHome(base, homeDir())); err != nil {
+			return "", err
+		}
+		cfg.Repos[short] = config.RepoConfig{FullName: fullRepo, BasePath: base}
+		ui.LogInfo(fmt.Sprintf("Added %s to the config as %s (clone found at %s)", fullRepo, short, ui.ShortenHome(clonePath, homeDir())))
+		return short, nil
+	}
+	return "", fmt.Errorf("%s is not a configured repo\n  Clone it and add it with: zen repo add <path-to-clone>", fullRepo)
+}
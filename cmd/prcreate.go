@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var prCreateCmd = &cobra.Command{
+	Use:   "create [worktree]",
+	Short: "Push a feature worktree's branch and open a PR for it",
+	Long: `Opens a PR for a feature worktree, by default the one containing the
+current directory. The branch is pushed to origin (to your fork, opening a
+cross-fork PR, for repos with fork: in the config), the PR body is rendered
+from pr_template in the config (a Go text/template; a list of commits by
+default) and the PR number is recorded in the worktree metadata.
+
+With --summary, Claude summarizes the branch's diff against the base and
+the summary is available to the template as {{.Summary}}.
+
+Template fields: .Title .Branch .Base .Commits .Files .Summary
+
+Example:
+  zen pr create
+  zen pr create --summary --draft
+  zen pr create mono-retries --title "Retry failed setups"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPRCreate,
+}
+
+var (
+	prCreateTitle   string
+	prCreateBase    string
+	prCreateDraft   bool
+	prCreateSummary bool
+)
+
+func init() {
+	prCreateCmd.Flags().StringVar(&prCreateTitle, "title", "", "PR title (default: the subject of a single commit, else the branch name)")
+	prCreateCmd.Flags().StringVar(&prCreateBase, "base", "main", "Branch to merge into")
+	prCreateCmd.Flags().BoolVar(&prCreateDraft, "draft", false, "Open the PR as a draft")
+	prCreateCmd.Flags().BoolVar(&prCreateSummary, "summary", false, "Add a Claude-generated summary of the diff to the body")
+	prCmd.AddCommand(prCreateCmd)
+}
+
+// maxSummaryDiff caps the diff sent to Claude for --summary.
+const maxSummaryDiff = 200 * 1024
+
+func runPRCreate(cmd *cobra.Command, args []string) error {
+	var (
+		w   *worktree.Worktree
+		err error
+	)
+	if len(args) == 1 {
+		w, err = resolveWorktree(args[0])
+	} else {
+		w, err = currentWorktree()
+	}
+	if err != nil {
+		return err
+	}
+	if w.Type != worktree.TypeFeature || w.Branch == "" {
+		return fmt.Errorf("%s is not a feature worktree with a branch", w.Name)
+	}
+	if w.OpenedPR > 0 {
+		return fmt.Errorf("%s already has PR #%d", w.Name, w.OpenedPR)
+	}
+
+	ctx := context.Background()
+	fullRepo := cfg.RepoFullName(w.Repo)
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("creating GitHub client: %w", err)
+	}
+	// Contributor mode: make sure the branch pushes to the fork, also for
+	// worktrees created before fork: was configured.
+	if cfg.RepoFork(w.Repo) != "" {
+		if _, err := setupFork(ctx, client, w.Repo, w.Path, w.Branch); err != nil {
+			return err
+		}
+	}
+	owner := headOwner(w.Repo, w.Path, w.Branch)
+	head := w.Branch
+	if owner != "" {
+		head = owner + ":" + w.Branch
+	}
+	if state, n, err := client.GetPRStateByBranch(ctx, fullRepo, owner, w.Branch); err == nil && state == "OPEN" {
+		worktree.RecordOpenedPR(w.Path, n)
+		return fmt.Errorf("branch %s already has PR #%d", w.Branch, n)
+	}
+
+	if dirty, _ := worktree.UncommittedChanges(w.Path); len(dirty) > 0 {
+		ui.LogWarn(fmt.Sprintf("%d uncommitted change(s) in %s will not be part of the PR", len(dirty), w.Name))
+	}
+
+	base := cfg.RepoUpstream(w.Repo) + "/" + prCreateBase
+	commits, err := worktree.CommitsBetween(w.Path, base, "HEAD")
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		return fmt.Errorf("no commits on %s that are not on %s", w.Branch, base)
+	}
+	mergeBase, err := worktree.MergeBase(w.Path, base, "HEAD")
+	if err != nil {
+		return err
+	}
+	files, err := worktree.FilesBetween(w.Path, mergeBase, "HEAD")
+	if err != nil {
+		return err
+	}
+	for i, f := range files {
+		_, files[i], _ = strings.Cut(f, "\t")
+	}
+
+	title := prCreateTitle
+	if title == "" {
+		title = w.Branch
+		if len(commits) == 1 {
+			_, title, _ = strings.Cut(commits[0], " ")
+		}
+	}
+
+	steps := ui.NewSteps()
+	if jsonFlag {
+		steps = ui.NewStepLogger(func(string) {})
+	}
+
+	data := ghpkg.PRBodyData{
+		Title:   title,
+		Branch:  w.Branch,
+		Base:    prCreateBase,
+		Commits: commits,
+		Files:   files,
+	}
+	if prCreateSummary {
+		steps.Step("claude summary")
+		data.Summary, err = summarizeDiff(ctx, w.Path, mergeBase)
+		steps.Done(err)
+		if err != nil {
+			return err
+		}
+	}
+	body, err := ghpkg.RenderPRBody(cfg.PRTemplate, data)
+	if err != nil {
+		return err
+	}
+
+	steps.Step(fmt.Sprintf("git push %s %s", worktree.PushRemote(w.Path, w.Branch), w.Branch))
+	err = worktree.PushBranch(w.Path, w.Branch)
+	steps.Done(err)
+	if err != nil {
+		return err
+	}
+
+	steps.Step("create PR")
+	pr, err := client.CreatePR(ctx, fullRepo, ghpkg.NewPR{
+		Title: title,
+		Head:  head,
+		Base:  prCreateBase,
+		Body:  body,
+		Draft: prCreateDraft,
+
+		MaintainerCanModify: owner != "",
+	})
+	steps.Done(err)
+	if err != nil {
+		return err
+	}
+
+	if err := worktree.RecordOpenedPR(w.Path, pr.Number); err != nil {
+		ui.LogWarn(fmt.Sprintf("Could not record PR #%d for %s: %v", pr.Number, w.Name, err))
+	}
+
+	if jsonFlag {
+		printJSON(pr)
+		return nil
+	}
+	ui.LogSuccess(fmt.Sprintf("Opened PR #%d: %s", pr.Number, pr.Title))
+	fmt.Printf("  %s\n", ui.CyanText(pr.URL))
+	return nil
+}
+
+// currentWorktree returns the zen worktree containing the working directory.
+func currentWorktree() (*worktree.Worktree, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if resolved, err := filepath.EvalSymlinks(cwd); err == nil {
+		cwd = resolved
+	}
+	wts, err := worktree.ListAll(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("listing worktrees: %w", err)
+	}
+	for _, w := range wts {
+		path := w.Path
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+		}
+		if cwd == path || strings.HasPrefix(cwd, path+string(filepath.Separator)) {
+			return &w, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not inside a zen worktree -- pass the worktree name", ui.ShortenHome(cwd, homeDir()))
+}
+
+// summarizeDiff asks Claude for a short Markdown summary of the changes
+// between base and HEAD in the worktree at path.
+func summarizeDiff(ctx context.Context, path, base string) (string, error) {
+	diffCmd := exec.Command("git", "diff", base, "HEAD")
+	diffCmd.Dir = path
+	diff, err := diffCmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git diff: %w", err)
+	}
+	if len(diff) > maxSummaryDiff {
+		diff = diff[:maxSummaryDiff]
+	}
+
+	prompt := "Summarize the diff on stdin for a pull request description. " +
+		"Reply with 2-5 Markdown bullet points describing what changed and why, with no preamble."
+	c := exec.CommandContext(ctx, cfg.ClaudeBin, "-p", prompt)
+	c.Dir = path
+	c.Stdin = bytes.NewReader(diff)
+	out, err := c.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s -p: %w", cfg.ClaudeBin, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
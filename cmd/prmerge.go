@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/reconciler"
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var prMergeCmd = &cobra.Command{
+	Use:   "merge <pr-number>",
+	Short: "Merge your approved PR once CI passes, then clean up its worktrees",
+	Long: `Merges one of your PRs through the GitHub API after checking that it is
+safe to: the PR is yours, open and not a draft, approved with no pending
+change requests, every CI check on its head commit passed, and it merges
+cleanly. The merge only goes through if the head is still the commit that
+was checked.
+
+After the merge, the PR's review worktrees and the feature worktree it was
+opened from are queued for cleanup by the watch daemon, which keeps any
+feature worktree with uncommitted or unpushed work. Without the daemon,
+the commands to remove them are printed instead.
+
+Example:
+  zen pr merge 42
+  zen pr merge 42 --squash
+  zen pr merge 42 --dry-run    # Only run the checks`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRMerge,
+}
+
+var (
+	prMergeRepo   string
+	prMergeSquash bool
+	prMergeRebase bool
+	prMergeDryRun bool
+)
+
+func init() {
+	prMergeCmd.Flags().StringVar(&prMergeRepo, "repo", "", "Repository short name or @group (auto-detected if omitted)")
+	prMergeCmd.Flags().BoolVar(&prMergeSquash, "squash", false, "Squash the commits into one")
+	prMergeCmd.Flags().BoolVar(&prMergeRebase, "rebase", false, "Rebase the commits onto the base branch")
+	prMergeCmd.Flags().BoolVar(&prMergeDryRun, "dry-run", false, "Run the checks without merging")
+	prMergeCmd.MarkFlagsMutuallyExclusive("squash", "rebase")
+	prCmd.AddCommand(prMergeCmd)
+}
+
+// prMergeResult is the JSON output of zen pr merge.
+type prMergeResult struct {
+	Repo      string   `json:"repo"`
+	PR        int      `json:"pr"`
+	Method    string   `json:"method"`
+	HeadSHA   string   `json:"head_sha"`
+	Problems  []string `json:"problems"`
+	Merged    bool     `json:"merged"`
+	SHA       string   `json:"sha,omitempty"` // the merge commit
+	Worktrees []string `json:"worktrees,omitempty"`
+	// CleanupQueued is whether the watch daemon will remove Worktrees.
+	CleanupQueued bool `json:"cleanup_queued"`
+}
+
+// mergeableRetries is how many times the PR is fetched again while GitHub
+// is still computing whether it merges cleanly.
+const mergeableRetries = 3
+
+func runPRMerge(cmd *cobra.Command, args []string) error {
+	prNumber, err := parsePRArg(args[0], &prMergeRepo)
+	if err != nil {
+		return err
+	}
+	method := ghpkg.MergeCommit
+	switch {
+	case prMergeSquash:
+		method = ghpkg.MergeSquash
+	case prMergeRebase:
+		method = ghpkg.MergeRebase
+	}
+
+	ctx := context.Background()
+	repo := prMergeRepo
+	if repo == "" || config.IsGroupRef(repo) {
+		detected, err := detectRepoForPR(ctx, prNumber, repo)
+		if err != nil {
+			return err
+		}
+		repo = detected
+	}
+	fullRepo := cfg.RepoFullName(repo)
+
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("creating GitHub client: %w", err)
+	}
+	login, err := ghpkg.GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	// GitHub computes mergeability in the background after a push
+	s, err := client.GetMergeState(ctx, fullRepo, prNumber)
+	for i := 0; err == nil && s.Mergeable == nil && i < mergeableRetries; i++ {
+		time.Sleep(2 * time.Second)
+		s, err = client.GetMergeState(ctx, fullRepo, prNumber)
+	}
+	if err != nil {
+		return err
+	}
+
+	res := prMergeResult{Repo: fullRepo, PR: prNumber, Method: method, HeadSHA: s.HeadSHA, Problems: s.Problems(login)}
+	if res.Problems == nil {
+		res.Problems = []string{}
+	}
+	if !jsonFlag {
+		displayMergeState(fullRepo, s, res.Problems)
+	}
+	if len(res.Problems) > 0 {
+		if jsonFlag {
+			printJSON(res)
+		}
+		return fmt.Errorf("not merging PR #%d: %d problem(s)", prNumber, len(res.Problems))
+	}
+	if prMergeDryRun {
+		if jsonFlag {
+			printJSON(res)
+		} else {
+			ui.LogSuccess(fmt.Sprintf("PR #%d is ready to merge (dry run)", prNumber))
+		}
+		return nil
+	}
+
+	if res.SHA, err = client.MergePR(ctx, fullRepo, prNumber, method, s.HeadSHA); err != nil {
+		return err
+	}
+	res.Merged = true
+	if !jsonFlag {
+		ui.LogSuccess(fmt.Sprintf("Merged PR #%d (%s) as %s", prNumber, method, ui.Truncate(res.SHA, 10)))
+	}
+
+	reviews, features := mergedPRWorktrees(repo, prNumber, s.HeadRef)
+	for _, w := range append(reviews, features...) {
+		res.Worktrees = append(res.Worktrees, w.Path)
+	}
+	if len(res.Worktrees) > 0 && reconciler.DaemonRunning() {
+		req := reconciler.CleanupRequest{Repo: repo, PRNumber: prNumber}
+		for _, w := range features {
+			req.Paths = append(req.Paths, w.Path)
+		}
+		if err := reconciler.RequestCleanup(req); err != nil {
+			ui.LogWarn(fmt.Sprintf("Could not queue the cleanup: %v", err))
+		} else {
+			res.CleanupQueued = true
+		}
+	}
+
+	if jsonFlag {
+		printJSON(res)
+		return nil
+	}
+	switch {
+	case len(res.Worktrees) == 0:
+	case res.CleanupQueued:
+		ui.LogInfo(fmt.Sprintf("Queued %d worktree(s) for cleanup by the watch daemon", len(res.Worktrees)))
+	default:
+		ui.LogInfo("The watch daemon is not running; to remove the PR's worktrees:")
+		if len(reviews) > 0 {
+			ui.Hint(fmt.Sprintf("  zen review delete %d", prNumber))
+		}
+		for _, w := range features {
+			ui.Hint("  zen work delete " + w.Name)
+		}
+	}
+	return nil
+}
+
+// mergedPRWorktrees returns the local review worktrees of a PR and the
+// feature worktrees it was opened from, recorded by zen pr create or found
+// by its head branch.
+func mergedPRWorktrees(repo string, prNumber int, headRef string) (reviews, features []wt.Worktree) {
+	wts, err := wt.ListForRepo(cfg, repo)
+	if err != nil {
+		ui.LogWarn(fmt.Sprintf("Listing worktrees: %v", err))
+		return nil, nil
+	}
+	for _, w := range wts {
+		switch {
+		case w.Type == wt.TypePRReview && w.PRNumber == prNumber:
+			reviews = append(reviews, w)
+		case w.Type == wt.TypeFeature && (w.OpenedPR == prNumber || (headRef != "" && w.Branch == headRef)):
+			features = append(features, w)
+		}
+	}
+	return reviews, features
+}
+
+func displayMergeState(fullRepo string, s *ghpkg.MergeState, problems []string) {
+	pending, passed, failed := ghpkg.SummarizeChecks(s.Checks)
+
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("PR #%d — %s", s.Number, ui.YellowText(fullRepo))))
+	ui.Hint(s.Title)
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	approvals := "none"
+	if len(s.Approvals) > 0 {
+		approvals = "@" + strings.Join(s.Approvals, ", @")
+	}
+	fmt.Printf("  %-12s %s\n", "Approved by", approvals)
+	fmt.Printf("  %-12s %d passed, %d failed, %d pending\n", "Checks", passed, failed, pending)
+	mergeable := s.MergeableState
+	if mergeable == "" {
+		mergeable = "unknown"
+	}
+	fmt.Printf("  %-12s %s\n", "Mergeable", mergeable)
+	fmt.Println()
+	for _, p := range problems {
+		ui.LogError(p)
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/mgreau/zen/internal/config"
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/prcache"
+	"github.com/mgreau/zen/internal/terminal"
+	"github.com/mgreau/zen/internal/ui"
+)
+
+// reviewPrompt returns the initial prompt of a new review session for a
+// PR in dir: the prompt of the worktree's playbook, else the repo's
+// review_prompt (/review-pr by default), or "" when the PR's cached labels
+// include a labels.no_ai_review label.
+func reviewPrompt(repo string, pr int, dir string) string {
+	data := config.PromptData{
+		Repo:     repo,
+		FullName: cfg.RepoFullName(repo),
+		PR:       pr,
+		Name:     filepath.Base(dir),
+		Worktree: dir,
+	}
+	if meta, ok := prcache.Get(repo, pr); ok {
+		if cfg.Labels.SkipsAIReview(meta.Labels.Names()) {
+			return ""
+		}
+		data.Author = meta.Author
+	}
+	if name := ctxpkg.RecordedPlaybook(dir); name != "" {
+		if p, ok := cfg.GetPlaybook(name); ok && p.Prompt != "" {
+			return renderPrompt(fmt.Sprintf("playbook %q prompt", name), p.Prompt, data)
+		}
+	}
+	return renderPrompt("review_prompt", cfg.RepoReviewPrompt(repo), data)
+}
+
+// checkPlaybook returns an error when name is not a playbook.
+func checkPlaybook(name string) error {
+	if _, ok := cfg.GetPlaybook(name); !ok {
+		return fmt.Errorf("unknown playbook %q: must be one of %s", name, strings.Join(cfg.PlaybookNames(), ", "))
+	}
+	return nil
+}
+
+// preparePlaybook sets the review playbook of the PR worktree in dir,
+// writing its instructions to CLAUDE.local.md: name when given (zen review
+// --playbook), else, when the worktree has none yet, the playbook matching
+// the PR's cached labels. Returns the worktree's playbook, "" for none.
+func preparePlaybook(repo string, pr int, dir, name string) (string, error) {
+	recorded := ctxpkg.RecordedPlaybook(dir)
+	if name == "" {
+		if recorded != "" {
+			return recorded, nil
+		}
+		meta, ok := prcache.Get(repo, pr)
+		if !ok {
+			return "", nil
+		}
+		if name = cfg.PlaybookForLabels(meta.Labels.Names()); name == "" {
+			return "", nil
+		}
+	}
+	p, ok := cfg.GetPlaybook(name)
+	if !ok {
+		return recorded, checkPlaybook(name)
+	}
+	if err := ctxpkg.SetPlaybook(dir, name, p.Instructions); err != nil {
+		return recorded, err
+	}
+	return name, nil
+}
+
+// featurePrompt returns the initial prompt of a new session in a feature
+// worktree: the repo's feature_prompt, or "" for a plain session.
+func featurePrompt(repo, dir, branch string) string {
+	return renderPrompt("feature_prompt", cfg.RepoFeaturePrompt(repo), config.PromptData{
+		Repo:     repo,
+		FullName: cfg.RepoFullName(repo),
+		Branch:   branch,
+		Name:     filepath.Base(dir),
+		Worktree: dir,
+	})
+}
+
+// renderPrompt expands a configured prompt, falling back to its text
+// when it does not render. Templates are checked when the config loads,
+// so this is unlikely.
+func renderPrompt(key, prompt string, data config.PromptData) string {
+	out, err := config.RenderPrompt(prompt, data)
+	if err != nil {
+		ui.LogWarn(fmt.Sprintf("Rendering %s: %v", key, err))
+		return prompt
+	}
+	return out
+}
+
+// promptCommand returns the name of the zen Claude command a prompt
+// starts with, e.g. "review-pr" for "/review-pr 42", or "" when it starts
+// with none.
+func promptCommand(prompt string) string {
+	fields := strings.Fields(prompt)
+	if len(fields) == 0 {
+		return ""
+	}
+	name, ok := strings.CutPrefix(fields[0], "/")
+	if !ok {
+		return ""
+	}
+	if _, err := fs.Stat(EmbeddedCommands, filepath.Join("commands", name+".md")); err != nil {
+		return ""
+	}
+	return name
+}
+
+// openClaudeTab opens a tab in t running claude in dir, starting with
+// prompt, or a plain session when prompt is "".
+func openClaudeTab(t terminal.Terminal, dir, prompt, claudeCmd, model string) error {
+	if prompt != "" {
+		return t.OpenTabWithClaude(dir, prompt, claudeCmd, model)
+	}
+	if model != "" {
+		claudeCmd += fmt.Sprintf(" --model %s", model)
+	}
+	return t.OpenTab(dir, claudeCmd)
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var prSuggestReviewersCmd = &cobra.Command{
+	Use:   "suggest-reviewers <pr-number>",
+	Short: "Rank reviewer candidates by who last changed the lines a PR touches",
+	Long: `Runs git blame on the lines a PR rewrites or deletes, in the PR's local
+review worktree, and ranks the authors of those lines as reviewer
+candidates. Each line counts less the older it is (half as much after six
+months), so people who changed the code recently rank first.
+
+Use it to delegate a review, or to confirm you are the right reviewer: you
+are marked in the list. The PR author is left out when their GitHub login
+can be matched to a commit email.
+
+The PR needs a review worktree (zen review <pr-number>). Lines added in
+new files have no history and are not counted.
+
+Example:
+  zen pr suggest-reviewers 42
+  zen pr suggest-reviewers 42 --limit 10`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRSuggestReviewers,
+}
+
+var prSuggestLimit int
+
+func init() {
+	prSuggestReviewersCmd.Flags().IntVarP(&prSuggestLimit, "limit", "n", 5, "Number of candidates to show")
+	prCmd.AddCommand(prSuggestReviewersCmd)
+}
+
+// reviewerCandidate is a ranked author in zen pr suggest-reviewers.
+type reviewerCandidate struct {
+	worktree.AuthorScore
+	You bool `json:"you,omitempty"`
+}
+
+// suggestReviewersResult is the JSON output of zen pr suggest-reviewers.
+type suggestReviewersResult struct {
+	Repo           string              `json:"repo"`
+	PR             int                 `json:"pr"`
+	Base           string              `json:"base"`
+	Files          int                 `json:"files"`
+	Lines          int                 `json:"lines"`
+	ExcludedAuthor string              `json:"excluded_author,omitempty"`
+	Candidates     []reviewerCandidate `json:"candidates"`
+}
+
+func runPRSuggestReviewers(cmd *cobra.Command, args []string) error {
+	prNumber, err := parsePRArg(args[0], nil)
+	if err != nil {
+		return err
+	}
+
+	w, err := findWorktreeByPR(prNumber, "")
+	if err != nil {
+		var nwErr *noWorktreeError
+		if errors.As(err, &nwErr) {
+			return fmt.Errorf("%w -- create one with: zen review %d --no-terminal", err, prNumber)
+		}
+		return err
+	}
+
+	base, err := worktree.MergeBase(w.Path, worktree.RemoteDefaultBranch(w.Path, cfg.RepoUpstream(w.Repo)), "HEAD")
+	if err != nil {
+		return fmt.Errorf("finding the PR's base: %w", err)
+	}
+	changed, err := worktree.ChangedLines(w.Path, base, "HEAD")
+	if err != nil {
+		return fmt.Errorf("diffing the PR: %w", err)
+	}
+
+	files := make([]string, 0, len(changed))
+	for f := range changed {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+
+	var (
+		mu    sync.Mutex
+		lines []worktree.BlameLine
+	)
+	g := new(errgroup.Group)
+	g.SetLimit(5)
+	for _, f := range files {
+		g.Go(func() error {
+			bl, err := worktree.Blame(w.Path, base, f, changed[f])
+			if err != nil {
+				ui.LogDebug(fmt.Sprintf("blame %s: %v", f, err))
+				return nil
+			}
+			mu.Lock()
+			lines = append(lines, bl...)
+			mu.Unlock()
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	result := suggestReviewersResult{
+		Repo:  w.Repo,
+		PR:    prNumber,
+		Base:  base,
+		Files: len(files),
+		Lines: len(lines),
+	}
+
+	// The author can't review their own PR; GitHub noreply addresses carry
+	// the login, other addresses can't be matched.
+	author := ""
+	if client, err := ghpkg.NewClient(context.Background()); err == nil {
+		author, _ = client.GetPRAuthor(context.Background(), cfg.RepoFullName(w.Repo), prNumber)
+	}
+	me := strings.ToLower(worktree.UserEmail(w.Path))
+	for _, a := range worktree.RankAuthors(lines, time.Now()) {
+		if author != "" && noreplyLogin(a.Email) == strings.ToLower(author) {
+			result.ExcludedAuthor = a.Email
+			continue
+		}
+		result.Candidates = append(result.Candidates, reviewerCandidate{
+			AuthorScore: a,
+			You:         me != "" && strings.ToLower(a.Email) == me,
+		})
+	}
+	if prSuggestLimit > 0 && len(result.Candidates) > prSuggestLimit {
+		// Keep yourself in the list so you can see where you rank
+		top := result.Candidates[:prSuggestLimit]
+		for _, c := range result.Candidates[prSuggestLimit:] {
+			if c.You {
+				top = append(top, c)
+			}
+		}
+		result.Candidates = top
+	}
+
+	if jsonFlag {
+		printJSON(result)
+		return nil
+	}
+
+	fmt.Println()
+	ui.SectionHeader(fmt.Sprintf("Reviewer Candidates for %s PR #%d", w.Repo, prNumber))
+	if len(result.Candidates) == 0 {
+		fmt.Println("  No history for the changed lines (new files only?)")
+		fmt.Println()
+		return nil
+	}
+	fmt.Printf("  Blamed %d line(s) in %d file(s) at %s\n\n", result.Lines, result.Files, shortSHA(base))
+	fmt.Printf("  %-4s  %-32s  %-6s  %-6s  %-10s  %s\n", "RANK", "AUTHOR", "LINES", "FILES", "LAST", "SCORE")
+	fmt.Printf("  %-4s  %-32s  %-6s  %-6s  %-10s  %s\n", "────", "────────────────────────────────", "──────", "──────", "──────────", "─────")
+	for i, c := range result.Candidates {
+		name := fmt.Sprintf("%s <%s>", c.Name, c.Email)
+		rank := fmt.Sprintf("%d", i+1)
+		if c.You {
+			name = ui.GreenText(fmt.Sprintf("%-32s", ui.Truncate("(you) "+name, 32)))
+		} else {
+			name = fmt.Sprintf("%-32s", ui.Truncate(name, 32))
+		}
+		fmt.Printf("  %-4s  %s  %-6d  %-6d  %-10s  %.2f\n",
+			rank, name, c.Lines, c.Files, session.FormatAge(c.LastTouched), c.Score)
+	}
+	fmt.Println()
+	if result.ExcludedAuthor != "" {
+		ui.Hint(fmt.Sprintf("PR author %s (%s) left out", author, result.ExcludedAuthor))
+	}
+	ui.Hint("Score: lines weighted by age (a line counts half after six months)")
+	fmt.Println()
+	return nil
+}
+
+// noreplyLogin returns the GitHub login of a users.noreply.github.com
+// address ("123+login@..." or "login@..."), or "".
+func noreplyLogin(email string) string {
+	local, domain, ok := strings.Cut(strings.ToLower(email), "@")
+	if !ok || domain != "users.noreply.github.com" {
+		return ""
+	}
+	if _, login, ok := strings.Cut(local, "+"); ok {
+		return login
+	}
+	return local
+}
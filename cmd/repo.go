@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var repoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "Manage configured repositories",
+}
+
+var repoAddCmd = &cobra.Command{
+	Use:   "add <path>",
+	Short: "Add an existing clone to the config, detecting its GitHub repo",
+	Long: `Adds a repo entry to ~/.zen/config.yaml from an existing clone.
+
+full_name is read from the clone's origin remote (SSH or HTTPS URL),
+base_path is the clone's parent directory (where worktrees are created),
+and the short name is the clone's directory name, as zen expects the
+clone at <base_path>/<short name>.
+
+Example:
+  zen repo add ~/git/repo-mono/mono`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRepoAdd,
+}
+
+func init() {
+	repoCmd.AddCommand(repoAddCmd)
+	rootCmd.AddCommand(repoCmd)
+}
+
+// repoAddResult is the JSON output of zen repo add.
+type repoAddResult struct {
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	BasePath string `json:"base_path"`
+}
+
+func runRepoAdd(cmd *cobra.Command, args []string) error {
+	clonePath, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(filepath.Join(clonePath, ".git"))
+	if err != nil {
+		return fmt.Errorf("%s is not a git clone", clonePath)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is a worktree -- pass the main clone instead", clonePath)
+	}
+
+	fullName, err := cloneFullName(clonePath)
+	if err != nil {
+		return err
+	}
+
+	short := filepath.Base(clonePath)
+	for name, r := range cfg.Repos {
+		if strings.EqualFold(r.FullName, fullName) {
+			return fmt.Errorf("%s is already configured as %q", fullName, name)
+		}
+	}
+
+	home := homeDir()
+	basePath := ui.ShortenHome(filepath.Dir(clonePath), home)
+	if err := config.AddRepo(short, fullName, basePath); err != nil {
+		return err
+	}
+
+	if jsonFlag {
+		printJSON(repoAddResult{Name: short, FullName: fullName, BasePath: basePath})
+		return nil
+	}
+
+	fmt.Println()
+	ui.LogSuccess(fmt.Sprintf("Added repo %s to %s", ui.BoldText(short), ui.ShortenHome(config.Path(), home)))
+	fmt.Printf("  full_name: %s\n", fullName)
+	fmt.Printf("  base_path: %s\n", basePath)
+	ui.Hint(fmt.Sprintf("Review PRs with: zen review <number> --repo %s", short))
+	fmt.Println()
+	return nil
+}
+
+// cloneFullName returns the GitHub owner/repo of a clone's origin remote.
+func cloneFullName(clonePath string) (string, error) {
+	out, err := exec.Command("git", "-C", clonePath, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "", fmt.Errorf("reading origin remote of %s: %w", clonePath, err)
+	}
+	return config.ParseRemoteURL(string(out))
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/mgreau/zen/internal/config"
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var resetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Stop the daemon and remove zen state to start over",
+	Long: `Resets zen to a clean slate.
+
+  zen reset            Stop the daemon and remove ~/.zen/state (same as --state)
+  zen reset --all      Also remove installed Claude commands and PR review worktrees
+
+Everything that will be removed is listed before asking for confirmation.
+Feature worktrees (zen work), ~/.zen/config.yaml and the archived review
+notes in ~/.zen/state/notes are always kept; they may hold unpushed work,
+findings or settings you want to reuse. The notes of removed review
+worktrees are archived there first.`,
+	Args: cobra.NoArgs,
+	RunE: runReset,
+}
+
+var (
+	resetState bool
+	resetAll   bool
+	resetForce bool
+)
+
+func init() {
+	resetCmd.Flags().BoolVar(&resetState, "state", false, "Remove daemon state files (default)")
+	resetCmd.Flags().BoolVar(&resetAll, "all", false, "Also remove installed Claude commands and PR review worktrees")
+	resetCmd.Flags().BoolVarP(&resetForce, "force", "f", false, "Skip confirmation")
+	resetCmd.MarkFlagsMutuallyExclusive("state", "all")
+	rootCmd.AddCommand(resetCmd)
+}
+
+func runReset(cmd *cobra.Command, args []string) error {
+	home := homeDir()
+	stateDir := config.StateDir()
+
+	// Archived review notes are findings, not daemon state
+	notesDir := ctxpkg.NotesArchiveDir()
+	var stateFiles []string
+	if entries, err := os.ReadDir(stateDir); err == nil {
+		for _, e := range entries {
+			if filepath.Join(stateDir, e.Name()) != notesDir {
+				stateFiles = append(stateFiles, e.Name())
+			}
+		}
+	}
+
+	var commandFiles []string
+	var reviewWorktrees []wt.Worktree
+	if resetAll {
+		commandFiles = installedClaudeCommands()
+
+		// Config may be missing or broken when starting over; worktrees
+		// can only be discovered when it loads.
+		if cfg == nil {
+			ui.LogWarn("Config not loaded -- PR review worktrees will not be removed")
+		} else {
+			all, _ := wt.ListAll(cfg)
+			for _, w := range all {
+				if w.Type == wt.TypePRReview {
+					reviewWorktrees = append(reviewWorktrees, w)
+				}
+			}
+		}
+	}
+
+	running, pid := watchIsRunning()
+
+	if !running && len(stateFiles) == 0 && len(commandFiles) == 0 && len(reviewWorktrees) == 0 {
+		fmt.Println("Nothing to reset.")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(ui.BoldText("zen reset will:"))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	if running {
+		fmt.Printf("  Stop watch daemon (PID: %d)\n", pid)
+	}
+	if len(stateFiles) > 0 {
+		fmt.Printf("  Remove %d file(s) from %s\n", len(stateFiles), ui.ShortenHome(stateDir, home))
+		for _, f := range stateFiles {
+			fmt.Printf("    %s\n", ui.DimText(f))
+		}
+	}
+	if len(commandFiles) > 0 {
+		fmt.Printf("  Remove %d Claude command(s)\n", len(commandFiles))
+		for _, f := range commandFiles {
+			fmt.Printf("    %s\n", ui.DimText(ui.ShortenHome(f, home)))
+		}
+	}
+	if len(reviewWorktrees) > 0 {
+		fmt.Printf("  Remove %d PR review worktree(s)\n", len(reviewWorktrees))
+		for _, w := range reviewWorktrees {
+			fmt.Printf("    %s\n", ui.DimText(ui.ShortenHome(w.Path, home)))
+		}
+		fmt.Printf("  Archive their notes in %s\n", ui.ShortenHome(notesDir, home))
+	} else if _, err := os.Stat(notesDir); err == nil {
+		fmt.Printf("  Keep the archived review notes in %s\n", ui.ShortenHome(notesDir, home))
+	}
+	fmt.Println()
+
+	if !resetForce {
+		fmt.Print("  Confirm [y/N]: ")
+		var resp string
+		fmt.Scanln(&resp)
+		if resp != "y" && resp != "Y" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	if running {
+		if err := watchStop(); err != nil {
+			return err
+		}
+	}
+
+	var failed int
+	for _, w := range reviewWorktrees {
+		originPath := filepath.Join(cfg.RepoBasePath(w.Repo), w.Repo)
+		if _, err := ctxpkg.ArchiveNotes(w.Path); err != nil {
+			ui.LogWarn(fmt.Sprintf("Could not archive the notes of %s: %v", w.Name, err))
+		}
+		if out, err := wt.Remove(originPath, w.Path, "reset"); err != nil {
+			ui.LogError(fmt.Sprintf("Failed to remove %s: %s", w.Name, out))
+			failed++
+			continue
+		}
+		ctxpkg.ForgetContext(w.Path)
+		ui.LogInfo(fmt.Sprintf("Removed worktree %s", w.Name))
+		deleted, err := removeBranch(originPath, w)
+		reportBranchRemoval(w, deleted, err)
+	}
+
+	for _, f := range commandFiles {
+		if err := os.Remove(f); err != nil {
+			ui.LogError(fmt.Sprintf("Failed to remove %s: %v", f, err))
+			failed++
+		}
+	}
+
+	for _, f := range stateFiles {
+		if err := os.RemoveAll(filepath.Join(stateDir, f)); err != nil {
+			return fmt.Errorf("removing %s: %w", filepath.Join(stateDir, f), err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("reset incomplete: %d item(s) could not be removed", failed)
+	}
+
+	ui.LogSuccess("zen has been reset")
+	ui.Hint(fmt.Sprintf("Config kept at %s -- run 'zen setup' to recreate it, or delete it to uninstall.", ui.ShortenHome(config.Path(), home)))
+	return nil
+}
+
+// installedClaudeCommands returns the paths of embedded Claude commands that
+// are currently installed in Claude's commands directory.
+func installedClaudeCommands() []string {
+	entries, err := fs.ReadDir(EmbeddedCommands, "commands")
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		dst := filepath.Join(session.ClaudeDir(), "commands", e.Name())
+		if _, err := os.Stat(dst); err == nil {
+			paths = append(paths, dst)
+		}
+	}
+	return paths
+}
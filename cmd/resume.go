@@ -4,7 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strconv"
+	"sort"
 	"strings"
 
 	"github.com/mgreau/zen/internal/session"
@@ -16,7 +16,7 @@ import (
 
 // resumeFlags holds the shared flags for resume subcommands.
 var (
-	resumeSession int
+	resumeSession string
 	resumeList    bool
 	resumeNoITerm bool
 	resumeModel   string
@@ -24,9 +24,15 @@ var (
 
 // resumeWorktree handles the core resume logic for a matched worktree.
 func resumeWorktree(wt worktree.Worktree, cmdName string, t terminal.Terminal) error {
+	// The print terminal is --no-terminal by configuration
+	if _, ok := t.(*terminal.PrintTerminal); ok {
+		resumeNoITerm = true
+	}
+
 	// Find Claude sessions
 	sessions, err := session.FindSessions(wt.Path)
 	noSessions := err != nil || len(sessions) == 0
+	session.ApplyNames(sessions)
 
 	// JSON output
 	if jsonFlag {
@@ -59,12 +65,16 @@ func resumeWorktree(wt worktree.Worktree, cmdName string, t terminal.Terminal) e
 				if i == 0 {
 					marker = " " + ui.GreenText("(most recent)")
 				}
+				if s.Name != "" {
+					marker = " " + ui.BoldText(s.Name) + marker
+				}
 				fmt.Printf("  %s %s%s\n", ui.BoldText(fmt.Sprintf("[%d]", i+1)), ui.CyanText(s.ID), marker)
 				fmt.Printf("      %s\n", ui.DimText(fmt.Sprintf("Modified: %s  Size: %s", s.ModHuman, s.SizeStr)))
 			}
 		}
 		fmt.Println()
-		ui.Hint(fmt.Sprintf("Resume with: %s --session N", cmdName))
+		ui.Hint(fmt.Sprintf("Resume with: %s --session N|NAME", cmdName))
+		ui.Hint("Name a session with: zen agent name <worktree> N <name>")
 		fmt.Println()
 		return nil
 	}
@@ -74,28 +84,23 @@ func resumeWorktree(wt worktree.Worktree, cmdName string, t terminal.Terminal) e
 		return openNewSession(wt, t)
 	}
 
-	// Pick session
-	targetIdx := 0
-	if resumeSession > 0 {
-		targetIdx = resumeSession - 1
-		if targetIdx >= len(sessions) || targetIdx < 0 {
-			return fmt.Errorf("session index %d out of range (1-%d)", resumeSession, len(sessions))
-		}
+	s, err := session.Pick(sessions, resumeSession)
+	if err != nil {
+		return err
 	}
-
-	s := sessions[targetIdx]
 	home := os.Getenv("HOME")
 	shortPath := ui.ShortenHome(wt.Path, home)
+	claudeCmd, model := claudeCommand(wt.Repo, wt.Path, resumeModel)
 
 	// No-iTerm mode
 	if resumeNoITerm {
 		fmt.Println()
 		fmt.Println(ui.BoldText("Resume command:"))
 		modelFlag := ""
-		if resumeModel != "" {
-			modelFlag = fmt.Sprintf(" --model %s", resumeModel)
+		if model != "" {
+			modelFlag = fmt.Sprintf(" --model %s", model)
 		}
-		fmt.Printf("  cd %s && %s%s --resume %s\n", wt.Path, cfg.ClaudeBin, modelFlag, s.ID)
+		fmt.Printf("  cd %s && %s%s --resume %s\n", wt.Path, claudeCmd, modelFlag, s.ID)
 		fmt.Println()
 		fmt.Println(ui.DimText(fmt.Sprintf("Worktree: %s", shortPath)))
 		fmt.Println(ui.DimText(fmt.Sprintf("Session:  %s (%s)", s.ModHuman, s.SizeStr)))
@@ -104,53 +109,68 @@ func resumeWorktree(wt worktree.Worktree, cmdName string, t terminal.Terminal) e
 
 	// Open in terminal
 	fmt.Println()
-	fmt.Println(ui.BoldText(fmt.Sprintf("Resuming Claude session in new %s tab", t.Name())))
+	fmt.Println(ui.BoldText(fmt.Sprintf("Resuming Claude session in %s", sessionPlace(t))))
 	fmt.Printf("  Worktree: %s\n", ui.CyanText(wt.Name))
 	fmt.Printf("  Path:     %s\n", ui.DimText(shortPath))
-	fmt.Printf("  Session:  %s\n", ui.DimText(s.ID))
+	if s.Name != "" {
+		fmt.Printf("  Session:  %s %s\n", ui.BoldText(s.Name), ui.DimText(s.ID))
+	} else {
+		fmt.Printf("  Session:  %s\n", ui.DimText(s.ID))
+	}
 	fmt.Printf("  Modified: %s\n", ui.DimText(fmt.Sprintf("%s (%s)", s.ModHuman, s.SizeStr)))
-	if resumeModel != "" {
-		fmt.Printf("  Model:    %s\n", ui.CyanText(resumeModel))
+	if model != "" {
+		fmt.Printf("  Model:    %s\n", ui.CyanText(model))
 	}
 	fmt.Println()
 
-	if err := t.OpenTabWithResume(wt.Path, s.ID, cfg.ClaudeBin, resumeModel); err != nil {
+	if err := t.OpenTabWithResume(wt.Path, s.ID, claudeCmd, model); err != nil {
 		return fmt.Errorf("opening %s tab: %w", t.Name(), err)
 	}
 
-	ui.LogSuccess(fmt.Sprintf("%s tab opened", t.Name()))
+	logTabOpened(t)
 	return nil
 }
 
 // openNewSession starts a new Claude session in a new terminal tab.
-// For PR worktrees, it starts with /review-pr. For others, it starts plain claude.
+// For PR worktrees, it starts with the prompt of the review playbook or
+// the repo's review_prompt (/review-pr by default) unless the PR has a
+// no-ai-review label. For others, it starts with the repo's
+// feature_prompt, if any.
 func openNewSession(wt worktree.Worktree, t terminal.Terminal) error {
 	home := os.Getenv("HOME")
 	shortPath := ui.ShortenHome(wt.Path, home)
 
-	initialPrompt := "/review-pr"
+	var initialPrompt string
 	action := "Starting PR review"
 	if wt.Type != worktree.TypePRReview {
-		initialPrompt = ""
+		initialPrompt = featurePrompt(wt.Repo, wt.Path, wt.Branch)
 		action = "Starting new session"
 	} else {
-		// Ensure /review-pr command is installed
-		if err := ensureClaudeCommand("review-pr"); err != nil {
-			ui.LogInfo(fmt.Sprintf("Warning: could not install /review-pr command: %v", err))
+		if _, err := preparePlaybook(wt.Repo, wt.PRNumber, wt.Path, ""); err != nil {
+			ui.LogWarn(fmt.Sprintf("Failed to set the review playbook: %v", err))
+		}
+		if initialPrompt = reviewPrompt(wt.Repo, wt.PRNumber, wt.Path); initialPrompt == "" {
+			action = "Starting new session (no-ai-review label)"
+		}
+	}
+	if name := promptCommand(initialPrompt); name != "" {
+		if err := ensureClaudeCommand(name); err != nil {
+			ui.LogInfo(fmt.Sprintf("Warning: could not install /%s command: %v", name, err))
 		}
 	}
 
+	claudeCmd, model := claudeCommand(wt.Repo, wt.Path, resumeModel)
 	if resumeNoITerm {
 		fmt.Println()
 		fmt.Println(ui.BoldText("Start command:"))
 		modelFlag := ""
-		if resumeModel != "" {
-			modelFlag = fmt.Sprintf(" --model %s", resumeModel)
+		if model != "" {
+			modelFlag = fmt.Sprintf(" --model %s", model)
 		}
 		if initialPrompt != "" {
-			fmt.Printf("  cd %s && %s%s %q\n", wt.Path, cfg.ClaudeBin, modelFlag, initialPrompt)
+			fmt.Printf("  cd %s && %s%s %q\n", wt.Path, claudeCmd, modelFlag, initialPrompt)
 		} else {
-			fmt.Printf("  cd %s && %s%s\n", wt.Path, cfg.ClaudeBin, modelFlag)
+			fmt.Printf("  cd %s && %s%s\n", wt.Path, claudeCmd, modelFlag)
 		}
 		fmt.Println()
 		fmt.Println(ui.DimText(fmt.Sprintf("Worktree: %s", shortPath)))
@@ -158,53 +178,89 @@ func openNewSession(wt worktree.Worktree, t terminal.Terminal) error {
 	}
 
 	fmt.Println()
-	fmt.Println(ui.BoldText(fmt.Sprintf("%s in new %s tab", action, t.Name())))
+	fmt.Println(ui.BoldText(fmt.Sprintf("%s in %s", action, sessionPlace(t))))
 	fmt.Printf("  Worktree: %s\n", ui.CyanText(wt.Name))
 	fmt.Printf("  Path:     %s\n", ui.DimText(shortPath))
-	if resumeModel != "" {
-		fmt.Printf("  Model:    %s\n", ui.CyanText(resumeModel))
+	if model != "" {
+		fmt.Printf("  Model:    %s\n", ui.CyanText(model))
 	}
 	fmt.Println()
 
-	var err error
-	if initialPrompt != "" {
-		err = t.OpenTabWithClaude(wt.Path, initialPrompt, cfg.ClaudeBin, resumeModel)
-	} else {
-		cmd := cfg.ClaudeBin
-		if resumeModel != "" {
-			cmd += fmt.Sprintf(" --model %s", resumeModel)
-		}
-		err = t.OpenTab(wt.Path, cmd)
-	}
-	if err != nil {
+	if err := openClaudeTab(t, wt.Path, initialPrompt, claudeCmd, model); err != nil {
 		return fmt.Errorf("opening %s tab: %w", t.Name(), err)
 	}
 
-	ui.LogSuccess(fmt.Sprintf("%s tab opened", t.Name()))
+	logTabOpened(t)
 	return nil
 }
 
-// findWorktreeByPR finds a PR review worktree by PR number.
-func findWorktreeByPR(prNumber int) (*worktree.Worktree, error) {
+// claudeCommand returns the claude invocation for a session in workDir,
+// a worktree of repo, with the configured launch options, and the model to
+// start it with: the --model flag if given, else the configured model.
+func claudeCommand(repo, workDir, modelFlag string) (string, string) {
+	launch := cfg.RepoClaude(repo)
+	model := modelFlag
+	if model == "" {
+		model = launch.Model
+	}
+	return launch.Command(cfg.ClaudeBin, workDir), model
+}
+
+// findWorktreeByPR finds a PR review worktree by PR number and suffix.
+// An empty suffix picks the PR's main review worktree, or another checkout
+// of the PR when there is no main one.
+func findWorktreeByPR(prNumber int, suffix string) (*worktree.Worktree, error) {
+	wts, err := prWorktrees(prNumber)
+	if err != nil {
+		var nwErr *noWorktreeError
+		if errors.As(err, &nwErr) {
+			nwErr.suffix = suffix
+		}
+		return nil, err
+	}
+
+	for _, wt := range wts {
+		if wt.Suffix == suffix {
+			return &wt, nil
+		}
+	}
+	if suffix == "" {
+		return &wts[0], nil
+	}
+	return nil, &noWorktreeError{prNumber: prNumber, suffix: suffix}
+}
+
+// prWorktrees returns every PR review worktree for a PR number, the main
+// one first.
+func prWorktrees(prNumber int) ([]worktree.Worktree, error) {
 	wts, err := worktree.ListAll(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("listing worktrees: %w", err)
 	}
 
+	var matches []worktree.Worktree
 	for _, wt := range wts {
-		if wt.Type == worktree.TypePRReview && wt.PRNumber == prNumber {
-			return &wt, nil
+		if wt.Type == worktree.TypePRReview && wt.PRNumber == prNumber && (prArgRepo == "" || wt.Repo == prArgRepo) {
+			matches = append(matches, wt)
 		}
 	}
-	return nil, &noWorktreeError{prNumber: prNumber}
+	if len(matches) == 0 {
+		return nil, &noWorktreeError{prNumber: prNumber}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Suffix < matches[j].Suffix })
+	return matches, nil
 }
 
 // noWorktreeError is returned when no worktree exists for a PR.
 type noWorktreeError struct {
 	prNumber int
+	suffix   string
 }
 
 func (e *noWorktreeError) Error() string {
+	if e.suffix != "" {
+		return fmt.Sprintf("no PR review worktree for #%d named %q", e.prNumber, e.suffix)
+	}
 	return fmt.Sprintf("no PR review worktree for #%d", e.prNumber)
 }
 
@@ -246,22 +302,75 @@ func findWorktreeByName(term string) (*worktree.Worktree, error) {
 	return &matches[0], nil
 }
 
-// addResumeFlags adds the shared --session, --list, --no-iterm, --model flags to a cobra command.
+// addResumeFlags adds the shared --session, --list, --no-iterm, --model, --terminal, --pair flags to a cobra command.
 func addResumeFlags(cmd *cobra.Command) {
-	cmd.Flags().IntVarP(&resumeSession, "session", "s", 0, "Resume Nth session instead of most recent (1-based)")
+	cmd.Flags().StringVarP(&resumeSession, "session", "s", "", "Session to resume instead of the most recent: position in --list (1-based), name, or ID prefix")
 	cmd.Flags().BoolVarP(&resumeList, "list", "l", false, "List available sessions without resuming")
 	cmd.Flags().BoolVar(&resumeNoITerm, "no-terminal", false, "Print the resume command instead of opening terminal")
 	cmd.Flags().StringVarP(&resumeModel, "model", "m", "", "Claude model to use (e.g., sonnet, opus, haiku)")
+	addTerminalFlag(cmd)
+	addPairFlag(cmd)
+}
+
+// terminalFlag overrides the configured terminal for a single command.
+var terminalFlag string
+
+// pairFlag opens the configured editor on the worktree next to the session.
+var pairFlag bool
+
+// addPairFlag adds --pair to a command that opens sessions.
+func addPairFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&pairFlag, "pair", false, "Also open the editor (pair.editor) on the worktree, in a split pane where supported")
+}
+
+// addTerminalFlag adds --terminal to a command that opens terminal tabs.
+func addTerminalFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&terminalFlag, "terminal", "", "Terminal to open the session in: "+strings.Join(terminal.Types, ", ")+" (default from config)")
+}
+
+// newTerminal returns the terminal named by --terminal, falling back to the
+// configured one. "auto" detects it from the environment. With --pair,
+// it also opens the editor alongside each session.
+func newTerminal() (terminal.Terminal, error) {
+	name := cfg.GetTerminal()
+	if terminalFlag != "" {
+		name = terminalFlag
+	}
+	t, err := terminal.NewTerminal(name)
+	if err != nil || !pairFlag {
+		return t, err
+	}
+	p := &terminal.Pair{Terminal: t, Editor: cfg.Pair.GetEditor(), Layout: cfg.Pair.GetLayout()}
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// sessionPlace describes where t opens sessions, e.g. "new iTerm2 tab".
+func sessionPlace(t terminal.Terminal) string {
+	if terminal.Inline(t) {
+		return "this terminal"
+	}
+	return fmt.Sprintf("new %s tab", t.Name())
+}
+
+// logTabOpened confirms a session was opened in a new tab. Inline
+// terminals have nothing to confirm: the session ran or was printed.
+func logTabOpened(t terminal.Terminal) {
+	if !terminal.Inline(t) {
+		ui.LogSuccess(fmt.Sprintf("%s tab opened", t.Name()))
+	}
 }
 
 // runReviewResume handles `zen review resume <pr-number>`.
 func runReviewResume(cmd *cobra.Command, args []string) error {
-	prNumber, err := strconv.Atoi(args[0])
+	prNumber, err := parsePRArg(args[0], nil)
 	if err != nil {
-		return fmt.Errorf("invalid PR number %q: %w", args[0], err)
+		return err
 	}
 
-	wt, err := findWorktreeByPR(prNumber)
+	wt, err := findWorktreeByPR(prNumber, reviewName)
 	if err != nil {
 		var nwErr *noWorktreeError
 		if errors.As(err, &nwErr) {
@@ -277,7 +386,7 @@ func runReviewResume(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	term, err := terminal.NewTerminal(cfg.GetTerminal())
+	term, err := newTerminal()
 	if err != nil {
 		return err
 	}
@@ -291,7 +400,7 @@ func runWorkResume(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	term, err := terminal.NewTerminal(cfg.GetTerminal())
+	term, err := newTerminal()
 	if err != nil {
 		return err
 	}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/mgreau/zen/internal/config"
+	ctxpkg "github.com/mgreau/zen/internal/context"
 	"github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/prcache"
+	"github.com/mgreau/zen/internal/reconciler"
 	"github.com/mgreau/zen/internal/review"
-	"github.com/mgreau/zen/internal/terminal"
 	"github.com/mgreau/zen/internal/ui"
 	wt "github.com/mgreau/zen/internal/worktree"
 	"github.com/spf13/cobra"
@@ -23,85 +26,241 @@ var reviewCmd = &cobra.Command{
 	Long: `Manage PR review worktrees.
 
 Usage:
-  zen review <pr-number>           Create worktree + open iTerm tab
+  zen review <pr-number>           Create worktree + open terminal tab
+  zen review <pr-url>              Same, taking the repo from a GitHub PR URL
+  zen review <pr-number> --sparse  Check out only the PR's changed dirs
+  zen review <pr-number> --name tests
+                                   Extra checkout of the PR (<repo>-pr-N-tests)
+  zen review <pr-number> --readonly
+                                   Block commits and pushes in the worktree
+  zen review <pr-number> --files-only
+                                   Print files, reviewers and CI; no worktree
+  zen review <pr-number> --linked  Also set up the PRs it depends on or is a
+                                   companion of, in any configured repo
+  zen review <pr-number> --playbook security
+                                   Focus the review with a playbook's
+                                   instructions and prompt (security, perf,
+                                   api, or one from playbooks: in the config)
+  zen review --patch <file|URL>    Review a patch file, patch URL or gist in a
+                                   scratch worktree (<repo>-patch-<name>)
   zen review resume <pr-number>    Resume existing session in new tab
-  zen review delete <pr-number>    Delete a PR review worktree`,
+  zen review delete <pr-number>    Delete a PR review worktree
+  zen review repair <pr-number>    Re-run missing setup steps
+  zen review lock <pr-number>      Make a review worktree read-only
+  zen review unlock <pr-number>    Allow commits in it again
+  zen review deps <pr-number>      Show open PRs touching the same files
+  zen review diff <pr-number>      Show changes since your last session
+  zen review watch <pr-number>     Notify on new commits, comments, CI, merge
+
+A PR number can be given as a GitHub PR URL anywhere, e.g.
+https://github.com/org/repo/pull/42; the repo then comes from the URL.`,
 	DisableFlagParsing: false,
 	RunE:               runReview,
 }
 
 var reviewResumeCmd = &cobra.Command{
 	Use:   "resume <pr-number>",
-	Short: "Resume a PR review session in a new iTerm2 tab",
+	Short: "Resume a PR review session in a new terminal tab",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runReviewResume,
 }
 
 var reviewDeleteCmd = &cobra.Command{
-	Use:   "delete <pr-number>",
-	Short: "Delete a PR review worktree",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runReviewDelete,
+	Use:   "delete [pr-number]",
+	Short: "Delete a PR review worktree, or all matching --merged/--closed/--older-than",
+	Long: `Delete a PR review worktree by PR number, or delete every PR review
+worktree matching the given filters in one pass:
+
+  zen review delete 42                  Delete the worktree(s) for PR #42
+  zen review delete 42 --name tests     Delete only the <repo>-pr-42-tests checkout
+  zen review delete --merged            All worktrees whose PR was merged
+  zen review delete --merged --closed   ...merged or closed without merging
+  zen review delete --older-than 14d    All worktrees inactive for 14+ days
+
+--merged and --closed match either state. Combined with --older-than, a
+worktree must match a state and be inactive for the given period.
+Matching worktrees are listed before asking for confirmation.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReviewDelete,
+}
+
+var reviewRepairCmd = &cobra.Command{
+	Use:   "repair <pr-number>",
+	Short: "Re-run any missing setup steps for a PR review worktree",
+	Long: `Brings a half-set-up PR review worktree (e.g. after an interrupted
+zen review) to the same state the watch daemon would leave it in. Each step
+is skipped when already done:
+
+  - create the worktree, or check it out if it was added but never checked out
+  - inject PR context into CLAUDE.local.md
+  - cache the PR title and author
+  - install the command review_prompt starts with (/review-pr by default)
+
+Example:
+  zen review repair 42`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReviewRepair,
 }
 
 var (
-	reviewRepo        string
-	reviewNoITerm     bool
-	reviewModel       string
-	reviewDeleteForce bool
+	reviewRepo         string
+	reviewNoITerm      bool
+	reviewModel        string
+	reviewSparse       bool
+	reviewReadOnly     bool
+	reviewFull         bool
+	reviewFilesOnly    bool
+	reviewName         string
+	reviewPatch        string
+	reviewPlaybook     string
+	reviewLinked       bool
+	reviewDeleteForce  bool
+	reviewDeleteMerged bool
+	reviewDeleteClosed bool
+	reviewDeleteOlder  string
+	reviewRepairRepo   string
 )
 
 func init() {
-	reviewCmd.Flags().StringVar(&reviewRepo, "repo", "", "Repository short name from config (auto-detected if omitted)")
+	reviewCmd.Flags().StringVar(&reviewRepo, "repo", "", "Repository short name from config, or @group to limit auto-detection (auto-detected if omitted)")
 	reviewCmd.Flags().BoolVar(&reviewNoITerm, "no-terminal", false, "Create worktree only, don't open terminal tab")
 	reviewCmd.Flags().StringVarP(&reviewModel, "model", "m", "", "Claude model to use (e.g., sonnet, opus, haiku)")
+	reviewCmd.Flags().BoolVar(&reviewSparse, "sparse", false, "Sparse-checkout only the PR's changed dirs (default from repo's sparse setting)")
+	reviewCmd.Flags().BoolVar(&reviewReadOnly, "readonly", false, "Block commits and pushes in the new worktree until zen review unlock (default from repo's readonly setting)")
+	reviewCmd.Flags().BoolVar(&reviewFull, "full", false, "Fetch full history, ignoring the repo's fetch_depth and fetch_filter")
+	reviewCmd.Flags().BoolVar(&reviewFilesOnly, "files-only", false, "Print the PR's files by directory, reviewers and CI without creating a worktree")
+	reviewCmd.Flags().StringVar(&reviewName, "name", "", "Create an extra checkout of the PR named <repo>-pr-N-<name> (with --patch: name the worktree <repo>-patch-<name>)")
+	reviewCmd.Flags().StringVar(&reviewPatch, "patch", "", "Review a patch file, patch URL or GitHub Gist instead of a PR")
+	reviewCmd.Flags().BoolVar(&reviewLinked, "linked", false, "Also create worktrees for the PRs its description pairs it with (\"Depends on\", \"Companion\")")
+	reviewCmd.Flags().StringVar(&reviewPlaybook, "playbook", "", "Review playbook: security, perf, api or one from the config (default from the PR's labels)")
+	addTerminalFlag(reviewCmd)
+	addPairFlag(reviewCmd)
 	addResumeFlags(reviewResumeCmd)
+	reviewResumeCmd.Flags().StringVar(&reviewName, "name", "", "Resume the <repo>-pr-N-<name> checkout")
+	reviewDeleteCmd.Flags().StringVar(&reviewName, "name", "", "Delete only the <repo>-pr-N-<name> checkout")
 	reviewDeleteCmd.Flags().BoolVarP(&reviewDeleteForce, "force", "f", false, "Skip confirmation")
+	reviewDeleteCmd.Flags().BoolVar(&reviewDeleteMerged, "merged", false, "Delete all worktrees whose PR is merged")
+	reviewDeleteCmd.Flags().BoolVar(&reviewDeleteClosed, "closed", false, "Delete all worktrees whose PR is closed without merging")
+	reviewDeleteCmd.Flags().StringVar(&reviewDeleteOlder, "older-than", "", "Delete all worktrees inactive for this long (e.g., 14d, 2w)")
+	reviewRepairCmd.Flags().StringVar(&reviewRepairRepo, "repo", "", "Repository short name or @group (auto-detected if omitted)")
 	reviewCmd.AddCommand(reviewResumeCmd)
 	reviewCmd.AddCommand(reviewDeleteCmd)
+	reviewCmd.AddCommand(reviewRepairCmd)
 	rootCmd.AddCommand(reviewCmd)
 }
 
 func runReview(cmd *cobra.Command, args []string) error {
+	if reviewPatch != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--patch reviews a patch instead of a PR: drop the PR number")
+		}
+		if reviewPlaybook != "" {
+			return fmt.Errorf("--playbook applies to PR reviews, not --patch")
+		}
+		if reviewLinked {
+			return fmt.Errorf("--linked applies to PR reviews, not --patch")
+		}
+		return runReviewPatch(context.Background(), reviewPatch)
+	}
 	if len(args) != 1 {
 		return cmd.Help()
 	}
-	prNumber, err := strconv.Atoi(args[0])
+	prNumber, err := parsePRArg(args[0], &reviewRepo)
 	if err != nil {
-		return fmt.Errorf("invalid PR number %q: %w", args[0], err)
+		return err
+	}
+	if reviewName != "" {
+		if err := wt.ValidateSuffix(reviewName); err != nil {
+			return err
+		}
+	}
+	if reviewPlaybook != "" {
+		if err := checkPlaybook(reviewPlaybook); err != nil {
+			return err
+		}
 	}
 
 	ctx := context.Background()
 
-	// Auto-detect repo if not specified
-	if reviewRepo == "" {
-		detected, err := detectRepoForPR(ctx, prNumber)
+	// Auto-detect repo if not specified (or only a group was given)
+	if reviewRepo == "" || config.IsGroupRef(reviewRepo) {
+		detected, err := detectRepoForPR(ctx, prNumber, reviewRepo)
 		if err != nil {
 			return err
 		}
 		reviewRepo = detected
 	}
 
+	if reviewFilesOnly {
+		if reviewLinked {
+			return fmt.Errorf("--linked creates worktrees: drop --files-only")
+		}
+		return runReviewFilesOnly(ctx, reviewRepo, prNumber)
+	}
+
+	var linked []linkedWorktree
+	if reviewLinked {
+		linked = setupLinkedPRs(ctx, reviewRepo, prNumber)
+	}
+
 	// Check if worktree already exists and resume
 	basePath := cfg.RepoBasePath(reviewRepo)
 	if basePath != "" {
-		worktreeName := fmt.Sprintf("%s-pr-%d", reviewRepo, prNumber)
+		worktreeName := wt.PRName(reviewRepo, prNumber, reviewName)
 		worktreePath := filepath.Join(basePath, worktreeName)
 		if _, err := os.Stat(worktreePath); err == nil {
+			if setupIncomplete(worktreePath) {
+				ui.LogWarn(fmt.Sprintf("Worktree setup looks incomplete -- run: zen review repair %d", prNumber))
+			}
 			ui.LogInfo(fmt.Sprintf("Worktree already exists, resuming PR #%d...", prNumber))
+			meta, _ := prcache.Get(reviewRepo, prNumber)
+			noteLinkedWorktrees(linkedWorktree{Ref: fmt.Sprintf("%s#%d", cfg.RepoFullName(reviewRepo), prNumber), Title: meta.Title, Path: worktreePath}, linked)
+			if _, err := preparePlaybook(reviewRepo, prNumber, worktreePath, reviewPlaybook); err != nil {
+				ui.LogWarn(fmt.Sprintf("Failed to set the review playbook: %v", err))
+			}
 			if reviewModel != "" {
 				resumeModel = reviewModel
 			}
-			return openReviewTab(worktreePath, worktreeName)
+			return openReviewTab(reviewRepo, prNumber, worktreePath, worktreeName)
 		}
 	}
 
+	// Sparse mode: explicit flag wins, otherwise the repo default
+	sparse := cfg.RepoSparse(reviewRepo)
+	if cmd.Flags().Changed("sparse") {
+		sparse = reviewSparse
+	}
+
+	readOnly := cfg.RepoReadOnly(reviewRepo)
+	if cmd.Flags().Changed("readonly") {
+		readOnly = reviewReadOnly
+	}
+
 	// Create worktree using shared logic
-	result, err := review.CreateWorktree(ctx, cfg, reviewRepo, prNumber, ui.LogInfo)
+	steps := ui.NewSteps()
+	result, err := review.CreateWorktree(ctx, cfg, reviewRepo, prNumber, review.Options{Sparse: sparse, Full: reviewFull, Suffix: reviewName, ReadOnly: readOnly}, steps)
 	if err != nil {
 		return err
 	}
 
+	noteLinkedWorktrees(linkedWorktree{Ref: fmt.Sprintf("%s#%d", cfg.RepoFullName(reviewRepo), prNumber), Title: result.Title, Path: result.WorktreePath}, linked)
+
+	playbook, err := preparePlaybook(reviewRepo, prNumber, result.WorktreePath, reviewPlaybook)
+	if err != nil {
+		ui.LogWarn(fmt.Sprintf("Failed to set the review playbook: %v", err))
+	}
+
+	// Ensure the command the prompt starts with, e.g. /review-pr, is installed
+	prompt := reviewPrompt(reviewRepo, prNumber, result.WorktreePath)
+	if name := promptCommand(prompt); name != "" {
+		steps.Step(fmt.Sprintf("Install /%s command", name))
+		err = ensureClaudeCommand(name)
+		steps.Done(err)
+		if err != nil {
+			ui.LogInfo(fmt.Sprintf("Warning: could not install /%s command: %v", name, err))
+		}
+	}
+
 	home := homeDir()
 	shortPath := ui.ShortenHome(result.WorktreePath, home)
 
@@ -114,59 +273,89 @@ func runReview(cmd *cobra.Command, args []string) error {
 	ui.LogSuccess(fmt.Sprintf("Created worktree: %s", shortPath))
 	fmt.Printf("  PR:     #%d — %s\n", result.PRNumber, result.Title)
 	fmt.Printf("  Author: %s\n", result.Author)
-
-	if reviewModel != "" {
-		fmt.Printf("  Model:  %s\n", ui.CyanText(reviewModel))
+	if result.ReadOnly {
+		fmt.Printf("  Mode:   %s\n", ui.CyanText(fmt.Sprintf("read-only (zen review unlock %d%s)", prNumber, nameFlag(reviewName))))
+	}
+	if playbook != "" {
+		fmt.Printf("  Review: %s\n", ui.CyanText(playbook+" playbook"))
+	}
+	for _, l := range linked {
+		fmt.Printf("  Linked: %s %s\n", l.Ref, ui.DimText(ui.ShortenHome(l.Path, home)))
 	}
 
-	// Ensure /review-pr command is installed
-	if err := ensureClaudeCommand("review-pr"); err != nil {
-		ui.LogInfo(fmt.Sprintf("Warning: could not install /review-pr command: %v", err))
+	claudeCmd, model := claudeCommand(reviewRepo, result.WorktreePath, reviewModel)
+	if model != "" {
+		fmt.Printf("  Model:  %s\n", ui.CyanText(model))
+	}
+	if prompt == "" {
+		ui.Hint("The PR has a no-ai-review label: starting a plain session")
 	}
 
 	if reviewNoITerm {
 		fmt.Println()
 		fmt.Println(ui.BoldText("Open manually:"))
 		modelFlag := ""
-		if reviewModel != "" {
-			modelFlag = fmt.Sprintf(" --model %s", reviewModel)
+		if model != "" {
+			modelFlag = fmt.Sprintf(" --model %s", model)
+		}
+		if prompt != "" {
+			fmt.Printf("  cd %s && %s%s %q\n", result.WorktreePath, claudeCmd, modelFlag, prompt)
+		} else {
+			fmt.Printf("  cd %s && %s%s\n", result.WorktreePath, claudeCmd, modelFlag)
 		}
-		fmt.Printf("  cd %s && %s%s \"/review-pr\"\n", result.WorktreePath, cfg.ClaudeBin, modelFlag)
 		return nil
 	}
 
 	// Open terminal tab
-	term, err := terminal.NewTerminal(cfg.GetTerminal())
+	term, err := newTerminal()
 	if err != nil {
 		return err
 	}
 
-	if err := term.OpenTabWithClaude(result.WorktreePath, "/review-pr", cfg.ClaudeBin, reviewModel); err != nil {
-		return fmt.Errorf("opening %s tab: %w", term.Name(), err)
+	if err := openClaudeTab(term, result.WorktreePath, prompt, claudeCmd, model); err != nil {
+		return fmt.Errorf("opening %s tab (the worktree is ready -- retry with: zen review resume %d): %w", term.Name(), prNumber, err)
 	}
 
-	ui.LogSuccess(fmt.Sprintf("%s tab opened", term.Name()))
+	logTabOpened(term)
 	fmt.Println()
 	return nil
 }
 
 func runReviewDelete(cmd *cobra.Command, args []string) error {
-	prNumber, err := strconv.Atoi(args[0])
-	if err != nil {
-		return fmt.Errorf("invalid PR number %q: %w", args[0], err)
+	bulk := reviewDeleteMerged || reviewDeleteClosed || reviewDeleteOlder != ""
+	switch {
+	case bulk && len(args) > 0:
+		return fmt.Errorf("pass either a PR number or --merged/--closed/--older-than, not both")
+	case bulk:
+		return runReviewDeleteBulk()
+	case len(args) == 0:
+		return cmd.Help()
 	}
 
-	match, err := findWorktreeByPR(prNumber)
+	prNumber, err := parsePRArg(args[0], nil)
 	if err != nil {
 		return err
 	}
 
+	// Without --name, every checkout of the PR goes
+	var matches []wt.Worktree
+	if reviewName != "" {
+		match, err := findWorktreeByPR(prNumber, reviewName)
+		if err != nil {
+			return err
+		}
+		matches = []wt.Worktree{*match}
+	} else if matches, err = prWorktrees(prNumber); err != nil {
+		return err
+	}
+
 	home := homeDir()
-	shortPath := ui.ShortenHome(match.Path, home)
 
 	if !reviewDeleteForce {
-		fmt.Printf("Delete worktree %s?\n", ui.CyanText(match.Name))
-		fmt.Printf("  Path: %s\n", shortPath)
+		for _, match := range matches {
+			fmt.Printf("Delete worktree %s?\n", ui.CyanText(match.Name))
+			fmt.Printf("  Path: %s\n", ui.ShortenHome(match.Path, home))
+		}
 		fmt.Print("  Confirm [y/N]: ")
 
 		var resp string
@@ -177,61 +366,253 @@ func runReviewDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	basePath := cfg.RepoBasePath(match.Repo)
-	originPath := filepath.Join(basePath, match.Repo)
+	for _, match := range matches {
+		basePath := cfg.RepoBasePath(match.Repo)
+		originPath := filepath.Join(basePath, match.Repo)
+
+		notes, err := ctxpkg.ArchiveNotes(match.Path)
+		if err != nil {
+			ui.LogWarn(fmt.Sprintf("Could not archive %s: %v", ctxpkg.NotesFile, err))
+		}
+		if out, err := wt.Remove(originPath, match.Path, ""); err != nil {
+			return fmt.Errorf("git worktree remove: %w: %s", err, out)
+		}
+		ctxpkg.ForgetContext(match.Path)
+
+		ui.LogSuccess(fmt.Sprintf("Deleted worktree: %s", ui.ShortenHome(match.Path, home)))
+		if notes != "" {
+			ui.LogInfo(fmt.Sprintf("Archived its notes to %s", ui.ShortenHome(notes, home)))
+		}
+		deleted, err := removeBranch(originPath, match)
+		reportBranchRemoval(match, deleted, err)
+	}
+	return nil
+}
+
+// runReviewDeleteBulk deletes every PR review worktree matching the
+// --merged/--closed/--older-than filters after a listing and confirmation.
+func runReviewDeleteBulk() error {
+	var cutoff time.Time
+	if reviewDeleteOlder != "" {
+		var err error
+		if cutoff, err = parsePeriod(reviewDeleteOlder); err != nil {
+			return err
+		}
+	}
+	byState := reviewDeleteMerged || reviewDeleteClosed
+
+	wts, err := wt.ListAll(cfg)
+	if err != nil {
+		return fmt.Errorf("listing worktrees: %w", err)
+	}
+	var reviews []wt.Worktree
+	for _, w := range wts {
+		if w.Type == wt.TypePRReview && w.PRNumber > 0 {
+			reviews = append(reviews, w)
+		}
+	}
 
-	removeCmd := exec.Command("git", "worktree", "remove", match.Path, "--force")
-	removeCmd.Dir = originPath
-	if out, err := removeCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("git worktree remove: %w: %s", err, string(out))
+	// Fetch PR states, one GraphQL request per repo, only when filtering by state
+	states := make([]string, len(reviews))
+	if byState {
+		if !jsonFlag {
+			fmt.Fprintf(os.Stderr, "  %s", ui.DimText(fmt.Sprintf("Checking %d PR review worktrees...", len(reviews))))
+		}
+		statuses, err := reconciler.FetchPRStatuses(context.Background(), cfg, reviews)
+		if !jsonFlag {
+			fmt.Fprintf(os.Stderr, "\r%-60s\r", "")
+		}
+		if err != nil && len(statuses) == 0 && len(reviews) > 0 {
+			return fmt.Errorf("looking up PR states: %w", err)
+		}
+		for i, w := range reviews {
+			states[i] = statuses[reconciler.MakePRKey(w.Repo, w.PRNumber)].State
+		}
 	}
 
-	ui.LogSuccess(fmt.Sprintf("Deleted worktree: %s", shortPath))
+	var matches []staleWorktree
+	for i, w := range reviews {
+		var reasons []string
+		if byState {
+			switch {
+			case reviewDeleteMerged && states[i] == "MERGED":
+				reasons = append(reasons, "PR merged")
+			case reviewDeleteClosed && states[i] == "CLOSED":
+				reasons = append(reasons, "PR closed (not merged)")
+			default:
+				continue
+			}
+		}
+		if !cutoff.IsZero() {
+			last, err := wt.LastActivity(w.Path)
+			if err != nil || last.After(cutoff) {
+				continue
+			}
+			reasons = append(reasons, fmt.Sprintf("inactive since %s", last.Format("2006-01-02")))
+		}
+		matches = append(matches, staleWorktree{Worktree: w, Reason: strings.Join(reasons, ", ")})
+	}
+
+	if jsonFlag && !reviewDeleteForce {
+		if matches == nil {
+			matches = []staleWorktree{}
+		}
+		printJSON(matches)
+		return nil
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("No matching PR review worktrees.")
+		return nil
+	}
+
+	home := homeDir()
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("%d PR review worktree(s) to delete", len(matches))))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	for _, m := range matches {
+		fmt.Printf("  %s  %-40s  %s\n",
+			ui.CyanText(fmt.Sprintf("#%-5d", m.PRNumber)),
+			ui.Truncate(m.Name, 40),
+			ui.DimText(m.Reason))
+		fmt.Printf("          %s\n", ui.DimText(ui.ShortenHome(m.Path, home)))
+	}
+	fmt.Println()
+
+	if !reviewDeleteForce {
+		fmt.Printf("  Delete all %d? [y/N]: ", len(matches))
+		var resp string
+		fmt.Scanln(&resp)
+		if resp != "y" && resp != "Y" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		fmt.Println()
+	}
+
+	deleted, failed := 0, 0
+	for _, m := range matches {
+		fmt.Printf("  %s\n", ui.CyanText(m.Name))
+		if deleteWorktree(m) {
+			deleted++
+		} else {
+			failed++
+		}
+	}
+	fmt.Println()
+	ui.Separator()
+	fmt.Printf("Deleted: %s  Failed: %s\n", ui.GreenText(fmt.Sprintf("%d", deleted)), ui.RedText(fmt.Sprintf("%d", failed)))
+	fmt.Println()
+	return nil
+}
+
+func runReviewRepair(cmd *cobra.Command, args []string) error {
+	prNumber, err := parsePRArg(args[0], &reviewRepairRepo)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	repo := reviewRepairRepo
+	if repo == "" || config.IsGroupRef(repo) {
+		detected, err := detectRepoForPR(ctx, prNumber, repo)
+		if err != nil {
+			return err
+		}
+		repo = detected
+	}
+
+	// Prefer fresh PR details; fall back to the cache when offline
+	title, author := "", ""
+	if meta, ok := prcache.Get(repo, prNumber); ok {
+		title, author = meta.Title, meta.Author
+	}
+	if client, err := github.NewClient(ctx); err == nil {
+		if details, err := client.GetPRDetails(ctx, cfg.RepoFullName(repo), prNumber); err == nil {
+			title, author = details.Title, details.Author
+			prcache.SetLabels(repo, prNumber, details.Labels)
+		} else {
+			ui.LogWarn(fmt.Sprintf("Could not fetch PR #%d details: %v", prNumber, err))
+		}
+	}
+
+	steps := ui.NewSteps()
+	worktreePath, err := reconciler.NewSetupReconciler(cfg).EnsurePR(ctx, repo, prNumber, title, author, steps)
+	if err != nil {
+		return err
+	}
+
+	if name := promptCommand(cfg.RepoReviewPrompt(repo)); name != "" {
+		steps.Step(fmt.Sprintf("Install /%s command", name))
+		err = ensureClaudeCommand(name)
+		steps.Done(err)
+		if err != nil {
+			ui.LogWarn(fmt.Sprintf("Could not install /%s command: %v", name, err))
+		}
+	}
+
+	if jsonFlag {
+		printJSON(review.Result{WorktreePath: worktreePath, PRNumber: prNumber, Title: title, Author: author})
+		return nil
+	}
+
+	fmt.Println()
+	ui.LogSuccess(fmt.Sprintf("Worktree ready: %s", ui.ShortenHome(worktreePath, homeDir())))
+	ui.Hint(fmt.Sprintf("Resume with: zen review resume %d", prNumber))
+	fmt.Println()
 	return nil
 }
 
-// openReviewTab resumes an existing worktree in a new iTerm tab.
-func openReviewTab(worktreePath, worktreeName string) error {
+// setupIncomplete reports whether a PR review worktree is missing a setup
+// step that zen review repair would redo.
+func setupIncomplete(worktreePath string) bool {
+	if wt.NeedsCheckout(worktreePath) {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(worktreePath, "CLAUDE.local.md"))
+	return os.IsNotExist(err)
+}
+
+// openReviewTab resumes an existing worktree in a new terminal tab.
+func openReviewTab(repo string, prNumber int, worktreePath, worktreeName string) error {
 	w := wt.Worktree{
-		Path:   worktreePath,
-		Name:   worktreeName,
-		Type:   wt.TypePRReview,
+		Repo:     repo,
+		Path:     worktreePath,
+		Name:     worktreeName,
+		Type:     wt.TypePRReview,
+		PRNumber: prNumber,
 	}
-	term, err := terminal.NewTerminal(cfg.GetTerminal())
+	term, err := newTerminal()
 	if err != nil {
 		return err
 	}
 	return resumeWorktree(w, fmt.Sprintf("zen review resume %s", worktreeName), term)
 }
 
-// detectRepoForPR tries each configured repo to find which one contains the
-// given PR number. If multiple repos have the same PR number, asks the user
-// to choose. Returns the repo short name or an error.
-func detectRepoForPR(ctx context.Context, prNumber int) (string, error) {
-	repos := cfg.RepoNames()
+// detectRepoForPR finds which configured repo (or which repo of the group
+// named by spec, e.g. "@images") contains the given PR number, checking all
+// of them in one GitHub request. Recently resolved PR numbers are answered
+// from state. If multiple repos have the same PR number, asks the user to
+// choose. Returns the repo short name or an error.
+func detectRepoForPR(ctx context.Context, prNumber int, spec string) (string, error) {
+	repos, err := cfg.ResolveRepos(spec)
+	if err != nil {
+		return "", err
+	}
 	if len(repos) == 1 {
 		return repos[0], nil
 	}
+	if repo, ok := prcache.LookupRepo(prNumber, repos); ok {
+		ui.LogInfo(fmt.Sprintf("Using %s for PR #%d (remembered -- pass --repo to override)", repo, prNumber))
+		return repo, nil
+	}
 
 	ui.LogInfo(fmt.Sprintf("Detecting repo for PR #%d...", prNumber))
 
-	client, err := github.NewClient(ctx)
+	matches, err := review.FindPR(ctx, cfg, prNumber, repos)
 	if err != nil {
-		return "", fmt.Errorf("creating GitHub client: %w", err)
-	}
-
-	type match struct {
-		repo   string
-		title  string
-		author string
-	}
-	var matches []match
-	for _, repo := range repos {
-		fullRepo := cfg.RepoFullName(repo)
-		details, err := client.GetPRDetails(ctx, fullRepo, prNumber)
-		if err == nil {
-			matches = append(matches, match{repo: repo, title: details.Title, author: details.Author})
-		}
+		return "", err
 	}
 
 	switch len(matches) {
@@ -239,29 +620,21 @@ func detectRepoForPR(ctx context.Context, prNumber int) (string, error) {
 		return "", fmt.Errorf("PR #%d not found in any configured repo (%s)\n  Specify with: zen review --repo <name> %d",
 			prNumber, strings.Join(repos, ", "), prNumber)
 	case 1:
-		ui.LogInfo(fmt.Sprintf("Found PR #%d in %s", prNumber, matches[0].repo))
-		return matches[0].repo, nil
+		ui.LogInfo(fmt.Sprintf("Found PR #%d in %s", prNumber, matches[0].Short))
+		prcache.RememberRepo(prNumber, matches[0].Short)
+		return matches[0].Short, nil
 	default:
 		// Check if the user is a requested reviewer on exactly one of them.
-		currentUser, _ := github.GetCurrentUser(ctx)
-		if currentUser != "" {
-			var reviewMatches []match
-			for _, m := range matches {
-				fullRepo := cfg.RepoFullName(m.repo)
-				if ok, _ := client.IsRequestedReviewer(ctx, fullRepo, prNumber, currentUser); ok {
-					reviewMatches = append(reviewMatches, m)
-				}
-			}
-			if len(reviewMatches) == 1 {
-				ui.LogInfo(fmt.Sprintf("Found PR #%d in %s (you're a requested reviewer)", prNumber, reviewMatches[0].repo))
-				return reviewMatches[0].repo, nil
-			}
+		if m, ok := review.ViewerRequested(matches); ok {
+			ui.LogInfo(fmt.Sprintf("Found PR #%d in %s (you're a requested reviewer)", prNumber, m.Short))
+			prcache.RememberRepo(prNumber, m.Short)
+			return m.Short, nil
 		}
 
 		// Multiple matches, ask the user.
 		fmt.Printf("PR #%d exists in multiple repos:\n", prNumber)
 		for i, m := range matches {
-			fmt.Printf("  [%d] %s — %s (by %s)\n", i+1, m.repo, ui.Truncate(m.title, 50), m.author)
+			fmt.Printf("  [%d] %s — %s (by %s)\n", i+1, m.Short, ui.Truncate(m.Title, 50), m.Author)
 		}
 		fmt.Print("Which repo? [1]: ")
 		var resp string
@@ -274,6 +647,7 @@ func detectRepoForPR(ctx context.Context, prNumber int) (string, error) {
 		if err != nil || idx < 1 || idx > len(matches) {
 			return "", fmt.Errorf("invalid choice %q", resp)
 		}
-		return matches[idx-1].repo, nil
+		prcache.RememberRepo(prNumber, matches[idx-1].Short)
+		return matches[idx-1].Short, nil
 	}
 }
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	captureInject bool
+	captureLines  int
+)
+
+var reviewCaptureCmd = &cobra.Command{
+	Use:   "capture <pr-number> -- <command> [args...]",
+	Short: "Run a command in a PR review worktree and record its output",
+	Long: `Runs a command (tests, a build, a linter) in the PR's review worktree,
+showing its output as usual, and appends the result and the last --lines
+lines of output to the worktree's NOTES.zen.md. That file is kept out of
+git and records what you ran during the review.
+
+With --inject the same output is also written to CLAUDE.local.md, replacing
+the previous output of the same command, so the review session sees it.
+
+A single argument after -- is run with sh -c, so pipes and && work when
+quoted. zen exits with an error when the command fails, after recording it.
+
+Example:
+  zen review capture 42 -- go test ./...
+  zen review capture 42 --inject -- make lint
+  zen review capture 42 -- "go test ./... 2>&1 | grep -v '^ok'"`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if dash := cmd.ArgsLenAtDash(); dash != 1 || len(args) < 2 {
+			return fmt.Errorf("usage: zen review capture <pr-number> -- <command> [args...]")
+		}
+		return nil
+	},
+	RunE: runReviewCapture,
+}
+
+func init() {
+	reviewCaptureCmd.Flags().StringVar(&reviewName, "name", "", "Run in the <repo>-pr-N-<name> checkout")
+	reviewCaptureCmd.Flags().BoolVar(&captureInject, "inject", false, "Also write the output to CLAUDE.local.md")
+	reviewCaptureCmd.Flags().IntVar(&captureLines, "lines", 200, "Lines of output to record, from the end (0 = all)")
+	reviewCmd.AddCommand(reviewCaptureCmd)
+}
+
+// reviewCaptureResult is the JSON output of zen review capture.
+type reviewCaptureResult struct {
+	Worktree string  `json:"worktree"`
+	Command  string  `json:"command"`
+	ExitCode int     `json:"exit_code"`
+	Duration float64 `json:"duration_seconds"`
+	Notes    string  `json:"notes"`
+	Injected bool    `json:"injected"`
+}
+
+func runReviewCapture(cmd *cobra.Command, args []string) error {
+	prNumber, err := parsePRArg(args[0], nil)
+	if err != nil {
+		return err
+	}
+	w, err := findWorktreeByPR(prNumber, reviewName)
+	if err != nil {
+		return err
+	}
+
+	argv := args[1:]
+	command := strings.Join(argv, " ")
+	var run *exec.Cmd
+	if len(argv) == 1 {
+		run = exec.Command("sh", "-c", argv[0])
+	} else {
+		run = exec.Command(argv[0], argv[1:]...)
+	}
+	run.Dir = w.Path
+	run.Stdin = os.Stdin
+	// Keep stdout for the JSON document
+	var shown io.Writer = os.Stdout
+	if jsonFlag {
+		shown = os.Stderr
+	}
+	var out bytes.Buffer
+	tee := io.MultiWriter(shown, &out)
+	run.Stdout = tee
+	run.Stderr = tee
+
+	c := ctxpkg.Capture{Command: command, Started: time.Now()}
+	err = run.Run()
+	c.Duration = time.Since(c.Started)
+	c.Output = out.String()
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		c.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		return fmt.Errorf("running %s: %w", command, err)
+	}
+
+	body := ctxpkg.FormatCapture(c, captureLines)
+	if err := ctxpkg.AppendNote(w.Path, ctxpkg.NotesTitle(prNumber), "## `"+command+"`\n\n"+body); err != nil {
+		return err
+	}
+	if captureInject {
+		if err := ctxpkg.SetNote(w.Path, "Output of `"+command+"`", body); err != nil {
+			return fmt.Errorf("writing note: %w", err)
+		}
+	}
+
+	if jsonFlag {
+		printJSON(reviewCaptureResult{
+			Worktree: w.Path,
+			Command:  command,
+			ExitCode: c.ExitCode,
+			Duration: c.Duration.Seconds(),
+			Notes:    ctxpkg.NotesPath(w.Path),
+			Injected: captureInject,
+		})
+	} else {
+		fmt.Println()
+		where := ctxpkg.NotesFile
+		if captureInject {
+			where += " and CLAUDE.local.md"
+		}
+		if c.ExitCode == 0 {
+			ui.LogSuccess(fmt.Sprintf("Recorded a passing run of %s in %s", command, where))
+		} else {
+			ui.LogWarn(fmt.Sprintf("Recorded a failing run of %s in %s", command, where))
+		}
+	}
+	if c.ExitCode != 0 {
+		return fmt.Errorf("%s exited with status %d", command, c.ExitCode)
+	}
+	return nil
+}
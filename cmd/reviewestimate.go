@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mgreau/zen/internal/config"
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/review"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var reviewEstimateCmd = &cobra.Command{
+	Use:   "estimate <pr-number>",
+	Short: "Estimate the effort to review a PR before creating a worktree",
+	Long: `Sizes a PR as S, M, L or XL from its changed files, using only the GitHub
+API. Changed lines are weighted by file kind: generated and lock files are
+not counted, tests and config count for half. The size goes up a step for
+changes spread over many files or directories and for code changes that
+come without test changes.
+
+Example:
+  zen review estimate 42
+  zen review estimate 42 --repo mono --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReviewEstimate,
+}
+
+var reviewEstimateRepo string
+
+func init() {
+	reviewEstimateCmd.Flags().StringVar(&reviewEstimateRepo, "repo", "", "Repository short name or @group (auto-detected if omitted)")
+	reviewCmd.AddCommand(reviewEstimateCmd)
+}
+
+// ReviewEstimate is the output of zen review estimate.
+type ReviewEstimate struct {
+	Repo string           `json:"repo"`
+	PR   *ghpkg.PRDetails `json:"pr"`
+	*review.Estimate
+}
+
+func runReviewEstimate(cmd *cobra.Command, args []string) error {
+	prNumber, err := parsePRArg(args[0], &reviewEstimateRepo)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	repo := reviewEstimateRepo
+	if repo == "" || config.IsGroupRef(repo) {
+		detected, err := detectRepoForPR(ctx, prNumber, repo)
+		if err != nil {
+			return err
+		}
+		repo = detected
+	}
+	fullRepo := cfg.RepoFullName(repo)
+
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("creating GitHub client: %w", err)
+	}
+	var (
+		details *ghpkg.PRDetails
+		files   []ghpkg.FileStat
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		details, err = client.GetPRDetails(gctx, fullRepo, prNumber)
+		return err
+	})
+	g.Go(func() (err error) {
+		if files, err = client.GetPRFileStats(gctx, fullRepo, prNumber); err != nil {
+			return fmt.Errorf("fetching PR files: %w", err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	est := ReviewEstimate{Repo: fullRepo, PR: details, Estimate: review.EstimateEffort(files)}
+	if jsonFlag {
+		printJSON(est)
+		return nil
+	}
+	displayReviewEstimate(est, repo)
+	return nil
+}
+
+func displayReviewEstimate(est ReviewEstimate, repo string) {
+	pr := est.PR
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("PR #%d — %s", pr.Number, pr.Title)))
+	ui.Hint(fmt.Sprintf("%s  |  by %s", est.Repo, pr.Author))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	size := est.Size
+	switch size {
+	case "S":
+		size = ui.GreenText(size)
+	case "M":
+		size = ui.CyanText(size)
+	case "L":
+		size = ui.YellowText(size)
+	default:
+		size = ui.RedText(size)
+	}
+	fmt.Printf("  Estimate:  %s  %s\n", ui.BoldText(size), ui.DimText("("+est.Time+")"))
+	fmt.Printf("  Changes:   %d file(s) in %d dir(s), %s %s\n\n", est.Files, est.Dirs,
+		ui.GreenText(fmt.Sprintf("+%d", est.Additions)), ui.RedText(fmt.Sprintf("-%d", est.Deletions)))
+
+	fmt.Println(ui.BoldText("  Why"))
+	for _, r := range est.Reasons {
+		fmt.Printf("    - %s\n", r)
+	}
+	fmt.Println()
+
+	fmt.Printf("  %-10s  %-6s  %s\n", "Kind", "Files", "Lines")
+	fmt.Printf("  %-10s  %-6s  %s\n", "──────────", "──────", "──────")
+	for _, kind := range []string{review.KindCode, review.KindTest, review.KindDocs, review.KindConfig, review.KindGenerated} {
+		k, ok := est.Kinds[kind]
+		if !ok {
+			continue
+		}
+		fmt.Printf("  %-10s  %-6d  %d\n", kind, k.Files, k.Lines)
+	}
+	fmt.Println()
+
+	repoFlag := ""
+	if len(cfg.RepoNames()) > 1 {
+		repoFlag = " --repo " + repo
+	}
+	ui.Hint(fmt.Sprintf("Start with: zen review %d%s  |  skim first: zen review %d --files-only%s", pr.Number, repoFlag, pr.Number, repoFlag))
+	fmt.Println()
+}
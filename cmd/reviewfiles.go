@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/ui"
+	"golang.org/x/sync/errgroup"
+)
+
+// ReviewSummary is the output of zen review --files-only.
+type ReviewSummary struct {
+	Repo      string           `json:"repo"`
+	PR        *ghpkg.PRDetails `json:"pr"`
+	Additions int              `json:"additions"`
+	Deletions int              `json:"deletions"`
+	Dirs      []ghpkg.DirStat  `json:"dirs"`
+	Reviewers *ghpkg.Reviewers `json:"reviewers"`
+	Checks    *prChecksResult  `json:"checks,omitempty"`
+}
+
+// maxSummaryFiles caps the files listed per directory in the summary.
+const maxSummaryFiles = 8
+
+// runReviewFilesOnly prints a summary of a PR from the GitHub API, without
+// creating a worktree or opening a terminal tab.
+func runReviewFilesOnly(ctx context.Context, repo string, prNumber int) error {
+	fullRepo := cfg.RepoFullName(repo)
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("creating GitHub client: %w", err)
+	}
+
+	var (
+		details   *ghpkg.PRDetails
+		files     []ghpkg.FileStat
+		reviewers *ghpkg.Reviewers
+		sha       string
+		checks    []ghpkg.Check
+		checksErr error
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		details, err = client.GetPRDetails(gctx, fullRepo, prNumber)
+		return err
+	})
+	g.Go(func() (err error) {
+		if files, err = client.GetPRFileStats(gctx, fullRepo, prNumber); err != nil {
+			return fmt.Errorf("fetching PR files: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() (err error) {
+		if reviewers, err = client.GetReviewers(gctx, fullRepo, prNumber); err != nil {
+			return fmt.Errorf("fetching reviewers: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		// CI is informational; a token without checks access shouldn't fail the summary
+		sha, checks, checksErr = client.GetPRChecks(gctx, fullRepo, prNumber)
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	sum := ReviewSummary{
+		Repo:      fullRepo,
+		PR:        details,
+		Dirs:      ghpkg.GroupByDir(files),
+		Reviewers: reviewers,
+	}
+	for _, f := range files {
+		sum.Additions += f.Additions
+		sum.Deletions += f.Deletions
+	}
+	if checksErr == nil {
+		sortChecks(checks)
+		if checks == nil {
+			checks = []ghpkg.Check{}
+		}
+		pending, passed, failed := ghpkg.SummarizeChecks(checks)
+		sum.Checks = &prChecksResult{Repo: fullRepo, PR: prNumber, SHA: sha, Pending: pending, Passed: passed, Failed: failed, Checks: checks}
+	}
+
+	if jsonFlag {
+		printJSON(sum)
+		return nil
+	}
+	displayReviewSummary(sum, len(files))
+	return nil
+}
+
+func displayReviewSummary(sum ReviewSummary, fileCount int) {
+	pr := sum.PR
+	fmt.Println()
+	fmt.Println(ui.BoldText(fmt.Sprintf("PR #%d — %s", pr.Number, pr.Title)))
+	ui.Hint(fmt.Sprintf("%s  |  by %s  |  %s → %s", sum.Repo, pr.Author, pr.HeadRefName, pr.BaseRefName))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	fmt.Printf("  %d file(s) changed, %s %s\n\n", fileCount,
+		ui.GreenText(fmt.Sprintf("+%d", sum.Additions)), ui.RedText(fmt.Sprintf("-%d", sum.Deletions)))
+	for _, d := range sum.Dirs {
+		fmt.Printf("  %s  %s %s\n", ui.BoldText(d.Dir+"/"),
+			ui.GreenText(fmt.Sprintf("+%d", d.Additions)), ui.RedText(fmt.Sprintf("-%d", d.Deletions)))
+		for i, f := range d.Files {
+			if i >= maxSummaryFiles {
+				fmt.Printf("      ... and %d more\n", len(d.Files)-maxSummaryFiles)
+				break
+			}
+			name := strings.TrimPrefix(f.Path, d.Dir+"/")
+			fmt.Printf("      %s %-44s %s %s\n", ui.YellowText(fmt.Sprintf("%-9s", f.Status)), ui.Truncate(name, 44),
+				ui.GreenText(fmt.Sprintf("+%d", f.Additions)), ui.RedText(fmt.Sprintf("-%d", f.Deletions)))
+		}
+	}
+	fmt.Println()
+
+	ui.SectionHeader("Reviewers")
+	if len(sum.Reviewers.Requested) == 0 && len(sum.Reviewers.Reviews) == 0 {
+		fmt.Println("  No reviewers requested yet")
+	}
+	if len(sum.Reviewers.Requested) > 0 {
+		fmt.Printf("  Requested: %s\n", strings.Join(sum.Reviewers.Requested, ", "))
+	}
+	logins := make([]string, 0, len(sum.Reviewers.Reviews))
+	for login := range sum.Reviewers.Reviews {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+	for _, login := range logins {
+		state := sum.Reviewers.Reviews[login]
+		switch state {
+		case "APPROVED":
+			state = ui.GreenText(state)
+		case "CHANGES_REQUESTED":
+			state = ui.RedText(state)
+		default:
+			state = ui.DimText(state)
+		}
+		fmt.Printf("  %-20s %s\n", login, state)
+	}
+	fmt.Println()
+
+	ui.SectionHeader("CI")
+	switch {
+	case sum.Checks == nil:
+		fmt.Println("  Could not fetch checks")
+	case len(sum.Checks.Checks) == 0:
+		fmt.Println("  No checks reported yet")
+	default:
+		c := sum.Checks
+		fmt.Printf("  %s  %s  %s\n",
+			ui.GreenText(fmt.Sprintf("%d passed", c.Passed)),
+			ui.RedText(fmt.Sprintf("%d failed", c.Failed)),
+			ui.YellowText(fmt.Sprintf("%d pending", c.Pending)))
+		for _, ch := range c.Checks {
+			if ch.State == ghpkg.CheckPassed {
+				continue
+			}
+			fmt.Printf("  %s  %s\n", checkIcon(ch.State), ch.Name)
+		}
+	}
+	fmt.Println()
+
+	ui.Hint(fmt.Sprintf("%s  |  'zen review %d' to check it out", pr.URL, pr.Number))
+	fmt.Println()
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/review"
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+)
+
+// linkedNoteHeading is the CLAUDE.local.md section listing the worktrees
+// of paired PRs.
+const linkedNoteHeading = "Linked PRs"
+
+// linkedWorktree is a worktree of a PR set up by zen review --linked.
+type linkedWorktree struct {
+	Ref   string // owner/name#N
+	Title string
+	Path  string
+}
+
+// setupLinkedPRs creates review worktrees for the PRs paired with a PR
+// through "Depends on" or "Companion" references, and returns those
+// available, including ones that already existed. Failures are reported
+// and skipped, so the PR itself can still be reviewed.
+func setupLinkedPRs(ctx context.Context, repo string, prNumber int) []linkedWorktree {
+	ui.LogInfo(fmt.Sprintf("Looking for PRs linked to #%d...", prNumber))
+	linked, err := review.FindLinked(ctx, cfg, repo, prNumber)
+	if err != nil {
+		ui.LogWarn(fmt.Sprintf("Could not look up linked PRs: %v", err))
+		return nil
+	}
+	if len(linked) == 0 {
+		ui.LogInfo("No linked PRs (no \"Depends on\" or \"Companion\" references in the description)")
+		return nil
+	}
+
+	var out []linkedWorktree
+	for _, l := range linked {
+		if l.Short == "" {
+			ui.LogWarn(fmt.Sprintf("Skipping %s: %s is not a configured repo", l.PRRef, l.PRRef.Repo))
+			continue
+		}
+		path := filepath.Join(cfg.RepoBasePath(l.Short), wt.PRName(l.Short, l.Number, ""))
+		if _, err := os.Stat(path); err == nil {
+			ui.LogInfo(fmt.Sprintf("%s already has a worktree: %s", l.PRRef, ui.ShortenHome(path, homeDir())))
+			out = append(out, linkedWorktree{Ref: l.PRRef.String(), Title: l.Title, Path: path})
+			continue
+		}
+		result, err := review.CreateWorktree(ctx, cfg, l.Short, l.Number, review.Options{
+			Sparse:   cfg.RepoSparse(l.Short),
+			ReadOnly: cfg.RepoReadOnly(l.Short),
+		}, ui.NewSteps())
+		if err != nil {
+			ui.LogWarn(fmt.Sprintf("Could not set up %s: %v", l.PRRef, err))
+			continue
+		}
+		ui.LogSuccess(fmt.Sprintf("Created worktree for %s: %s", l.PRRef, ui.ShortenHome(result.WorktreePath, homeDir())))
+		out = append(out, linkedWorktree{Ref: l.PRRef.String(), Title: result.Title, Path: result.WorktreePath})
+	}
+	return out
+}
+
+// noteLinkedWorktrees adds a Linked PRs section to the CLAUDE.local.md of
+// every worktree of the set, pointing at the others, so a review of one
+// PR can read the code of its companions.
+func noteLinkedWorktrees(main linkedWorktree, linked []linkedWorktree) {
+	if len(linked) == 0 {
+		return
+	}
+	set := append([]linkedWorktree{main}, linked...)
+	for i, w := range set {
+		var b strings.Builder
+		b.WriteString("This PR is part of a change spanning several PRs. The others are checked out next to this worktree:\n\n")
+		for j, o := range set {
+			if j == i {
+				continue
+			}
+			fmt.Fprintf(&b, "- %s", o.Ref)
+			if o.Title != "" {
+				fmt.Fprintf(&b, " %q", o.Title)
+			}
+			fmt.Fprintf(&b, ": `%s`\n", o.Path)
+		}
+		b.WriteString("\nReview the changes as one: check that they agree with each other and can be merged in order.")
+		if err := ctxpkg.SetNote(w.Path, linkedNoteHeading, b.String()); err != nil {
+			ui.LogWarn(fmt.Sprintf("Could not note the linked PRs in %s: %v", ui.ShortenHome(w.Path, homeDir()), err))
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var reviewLockCmd = &cobra.Command{
+	Use:   "lock <pr-number>",
+	Short: "Make a PR review worktree read-only",
+	Long: `Blocks commits, merge commits and pushes from a PR review worktree, so
+nothing gets committed to its pr-N branch by accident. Git refuses them
+with a message pointing at zen review unlock. Other worktrees of the repo
+are not affected.
+
+The repo's own git hooks don't run in a locked worktree, and
+git commit --no-verify still gets through.
+
+Set readonly: true on a repo to lock its new review worktrees
+automatically.
+
+Example:
+  zen review lock 42
+  zen review lock 42 --name tests`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReviewLock,
+}
+
+var reviewUnlockCmd = &cobra.Command{
+	Use:   "unlock <pr-number>",
+	Short: "Allow commits in a read-only PR review worktree again",
+	Long: `Removes the protection added by zen review lock or the repo's readonly
+setting, e.g. to commit and push a suggestion to the PR branch. The
+worktree stays unlocked; run zen review lock to protect it again.
+
+Example:
+  zen review unlock 42`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReviewUnlock,
+}
+
+func init() {
+	reviewLockCmd.Flags().StringVar(&reviewName, "name", "", "Lock the <repo>-pr-N-<name> checkout")
+	reviewUnlockCmd.Flags().StringVar(&reviewName, "name", "", "Unlock the <repo>-pr-N-<name> checkout")
+	reviewCmd.AddCommand(reviewLockCmd)
+	reviewCmd.AddCommand(reviewUnlockCmd)
+}
+
+// reviewLockResult is the JSON output of zen review lock and unlock.
+type reviewLockResult struct {
+	Worktree string `json:"worktree"`
+	ReadOnly bool   `json:"read_only"`
+}
+
+func runReviewLock(cmd *cobra.Command, args []string) error {
+	return setReviewLock(args[0], true)
+}
+
+func runReviewUnlock(cmd *cobra.Command, args []string) error {
+	return setReviewLock(args[0], false)
+}
+
+func setReviewLock(arg string, lock bool) error {
+	prNumber, err := parsePRArg(arg, nil)
+	if err != nil {
+		return err
+	}
+	w, err := findWorktreeByPR(prNumber, reviewName)
+	if err != nil {
+		return err
+	}
+
+	if lock {
+		err = wt.LockReview(w.Path, prNumber, w.Suffix)
+	} else {
+		err = wt.Unlock(w.Path)
+	}
+	if err != nil {
+		return fmt.Errorf("updating %s: %w", w.Name, err)
+	}
+
+	if jsonFlag {
+		printJSON(reviewLockResult{Worktree: w.Path, ReadOnly: lock})
+		return nil
+	}
+	if lock {
+		ui.LogSuccess(fmt.Sprintf("%s is read-only: commits and pushes are blocked", w.Name))
+		ui.Hint(fmt.Sprintf("Undo with: zen review unlock %d%s", prNumber, nameFlag(w.Suffix)))
+	} else {
+		ui.LogSuccess(fmt.Sprintf("%s is unlocked: commits and pushes are allowed", w.Name))
+	}
+	return nil
+}
+
+// nameFlag returns the --name flag selecting a PR checkout with suffix,
+// or "" for the main review worktree.
+func nameFlag(suffix string) string {
+	if suffix == "" {
+		return ""
+	}
+	return " --name " + suffix
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var reviewNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Start the review of the PR that most needs it",
+	Long: `Picks the pending review request to do next across the configured repos,
+creates its worktree and opens the review tab, like zen review <pr-number>.
+
+PRs are ranked by:
+
+  1. PRs with an urgent label (labels.urgent)
+  2. PRs requesting your review, then re-reviews, then team requests
+  3. oldest first
+
+PRs from authors outside the authors filter are skipped, as are PRs whose
+worktree already has a Claude session: those were started already, and
+zen review resume picks them up.
+
+Example:
+  zen review next
+  zen review next --dry-run       Show the ranking without starting a review
+  zen review next --repo @backend`,
+	Args: cobra.NoArgs,
+	RunE: runReviewNext,
+}
+
+var (
+	reviewNextRepo   string
+	reviewNextAll    bool
+	reviewNextDryRun bool
+)
+
+func init() {
+	reviewNextCmd.Flags().StringVar(&reviewNextRepo, "repo", "", "Repository short name or @group (default: all configured repos)")
+	reviewNextCmd.Flags().BoolVar(&reviewNextAll, "all", false, "Consider PRs from all authors")
+	reviewNextCmd.Flags().BoolVar(&reviewNextDryRun, "dry-run", false, "Print the ranked PRs without starting a review")
+	reviewNextCmd.Flags().BoolVar(&reviewNoITerm, "no-terminal", false, "Create worktree only, don't open terminal tab")
+	reviewNextCmd.Flags().StringVarP(&reviewModel, "model", "m", "", "Claude model to use (e.g., sonnet, opus, haiku)")
+	addTerminalFlag(reviewNextCmd)
+	addPairFlag(reviewNextCmd)
+	reviewCmd.AddCommand(reviewNextCmd)
+}
+
+// NextReview is a candidate of zen review next.
+type NextReview struct {
+	Repo      string `json:"repo"`
+	PRNumber  int    `json:"pr_number"`
+	Title     string `json:"title"`
+	Author    string `json:"author"`
+	CreatedAt string `json:"created_at"`
+	URL       string `json:"url"`
+	Urgent    bool   `json:"urgent,omitempty"`
+	Rereview  bool   `json:"rereview,omitempty"`
+	Team      string `json:"team,omitempty"` // requested from this team only
+}
+
+// rank orders candidates: urgent first, then personal requests, re-reviews
+// and team requests, then oldest first.
+func (n NextReview) rank() int {
+	r := 0
+	switch {
+	case n.Team != "":
+		r = 2
+	case n.Rereview:
+		r = 1
+	}
+	if !n.Urgent {
+		r += 3
+	}
+	return r
+}
+
+// reason describes why a candidate ranks where it does.
+func (n NextReview) reason() string {
+	var parts []string
+	if n.Urgent {
+		parts = append(parts, "urgent")
+	}
+	switch {
+	case n.Team != "":
+		parts = append(parts, "team "+n.Team)
+	case n.Rereview:
+		parts = append(parts, "re-review")
+	default:
+		parts = append(parts, "requested")
+	}
+	if age := ageCell(n.CreatedAt); age != "" {
+		parts = append(parts, "opened "+age)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func runReviewNext(cmd *cobra.Command, _ []string) error {
+	repos, err := cfg.ResolveRepos(reviewNextRepo)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var authors []string
+	if !reviewNextAll {
+		if err := loadTeamAuthors(ctx); err != nil {
+			ui.LogWarn(err.Error())
+		}
+		authors = cfg.AllAuthors()
+	}
+
+	candidates, err := nextReviewCandidates(ctx, repos, authors)
+	if err != nil {
+		return err
+	}
+
+	if reviewNextDryRun {
+		if jsonFlag {
+			printJSON(candidates)
+			return nil
+		}
+		if len(candidates) == 0 {
+			ui.LogInfo("No pending review requests")
+			return nil
+		}
+		tbl := ui.NewTable([]ui.Column{
+			{Key: "repo", Header: "Repo"},
+			{Key: "pr", Header: "#"},
+			{Key: "reason", Header: "Why"},
+			{Key: "author", Header: "Author"},
+			{Key: "title", Header: "Title"},
+		}, nil)
+		for _, c := range candidates {
+			tbl.Row(c.Repo, prCell(c.PRNumber), c.reason(), c.Author, c.Title)
+		}
+		tbl.Print()
+		return nil
+	}
+
+	if len(candidates) == 0 {
+		ui.LogInfo("No pending review requests: nothing to review next")
+		if !reviewNextAll && len(authors) > 0 {
+			ui.Hint("Authors: " + authorsLabel(authors))
+			ui.Hint("Use --all to consider all authors")
+		}
+		return nil
+	}
+	next := candidates[0]
+	ui.LogInfo(fmt.Sprintf("Next review: %s #%d — %s (%s)", next.Repo, next.PRNumber, next.Title, next.reason()))
+	if len(candidates) > 1 {
+		ui.Hint(fmt.Sprintf("%d more waiting (zen review next --dry-run)", len(candidates)-1))
+	}
+
+	reviewRepo = next.Repo
+	return runReview(cmd, []string{strconv.Itoa(next.PRNumber)})
+}
+
+// nextReviewCandidates fetches the pending review requests of repos and
+// returns the ones not started yet, best first.
+func nextReviewCandidates(ctx context.Context, repos, authors []string) ([]NextReview, error) {
+	var (
+		mu         sync.Mutex
+		candidates []NextReview
+	)
+	limit := cfg.GetSearchLimit()
+	g, gctx := errgroup.WithContext(ctx)
+	for _, repo := range repos {
+		g.Go(func() error {
+			fullRepo := cfg.RepoFullName(repo)
+			reviews, _, err := ghpkg.GetReviewRequests(gctx, fullRepo, limit)
+			if err != nil {
+				return fmt.Errorf("fetching review requests for %s: %w", repo, err)
+			}
+			var team []ghpkg.ReviewRequest
+			if len(cfg.Teams) > 0 {
+				teamReviews, _, err := ghpkg.GetTeamReviewRequests(gctx, fullRepo, cfg.Teams, limit)
+				if err != nil {
+					ui.LogWarn(fmt.Sprintf("fetching team review requests for %s: %v", repo, err))
+				}
+				team = teamOnlyRequests(filterByAuthors(teamReviews, authors), reviews)
+			}
+
+			var found []NextReview
+			for _, pr := range append(filterByAuthors(reviews, authors), team...) {
+				if reviewStarted(repo, pr.Number) {
+					continue
+				}
+				found = append(found, NextReview{
+					Repo:      repo,
+					PRNumber:  pr.Number,
+					Title:     pr.Title,
+					Author:    pr.Author.Login,
+					CreatedAt: pr.CreatedAt,
+					URL:       pr.URL,
+					Urgent:    cfg.Labels.IsUrgent(pr.Labels.Names()),
+					Rereview:  pr.Rereview,
+					Team:      pr.Team,
+				})
+			}
+			mu.Lock()
+			candidates = append(candidates, found...)
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.rank() != b.rank() {
+			return a.rank() < b.rank()
+		}
+		if a.CreatedAt != b.CreatedAt {
+			return a.CreatedAt < b.CreatedAt
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.PRNumber < b.PRNumber
+	})
+	return candidates, nil
+}
+
+// reviewStarted reports whether a PR's review worktree has a Claude
+// session already.
+func reviewStarted(repo string, prNumber int) bool {
+	basePath := cfg.RepoBasePath(repo)
+	if basePath == "" {
+		return false
+	}
+	path := filepath.Join(basePath, wt.PRName(repo, prNumber, ""))
+	if _, err := os.Stat(path); err != nil {
+		return false
+	}
+	return session.HasActiveSession(path)
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var (
+	notesPrint bool
+	notesPath  bool
+)
+
+var reviewNotesCmd = &cobra.Command{
+	Use:   "notes <pr-number>",
+	Short: "Open or print the notes of a PR review",
+	Long: `Opens the PR review worktree's NOTES.zen.md in your editor (pair.editor,
+$VISUAL or $EDITOR), or prints it when not run from a terminal or with
+--print. The file is created with each review worktree and kept out of git:
+jot your findings there, next to what zen review capture records.
+
+When the worktree is deleted, by zen review delete, zen cleanup or the
+daemon once the PR is merged, its notes are archived in the state
+directory, which zen export includes. zen review notes then shows the
+archived notes, and checking the PR out again restores them.
+
+Add the notes to the review summary with: zen agent report <pr> --notes
+
+Example:
+  zen review notes 42
+  zen review notes 42 --print
+  zen review notes 42 --name perf --path`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReviewNotes,
+}
+
+func init() {
+	reviewNotesCmd.Flags().StringVar(&reviewName, "name", "", "Use the notes of the <repo>-pr-N-<name> checkout")
+	reviewNotesCmd.Flags().BoolVar(&notesPrint, "print", false, "Print the notes instead of opening an editor")
+	reviewNotesCmd.Flags().BoolVar(&notesPath, "path", false, "Only print the path of the notes file")
+	reviewNotesCmd.MarkFlagsMutuallyExclusive("print", "path")
+	reviewCmd.AddCommand(reviewNotesCmd)
+}
+
+// reviewNotesResult is the JSON output of zen review notes.
+type reviewNotesResult struct {
+	Worktree string `json:"worktree,omitempty"`
+	Path     string `json:"path"`
+	Archived bool   `json:"archived"`
+	Notes    string `json:"notes"`
+}
+
+func runReviewNotes(cmd *cobra.Command, args []string) error {
+	prNumber, err := parsePRArg(args[0], nil)
+	if err != nil {
+		return err
+	}
+
+	var res reviewNotesResult
+	w, err := findWorktreeByPR(prNumber, reviewName)
+	var nwErr *noWorktreeError
+	switch {
+	case err == nil:
+		// Worktrees created before notes were standard have none yet
+		if _, err := ctxpkg.EnsureNotes(w.Path, ctxpkg.NotesTitle(prNumber)); err != nil {
+			return err
+		}
+		res.Worktree, res.Path = w.Path, ctxpkg.NotesPath(w.Path)
+	case errors.As(err, &nwErr):
+		if res.Path = archivedPRNotes(prNumber, reviewName); res.Path == "" {
+			return fmt.Errorf("%w, and no archived notes for it", err)
+		}
+		res.Archived = true
+	default:
+		return err
+	}
+
+	data, err := os.ReadFile(res.Path)
+	if err != nil {
+		return fmt.Errorf("reading notes: %w", err)
+	}
+	res.Notes = string(data)
+
+	switch {
+	case jsonFlag:
+		printJSON(res)
+		return nil
+	case notesPath:
+		fmt.Println(res.Path)
+		return nil
+	case notesPrint || !isInteractive() || !stdoutIsTerminal():
+		if res.Archived {
+			ui.LogInfo(fmt.Sprintf("Worktree deleted, showing archived notes: %s", ui.ShortenHome(res.Path, homeDir())))
+		}
+		fmt.Print(res.Notes)
+		return nil
+	}
+
+	if res.Archived {
+		ui.LogInfo(fmt.Sprintf("Worktree deleted, opening archived notes: %s", ui.ShortenHome(res.Path, homeDir())))
+	}
+	editor := strings.Fields(cfg.Pair.GetEditor())
+	edit := exec.Command(editor[0], append(editor[1:], res.Path)...)
+	edit.Stdin, edit.Stdout, edit.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := edit.Run(); err != nil {
+		return fmt.Errorf("running %s: %w", editor[0], err)
+	}
+	return nil
+}
+
+// archivedPRNotes returns the archived notes of PR prNumber's review
+// checkout named suffix, in the PR URL's repo or else any configured
+// repo, or "" when there are none.
+func archivedPRNotes(prNumber int, suffix string) string {
+	repos := cfg.RepoNames()
+	if prArgRepo != "" {
+		repos = []string{prArgRepo}
+	}
+	for _, repo := range repos {
+		path := ctxpkg.ArchivedNotesPath(wt.PRName(repo, prNumber, suffix))
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// stdoutIsTerminal reports whether stdout is a terminal rather than a pipe
+// or a file.
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/review"
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+)
+
+// runReviewPatch creates a scratch worktree with the patch at src applied
+// and opens a review session in it.
+func runReviewPatch(ctx context.Context, src string) error {
+	repo, err := patchRepo()
+	if err != nil {
+		return err
+	}
+	name := reviewName
+	if name == "" {
+		name = review.PatchName(src)
+	}
+	if err := wt.ValidateSuffix(name); err != nil {
+		return err
+	}
+
+	ui.LogInfo(fmt.Sprintf("Loading patch from %s...", src))
+	patch, err := review.LoadPatch(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	steps := ui.NewSteps()
+	result, err := review.CreatePatchWorktree(ctx, cfg, repo, patch, name, steps)
+	if err != nil {
+		return err
+	}
+
+	if jsonFlag {
+		printJSON(result)
+		return nil
+	}
+
+	fmt.Println()
+	ui.LogSuccess(fmt.Sprintf("Created worktree: %s", ui.ShortenHome(result.WorktreePath, homeDir())))
+	if result.Subject != "" {
+		fmt.Printf("  Patch:  %s\n", result.Subject)
+	}
+	if patch.Author != "" {
+		fmt.Printf("  Author: %s\n", patch.Author)
+	}
+	fmt.Printf("  Base:   %s (%d file(s) changed, staged)\n", ui.CyanText(result.Base), len(result.Files))
+
+	claudeCmd, model := claudeCommand(repo, result.WorktreePath, reviewModel)
+	if model != "" {
+		fmt.Printf("  Model:  %s\n", ui.CyanText(model))
+	}
+
+	if reviewNoITerm {
+		fmt.Println()
+		fmt.Println(ui.BoldText("Open manually:"))
+		modelFlag := ""
+		if model != "" {
+			modelFlag = fmt.Sprintf(" --model %s", model)
+		}
+		fmt.Printf("  cd %s && %s%s %q\n", result.WorktreePath, claudeCmd, modelFlag, ctxpkg.PatchPrompt)
+		return nil
+	}
+
+	term, err := newTerminal()
+	if err != nil {
+		return err
+	}
+	if err := term.OpenTabWithClaude(result.WorktreePath, ctxpkg.PatchPrompt, claudeCmd, model); err != nil {
+		return fmt.Errorf("opening %s tab (the worktree is ready -- retry with: zen work resume %s): %w", term.Name(), result.Branch, err)
+	}
+
+	logTabOpened(term)
+	fmt.Println()
+	return nil
+}
+
+// patchRepo picks the repo a patch is applied to: --repo, the only
+// configured repo (of the group, with --repo @group), or the repo of the
+// worktree the command runs in.
+func patchRepo() (string, error) {
+	repos, err := cfg.ResolveRepos(reviewRepo)
+	if err != nil {
+		return "", err
+	}
+	if len(repos) == 1 {
+		return repos[0], nil
+	}
+	if w, err := currentWorktree(); err == nil && slices.Contains(repos, w.Repo) {
+		return w.Repo, nil
+	}
+	return "", fmt.Errorf("which repo does the patch apply to? Pass --repo (one of %s)", strings.Join(repos, ", "))
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/mgreau/zen/internal/github"
 	"github.com/mgreau/zen/internal/prcache"
 	"github.com/mgreau/zen/internal/session"
 	"github.com/mgreau/zen/internal/ui"
@@ -20,6 +21,7 @@ var reviewsDays int
 
 func init() {
 	reviewsCmd.Flags().IntVarP(&reviewsDays, "days", "d", 7, "Show reviews from past N days")
+	addColumnsFlag(reviewsCmd, "reviews")
 	rootCmd.AddCommand(reviewsCmd)
 }
 
@@ -28,9 +30,16 @@ type ReviewEntry struct {
 	worktree.Worktree
 	Title      string `json:"title,omitempty"`
 	HasSession bool   `json:"has_active_session"`
+
+	// MyReview is your latest review of the PR, as last seen on GitHub
+	MyReview *github.ReviewOutcome `json:"my_review,omitempty"`
 }
 
 func runReviews(cmd *cobra.Command, args []string) error {
+	columns, err := tableColumns("reviews")
+	if err != nil {
+		return err
+	}
 	wts, err := worktree.ListAll(cfg)
 	if err != nil {
 		return fmt.Errorf("listing worktrees: %w", err)
@@ -65,6 +74,7 @@ func runReviews(cmd *cobra.Command, args []string) error {
 				Worktree:   r,
 				Title:      title,
 				HasSession: session.HasActiveSession(r.Path),
+				MyReview:   prCache[key].Review,
 			})
 		}
 		printJSON(entries)
@@ -82,9 +92,15 @@ func runReviews(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Printf("%-8s %-12s %-45s %s\n", "PR#", "Repo", "Title", "Session")
-	fmt.Printf("%-8s %-12s %-45s %s\n", "────────", "────────────", "─────────────────────────────────────────────", "───────")
-
+	t := ui.NewTable([]ui.Column{
+		{Key: "pr", Header: "PR#"},
+		{Key: "repo", Header: "Repo", Max: 20},
+		{Key: "title", Header: "Title", Flex: true, Min: 20},
+		{Key: "review", Header: "Review", Optional: true},
+		{Key: "session", Header: "Session"},
+		{Key: "path", Header: "Path"},
+	}, columns)
+	t.Indent = ""
 	home := homeDir()
 	for _, r := range reviews {
 		key := fmt.Sprintf("%s/%d", r.Repo, r.PRNumber)
@@ -98,14 +114,12 @@ func runReviews(cmd *cobra.Command, args []string) error {
 			sessionIndicator = ui.GreenText("●")
 		}
 
-		shortTitle := ui.Truncate(title, 43)
-		if shortTitle == "" {
-			shortTitle = r.Name
+		if title == "" {
+			title = r.Name
 		}
-
-		fmt.Printf("%-8s %-12s %-45s %s\n", fmt.Sprintf("#%d", r.PRNumber), r.Repo, shortTitle, sessionIndicator)
-		fmt.Printf("         %s\n", ui.DimText(ui.ShortenHome(r.Path, home)))
+		t.Row(fmt.Sprintf("#%d", r.PRNumber), r.Repo, title, reviewCell(prCache[key].Review), sessionIndicator, ui.DimText(ui.ShortenHome(r.Path, home)))
 	}
+	t.Print()
 
 	fmt.Println()
 	ui.Hint("● = Active Claude session")
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mgreau/zen/internal/config"
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/reconciler"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var reviewWatchCmd = &cobra.Command{
+	Use:   "watch [pr-number]",
+	Short: "Get notified about new commits, comments, CI and merge of a PR",
+	Long: `Subscribes to a PR's events in the watch daemon. At every poll the
+daemon checks the PR and sends a notification when:
+
+  - new commits are pushed
+  - new comments are posted (conversation or review comments)
+  - all CI checks on the head commit have finished
+  - the PR is merged or closed
+
+Merged and closed PRs stop being watched. Watched PRs are marked with 👁
+in zen status. Without a PR number, lists the watched PRs.
+
+Example:
+  zen review watch 42
+  zen review watch
+  zen review unwatch 42`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReviewWatch,
+}
+
+var reviewUnwatchCmd = &cobra.Command{
+	Use:   "unwatch <pr-number>",
+	Short: "Stop watching a PR",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runReviewUnwatch,
+}
+
+var reviewWatchRepo string
+
+func init() {
+	reviewWatchCmd.Flags().StringVar(&reviewWatchRepo, "repo", "", "Repository short name or @group (auto-detected if omitted)")
+	reviewUnwatchCmd.Flags().StringVar(&reviewWatchRepo, "repo", "", "Repository short name (needed only if PRs with this number are watched in several repos)")
+	reviewCmd.AddCommand(reviewWatchCmd)
+	reviewCmd.AddCommand(reviewUnwatchCmd)
+}
+
+func runReviewWatch(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return listWatchedPRs()
+	}
+	prNumber, err := parsePRArg(args[0], &reviewWatchRepo)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	repo := reviewWatchRepo
+	if repo == "" || config.IsGroupRef(repo) {
+		detected, err := detectRepoForPR(ctx, prNumber, repo)
+		if err != nil {
+			return err
+		}
+		repo = detected
+	}
+
+	// The current activity is the baseline, so the first poll only
+	// reports what happens from now on
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("creating GitHub client: %w", err)
+	}
+	activity, err := client.GetPRActivity(ctx, cfg.RepoFullName(repo), prNumber)
+	if err != nil {
+		return err
+	}
+	if activity.State != "OPEN" {
+		return fmt.Errorf("PR #%d is %s; only open PRs can be watched", prNumber, activity.State)
+	}
+
+	w := reconciler.WatchedPR{Repo: repo, PRNumber: prNumber, Last: activity}
+	if err := reconciler.WatchPR(w); err != nil {
+		return fmt.Errorf("saving watch: %w", err)
+	}
+
+	if jsonFlag {
+		printJSON(w)
+		return nil
+	}
+	ui.LogSuccess(fmt.Sprintf("Watching %s PR #%d: %s", repo, prNumber, activity.Title))
+	if running, _ := watchIsRunning(); !running {
+		ui.LogWarn("The watch daemon is not running, so no notifications will be sent -- start it with: zen watch start")
+	}
+	return nil
+}
+
+func runReviewUnwatch(cmd *cobra.Command, args []string) error {
+	prNumber, err := parsePRArg(args[0], &reviewWatchRepo)
+	if err != nil {
+		return err
+	}
+
+	repo := reviewWatchRepo
+	if repo == "" {
+		var repos []string
+		for _, w := range reconciler.WatchedPRs() {
+			if w.PRNumber == prNumber {
+				repos = append(repos, w.Repo)
+			}
+		}
+		switch len(repos) {
+		case 0:
+			return fmt.Errorf("PR #%d is not watched", prNumber)
+		case 1:
+			repo = repos[0]
+		default:
+			return fmt.Errorf("PR #%d is watched in %d repos; pass --repo", prNumber, len(repos))
+		}
+	}
+
+	ok, err := reconciler.UnwatchPR(repo, prNumber)
+	if err != nil {
+		return fmt.Errorf("saving watches: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%s PR #%d is not watched", repo, prNumber)
+	}
+	ui.LogSuccess(fmt.Sprintf("No longer watching %s PR #%d", repo, prNumber))
+	return nil
+}
+
+func listWatchedPRs() error {
+	watched := reconciler.WatchedPRs()
+	if jsonFlag {
+		printJSON(watched)
+		return nil
+	}
+	if len(watched) == 0 {
+		fmt.Println("No watched PRs.")
+		ui.Hint("'zen review watch <number>' to get notified about a PR")
+		return nil
+	}
+
+	fmt.Println()
+	ui.SectionHeader("Watched PRs")
+	fmt.Printf("  %-6s  %-12s  %-42s  %s\n", "PR", "Repo", "Title", "Since")
+	fmt.Printf("  %-6s  %-12s  %-42s  %s\n", "──────", "────────────", "──────────────────────────────────────────", "──────────")
+	for _, w := range watched {
+		title := ""
+		if w.Last != nil {
+			title = w.Last.Title
+		}
+		fmt.Printf("  %s  %-12s  %-42s  %s\n",
+			ui.CyanText(fmt.Sprintf("#%-5d", w.PRNumber)),
+			ui.Truncate(w.Repo, 12),
+			ui.Truncate(title, 42),
+			session.FormatAge(w.Since))
+	}
+	fmt.Println()
+	ui.Hint("'zen review unwatch <number>' to stop watching")
+	fmt.Println()
+	return nil
+}
@@ -4,8 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/mgreau/zen/internal/audit"
 	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/metrics"
 	"github.com/mgreau/zen/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -17,9 +22,11 @@ var (
 )
 
 var (
-	debugFlag bool
-	jsonFlag  bool
-	cfg       *config.Config
+	debugFlag   bool
+	jsonFlag    bool
+	noColorFlag bool
+	configFlag  string
+	cfg         *config.Config
 )
 
 var rootCmd = &cobra.Command{
@@ -27,24 +34,59 @@ var rootCmd = &cobra.Command{
 	Short: "Worktree orchestrator for PR reviews and feature work",
 	Long: `zen - Worktree orchestrator for PR reviews and feature work
 
-Manages git worktrees and Claude Code sessions across iTerm tabs.
+Manages git worktrees and Claude Code sessions across terminal tabs.
 Silently prepares worktrees, retries failures, and cleans up after itself.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// --config is exported as ZEN_CONFIG so that config reloads and
+		// child processes (watch daemon, supervisor) use the same file.
+		if configFlag != "" {
+			abs, err := filepath.Abs(configFlag)
+			if err != nil {
+				return fmt.Errorf("resolving --config: %w", err)
+			}
+			os.Setenv("ZEN_CONFIG", abs)
+		}
+
 		ui.DebugEnabled = debugFlag
 		if debugFlag {
 			os.Setenv("ZEN_DEBUG", "1")
 		}
+		if noColorFlag {
+			ui.SetColorsEnabled(false)
+		}
+
+		metricsCommand = metricsName(cmd, args)
+		audit.SetSource(auditSource(metricsCommand), metricsCommand)
 
-		if cmd.Name() == "setup" || cmd.Name() == "version" {
+		// import restores the config on a new machine, so there is none
+		// yet, shell startup files run shellenv whatever the config, and
+		// migrate fixes configs that may not load
+		if cmd.Name() == "setup" || cmd.Name() == "version" || cmd.Name() == "import" || cmd.Name() == "shellenv" || cmd.Name() == "migrate" {
 			return nil
 		}
 
 		var err error
 		cfg, err = config.Load()
+		if err != nil && cmd.Name() == "reset" {
+			// reset must work even with a missing or broken config
+			cfg = nil
+			return nil
+		}
+		// A config from an older zen that an upgrade would change is
+		// upgraded first if the user agrees; one that doesn't load says so
+		// in the error otherwise
+		outdated := err == nil && cfg.Outdated()
+		if err != nil && isInteractive() && !jsonFlag && !quietFlag {
+			v, verr := config.FileVersion(config.Path())
+			outdated = verr == nil && v < config.CurrentVersion
+		}
+		if outdated && config.NeedsMigration(config.Path()) && offerConfigMigration(metricsCommand) {
+			cfg, err = config.Load()
+		}
 		if err != nil {
 			return fmt.Errorf("loading config: %w", err)
 		}
-		return nil
+		return ui.SetTheme(cfg.Theme)
 	},
 	Version:       Version,
 	SilenceUsage:  true,
@@ -54,11 +96,61 @@ Silently prepares worktrees, retries failures, and cleans up after itself.`,
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "Enable debug output")
 	rootCmd.PersistentFlags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	rootCmd.PersistentFlags().StringVar(&configFlag, "config", "", "Config file (default ~/.zen/config.yaml, or $ZEN_CONFIG)")
+	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable colored output (also NO_COLOR env)")
+}
+
+// metricsCommand is the name the running command is recorded under when
+// metrics are enabled, set once flags are parsed.
+var metricsCommand string
+
+// untimedCommands run until stopped, so their durations say nothing about
+// speed.
+var untimedCommands = map[string]bool{
+	"watch daemon": true,
+	"watch logs":   true,
+	"mcp serve":    true,
+	"serve":        true,
+}
+
+// auditSource returns the audit log source of a command: the long-running
+// ones act on behalf of the daemon, an agent or API clients.
+func auditSource(command string) string {
+	switch command {
+	case "watch daemon":
+		return audit.SourceDaemon
+	case "mcp serve":
+		return audit.SourceMCP
+	case "serve":
+		return audit.SourceAPI
+	}
+	return audit.SourceCLI
+}
+
+// metricsName returns the command path without the binary name. watch takes
+// its action as an argument, which is included.
+func metricsName(cmd *cobra.Command, args []string) string {
+	name := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if name == "watch" && len(args) > 0 {
+		name += " " + args[0]
+	}
+	return name
 }
 
-// Execute runs the root command.
+// Execute runs the root command, recording its duration when metrics are
+// enabled in the config.
 func Execute() error {
-	return rootCmd.Execute()
+	start := time.Now()
+	err := rootCmd.Execute()
+	if cfg != nil && cfg.Metrics && metricsCommand != "" && !untimedCommands[metricsCommand] {
+		metrics.Append(metrics.Record{
+			Time:       start,
+			Command:    metricsCommand,
+			DurationMS: time.Since(start).Milliseconds(),
+			Failed:     err != nil,
+		})
+	}
+	return err
 }
 
 // printJSON is a helper that marshals v to JSON and prints it.
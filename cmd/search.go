@@ -3,8 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/mgreau/zen/internal/prcache"
 	"github.com/mgreau/zen/internal/session"
 	"github.com/mgreau/zen/internal/ui"
 	"github.com/mgreau/zen/internal/worktree"
@@ -13,34 +17,81 @@ import (
 
 var searchCmd = &cobra.Command{
 	Use:   "search <term>",
-	Short: "Search across PR reviews and worktrees",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runSearch,
+	Short: "Search worktrees, cached PR titles and Claude sessions",
+	Long: `Searches, ignoring case:
+
+  worktrees  worktree names, paths, branches, repos, PR numbers and the
+             contents of CLAUDE.local.md
+  prs        cached PR titles and authors, including PRs whose worktree
+             was removed
+  sessions   prompts and replies in Claude session transcripts (slower)
+
+--in picks the sources; worktrees and prs are searched by default.
+
+Example:
+  zen search retry
+  zen search mgreau --in prs
+  zen search "flaky test" --in sessions`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSearch,
 }
 
-var searchType string
+var (
+	searchType string
+	searchIn   []string
+)
+
+// Search sources for --in.
+const (
+	searchInWorktrees = "worktrees"
+	searchInPRs       = "prs"
+	searchInSessions  = "sessions"
+)
+
+// searchSessionMatches caps the transcript matches shown per session.
+const searchSessionMatches = 3
 
 func init() {
 	searchCmd.Flags().StringVarP(&searchType, "type", "t", "all", "Filter by type: all, pr, feature")
+	searchCmd.Flags().StringSliceVar(&searchIn, "in", []string{searchInWorktrees, searchInPRs}, "Sources to search: worktrees, prs, sessions")
 	rootCmd.AddCommand(searchCmd)
 }
 
 // SearchResult holds a search result.
 type SearchResult struct {
-	Name       string `json:"name"`
-	Path       string `json:"path,omitempty"`
-	Type       string `json:"type"`
-	PRNumber   int    `json:"pr_number,omitempty"`
-	Branch     string `json:"branch,omitempty"`
-	Repo       string `json:"repo,omitempty"`
-	HasSession bool   `json:"has_session"`
+	Name       string        `json:"name"`
+	Path       string        `json:"path,omitempty"`
+	Type       string        `json:"type"` // pr-review, feature, or pr for a cached PR without worktree
+	PRNumber   int           `json:"pr_number,omitempty"`
+	Branch     string        `json:"branch,omitempty"`
+	Repo       string        `json:"repo,omitempty"`
+	Title      string        `json:"title,omitempty"`
+	HasSession bool          `json:"has_session"`
+	Matches    []SearchMatch `json:"matches"`
+}
+
+// SearchMatch is one place a result matched the search term.
+type SearchMatch struct {
+	Field     string `json:"field"` // name, path, branch, repo, pr, title, author, CLAUDE.local.md, session
+	Text      string `json:"text"`
+	SessionID string `json:"session_id,omitempty"`
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
 	term := args[0]
 	termLower := strings.ToLower(term)
 
-	results := searchWorktrees(termLower)
+	in := make(map[string]bool)
+	for _, src := range searchIn {
+		switch src {
+		case searchInWorktrees, searchInPRs, searchInSessions:
+			in[src] = true
+		default:
+			return fmt.Errorf("invalid --in %q: must be worktrees, prs or sessions", src)
+		}
+	}
+
+	results := searchWorktrees(termLower, in)
 
 	if jsonFlag {
 		printJSON(results)
@@ -60,13 +111,15 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	}
 
 	// Group by type
-	var prResults, featureResults, otherResults []SearchResult
+	var prResults, featureResults, cachedResults, otherResults []SearchResult
 	for _, r := range results {
 		switch {
 		case r.Type == "pr-review":
 			prResults = append(prResults, r)
 		case r.Type == "feature":
 			featureResults = append(featureResults, r)
+		case r.Type == "pr":
+			cachedResults = append(cachedResults, r)
 		default:
 			otherResults = append(otherResults, r)
 		}
@@ -81,7 +134,9 @@ func runSearch(cmd *cobra.Command, args []string) error {
 			if r.HasSession {
 				sessionInd = " " + ui.GreenText("●")
 			}
-			if r.PRNumber > 0 {
+			if r.PRNumber > 0 && r.Title != "" {
+				fmt.Printf("  %s %s%s\n", ui.CyanText(fmt.Sprintf("PR #%d", r.PRNumber)), ui.Highlight(r.Title, term), sessionInd)
+			} else if r.PRNumber > 0 {
 				fmt.Printf("  %s%s\n", ui.CyanText(fmt.Sprintf("PR #%d", r.PRNumber)), sessionInd)
 			} else {
 				fmt.Printf("  %s%s\n", ui.CyanText(r.Name), sessionInd)
@@ -92,6 +147,7 @@ func runSearch(cmd *cobra.Command, args []string) error {
 			if r.Repo != "" {
 				fmt.Printf("    %s\n", ui.DimText("Repo: "+r.Repo))
 			}
+			printSearchMatches(r, term)
 		}
 		fmt.Println()
 	}
@@ -112,6 +168,17 @@ func runSearch(cmd *cobra.Command, args []string) error {
 			} else if r.Repo != "" {
 				fmt.Printf("    %s\n", ui.DimText("Repo: "+r.Repo))
 			}
+			printSearchMatches(r, term)
+		}
+		fmt.Println()
+	}
+
+	if len(cachedResults) > 0 {
+		ui.SectionHeader(fmt.Sprintf("Cached PRs Without Worktree (%d)", len(cachedResults)))
+		for _, r := range cachedResults {
+			fmt.Printf("  %s %s\n", ui.CyanText(fmt.Sprintf("PR #%d", r.PRNumber)), ui.Highlight(r.Title, term))
+			fmt.Printf("    %s\n", ui.DimText("Repo: "+r.Repo))
+			printSearchMatches(r, term)
 		}
 		fmt.Println()
 	}
@@ -134,14 +201,38 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func searchWorktrees(termLower string) []SearchResult {
+// printSearchMatches prints the fields a result matched in, except those
+// already visible in its header lines.
+func printSearchMatches(r SearchResult, term string) {
+	for _, m := range r.Matches {
+		switch m.Field {
+		case "name", "path", "repo", "branch", "pr", "title":
+			continue
+		}
+		label := m.Field
+		if m.SessionID != "" {
+			label = "session " + ui.Truncate(m.SessionID, 8)
+		}
+		fmt.Printf("    %s %s\n", ui.DimText(label+":"), ui.Highlight(m.Text, term))
+	}
+}
+
+// searchSnippetWidth is the width of match excerpts from file contents.
+const searchSnippetWidth = 90
+
+func searchWorktrees(termLower string, in map[string]bool) []SearchResult {
 	wts, err := worktree.ListAll(cfg)
 	if err != nil {
 		return nil
 	}
+	cache := prcache.Load()
 
 	var results []SearchResult
+	withWorktree := make(map[string]bool) // prcache keys of PR review worktrees
 	for _, wt := range wts {
+		if wt.Type == worktree.TypePRReview {
+			withWorktree[fmt.Sprintf("%s/%d", wt.Repo, wt.PRNumber)] = true
+		}
 		if searchType == "pr" && wt.Type != worktree.TypePRReview {
 			continue
 		}
@@ -149,29 +240,51 @@ func searchWorktrees(termLower string) []SearchResult {
 			continue
 		}
 
-		matched := false
-		nameLower := strings.ToLower(wt.Name)
-		pathLower := strings.ToLower(wt.Path)
-		branchLower := strings.ToLower(wt.Branch)
-		repoLower := strings.ToLower(wt.Repo)
-
-		if wt.PRNumber > 0 && strings.Contains(fmt.Sprintf("%d", wt.PRNumber), termLower) {
-			matched = true
+		var matches []SearchMatch
+		field := func(name, value string) {
+			if value != "" && strings.Contains(strings.ToLower(value), termLower) {
+				matches = append(matches, SearchMatch{Field: name, Text: value})
+			}
 		}
-		if strings.Contains(nameLower, termLower) {
-			matched = true
+		var meta prcache.PRMeta
+		hasMeta := false
+		if wt.PRNumber > 0 {
+			meta, hasMeta = cache[fmt.Sprintf("%s/%d", wt.Repo, wt.PRNumber)]
 		}
-		if strings.Contains(pathLower, termLower) {
-			matched = true
+
+		if in[searchInWorktrees] {
+			if wt.PRNumber > 0 {
+				field("pr", fmt.Sprintf("%d", wt.PRNumber))
+			}
+			field("name", wt.Name)
+			field("path", wt.Path)
+			field("branch", wt.Branch)
+			field("repo", wt.Repo)
+			if data, err := os.ReadFile(filepath.Join(wt.Path, "CLAUDE.local.md")); err == nil {
+				if snip := ui.Snippet(string(data), termLower, searchSnippetWidth); snip != "" {
+					matches = append(matches, SearchMatch{Field: "CLAUDE.local.md", Text: snip})
+				}
+			}
 		}
-		if wt.Branch != "" && strings.Contains(branchLower, termLower) {
-			matched = true
+		if in[searchInPRs] && hasMeta {
+			field("title", meta.Title)
+			field("author", meta.Author)
 		}
-		if strings.Contains(repoLower, termLower) {
-			matched = true
+		if in[searchInSessions] {
+			sessions, _ := session.FindSessions(wt.Path)
+			for _, s := range sessions {
+				events, _ := session.SearchEvents(session.SessionFilePath(wt.Path, s.ID), termLower, searchSessionMatches)
+				for _, ev := range events {
+					matches = append(matches, SearchMatch{
+						Field:     "session",
+						Text:      ui.Snippet(ev.Text, termLower, searchSnippetWidth),
+						SessionID: s.ID,
+					})
+				}
+			}
 		}
 
-		if matched {
+		if len(matches) > 0 {
 			results = append(results, SearchResult{
 				Name:       wt.Name,
 				Path:       wt.Path,
@@ -179,9 +292,47 @@ func searchWorktrees(termLower string) []SearchResult {
 				PRNumber:   wt.PRNumber,
 				Branch:     wt.Branch,
 				Repo:       wt.Repo,
+				Title:      meta.Title,
 				HasSession: session.HasActiveSession(wt.Path),
+				Matches:    matches,
 			})
 		}
 	}
+
+	// Cached PRs whose worktree is gone
+	if in[searchInPRs] && searchType != "feature" {
+		keys := make([]string, 0, len(cache))
+		for key := range cache {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if withWorktree[key] {
+				continue
+			}
+			repo, num, ok := strings.Cut(key, "/")
+			prNumber, err := strconv.Atoi(num)
+			if !ok || err != nil {
+				continue
+			}
+			meta := cache[key]
+			var matches []SearchMatch
+			for _, f := range []struct{ name, value string }{{"pr", num}, {"title", meta.Title}, {"author", meta.Author}} {
+				if f.value != "" && strings.Contains(strings.ToLower(f.value), termLower) {
+					matches = append(matches, SearchMatch{Field: f.name, Text: f.value})
+				}
+			}
+			if len(matches) > 0 {
+				results = append(results, SearchResult{
+					Name:     fmt.Sprintf("%s-pr-%d", repo, prNumber),
+					Type:     "pr",
+					PRNumber: prNumber,
+					Repo:     repo,
+					Title:    meta.Title,
+					Matches:  matches,
+				})
+			}
+		}
+	}
 	return results
 }
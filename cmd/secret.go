@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mgreau/zen/internal/secrets"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage credentials referenced from the config",
+	Long: `Hook URLs, headers and env values can refer to secrets instead of
+holding them in plaintext:
+
+  env://NAME              the environment variable NAME
+  secret://keychain/NAME  the macOS Keychain item NAME
+
+References are resolved when a hook fires.`,
+}
+
+var secretSetCmd = &cobra.Command{
+	Use:   "set <name>",
+	Short: "Store a secret in the macOS Keychain",
+	Long: `Stores a secret in the macOS Keychain, prompting for its value, so the
+config can refer to it as secret://keychain/<name>. An existing secret of
+the same name is replaced.
+
+Example:
+  zen secret set slack-webhook`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := secrets.Store(args[0]); err != nil {
+			return err
+		}
+		ui.LogSuccess(fmt.Sprintf("Stored %s; use secret://keychain/%s in the config", args[0], args[0]))
+		return nil
+	},
+}
+
+var secretCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check that every secret referenced in the config resolves",
+	Args:  cobra.NoArgs,
+	RunE:  runSecretCheck,
+}
+
+func init() {
+	secretCmd.AddCommand(secretSetCmd)
+	secretCmd.AddCommand(secretCheckCmd)
+	rootCmd.AddCommand(secretCmd)
+}
+
+// secretRef is one secret reference found in the config.
+type secretRef struct {
+	Field string `json:"field"`
+	Ref   string `json:"ref"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// configSecretRefs lists the secret references in the config, in config
+// order.
+func configSecretRefs() []secretRef {
+	var refs []secretRef
+	add := func(field, v string) {
+		if secrets.IsRef(v) {
+			refs = append(refs, secretRef{Field: field, Ref: v})
+		}
+	}
+	addMap := func(field string, m map[string]string) {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			add(field+"."+k, m[k])
+		}
+	}
+	for i, h := range cfg.Hooks {
+		name := fmt.Sprintf("hooks[%d]", i)
+		add(name+".url", h.URL)
+		addMap(name+".headers", h.Headers)
+		addMap(name+".env", h.Env)
+	}
+	return refs
+}
+
+func runSecretCheck(cmd *cobra.Command, args []string) error {
+	refs := configSecretRefs()
+	failed := 0
+	for i := range refs {
+		// Long enough to answer a Keychain access prompt
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		_, err := secrets.Resolve(ctx, refs[i].Ref)
+		cancel()
+		refs[i].OK = err == nil
+		if err != nil {
+			refs[i].Error = err.Error()
+			failed++
+		}
+	}
+
+	if jsonFlag {
+		printJSON(refs)
+	} else if len(refs) == 0 {
+		fmt.Println("No secret references in the config.")
+	} else {
+		for _, r := range refs {
+			if r.OK {
+				fmt.Printf("  %s %s %s\n", ui.GreenText("ok  "), r.Field, ui.DimText(r.Ref))
+			} else {
+				fmt.Printf("  %s %s %s\n", ui.RedText("FAIL"), r.Field, ui.DimText(r.Error))
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d secret(s) did not resolve", failed, len(refs))
+	}
+	return nil
+}
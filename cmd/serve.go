@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/localapi"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/terminal"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a localhost JSON API for editor integrations",
+	Long: `Serves a small JSON API on localhost so a VS Code extension, Raycast
+script or similar can drive zen without parsing CLI output.
+
+Requests must send the token stored in ~/.zen/state/local_api.token as
+"Authorization: Bearer <token>". The API only binds to loopback.
+
+Endpoints:
+  GET  /v1/health            Version check (no token needed)
+  GET  /v1/worktrees?repo=   List worktrees, with has_active_session
+  POST /v1/reviews           Create a PR review worktree
+                             {"pr_number": 42, "repo": "mono", "open": true}
+  POST /v1/sessions/resume   Resume or start a Claude session in a terminal tab
+                             {"path": "..."} or {"pr_number": 42, "repo": "mono"}
+
+Example:
+  zen serve --local-api
+  curl -H "Authorization: Bearer $(cat ~/.zen/state/local_api.token)" \
+    http://127.0.0.1:7667/v1/worktrees`,
+	RunE: runServe,
+}
+
+var (
+	serveLocalAPI bool
+	serveAddr     string
+)
+
+func init() {
+	serveCmd.Flags().BoolVar(&serveLocalAPI, "local-api", false, "Serve the localhost JSON API")
+	serveCmd.Flags().StringVar(&serveAddr, "addr", localapi.DefaultAddr, "Loopback address to listen on")
+	addTerminalFlag(serveCmd)
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	if !serveLocalAPI {
+		return errors.New("nothing to serve -- pass --local-api (for MCP, use zen mcp serve)")
+	}
+	if err := localapi.CheckAddr(serveAddr); err != nil {
+		return err
+	}
+	t, err := newTerminal()
+	if err != nil {
+		return err
+	}
+	if terminal.Inline(t) {
+		return fmt.Errorf("terminal %q runs sessions in place -- the local API needs one that opens tabs (set --terminal)", t.Name())
+	}
+	token, err := localapi.LoadOrCreateToken()
+	if err != nil {
+		return fmt.Errorf("creating API token: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := localapi.New(cfg, token, Version, func(ctx context.Context, wt worktree.Worktree, model string) (*localapi.ResumeResult, error) {
+		return apiResume(t, wt, model)
+	})
+	if err := config.Watch(ctx, srv.SetConfig, func(err error) {
+		ui.LogWarn(fmt.Sprintf("config reload: %v", err))
+	}); err != nil {
+		ui.LogWarn(err.Error())
+	}
+
+	ui.LogInfo(fmt.Sprintf("Local API listening on http://%s", serveAddr))
+	ui.Hint(fmt.Sprintf("Token: %s  |  Ctrl-C to stop", localapi.TokenPath()))
+	if err := srv.Serve(ctx, serveAddr); err != nil {
+		return err
+	}
+	ui.LogInfo("Local API stopped")
+	return nil
+}
+
+// apiResume opens a Claude session for wt in a new tab of t: the most
+// recent session if there is one, else a new one, starting with
+// the repo's review or feature prompt.
+func apiResume(t terminal.Terminal, wt worktree.Worktree, modelFlag string) (*localapi.ResumeResult, error) {
+	res := &localapi.ResumeResult{Worktree: wt.Path, Name: wt.Name, Terminal: t.Name()}
+	claudeCmd, model := claudeCommand(wt.Repo, wt.Path, modelFlag)
+
+	var err error
+	if sessions, _ := session.FindSessions(wt.Path); len(sessions) > 0 {
+		res.SessionID = sessions[0].ID
+		err = t.OpenTabWithResume(wt.Path, res.SessionID, claudeCmd, model)
+	} else {
+		res.NewSession = true
+		prompt := featurePrompt(wt.Repo, wt.Path, wt.Branch)
+		if wt.Type == worktree.TypePRReview {
+			if _, err := preparePlaybook(wt.Repo, wt.PRNumber, wt.Path, ""); err != nil {
+				ui.LogWarn(fmt.Sprintf("could not set the review playbook of %s: %v", wt.Name, err))
+			}
+			prompt = reviewPrompt(wt.Repo, wt.PRNumber, wt.Path)
+		}
+		if name := promptCommand(prompt); name != "" {
+			if err := ensureClaudeCommand(name); err != nil {
+				ui.LogWarn(fmt.Sprintf("could not install /%s command: %v", name, err))
+			}
+		}
+		err = openClaudeTab(t, wt.Path, prompt, claudeCmd, model)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening %s tab: %w", t.Name(), err)
+	}
+	ui.LogInfo(fmt.Sprintf("Opened session for %s", wt.Name))
+	return res, nil
+}
@@ -7,9 +7,12 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/terminal"
 	"github.com/mgreau/zen/internal/ui"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -21,18 +24,53 @@ var EmbeddedCommands embed.FS
 
 var setupCmd = &cobra.Command{
 	Use:   "setup",
-	Short: "Interactive setup to create ~/.zen/config.yaml",
+	Short: "Interactive setup to create ~/.zen/config.yaml (or --config path)",
 	RunE:  runSetup,
 }
 
+var setupXDGCmd = &cobra.Command{
+	Use:   "xdg",
+	Short: "Move ~/.zen into the XDG config and state directories",
+	Long: `Moves config.yaml (and anything else in ~/.zen) to $XDG_CONFIG_HOME/zen
+(default ~/.config/zen) and ~/.zen/state to $XDG_STATE_HOME/zen (default
+~/.local/state/zen), then removes ~/.zen. From then on zen finds its files
+there without any environment variable set.
+
+Stop the watch daemon first; restart it afterwards.`,
+	Args: cobra.NoArgs,
+	RunE: runSetupXDG,
+}
+
 func init() {
+	setupCmd.AddCommand(setupXDGCmd)
 	rootCmd.AddCommand(setupCmd)
 }
 
+func runSetupXDG(cmd *cobra.Command, args []string) error {
+	if config.UsingXDG() {
+		ui.LogInfo("Already using the XDG directories")
+		return nil
+	}
+	if running, pid := watchIsRunning(); running {
+		return fmt.Errorf("watch daemon is running (PID %d) -- stop it first with: zen watch stop", pid)
+	}
+
+	configDir, stateDir, err := config.MigrateToXDG()
+	if err != nil {
+		return fmt.Errorf("migrating %s: %w", config.LegacyDir(), err)
+	}
+
+	home := homeDir()
+	ui.LogSuccess(fmt.Sprintf("Config moved to %s", ui.ShortenHome(configDir, home)))
+	ui.LogSuccess(fmt.Sprintf("State moved to %s", ui.ShortenHome(stateDir, home)))
+	ui.Hint("Restart the daemon with: zen watch start")
+	return nil
+}
+
 func runSetup(cmd *cobra.Command, args []string) error {
 	scanner := bufio.NewScanner(os.Stdin)
 
-	configPath := filepath.Join(os.Getenv("HOME"), ".zen", "config.yaml")
+	configPath := config.Path()
 
 	fmt.Println()
 	fmt.Println(ui.BoldText("Zen Setup"))
@@ -40,7 +78,7 @@ func runSetup(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	fmt.Println("Prerequisites:")
 	fmt.Println("  gh auth login       — authenticate GitHub CLI")
-	fmt.Println("  iTerm2 installed    — for tab management")
+	fmt.Println("  a terminal          — iTerm2, Ghostty, Terminal.app or tmux")
 	fmt.Println("  claude installed    — Claude Code CLI")
 	fmt.Println()
 
@@ -87,6 +125,21 @@ func runSetup(cmd *cobra.Command, args []string) error {
 	authors := promptRequired(scanner, "GitHub username(s) for PR filtering (comma-separated)")
 	fmt.Println()
 
+	// Pick the terminal, showing what auto-detection finds right now
+	detected, err := terminal.Detect()
+	if err != nil {
+		detected = "none"
+	}
+	var term string
+	for {
+		term = prompt(scanner, fmt.Sprintf("Terminal (%s; auto detects %s)", strings.Join(terminal.Types, ", "), detected), "auto")
+		if slices.Contains(terminal.Types, term) {
+			break
+		}
+		fmt.Printf("  (must be one of %s)\n", strings.Join(terminal.Types, ", "))
+	}
+	fmt.Println()
+
 	// Build config
 	repoMap := make(map[string]config.RepoConfig, len(repos))
 	for _, r := range repos {
@@ -102,10 +155,12 @@ func runSetup(cmd *cobra.Command, args []string) error {
 	}
 
 	cfg := config.Config{
-		Repos:        repoMap,
-		Authors:      authorList,
-		PollInterval: "5m",
-		ClaudeBin:    "claude",
+		ConfigVersion: config.CurrentVersion,
+		Repos:         repoMap,
+		Authors:       authorList,
+		PollInterval:  "5m",
+		ClaudeBin:     "claude",
+		Terminal:      term,
 		Watch: config.WatchConfig{
 			DispatchInterval: "10s",
 			CleanupInterval:  "1h",
@@ -185,11 +240,7 @@ func promptRequired(scanner *bufio.Scanner, label string) string {
 // ensureClaudeCommand checks if a specific Claude command file exists and
 // installs it silently from the embedded FS if missing.
 func ensureClaudeCommand(name string) error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("resolving home directory: %w", err)
-	}
-	targetDir := filepath.Join(home, ".claude", "commands")
+	targetDir := filepath.Join(session.ClaudeDir(), "commands")
 	dst := filepath.Join(targetDir, name+".md")
 
 	if _, err := os.Stat(dst); err == nil {
@@ -235,7 +286,7 @@ func installClaudeCommands(scanner *bufio.Scanner) (int, error) {
 
 	fmt.Println("Install Claude Code commands?")
 	fmt.Printf("  Commands: %s\n", strings.Join(names, ", "))
-	fmt.Println("  Target:   ~/.claude/commands/")
+	fmt.Printf("  Target:   %s/\n", ui.ShortenHome(filepath.Join(session.ClaudeDir(), "commands"), homeDir()))
 	fmt.Print("Install? [Y/n]: ")
 	scanner.Scan()
 	answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
@@ -244,7 +295,7 @@ func installClaudeCommands(scanner *bufio.Scanner) (int, error) {
 		return 0, nil
 	}
 
-	targetDir := filepath.Join(os.Getenv("HOME"), ".claude", "commands")
+	targetDir := filepath.Join(session.ClaudeDir(), "commands")
 	if err := os.MkdirAll(targetDir, 0o755); err != nil {
 		return 0, fmt.Errorf("creating %s: %w", targetDir, err)
 	}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var shellenvCmd = &cobra.Command{
+	Use:   "shellenv [bash|zsh|sh|fish]",
+	Short: "Print the shell function that makes zen cd change directory",
+	Long: `Prints a zen shell function to load in your shell's startup file. It runs
+zen as usual, except for zen cd, which it handles itself: a command cannot
+change its parent shell's directory, so the function asks zen for the
+worktree's path and changes to it.
+
+The shell is detected from $SHELL when not given.
+
+Setup:
+  echo 'eval "$(zen shellenv)"' >> ~/.zshrc     # or ~/.bashrc, ~/.profile
+  echo 'zen shellenv fish | source' >> ~/.config/fish/config.fish
+
+Then:
+  zen cd 42              PR #42's review worktree
+  zen cd auth            A worktree whose name or branch matches "auth"
+  zen cd mono            The mono clone`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"bash", "zsh", "sh", "fish"},
+	RunE:      runShellenv,
+}
+
+var cdCmd = &cobra.Command{
+	Use:   "cd <pr|name>",
+	Short: "Change to a worktree's directory (needs zen shellenv)",
+	Long: `Changes the current shell's directory to a worktree, found by PR number,
+PR URL, name, or a fuzzy match of its name or branch, or to a repo's clone.
+This needs the shell function printed by zen shellenv; without it, zen cd
+only prints the directory.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runPath(cmd, args); err != nil {
+			return err
+		}
+		ui.LogWarn(`zen cd needs the shell function to change directory: add eval "$(zen shellenv)" to your shell's startup file`)
+		return nil
+	},
+}
+
+var pathCmd = &cobra.Command{
+	Use:    "path <pr|name>",
+	Short:  "Print the path of a worktree, for zen cd",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE:   runPath,
+}
+
+func init() {
+	rootCmd.AddCommand(shellenvCmd)
+	rootCmd.AddCommand(cdCmd)
+	rootCmd.AddCommand(pathCmd)
+}
+
+const shellenvPOSIX = `zen() {
+  if [ "$1" = "cd" ]; then
+    shift
+    local dir
+    dir="$(command zen path "$@")" && cd "$dir"
+  else
+    command zen "$@"
+  fi
+}
+`
+
+const shellenvFish = `function zen
+  if test (count $argv) -gt 0; and test $argv[1] = cd
+    set -l dir (command zen path $argv[2..-1]); and cd $dir
+  else
+    command zen $argv
+  end
+end
+`
+
+func runShellenv(cmd *cobra.Command, args []string) error {
+	shell := filepath.Base(os.Getenv("SHELL"))
+	if len(args) > 0 {
+		shell = args[0]
+	}
+	switch shell {
+	case "bash", "zsh", "sh":
+		fmt.Print(shellenvPOSIX)
+	case "fish":
+		fmt.Print(shellenvFish)
+	default:
+		return fmt.Errorf("unsupported shell %q: must be one of bash, zsh, sh, fish", shell)
+	}
+	return nil
+}
+
+// runPath prints the directory zen cd changes to: a worktree by PR number,
+// PR URL, name or path, a repo's clone, or the best fuzzy match of a
+// worktree name or branch. Other fuzzy matches are listed on stderr.
+func runPath(cmd *cobra.Command, args []string) error {
+	target := args[0]
+	if w, err := resolveWorktree(target); err == nil {
+		fmt.Println(w.Path)
+		return nil
+	}
+	if basePath := cfg.RepoBasePath(target); basePath != "" {
+		fmt.Println(filepath.Join(basePath, target))
+		return nil
+	}
+
+	wts, err := wt.ListAll(cfg)
+	if err != nil {
+		return fmt.Errorf("listing worktrees: %w", err)
+	}
+	matches := wt.Match(wts, target)
+	if len(matches) == 0 {
+		return fmt.Errorf("no worktree matching %q", target)
+	}
+	if len(matches) > 1 {
+		names := make([]string, 0, len(matches)-1)
+		for _, m := range matches[1:] {
+			names = append(names, m.Name)
+		}
+		ui.LogInfo(fmt.Sprintf("Also matching %q: %s", target, strings.Join(names, ", ")))
+	}
+	fmt.Println(matches[0].Path)
+	return nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mgreau/zen/internal/metrics"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsCLI   bool
+	statsDays  int
+	statsReset bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show local usage statistics",
+	Long: `Shows statistics zen collected locally.
+
+With --cli, lists how often each zen command ran and how long it took
+(median, 95th percentile and slowest run), slowest first -- to find the
+commands that are slow in real use. Timings are only recorded when
+metrics: true is set in the config, and stay in ~/.zen/state/metrics.jsonl;
+nothing is sent anywhere. Long-running commands (watch daemon, watch logs,
+mcp serve) are not recorded.
+
+For Claude token usage, see zen agent stats.
+
+Example:
+  zen stats --cli
+  zen stats --cli --days 7
+  zen stats --cli --reset`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	statsCmd.Flags().BoolVar(&statsCLI, "cli", false, "Show per-command execution counts and durations")
+	statsCmd.Flags().IntVarP(&statsDays, "days", "d", 30, "Only count runs in the last N days")
+	statsCmd.Flags().BoolVar(&statsReset, "reset", false, "With --cli: delete the recorded timings")
+	rootCmd.AddCommand(statsCmd)
+}
+
+// cliStatsResult is the JSON output of zen stats --cli.
+type cliStatsResult struct {
+	Enabled  bool                   `json:"enabled"`
+	Days     int                    `json:"days"`
+	Commands []metrics.CommandStats `json:"commands"`
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	if !statsCLI {
+		return cmd.Help()
+	}
+
+	if statsReset {
+		if err := metrics.Clear(); err != nil {
+			return fmt.Errorf("removing metrics: %w", err)
+		}
+		ui.LogSuccess("Recorded command timings removed")
+		return nil
+	}
+
+	records, err := metrics.Read(time.Now().AddDate(0, 0, -statsDays))
+	if err != nil {
+		return fmt.Errorf("reading metrics: %w", err)
+	}
+	result := cliStatsResult{
+		Enabled:  cfg.Metrics,
+		Days:     statsDays,
+		Commands: metrics.Summarize(records),
+	}
+	if jsonFlag {
+		printJSON(result)
+		return nil
+	}
+
+	if len(result.Commands) == 0 {
+		fmt.Printf("No command timings in the last %d days.\n", statsDays)
+		if !cfg.Metrics {
+			ui.Hint("Set metrics: true in ~/.zen/config.yaml to record them")
+		}
+		return nil
+	}
+
+	fmt.Println()
+	ui.SectionHeader(fmt.Sprintf("Command Timings (last %d days)", statsDays))
+	fmt.Println()
+	fmt.Printf("  %-20s  %-6s  %-6s  %-8s  %-8s  %-8s  %s\n", "COMMAND", "RUNS", "FAILED", "MEDIAN", "P95", "MAX", "LAST RUN")
+	fmt.Printf("  %-20s  %-6s  %-6s  %-8s  %-8s  %-8s  %s\n", "────────────────────", "──────", "──────", "────────", "────────", "────────", "────────")
+	for _, c := range result.Commands {
+		failed := "-"
+		if c.Failures > 0 {
+			failed = fmt.Sprintf("%d", c.Failures)
+		}
+		fmt.Printf("  %-20s  %-6d  %-6s  %-8s  %-8s  %-8s  %s\n",
+			ui.Truncate(c.Command, 20),
+			c.Count,
+			failed,
+			formatMetricDuration(c.P50),
+			formatMetricDuration(c.P95),
+			formatMetricDuration(c.Max),
+			session.FormatAge(c.LastRun))
+	}
+	fmt.Println()
+	if !cfg.Metrics {
+		ui.Hint("Recording is off; set metrics: true in ~/.zen/config.yaml to resume")
+		fmt.Println()
+	}
+	return nil
+}
+
+// formatMetricDuration rounds d for display: milliseconds below a second,
+// tenths of a second above.
+func formatMetricDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
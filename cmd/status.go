@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,12 +14,14 @@ import (
 
 	"github.com/mgreau/zen/internal/config"
 	"github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/journal"
 	"github.com/mgreau/zen/internal/prcache"
 	"github.com/mgreau/zen/internal/reconciler"
 	"github.com/mgreau/zen/internal/session"
 	"github.com/mgreau/zen/internal/ui"
 	"github.com/mgreau/zen/internal/worktree"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 var statusCmd = &cobra.Command{
@@ -28,26 +31,47 @@ var statusCmd = &cobra.Command{
 	RunE:    runStatus,
 }
 
+var statusSessions bool
+
 func init() {
+	addColumnsFlag(statusCmd, "status")
+	addResultFlags(statusCmd, "something needs you: a Claude session waiting for input, a failed setup or a hung daemon")
+	statusCmd.Flags().BoolVar(&statusSessions, "sessions", false, "Show each worktree's latest Claude session with token usage")
+	statusCmd.Flags().StringVar(&statusGroupBy, "group-by", "", "Group worktrees by "+strings.Join(config.StatusGroupings, ", ")+" (default from status.group_by, else type)")
+	statusCmd.Flags().StringVar(&statusSort, "sort", "", "Sort worktrees by "+strings.Join(config.StatusSorts, ", ")+" (default from status.sort, else repo)")
 	rootCmd.AddCommand(statusCmd)
 }
 
 // StatusData holds the structured status output.
 type StatusData struct {
-	Worktrees    *worktree.Stats  `json:"worktrees"`
-	PRReviews    []StatusPRReview `json:"pr_reviews"`
-	Features     []StatusFeature  `json:"features"`
-	DaemonStatus string           `json:"daemon_status"`
-	DaemonPID    string           `json:"daemon_pid,omitempty"`
+	Worktrees      *worktree.Stats  `json:"worktrees"`
+	PRReviews      []StatusPRReview `json:"pr_reviews"`
+	Features       []StatusFeature  `json:"features"`
+	DaemonStatus   string           `json:"daemon_status"`
+	DaemonPID      string           `json:"daemon_pid,omitempty"`
+	HeartbeatAge   int              `json:"heartbeat_age_seconds,omitempty"`
+	HeartbeatStale bool             `json:"heartbeat_stale,omitempty"`
+
+	SetupFailures []reconciler.SetupFailure `json:"setup_failures,omitempty"`
+	ThisWeek      *journal.Week             `json:"this_week,omitempty"`
 }
 
 // StatusPRReview enriches a worktree with remote PR state and cleanup info.
 type StatusPRReview struct {
 	worktree.Worktree
-	Title      string `json:"title,omitempty"`
-	State      string `json:"state,omitempty"`
-	AgeDays    int    `json:"age_days"`
-	CleanupIn  int    `json:"cleanup_in_days,omitempty"`
+	Title     string        `json:"title,omitempty"`
+	State     string        `json:"state,omitempty"`
+	AgeDays   int           `json:"age_days"`
+	CleanupIn int           `json:"cleanup_in_days,omitempty"`
+	Watched   bool          `json:"watched,omitempty"` // zen review watch
+	Labels    github.Labels `json:"labels,omitempty"`
+	Held      bool          `json:"held,omitempty"` // has a hold label, so cleanup skips it
+	Kept      bool          `json:"kept,omitempty"` // cleanup_by_review keeps it after merge
+
+	// MyReview is your latest review of the PR
+	MyReview *github.ReviewOutcome `json:"my_review,omitempty"`
+
+	Session *StatusSession `json:"session,omitempty"`
 }
 
 // StatusFeature enriches a feature worktree with session and age info.
@@ -58,9 +82,31 @@ type StatusFeature struct {
 	HasSession    bool   `json:"has_session"`
 	Running       bool   `json:"running"`
 	SessionStatus string `json:"session_status,omitempty"` // "running", "waiting", "stopped", or ""
+
+	Session *StatusSession `json:"session,omitempty"`
+}
+
+// StatusSession is the most recent Claude session in a worktree.
+type StatusSession struct {
+	ID         string             `json:"id"`
+	Status     string             `json:"status"` // "running", "waiting" or "stopped"
+	Model      string             `json:"model,omitempty"`
+	Tokens     session.TokenUsage `json:"tokens"`
+	LastActive int64              `json:"last_active_epoch"`
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
+	defer silence()()
+
+	columns, err := tableColumns("status")
+	if err != nil {
+		return err
+	}
+	groupBy, sortBy, err := statusLayout()
+	if err != nil {
+		return err
+	}
+
 	// Worktree stats
 	wtStats, err := worktree.GetStats(cfg)
 	if err != nil {
@@ -81,23 +127,47 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Latest session per worktree
+	sessions := worktreeSessions(wts)
+
 	// Enrich PR reviews with remote state
 	prCache := prcache.Load()
-	prReviews := enrichPRReviews(prWTs, prCache)
+	prReviews := enrichPRReviews(prWTs, prCache, sessions)
 
 	// Enrich features with session and age info
-	enrichedFeatures := enrichFeatures(features)
+	enrichedFeatures := enrichFeatures(features, sessions)
+	sortStatusReviews(prReviews, sortBy)
+	sortStatusFeatures(enrichedFeatures, sortBy)
 
 	// Daemon status
 	daemonStatus, daemonPID := getDaemonStatus()
 
+	// A running daemon whose heartbeat is older than 2x poll interval is hung
+	hbAge, hbOK := heartbeatAge()
+	hbStale := daemonStatus == "running" && hbOK && hbAge > heartbeatStaleAfter(cfg)
+
+	setupFailures := reconciler.SetupFailures()
+	week := reviewLoad()
+
+	waiting := false
+	for _, s := range sessions {
+		if s.Status == "waiting" {
+			waiting = true
+		}
+	}
+	setResults(waiting || len(setupFailures) > 0 || hbStale)
+
 	if jsonFlag {
 		printJSON(StatusData{
-			Worktrees:    wtStats,
-			PRReviews:    prReviews,
-			Features:     enrichedFeatures,
-			DaemonStatus: daemonStatus,
-			DaemonPID:    daemonPID,
+			Worktrees:      wtStats,
+			PRReviews:      prReviews,
+			Features:       enrichedFeatures,
+			DaemonStatus:   daemonStatus,
+			DaemonPID:      daemonPID,
+			HeartbeatAge:   int(hbAge.Seconds()),
+			HeartbeatStale: hbStale,
+			SetupFailures:  setupFailures,
+			ThisWeek:       week,
 		})
 		return nil
 	}
@@ -116,68 +186,132 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	ui.SectionHeader("PR Reviews")
 	if len(prReviews) == 0 {
 		fmt.Println("  No PR review worktrees")
-	} else {
-		fmt.Printf("  %-8s  %-6s  %-42s  %s\n", "State", "PR", "Title", "Path")
-		fmt.Printf("  %-8s  %-6s  %-42s  %s\n", "────────", "──────", "──────────────────────────────────────────", "──────────────────────────────")
-
-		for i, r := range prReviews {
+	}
+	for gi, g := range reviewGroups(prReviews, groupBy) {
+		if gi > 0 {
+			fmt.Println()
+		}
+		printGroupHeader(g.Name, len(g.Rows))
+		t := ui.NewTable([]ui.Column{
+			{Key: "state", Header: "State"},
+			{Key: "pr", Header: "PR"},
+			{Key: "title", Header: "Title", Flex: true, Min: 20},
+			{Key: "labels", Header: "Labels", Max: 30, Optional: true},
+			{Key: "review", Header: "Review", Optional: true},
+			{Key: "path", Header: "Path"},
+		}, columns)
+		for i, r := range g.Rows {
 			if i >= 10 {
-				fmt.Printf("  ... and %d more\n", len(prReviews)-10)
 				break
 			}
-			title := ui.Truncate(r.Title, 40)
-			stateCol := formatPRState(r.State, r.CleanupIn)
-			fmt.Printf("  %s  %s  %-42s  %s\n",
-				stateCol,
-				ui.CyanText(fmt.Sprintf("#%-5d", r.PRNumber)),
-				title,
-				ui.DimText(ui.ShortenHome(r.Path, home)))
+			title := r.Title
+			if r.Suffix != "" {
+				title = fmt.Sprintf("[%s] %s", r.Suffix, title)
+			}
+			if r.Watched {
+				title = "👁 " + title
+			}
+			t.Row(formatPRState(r.State, r.CleanupIn), prCell(r.PRNumber), title, labelChips(r.Labels), reviewCell(r.MyReview), ui.DimText(ui.ShortenHome(r.Path, home)))
 		}
+		t.Print()
+		if len(g.Rows) > 10 {
+			fmt.Printf("  ... and %d more\n", len(g.Rows)-10)
+		}
+	}
+	if others := watchedWithoutWorktree(prReviews); len(others) > 0 {
+		fmt.Printf("\n  👁 Also watching: %s\n", strings.Join(others, ", "))
 	}
 	ui.Hint("'zen review resume <number>' to open  |  'zen inbox' for new PRs")
 	fmt.Println()
 
-	// Features — sorted by age (newest first)
+	// PRs the daemon gave up setting up
+	if len(setupFailures) > 0 {
+		ui.SectionHeader("Setup Failed")
+		fmt.Printf("  %-6s  %-32s  %-8s  %s\n", "PR", "Title", "Failed", "Error")
+		fmt.Printf("  %-6s  %-32s  %-8s  %s\n", "──────", "────────────────────────────────", "────────", "──────────────────────────────")
+		for _, f := range setupFailures {
+			fmt.Printf("  %s  %-32s  %-8s  %s\n",
+				ui.CyanText(fmt.Sprintf("#%-5d", f.PRNumber)),
+				ui.Truncate(f.Title, 32),
+				session.FormatAge(f.FailedAt),
+				ui.RedText(ui.Truncate(strings.Join(strings.Fields(f.Error), " "), 50)))
+		}
+		ui.Hint(fmt.Sprintf("'zen watch retry %s' to retry  |  'zen watch logs search <pr>' for details", setupFailures[0].Key))
+		fmt.Println()
+	}
+
+	// Review load from the daemon's event journal
+	ui.SectionHeader("This Week")
+	if week == nil {
+		fmt.Println("  No review activity recorded yet")
+		ui.Hint("'zen watch start' records review requests and completed reviews")
+	} else {
+		median := "—"
+		if week.MedianTurnaround > 0 {
+			median = ui.FormatDuration(int(week.MedianTurnaround.Seconds()))
+		}
+		fmt.Printf("  Received: %d  |  Completed: %d  |  Median turnaround: %s\n",
+			week.Received, week.Completed, median)
+		switch {
+		case week.Pending == 0 && week.NextWeek == 0:
+			fmt.Printf("  Pending: %s\n", ui.GreenText("0"))
+		case week.ClearIn > 0:
+			fmt.Printf("  Pending: %d  →  %s\n", week.Pending,
+				ui.GreenText(fmt.Sprintf("cleared in ~%s at this pace", ui.FormatDuration(int(week.ClearIn.Seconds())))))
+		case week.NextWeek > week.Pending:
+			fmt.Printf("  Pending: %d  →  %s\n", week.Pending, ui.YellowText(fmt.Sprintf("~%d next week at this pace", week.NextWeek)))
+		default:
+			fmt.Printf("  Pending: %d  →  ~%d next week at this pace\n", week.Pending, week.NextWeek)
+		}
+	}
+	fmt.Println()
+
+	// Features
 	ui.SectionHeader("Feature Work")
 	if len(enrichedFeatures) == 0 {
 		fmt.Println("  No feature worktrees")
-	} else {
-		sort.Slice(enrichedFeatures, func(i, j int) bool {
-			return enrichedFeatures[i].AgeDays < enrichedFeatures[j].AgeDays
-		})
-
-		fmt.Printf("  %-3s  %-34s  %-22s  %-5s  %s\n", "", "Name", "Branch", "Age", "Path")
-		fmt.Printf("  %-3s  %-34s  %-22s  %-5s  %s\n", "───", "──────────────────────────────────", "──────────────────────", "─────", "──────────────────────────────")
-
-		for i, f := range enrichedFeatures {
+	}
+	for gi, g := range featureGroups(enrichedFeatures, groupBy) {
+		if gi > 0 {
+			fmt.Println()
+		}
+		printGroupHeader(g.Name, len(g.Rows))
+		t := ui.NewTable([]ui.Column{
+			{Key: "session", Header: ""},
+			{Key: "name", Header: "Name", Flex: true, Min: 20},
+			{Key: "branch", Header: "Branch", Flex: true, Min: 12},
+			{Key: "age", Header: "Age"},
+			{Key: "path", Header: "Path"},
+		}, columns)
+		for i, f := range g.Rows {
 			if i >= 15 {
-				fmt.Printf("  ... and %d more\n", len(enrichedFeatures)-15)
 				break
 			}
-			sessionIcon := "   "
+			sessionIcon := ""
 			switch f.SessionStatus {
 			case "running":
-				sessionIcon = ui.GreenText(" ● ")
+				sessionIcon = ui.GreenText("●")
 			case "waiting":
-				sessionIcon = ui.YellowText(" ● ")
+				sessionIcon = ui.YellowText("●")
 			default:
 				if f.HasSession {
-					sessionIcon = ui.DimText(" ○ ")
+					sessionIcon = ui.DimText("○")
 				}
 			}
-			branch := ui.Truncate(f.Branch, 22)
-			name := ui.Truncate(f.Name, 34)
-			fmt.Printf("  %s  %-34s  %s  %-5s  %s\n",
-				sessionIcon,
-				name,
-				ui.CyanText(fmt.Sprintf("%-22s", branch)),
-				ui.DimText(f.AgeStr),
-				ui.DimText(ui.ShortenHome(f.Path, home)))
+			t.Row(sessionIcon, f.Name, ui.CyanText(f.Branch), ui.DimText(f.AgeStr), ui.DimText(ui.ShortenHome(f.Path, home)))
+		}
+		t.Print()
+		if len(g.Rows) > 15 {
+			fmt.Printf("  ... and %d more\n", len(g.Rows)-15)
 		}
 	}
 	ui.Hint("'zen work resume <name>' to continue  |  'zen work new <repo> <branch>' to start  |  " + ui.GreenText("●") + " running  " + ui.YellowText("●") + " waiting")
 	fmt.Println()
 
+	if statusSessions {
+		displayStatusSessions(wts, sessions)
+	}
+
 	// Watch daemon
 	ui.SectionHeader("Watch Daemon")
 	switch daemonStatus {
@@ -188,25 +322,73 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	default:
 		fmt.Printf("  Status: %s\n", ui.DimText("Not running"))
 	}
+	if hbStale {
+		ui.LogWarn(fmt.Sprintf("Daemon heartbeat is %s old (> 2x poll interval) -- it may be hung; try 'zen watch stop && zen watch start --supervise'",
+			hbAge.Round(time.Second)))
+	}
 	ui.Hint("'zen watch start/stop' to control  |  'zen watch logs' for logs")
 	fmt.Println()
 
 	return nil
 }
 
-// enrichFeatures builds StatusFeature entries with age and session info.
-// Uses cached session snapshot when available and fresh (< 60s), falls back
-// to real-time scanning otherwise.
-func enrichFeatures(wts []worktree.Worktree) []StatusFeature {
-	// Try to use cached session data
-	sessionMap := make(map[string]reconciler.SessionState)
+// worktreeSessions returns the most recent session of each worktree that
+// has one, keyed by worktree path. Uses the daemon's session snapshot when
+// available and fresh (< 60s), falls back to scanning the worktrees in
+// parallel otherwise.
+func worktreeSessions(wts []worktree.Worktree) map[string]*StatusSession {
+	sessions := make(map[string]*StatusSession)
 	snapshot, _ := reconciler.ReadSessionSnapshot()
-	if reconciler.IsSnapshotFresh(snapshot, 60*time.Second) {
+	if reconciler.IsSnapshotFresh(snapshot, 60*time.Second) && len(snapshot.Sessions) > 0 {
 		for _, s := range snapshot.Sessions {
-			sessionMap[s.WorktreePath] = s
+			sessions[s.WorktreePath] = &StatusSession{
+				ID:         s.SessionID,
+				Status:     s.Status,
+				Model:      s.Model,
+				Tokens:     s.Tokens,
+				LastActive: s.LastModified,
+			}
 		}
+		return sessions
 	}
 
+	// One slot per worktree, nil when it has no session
+	slots := make([]*StatusSession, len(wts))
+	g := new(errgroup.Group)
+	g.SetLimit(5)
+	for i, wt := range wts {
+		g.Go(func() error {
+			found, _ := session.FindSessions(wt.Path)
+			if len(found) == 0 {
+				return nil
+			}
+			s := found[0]
+			model, tokens, _ := session.ParseSessionDetailTail(session.SessionFilePath(wt.Path, s.ID))
+			status := "stopped"
+			if session.IsProcessRunning(s.ID) {
+				status = "running"
+			}
+			slots[i] = &StatusSession{
+				ID:         s.ID,
+				Status:     status,
+				Model:      session.ShortenModel(model),
+				Tokens:     tokens,
+				LastActive: s.Modified,
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	for i, wt := range wts {
+		if slots[i] != nil {
+			sessions[wt.Path] = slots[i]
+		}
+	}
+	return sessions
+}
+
+// enrichFeatures builds StatusFeature entries with age and session info.
+func enrichFeatures(wts []worktree.Worktree, sessions map[string]*StatusSession) []StatusFeature {
 	features := make([]StatusFeature, 0, len(wts))
 	for _, wt := range wts {
 		f := StatusFeature{Worktree: wt}
@@ -223,23 +405,11 @@ func enrichFeatures(wts []worktree.Worktree) []StatusFeature {
 			}
 		}
 
-		// Session status — prefer cache, fall back to real-time
-		if cached, ok := sessionMap[wt.Path]; ok {
+		if s, ok := sessions[wt.Path]; ok {
 			f.HasSession = true
-			f.Running = cached.Status == "running" || cached.Status == "waiting"
-			f.SessionStatus = cached.Status
-		} else if len(sessionMap) == 0 {
-			// No cache available — fall back to real-time scanning
-			sessions, _ := session.FindSessions(wt.Path)
-			if len(sessions) > 0 {
-				f.HasSession = true
-				f.Running = session.IsProcessRunning(sessions[0].ID)
-				if f.Running {
-					f.SessionStatus = "running"
-				} else {
-					f.SessionStatus = "stopped"
-				}
-			}
+			f.Running = s.Status == "running" || s.Status == "waiting"
+			f.SessionStatus = s.Status
+			f.Session = s
 		}
 
 		features = append(features, f)
@@ -249,20 +419,37 @@ func enrichFeatures(wts []worktree.Worktree) []StatusFeature {
 
 // enrichPRReviews builds StatusPRReview entries with remote state and cleanup ETA.
 // Falls back gracefully if GitHub is unreachable.
-func enrichPRReviews(wts []worktree.Worktree, prCache map[string]prcache.PRMeta) []StatusPRReview {
-	ctx := context.Background()
-	ghClient, _ := github.NewClient(ctx)
+func enrichPRReviews(wts []worktree.Worktree, prCache map[string]prcache.PRMeta, sessions map[string]*StatusSession) []StatusPRReview {
+	// One GraphQL request per repo; PRs it couldn't look up have no state
+	statuses, _ := reconciler.FetchPRStatuses(context.Background(), cfg, wts)
 
-	cleanupDays := cfg.Watch.GetCleanupAfterDays()
 	reviews := make([]StatusPRReview, 0, len(wts))
+	watched := make(map[string]bool)
+	for _, w := range reconciler.WatchedPRs() {
+		watched[w.Key] = true
+	}
 
 	for _, wt := range wts {
-		r := StatusPRReview{Worktree: wt}
+		r := StatusPRReview{Worktree: wt, Session: sessions[wt.Path]}
+		prKey := reconciler.MakePRKey(wt.Repo, wt.PRNumber)
+		r.Watched = watched[prKey]
+		status, found := statuses[prKey]
 
 		// Title from cache
 		key := fmt.Sprintf("%s/%d", wt.Repo, wt.PRNumber)
 		if meta, ok := prCache[key]; ok && meta.Title != "" {
 			r.Title = meta.Title
+		} else if found {
+			r.Title = status.Title
+		}
+		r.Labels = prCache[key].Labels
+		if found {
+			r.Labels = status.Labels
+		}
+		r.Held = cfg.Labels.IsHold(r.Labels.Names())
+		r.MyReview = prCache[key].Review
+		if found && status.MyReview != nil {
+			r.MyReview = status.MyReview
 		}
 
 		// Age
@@ -271,25 +458,93 @@ func enrichPRReviews(wts []worktree.Worktree, prCache map[string]prcache.PRMeta)
 		}
 
 		// Remote state
-		if ghClient != nil && wt.PRNumber > 0 {
-			fullRepo := cfg.RepoFullName(wt.Repo)
-			if state, err := ghClient.GetPRState(ctx, fullRepo, wt.PRNumber); err == nil {
-				r.State = state
-				if state == "MERGED" {
-					remaining := cleanupDays - r.AgeDays
-					if remaining < 0 {
-						remaining = 0
-					}
-					r.CleanupIn = remaining
+		if found {
+			r.State = status.State
+			outcome := config.ReviewOutcomeKey("")
+			if r.MyReview != nil {
+				outcome = config.ReviewOutcomeKey(r.MyReview.State)
+			}
+			cleanupDays, keep := cfg.Watch.CleanupAfterDaysFor(outcome)
+			if status.State == "MERGED" && keep {
+				r.Kept = true
+			} else if status.State == "MERGED" && !r.Held {
+				remaining := cleanupDays - r.AgeDays
+				if remaining < 0 {
+					remaining = 0
 				}
+				r.CleanupIn = remaining
 			}
 		}
 
 		reviews = append(reviews, r)
 	}
+
 	return reviews
 }
 
+// watchedWithoutWorktree returns the watched PRs that have no review
+// worktree, as "#N (repo)".
+func watchedWithoutWorktree(reviews []StatusPRReview) []string {
+	have := make(map[string]bool, len(reviews))
+	for _, r := range reviews {
+		have[reconciler.MakePRKey(r.Repo, r.PRNumber)] = true
+	}
+	var out []string
+	for _, w := range reconciler.WatchedPRs() {
+		if !have[w.Key] {
+			out = append(out, fmt.Sprintf("#%d (%s)", w.PRNumber, w.Repo))
+		}
+	}
+	return out
+}
+
+// displayStatusSessions lists the latest session of each worktree, most
+// recently active first, with its token usage.
+func displayStatusSessions(wts []worktree.Worktree, sessions map[string]*StatusSession) {
+	ui.SectionHeader("Sessions")
+	type row struct {
+		name string
+		s    *StatusSession
+	}
+	var rows []row
+	var total session.TokenUsage
+	for _, wt := range wts {
+		if s, ok := sessions[wt.Path]; ok {
+			rows = append(rows, row{wt.Name, s})
+			total.Add(s.Tokens)
+		}
+	}
+	if len(rows) == 0 {
+		fmt.Println("  No Claude sessions")
+		fmt.Println()
+		return
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].s.LastActive > rows[j].s.LastActive })
+
+	fmt.Printf("  %-3s  %-34s  %-8s  %-8s  %-8s  %s\n", "", "Worktree", "Model", "Input", "Output", "Active")
+	fmt.Printf("  %-3s  %-34s  %-8s  %-8s  %-8s  %s\n", "───", "──────────────────────────────────", "────────", "────────", "────────", "────────")
+	for _, r := range rows {
+		icon := ui.DimText(" ○ ")
+		switch r.s.Status {
+		case "running":
+			icon = ui.GreenText(" ● ")
+		case "waiting":
+			icon = ui.YellowText(" ● ")
+		}
+		fmt.Printf("  %s  %-34s  %-8s  %-8s  %-8s  %s\n",
+			icon,
+			ui.Truncate(r.name, 34),
+			ui.Truncate(r.s.Model, 8),
+			session.FormatTokenCount(r.s.Tokens.InputTokens),
+			session.FormatTokenCount(r.s.Tokens.OutputTokens),
+			ui.DimText(session.FormatAge(time.Unix(r.s.LastActive, 0))))
+	}
+	fmt.Printf("  Total: %s in  |  %s out\n",
+		session.FormatTokenCount(total.InputTokens), session.FormatTokenCount(total.OutputTokens))
+	ui.Hint("'zen agent status' for all sessions  |  'zen agent report <worktree>' for findings")
+	fmt.Println()
+}
+
 // formatPRState returns a colored, pre-padded state string for display.
 // Padding is applied before color codes so ANSI escapes don't break alignment.
 func formatPRState(state string, cleanupIn int) string {
@@ -306,6 +561,42 @@ func formatPRState(state string, cleanupIn int) string {
 	}
 }
 
+// reviewCell formats your review of a PR for the review column, "" when
+// you haven't reviewed it.
+func reviewCell(r *github.ReviewOutcome) string {
+	if r == nil {
+		return ""
+	}
+	switch r.State {
+	case "APPROVED":
+		return ui.GreenText("approved")
+	case "CHANGES_REQUESTED":
+		return ui.YellowText("changes requested")
+	default:
+		return ui.DimText(strings.ToLower(r.State))
+	}
+}
+
+// reviewLoad summarizes the last week of the event journal against the
+// review requests pending at the daemon's last poll. Returns nil when the
+// journal has nothing to summarize.
+func reviewLoad() *journal.Week {
+	now := time.Now()
+	events, err := journal.Read(now.Add(-journal.Lookback))
+	if err != nil || len(events) == 0 {
+		return nil
+	}
+	var pending int
+	if data, err := os.ReadFile(lastCheckFile()); err == nil {
+		var state checkState
+		if json.Unmarshal(data, &state) == nil {
+			pending = state.PRCount
+		}
+	}
+	week := journal.Summarize(events, pending, now)
+	return &week
+}
+
 func getDaemonStatus() (string, string) {
 	pidFile := filepath.Join(config.StateDir(), "watch.pid")
 	data, err := os.ReadFile(pidFile)
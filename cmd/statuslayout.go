@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/ui"
+)
+
+var (
+	statusGroupBy string
+	statusSort    string
+)
+
+// Group orders of zen status --group-by state and age; groups not listed
+// come last.
+var (
+	reviewStateGroups  = []string{"Open", "Closed", "Merged", "Unknown"}
+	featureStateGroups = []string{"Waiting", "Running", "Stopped", "No session"}
+	ageGroups          = []string{"Today", "This week", "This month", "Older"}
+)
+
+// statusLayout returns how zen status groups and sorts worktrees: the
+// --group-by and --sort flags, else the status: config.
+func statusLayout() (groupBy, sortBy string, err error) {
+	groupBy, sortBy = cfg.Status.GetGroupBy(), cfg.Status.GetSort()
+	if statusGroupBy != "" {
+		groupBy = statusGroupBy
+	}
+	if statusSort != "" {
+		sortBy = statusSort
+	}
+	if !slices.Contains(config.StatusGroupings, groupBy) {
+		return "", "", fmt.Errorf("invalid --group-by %q: must be one of %s", groupBy, strings.Join(config.StatusGroupings, ", "))
+	}
+	if !slices.Contains(config.StatusSorts, sortBy) {
+		return "", "", fmt.Errorf("invalid --sort %q: must be one of %s", sortBy, strings.Join(config.StatusSorts, ", "))
+	}
+	return groupBy, sortBy, nil
+}
+
+// sortStatusReviews orders PR reviews by repo then PR number, by PR number
+// across repos, or newest first. The checkouts of a PR stay together, main
+// worktree first.
+func sortStatusReviews(reviews []StatusPRReview, by string) {
+	sort.SliceStable(reviews, func(i, j int) bool {
+		a, b := reviews[i], reviews[j]
+		switch {
+		case by == "age" && a.AgeDays != b.AgeDays:
+			return a.AgeDays < b.AgeDays
+		case by == "repo" && a.Repo != b.Repo:
+			return a.Repo < b.Repo
+		case a.PRNumber != b.PRNumber:
+			return a.PRNumber < b.PRNumber
+		case a.Repo != b.Repo:
+			return a.Repo < b.Repo
+		}
+		return a.Suffix < b.Suffix
+	})
+}
+
+// sortStatusFeatures orders feature worktrees by repo then newest first,
+// by name (they have no PR number), or newest first.
+func sortStatusFeatures(features []StatusFeature, by string) {
+	sort.SliceStable(features, func(i, j int) bool {
+		a, b := features[i], features[j]
+		switch by {
+		case "repo":
+			if a.Repo != b.Repo {
+				return a.Repo < b.Repo
+			}
+		case "pr":
+			return a.Name < b.Name
+		}
+		if a.AgeDays != b.AgeDays {
+			return a.AgeDays < b.AgeDays
+		}
+		return a.Name < b.Name
+	})
+}
+
+// statusGroup is one group of rows in a zen status section.
+type statusGroup[T any] struct {
+	Name string
+	Rows []T
+}
+
+// groupRows splits rows by key, keeping their order within each group.
+// Groups come in order, then the others alphabetically. A nil key
+// function puts all rows in one unnamed group.
+func groupRows[T any](rows []T, key func(T) string, order []string) []statusGroup[T] {
+	if len(rows) == 0 {
+		return nil
+	}
+	if key == nil {
+		return []statusGroup[T]{{Rows: rows}}
+	}
+	byName := make(map[string][]T)
+	var names []string
+	for _, r := range rows {
+		k := key(r)
+		if _, ok := byName[k]; !ok {
+			names = append(names, k)
+		}
+		byName[k] = append(byName[k], r)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		a, b := slices.Index(order, names[i]), slices.Index(order, names[j])
+		switch {
+		case a >= 0 && b >= 0:
+			return a < b
+		case a >= 0 || b >= 0:
+			return a >= 0
+		}
+		return names[i] < names[j]
+	})
+	groups := make([]statusGroup[T], 0, len(names))
+	for _, n := range names {
+		groups = append(groups, statusGroup[T]{Name: n, Rows: byName[n]})
+	}
+	return groups
+}
+
+// reviewGroups groups PR reviews for --group-by.
+func reviewGroups(reviews []StatusPRReview, by string) []statusGroup[StatusPRReview] {
+	switch by {
+	case "repo":
+		return groupRows(reviews, func(r StatusPRReview) string { return r.Repo }, nil)
+	case "state":
+		return groupRows(reviews, func(r StatusPRReview) string {
+			switch r.State {
+			case "OPEN":
+				return "Open"
+			case "CLOSED":
+				return "Closed"
+			case "MERGED":
+				return "Merged"
+			}
+			return "Unknown"
+		}, reviewStateGroups)
+	case "age":
+		return groupRows(reviews, func(r StatusPRReview) string { return ageGroup(r.AgeDays) }, ageGroups)
+	}
+	return groupRows(reviews, nil, nil)
+}
+
+// featureGroups groups feature worktrees for --group-by. Their state is
+// that of their latest Claude session.
+func featureGroups(features []StatusFeature, by string) []statusGroup[StatusFeature] {
+	switch by {
+	case "repo":
+		return groupRows(features, func(f StatusFeature) string { return f.Repo }, nil)
+	case "state":
+		return groupRows(features, func(f StatusFeature) string {
+			switch {
+			case f.SessionStatus == "waiting":
+				return "Waiting"
+			case f.SessionStatus == "running":
+				return "Running"
+			case f.HasSession:
+				return "Stopped"
+			}
+			return "No session"
+		}, featureStateGroups)
+	case "age":
+		return groupRows(features, func(f StatusFeature) string { return ageGroup(f.AgeDays) }, ageGroups)
+	}
+	return groupRows(features, nil, nil)
+}
+
+// ageGroup buckets a worktree's age in days.
+func ageGroup(days int) string {
+	switch {
+	case days < 1:
+		return "Today"
+	case days < 7:
+		return "This week"
+	case days < 30:
+		return "This month"
+	}
+	return "Older"
+}
+
+// printGroupHeader names a group within a zen status section. The plain
+// layout's single group has no header.
+func printGroupHeader(name string, n int) {
+	if name == "" {
+		return
+	}
+	fmt.Printf("  %s %s\n", ui.BoldText(name), ui.DimText(fmt.Sprintf("(%d)", n)))
+}
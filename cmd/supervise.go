@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	zenstate "github.com/mgreau/zen/internal/state"
+)
+
+const (
+	// heartbeatInterval is how often the daemon refreshes its heartbeat.
+	heartbeatInterval = 30 * time.Second
+
+	// supervisorCheckInterval is how often the supervisor checks the heartbeat.
+	supervisorCheckInterval = 30 * time.Second
+
+	// Restart backoff for a daemon that keeps dying; reset once it has been
+	// healthy for supervisorHealthyAfter.
+	supervisorMinBackoff   = 5 * time.Second
+	supervisorMaxBackoff   = 5 * time.Minute
+	supervisorHealthyAfter = 10 * time.Minute
+)
+
+func heartbeatFile() string {
+	return filepath.Join(config.StateDir(), "heartbeat")
+}
+
+func supervisorPidFile() string {
+	return filepath.Join(config.StateDir(), "watch.supervisor.pid")
+}
+
+// writeHeartbeat records that the daemon's main loop is alive.
+func writeHeartbeat() {
+	zenstate.WriteFile(heartbeatFile(), []byte(time.Now().UTC().Format(time.RFC3339)), 0o644)
+}
+
+// heartbeatAge returns how long ago the daemon last wrote its heartbeat.
+// ok is false when no heartbeat has been recorded.
+func heartbeatAge() (age time.Duration, ok bool) {
+	data, err := os.ReadFile(heartbeatFile())
+	if err != nil {
+		return 0, false
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, false
+	}
+	return time.Since(t), true
+}
+
+// heartbeatStaleAfter is the heartbeat age at which the daemon is considered
+// hung: twice the poll interval, but never less than a few heartbeats.
+func heartbeatStaleAfter(c *config.Config) time.Duration {
+	d := 2 * c.PollIntervalDuration()
+	if floor := 4 * heartbeatInterval; d < floor {
+		d = floor
+	}
+	return d
+}
+
+// pidAlive reads a PID file and reports whether that process is alive,
+// removing the file when it is stale.
+func pidAlive(path string) (bool, int) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return false, 0
+	}
+	if err := syscall.Kill(pid, 0); err != nil {
+		os.Remove(path)
+		return false, 0
+	}
+	return true, pid
+}
+
+func supervisorIsRunning() (bool, int) {
+	return pidAlive(supervisorPidFile())
+}
+
+// watchSupervisor runs the daemon as a child process and restarts it when it
+// exits or its heartbeat goes stale. Runs until SIGTERM/SIGINT.
+func watchSupervisor() error {
+	config.EnsureDirs()
+	zenstate.WriteFile(supervisorPidFile(), []byte(strconv.Itoa(os.Getpid())), 0o644)
+	defer os.Remove(supervisorPidFile())
+
+	binPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	fmt.Printf("[%s] Supervisor started (stale after %s)\n", time.Now().Format(time.RFC3339), heartbeatStaleAfter(cfg))
+
+	backoff := supervisorMinBackoff
+	for {
+		child := exec.Command(binPath, "watch", "daemon")
+		child.Stdout = os.Stdout
+		child.Stderr = os.Stderr
+		if err := child.Start(); err != nil {
+			return fmt.Errorf("starting daemon: %w", err)
+		}
+		started := time.Now()
+		fmt.Printf("[%s] Supervisor: daemon started (PID: %d)\n", time.Now().Format(time.RFC3339), child.Process.Pid)
+
+		done := make(chan error, 1)
+		go func() { done <- child.Wait() }()
+
+		exitErr := superviseChild(ctx, child, started, done)
+		if ctx.Err() != nil {
+			fmt.Printf("[%s] Supervisor stopping\n", time.Now().Format(time.RFC3339))
+			return nil
+		}
+
+		if time.Since(started) > supervisorHealthyAfter {
+			backoff = supervisorMinBackoff
+		}
+		fmt.Printf("[%s] Supervisor: daemon exited (%v), restarting in %s\n", time.Now().Format(time.RFC3339), exitErr, backoff)
+
+		select {
+		case <-ctx.Done():
+			fmt.Printf("[%s] Supervisor stopping\n", time.Now().Format(time.RFC3339))
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, supervisorMaxBackoff)
+	}
+}
+
+// superviseChild waits for the daemon to exit, killing it first if its
+// heartbeat goes stale or the supervisor is asked to stop.
+func superviseChild(ctx context.Context, child *exec.Cmd, started time.Time, done <-chan error) error {
+	ticker := time.NewTicker(supervisorCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-done:
+			return err
+
+		case <-ctx.Done():
+			child.Process.Signal(syscall.SIGTERM)
+			select {
+			case err := <-done:
+				return err
+			case <-time.After(10 * time.Second):
+				child.Process.Kill()
+				return <-done
+			}
+
+		case <-ticker.C:
+			// Pick up poll_interval changes the daemon itself has reloaded
+			if c, err := config.Load(); err == nil {
+				cfg = c
+			}
+			staleAfter := heartbeatStaleAfter(cfg)
+			if time.Since(started) < staleAfter {
+				continue
+			}
+			if age, ok := heartbeatAge(); ok && age < staleAfter {
+				continue
+			}
+			fmt.Printf("[%s] Supervisor: heartbeat stale (> %s), killing daemon (PID: %d)\n",
+				time.Now().Format(time.RFC3339), staleAfter, child.Process.Pid)
+			child.Process.Kill()
+			return <-done
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -19,9 +20,17 @@ import (
 	"github.com/chainguard-dev/clog"
 	"github.com/mgreau/zen/internal/config"
 	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/hooks"
+	"github.com/mgreau/zen/internal/journal"
+	"github.com/mgreau/zen/internal/launchd"
 	"github.com/mgreau/zen/internal/notify"
+	"github.com/mgreau/zen/internal/prcache"
 	"github.com/mgreau/zen/internal/reconciler"
+	"github.com/mgreau/zen/internal/review"
+	"github.com/mgreau/zen/internal/session"
+	zenstate "github.com/mgreau/zen/internal/state"
 	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
 	"github.com/spf13/cobra"
 )
 
@@ -32,15 +41,30 @@ var watchCmd = &cobra.Command{
 
 Actions:
   start              Start the background daemon
+                     (--supervise: restart it if it exits or its heartbeat goes stale)
   stop               Stop the background daemon
   status             Show daemon status
   logs               Tail daemon log output
-  logs search <term> Search logs for a PR number, worktree, or keyword`,
+  logs search <term> Search logs for a PR number, worktree, or keyword
+  queue              List queued, in-progress, retrying and failed setup/cleanup keys
+  retry <repo:pr>    Re-run a failed worktree setup in the foreground
+  simulate           Run one poll as a dry run and print what the daemon would do
+                     (--fresh: ignore requests already seen by the daemon)
+  install-launchd    Run the daemon as a macOS LaunchAgent: started now, at
+                     login, and again if it crashes
+  uninstall-launchd  Stop the LaunchAgent's daemon and remove it`,
 	Args: cobra.RangeArgs(1, 3),
 	RunE: runWatch,
 }
 
+var (
+	watchSuperviseFlag bool
+	watchFreshFlag     bool
+)
+
 func init() {
+	watchCmd.Flags().BoolVar(&watchSuperviseFlag, "supervise", false, "With start: run under a supervisor that restarts a dead or hung daemon")
+	watchCmd.Flags().BoolVar(&watchFreshFlag, "fresh", false, "With simulate: treat every review request as new")
 	rootCmd.AddCommand(watchCmd)
 }
 
@@ -61,10 +85,25 @@ func runWatch(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("usage: zen watch logs search <term>")
 		}
 		return watchLogs()
+	case "queue":
+		return watchQueue()
+	case "retry":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: zen watch retry <repo:pr>")
+		}
+		return watchRetry(args[1])
 	case "daemon":
 		return watchDaemon()
+	case "supervise":
+		return watchSupervisor()
+	case "simulate":
+		return watchSimulate(cmd.Context())
+	case "install-launchd":
+		return watchInstallLaunchd()
+	case "uninstall-launchd":
+		return watchUninstallLaunchd()
 	default:
-		return fmt.Errorf("unknown action: %s (use start, stop, status, or logs)", action)
+		return fmt.Errorf("unknown action: %s (use start, stop, status, logs, queue, retry, simulate, install-launchd, or uninstall-launchd)", action)
 	}
 }
 
@@ -81,19 +120,7 @@ func lastCheckFile() string {
 }
 
 func watchIsRunning() (bool, int) {
-	data, err := os.ReadFile(pidFile())
-	if err != nil {
-		return false, 0
-	}
-	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
-	if err != nil {
-		return false, 0
-	}
-	if err := syscall.Kill(pid, 0); err != nil {
-		os.Remove(pidFile())
-		return false, 0
-	}
-	return true, pid
+	return pidAlive(pidFile())
 }
 
 func watchStart() error {
@@ -106,6 +133,20 @@ func watchStart() error {
 		ui.LogWarn(fmt.Sprintf("Watch daemon already running (PID: %d)", pid))
 		return nil
 	}
+	if running, pid := supervisorIsRunning(); running {
+		ui.LogWarn(fmt.Sprintf("Watch supervisor already running (PID: %d)", pid))
+		return nil
+	}
+
+	// An installed LaunchAgent owns the daemon; launchd restarts it itself
+	if loaded, _ := launchd.Status(); loaded {
+		if err := launchd.Start(); err != nil {
+			return err
+		}
+		ui.LogSuccess(fmt.Sprintf("Watch daemon started by launchd (%s)", launchd.Label))
+		ui.LogInfo("Log file: " + logFile())
+		return nil
+	}
 
 	binPath, err := os.Executable()
 	if err != nil {
@@ -123,32 +164,62 @@ func watchStart() error {
 		Files: []*os.File{os.Stdin, logF, logF},
 	}
 
-	proc, err := os.StartProcess(binPath, []string{binPath, "watch", "daemon"}, attr)
+	action := "daemon"
+	if watchSuperviseFlag {
+		action = "supervise"
+	}
+
+	proc, err := os.StartProcess(binPath, []string{binPath, "watch", action}, attr)
 	if err != nil {
 		logF.Close()
 		return fmt.Errorf("starting daemon: %w", err)
 	}
 	logF.Close()
 
-	if err := os.WriteFile(pidFile(), []byte(strconv.Itoa(proc.Pid)), 0o644); err != nil {
+	// The supervisor writes its own PID file and the daemon's
+	pf := pidFile()
+	if watchSuperviseFlag {
+		pf = supervisorPidFile()
+	}
+	newPID := proc.Pid // Release resets proc.Pid
+	if err := zenstate.WriteFile(pf, []byte(strconv.Itoa(newPID)), 0o644); err != nil {
 		return err
 	}
 	proc.Release()
 
-	ui.LogSuccess(fmt.Sprintf("Watch daemon started (PID: %d)", proc.Pid))
+	if watchSuperviseFlag {
+		ui.LogSuccess(fmt.Sprintf("Watch supervisor started (PID: %d)", newPID))
+	} else {
+		ui.LogSuccess(fmt.Sprintf("Watch daemon started (PID: %d)", newPID))
+	}
 	ui.LogInfo("Log file: " + logFile())
 	return nil
 }
 
 func watchStop() error {
+	// Stop the supervisor first so it doesn't restart the daemon; it
+	// terminates its daemon child on the way out.
+	supervised, spid := supervisorIsRunning()
+	if supervised {
+		syscall.Kill(spid, syscall.SIGTERM)
+		os.Remove(supervisorPidFile())
+		ui.LogSuccess(fmt.Sprintf("Watch supervisor stopped (PID: %d)", spid))
+	}
+
 	running, pid := watchIsRunning()
 	if !running {
+		if supervised {
+			return nil
+		}
 		ui.LogWarn("Watch daemon is not running")
 		return nil
 	}
 	syscall.Kill(pid, syscall.SIGTERM)
 	os.Remove(pidFile())
 	ui.LogSuccess(fmt.Sprintf("Watch daemon stopped (PID: %d)", pid))
+	if loaded, _ := launchd.Status(); loaded {
+		ui.Hint("launchd starts it again at login -- run 'zen watch uninstall-launchd' to stop that.")
+	}
 	return nil
 }
 
@@ -193,12 +264,32 @@ func watchStatus() error {
 	ui.Separator()
 
 	running, pid := watchIsRunning()
+	loaded, launchdPID := launchd.Status()
+	if !running && launchdPID > 0 {
+		running, pid = true, launchdPID
+	}
 	if running {
 		fmt.Printf("Status: %s\n", ui.GreenText("Running"))
 		fmt.Printf("PID: %d\n", pid)
 	} else {
 		fmt.Printf("Status: %s\n", ui.DimText("Not running"))
 	}
+	switch {
+	case loaded:
+		fmt.Printf("Managed by: launchd (%s, starts at login)\n", launchd.Label)
+	case launchd.Installed():
+		fmt.Printf("Managed by: %s\n", ui.YellowText("launchd, but the LaunchAgent is not loaded -- run 'zen watch install-launchd' again"))
+	}
+	if running, pid := supervisorIsRunning(); running {
+		fmt.Printf("Supervisor: %s (PID: %d)\n", ui.GreenText("Running"), pid)
+	}
+	if age, ok := heartbeatAge(); ok {
+		hb := fmt.Sprintf("%s ago", age.Round(time.Second))
+		if age > heartbeatStaleAfter(cfg) {
+			hb = ui.YellowText(hb + " (stale)")
+		}
+		fmt.Printf("Heartbeat: %s\n", hb)
+	}
 	fmt.Println()
 
 	data, err := os.ReadFile(lastCheckFile())
@@ -215,8 +306,27 @@ func watchStatus() error {
 	}
 	fmt.Println()
 
-	if len(cfg.Authors) > 0 {
-		fmt.Printf("Auto-spawn authors: %s\n", strings.Join(cfg.Authors, " "))
+	if off := disabledComponents(cfg.Watch); len(off) > 0 {
+		fmt.Printf("Disabled: %s\n", ui.YellowText(strings.Join(off, ", ")))
+	}
+	if !cfg.Watch.AutoSpawnEnabled() {
+		fmt.Println("Auto-spawn: disabled (watch.auto_spawn: false)")
+	} else if len(cfg.Authors) > 0 || cfg.AuthorsTeam != "" {
+		if err := loadTeamAuthors(context.Background()); err != nil {
+			ui.LogWarn(err.Error())
+		}
+		fmt.Printf("Auto-spawn authors: %s\n", authorsLabel(cfg.AllAuthors()))
+		if w := cfg.Watch.SpawnWindow; w.Enabled() {
+			days := "every day"
+			if len(w.Days) > 0 {
+				days = strings.Join(w.Days, " ")
+			}
+			state := ui.GreenText("open")
+			if now := time.Now(); !w.Contains(now) {
+				state = ui.YellowText("closed until " + w.NextOpen(now).Format("Mon 15:04"))
+			}
+			fmt.Printf("Spawn window: %s–%s, %s (%s)\n", w.Start, w.End, days, state)
+		}
 	} else {
 		fmt.Println("Auto-spawn: disabled (no authors configured)")
 	}
@@ -224,17 +334,129 @@ func watchStatus() error {
 	return nil
 }
 
-func watchDaemon() error {
-	config.EnsureDirs()
+// watchQueue lists the keys in the daemon's setup and cleanup workqueues
+// from the snapshot it writes on every dispatch tick.
+func watchQueue() error {
+	running, _ := watchIsRunning()
+	state, err := reconciler.LoadQueueState()
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading queue state: %w", err)
+	}
+	if state == nil {
+		state = &reconciler.QueueState{}
+	}
+	if state.Entries == nil {
+		state.Entries = []reconciler.QueueEntry{}
+	}
 
-	os.WriteFile(pidFile(), []byte(strconv.Itoa(os.Getpid())), 0o644)
+	if jsonFlag {
+		printJSON(state)
+		return nil
+	}
 
-	pollInterval := 5 * time.Minute
-	if cfg.PollInterval != "" {
-		if d, err := time.ParseDuration(cfg.PollInterval); err == nil {
-			pollInterval = d
+	fmt.Println()
+	fmt.Println(ui.BoldText("Watch Queues"))
+	if !state.UpdatedAt.IsZero() {
+		ui.Hint(fmt.Sprintf("Snapshot from %s ago", time.Since(state.UpdatedAt).Round(time.Second)))
+	}
+	if !running {
+		ui.Hint("Daemon is not running -- this snapshot may be stale")
+	}
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	if len(state.Entries) == 0 {
+		fmt.Println("  No queued, in-progress or failed keys.")
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("  %-8s  %-24s  %-12s  %-8s  %-10s  %s\n", "Queue", "Key", "State", "Attempts", "Next Retry", "Last Error")
+	fmt.Printf("  %-8s  %-24s  %-12s  %-8s  %-10s  %s\n", "────────", "────────────────────────", "────────────", "────────", "──────────", "────────────────────────")
+	for _, e := range state.Entries {
+		stateCol := fmt.Sprintf("%-12s", e.State)
+		switch e.State {
+		case reconciler.QueueFailed:
+			stateCol = ui.RedText(stateCol)
+		case reconciler.QueueRetrying:
+			stateCol = ui.YellowText(stateCol)
+		case reconciler.QueueInProgress:
+			stateCol = ui.CyanText(stateCol)
+		}
+		next := ""
+		if !e.NextRetry.IsZero() {
+			if d := time.Until(e.NextRetry); d > 0 {
+				next = "in " + d.Round(time.Second).String()
+			} else {
+				next = "due"
+			}
+		}
+		fmt.Printf("  %-8s  %-24s  %s  %-8d  %-10s  %s\n",
+			e.Queue,
+			ui.Truncate(e.Key, 24),
+			stateCol,
+			e.Attempts,
+			next,
+			ui.DimText(ui.Truncate(strings.Join(strings.Fields(e.LastError), " "), 60)))
+	}
+	fmt.Println()
+	for _, e := range state.Entries {
+		if e.Queue == "setup" && e.State == reconciler.QueueFailed {
+			ui.Hint(fmt.Sprintf("Retry a failed setup: zen watch retry %s", e.Key))
+			break
 		}
 	}
+	ui.Hint("Full errors: zen watch logs search <key>")
+	fmt.Println()
+	return nil
+}
+
+// watchRetry re-runs the setup of a PR the daemon gave up on, in the
+// foreground, and clears its "setup failed" mark on success.
+func watchRetry(key string) error {
+	repo, prNumber, err := reconciler.ParsePRKey(key)
+	if err != nil {
+		return err
+	}
+	if cfg.RepoBasePath(repo) == "" {
+		return fmt.Errorf("unknown repo %q", repo)
+	}
+
+	title, author := "", ""
+	if f, ok := reconciler.GetSetupFailure(key); ok {
+		title, author = f.Title, f.Author
+		ui.LogInfo(fmt.Sprintf("Retrying %s PR #%d (failed %s after %d attempt(s))", repo, prNumber, session.FormatAge(f.FailedAt), f.Attempts))
+	} else {
+		ui.LogInfo(fmt.Sprintf("No recorded setup failure for %s, running setup anyway", key))
+	}
+	if meta, ok := prcache.Get(repo, prNumber); ok && title == "" {
+		title, author = meta.Title, meta.Author
+	}
+
+	steps := ui.NewSteps()
+	worktreePath, err := reconciler.NewSetupReconciler(cfg).EnsurePR(context.Background(), repo, prNumber, title, author, steps)
+	if err != nil {
+		return err
+	}
+	reconciler.ClearSetupFailure(key)
+
+	if jsonFlag {
+		printJSON(review.Result{WorktreePath: worktreePath, PRNumber: prNumber, Title: title, Author: author})
+		return nil
+	}
+	fmt.Println()
+	ui.LogSuccess(fmt.Sprintf("Worktree ready: %s", ui.ShortenHome(worktreePath, homeDir())))
+	ui.Hint(fmt.Sprintf("Resume with: zen review resume %d", prNumber))
+	fmt.Println()
+	return nil
+}
+
+func watchDaemon() error {
+	config.EnsureDirs()
+
+	zenstate.WriteFile(pidFile(), []byte(strconv.Itoa(os.Getpid())), 0o644)
+
+	pollInterval := cfg.PollIntervalDuration()
 
 	watchCfg := cfg.Watch
 	dispatchInterval := watchCfg.DispatchIntervalDuration()
@@ -242,18 +464,39 @@ func watchDaemon() error {
 	sessionScanInterval := watchCfg.SessionScanIntervalDuration()
 	digestInterval, digestEnabled := watchCfg.DigestIntervalDuration()
 	concurrency := watchCfg.GetConcurrency()
+	perRepoConcurrency, perRepo := watchCfg.GetPerRepoConcurrency()
 	maxRetries := watchCfg.GetMaxRetries()
 
 	digestStr := "disabled"
 	if digestEnabled {
 		digestStr = digestInterval.String()
 	}
-	fmt.Printf("[%s] Watch daemon started (poll=%s, dispatch=%s, cleanup=%s, session_scan=%s, digest=%s, concurrency=%d, maxRetries=%d)\n",
-		time.Now().Format(time.RFC3339), pollInterval, dispatchInterval, cleanupInterval, sessionScanInterval, digestStr, concurrency, maxRetries)
+	concurrencyStr := fmt.Sprintf("%d", concurrency)
+	if perRepo {
+		concurrency = perRepoConcurrency
+		concurrencyStr = fmt.Sprintf("%d/repo", perRepoConcurrency)
+	}
+	fmt.Printf("[%s] Watch daemon started (poll=%s, dispatch=%s, cleanup=%s, session_scan=%s, digest=%s, concurrency=%s, maxRetries=%d)\n",
+		time.Now().Format(time.RFC3339), pollInterval, dispatchInterval, cleanupInterval, sessionScanInterval, digestStr, concurrencyStr, maxRetries)
+	if off := disabledComponents(watchCfg); len(off) > 0 {
+		fmt.Printf("[%s] Disabled: %s\n", time.Now().Format(time.RFC3339), strings.Join(off, ", "))
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Notifications are delivered in the background, batched and deduped,
+	// and whatever is still queued is delivered before the daemon exits
+	stopNotify := notify.Start(watchCfg.NotifyBatchWindowDuration(), watchCfg.NotifyDedupeWindowDuration())
+	defer stopNotify()
+	notify.Mute(!watchCfg.NotifyEnabled())
+
+	// Events are relayed to the configured hooks the same way
+	stopHooks := hooks.Start(cfg.Hooks, func(format string, args ...any) {
+		fmt.Printf("[%s] %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+	})
+	defer stopHooks()
+
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
 
@@ -266,13 +509,22 @@ func watchDaemon() error {
 	setupCtx := clog.WithLogger(ctx, clog.FromContext(ctx).With("queue", "setup"))
 	cleanupCtx := clog.WithLogger(ctx, clog.FromContext(ctx).With("queue", "cleanup"))
 
-	// Create workqueues and reconcilers
-	setupQueue := inmem.NewWorkQueue(10)
-	cleanupQueue := inmem.NewWorkQueue(10)
+	// Create workqueues and reconcilers. With per_repo_concurrency set, each
+	// repo gets its own setup queue so a slow fetch can't starve the others.
+	// The tracker records every queued key and attempt for zen watch queue.
+	tracker := reconciler.NewQueueTracker()
+	setupQueues := reconciler.NewQueueSet(10, perRepo)
+	setupQueues.Track(tracker, "setup")
+	cleanupQueue := tracker.Track("cleanup", inmem.NewWorkQueue(10))
 	setupRec := reconciler.NewSetupReconciler(cfg)
 	cleanupRec := reconciler.NewCleanupReconciler(cfg)
+	setupFn := tracker.Wrap("setup", setupRec.Reconcile, maxRetries)
+	tracker.OnFailed("setup", setupRec.SetupFailed)
+	cleanupFn := tracker.Wrap("cleanup", cleanupRec.Reconcile, 3)
 
 	seenPRs := loadSeenPRs()
+	// Pending review requests as of the last poll, to journal how they end
+	requested := make(map[string]ghpkg.ReviewRequest)
 
 	pollTicker := time.NewTicker(pollInterval)
 	defer pollTicker.Stop()
@@ -285,6 +537,10 @@ func watchDaemon() error {
 	sessionTicker := time.NewTicker(sessionScanInterval)
 	defer sessionTicker.Stop()
 
+	// Heartbeat ticker — lets `zen status` and the supervisor detect a hung loop
+	heartbeatTicker := time.NewTicker(heartbeatInterval)
+	defer heartbeatTicker.Stop()
+
 	// Log rotation ticker — check once per hour
 	rotateTicker := time.NewTicker(1 * time.Hour)
 	defer rotateTicker.Stop()
@@ -297,9 +553,34 @@ func watchDaemon() error {
 		digestC = digestTicker.C
 	}
 
-	// Initial poll and session scan
-	pollOnce(ctx, seenPRs, setupQueue, setupRec)
+	// Config edits apply right away instead of at the next poll
+	configC := make(chan *config.Config, 1)
+	if err := config.Watch(ctx, func(c *config.Config) {
+		select {
+		case configC <- c:
+		case <-ctx.Done():
+		}
+	}, func(err error) {
+		fmt.Printf("[%s] Config reload failed: %v\n", time.Now().Format(time.RFC3339), err)
+	}); err != nil {
+		fmt.Printf("[%s] Not watching the config file: %v\n", time.Now().Format(time.RFC3339), err)
+	}
+
+	// Worktree listings are reused until git changes them
+	if err := wt.WatchLists(ctx, cfg); err != nil {
+		fmt.Printf("[%s] Not watching worktrees: %v\n", time.Now().Format(time.RFC3339), err)
+	}
+
+	// New review requests polled outside watch.spawn_window are queued
+	// to start when it opens; requested setups and retries are not held
+	spawnOpen := true
+
+	// Initial heartbeat, poll and session scan
+	writeHeartbeat()
+	pollOnce(ctx, seenPRs, requested, setupQueues, setupRec)
+	reconciler.CheckWatchedPRs(ctx, cfg)
 	reconciler.ScanSessions(cfg, 10*time.Second)
+	reconciler.FillPoolsAsync(ctx, cfg)
 
 	for {
 		select {
@@ -308,26 +589,57 @@ func watchDaemon() error {
 			os.Remove(pidFile())
 			return nil
 
+		case <-heartbeatTicker.C:
+			writeHeartbeat()
+
+		case newCfg := <-configC:
+			applyConfig(newCfg, setupRec, cleanupRec, pollTicker)
+			fmt.Printf("[%s] Config reloaded: %s changed\n", time.Now().Format(time.RFC3339), config.Path())
+
 		case <-rotateTicker.C:
 			rotateLogIfNeeded()
 
 		case <-pollTicker.C:
 			reloadConfig(setupRec, cleanupRec, pollTicker)
-			pollOnce(ctx, seenPRs, setupQueue, setupRec)
+			pollOnce(ctx, seenPRs, requested, setupQueues, setupRec)
+			reconciler.CheckWatchedPRs(ctx, cfg)
+			reconciler.RefreshContextsAsync(ctx, cfg)
+			reconciler.FillPoolsAsync(ctx, cfg)
 
 		case <-dispatchTicker.C:
-			if err := dispatcher.HandleAsync(setupCtx, setupQueue, concurrency, concurrency, setupRec.Reconcile, maxRetries)(); err != nil {
-				fmt.Printf("[%s] Setup dispatch error: %v\n", time.Now().Format(time.RFC3339), err)
+			now := time.Now()
+			if open := cfg.Watch.SpawnWindow.Contains(now); open != spawnOpen {
+				spawnOpen = open
+				if open {
+					fmt.Printf("[%s] Spawn window open: processing held setups\n", now.Format(time.RFC3339))
+				} else {
+					fmt.Printf("[%s] Outside spawn window: holding new setups until %s\n",
+						now.Format(time.RFC3339), cfg.Watch.SpawnWindow.NextOpen(now).Format("Mon 15:04"))
+				}
 			}
-			if err := dispatcher.HandleAsync(cleanupCtx, cleanupQueue, 1, 1, cleanupRec.Reconcile, 3)(); err != nil {
+			queueSetupRequests(ctx, setupQueues, setupRec)
+			setupQueues.Each(func(repo string, q workqueue.Interface) {
+				qctx := setupCtx
+				if repo != "" {
+					qctx = clog.WithLogger(setupCtx, clog.FromContext(setupCtx).With("repo", repo))
+				}
+				if err := dispatcher.HandleAsync(qctx, q, concurrency, concurrency, setupFn, maxRetries)(); err != nil {
+					fmt.Printf("[%s] Setup dispatch error%s: %v\n", time.Now().Format(time.RFC3339), repoSuffix(repo), err)
+				}
+			})
+			queueCleanupRequests(ctx, cleanupQueue, cleanupRec)
+			if err := dispatcher.HandleAsync(cleanupCtx, cleanupQueue, 1, 1, cleanupFn, 3)(); err != nil {
 				fmt.Printf("[%s] Cleanup dispatch error: %v\n", time.Now().Format(time.RFC3339), err)
 			}
+			tracker.Save(ctx)
 
 		case <-sessionTicker.C:
 			reconciler.ScanSessions(cfg, 10*time.Second)
 
 		case <-cleanupTicker.C:
-			reconciler.ScanMergedPRs(ctx, cfg, cleanupQueue, cfg.Watch.GetCleanupAfterDays())
+			reconciler.ScanMergedPRs(ctx, cfg, cleanupQueue)
+			reconciler.MaybeSendCleanupSummary()
+			reconciler.MaybeCollectSessions(cfg)
 
 		case <-digestC:
 			reconciler.SendDigest(cfg)
@@ -335,28 +647,23 @@ func watchDaemon() error {
 	}
 }
 
-// reloadConfig re-reads ~/.zen/config.yaml and updates the global cfg
-// and reconcilers. If the poll interval changed, the ticker is reset.
+// reloadConfig re-reads ~/.zen/config.yaml and applies it. This catches
+// edits the file watcher missed, e.g. on filesystems without inotify.
 func reloadConfig(setupRec *reconciler.SetupReconciler, cleanupRec *reconciler.CleanupReconciler, pollTicker *time.Ticker) {
 	newCfg, err := config.Load()
 	if err != nil {
 		fmt.Printf("[%s] Config reload failed: %v\n", time.Now().Format(time.RFC3339), err)
 		return
 	}
+	applyConfig(newCfg, setupRec, cleanupRec, pollTicker)
+}
 
+// applyConfig makes newCfg the global cfg and hands it to the reconcilers.
+// If the poll interval changed, the ticker is reset.
+func applyConfig(newCfg *config.Config, setupRec *reconciler.SetupReconciler, cleanupRec *reconciler.CleanupReconciler, pollTicker *time.Ticker) {
 	// Detect poll interval change
-	oldInterval := 5 * time.Minute
-	if cfg.PollInterval != "" {
-		if d, err := time.ParseDuration(cfg.PollInterval); err == nil {
-			oldInterval = d
-		}
-	}
-	newInterval := 5 * time.Minute
-	if newCfg.PollInterval != "" {
-		if d, err := time.ParseDuration(newCfg.PollInterval); err == nil {
-			newInterval = d
-		}
-	}
+	oldInterval := cfg.PollIntervalDuration()
+	newInterval := newCfg.PollIntervalDuration()
 
 	if oldInterval != newInterval {
 		pollTicker.Reset(newInterval)
@@ -364,11 +671,40 @@ func reloadConfig(setupRec *reconciler.SetupReconciler, cleanupRec *reconciler.C
 			time.Now().Format(time.RFC3339), oldInterval, newInterval)
 	}
 
+	if old, now := disabledComponents(cfg.Watch), disabledComponents(newCfg.Watch); !slices.Equal(old, now) {
+		fmt.Printf("[%s] Config reloaded: disabled components %s → %s\n",
+			time.Now().Format(time.RFC3339), componentList(old), componentList(now))
+	}
+	notify.Mute(!newCfg.Watch.NotifyEnabled())
+
 	cfg = newCfg
+	hooks.Configure(newCfg.Hooks)
 	setupRec.SetConfig(newCfg)
 	cleanupRec.SetConfig(newCfg)
 }
 
+// disabledComponents lists the daemon components turned off in w.
+func disabledComponents(w config.WatchConfig) []string {
+	var off []string
+	if !w.NotifyEnabled() {
+		off = append(off, "notify")
+	}
+	if !w.AutoSpawnEnabled() {
+		off = append(off, "auto_spawn")
+	}
+	if !w.AutoCleanupEnabled() {
+		off = append(off, "auto_cleanup")
+	}
+	return off
+}
+
+func componentList(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
 type checkState struct {
 	Timestamp string   `json:"timestamp"`
 	PRCount   int      `json:"pr_count"`
@@ -386,11 +722,20 @@ func loadSeenPRs() map[string]bool {
 	}
 	m := make(map[string]bool)
 	for _, pr := range state.SeenPRs {
+		// Before every repo was polled, only chainguard-dev/mono was and
+		// entries were bare PR numbers
+		if n, err := strconv.Atoi(pr); err == nil {
+			pr = reconciler.MakePRKey(cfg.RepoShortName(legacyPollRepo), n)
+		}
 		m[pr] = true
 	}
 	return m
 }
 
+// legacyPollRepo is the only repo polled by daemons that saved seen PRs as
+// bare numbers.
+const legacyPollRepo = "chainguard-dev/mono"
+
 func saveState(seenPRs map[string]bool, prCount int) {
 	prs := make([]string, 0, len(seenPRs))
 	for pr := range seenPRs {
@@ -401,45 +746,284 @@ func saveState(seenPRs map[string]bool, prCount int) {
 		PRCount:   prCount,
 		SeenPRs:   prs,
 	}
-	data, _ := json.MarshalIndent(state, "", "  ")
-	os.WriteFile(lastCheckFile(), data, 0o644)
+	zenstate.WriteJSON(lastCheckFile(), state)
+}
+
+// repoSuffix formats a repo name for log lines, or "" for the shared queue.
+func repoSuffix(repo string) string {
+	if repo == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", repo)
+}
+
+// pollReviewRequests fetches the pending review requests of every
+// configured repo, in repo-name order.
+func pollReviewRequests(ctx context.Context) ([]ghpkg.ReviewRequest, error) {
+	names := cfg.RepoNames()
+	slices.Sort(names)
+	var reviews []ghpkg.ReviewRequest
+	for _, name := range names {
+		rr, _, err := ghpkg.GetReviewRequests(ctx, cfg.RepoFullName(name), cfg.GetSearchLimit())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		reviews = append(reviews, rr...)
+	}
+	return reviews, nil
+}
+
+// PollDecision is what a poll does with one review request, as computed
+// by decidePoll and printed by zen watch simulate.
+type PollDecision struct {
+	Repo      string `json:"repo"`
+	PRNumber  int    `json:"pr_number"`
+	Title     string `json:"title"`
+	Author    string `json:"author"`
+	Rereview  bool   `json:"rereview"`
+	Seen      bool   `json:"seen"` // handled by an earlier poll, so skipped
+	Notify    bool   `json:"notify"`
+	NotifyWhy string `json:"notify_why,omitempty"` // ignore pattern that suppressed the notification
+	Queue     bool   `json:"queue"`                // queued for worktree setup
+	Urgent    bool   `json:"urgent,omitempty"`     // has an urgent label, so set up first
+	QueueWhy  string `json:"queue_why,omitempty"`  // why it is not queued
+	HeldUntil string `json:"held_until,omitempty"` // queued outside the spawn window
+	Warning   string `json:"warning,omitempty"`    // setup will likely fail or do nothing
+	key       string // reconciler key
+	pr        ghpkg.ReviewRequest
+	// notBefore is when a setup held by the spawn window may start
+	notBefore time.Time
 }
 
-func pollOnce(ctx context.Context, seenPRs map[string]bool, queue workqueue.Interface, rec *reconciler.SetupReconciler) {
-	reviews, err := ghpkg.GetReviewRequests(ctx, "chainguard-dev/mono")
+// Setup queue priorities: higher values are processed first.
+const (
+	setupPriority       = 1
+	urgentSetupPriority = 10
+)
+
+// decidePoll applies the daemon's rules to the review requests of a poll:
+// requests seen by an earlier poll are skipped; new ones are notified
+// unless watch.notify is off or watch.ignore.notify matches, and queued
+// for setup when their author is in authors, watch.auto_spawn is on and
+// watch.ignore.setup does not match, ahead of the others when they have
+// an urgent label.
+func decidePoll(reviews []ghpkg.ReviewRequest, seenPRs map[string]bool, now time.Time) []PollDecision {
+	decisions := make([]PollDecision, 0, len(reviews))
+	for _, pr := range reviews {
+		d := PollDecision{
+			Repo:     pr.Repository.Name,
+			PRNumber: pr.Number,
+			Title:    pr.Title,
+			Author:   pr.Author.Login,
+			Rereview: pr.Rereview,
+			Seen:     seenPRs[reconciler.MakePRKey(pr.Repository.Name, pr.Number)],
+			key:      reconciler.MakePRKey(pr.Repository.Name, pr.Number),
+			pr:       pr,
+		}
+		if d.Seen {
+			decisions = append(decisions, d)
+			continue
+		}
+
+		if !cfg.Watch.NotifyEnabled() {
+			d.NotifyWhy = "watch.notify is off"
+		} else if pattern, ignored := cfg.Watch.Ignore.Notify.Match(pr.Author.Login, pr.Title); ignored {
+			d.NotifyWhy = "ignored by " + pattern
+		} else {
+			d.Notify = true
+		}
+
+		switch pattern, ignored := cfg.Watch.Ignore.Setup.Match(pr.Author.Login, pr.Title); {
+		case !cfg.IsAuthor(pr.Author.Login):
+			d.QueueWhy = fmt.Sprintf("author %s is not in authors", pr.Author.Login)
+		case !cfg.Watch.AutoSpawnEnabled():
+			d.QueueWhy = "watch.auto_spawn is off"
+		case ignored:
+			d.QueueWhy = "ignored by " + pattern
+		default:
+			d.Queue = true
+			d.Urgent = cfg.Labels.IsUrgent(pr.Labels.Names())
+			if !cfg.Watch.SpawnWindow.Contains(now) {
+				d.notBefore = cfg.Watch.SpawnWindow.NextOpen(now)
+				d.HeldUntil = d.notBefore.Format("Mon 15:04")
+			}
+			if cfg.RepoBasePath(d.Repo) == "" {
+				d.Warning = fmt.Sprintf("repo %q is not configured, setup will fail", d.Repo)
+			}
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions
+}
+
+func pollOnce(ctx context.Context, seenPRs map[string]bool, requested map[string]ghpkg.ReviewRequest, queues *reconciler.QueueSet, rec *reconciler.SetupReconciler) {
+	reviews, err := pollReviewRequests(ctx)
 	if err != nil {
 		fmt.Printf("[%s] Error fetching reviews: %v\n", time.Now().Format(time.RFC3339), err)
 		return
 	}
 
+	journalResolved(ctx, requested, reviews)
+
+	if err := loadTeamAuthors(ctx); err != nil {
+		fmt.Printf("[%s] %v (using the last known members)\n", time.Now().Format(time.RFC3339), err)
+	}
+
+	// Keep the labels of PRs with worktrees current, for hold and
+	// no-ai-review
+	labels := make(map[string]ghpkg.Labels, len(reviews))
 	for _, pr := range reviews {
-		prKey := fmt.Sprintf("%d", pr.Number)
-		if seenPRs[prKey] {
+		labels[prcache.Key(pr.Repository.Name, pr.Number)] = pr.Labels
+	}
+	prcache.UpdateLabels(labels)
+
+	for _, d := range decidePoll(reviews, seenPRs, time.Now()) {
+		if d.Seen {
 			continue
 		}
+		pr := d.pr
 
 		fmt.Printf("[%s] New PR review request: #%d - %s (by %s)\n",
 			time.Now().Format(time.RFC3339), pr.Number, pr.Title, pr.Author.Login)
 
-		notify.PRReview(pr.Number, pr.Title, pr.Author.Login, pr.Repository.Name)
+		if !pr.Rereview {
+			journal.Append(journal.Event{
+				Kind:     journal.ReviewRequested,
+				Repo:     pr.Repository.NameWithOwner,
+				PRNumber: pr.Number,
+				Title:    pr.Title,
+				Author:   pr.Author.Login,
+			})
+		}
+
+		if d.Notify {
+			notify.PRReview(pr.Number, pr.Title, pr.Author.Login, pr.Repository.Name)
+		} else {
+			fmt.Printf("[%s] Not notifying for PR #%d: %s\n", time.Now().Format(time.RFC3339), pr.Number, d.NotifyWhy)
+		}
 
-		if cfg.IsAuthor(pr.Author.Login) {
-			key := reconciler.MakePRKey(pr.Repository.Name, pr.Number)
-			rec.StorePRData(key, pr)
-			if err := queue.Queue(ctx, key, workqueue.Options{Priority: 1}); err != nil {
+		if d.Queue {
+			rec.StorePRData(d.key, pr)
+			var priority int64 = setupPriority
+			if d.Urgent {
+				priority = urgentSetupPriority
+			}
+			// Outside watch.spawn_window the setup waits in the queue until
+			// the window opens
+			opts := workqueue.Options{Priority: priority, NotBefore: d.notBefore}
+			if err := queues.For(pr.Repository.Name).Queue(ctx, d.key, opts); err != nil {
 				fmt.Printf("[%s] Error queuing PR #%d: %v\n", time.Now().Format(time.RFC3339), pr.Number, err)
 			} else {
-				fmt.Printf("[%s] Queued PR #%d for setup (author: %s)\n",
-					time.Now().Format(time.RFC3339), pr.Number, pr.Author.Login)
+				held := ""
+				if d.Urgent {
+					held = ", urgent"
+				}
+				if d.HeldUntil != "" {
+					held += ", held until " + d.HeldUntil
+				}
+				fmt.Printf("[%s] Queued PR #%d for setup (author: %s%s)\n",
+					time.Now().Format(time.RFC3339), pr.Number, pr.Author.Login, held)
 			}
+		} else if cfg.IsAuthor(pr.Author.Login) {
+			fmt.Printf("[%s] Not setting up PR #%d: %s\n", time.Now().Format(time.RFC3339), pr.Number, d.QueueWhy)
 		}
 
-		seenPRs[prKey] = true
+		seenPRs[d.key] = true
 	}
 
 	saveState(seenPRs, len(reviews))
 }
 
+// queueSetupRequests queues the setups requested through the
+// zen_review_setup MCP tool since the last dispatch.
+func queueSetupRequests(ctx context.Context, queues *reconciler.QueueSet, rec *reconciler.SetupReconciler) {
+	for _, r := range reconciler.TakeSetupRequests() {
+		if cfg.RepoBasePath(r.Repo) == "" {
+			fmt.Printf("[%s] Ignoring setup request for %s: unknown repo\n", time.Now().Format(time.RFC3339), r.Key)
+			continue
+		}
+		rec.StorePRData(r.Key, ghpkg.ReviewRequest{
+			Number:     r.PRNumber,
+			Title:      r.Title,
+			Author:     ghpkg.AuthorInfo{Login: r.Author},
+			Repository: ghpkg.RepoInfo{Name: r.Repo, NameWithOwner: cfg.RepoFullName(r.Repo)},
+		})
+		if err := queues.For(r.Repo).Queue(ctx, r.Key, workqueue.Options{Priority: setupPriority}); err != nil {
+			fmt.Printf("[%s] Error queuing requested setup of %s: %v\n", time.Now().Format(time.RFC3339), r.Key, err)
+			continue
+		}
+		fmt.Printf("[%s] Queued %s PR #%d for setup (requested through zen mcp)\n", time.Now().Format(time.RFC3339), r.Repo, r.PRNumber)
+	}
+}
+
+// queueCleanupRequests queues the cleanups requested by zen pr merge since
+// the last dispatch.
+func queueCleanupRequests(ctx context.Context, queue workqueue.Interface, rec *reconciler.CleanupReconciler) {
+	for _, r := range reconciler.TakeCleanupRequests() {
+		if cfg.RepoBasePath(r.Repo) == "" {
+			fmt.Printf("[%s] Ignoring cleanup request for %s: unknown repo\n", time.Now().Format(time.RFC3339), r.Key)
+			continue
+		}
+		rec.StoreRequest(r)
+		if err := queue.Queue(ctx, r.Key, workqueue.Options{}); err != nil {
+			fmt.Printf("[%s] Error queuing requested cleanup of %s: %v\n", time.Now().Format(time.RFC3339), r.Key, err)
+			continue
+		}
+		fmt.Printf("[%s] Queued %s PR #%d for cleanup (merged with zen pr merge)\n", time.Now().Format(time.RFC3339), r.Repo, r.PRNumber)
+	}
+}
+
+// journalResolved records a review_completed or request_dropped event for
+// each PR in requested that no longer has a pending request for your
+// review, then adds the pending requests in reviews to requested.
+func journalResolved(ctx context.Context, requested map[string]ghpkg.ReviewRequest, reviews []ghpkg.ReviewRequest) {
+	pending := make(map[string]bool, len(reviews))
+	for _, pr := range reviews {
+		if !pr.Rereview {
+			pending[reconciler.MakePRKey(pr.Repository.Name, pr.Number)] = true
+		}
+	}
+
+	var client *ghpkg.Client
+	for key, pr := range requested {
+		if pending[key] {
+			continue
+		}
+		if client == nil {
+			c, err := ghpkg.NewClient(ctx)
+			if err != nil {
+				fmt.Printf("[%s] Journal: creating GitHub client: %v\n", time.Now().Format(time.RFC3339), err)
+				return
+			}
+			client = c
+		}
+		// Kept in requested on error so the next poll tries again
+		state, err := client.GetReviewStatus(ctx, pr.Repository.NameWithOwner, pr.Number)
+		if err != nil {
+			fmt.Printf("[%s] Journal: review status of %s PR #%d: %v\n", time.Now().Format(time.RFC3339), pr.Repository.Name, pr.Number, err)
+			continue
+		}
+		delete(requested, key)
+		kind := journal.RequestDropped
+		if state != "" && state != "PENDING" {
+			kind = journal.ReviewCompleted
+		}
+		journal.Append(journal.Event{
+			Kind:     kind,
+			Repo:     pr.Repository.NameWithOwner,
+			PRNumber: pr.Number,
+			Title:    pr.Title,
+			Author:   pr.Author.Login,
+		})
+	}
+
+	for _, pr := range reviews {
+		if key := reconciler.MakePRKey(pr.Repository.Name, pr.Number); pending[key] {
+			requested[key] = pr
+		}
+	}
+}
+
 const maxLogSize = 10 * 1024 * 1024 // 10 MB
 
 // rotateLogIfNeeded checks the log file size and rotates if it exceeds maxLogSize.
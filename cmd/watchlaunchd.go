@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/launchd"
+	"github.com/mgreau/zen/internal/ui"
+)
+
+// launchdEnv lists the variables passed to the daemon when they are set:
+// launchd starts agents with a minimal PATH, and the others select zen's
+// config and state.
+var launchdEnv = []string{"PATH", "ZEN_CONFIG", "ZEN_HOME", "XDG_CONFIG_HOME", "XDG_STATE_HOME", "GH_HOST"}
+
+// watchInstallLaunchd installs the daemon as a LaunchAgent, which starts
+// it now and at every login.
+func watchInstallLaunchd() error {
+	if err := config.EnsureDirs(); err != nil {
+		return err
+	}
+	if running, pid := watchIsRunning(); running {
+		if loaded, _ := launchd.Status(); !loaded {
+			return fmt.Errorf("the watch daemon is already running (PID %d) -- stop it first with: zen watch stop", pid)
+		}
+	}
+
+	binPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	agent := launchd.Agent{
+		Program: binPath,
+		WorkDir: homeDir(),
+		LogPath: logFile(),
+		Env:     map[string]string{"HOME": homeDir()},
+	}
+	for _, k := range launchdEnv {
+		if v := os.Getenv(k); v != "" {
+			agent.Env[k] = v
+		}
+	}
+	if err := launchd.Install(agent); err != nil {
+		return err
+	}
+
+	home := homeDir()
+	ui.LogSuccess(fmt.Sprintf("Installed LaunchAgent %s", launchd.Label))
+	ui.LogInfo("Plist: " + ui.ShortenHome(launchd.PlistPath(), home))
+	ui.LogInfo("Log file: " + ui.ShortenHome(logFile(), home))
+	ui.Hint("The daemon now starts at login. Reinstall after moving the zen binary or changing PATH.")
+	return nil
+}
+
+// watchUninstallLaunchd stops the LaunchAgent's daemon and removes it.
+func watchUninstallLaunchd() error {
+	removed, err := launchd.Uninstall()
+	if err != nil {
+		return err
+	}
+	if !removed {
+		ui.LogWarn("The LaunchAgent is not installed")
+		return nil
+	}
+	os.Remove(pidFile())
+	ui.LogSuccess(fmt.Sprintf("Uninstalled LaunchAgent %s (daemon stopped)", launchd.Label))
+	return nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mgreau/zen/internal/ui"
+)
+
+// watchSimulate runs the decision half of one daemon poll in the
+// foreground and prints what the daemon would do with each review
+// request. Nothing is notified, queued, journaled or marked seen.
+func watchSimulate(ctx context.Context) error {
+	reviews, err := pollReviewRequests(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching review requests: %w", err)
+	}
+
+	if err := loadTeamAuthors(ctx); err != nil {
+		ui.LogWarn(err.Error())
+	}
+
+	seenPRs := loadSeenPRs()
+	if watchFreshFlag {
+		seenPRs = make(map[string]bool)
+	}
+	decisions := decidePoll(reviews, seenPRs, time.Now())
+
+	local := make(map[string]map[int]bool)
+	for i, d := range decisions {
+		if !d.Queue || d.Warning != "" {
+			continue
+		}
+		if local[d.Repo] == nil {
+			local[d.Repo] = getLocalPRNumbers(d.Repo)
+		}
+		if local[d.Repo][d.PRNumber] {
+			decisions[i].Warning = "worktree exists, setup will only refresh it"
+		}
+	}
+
+	if jsonFlag {
+		printJSON(decisions)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(ui.BoldText("Watch Simulate"))
+	ui.Hint("One poll, dry run: nothing is notified, queued or marked seen")
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	if len(decisions) == 0 {
+		fmt.Println("  No pending review requests.")
+		fmt.Println()
+		return nil
+	}
+
+	t := ui.NewTable([]ui.Column{
+		{Key: "pr", Header: "PR"},
+		{Key: "author", Header: "Author", Max: 20},
+		{Key: "title", Header: "Title", Flex: true, Min: 20},
+		{Key: "notify", Header: "Notify"},
+		{Key: "setup", Header: "Setup"},
+		{Key: "reason", Header: "Reason"},
+	}, nil)
+	var newPRs, queued int
+	for _, d := range decisions {
+		notifyCol, setupCol, reason := "-", "-", "seen by an earlier poll"
+		if !d.Seen {
+			newPRs++
+			reason = ""
+			notifyCol = ui.GreenText("yes")
+			if !d.Notify {
+				notifyCol = ui.DimText("no")
+			}
+			switch {
+			case d.Queue && d.HeldUntil != "":
+				queued++
+				setupCol = ui.YellowText("held")
+				reason = "outside spawn window until " + d.HeldUntil
+			case d.Queue:
+				queued++
+				setupCol = ui.GreenText("queue")
+			default:
+				setupCol = ui.DimText("skip")
+				reason = "setup: " + d.QueueWhy
+			}
+			if d.Urgent {
+				reason = joinReason("urgent label, set up first", reason)
+			}
+			if d.NotifyWhy != "" {
+				reason = joinReason("notify: "+d.NotifyWhy, reason)
+			}
+			if d.Warning != "" {
+				reason = joinReason(reason, ui.YellowText(d.Warning))
+			}
+		}
+		title := d.Title
+		if d.Rereview {
+			title = "(re-review) " + title
+		}
+		t.Row(prCell(d.PRNumber), d.Author, title, notifyCol, setupCol, ui.DimText(reason))
+	}
+	t.Print()
+	fmt.Println()
+	fmt.Printf("  %d request(s): %d new, %d would be queued for setup\n", len(decisions), newPRs, queued)
+	if newPRs < len(decisions) && !watchFreshFlag {
+		ui.Hint("Treat every request as new: zen watch simulate --fresh")
+	}
+	fmt.Println()
+	return nil
+}
+
+func joinReason(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return a + "; " + b
+}
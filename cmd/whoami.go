@@ -33,7 +33,7 @@ PR reviews, and Claude session activity. Defaults to the last 7 days.`,
 
 func init() {
 	whoamiCmd.Flags().StringVarP(&whoamiPeriod, "period", "p", "7d", "Time period (e.g., 1d, 7d, 30d)")
-	whoamiCmd.Flags().StringVarP(&whoamiRepo, "repo", "r", "", "Filter by repo (short name)")
+	whoamiCmd.Flags().StringVarP(&whoamiRepo, "repo", "r", "", "Filter by repo (short name or @group)")
 	whoamiCmd.Flags().BoolVar(&whoamiMerged, "merged", false, "Show only merged & deployed PRs")
 	rootCmd.AddCommand(whoamiCmd)
 }
@@ -80,12 +80,16 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 	}
 
 	// Determine which repos to scan
-	repos := cfg.RepoNames()
-	if whoamiRepo != "" {
-		if cfg.RepoBasePath(whoamiRepo) == "" {
-			return fmt.Errorf("unknown repo %q", whoamiRepo)
+	repos, err := cfg.ResolveRepos(whoamiRepo)
+	if err != nil {
+		return err
+	}
+	repoFilter := make(map[string]bool, len(repos))
+	for _, r := range repos {
+		if cfg.RepoBasePath(r) == "" {
+			return fmt.Errorf("unknown repo %q", r)
 		}
-		repos = []string{whoamiRepo}
+		repoFilter[r] = true
 	}
 
 	// --- Merged work (commits on origin/main by the user) ---
@@ -116,7 +120,7 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 	totalBranchCommits := 0
 
 	for _, w := range wts {
-		if whoamiRepo != "" && w.Repo != whoamiRepo {
+		if !repoFilter[w.Repo] {
 			continue
 		}
 
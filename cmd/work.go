@@ -3,11 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 
+	"github.com/mgreau/zen/internal/audit"
+	"github.com/mgreau/zen/internal/config"
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	ghpkg "github.com/mgreau/zen/internal/github"
 	"github.com/mgreau/zen/internal/session"
-	"github.com/mgreau/zen/internal/terminal"
 	"github.com/mgreau/zen/internal/ui"
 	wt "github.com/mgreau/zen/internal/worktree"
 	"github.com/spf13/cobra"
@@ -21,11 +23,15 @@ var workCmd = &cobra.Command{
 
 var workNewCmd = &cobra.Command{
 	Use:   "new <repo> <branch> [context]",
-	Short: "Create a new feature worktree and open in iTerm2",
-	Long: `Create a new feature worktree from origin/main and open it in a new iTerm2 tab.
+	Short: "Create a new feature worktree and open it in a terminal tab",
+	Long: `Create a new feature worktree from origin/main and open it in a new terminal tab.
 
 The branch will be prefixed with mgreau/ per naming convention.
-Optionally provide a context string to use as the initial Claude prompt.`,
+Optionally provide a context string to use as the initial Claude prompt;
+without one, the session starts with the repo's feature_prompt, if any.
+
+For repos with fork: in the config (contributor mode), the branch pushes
+to your fork, which is found or created through the GitHub API.`,
 	Args: cobra.RangeArgs(2, 3),
 	RunE: runWorkNew,
 }
@@ -43,25 +49,49 @@ Shows a summary of what will be removed before confirming.`,
 
 var workResumeCmd = &cobra.Command{
 	Use:   "resume <name>",
-	Short: "Resume a feature work session in a new iTerm2 tab",
+	Short: "Resume a feature work session in a new terminal tab",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runWorkResume,
 }
 
+var workSyncCmd = &cobra.Command{
+	Use:   "sync <name>",
+	Short: "Move in-progress changes from the main clone into a feature worktree",
+	Long: `Move work started in the main clone into a feature worktree.
+
+Uncommitted and untracked changes in the main clone are stashed and applied
+in the worktree. With --commits, local commits on the main clone's branch
+that were never pushed are cherry-picked onto the worktree branch first.
+
+Use --reverse to move work from the worktree back into the main clone.
+The destination must have no uncommitted changes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkSync,
+}
+
 var (
 	workNewNoITerm  bool
 	workNewModel    string
 	workDeleteForce bool
+	workSyncReverse bool
+	workSyncCommits bool
+	workSyncForce   bool
 )
 
 func init() {
 	workNewCmd.Flags().BoolVar(&workNewNoITerm, "no-terminal", false, "Create worktree only, don't open terminal tab")
 	workNewCmd.Flags().StringVarP(&workNewModel, "model", "m", "", "Claude model to use (e.g., sonnet, opus, haiku)")
+	addTerminalFlag(workNewCmd)
+	addPairFlag(workNewCmd)
 	workDeleteCmd.Flags().BoolVarP(&workDeleteForce, "force", "f", false, "Skip confirmation")
+	workSyncCmd.Flags().BoolVar(&workSyncReverse, "reverse", false, "Move work from the worktree into the main clone instead")
+	workSyncCmd.Flags().BoolVar(&workSyncCommits, "commits", false, "Also cherry-pick unpushed local commits")
+	workSyncCmd.Flags().BoolVarP(&workSyncForce, "force", "f", false, "Skip confirmation")
 	addResumeFlags(workResumeCmd)
 	workCmd.AddCommand(workNewCmd)
 	workCmd.AddCommand(workDeleteCmd)
 	workCmd.AddCommand(workResumeCmd)
+	workCmd.AddCommand(workSyncCmd)
 	rootCmd.AddCommand(workCmd)
 }
 
@@ -138,7 +168,7 @@ func runWorkNew(cmd *cobra.Command, args []string) error {
 	// Validate repo exists in config
 	basePath := cfg.RepoBasePath(repo)
 	if basePath == "" {
-		return fmt.Errorf("unknown repo %q — check ~/.zen/config.yaml", repo)
+		return fmt.Errorf("unknown repo %q — check %s", repo, config.Path())
 	}
 
 	// Construct paths
@@ -158,41 +188,73 @@ func runWorkNew(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("worktree already exists: %s\n  Resume with: zen work resume %s", worktreePath, branch)
 	}
 
-	// Create worktree under lock
-	wt.GitMu.Lock()
-
-	ui.LogInfo(fmt.Sprintf("Fetching origin/main in %s...", repo))
-	fetchCmd := exec.Command("git", "fetch", "origin", "main")
-	fetchCmd.Dir = originPath
-	if out, err := fetchCmd.CombinedOutput(); err != nil {
-		wt.GitMu.Unlock()
-		return fmt.Errorf("git fetch: %w: %s", err, string(out))
+	// Create worktree under the clone's lock
+	mu := wt.RepoLock(originPath)
+	mu.Lock()
+
+	// Triangular mode: branch from the canonical repo's main, push to origin
+	upstream := cfg.RepoUpstream(repo)
+	steps := ui.NewSteps()
+	steps.Step(fmt.Sprintf("git fetch %s/main in %s", upstream, repo))
+	ctx := cmd.Context()
+	timeout := cfg.RepoGitTimeout(repo)
+	if _, err := wt.Git(ctx, timeout, originPath, "fetch", upstream, "main"); err != nil {
+		steps.Done(err)
+		mu.Unlock()
+		return err
 	}
 
-	ui.LogInfo(fmt.Sprintf("Creating worktree %s (branch %s)...", worktreeName, gitBranch))
+	steps.Step(fmt.Sprintf("git worktree add %s (branch %s)", worktreeName, gitBranch))
 	// Use --no-checkout + separate checkout to avoid "Could not write new index file"
 	// on large repos (13K+ files). The two-step approach handles the index write reliably.
-	wtCmd := exec.Command("git", "worktree", "add", "--no-checkout", worktreePath, "-b", gitBranch, "origin/main")
-	wtCmd.Dir = originPath
-	if out, err := wtCmd.CombinedOutput(); err != nil {
+	if _, err := wt.Git(ctx, timeout, originPath, "worktree", "add", "--no-checkout", worktreePath, "-b", gitBranch, upstream+"/main"); err != nil {
+		steps.Done(err)
 		wt.CleanupFailedAdd(originPath, worktreePath, gitBranch)
-		wt.GitMu.Unlock()
-		return fmt.Errorf("git worktree add: %w: %s", err, string(out))
+		mu.Unlock()
+		return err
 	}
 
-	checkoutCmd := exec.Command("git", "checkout")
-	checkoutCmd.Dir = worktreePath
-	if out, err := checkoutCmd.CombinedOutput(); err != nil {
+	steps.Step("git checkout")
+	if _, err := wt.Git(ctx, timeout, worktreePath, "checkout"); err != nil {
+		steps.Done(err)
 		wt.CleanupFailedAdd(originPath, worktreePath, gitBranch)
-		wt.GitMu.Unlock()
-		return fmt.Errorf("git checkout in worktree: %w: %s", err, string(out))
+		mu.Unlock()
+		return err
 	}
+	steps.Done(nil)
+	audit.Record(audit.Event{Action: audit.WorktreeCreated, Repo: repo, Target: worktreePath, Detail: "branch " + gitBranch})
 
 	// Clean stale index.lock (only if holding process is dead)
 	lockFile := filepath.Join(originPath, ".git", "worktrees", worktreeName, "index.lock")
 	wt.RemoveStaleLock(lockFile, worktreeName)
 
-	wt.GitMu.Unlock()
+	// Keep CLAUDE.local.md and the like out of git status
+	if err := wt.Exclude(worktreePath, cfg.GitExcludes()); err != nil {
+		ui.LogWarn(fmt.Sprintf("Failed to update info/exclude: %v", err))
+	}
+	wt.InitExtras(ctx, cfg, repo, worktreePath, steps)
+	if upstream != "origin" {
+		if err := wt.SetPushRemote(worktreePath, gitBranch, "origin"); err != nil {
+			ui.LogWarn(fmt.Sprintf("Failed to make origin the push remote of %s: %v", gitBranch, err))
+		}
+	}
+
+	mu.Unlock()
+
+	// Contributor mode: push the branch to the fork. A failure here leaves
+	// a usable worktree; zen pr create sets the fork up again.
+	fork := ""
+	if cfg.RepoFork(repo) != "" {
+		steps.Step("set up fork remote")
+		client, err := ghpkg.NewClient(ctx)
+		if err == nil {
+			fork, err = setupFork(ctx, client, repo, worktreePath, gitBranch)
+		}
+		steps.Done(err)
+		if err != nil {
+			ui.LogWarn(fmt.Sprintf("Branch will push to origin until the fork is set up (zen pr create retries): %v", err))
+		}
+	}
 
 	home := homeDir()
 	shortPath := ui.ShortenHome(worktreePath, home)
@@ -200,47 +262,50 @@ func runWorkNew(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	ui.LogSuccess(fmt.Sprintf("Created worktree: %s", shortPath))
 	fmt.Printf("  Branch: %s\n", ui.CyanText(gitBranch))
+	if fork != "" {
+		fmt.Printf("  Push:   %s\n", ui.CyanText(fork))
+	}
 
-	if workNewModel != "" {
-		fmt.Printf("  Model:  %s\n", ui.CyanText(workNewModel))
+	claudeCmd, model := claudeCommand(repo, worktreePath, workNewModel)
+	if model != "" {
+		fmt.Printf("  Model:  %s\n", ui.CyanText(model))
+	}
+	prompt := context
+	if prompt == "" {
+		prompt = featurePrompt(repo, worktreePath, gitBranch)
+	}
+	if name := promptCommand(prompt); name != "" {
+		if err := ensureClaudeCommand(name); err != nil {
+			ui.LogWarn(fmt.Sprintf("Could not install /%s command: %v", name, err))
+		}
 	}
 
 	if workNewNoITerm {
 		fmt.Println()
 		fmt.Println(ui.BoldText("Open manually:"))
 		modelFlag := ""
-		if workNewModel != "" {
-			modelFlag = fmt.Sprintf(" --model %s", workNewModel)
+		if model != "" {
+			modelFlag = fmt.Sprintf(" --model %s", model)
 		}
-		if context != "" {
-			fmt.Printf("  cd %s && %s%s %q\n", worktreePath, cfg.ClaudeBin, modelFlag, context)
+		if prompt != "" {
+			fmt.Printf("  cd %s && %s%s %q\n", worktreePath, claudeCmd, modelFlag, prompt)
 		} else {
-			fmt.Printf("  cd %s && %s%s\n", worktreePath, cfg.ClaudeBin, modelFlag)
+			fmt.Printf("  cd %s && %s%s\n", worktreePath, claudeCmd, modelFlag)
 		}
 		return nil
 	}
 
 	// Open terminal tab
-	term, err := terminal.NewTerminal(cfg.GetTerminal())
+	term, err := newTerminal()
 	if err != nil {
 		return err
 	}
 
-	if context != "" {
-		if err := term.OpenTabWithClaude(worktreePath, context, cfg.ClaudeBin, workNewModel); err != nil {
-			return fmt.Errorf("opening %s tab: %w", term.Name(), err)
-		}
-	} else {
-		cmd := cfg.ClaudeBin
-		if workNewModel != "" {
-			cmd += fmt.Sprintf(" --model %s", workNewModel)
-		}
-		if err := term.OpenTab(worktreePath, cmd); err != nil {
-			return fmt.Errorf("opening %s tab: %w", term.Name(), err)
-		}
+	if err := openClaudeTab(term, worktreePath, prompt, claudeCmd, model); err != nil {
+		return fmt.Errorf("opening %s tab: %w", term.Name(), err)
 	}
 
-	ui.LogSuccess(fmt.Sprintf("%s tab opened", term.Name()))
+	logTabOpened(term)
 	fmt.Println()
 	return nil
 }
@@ -327,12 +392,13 @@ func runWorkDelete(cmd *cobra.Command, args []string) error {
 	basePath := cfg.RepoBasePath(match.Repo)
 	originPath := filepath.Join(basePath, match.Repo)
 
-	removeCmd := exec.Command("git", "worktree", "remove", match.Path, "--force")
-	removeCmd.Dir = originPath
-	if out, err := removeCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("git worktree remove: %w: %s", err, string(out))
+	if out, err := wt.Remove(originPath, match.Path, ""); err != nil {
+		return fmt.Errorf("git worktree remove: %w: %s", err, out)
 	}
+	ctxpkg.ForgetContext(match.Path)
 	ui.LogSuccess("Removed worktree")
+	deleted, err := removeBranch(originPath, *match)
+	reportBranchRemoval(*match, deleted, err)
 
 	// Clean up Claude session files
 	if len(sessions) > 0 {
@@ -349,3 +415,93 @@ func runWorkDelete(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	return nil
 }
+
+func runWorkSync(cmd *cobra.Command, args []string) error {
+	match, err := findWorktreeByName(args[0])
+	if err != nil {
+		return err
+	}
+
+	originPath := filepath.Join(cfg.RepoBasePath(match.Repo), match.Repo)
+	src, dst := originPath, match.Path
+	srcLabel, dstLabel := "main clone", match.Name
+	if workSyncReverse {
+		src, dst = dst, src
+		srcLabel, dstLabel = dstLabel, srcLabel
+	}
+
+	changes, err := wt.UncommittedChanges(src)
+	if err != nil {
+		return err
+	}
+	var commits []string
+	if workSyncCommits {
+		commits, err = wt.LocalCommits(src, dst, cfg.RepoUpstream(match.Repo))
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(changes) == 0 && len(commits) == 0 {
+		fmt.Printf("Nothing to sync: %s has no uncommitted changes", srcLabel)
+		if workSyncCommits {
+			fmt.Print(" or unpushed commits")
+		}
+		fmt.Println(".")
+		return nil
+	}
+
+	home := homeDir()
+	fmt.Println()
+	fmt.Printf("  From:     %s (%s)\n", ui.CyanText(srcLabel), ui.ShortenHome(src, home))
+	fmt.Printf("  To:       %s (%s)\n", ui.CyanText(dstLabel), ui.ShortenHome(dst, home))
+	if len(commits) > 0 {
+		fmt.Printf("  Commits:  %d to cherry-pick\n", len(commits))
+	}
+	if len(changes) > 0 {
+		fmt.Printf("  Changes:  %d path(s)\n", len(changes))
+		for i, c := range changes {
+			if i >= 10 {
+				fmt.Printf("            %s\n", ui.DimText(fmt.Sprintf("... and %d more", len(changes)-10)))
+				break
+			}
+			fmt.Printf("            %s\n", ui.DimText(c))
+		}
+	}
+	fmt.Println()
+
+	if !workSyncForce {
+		fmt.Print("  Sync? [y/N]: ")
+		var resp string
+		fmt.Scanln(&resp)
+		if resp != "y" && resp != "Y" {
+			fmt.Println("  Cancelled.")
+			return nil
+		}
+		fmt.Println()
+	}
+
+	mu := wt.RepoLock(originPath)
+	mu.Lock()
+	res, err := wt.MoveWork(src, dst, commits, match.Name)
+	mu.Unlock()
+	if err != nil {
+		if res != nil && res.StashKept != "" {
+			ui.Hint(fmt.Sprintf("Resolve the conflicts in %s, then drop the stash with 'git stash drop'.", ui.ShortenHome(dst, home)))
+		}
+		return err
+	}
+
+	if len(res.Commits) > 0 {
+		ui.LogSuccess(fmt.Sprintf("Cherry-picked %d commit(s) onto %s", len(res.Commits), dstLabel))
+	}
+	if res.Changes > 0 {
+		ui.LogSuccess(fmt.Sprintf("Moved %d uncommitted change(s) to %s", res.Changes, dstLabel))
+	}
+	if len(res.Commits) > 0 {
+		ui.Hint(fmt.Sprintf("The commits are still on the %s branch. Once you're happy, drop them with:", srcLabel))
+		ui.Hint(fmt.Sprintf("  git -C %s reset --keep %s^", ui.ShortenHome(src, home), res.Commits[0]))
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
+	"github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var workCheckpointCmd = &cobra.Command{
+	Use:   "checkpoint <name>",
+	Short: "Snapshot a feature worktree's current changes as a rollback point",
+	Long: `Commits every tracked and untracked file of a feature worktree, by default
+the one containing the current directory, as a checkpoint on top of HEAD.
+The branch, index and files are left untouched, so Claude can keep working
+while the checkpoint stays available for zen work checkpoints --restore.
+
+Checkpoints are stored as refs/worktree/zen/checkpoints/<name>, private to
+the worktree and never pushed.
+
+Example:
+  zen work checkpoint tests-green -m "all tests pass before the refactor"
+  zen work checkpoint before-rename -w mono-retries`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkCheckpoint,
+}
+
+var workCheckpointsCmd = &cobra.Command{
+	Use:   "checkpoints [worktree]",
+	Short: "List, restore or delete a feature worktree's checkpoints",
+	Long: `Lists the checkpoints of a feature worktree, by default the one containing
+the current directory.
+
+With --restore, the branch is reset to the commit the checkpoint was taken
+from and the files are rewritten to their checkpointed content, as unstaged
+changes. The current state, including commits made since, is first saved as
+a "before-restore-..." checkpoint so the restore can be undone. Untracked
+files created after the checkpoint are left in place.
+
+Example:
+  zen work checkpoints
+  zen work checkpoints mono-retries --restore tests-green
+  zen work checkpoints --delete before-rename`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWorkCheckpoints,
+}
+
+var (
+	workCheckpointMessage  string
+	workCheckpointWorktree string
+	workCheckpointsRestore string
+	workCheckpointsDelete  string
+	workCheckpointsForce   bool
+)
+
+func init() {
+	workCheckpointCmd.Flags().StringVarP(&workCheckpointMessage, "message", "m", "", "Describe the checkpoint")
+	workCheckpointCmd.Flags().StringVarP(&workCheckpointWorktree, "worktree", "w", "", "Feature worktree to checkpoint (default: current directory)")
+	workCheckpointsCmd.Flags().StringVar(&workCheckpointsRestore, "restore", "", "Restore the worktree to this checkpoint")
+	workCheckpointsCmd.Flags().StringVar(&workCheckpointsDelete, "delete", "", "Delete this checkpoint")
+	workCheckpointsCmd.Flags().BoolVarP(&workCheckpointsForce, "force", "f", false, "Skip confirmation")
+	workCheckpointsCmd.MarkFlagsMutuallyExclusive("restore", "delete")
+	workCmd.AddCommand(workCheckpointCmd)
+	workCmd.AddCommand(workCheckpointsCmd)
+}
+
+// checkpointWorktree resolves the feature worktree named by target, or the
+// one containing the current directory when target is empty.
+func checkpointWorktree(target string) (*worktree.Worktree, error) {
+	var (
+		w   *worktree.Worktree
+		err error
+	)
+	if target != "" {
+		w, err = resolveWorktree(target)
+	} else {
+		w, err = currentWorktree()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if w.Type != worktree.TypeFeature {
+		return nil, fmt.Errorf("%s is not a feature worktree", w.Name)
+	}
+	return w, nil
+}
+
+func runWorkCheckpoint(cmd *cobra.Command, args []string) error {
+	w, err := checkpointWorktree(workCheckpointWorktree)
+	if err != nil {
+		return err
+	}
+	cp, err := worktree.CreateCheckpoint(w.Path, args[0], workCheckpointMessage)
+	if err != nil {
+		return err
+	}
+
+	if jsonFlag {
+		printJSON(cp)
+		return nil
+	}
+	ui.LogSuccess(fmt.Sprintf("Checkpoint %s saved for %s (%d changed file(s) on top of %s)",
+		ui.CyanText(cp.Name), w.Name, cp.Files, shortSHA(cp.Base)))
+	ui.Hint(fmt.Sprintf("Restore with: zen work checkpoints %s --restore %s", w.Name, cp.Name))
+	return nil
+}
+
+func runWorkCheckpoints(cmd *cobra.Command, args []string) error {
+	target := ""
+	if len(args) == 1 {
+		target = args[0]
+	}
+	w, err := checkpointWorktree(target)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case workCheckpointsDelete != "":
+		if err := worktree.DeleteCheckpoint(w.Path, workCheckpointsDelete); err != nil {
+			return err
+		}
+		ui.LogSuccess(fmt.Sprintf("Deleted checkpoint %s of %s", workCheckpointsDelete, w.Name))
+		return nil
+	case workCheckpointsRestore != "":
+		return restoreCheckpoint(w, workCheckpointsRestore)
+	}
+
+	cps, err := worktree.ListCheckpoints(w.Path)
+	if err != nil {
+		return err
+	}
+	if jsonFlag {
+		printJSON(cps)
+		return nil
+	}
+
+	fmt.Println()
+	ui.SectionHeader(fmt.Sprintf("Checkpoints — %s", w.Name))
+	if len(cps) == 0 {
+		fmt.Println("  No checkpoints yet.")
+		ui.Hint("Create one with: zen work checkpoint <name>")
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("  %-30s %-10s %-8s %-6s %s\n", "Name", "Age", "Base", "Files", "Message")
+	fmt.Printf("  %-30s %-10s %-8s %-6s %s\n", "──────────────────────────────", "──────────", "────────", "──────", "───────")
+	for _, cp := range cps {
+		fmt.Printf("  %-30s %-10s %-8s %-6d %s\n",
+			ui.Truncate(cp.Name, 30), session.FormatAge(cp.Time), shortSHA(cp.Base), cp.Files, ui.Truncate(cp.Message, 50))
+	}
+	fmt.Println()
+	ui.Hint(fmt.Sprintf("Restore with: zen work checkpoints %s --restore <name>", w.Name))
+	fmt.Println()
+	return nil
+}
+
+func restoreCheckpoint(w *worktree.Worktree, name string) error {
+	if !workCheckpointsForce {
+		fmt.Printf("  Reset %s to checkpoint %s? The current state is saved first. [y/N]: ", w.Name, name)
+		var resp string
+		fmt.Scanln(&resp)
+		if resp != "y" && resp != "Y" {
+			fmt.Println("  Cancelled.")
+			return nil
+		}
+	}
+
+	backup, err := worktree.RestoreCheckpoint(w.Path, name)
+	if backup != "" {
+		ui.LogInfo(fmt.Sprintf("Saved the previous state as checkpoint %s", ui.CyanText(backup)))
+	}
+	if err != nil {
+		return fmt.Errorf("restoring checkpoint %s: %w", name, err)
+	}
+	ui.LogSuccess(fmt.Sprintf("Restored %s to checkpoint %s", w.Name, name))
+	ui.Hint(fmt.Sprintf("Undo with: zen work checkpoints %s --restore %s", w.Name, backup))
+	return nil
+}
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/prcache"
+	"github.com/mgreau/zen/internal/reconciler"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var worktreeCmd = &cobra.Command{
+	Use:   "worktree",
+	Short: "Adopt worktrees zen didn't create and manage the worktree pool",
+}
+
+var worktreeAdoptCmd = &cobra.Command{
+	Use:   "adopt <path>",
+	Short: "Register an existing worktree with zen",
+	Long: `Registers a worktree created outside zen (e.g. with plain git worktree add)
+so it is classified correctly in status, reviews and cleanup.
+
+The path must be a worktree of a configured repo's origin clone. With --pr
+(or a pr-<n> branch) it becomes a PR review worktree: PR info is cached and
+PR context injected into CLAUDE.local.md. Otherwise it is adopted as feature
+work. --rename moves it to zen's standard location and name
+(<base_path>/<repo>-pr-<n> or <base_path>/<repo>-<branch>).
+
+Example:
+  zen worktree adopt ~/src/mono-review --pr 1234 --rename
+  zen worktree adopt ../my-experiment`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorktreeAdopt,
+}
+
+var worktreePoolCmd = &cobra.Command{
+	Use:   "pool [repo]",
+	Short: "Show or fill the pools of blank worktrees used for fast PR setup",
+	Long: `For repos with pool_size set, zen keeps that many blank worktrees checked
+out at origin/main under <base_path>/.zen-pool. PR setup (zen review and the
+watch daemon) claims one and checks out the PR branch in it, which only
+rewrites the files the PR touches instead of checking out the whole tree.
+
+The daemon refills the pools after each claim and on every poll, and moves
+pooled worktrees older than pool_refresh (default 6h) to the latest
+origin/main. --fill does the same now, in the foreground.
+
+Example:
+  zen worktree pool
+  zen worktree pool mono --fill`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWorktreePool,
+}
+
+var worktreePoolFill bool
+
+var (
+	worktreeAdoptPR     int
+	worktreeAdoptRepo   string
+	worktreeAdoptRename bool
+)
+
+func init() {
+	worktreeAdoptCmd.Flags().IntVar(&worktreeAdoptPR, "pr", 0, "PR number under review in this worktree")
+	worktreeAdoptCmd.Flags().StringVar(&worktreeAdoptRepo, "repo", "", "Repository short name (auto-detected from the worktree's origin clone)")
+	worktreeAdoptCmd.Flags().BoolVar(&worktreeAdoptRename, "rename", false, "Move the worktree to zen's standard path and name")
+
+	worktreePoolCmd.Flags().BoolVar(&worktreePoolFill, "fill", false, "Create, refresh or remove pooled worktrees to match pool_size now")
+
+	worktreeCmd.AddCommand(worktreeAdoptCmd)
+	worktreeCmd.AddCommand(worktreePoolCmd)
+	rootCmd.AddCommand(worktreeCmd)
+}
+
+// prBranchPattern matches the local branch names zen uses for PR reviews.
+var prBranchPattern = regexp.MustCompile(`^pr-(\d+)$`)
+
+func runWorktreeAdopt(cmd *cobra.Command, args []string) error {
+	if worktreeAdoptRepo != "" && cfg.RepoBasePath(worktreeAdoptRepo) == "" {
+		return fmt.Errorf("unknown repo %q -- check %s", worktreeAdoptRepo, config.Path())
+	}
+
+	a, err := wt.FindAdoption(cfg, args[0], worktreeAdoptRepo)
+	if err != nil {
+		return err
+	}
+
+	pr := worktreeAdoptPR
+	if pr == 0 {
+		if m := prBranchPattern.FindStringSubmatch(a.Branch); m != nil {
+			pr, _ = strconv.Atoi(m[1])
+		}
+	}
+
+	if worktreeAdoptRename {
+		if pr == 0 && a.Branch == "" {
+			return fmt.Errorf("cannot derive a name for a detached HEAD -- pass --pr or check out a branch first")
+		}
+		dest := filepath.Join(cfg.RepoBasePath(a.Repo), a.CanonicalName(cfg, pr))
+		if dest != a.Path {
+			from := a.Path
+			if err := a.Move(cfg, dest); err != nil {
+				return fmt.Errorf("moving worktree: %w", err)
+			}
+			ui.LogInfo(fmt.Sprintf("Moved %s -> %s", ui.ShortenHome(from, homeDir()), ui.ShortenHome(dest, homeDir())))
+		}
+	}
+
+	meta := wt.Meta{Type: wt.TypeFeature}
+	if pr > 0 {
+		meta = wt.Meta{Type: wt.TypePRReview, PRNumber: pr}
+	}
+	if err := wt.SetMeta(a.Path, meta); err != nil {
+		return fmt.Errorf("saving worktree metadata: %w", err)
+	}
+
+	result := wt.Worktree{
+		Path:     a.Path,
+		Name:     filepath.Base(a.Path),
+		Branch:   a.Branch,
+		Type:     meta.Type,
+		PRNumber: meta.PRNumber,
+		Repo:     a.Repo,
+	}
+
+	title := ""
+	if pr > 0 {
+		title = adoptPRInfo(a.Repo, pr, a.Path)
+	}
+
+	if jsonFlag {
+		printJSON(result)
+		return nil
+	}
+
+	fmt.Println()
+	ui.LogSuccess(fmt.Sprintf("Adopted worktree: %s", ui.ShortenHome(a.Path, homeDir())))
+	fmt.Printf("  Repo:   %s\n", a.Repo)
+	if a.Branch != "" {
+		fmt.Printf("  Branch: %s\n", ui.CyanText(a.Branch))
+	}
+	if pr > 0 {
+		fmt.Printf("  PR:     #%d", pr)
+		if title != "" {
+			fmt.Printf(" — %s", title)
+		}
+		fmt.Println()
+		ui.Hint(fmt.Sprintf("Resume with: zen review resume %d", pr))
+	} else {
+		fmt.Printf("  Type:   %s\n", meta.Type)
+		ui.Hint(fmt.Sprintf("Resume with: zen work resume %s", result.Name))
+	}
+	fmt.Println()
+	return nil
+}
+
+// adoptPRInfo caches the PR's title and author and injects PR context into
+// the worktree if it has none yet. Failures only warn: the worktree is
+// adopted either way. Returns the PR title, if fetched.
+func adoptPRInfo(repo string, pr int, path string) string {
+	ctx := context.Background()
+	fullRepo := cfg.RepoFullName(repo)
+
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		ui.LogWarn(fmt.Sprintf("Could not fetch PR #%d details: %v", pr, err))
+		return ""
+	}
+	details, err := client.GetPRDetails(ctx, fullRepo, pr)
+	if err != nil {
+		ui.LogWarn(fmt.Sprintf("Could not fetch PR #%d details: %v", pr, err))
+		return ""
+	}
+	prcache.Set(repo, pr, details.Title, details.Author)
+	prcache.SetLabels(repo, pr, details.Labels)
+
+	if _, err := os.Stat(filepath.Join(path, "CLAUDE.local.md")); os.IsNotExist(err) {
+		if err := ctxpkg.InjectPRContext(ctx, path, fullRepo, pr); err != nil {
+			ui.LogWarn(fmt.Sprintf("Could not inject PR context: %v", err))
+		}
+	}
+	return details.Title
+}
+
+// worktreePoolStatus is the JSON output of zen worktree pool.
+type worktreePoolStatus struct {
+	Repo    string    `json:"repo"`
+	Size    int       `json:"size"`
+	Ready   int       `json:"ready"`
+	Refresh string    `json:"refresh"`
+	Oldest  time.Time `json:"oldest,omitzero"`
+}
+
+func runWorktreePool(cmd *cobra.Command, args []string) error {
+	repos := cfg.RepoNames()
+	if len(args) == 1 {
+		if cfg.RepoBasePath(args[0]) == "" {
+			return fmt.Errorf("unknown repo %q -- check %s", args[0], config.Path())
+		}
+		repos = args
+	}
+
+	var statuses []worktreePoolStatus
+	for _, repo := range repos {
+		if cfg.RepoPoolSize(repo) == 0 {
+			if len(args) == 1 {
+				return fmt.Errorf("repo %q has no pool_size set in %s", repo, config.Path())
+			}
+			continue
+		}
+		if worktreePoolFill {
+			steps := ui.NewSteps()
+			if jsonFlag {
+				steps = ui.NewStepLogger(func(string) {})
+			}
+			res, err := reconciler.FillPool(context.Background(), cfg, repo, steps)
+			if err != nil {
+				return fmt.Errorf("filling %s pool: %w", repo, err)
+			}
+			if !jsonFlag {
+				ui.LogSuccess(fmt.Sprintf("%s pool: %d/%d ready (added %d, refreshed %d, removed %d)",
+					repo, res.Ready, res.Size, res.Added, res.Refreshed, res.Removed))
+			}
+		}
+
+		st := worktreePoolStatus{Repo: repo, Size: cfg.RepoPoolSize(repo), Refresh: cfg.RepoPoolRefresh(repo).String()}
+		pooled, err := wt.Pooled(cfg.RepoBasePath(repo), repo)
+		if err != nil {
+			return fmt.Errorf("listing %s pool: %w", repo, err)
+		}
+		st.Ready = len(pooled)
+		for _, path := range pooled {
+			if at, err := wt.PooledAt(path); err == nil && (st.Oldest.IsZero() || at.Before(st.Oldest)) {
+				st.Oldest = at
+			}
+		}
+		statuses = append(statuses, st)
+	}
+
+	if jsonFlag {
+		if statuses == nil {
+			statuses = []worktreePoolStatus{}
+		}
+		printJSON(statuses)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(ui.BoldText("Worktree Pools"))
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+	if len(statuses) == 0 {
+		fmt.Println("  No repo has a pool_size set.")
+		ui.Hint(fmt.Sprintf("Add pool_size: 2 under a repo in %s", config.Path()))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("  %-16s  %-7s  %-8s  %s\n", "Repo", "Ready", "Refresh", "Oldest Checkout")
+	fmt.Printf("  %-16s  %-7s  %-8s  %s\n", "────────────────", "───────", "────────", "───────────────")
+	for _, st := range statuses {
+		ready := fmt.Sprintf("%d/%d", st.Ready, st.Size)
+		readyCol := ui.GreenText(fmt.Sprintf("%-7s", ready))
+		if st.Ready < st.Size {
+			readyCol = ui.YellowText(fmt.Sprintf("%-7s", ready))
+		}
+		oldest := "-"
+		if !st.Oldest.IsZero() {
+			oldest = session.FormatAge(st.Oldest)
+		}
+		fmt.Printf("  %-16s  %s  %-8s  %s\n", st.Repo, readyCol, st.Refresh, ui.DimText(oldest))
+	}
+	fmt.Println()
+	if !worktreePoolFill {
+		ui.Hint("Fill now with: zen worktree pool --fill")
+		fmt.Println()
+	}
+	return nil
+}
+
+// removeBranch deletes the branch of a worktree that was just removed,
+// unless keep_branches is set. A pr-N review branch is always deleted, a
+// feature branch only when it is merged into the default branch. Returns
+// the deleted branch, or "" when it was kept.
+func removeBranch(originPath string, w wt.Worktree) (string, error) {
+	if cfg.KeepBranches || w.Branch == "" {
+		return "", nil
+	}
+	if err := wt.DeleteBranch(originPath, w.Branch, w.Type == wt.TypePRReview); err != nil {
+		return "", err
+	}
+	return w.Branch, nil
+}
+
+// reportBranchRemoval logs the outcome of removeBranch.
+func reportBranchRemoval(w wt.Worktree, deleted string, err error) {
+	switch {
+	case errors.Is(err, wt.ErrBranchUnmerged):
+		ui.LogWarn(fmt.Sprintf("Kept branch %s: it has commits not on the default branch (delete with: git branch -D %s)", w.Branch, w.Branch))
+	case err != nil:
+		ui.LogWarn(fmt.Sprintf("Could not delete branch %s: %v", w.Branch, err))
+	case deleted != "":
+		ui.LogSuccess(fmt.Sprintf("Deleted branch %s", deleted))
+	}
+}
@@ -0,0 +1,138 @@
+// Package audit keeps an append-only log of the actions that create or
+// destroy work: worktree creation and removal and branch deletion, whether
+// run from the CLI or by the watch daemon's background cleanup.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+)
+
+// Actions.
+const (
+	WorktreeCreated = "worktree_created"
+	WorktreeRemoved = "worktree_removed"
+	BranchDeleted   = "branch_deleted"
+)
+
+// Sources, the kind of process that ran an action.
+const (
+	SourceCLI    = "cli"
+	SourceDaemon = "daemon"
+	SourceMCP    = "mcp"
+	SourceAPI    = "api"
+)
+
+// rotateSize is the size above which the log is rotated to audit.jsonl.1.
+// Entries are never rewritten.
+const rotateSize = 5 << 20
+
+// Event is one entry in the audit log.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"`
+	Source  string    `json:"source"`            // cli, daemon, mcp or api
+	Command string    `json:"command,omitempty"` // zen command that ran it
+	Repo    string    `json:"repo,omitempty"`    // short name
+	Target  string    `json:"target"`            // worktree path or branch
+	Detail  string    `json:"detail,omitempty"`  // e.g. why cleanup removed a worktree
+}
+
+var (
+	mu      sync.Mutex
+	source  = SourceCLI
+	command string
+)
+
+// SetSource sets the source and command recorded with the events of this
+// process.
+func SetSource(src, cmd string) {
+	mu.Lock()
+	defer mu.Unlock()
+	source, command = src, cmd
+}
+
+// Path returns the audit log file path.
+func Path() string {
+	return filepath.Join(config.StateDir(), "audit.jsonl")
+}
+
+// Record appends an event to the audit log (best-effort), filling in its
+// time, source and command when unset.
+func Record(ev Event) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	if ev.Source == "" {
+		ev.Source = source
+	}
+	if ev.Command == "" {
+		ev.Command = command
+	}
+	line, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(config.StateDir(), 0o755); err != nil {
+		return err
+	}
+	if info, err := os.Stat(Path()); err == nil && info.Size() > rotateSize {
+		os.Rename(Path(), Path()+".1")
+	}
+	f, err := os.OpenFile(Path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// Read returns the events recorded at or after since, oldest first,
+// including those of the rotated log. A missing log yields no events.
+func Read(since time.Time) ([]Event, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	var events []Event
+	for _, path := range []string{Path() + ".1", Path()} {
+		evs, err := read(path, since)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, evs...)
+	}
+	return events, nil
+}
+
+func read(path string, since time.Time) ([]Event, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var ev Event
+		if json.Unmarshal(scanner.Bytes(), &ev) != nil {
+			continue
+		}
+		if !ev.Time.Before(since) {
+			events = append(events, ev)
+		}
+	}
+	return events, scanner.Err()
+}
@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRecordAndRead(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { SetSource(SourceCLI, "") })
+
+	if events, err := Read(time.Time{}); err != nil || len(events) != 0 {
+		t.Fatalf("Read() on a missing log = %v, %v", events, err)
+	}
+
+	now := time.Now()
+	SetSource(SourceCLI, "review delete")
+	Record(Event{Time: now.Add(-48 * time.Hour), Action: WorktreeRemoved, Repo: "mono", Target: "/src/mono-pr-1"})
+	SetSource(SourceDaemon, "")
+	Record(Event{Action: BranchDeleted, Repo: "mono", Target: "pr-1", Detail: "PR merged"})
+
+	all, err := Read(time.Time{})
+	if err != nil || len(all) != 2 {
+		t.Fatalf("Read() = %+v, %v; want 2 events", all, err)
+	}
+	if all[0].Source != SourceCLI || all[0].Command != "review delete" {
+		t.Errorf("first event = %+v, want the CLI command", all[0])
+	}
+	if all[1].Source != SourceDaemon || all[1].Time.IsZero() {
+		t.Errorf("second event = %+v, want a daemon event with a time", all[1])
+	}
+	if recent, _ := Read(now.Add(-time.Hour)); len(recent) != 1 || recent[0].Action != BranchDeleted {
+		t.Errorf("Read(since 1h) = %+v; want only the branch deletion", recent)
+	}
+}
+
+func TestRotatedLogIsRead(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	Record(Event{Action: WorktreeCreated, Target: "/src/mono-pr-1"})
+	if err := os.Rename(Path(), Path()+".1"); err != nil {
+		t.Fatal(err)
+	}
+	Record(Event{Action: WorktreeRemoved, Target: "/src/mono-pr-1"})
+
+	all, err := Read(time.Time{})
+	if err != nil || len(all) != 2 || all[0].Action != WorktreeCreated {
+		t.Errorf("Read() = %+v, %v; want the rotated event first", all, err)
+	}
+}
@@ -0,0 +1,197 @@
+// Package backup saves zen's config and state to a tar.gz archive and
+// restores it, to move zen to a new machine.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// configName is the archive entry of config.yaml; state files are under
+// stateDir.
+const (
+	configName = "config.yaml"
+	stateDir   = "state"
+)
+
+// machineLocal lists state files that only make sense on the machine, and
+// for the process, that wrote them. They are not exported.
+var machineLocal = map[string]bool{
+	"watch.pid":            true,
+	"watch.supervisor.pid": true,
+	"watch.log":            true,
+	"heartbeat":            true,
+	"local_api.token":      true,
+	"worktree_list.json":   true,
+}
+
+// Export writes config.yaml and the state directory to w as a gzipped
+// tar and returns the names of the archived files: config.yaml and
+// state/<file>.
+func Export(w io.Writer) ([]string, error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	var names []string
+
+	add := func(name, src string) error {
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(src)
+		if err != nil {
+			return err
+		}
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    int64(info.Mode().Perm()),
+			Size:    int64(len(data)),
+			ModTime: info.ModTime(),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+		names = append(names, name)
+		return nil
+	}
+
+	if err := add(configName, config.Path()); err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	dir := config.StateDir()
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == dir && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.Type().IsRegular() || machineLocal[d.Name()] || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		return add(path.Join(stateDir, filepath.ToSlash(rel)), p)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading state: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// file is an archived file to restore.
+type file struct {
+	dest    string
+	data    []byte
+	mode    os.FileMode
+	modTime time.Time
+}
+
+// Import restores an archive written by Export: config.yaml to
+// config.Path() and state files to config.StateDir(). Unless force is
+// set, nothing is written when any of these files already exists. State
+// files not in the archive are kept. Returns the restored file names.
+func Import(r io.Reader, force bool) ([]string, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a zen export: %w", err)
+	}
+	tr := tar.NewReader(gz)
+
+	files := make(map[string]file)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		dest, err := destination(hdr.Name)
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", hdr.Name, err)
+		}
+		mode := os.FileMode(hdr.Mode).Perm()
+		if mode == 0 {
+			mode = 0o644
+		}
+		files[hdr.Name] = file{dest: dest, data: data, mode: mode, modTime: hdr.ModTime}
+	}
+	if _, ok := files[configName]; !ok {
+		return nil, fmt.Errorf("not a zen export: no %s in the archive", configName)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if !force {
+		var existing []string
+		for _, name := range names {
+			if _, err := os.Stat(files[name].dest); err == nil {
+				existing = append(existing, files[name].dest)
+			}
+		}
+		if len(existing) > 0 {
+			return nil, fmt.Errorf("%d file(s) would be overwritten, starting with %s -- use --force to replace them", len(existing), existing[0])
+		}
+	}
+
+	for _, name := range names {
+		f := files[name]
+		if err := state.WriteFile(f.dest, f.data, f.mode); err != nil {
+			return nil, err
+		}
+		if !f.modTime.IsZero() {
+			os.Chtimes(f.dest, f.modTime, f.modTime)
+		}
+	}
+	return names, nil
+}
+
+// destination maps an archive entry to where it is restored, rejecting
+// names that would escape the state directory.
+func destination(name string) (string, error) {
+	if name == configName {
+		return config.Path(), nil
+	}
+	rel, ok := strings.CutPrefix(name, stateDir+"/")
+	if !ok || rel == "" || path.Clean(rel) != rel || rel == ".." || strings.HasPrefix(rel, "../") || path.IsAbs(rel) {
+		return "", fmt.Errorf("not a zen export: unexpected entry %q", name)
+	}
+	return filepath.Join(config.StateDir(), filepath.FromSlash(rel)), nil
+}
@@ -0,0 +1,88 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mgreau/zen/internal/config"
+)
+
+func TestExportImport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(config.Path(), "authors: [alice]\n")
+	write(filepath.Join(config.StateDir(), "pr_cache.json"), "{}")
+	write(filepath.Join(config.StateDir(), "session_gc", "abc"), "x")
+	write(filepath.Join(config.StateDir(), "watch.pid"), "123")
+
+	var buf bytes.Buffer
+	names, err := Export(&buf)
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	want := []string{"config.yaml", "state/pr_cache.json", "state/session_gc/abc"}
+	slices.Sort(names)
+	if !slices.Equal(names, want) {
+		t.Errorf("Export() = %v; want %v", names, want)
+	}
+	archive := buf.Bytes()
+
+	// Importing over an existing setup needs force
+	if _, err := Import(bytes.NewReader(archive), false); err == nil {
+		t.Error("Import() over existing files succeeded without force")
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	names, err = Import(bytes.NewReader(archive), false)
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if !slices.Equal(names, want) {
+		t.Errorf("Import() = %v; want %v", names, want)
+	}
+	if data, _ := os.ReadFile(config.Path()); string(data) != "authors: [alice]\n" {
+		t.Errorf("imported config = %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(config.StateDir(), "session_gc", "abc")); err != nil {
+		t.Errorf("nested state file not imported: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(config.StateDir(), "watch.pid")); err == nil {
+		t.Error("watch.pid was exported")
+	}
+	if _, err := Import(bytes.NewReader(archive), true); err != nil {
+		t.Errorf("Import() with force error: %v", err)
+	}
+}
+
+func TestImportRejectsEscapes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, name := range []string{"state/../../evil", "/etc/passwd", "other/file"} {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		for _, n := range []string{"config.yaml", name} {
+			tw.WriteHeader(&tar.Header{Name: n, Mode: 0o644, Size: 1, Typeflag: tar.TypeReg})
+			tw.Write([]byte("x"))
+		}
+		tw.Close()
+		gz.Close()
+		_, err := Import(&buf, true)
+		if err == nil || !strings.Contains(err.Error(), "unexpected entry") {
+			t.Errorf("Import() of %q = %v; want an unexpected entry error", name, err)
+		}
+	}
+}
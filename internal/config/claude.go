@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ClaudeLaunch holds the options zen starts and resumes interactive Claude
+// sessions with. Set globally under `claude:` and per repo under
+// `repos.<name>.claude:`.
+type ClaudeLaunch struct {
+	Model          string            `yaml:"model"`           // default --model; a --model flag wins
+	PermissionMode string            `yaml:"permission_mode"` // --permission-mode, e.g. "plan" or "acceptEdits"
+	Args           []string          `yaml:"args"`            // extra flags, e.g. ["--add-dir", "../shared"]
+	Env            map[string]string `yaml:"env"`             // environment variables for the session
+}
+
+// WorktreePlaceholder in launch args and env values is replaced with the
+// worktree path, e.g. `CLAUDE_PROJECT_DIR: "{worktree}"`.
+const WorktreePlaceholder = "{worktree}"
+
+var envNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func (l ClaudeLaunch) validate(where string) error {
+	for k := range l.Env {
+		if !envNameRe.MatchString(k) {
+			return fmt.Errorf("%s: invalid environment variable name %q", where, k)
+		}
+	}
+	return nil
+}
+
+// RepoClaude returns the launch options for sessions in the repo: the
+// repo's model and permission mode override the global ones, its args
+// are added after the global args, and its env is merged over the global
+// env.
+func (c *Config) RepoClaude(short string) ClaudeLaunch {
+	l := c.Claude
+	l.Args = append([]string(nil), c.Claude.Args...)
+	l.Env = maps.Clone(c.Claude.Env)
+	repo, ok := c.Repos[short]
+	if !ok {
+		return l
+	}
+	if repo.Claude.Model != "" {
+		l.Model = repo.Claude.Model
+	}
+	if repo.Claude.PermissionMode != "" {
+		l.PermissionMode = repo.Claude.PermissionMode
+	}
+	l.Args = append(l.Args, repo.Claude.Args...)
+	if len(repo.Claude.Env) > 0 {
+		if l.Env == nil {
+			l.Env = make(map[string]string)
+		}
+		maps.Copy(l.Env, repo.Claude.Env)
+	}
+	return l
+}
+
+// Command returns the shell command that starts claudeBin with the launch
+// options, for a session in workDir. The model is left out so callers
+// can apply a --model flag. Arguments are shell-quoted.
+func (l ClaudeLaunch) Command(claudeBin, workDir string) string {
+	var parts []string
+	if len(l.Env) > 0 {
+		keys := make([]string, 0, len(l.Env))
+		for k := range l.Env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts = append(parts, "env")
+		for _, k := range keys {
+			v := strings.ReplaceAll(l.Env[k], WorktreePlaceholder, workDir)
+			parts = append(parts, k+"="+shellQuote(v))
+		}
+	}
+	parts = append(parts, claudeBin)
+	if l.PermissionMode != "" {
+		parts = append(parts, "--permission-mode", shellQuote(l.PermissionMode))
+	}
+	for _, a := range l.Args {
+		parts = append(parts, shellQuote(strings.ReplaceAll(a, WorktreePlaceholder, workDir)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// shellQuote quotes s for a POSIX shell when it contains anything but
+// safe characters.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,@+%") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -6,33 +6,178 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
+	"text/template"
 	"time"
 
+	"github.com/mgreau/zen/internal/terminal"
 	"gopkg.in/yaml.v3"
 )
 
 // Config holds the complete zen configuration.
 type Config struct {
-	Repos        map[string]RepoConfig `yaml:"repos"`
-	WatchPaths   []string              `yaml:"watch_paths"`
-	Authors      []string              `yaml:"authors"`
-	PollInterval string                `yaml:"poll_interval"`
-	ClaudeBin    string                `yaml:"claude_bin"`
-	Terminal     string                `yaml:"terminal"` // "iterm" or "ghostty"
-	BranchPrefix string                `yaml:"branch_prefix"`
-	Watch        WatchConfig           `yaml:"watch"`
+	ConfigVersion int                   `yaml:"config_version"` // schema version, see CurrentVersion and zen config migrate
+	Repos         map[string]RepoConfig `yaml:"repos"`
+	Groups        map[string][]string   `yaml:"groups"` // named repo groups, used as --repo @name
+	WatchPaths    []string              `yaml:"watch_paths"`
+	Authors       []string              `yaml:"authors"`
+	AuthorsTeam   string                `yaml:"authors_from_team"` // "org/team" whose members are added to authors
+	Teams         []string              `yaml:"teams"`             // "org/team" slugs whose review requests show in inbox
+	PollInterval  string                `yaml:"poll_interval"`
+	ClaudeBin     string                `yaml:"claude_bin"`
+	Terminal      string                `yaml:"terminal"` // "auto", "iterm", "ghostty", "terminal" or "tmux"
+	Theme         string                `yaml:"theme"`    // "default", "light" or "high-contrast"
+	BranchPrefix  string                `yaml:"branch_prefix"`
+	SearchLimit   int                   `yaml:"search_limit"`   // max PRs fetched per GitHub search, default 200
+	PRTemplate    string                `yaml:"pr_template"`    // text/template for zen pr create bodies
+	KeepBranches  bool                  `yaml:"keep_branches"`  // keep branches when their worktree is removed
+	GitExclude    []string              `yaml:"git_exclude"`    // patterns added to info/exclude of worktrees, besides DefaultGitExclude
+	Metrics       bool                  `yaml:"metrics"`        // record local per-command timings, see zen stats --cli
+	Claude        ClaudeLaunch          `yaml:"claude"`         // options for interactive claude sessions
+	ReviewPrompt  string                `yaml:"review_prompt"`  // initial prompt of review sessions, default "/review-pr"
+	FeaturePrompt string                `yaml:"feature_prompt"` // initial prompt of feature sessions, default none
+	Playbooks     map[string]Playbook   `yaml:"playbooks"`      // review playbooks, besides DefaultPlaybooks
+	Labels        LabelRules            `yaml:"labels"`         // PR labels that hold, hurry or skip AI review
+	Pair          PairConfig            `yaml:"pair"`           // editor opened next to sessions with --pair
+	Inbox         InboxConfig           `yaml:"inbox"`
+	Status        StatusConfig          `yaml:"status"`
+	Watch         WatchConfig           `yaml:"watch"`
+	Hooks         []Hook                `yaml:"hooks"`   // relay daemon events to URLs or scripts
+	Columns       map[string][]string   `yaml:"columns"` // columns shown per table, keyed by TableColumns names
+
+	// TeamAuthors are the members of AuthorsTeam, set by the commands
+	// that need them (they're fetched from GitHub, then cached for a day).
+	TeamAuthors []string `yaml:"-"`
+}
+
+// TableColumns lists, per table, the column names accepted in columns: and
+// --columns. The inbox's sections each show the subset they have.
+var TableColumns = map[string][]string{
+	"inbox":   {"worktree", "repo", "pr", "why", "reason", "state", "age", "updated", "author", "title", "team", "files", "labels", "link"},
+	"status":  {"state", "pr", "title", "labels", "review", "session", "name", "branch", "age", "path"},
+	"reviews": {"pr", "repo", "title", "review", "session", "path"},
+}
+
+// ValidateColumns checks that table is one of TableColumns and keys are
+// its column names.
+func ValidateColumns(table string, keys []string) error {
+	valid, ok := TableColumns[table]
+	if !ok {
+		return fmt.Errorf("invalid columns table %q: must be one of inbox, reviews, status", table)
+	}
+	for _, k := range keys {
+		if !slices.Contains(valid, k) {
+			return fmt.Errorf("invalid %s column %q: must be one of %s", table, k, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
+
+// InboxSections are the section names accepted in inbox.sections.
+var InboxSections = []string{"review_requests", "team_requests", "approved_unmerged", "watched_paths", "issues", "discussions"}
+
+// defaultInboxSections are shown when inbox.sections is not set.
+var defaultInboxSections = []string{"review_requests", "team_requests", "approved_unmerged", "watched_paths"}
+
+// InboxConfig controls what zen inbox shows.
+type InboxConfig struct {
+	Sections   []string `yaml:"sections"`    // sections to show, in InboxSections; default: all PR sections
+	ThreadDays int      `yaml:"thread_days"` // how recently issues/discussions must have been updated, default 14
+}
+
+// Shows reports whether the inbox shows the named section.
+func (i InboxConfig) Shows(section string) bool {
+	if len(i.Sections) == 0 {
+		return slices.Contains(defaultInboxSections, section)
+	}
+	return slices.Contains(i.Sections, section)
+}
+
+// GetThreadDays returns ThreadDays with a default of 14.
+func (i InboxConfig) GetThreadDays() int {
+	if i.ThreadDays > 0 {
+		return i.ThreadDays
+	}
+	return 14
+}
+
+// StatusGroupings are the values accepted in status.group_by and
+// zen status --group-by. "type" is the plain layout: PR reviews, then
+// feature work.
+var StatusGroupings = []string{"type", "repo", "state", "age"}
+
+// StatusSorts are the values accepted in status.sort and zen status --sort.
+var StatusSorts = []string{"repo", "pr", "age"}
+
+// StatusConfig controls the layout of zen status.
+type StatusConfig struct {
+	GroupBy string `yaml:"group_by"` // in StatusGroupings, default "type"
+	Sort    string `yaml:"sort"`     // in StatusSorts, default "repo"
+}
+
+// GetGroupBy returns GroupBy with a default of "type".
+func (s StatusConfig) GetGroupBy() string {
+	if s.GroupBy != "" {
+		return s.GroupBy
+	}
+	return "type"
+}
+
+// GetSort returns Sort with a default of "repo".
+func (s StatusConfig) GetSort() string {
+	if s.Sort != "" {
+		return s.Sort
+	}
+	return "repo"
 }
 
 // WatchConfig holds configuration for the watch daemon's workqueue behavior.
 type WatchConfig struct {
+	// Components of the daemon, each on unless set to false
+	Notify      *bool `yaml:"notify"`       // desktop notifications
+	AutoSpawn   *bool `yaml:"auto_spawn"`   // worktree setup for new review requests
+	AutoCleanup *bool `yaml:"auto_cleanup"` // removal of merged PRs' worktrees
+
 	DispatchInterval    string `yaml:"dispatch_interval"`     // default "10s"
 	CleanupInterval     string `yaml:"cleanup_interval"`      // default "1h"
 	SessionScanInterval string `yaml:"session_scan_interval"` // default "10s"
 	CleanupAfterDays    int    `yaml:"cleanup_after_days"`    // default 5
 	Concurrency         int    `yaml:"concurrency"`           // default 2
+	PerRepoConcurrency  int    `yaml:"per_repo_concurrency"`  // 0 = shared queue, >0 = one queue per repo
 	MaxRetries          int    `yaml:"max_retries"`           // default 5
 	DigestInterval      string `yaml:"digest_interval"`       // "" = disabled, e.g. "2h"
+	SessionGCAfterDays  int    `yaml:"session_gc_after_days"` // 0 = disabled; delete orphaned Claude sessions inactive this long
+	NotifyBatchWindow   string `yaml:"notify_batch_window"`   // default "3s"; notifications arriving within it are batched
+	NotifyDedupeWindow  string `yaml:"notify_dedupe_window"`  // default "10m"; repeats within it are dropped
+
+	// Ignore excludes PRs (e.g. from bots) from notifications and auto-setup
+	Ignore WatchIgnore `yaml:"ignore"`
+
+	// SpawnWindow holds auto-setup of new PRs to working hours
+	SpawnWindow SpawnWindow `yaml:"spawn_window"`
+
+	// CleanupByReview overrides cleanup_after_days by your review of the
+	// merged PR, keyed by ReviewOutcomes; a negative value keeps the
+	// worktree until it is deleted by hand.
+	CleanupByReview map[string]int `yaml:"cleanup_by_review"`
+}
+
+// NotifyEnabled reports whether the daemon sends desktop notifications.
+func (w WatchConfig) NotifyEnabled() bool {
+	return w.Notify == nil || *w.Notify
+}
+
+// AutoSpawnEnabled reports whether the daemon sets up worktrees for new
+// review requests.
+func (w WatchConfig) AutoSpawnEnabled() bool {
+	return w.AutoSpawn == nil || *w.AutoSpawn
+}
+
+// AutoCleanupEnabled reports whether the daemon removes the worktrees of
+// merged PRs.
+func (w WatchConfig) AutoCleanupEnabled() bool {
+	return w.AutoCleanup == nil || *w.AutoCleanup
 }
 
 // DispatchIntervalDuration returns the dispatch interval as a time.Duration,
@@ -65,6 +210,31 @@ func (w WatchConfig) GetCleanupAfterDays() int {
 	return 5
 }
 
+// ReviewOutcomes are the keys of cleanup_by_review: your latest review of
+// a PR, or "unreviewed".
+var ReviewOutcomes = []string{"approved", "changes_requested", "commented", "dismissed", "unreviewed"}
+
+// ReviewOutcomeKey returns the cleanup_by_review key of a GitHub review
+// state such as CHANGES_REQUESTED, "" meaning no review.
+func ReviewOutcomeKey(state string) string {
+	if state == "" {
+		return "unreviewed"
+	}
+	return strings.ToLower(state)
+}
+
+// CleanupAfterDaysFor returns how many days after merge the worktree of a
+// PR with the given review outcome is removed, or keep when it never is.
+func (w WatchConfig) CleanupAfterDaysFor(outcome string) (days int, keep bool) {
+	if n, ok := w.CleanupByReview[outcome]; ok {
+		if n < 0 {
+			return 0, true
+		}
+		return n, false
+	}
+	return w.GetCleanupAfterDays(), false
+}
+
 // GetConcurrency returns the concurrency limit with a default of 2.
 func (w WatchConfig) GetConcurrency() int {
 	if w.Concurrency > 0 {
@@ -73,6 +243,15 @@ func (w WatchConfig) GetConcurrency() int {
 	return 2
 }
 
+// GetPerRepoConcurrency returns the per-repo concurrency limit and whether
+// per-repo queues are enabled. Zero (the default) keeps a single shared queue.
+func (w WatchConfig) GetPerRepoConcurrency() (int, bool) {
+	if w.PerRepoConcurrency > 0 {
+		return w.PerRepoConcurrency, true
+	}
+	return 0, false
+}
+
 // GetMaxRetries returns the max retries with a default of 5.
 func (w WatchConfig) GetMaxRetries() int {
 	if w.MaxRetries > 0 {
@@ -105,26 +284,98 @@ func (w WatchConfig) SessionScanIntervalDuration() time.Duration {
 	return 10 * time.Second
 }
 
+// NotifyBatchWindowDuration returns how long the daemon collects
+// notifications before delivering them in batches, falling back to the
+// default of 3 seconds.
+func (w WatchConfig) NotifyBatchWindowDuration() time.Duration {
+	if w.NotifyBatchWindow != "" {
+		if d, err := time.ParseDuration(w.NotifyBatchWindow); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return 3 * time.Second
+}
+
+// NotifyDedupeWindowDuration returns how long the daemon drops repeats of
+// a delivered notification, falling back to the default of 10 minutes.
+func (w WatchConfig) NotifyDedupeWindowDuration() time.Duration {
+	if w.NotifyDedupeWindow != "" {
+		if d, err := time.ParseDuration(w.NotifyDedupeWindow); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return 10 * time.Minute
+}
+
 // RepoConfig holds per-repository configuration.
 type RepoConfig struct {
-	FullName string `yaml:"full_name"`
-	BasePath string `yaml:"base_path"`
+	FullName      string   `yaml:"full_name"`
+	BasePath      string   `yaml:"base_path"`
+	Sparse        bool     `yaml:"sparse"`         // sparse-checkout review worktrees by default
+	ReadOnly      bool     `yaml:"readonly"`       // block commits and pushes in new review worktrees
+	SparseInclude []string `yaml:"sparse_include"` // dirs always checked out in sparse mode
+	FetchDepth    int      `yaml:"fetch_depth"`    // git fetch --depth for review worktrees, 0 = full history
+	FetchFilter   string   `yaml:"fetch_filter"`   // git fetch --filter for review worktrees, e.g. "blob:none"
+	PoolSize      int      `yaml:"pool_size"`      // pre-created worktrees kept ready for PR reviews, 0 = no pool
+	PoolRefresh   string   `yaml:"pool_refresh"`   // how often pooled worktrees move to origin/main, default "6h"
+	GitTimeout    string   `yaml:"git_timeout"`    // max duration of one git command (fetch, worktree add, checkout), default "5m"
+	Fork          string   `yaml:"fork"`           // contributor mode: "auto" or owner/name of the fork feature branches are pushed to
+	Upstream      string   `yaml:"upstream"`       // triangular mode: remote of the canonical repo when origin is your fork, e.g. "upstream"
+	ReviewPrompt  string   `yaml:"review_prompt"`  // overrides the global review_prompt
+	FeaturePrompt string   `yaml:"feature_prompt"` // overrides the global feature_prompt
+	LFS           *bool    `yaml:"lfs"`            // git lfs pull in new worktrees of repos using LFS, default true
+	Submodules    *bool    `yaml:"submodules"`     // init submodules in new worktrees of repos that have them, default true
+
+	Claude ClaudeLaunch `yaml:"claude"` // overrides the global claude launch options
 }
 
-// zenHome returns the path to ~/.zen.
-func zenHome() string {
-	return filepath.Join(os.Getenv("HOME"), ".zen")
+// Path returns the config file path: $ZEN_CONFIG if set, otherwise
+// config.yaml in the zen config directory (~/.zen by default, see
+// dirs.go for the XDG layout).
+func Path() string {
+	if p := os.Getenv("ZEN_CONFIG"); p != "" {
+		return p
+	}
+	return filepath.Join(zenHome(), "config.yaml")
 }
 
-// Load reads the YAML config from ~/.zen/config.yaml.
+// Themes lists the built-in color themes of internal/ui, in sorted order.
+var Themes = []string{"default", "high-contrast", "light"}
+
+// Load reads the YAML config from Path().
 // Returns an error if the config file does not exist or is invalid.
 func Load() (*Config, error) {
-	yamlPath := filepath.Join(zenHome(), "config.yaml")
+	return LoadFile(Path())
+}
+
+// LoadFile reads the YAML config from yamlPath, applying the same defaults
+// and validation as Load.
+// A config from before the current schema that fails to load gets a hint
+// to run zen config migrate.
+func LoadFile(yamlPath string) (*Config, error) {
 	data, err := os.ReadFile(yamlPath)
 	if err != nil {
 		return nil, fmt.Errorf("config file not found: %s\nRun 'zen setup' to create it", yamlPath)
 	}
+	cfg, err := parse(data, yamlPath)
+	if err != nil {
+		if v, verr := dataVersion(data); verr == nil && v < CurrentVersion {
+			return nil, fmt.Errorf("%w\n%s is from an older zen: 'zen config migrate' may fix it", err, yamlPath)
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Outdated reports whether the config was written for an older schema.
+// Whether upgrading it changes anything is up to NeedsMigration.
+func (c *Config) Outdated() bool {
+	return c.ConfigVersion < CurrentVersion
+}
 
+// parse decodes, validates and applies the defaults to the config data
+// read from yamlPath.
+func parse(data []byte, yamlPath string) (*Config, error) {
 	cfg := &Config{}
 	if err := yaml.Unmarshal(data, cfg); err != nil {
 		return nil, fmt.Errorf("parsing %s: %w", yamlPath, err)
@@ -138,20 +389,147 @@ func Load() (*Config, error) {
 		cfg.ClaudeBin = "claude"
 	}
 	if cfg.Terminal == "" {
-		cfg.Terminal = "iterm" // default to iTerm for backward compatibility
+		cfg.Terminal = "auto"
+	}
+	if !slices.Contains(terminal.Types, cfg.Terminal) {
+		return nil, fmt.Errorf("invalid terminal type %q: must be one of %s", cfg.Terminal, strings.Join(terminal.Types, ", "))
+	}
+	if cfg.Theme != "" && !slices.Contains(Themes, cfg.Theme) {
+		return nil, fmt.Errorf("invalid theme %q: must be one of %s", cfg.Theme, strings.Join(Themes, ", "))
+	}
+	for _, team := range cfg.Teams {
+		if org, slug, ok := strings.Cut(team, "/"); !ok || org == "" || slug == "" || strings.Contains(slug, "/") {
+			return nil, fmt.Errorf("invalid team %q: must be \"org/team\"", team)
+		}
+	}
+	if team := cfg.AuthorsTeam; team != "" {
+		if org, slug, ok := strings.Cut(team, "/"); !ok || org == "" || slug == "" || strings.Contains(slug, "/") {
+			return nil, fmt.Errorf("invalid authors_from_team %q: must be \"org/team\"", team)
+		}
+	}
+	if cfg.PRTemplate != "" {
+		if _, err := template.New("pr").Parse(cfg.PRTemplate); err != nil {
+			return nil, fmt.Errorf("invalid pr_template: %w", err)
+		}
+	}
+	for _, section := range cfg.Inbox.Sections {
+		if !slices.Contains(InboxSections, section) {
+			return nil, fmt.Errorf("invalid inbox section %q: must be one of %s", section, strings.Join(InboxSections, ", "))
+		}
+	}
+	if cfg.Status.GroupBy != "" && !slices.Contains(StatusGroupings, cfg.Status.GroupBy) {
+		return nil, fmt.Errorf("invalid status.group_by %q: must be one of %s", cfg.Status.GroupBy, strings.Join(StatusGroupings, ", "))
+	}
+	if cfg.Status.Sort != "" && !slices.Contains(StatusSorts, cfg.Status.Sort) {
+		return nil, fmt.Errorf("invalid status.sort %q: must be one of %s", cfg.Status.Sort, strings.Join(StatusSorts, ", "))
+	}
+	for table, keys := range cfg.Columns {
+		if err := ValidateColumns(table, keys); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.Claude.validate("claude"); err != nil {
+		return nil, err
+	}
+	if err := validateHooks(cfg.Hooks); err != nil {
+		return nil, err
+	}
+	if err := cfg.Pair.validate(); err != nil {
+		return nil, err
+	}
+	if err := validatePrompt("review_prompt", cfg.ReviewPrompt); err != nil {
+		return nil, err
+	}
+	if err := validatePrompt("feature_prompt", cfg.FeaturePrompt); err != nil {
+		return nil, err
 	}
-	if cfg.Terminal != "iterm" && cfg.Terminal != "ghostty" {
-		return nil, fmt.Errorf("invalid terminal type %q: must be \"iterm\" or \"ghostty\"", cfg.Terminal)
+	if err := cfg.validatePlaybooks(); err != nil {
+		return nil, err
 	}
 	if cfg.Repos == nil {
 		cfg.Repos = make(map[string]RepoConfig)
 	}
+	for short, repo := range cfg.Repos {
+		if err := repo.Claude.validate(fmt.Sprintf("repo %q: claude", short)); err != nil {
+			return nil, err
+		}
+		if err := validatePrompt(fmt.Sprintf("repo %q: review_prompt", short), repo.ReviewPrompt); err != nil {
+			return nil, err
+		}
+		if err := validatePrompt(fmt.Sprintf("repo %q: feature_prompt", short), repo.FeaturePrompt); err != nil {
+			return nil, err
+		}
+		if repo.FetchDepth < 0 {
+			return nil, fmt.Errorf("repo %q: fetch_depth must be >= 0, got %d", short, repo.FetchDepth)
+		}
+		if repo.PoolSize < 0 {
+			return nil, fmt.Errorf("repo %q: pool_size must be >= 0, got %d", short, repo.PoolSize)
+		}
+		if repo.GitTimeout != "" {
+			if d, err := time.ParseDuration(repo.GitTimeout); err != nil || d <= 0 {
+				return nil, fmt.Errorf("repo %q: invalid git_timeout %q: must be a positive duration such as \"5m\"", short, repo.GitTimeout)
+			}
+		}
+		if repo.Fork != "" && repo.Fork != "auto" {
+			if owner, name, ok := strings.Cut(repo.Fork, "/"); !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+				return nil, fmt.Errorf("repo %q: invalid fork %q: must be \"auto\" or \"owner/name\"", short, repo.Fork)
+			}
+		}
+		if repo.Upstream != "" {
+			if strings.ContainsAny(repo.Upstream, "/ \t") || strings.HasPrefix(repo.Upstream, "-") {
+				return nil, fmt.Errorf("repo %q: invalid upstream %q: must be a git remote name such as \"upstream\"", short, repo.Upstream)
+			}
+			if repo.Fork != "" {
+				return nil, fmt.Errorf("repo %q: fork and upstream can't both be set: use fork when origin is the canonical repo, upstream when origin is your fork", short)
+			}
+		}
+		if repo.PoolRefresh != "" {
+			if _, err := time.ParseDuration(repo.PoolRefresh); err != nil {
+				return nil, fmt.Errorf("repo %q: invalid pool_refresh %q: %w", short, repo.PoolRefresh, err)
+			}
+		}
+	}
+	if err := cfg.Watch.Ignore.Notify.validate("notify"); err != nil {
+		return nil, err
+	}
+	if err := cfg.Watch.Ignore.Setup.validate("setup"); err != nil {
+		return nil, err
+	}
+	if err := cfg.Watch.SpawnWindow.validate(); err != nil {
+		return nil, err
+	}
+	for outcome := range cfg.Watch.CleanupByReview {
+		if !slices.Contains(ReviewOutcomes, outcome) {
+			return nil, fmt.Errorf("invalid watch.cleanup_by_review key %q: must be one of %s", outcome, strings.Join(ReviewOutcomes, ", "))
+		}
+	}
+	for group, members := range cfg.Groups {
+		for _, m := range members {
+			if _, ok := cfg.Repos[m]; !ok {
+				return nil, fmt.Errorf("group %q references unknown repo %q", group, m)
+			}
+		}
+	}
 
 	cfg.expandPaths()
 	return cfg, nil
 }
 
-// GetTerminal returns the configured terminal type.
+// DefaultSearchLimit is the max PRs fetched per GitHub search query when
+// search_limit is unset.
+const DefaultSearchLimit = 200
+
+// GetSearchLimit returns the max PRs fetched per GitHub search query,
+// with a default of DefaultSearchLimit.
+func (c *Config) GetSearchLimit() int {
+	if c.SearchLimit > 0 {
+		return c.SearchLimit
+	}
+	return DefaultSearchLimit
+}
+
+// GetTerminal returns the configured terminal type; "auto" means detect
+// it at runtime.
 func (c *Config) GetTerminal() string {
 	return c.Terminal
 }
@@ -195,6 +573,29 @@ func (c *Config) RepoNames() []string {
 	return names
 }
 
+// ResolveRepos expands a --repo value into repo short names. An empty spec
+// means all configured repos, "@name" expands the named group, and anything
+// else is returned as a single repo name.
+func (c *Config) ResolveRepos(spec string) ([]string, error) {
+	if spec == "" {
+		return c.RepoNames(), nil
+	}
+	if IsGroupRef(spec) {
+		name := strings.TrimPrefix(spec, "@")
+		members, ok := c.Groups[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown repo group %q -- check %s", name, Path())
+		}
+		return members, nil
+	}
+	return []string{spec}, nil
+}
+
+// IsGroupRef reports whether a --repo value refers to a repo group ("@name").
+func IsGroupRef(spec string) bool {
+	return strings.HasPrefix(spec, "@")
+}
+
 // RepoFullName maps a short name to full GitHub owner/repo.
 func (c *Config) RepoFullName(short string) string {
 	if repo, ok := c.Repos[short]; ok {
@@ -224,6 +625,137 @@ func (c *Config) RepoBasePath(short string) string {
 	return ""
 }
 
+// PollIntervalDuration returns the parsed poll interval with a default of 5m.
+func (c *Config) PollIntervalDuration() time.Duration {
+	if c.PollInterval != "" {
+		if d, err := time.ParseDuration(c.PollInterval); err == nil {
+			return d
+		}
+	}
+	return 5 * time.Minute
+}
+
+// RepoSparse reports whether review worktrees for the repo use
+// sparse-checkout by default.
+func (c *Config) RepoSparse(short string) bool {
+	if repo, ok := c.Repos[short]; ok {
+		return repo.Sparse
+	}
+	return false
+}
+
+// RepoReadOnly reports whether new review worktrees of the repo are made
+// read-only.
+func (c *Config) RepoReadOnly(short string) bool {
+	if repo, ok := c.Repos[short]; ok {
+		return repo.ReadOnly
+	}
+	return false
+}
+
+// RepoLFS reports whether new worktrees of the repo get their LFS files
+// pulled when the repo uses LFS.
+func (c *Config) RepoLFS(short string) bool {
+	repo, ok := c.Repos[short]
+	return !ok || repo.LFS == nil || *repo.LFS
+}
+
+// RepoSubmodules reports whether new worktrees of the repo get their
+// submodules initialized when the repo has any.
+func (c *Config) RepoSubmodules(short string) bool {
+	repo, ok := c.Repos[short]
+	return !ok || repo.Submodules == nil || *repo.Submodules
+}
+
+// DefaultGitExclude lists the files zen writes into worktrees, which are
+// kept out of git status.
+var DefaultGitExclude = []string{"CLAUDE.local.md", "NOTES.zen.md", ".zen/"}
+
+// GitExcludes returns the patterns added to the info/exclude file of the
+// repos zen creates worktrees in: DefaultGitExclude and git_exclude.
+func (c *Config) GitExcludes() []string {
+	return append(slices.Clone(DefaultGitExclude), c.GitExclude...)
+}
+
+// RepoSparseInclude returns the dirs always checked out in sparse mode.
+func (c *Config) RepoSparseInclude(short string) []string {
+	if repo, ok := c.Repos[short]; ok {
+		return repo.SparseInclude
+	}
+	return nil
+}
+
+// RepoFetchDepth returns the git fetch depth for review worktrees of the
+// repo, 0 meaning full history.
+func (c *Config) RepoFetchDepth(short string) int {
+	if repo, ok := c.Repos[short]; ok {
+		return repo.FetchDepth
+	}
+	return 0
+}
+
+// RepoFetchFilter returns the git fetch --filter spec for review worktrees
+// of the repo, or "" for none.
+func (c *Config) RepoFetchFilter(short string) string {
+	if repo, ok := c.Repos[short]; ok {
+		return repo.FetchFilter
+	}
+	return ""
+}
+
+// RepoPoolSize returns how many blank worktrees to keep ready for the
+// repo's PR reviews, 0 meaning no pool.
+func (c *Config) RepoPoolSize(short string) int {
+	if repo, ok := c.Repos[short]; ok {
+		return repo.PoolSize
+	}
+	return 0
+}
+
+// RepoPoolRefresh returns how often the repo's pooled worktrees are moved
+// to the latest origin/main, with a default of 6 hours.
+func (c *Config) RepoPoolRefresh(short string) time.Duration {
+	if repo, ok := c.Repos[short]; ok && repo.PoolRefresh != "" {
+		if d, err := time.ParseDuration(repo.PoolRefresh); err == nil {
+			return d
+		}
+	}
+	return 6 * time.Hour
+}
+
+// DefaultGitTimeout bounds a single git command in repos without a
+// git_timeout.
+const DefaultGitTimeout = 5 * time.Minute
+
+// RepoGitTimeout returns how long one git command may run in the repo
+// before it is killed, with a default of DefaultGitTimeout.
+func (c *Config) RepoGitTimeout(short string) time.Duration {
+	if repo, ok := c.Repos[short]; ok && repo.GitTimeout != "" {
+		if d, err := time.ParseDuration(repo.GitTimeout); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultGitTimeout
+}
+
+// RepoFork returns the repo's fork setting: "" when branches are pushed
+// to origin, "auto" for the user's own fork, or the fork's owner/name.
+func (c *Config) RepoFork(short string) string {
+	if repo, ok := c.Repos[short]; ok {
+		return repo.Fork
+	}
+	return ""
+}
+
+// RepoUpstream returns the remote PRs are fetched from and feature
+// branches start from: the repo's upstream setting, else origin.
+func (c *Config) RepoUpstream(short string) string {
+	if repo, ok := c.Repos[short]; ok && repo.Upstream != "" {
+		return repo.Upstream
+	}
+	return "origin"
+}
+
 // AllBasePaths returns all configured repo base paths.
 func (c *Config) AllBasePaths() []string {
 	paths := make([]string, 0, len(c.Repos))
@@ -233,19 +765,22 @@ func (c *Config) AllBasePaths() []string {
 	return paths
 }
 
-// IsAuthor returns true if the given login is in the authors list.
-func (c *Config) IsAuthor(login string) bool {
-	for _, a := range c.Authors {
-		if a == login {
-			return true
+// AllAuthors returns the authors list with the members of
+// authors_from_team, once resolved into TeamAuthors.
+func (c *Config) AllAuthors() []string {
+	all := slices.Clone(c.Authors)
+	for _, a := range c.TeamAuthors {
+		if !slices.Contains(all, a) {
+			all = append(all, a)
 		}
 	}
-	return false
+	return all
 }
 
-// StateDir returns the path to the zen state directory.
-func StateDir() string {
-	return filepath.Join(zenHome(), "state")
+// IsAuthor returns true if the given login is in the authors list or a
+// member of authors_from_team.
+func (c *Config) IsAuthor(login string) bool {
+	return slices.Contains(c.Authors, login) || slices.Contains(c.TeamAuthors, login)
 }
 
 // EnsureDirs creates required zen directories.
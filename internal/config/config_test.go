@@ -3,7 +3,11 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
+
+	"github.com/mgreau/zen/internal/ui"
 )
 
 func TestRepoFullName(t *testing.T) {
@@ -73,6 +77,14 @@ func TestIsAuthor(t *testing.T) {
 	if cfg.IsAuthor("nobody") {
 		t.Error("IsAuthor(nobody) should be false")
 	}
+
+	cfg.TeamAuthors = []string{"bob", "carol"}
+	if !cfg.IsAuthor("carol") {
+		t.Error("IsAuthor(carol) should be true for a member of authors_from_team")
+	}
+	if got := cfg.AllAuthors(); !slices.Equal(got, []string{"alice", "bob", "carol"}) {
+		t.Errorf("AllAuthors() = %v", got)
+	}
 }
 
 func TestRepoNames(t *testing.T) {
@@ -88,6 +100,170 @@ func TestRepoNames(t *testing.T) {
 	}
 }
 
+func TestResolveRepos(t *testing.T) {
+	cfg := &Config{
+		Repos: map[string]RepoConfig{
+			"apko":    {FullName: "chainguard-dev/apko"},
+			"melange": {FullName: "chainguard-dev/melange"},
+			"mono":    {FullName: "chainguard-dev/mono"},
+		},
+		Groups: map[string][]string{
+			"images": {"apko", "melange"},
+		},
+	}
+
+	all, err := cfg.ResolveRepos("")
+	if err != nil || len(all) != 3 {
+		t.Errorf("ResolveRepos(\"\") = %v, %v; want 3 repos", all, err)
+	}
+
+	group, err := cfg.ResolveRepos("@images")
+	if err != nil {
+		t.Fatalf("ResolveRepos(@images) error: %v", err)
+	}
+	if len(group) != 2 || group[0] != "apko" || group[1] != "melange" {
+		t.Errorf("ResolveRepos(@images) = %v, want [apko melange]", group)
+	}
+
+	single, err := cfg.ResolveRepos("mono")
+	if err != nil || len(single) != 1 || single[0] != "mono" {
+		t.Errorf("ResolveRepos(mono) = %v, %v; want [mono]", single, err)
+	}
+
+	if _, err := cfg.ResolveRepos("@missing"); err == nil {
+		t.Error("ResolveRepos(@missing) should return an error")
+	}
+}
+
+func TestLoadRejectsUnknownGroupMember(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	yamlContent := `repos:
+  apko:
+    full_name: chainguard-dev/apko
+    base_path: /tmp/apko
+groups:
+  images: [apko, melange]
+`
+	os.WriteFile(filepath.Join(zenDir, "config.yaml"), []byte(yamlContent), 0o644)
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject a group referencing an unknown repo")
+	}
+}
+
+func TestGetSearchLimit(t *testing.T) {
+	if got := (&Config{}).GetSearchLimit(); got != DefaultSearchLimit {
+		t.Errorf("default GetSearchLimit() = %d, want %d", got, DefaultSearchLimit)
+	}
+	if got := (&Config{SearchLimit: 500}).GetSearchLimit(); got != 500 {
+		t.Errorf("GetSearchLimit() = %d, want 500", got)
+	}
+}
+
+func TestPollIntervalDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 5 * time.Minute},
+		{"10m", 10 * time.Minute},
+		{"bogus", 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		c := &Config{PollInterval: tt.in}
+		if got := c.PollIntervalDuration(); got != tt.want {
+			t.Errorf("PollIntervalDuration(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoadRejectsUnknownTheme(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	os.WriteFile(filepath.Join(zenDir, "config.yaml"), []byte("theme: neon\n"), 0o644)
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject an unknown theme")
+	}
+}
+
+func TestThemesMatchUI(t *testing.T) {
+	if !slices.Equal(Themes, ui.ThemeNames()) {
+		t.Errorf("Themes = %v, want the ui themes %v", Themes, ui.ThemeNames())
+	}
+}
+
+func TestLoadRejectsBadPRTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	os.WriteFile(filepath.Join(zenDir, "config.yaml"), []byte("pr_template: \"{{.Summary\"\n"), 0o644)
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject a malformed pr_template")
+	}
+}
+
+func TestLoadValidatesTeams(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	cfgPath := filepath.Join(zenDir, "config.yaml")
+
+	os.WriteFile(cfgPath, []byte("teams: [chainguard-dev/platform]\n"), 0o644)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.Teams) != 1 || cfg.Teams[0] != "chainguard-dev/platform" {
+		t.Errorf("Teams = %v, want [chainguard-dev/platform]", cfg.Teams)
+	}
+
+	for _, bad := range []string{"platform", "/platform", "org/", "org/a/b"} {
+		os.WriteFile(cfgPath, []byte("teams: [\""+bad+"\"]\n"), 0o644)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() should reject team %q", bad)
+		}
+	}
+}
+
+func TestLoadFromZenConfigEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	alt := filepath.Join(tmpDir, "profiles", "work.yaml")
+	os.MkdirAll(filepath.Dir(alt), 0o755)
+	os.WriteFile(alt, []byte("authors: [alt]\n"), 0o644)
+	t.Setenv("ZEN_CONFIG", alt)
+
+	if got := Path(); got != alt {
+		t.Errorf("Path() = %q, want %q", got, alt)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.Authors) != 1 || cfg.Authors[0] != "alt" {
+		t.Errorf("Authors = %v, want [alt] from $ZEN_CONFIG", cfg.Authors)
+	}
+
+	t.Setenv("ZEN_CONFIG", "")
+	if got, want := Path(), filepath.Join(tmpDir, ".zen", "config.yaml"); got != want {
+		t.Errorf("Path() without ZEN_CONFIG = %q, want %q", got, want)
+	}
+}
+
 func TestLoadYAML(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("HOME", tmpDir)
@@ -99,6 +275,9 @@ func TestLoadYAML(t *testing.T) {
   test-repo:
     full_name: org/test-repo
     base_path: ~/git/test
+    sparse: true
+    sparse_include:
+      - hack
 watch_paths:
   - src
 authors:
@@ -126,6 +305,16 @@ claude_bin: /usr/local/bin/claude
 		t.Errorf("test-repo base_path = %q, want %q", cfg.Repos["test-repo"].BasePath, expectedBase)
 	}
 
+	if !cfg.RepoSparse("test-repo") {
+		t.Error("RepoSparse(test-repo) = false, want true")
+	}
+	if inc := cfg.RepoSparseInclude("test-repo"); len(inc) != 1 || inc[0] != "hack" {
+		t.Errorf("RepoSparseInclude(test-repo) = %v, want [hack]", inc)
+	}
+	if cfg.RepoSparse("unknown") {
+		t.Error("RepoSparse(unknown) = true, want false")
+	}
+
 	if len(cfg.WatchPaths) != 1 || cfg.WatchPaths[0] != "src" {
 		t.Errorf("WatchPaths = %v, want [src]", cfg.WatchPaths)
 	}
@@ -231,15 +420,54 @@ func TestWatchConfigDefaults(t *testing.T) {
 	if n := w.GetMaxRetries(); n != 5 {
 		t.Errorf("GetMaxRetries default = %d, want 5", n)
 	}
+	if n, ok := w.GetPerRepoConcurrency(); ok || n != 0 {
+		t.Errorf("GetPerRepoConcurrency default = (%d, %v), want (0, false)", n, ok)
+	}
+	if d := w.NotifyBatchWindowDuration(); d.String() != "3s" {
+		t.Errorf("NotifyBatchWindowDuration default = %v, want 3s", d)
+	}
+	if d := w.NotifyDedupeWindowDuration(); d.String() != "10m0s" {
+		t.Errorf("NotifyDedupeWindowDuration default = %v, want 10m0s", d)
+	}
+	if !w.NotifyEnabled() || !w.AutoSpawnEnabled() || !w.AutoCleanupEnabled() {
+		t.Error("daemon components should default to enabled")
+	}
+}
+
+func TestWatchComponents(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	os.WriteFile(filepath.Join(zenDir, "config.yaml"), []byte(`repos:
+  mono:
+    full_name: o/mono
+    base_path: /tmp
+watch:
+  notify: true
+  auto_spawn: false
+`), 0o644)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !cfg.Watch.NotifyEnabled() || cfg.Watch.AutoSpawnEnabled() || !cfg.Watch.AutoCleanupEnabled() {
+		t.Errorf("components = notify %v, auto_spawn %v, auto_cleanup %v; want true, false, true (unset)",
+			cfg.Watch.NotifyEnabled(), cfg.Watch.AutoSpawnEnabled(), cfg.Watch.AutoCleanupEnabled())
+	}
 }
 
 func TestWatchConfigCustom(t *testing.T) {
 	w := WatchConfig{
-		DispatchInterval: "30s",
-		CleanupInterval:  "2h",
-		CleanupAfterDays: 10,
-		Concurrency:      4,
-		MaxRetries:       3,
+		DispatchInterval:   "30s",
+		CleanupInterval:    "2h",
+		CleanupAfterDays:   10,
+		Concurrency:        4,
+		PerRepoConcurrency: 1,
+		MaxRetries:         3,
+		NotifyBatchWindow:  "0s",
+		NotifyDedupeWindow: "1h",
 	}
 
 	if d := w.DispatchIntervalDuration(); d.String() != "30s" {
@@ -257,4 +485,322 @@ func TestWatchConfigCustom(t *testing.T) {
 	if n := w.GetMaxRetries(); n != 3 {
 		t.Errorf("GetMaxRetries = %d, want 3", n)
 	}
+	if n, ok := w.GetPerRepoConcurrency(); !ok || n != 1 {
+		t.Errorf("GetPerRepoConcurrency = (%d, %v), want (1, true)", n, ok)
+	}
+	if d := w.NotifyBatchWindowDuration(); d != 0 {
+		t.Errorf("NotifyBatchWindowDuration = %v, want 0s", d)
+	}
+	if d := w.NotifyDedupeWindowDuration(); d.String() != "1h0m0s" {
+		t.Errorf("NotifyDedupeWindowDuration = %v, want 1h0m0s", d)
+	}
+}
+
+func TestCleanupByReview(t *testing.T) {
+	w := WatchConfig{CleanupAfterDays: 7, CleanupByReview: map[string]int{"approved": 1, "unreviewed": -1}}
+	for _, tt := range []struct {
+		state string
+		days  int
+		keep  bool
+	}{
+		{"APPROVED", 1, false},
+		{"", 0, true},
+		{"CHANGES_REQUESTED", 7, false},
+	} {
+		days, keep := w.CleanupAfterDaysFor(ReviewOutcomeKey(tt.state))
+		if days != tt.days || keep != tt.keep {
+			t.Errorf("CleanupAfterDaysFor(%q) = (%d, %v), want (%d, %v)", tt.state, days, keep, tt.days, tt.keep)
+		}
+	}
+
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	os.WriteFile(filepath.Join(zenDir, "config.yaml"), []byte("watch:\n  cleanup_by_review:\n    approve: 1\n"), 0o644)
+	if _, err := Load(); err == nil {
+		t.Error("Load() should reject an unknown cleanup_by_review key")
+	}
+}
+
+func TestRepoPool(t *testing.T) {
+	cfg := &Config{Repos: map[string]RepoConfig{
+		"mono": {PoolSize: 3, PoolRefresh: "2h"},
+		"os":   {},
+	}}
+	if got := cfg.RepoPoolSize("mono"); got != 3 {
+		t.Errorf("RepoPoolSize(mono) = %d, want 3", got)
+	}
+	if got := cfg.RepoPoolRefresh("mono"); got != 2*time.Hour {
+		t.Errorf("RepoPoolRefresh(mono) = %s, want 2h", got)
+	}
+	if got := cfg.RepoPoolSize("os"); got != 0 {
+		t.Errorf("RepoPoolSize(os) = %d, want 0", got)
+	}
+	if got := cfg.RepoPoolRefresh("os"); got != 6*time.Hour {
+		t.Errorf("RepoPoolRefresh(os) = %s, want 6h default", got)
+	}
+
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	for _, bad := range []string{"pool_size: -1", "pool_refresh: soon"} {
+		os.WriteFile(filepath.Join(zenDir, "config.yaml"), []byte("repos:\n  mono:\n    full_name: o/mono\n    base_path: /tmp\n    "+bad+"\n"), 0o644)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() should reject %q", bad)
+		}
+	}
+}
+
+func TestRepoGitTimeout(t *testing.T) {
+	cfg := &Config{Repos: map[string]RepoConfig{
+		"mono": {GitTimeout: "15m"},
+		"os":   {},
+	}}
+	if got := cfg.RepoGitTimeout("mono"); got != 15*time.Minute {
+		t.Errorf("RepoGitTimeout(mono) = %s, want 15m", got)
+	}
+	if got := cfg.RepoGitTimeout("os"); got != DefaultGitTimeout {
+		t.Errorf("RepoGitTimeout(os) = %s, want the default", got)
+	}
+
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	for _, bad := range []string{"git_timeout: soon", "git_timeout: 0s", "git_timeout: -1m"} {
+		os.WriteFile(filepath.Join(zenDir, "config.yaml"), []byte("repos:\n  mono:\n    full_name: o/mono\n    base_path: /tmp\n    "+bad+"\n"), 0o644)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() should reject %q", bad)
+		}
+	}
+}
+
+func TestRepoForkValidation(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	write := func(fork string) {
+		os.WriteFile(filepath.Join(zenDir, "config.yaml"), []byte("repos:\n  mono:\n    full_name: o/mono\n    base_path: /tmp\n    fork: "+fork+"\n"), 0o644)
+	}
+	for _, good := range []string{"auto", "me/mono"} {
+		write(good)
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load() with fork %q: %v", good, err)
+		}
+		if got := cfg.RepoFork("mono"); got != good {
+			t.Errorf("RepoFork(mono) = %q, want %q", got, good)
+		}
+	}
+	for _, bad := range []string{"me", "me/", "/mono", "a/b/c"} {
+		write(bad)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() should reject fork %q", bad)
+		}
+	}
+}
+
+func TestRepoUpstreamValidation(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	write := func(extra string) {
+		os.WriteFile(filepath.Join(zenDir, "config.yaml"), []byte("repos:\n  mono:\n    full_name: o/mono\n    base_path: /tmp\n"+extra), 0o644)
+	}
+
+	write("")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load(): %v", err)
+	}
+	if got := cfg.RepoUpstream("mono"); got != "origin" {
+		t.Errorf("RepoUpstream(mono) without upstream = %q, want origin", got)
+	}
+	write("    upstream: upstream\n")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load() with upstream: %v", err)
+	}
+	if got := cfg.RepoUpstream("mono"); got != "upstream" {
+		t.Errorf("RepoUpstream(mono) = %q, want upstream", got)
+	}
+
+	for _, bad := range []string{"    upstream: up/stream\n", "    upstream: -x\n", "    upstream: upstream\n    fork: auto\n"} {
+		write(bad)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() should reject %q", bad)
+		}
+	}
+}
+
+func TestHooksValidation(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	write := func(hooks string) {
+		os.WriteFile(filepath.Join(zenDir, "config.yaml"), []byte("repos:\n  mono:\n    full_name: o/mono\n    base_path: /tmp\nhooks:\n"+hooks), 0o644)
+	}
+
+	write("  - url: https://example.com/zen\n    events: [pr_merged]\n  - command: ./notify.sh\n    timeout: 30s\n" +
+		"  - url: secret://keychain/slack-webhook\n    headers: {Authorization: env://ZEN_HOOK_TOKEN}\n")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() with valid hooks: %v", err)
+	}
+	if !cfg.Hooks[0].Handles("pr_merged") || cfg.Hooks[0].Handles("worktree_ready") {
+		t.Error("hooks[0] should handle only pr_merged")
+	}
+	if !cfg.Hooks[1].Handles("setup_failed") || cfg.Hooks[1].TimeoutDuration() != 30*time.Second {
+		t.Errorf("hooks[1] = %+v; want all events and a 30s timeout", cfg.Hooks[1])
+	}
+
+	for _, bad := range []string{
+		"  - events: [pr_merged]\n",
+		"  - url: https://example.com\n    command: ./x.sh\n",
+		"  - url: ftp://example.com\n",
+		"  - command: ./x.sh\n    events: [pr_opened]\n",
+		"  - command: ./x.sh\n    timeout: soon\n",
+		"  - url: secret://vault/hook\n",
+		"  - url: env://not a name\n",
+		"  - command: ./x.sh\n    env: {TOKEN: secret://keychain/}\n",
+		"  - command: ./x.sh\n    headers: {Authorization: token}\n",
+	} {
+		write(bad)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() should reject hooks %q", bad)
+		}
+	}
+}
+
+func TestColumnsValidation(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	write := func(columns string) {
+		os.WriteFile(filepath.Join(zenDir, "config.yaml"), []byte("repos:\n  mono:\n    full_name: o/mono\n    base_path: /tmp\ncolumns:\n"+columns), 0o644)
+	}
+
+	write("  inbox: [pr, title, link]\n  reviews: [pr, title]\n")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := cfg.Columns["inbox"]; len(got) != 3 || got[1] != "title" {
+		t.Errorf("Columns[inbox] = %v, want [pr title link]", got)
+	}
+
+	for _, bad := range []string{"  inbox: [pr, bogus]\n", "  dashboard: [pr]\n", "  reviews: [team]\n"} {
+		write(bad)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() should reject columns %q", bad)
+		}
+	}
+}
+
+func TestRepoClaude(t *testing.T) {
+	cfg := &Config{
+		Claude: ClaudeLaunch{
+			Model:          "sonnet",
+			PermissionMode: "acceptEdits",
+			Args:           []string{"--verbose"},
+			Env:            map[string]string{"A": "1", "B": "global"},
+		},
+		Repos: map[string]RepoConfig{
+			"mono": {Claude: ClaudeLaunch{
+				PermissionMode: "plan",
+				Args:           []string{"--add-dir", "{worktree}/../shared"},
+				Env:            map[string]string{"B": "repo", "CLAUDE_PROJECT_DIR": "{worktree}"},
+			}},
+			"os": {},
+		},
+	}
+
+	l := cfg.RepoClaude("mono")
+	if l.Model != "sonnet" || l.PermissionMode != "plan" {
+		t.Errorf("RepoClaude(mono) model, mode = %q, %q; want sonnet, plan", l.Model, l.PermissionMode)
+	}
+	got := l.Command("claude", "/src/mono pr")
+	want := "env A=1 B=repo CLAUDE_PROJECT_DIR='/src/mono pr' claude --permission-mode plan --verbose --add-dir '/src/mono pr/../shared'"
+	if got != want {
+		t.Errorf("Command() =\n  %s\nwant\n  %s", got, want)
+	}
+	if cfg.Claude.Env["B"] != "global" || len(cfg.Claude.Args) != 1 {
+		t.Errorf("RepoClaude modified the global options: %+v", cfg.Claude)
+	}
+
+	if got := cfg.RepoClaude("os").Command("claude", "/src/os"); got != "env A=1 B=global claude --permission-mode acceptEdits --verbose" {
+		t.Errorf("RepoClaude(os).Command() = %q", got)
+	}
+	if got := (&Config{}).RepoClaude("mono").Command("claude", "/x"); got != "claude" {
+		t.Errorf("Command() without options = %q, want claude", got)
+	}
+}
+
+func TestLoadRejectsBadClaudeEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	path := filepath.Join(tmpDir, "config.yaml")
+	os.WriteFile(path, []byte("claude:\n  env:\n    \"BAD NAME\": x\n"), 0o644)
+
+	if _, err := LoadFile(path); err == nil {
+		t.Error("LoadFile() accepted an invalid env var name")
+	}
+}
+
+func TestInboxSections(t *testing.T) {
+	var def InboxConfig
+	if !def.Shows("review_requests") || def.Shows("issues") {
+		t.Error("default inbox should show PR sections but not issues")
+	}
+	if def.GetThreadDays() != 14 {
+		t.Errorf("GetThreadDays() = %d, want 14", def.GetThreadDays())
+	}
+
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	path := filepath.Join(tmpDir, "config.yaml")
+	os.WriteFile(path, []byte("inbox:\n  sections: [review_requests, issues]\n"), 0o644)
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Inbox.Shows("issues") || cfg.Inbox.Shows("approved_unmerged") {
+		t.Errorf("Shows() with sections %v is wrong", cfg.Inbox.Sections)
+	}
+
+	os.WriteFile(path, []byte("inbox:\n  sections: [prs]\n"), 0o644)
+	if _, err := LoadFile(path); err == nil {
+		t.Error("LoadFile() accepted an unknown inbox section")
+	}
+}
+
+func TestStatusLayout(t *testing.T) {
+	var def StatusConfig
+	if def.GetGroupBy() != "type" || def.GetSort() != "repo" {
+		t.Errorf("defaults = %q, %q; want type, repo", def.GetGroupBy(), def.GetSort())
+	}
+
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	path := filepath.Join(tmpDir, "config.yaml")
+	os.WriteFile(path, []byte("status:\n  group_by: state\n  sort: age\n"), 0o644)
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Status.GetGroupBy() != "state" || cfg.Status.GetSort() != "age" {
+		t.Errorf("status = %+v", cfg.Status)
+	}
+
+	for _, bad := range []string{"status:\n  group_by: owner\n", "status:\n  sort: size\n"} {
+		os.WriteFile(path, []byte(bad), 0o644)
+		if _, err := LoadFile(path); err == nil {
+			t.Errorf("LoadFile() accepted %q", bad)
+		}
+	}
 }
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Zen keeps its config and state in one of three layouts, checked in order:
+//
+//   - $ZEN_HOME, with state in $ZEN_HOME/state, for non-standard setups
+//   - ~/.zen, with state in ~/.zen/state, when that directory exists
+//   - the XDG base directories, $XDG_CONFIG_HOME/zen (default ~/.config/zen)
+//     and $XDG_STATE_HOME/zen (default ~/.local/state/zen), when either
+//     variable is set or a config already lives there
+//
+// Otherwise zen falls back to ~/.zen. `zen setup xdg` moves an existing
+// ~/.zen into the XDG layout.
+
+// LegacyDir returns ~/.zen.
+func LegacyDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".zen")
+}
+
+// XDGDirs returns zen's config and state directories under the XDG base
+// directories, whether or not they are in use.
+func XDGDirs() (configDir, stateDir string) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(os.Getenv("HOME"), ".config")
+	}
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		stateHome = filepath.Join(os.Getenv("HOME"), ".local", "state")
+	}
+	return filepath.Join(configHome, "zen"), filepath.Join(stateHome, "zen")
+}
+
+// UsingXDG reports whether config and state live in the XDG directories.
+func UsingXDG() bool {
+	if os.Getenv("ZEN_HOME") != "" {
+		return false
+	}
+	if _, err := os.Stat(LegacyDir()); err == nil {
+		return false
+	}
+	if os.Getenv("XDG_CONFIG_HOME") != "" || os.Getenv("XDG_STATE_HOME") != "" {
+		return true
+	}
+	configDir, _ := XDGDirs()
+	_, err := os.Stat(filepath.Join(configDir, "config.yaml"))
+	return err == nil
+}
+
+// zenHome returns the directory holding config.yaml.
+func zenHome() string {
+	if home := os.Getenv("ZEN_HOME"); home != "" {
+		return home
+	}
+	if UsingXDG() {
+		configDir, _ := XDGDirs()
+		return configDir
+	}
+	return LegacyDir()
+}
+
+// StateDir returns the path to the zen state directory.
+func StateDir() string {
+	if home := os.Getenv("ZEN_HOME"); home != "" {
+		return filepath.Join(home, "state")
+	}
+	if UsingXDG() {
+		_, stateDir := XDGDirs()
+		return stateDir
+	}
+	return filepath.Join(LegacyDir(), "state")
+}
+
+// MigrateToXDG moves ~/.zen into the XDG layout: state/ becomes the XDG
+// state directory and everything else (config.yaml, etc.) goes to the XDG
+// config directory. ~/.zen is removed once empty. Returns the config and
+// state directories the files were moved to.
+func MigrateToXDG() (configDir, stateDir string, err error) {
+	if os.Getenv("ZEN_HOME") != "" {
+		return "", "", fmt.Errorf("ZEN_HOME is set -- unset it to use the XDG directories")
+	}
+	legacy := LegacyDir()
+	entries, err := os.ReadDir(legacy)
+	if err != nil {
+		return "", "", fmt.Errorf("nothing to migrate: %w", err)
+	}
+
+	configDir, stateDir = XDGDirs()
+	if _, err := os.Stat(filepath.Join(configDir, "config.yaml")); err == nil {
+		return "", "", fmt.Errorf("%s already exists -- move or remove it first", filepath.Join(configDir, "config.yaml"))
+	}
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		return "", "", fmt.Errorf("creating %s: %w", configDir, err)
+	}
+
+	for _, e := range entries {
+		src := filepath.Join(legacy, e.Name())
+		dst := filepath.Join(configDir, e.Name())
+		if e.Name() == "state" {
+			dst = stateDir
+		}
+		if err := moveInto(src, dst); err != nil {
+			return "", "", err
+		}
+	}
+
+	if err := os.Remove(legacy); err != nil {
+		return "", "", fmt.Errorf("removing %s: %w", legacy, err)
+	}
+	return configDir, stateDir, nil
+}
+
+// moveInto renames src to dst. When dst is an existing directory, the
+// entries of src are moved into it one by one instead.
+func moveInto(src, dst string) error {
+	info, err := os.Stat(dst)
+	if os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return fmt.Errorf("creating %s: %w", filepath.Dir(dst), err)
+		}
+		if err := os.Rename(src, dst); err != nil {
+			return fmt.Errorf("moving %s: %w", src, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s already exists", dst)
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", src, err)
+	}
+	for _, e := range entries {
+		if err := moveInto(filepath.Join(src, e.Name()), filepath.Join(dst, e.Name())); err != nil {
+			return err
+		}
+	}
+	return os.Remove(src)
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// isolateDirs points HOME at a temp dir and clears the variables that
+// select zen's directory layout.
+func isolateDirs(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ZEN_HOME", "")
+	t.Setenv("ZEN_CONFIG", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "")
+	return home
+}
+
+func TestDirLayouts(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		home := isolateDirs(t)
+		if got, want := Path(), filepath.Join(home, ".zen", "config.yaml"); got != want {
+			t.Errorf("Path() = %q, want %q", got, want)
+		}
+		if got, want := StateDir(), filepath.Join(home, ".zen", "state"); got != want {
+			t.Errorf("StateDir() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("ZEN_HOME", func(t *testing.T) {
+		home := isolateDirs(t)
+		custom := filepath.Join(home, "custom")
+		t.Setenv("ZEN_HOME", custom)
+		t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+		if got, want := Path(), filepath.Join(custom, "config.yaml"); got != want {
+			t.Errorf("Path() = %q, want %q", got, want)
+		}
+		if got, want := StateDir(), filepath.Join(custom, "state"); got != want {
+			t.Errorf("StateDir() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("XDG variables", func(t *testing.T) {
+		home := isolateDirs(t)
+		t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "cfg"))
+		if got, want := Path(), filepath.Join(home, "cfg", "zen", "config.yaml"); got != want {
+			t.Errorf("Path() = %q, want %q", got, want)
+		}
+		// XDG_STATE_HOME unset falls back to the XDG default
+		if got, want := StateDir(), filepath.Join(home, ".local", "state", "zen"); got != want {
+			t.Errorf("StateDir() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("legacy dir wins over XDG variables", func(t *testing.T) {
+		home := isolateDirs(t)
+		os.MkdirAll(filepath.Join(home, ".zen"), 0o755)
+		t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "cfg"))
+		if got, want := Path(), filepath.Join(home, ".zen", "config.yaml"); got != want {
+			t.Errorf("Path() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("existing XDG config without variables", func(t *testing.T) {
+		home := isolateDirs(t)
+		os.MkdirAll(filepath.Join(home, ".config", "zen"), 0o755)
+		os.WriteFile(filepath.Join(home, ".config", "zen", "config.yaml"), nil, 0o644)
+		if got, want := Path(), filepath.Join(home, ".config", "zen", "config.yaml"); got != want {
+			t.Errorf("Path() = %q, want %q", got, want)
+		}
+		if !UsingXDG() {
+			t.Error("UsingXDG() = false, want true")
+		}
+	})
+}
+
+func TestMigrateToXDG(t *testing.T) {
+	home := isolateDirs(t)
+	legacy := filepath.Join(home, ".zen")
+	os.MkdirAll(filepath.Join(legacy, "state"), 0o755)
+	os.WriteFile(filepath.Join(legacy, "config.yaml"), []byte("authors: [alice]\n"), 0o644)
+	os.WriteFile(filepath.Join(legacy, "state", "pr_cache.json"), []byte("{}"), 0o644)
+
+	// The XDG state dir already exists; its contents must be kept
+	os.MkdirAll(filepath.Join(home, ".local", "state", "zen"), 0o755)
+	os.WriteFile(filepath.Join(home, ".local", "state", "zen", "other"), nil, 0o644)
+
+	configDir, stateDir, err := MigrateToXDG()
+	if err != nil {
+		t.Fatalf("MigrateToXDG: %v", err)
+	}
+	for _, p := range []string{
+		filepath.Join(configDir, "config.yaml"),
+		filepath.Join(stateDir, "pr_cache.json"),
+		filepath.Join(stateDir, "other"),
+	} {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("%s missing after migration", p)
+		}
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("~/.zen still exists after migration")
+	}
+
+	// Subsequent lookups find the migrated files
+	if got := Path(); got != filepath.Join(configDir, "config.yaml") {
+		t.Errorf("Path() after migration = %q", got)
+	}
+	cfg, err := Load()
+	if err != nil || len(cfg.Authors) != 1 {
+		t.Errorf("Load() after migration = %+v, %v", cfg, err)
+	}
+
+	if _, _, err := MigrateToXDG(); err == nil {
+		t.Error("second MigrateToXDG should fail: nothing to migrate")
+	}
+}
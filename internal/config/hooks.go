@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mgreau/zen/internal/secrets"
+)
+
+// HookEvents are the daemon events accepted in hooks[].events.
+var HookEvents = []string{"worktree_ready", "setup_failed", "pr_merged"}
+
+// Hook relays daemon events to an external automation: each event is
+// POSTed as JSON to URL, or Command is run with sh -c, the event in ZEN_*
+// environment variables and as JSON on stdin.
+//
+// URL and the values of Headers and Env may be secret references such as
+// env://SLACK_WEBHOOK or secret://keychain/slack-webhook, resolved at
+// delivery time (see package secrets).
+type Hook struct {
+	Events  []string          `yaml:"events"`  // events in HookEvents to relay; default all
+	URL     string            `yaml:"url"`     // http(s) URL the event is POSTed to
+	Headers map[string]string `yaml:"headers"` // extra request headers for URL, e.g. Authorization
+	Command string            `yaml:"command"` // shell command run for each event
+	Env     map[string]string `yaml:"env"`     // extra environment variables for Command
+	Timeout string            `yaml:"timeout"` // max duration of one delivery, default "10s"
+}
+
+// Handles reports whether the hook relays event.
+func (h Hook) Handles(event string) bool {
+	return len(h.Events) == 0 || slices.Contains(h.Events, event)
+}
+
+// TimeoutDuration returns Timeout with a default of 10 seconds.
+func (h Hook) TimeoutDuration() time.Duration {
+	if d, err := time.ParseDuration(h.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return 10 * time.Second
+}
+
+// Target describes where the hook delivers, for logs.
+func (h Hook) Target() string {
+	if h.URL != "" {
+		return h.URL
+	}
+	return h.Command
+}
+
+func validateHooks(hooks []Hook) error {
+	for i, h := range hooks {
+		name := fmt.Sprintf("hooks[%d]", i)
+		if (h.URL == "") == (h.Command == "") {
+			return fmt.Errorf("%s: set exactly one of url and command", name)
+		}
+		if secrets.IsRef(h.URL) {
+			if err := secrets.Validate(h.URL); err != nil {
+				return fmt.Errorf("%s: url: %w", name, err)
+			}
+		} else if h.URL != "" {
+			if u, err := url.Parse(h.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("%s: invalid url %q: must be an http(s) URL", name, h.URL)
+			}
+		}
+		if len(h.Headers) > 0 && h.URL == "" {
+			return fmt.Errorf("%s: headers only apply to url hooks", name)
+		}
+		if len(h.Env) > 0 && h.Command == "" {
+			return fmt.Errorf("%s: env only applies to command hooks", name)
+		}
+		for _, field := range []struct {
+			key  string
+			vals map[string]string
+		}{{"headers", h.Headers}, {"env", h.Env}} {
+			for k, v := range field.vals {
+				if err := secrets.Validate(v); err != nil {
+					return fmt.Errorf("%s: %s.%s: %w", name, field.key, k, err)
+				}
+			}
+		}
+		for _, e := range h.Events {
+			if !slices.Contains(HookEvents, e) {
+				return fmt.Errorf("%s: invalid event %q: must be one of %s", name, e, strings.Join(HookEvents, ", "))
+			}
+		}
+		if h.Timeout != "" {
+			if d, err := time.ParseDuration(h.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("%s: invalid timeout %q: must be a positive duration such as \"10s\"", name, h.Timeout)
+			}
+		}
+	}
+	return nil
+}
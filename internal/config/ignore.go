@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultIgnoredAuthors are the author patterns used when a rule set does
+// not list its own: dependency and automation bots that open PRs in bulk.
+// GitHub's GraphQL API reports bot logins without the "[bot]" suffix, so
+// both forms are matched.
+var DefaultIgnoredAuthors = []string{
+	`dependabot(\[bot\])?`,
+	`renovate(\[bot\])?`,
+	`github-actions(\[bot\])?`,
+	`.*\[bot\]`,
+}
+
+// WatchIgnore holds the daemon's PR exclusion rules. Notifications and
+// worktree auto-setup are filtered separately, so bot PRs can still get a
+// worktree without a notification, or the other way around.
+type WatchIgnore struct {
+	Notify IgnoreRules `yaml:"notify"`
+	Setup  IgnoreRules `yaml:"setup"`
+}
+
+// IgnoreRules excludes PRs by author login or title. Patterns are regular
+// expressions; author patterns must match the whole login, title patterns
+// any part of the title. Leaving Authors unset uses DefaultIgnoredAuthors,
+// an empty list ignores no authors.
+type IgnoreRules struct {
+	Authors []string `yaml:"authors"`
+	Titles  []string `yaml:"titles"`
+}
+
+// AuthorPatterns returns the configured author patterns, or the defaults
+// when none are set.
+func (r IgnoreRules) AuthorPatterns() []string {
+	if r.Authors == nil {
+		return DefaultIgnoredAuthors
+	}
+	return r.Authors
+}
+
+// Match reports whether a PR by author with the given title is ignored,
+// and the pattern that matched. Invalid patterns are skipped; Load rejects
+// them up front.
+func (r IgnoreRules) Match(author, title string) (string, bool) {
+	for _, p := range r.AuthorPatterns() {
+		if re, err := regexp.Compile(`^(?:` + p + `)$`); err == nil && re.MatchString(author) {
+			return "author " + p, true
+		}
+	}
+	for _, p := range r.Titles {
+		if re, err := regexp.Compile(p); err == nil && re.MatchString(title) {
+			return "title " + p, true
+		}
+	}
+	return "", false
+}
+
+func (r IgnoreRules) validate(name string) error {
+	for _, p := range r.Authors {
+		if _, err := regexp.Compile(`^(?:` + p + `)$`); err != nil {
+			return fmt.Errorf("watch.ignore.%s: invalid author pattern %q: %w", name, p, err)
+		}
+	}
+	for _, p := range r.Titles {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("watch.ignore.%s: invalid title pattern %q: %w", name, p, err)
+		}
+	}
+	return nil
+}
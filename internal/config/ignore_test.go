@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIgnoreRulesMatch(t *testing.T) {
+	defaults := IgnoreRules{}
+	custom := IgnoreRules{
+		Authors: []string{"ci-.*"},
+		Titles:  []string{`^chore\(deps\)`, "(?i)bump "},
+	}
+	none := IgnoreRules{Authors: []string{}}
+
+	tests := []struct {
+		name   string
+		rules  IgnoreRules
+		author string
+		title  string
+		want   bool
+	}{
+		{"default dependabot", defaults, "dependabot", "Bump x from 1 to 2", true},
+		{"default dependabot[bot]", defaults, "dependabot[bot]", "Bump x", true},
+		{"default renovate", defaults, "renovate", "Update module y", true},
+		{"default any bot", defaults, "octo-sts[bot]", "Sync", true},
+		{"default human", defaults, "alice", "Fix auth", false},
+		{"default partial login", defaults, "not-dependabot-fan", "Fix", false},
+		{"custom author", custom, "ci-robot", "Fix", true},
+		{"custom author replaces defaults", custom, "dependabot", "Fix", false},
+		{"custom title", custom, "alice", "chore(deps): update go", true},
+		{"custom title case-insensitive", custom, "alice", "Deps: BUMP golang", true},
+		{"custom no match", custom, "alice", "feat: add flag", false},
+		{"empty authors ignores nobody", none, "dependabot", "Bump x", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, got := tt.rules.Match(tt.author, tt.title)
+			if got != tt.want {
+				t.Errorf("Match(%q, %q) = %v (%q), want %v", tt.author, tt.title, got, pattern, tt.want)
+			}
+			if got && pattern == "" {
+				t.Error("Match() returned no pattern for an ignored PR")
+			}
+		})
+	}
+}
+
+func TestLoadWatchIgnore(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	zenDir := filepath.Join(tmpDir, ".zen")
+	os.MkdirAll(zenDir, 0o755)
+	cfgPath := filepath.Join(zenDir, "config.yaml")
+
+	os.WriteFile(cfgPath, []byte(`watch:
+  ignore:
+    notify:
+      titles: ["^chore"]
+    setup:
+      authors: []
+`), 0o644)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if _, ignored := cfg.Watch.Ignore.Notify.Match("dependabot", "Bump"); !ignored {
+		t.Error("notify rules without authors should keep the default bot patterns")
+	}
+	if _, ignored := cfg.Watch.Ignore.Setup.Match("dependabot", "Bump"); ignored {
+		t.Error("setup rules with an empty author list should ignore no authors")
+	}
+
+	os.WriteFile(cfgPath, []byte("watch:\n  ignore:\n    setup:\n      titles: [\"(unclosed\"]\n"), 0o644)
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject an invalid title pattern")
+	}
+}
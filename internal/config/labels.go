@@ -0,0 +1,45 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// LabelRules maps PR labels to worktree lifecycle behavior. Each list
+// left unset uses its default label; an empty list turns the behavior off.
+// Labels match case-insensitively.
+type LabelRules struct {
+	// Hold keeps a PR's worktrees through cleanup, even once merged.
+	// Default: "hold".
+	Hold []string `yaml:"hold"`
+	// Urgent moves a PR's worktree setup ahead of the daemon's queue.
+	// Default: "urgent".
+	Urgent []string `yaml:"urgent"`
+	// NoAIReview opens review sessions without the review prompt.
+	// Default: "no-ai-review".
+	NoAIReview []string `yaml:"no_ai_review"`
+}
+
+// IsHold reports whether labels include a hold label.
+func (r LabelRules) IsHold(labels []string) bool {
+	return matchLabel(r.Hold, "hold", labels)
+}
+
+// IsUrgent reports whether labels include an urgent label.
+func (r LabelRules) IsUrgent(labels []string) bool {
+	return matchLabel(r.Urgent, "urgent", labels)
+}
+
+// SkipsAIReview reports whether labels include a no-ai-review label.
+func (r LabelRules) SkipsAIReview(labels []string) bool {
+	return matchLabel(r.NoAIReview, "no-ai-review", labels)
+}
+
+func matchLabel(rule []string, def string, labels []string) bool {
+	if rule == nil {
+		rule = []string{def}
+	}
+	return slices.ContainsFunc(labels, func(l string) bool {
+		return slices.ContainsFunc(rule, func(r string) bool { return strings.EqualFold(r, l) })
+	})
+}
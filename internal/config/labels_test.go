@@ -0,0 +1,32 @@
+package config
+
+import "testing"
+
+func TestLabelRules(t *testing.T) {
+	defaults := LabelRules{}
+	custom := LabelRules{Hold: []string{"do-not-merge", "WIP"}, Urgent: []string{}}
+
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"default hold", defaults.IsHold([]string{"bug", "hold"}), true},
+		{"default hold case-insensitive", defaults.IsHold([]string{"Hold"}), true},
+		{"default hold no match", defaults.IsHold([]string{"holding"}), false},
+		{"default urgent", defaults.IsUrgent([]string{"urgent"}), true},
+		{"default no-ai-review", defaults.SkipsAIReview([]string{"no-ai-review"}), true},
+		{"no labels", defaults.IsHold(nil), false},
+		{"custom hold", custom.IsHold([]string{"wip"}), true},
+		{"custom hold replaces default", custom.IsHold([]string{"hold"}), false},
+		{"empty list disables", custom.IsUrgent([]string{"urgent"}), false},
+		{"unset keeps default", custom.SkipsAIReview([]string{"no-ai-review"}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
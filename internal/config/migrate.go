@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mgreau/zen/internal/state"
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion is the config_version of the config schema this zen
+// reads. Each schema change that old files need upgrading for bumps it and
+// adds a step to migrations.
+const CurrentVersion = 1
+
+// migrations upgrade a config file one version at a time, migrations[i]
+// from version i to i+1. Each edits the YAML document in place, so
+// comments are kept, and describes the changes it made. Writing the
+// document back normalizes its formatting: blank lines are dropped and
+// indentation becomes two spaces.
+var migrations = []func(root *yaml.Node) []string{
+	migrateV1,
+}
+
+// migrateV1 upgrades unversioned configs: terminal names such as
+// "iTerm2" or "Terminal.app", which zen rejects, are normalized to the
+// values terminal accepts.
+func migrateV1(root *yaml.Node) []string {
+	term := mappingValue(root, "terminal")
+	if term == nil || term.Kind != yaml.ScalarNode {
+		return nil
+	}
+	aliases := map[string]string{
+		"iterm2": "iterm", "iterm.app": "iterm",
+		"terminal.app": "terminal", "apple_terminal": "terminal",
+	}
+	name := strings.ToLower(term.Value)
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	if name == term.Value {
+		return nil
+	}
+	change := fmt.Sprintf("terminal: %s -> %s", term.Value, name)
+	term.Value = name
+	return []string{change}
+}
+
+// mappingValue returns the value of key in the mapping m, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// FileVersion returns the config_version of the config file at path, 0 for
+// files written before it existed.
+func FileVersion(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return dataVersion(data)
+}
+
+func dataVersion(data []byte) (int, error) {
+	var v struct {
+		ConfigVersion int `yaml:"config_version"`
+	}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return 0, err
+	}
+	return v.ConfigVersion, nil
+}
+
+// Migration is the outcome of MigrateFile.
+type Migration struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+	// Changes are what the migration steps changed, besides setting
+	// config_version.
+	Changes []string `json:"changes"`
+	Backup  string   `json:"backup,omitempty"` // copy of the file before migrating
+}
+
+// NeedsMigration reports whether upgrading the config file at path would
+// change more than its config_version. An older config that loads as is
+// needs no upgrade, so zen doesn't ask to rewrite it.
+func NeedsMigration(path string) bool {
+	m, err := MigrateFile(path, true)
+	return err == nil && len(m.Changes) > 0
+}
+
+// MigrateFile upgrades the config file at path to CurrentVersion, keeping
+// comments but not blank lines, after copying it to <path>.v<N>.bak. The upgraded file must
+// load, otherwise it is left as is. With dryRun nothing is written. A file
+// already at CurrentVersion is left alone; one from a newer zen is an
+// error.
+func MigrateFile(path string, dryRun bool) (*Migration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config file not found: %s\nRun 'zen setup' to create it", path)
+	}
+	from, err := dataVersion(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	m := &Migration{From: from, To: CurrentVersion}
+	switch {
+	case from > CurrentVersion:
+		return nil, fmt.Errorf("%s has config_version %d, newer than this zen supports (%d): upgrade zen", path, from, CurrentVersion)
+	case from == CurrentVersion:
+		return m, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parsing %s: top level is not a mapping", path)
+	}
+	for v := from; v < CurrentVersion; v++ {
+		m.Changes = append(m.Changes, migrations[v](root)...)
+	}
+
+	// config_version goes first, where it's easy to spot
+	version := strconv.Itoa(CurrentVersion)
+	if n := mappingValue(root, "config_version"); n != nil {
+		n.Value, n.Tag, n.Style = version, "!!int", 0
+	} else {
+		root.Content = append([]*yaml.Node{scalarNode("config_version"), {Kind: yaml.ScalarNode, Tag: "!!int", Value: version}}, root.Content...)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("encoding %s: %w", path, err)
+	}
+	enc.Close()
+	if _, err := parse(buf.Bytes(), path); err != nil {
+		return nil, fmt.Errorf("the migrated config would not load, fix it first: %w", err)
+	}
+	if dryRun {
+		return m, nil
+	}
+
+	perm := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	m.Backup = fmt.Sprintf("%s.v%d.bak", path, from)
+	if err := state.WriteFile(m.Backup, data, perm); err != nil {
+		return nil, fmt.Errorf("backing up %s: %w", path, err)
+	}
+	if err := state.WriteFile(path, buf.Bytes(), perm); err != nil {
+		return nil, fmt.Errorf("writing %s: %w", path, err)
+	}
+	return m, nil
+}
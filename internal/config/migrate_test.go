@@ -0,0 +1,90 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrateFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	old := `# my zen config
+terminal: iTerm2 # the one I use
+repos:
+  mono:
+    full_name: acme/mono
+    base_path: /src
+`
+	os.WriteFile(path, []byte(old), 0o600)
+	if !NeedsMigration(path) {
+		t.Error("NeedsMigration() of a config with a terminal alias = false")
+	}
+
+	if _, err := LoadFile(path); err == nil || !strings.Contains(err.Error(), "zen config migrate") {
+		t.Fatalf("LoadFile() of an outdated, invalid config = %v, want a hint to migrate", err)
+	}
+
+	m, err := MigrateFile(path, true)
+	if err != nil {
+		t.Fatalf("MigrateFile(dry run) error: %v", err)
+	}
+	if m.From != 0 || m.To != CurrentVersion || len(m.Changes) != 1 || m.Changes[0] != "terminal: iTerm2 -> iterm" {
+		t.Errorf("MigrateFile(dry run) = %+v", m)
+	}
+	if data, _ := os.ReadFile(path); string(data) != old {
+		t.Errorf("dry run changed the file:\n%s", data)
+	}
+
+	if m, err = MigrateFile(path, false); err != nil {
+		t.Fatalf("MigrateFile() error: %v", err)
+	}
+	if backup, _ := os.ReadFile(m.Backup); string(backup) != old {
+		t.Errorf("backup %s = %q, want the original", m.Backup, backup)
+	}
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{"config_version: 1\n", "# my zen config", "terminal: iterm # the one I use"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("migrated config lacks %q:\n%s", want, data)
+		}
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("migrated config mode = %v, want 0600", info.Mode().Perm())
+	}
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() after migrating: %v", err)
+	}
+	if cfg.Outdated() || cfg.Terminal != "iterm" {
+		t.Errorf("migrated config: version %d, terminal %q", cfg.ConfigVersion, cfg.Terminal)
+	}
+
+	// Nothing left to do
+	if m, err = MigrateFile(path, false); err != nil || len(m.Changes) != 0 || m.Backup != "" {
+		t.Errorf("MigrateFile() of a current config = %+v, %v", m, err)
+	}
+}
+
+func TestMigrateFile_newer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("config_version: 99\n"), 0o644)
+	if _, err := MigrateFile(path, false); err == nil {
+		t.Error("MigrateFile() of a config from a newer zen should fail")
+	}
+}
+
+func TestNeedsMigration_unversioned(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("terminal: iterm\nrepos:\n  mono:\n    full_name: acme/mono\n    base_path: /src\n"), 0o644)
+	if NeedsMigration(path) {
+		t.Error("NeedsMigration() of an unversioned config the upgrade doesn't change = true")
+	}
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Outdated() {
+		t.Error("Outdated() of an unversioned config = false")
+	}
+}
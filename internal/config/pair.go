@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// PairLayouts are the values accepted in pair.layout.
+var PairLayouts = []string{"side-by-side", "stacked", "tabs"}
+
+// PairConfig controls --pair, which opens an editor on the worktree next
+// to the Claude session.
+type PairConfig struct {
+	// Editor is the command opening the editor, run in the worktree with
+	// "." as its argument, e.g. "nvim" or "code". Default: $VISUAL, then
+	// $EDITOR, then "code".
+	Editor string `yaml:"editor"`
+	// Layout arranges a terminal editor and the session: in split panes
+	// side by side (default) or stacked, where the terminal supports
+	// splits, or in two tabs. GUI editors open in their own window.
+	Layout string `yaml:"layout"`
+}
+
+// GetEditor returns the editor command, falling back to $VISUAL, $EDITOR
+// and "code".
+func (p PairConfig) GetEditor() string {
+	for _, e := range []string{p.Editor, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if strings.TrimSpace(e) != "" {
+			return e
+		}
+	}
+	return "code"
+}
+
+// GetLayout returns Layout with a default of "side-by-side".
+func (p PairConfig) GetLayout() string {
+	if p.Layout == "" {
+		return "side-by-side"
+	}
+	return p.Layout
+}
+
+func (p PairConfig) validate() error {
+	if p.Layout != "" && !slices.Contains(PairLayouts, p.Layout) {
+		return fmt.Errorf("invalid pair.layout %q: must be one of %s", p.Layout, strings.Join(PairLayouts, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPairEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := (PairConfig{}).GetEditor(); got != "code" {
+		t.Errorf("GetEditor() = %q, want code", got)
+	}
+	t.Setenv("EDITOR", "vim")
+	if got := (PairConfig{}).GetEditor(); got != "vim" {
+		t.Errorf("GetEditor() = %q, want $EDITOR", got)
+	}
+	t.Setenv("VISUAL", "nvim")
+	if got := (PairConfig{}).GetEditor(); got != "nvim" {
+		t.Errorf("GetEditor() = %q, want $VISUAL over $EDITOR", got)
+	}
+	if got := (PairConfig{Editor: "zed"}).GetEditor(); got != "zed" {
+		t.Errorf("GetEditor() = %q, want the configured editor", got)
+	}
+}
+
+func TestPairLayout(t *testing.T) {
+	if got := (PairConfig{}).GetLayout(); got != "side-by-side" {
+		t.Errorf("GetLayout() = %q, want side-by-side", got)
+	}
+
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	path := filepath.Join(tmpDir, "config.yaml")
+	os.WriteFile(path, []byte("pair:\n  layout: diagonal\n"), 0o644)
+	if _, err := LoadFile(path); err == nil {
+		t.Error("LoadFile() accepted an unknown pair.layout")
+	}
+	os.WriteFile(path, []byte("pair:\n  editor: nvim\n  layout: stacked\n"), 0o644)
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	if cfg.Pair.GetLayout() != "stacked" || cfg.Pair.GetEditor() != "nvim" {
+		t.Errorf("Pair = %+v, want nvim, stacked", cfg.Pair)
+	}
+}
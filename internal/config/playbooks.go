@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Playbook is a kind of review, e.g. a security review: instructions
+// added to the review worktree's CLAUDE.local.md and the initial prompt
+// of its sessions.
+type Playbook struct {
+	// Instructions are added to CLAUDE.local.md under "Review Playbook".
+	Instructions string `yaml:"instructions"`
+	// Prompt replaces review_prompt, with the same template variables.
+	// Empty keeps review_prompt.
+	Prompt string `yaml:"prompt"`
+	// Labels select the playbook for PRs carrying one of them, when
+	// zen review is not given --playbook. Case-insensitive.
+	Labels []string `yaml:"labels"`
+}
+
+// DefaultPlaybooks are available without configuration. A playbooks:
+// entry of the same name replaces them field by field, so setting only
+// labels: keeps the built-in instructions and prompt.
+var DefaultPlaybooks = map[string]Playbook{
+	"security": {
+		Instructions: `This is a security review. Look past correctness and style for:
+
+- Injection: SQL, shell commands, templates, paths built from input
+- Authentication and authorization: missing checks, privilege escalation, IDOR
+- Secrets and sensitive data in code, logs, errors or responses
+- Input validation and parsing of untrusted data, including deserialization
+- Cryptography: weak algorithms, hand-rolled crypto, predictable randomness
+- New dependencies and their known vulnerabilities
+
+Rate each finding by severity and explain how it could be exploited.`,
+		Prompt: "/review-pr {{.PR}} as a security review, following the Review Playbook in CLAUDE.local.md",
+	},
+	"perf": {
+		Instructions: `This is a performance review. Look for:
+
+- Work added to hot paths: loops, request handlers, per-item processing
+- N+1 queries, missing indexes, unbounded result sets
+- Allocations and copies that could be avoided, unbounded caches and buffers
+- Blocking calls, lock contention, missing timeouts
+- Algorithmic complexity that grows with input size
+
+Point out what should be benchmarked or profiled before merging.`,
+		Prompt: "/review-pr {{.PR}} as a performance review, following the Review Playbook in CLAUDE.local.md",
+	},
+	"api": {
+		Instructions: `This is an API review. Look at the public surface the PR changes:
+
+- Breaking changes to signatures, endpoints, schemas, flags or config
+- Naming and consistency with the existing API
+- Error semantics: what callers see and can act on
+- Versioning, deprecation and migration path for existing callers
+- Documentation and examples for new or changed behavior
+
+List every breaking change explicitly, even when intended.`,
+		Prompt: "/review-pr {{.PR}} as an API review, following the Review Playbook in CLAUDE.local.md",
+	},
+}
+
+var playbookName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// GetPlaybook returns the named playbook: the configured one, completed
+// by the built-in one of the same name.
+func (c *Config) GetPlaybook(name string) (Playbook, bool) {
+	def, builtin := DefaultPlaybooks[name]
+	p, ok := c.Playbooks[name]
+	if !ok {
+		return def, builtin
+	}
+	if p.Instructions == "" {
+		p.Instructions = def.Instructions
+	}
+	if p.Prompt == "" {
+		p.Prompt = def.Prompt
+	}
+	return p, true
+}
+
+// PlaybookNames returns the names of the built-in and configured
+// playbooks, sorted.
+func (c *Config) PlaybookNames() []string {
+	var names []string
+	for name := range DefaultPlaybooks {
+		names = append(names, name)
+	}
+	for name := range c.Playbooks {
+		if _, ok := DefaultPlaybooks[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PlaybookForLabels returns the first playbook, by name, with a label
+// among labels, or "" when none has.
+func (c *Config) PlaybookForLabels(labels []string) string {
+	for _, name := range c.PlaybookNames() {
+		p, _ := c.GetPlaybook(name)
+		if len(p.Labels) > 0 && matchLabel(p.Labels, "", labels) {
+			return name
+		}
+	}
+	return ""
+}
+
+// validatePlaybooks checks playbook names and prompts, and that custom
+// playbooks have instructions.
+func (c *Config) validatePlaybooks() error {
+	for name := range c.Playbooks {
+		if !playbookName.MatchString(name) {
+			return fmt.Errorf("invalid playbook name %q: use lowercase letters, digits, - and _", name)
+		}
+		p, _ := c.GetPlaybook(name)
+		if strings.TrimSpace(p.Instructions) == "" {
+			return fmt.Errorf("playbook %q: instructions are required", name)
+		}
+		if err := validatePrompt(fmt.Sprintf("playbook %q: prompt", name), p.Prompt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
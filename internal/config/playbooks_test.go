@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPlaybooks(t *testing.T) {
+	cfg := &Config{Playbooks: map[string]Playbook{
+		"security": {Labels: []string{"Security"}},
+		"a11y":     {Instructions: "Check accessibility.", Labels: []string{"ui"}},
+	}}
+
+	if got := cfg.PlaybookNames(); !slices.Equal(got, []string{"a11y", "api", "perf", "security"}) {
+		t.Errorf("PlaybookNames() = %v", got)
+	}
+	p, ok := cfg.GetPlaybook("security")
+	if !ok || p.Instructions != DefaultPlaybooks["security"].Instructions || p.Prompt != DefaultPlaybooks["security"].Prompt {
+		t.Errorf("GetPlaybook(security) = %+v, %v; want the built-in instructions and prompt", p, ok)
+	}
+	if p, ok := cfg.GetPlaybook("a11y"); !ok || p.Prompt != "" {
+		t.Errorf("GetPlaybook(a11y) = %+v, %v", p, ok)
+	}
+	if _, ok := cfg.GetPlaybook("nope"); ok {
+		t.Error("GetPlaybook(nope) found a playbook")
+	}
+
+	for _, tt := range []struct {
+		labels []string
+		want   string
+	}{
+		{[]string{"security"}, "security"},
+		{[]string{"ui", "security"}, "a11y"},
+		{[]string{"perf"}, ""},
+		{nil, ""},
+	} {
+		if got := cfg.PlaybookForLabels(tt.labels); got != tt.want {
+			t.Errorf("PlaybookForLabels(%v) = %q, want %q", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestLoadPlaybooks(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	path := filepath.Join(tmpDir, "config.yaml")
+
+	for _, bad := range []string{
+		"playbooks:\n  custom:\n    labels: [x]\n",
+		"playbooks:\n  Bad Name:\n    instructions: x\n",
+		"playbooks:\n  perf:\n    prompt: \"{{.Nope}}\"\n",
+	} {
+		os.WriteFile(path, []byte(bad), 0o644)
+		if _, err := LoadFile(path); err == nil {
+			t.Errorf("LoadFile() accepted %q", bad)
+		}
+	}
+
+	os.WriteFile(path, []byte("playbooks:\n  perf:\n    labels: [performance]\n  docs:\n    instructions: Check the docs.\n    prompt: \"Review docs of #{{.PR}}\"\n"), 0o644)
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if got := cfg.PlaybookForLabels([]string{"performance"}); got != "perf" {
+		t.Errorf("PlaybookForLabels(performance) = %q, want perf", got)
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultReviewPrompt starts new review sessions when no review_prompt
+// is configured.
+const DefaultReviewPrompt = "/review-pr"
+
+// PromptData holds the variables review_prompt and feature_prompt
+// templates can use, e.g. "/review-pr {{.PR}}". The PR title is left out
+// on purpose: prompts are passed to claude on a shell command line, and
+// titles are written by whoever opens the PR.
+type PromptData struct {
+	Repo     string // short repo name, e.g. "mono"
+	FullName string // owner/name
+	PR       int    // PR number; 0 in feature worktrees
+	Author   string // PR author login, when known
+	Branch   string // branch of a feature worktree
+	Name     string // worktree name
+	Worktree string // worktree path
+}
+
+// RepoReviewPrompt returns the initial prompt template of new review
+// sessions in the repo: its review_prompt, else the global one, else
+// /review-pr.
+func (c *Config) RepoReviewPrompt(short string) string {
+	if p := c.Repos[short].ReviewPrompt; p != "" {
+		return p
+	}
+	if c.ReviewPrompt != "" {
+		return c.ReviewPrompt
+	}
+	return DefaultReviewPrompt
+}
+
+// RepoFeaturePrompt returns the initial prompt template of new sessions
+// in feature worktrees of the repo: its feature_prompt, else the global
+// one. "" starts a plain session.
+func (c *Config) RepoFeaturePrompt(short string) string {
+	if p := c.Repos[short].FeaturePrompt; p != "" {
+		return p
+	}
+	return c.FeaturePrompt
+}
+
+// RenderPrompt expands the template variables of a prompt and trims
+// surrounding whitespace, e.g. the final newline of a YAML block.
+func RenderPrompt(prompt string, data PromptData) (string, error) {
+	if !strings.Contains(prompt, "{{") {
+		return strings.TrimSpace(prompt), nil
+	}
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(prompt)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// validatePrompt checks that a prompt template parses and only uses
+// PromptData fields.
+func validatePrompt(where, prompt string) error {
+	if _, err := RenderPrompt(prompt, PromptData{}); err != nil {
+		return fmt.Errorf("invalid %s: %w", where, err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRepoPrompts(t *testing.T) {
+	cfg := &Config{Repos: map[string]RepoConfig{
+		"mono": {ReviewPrompt: "Review PR #{{.PR}}", FeaturePrompt: "Work on {{.Branch}}"},
+		"docs": {},
+	}}
+	if got := cfg.RepoReviewPrompt("docs"); got != DefaultReviewPrompt {
+		t.Errorf("RepoReviewPrompt(docs) = %q, want %q", got, DefaultReviewPrompt)
+	}
+	if got := cfg.RepoFeaturePrompt("docs"); got != "" {
+		t.Errorf("RepoFeaturePrompt(docs) = %q, want none", got)
+	}
+	if got := cfg.RepoReviewPrompt("mono"); got != "Review PR #{{.PR}}" {
+		t.Errorf("RepoReviewPrompt(mono) = %q, want the repo's prompt", got)
+	}
+
+	cfg.ReviewPrompt = "/code-review"
+	cfg.FeaturePrompt = "/plan"
+	if got := cfg.RepoReviewPrompt("docs"); got != "/code-review" {
+		t.Errorf("RepoReviewPrompt(docs) = %q, want the global prompt", got)
+	}
+	if got := cfg.RepoFeaturePrompt("docs"); got != "/plan" {
+		t.Errorf("RepoFeaturePrompt(docs) = %q, want the global prompt", got)
+	}
+	if got := cfg.RepoFeaturePrompt("mono"); got != "Work on {{.Branch}}" {
+		t.Errorf("RepoFeaturePrompt(mono) = %q, want the repo's prompt over the global one", got)
+	}
+}
+
+func TestRenderPrompt(t *testing.T) {
+	data := PromptData{Repo: "mono", PR: 42, Author: "alice"}
+	tests := []struct {
+		prompt, want string
+	}{
+		{"/review-pr", "/review-pr"},
+		{"/review-pr {{.PR}}", "/review-pr 42"},
+		{"Review #{{.PR}} by @{{.Author}} in {{.Repo}}", "Review #42 by @alice in mono"},
+		{"{{if .Author}}Review @{{.Author}}'s PR{{end}}\n", "Review @alice's PR"},
+	}
+	for _, tt := range tests {
+		got, err := RenderPrompt(tt.prompt, data)
+		if err != nil || got != tt.want {
+			t.Errorf("RenderPrompt(%q) = %q, %v; want %q", tt.prompt, got, err, tt.want)
+		}
+	}
+	if _, err := RenderPrompt("{{.Title}}", data); err == nil {
+		t.Error("RenderPrompt() accepted an unknown variable")
+	}
+}
+
+func TestLoadPrompts(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	path := filepath.Join(tmpDir, "config.yaml")
+
+	for _, bad := range []string{
+		"review_prompt: \"{{.PR\"\n",
+		"feature_prompt: \"{{.Nope}}\"\n",
+		"repos:\n  mono:\n    full_name: o/mono\n    review_prompt: \"{{.Missing}}\"\n",
+	} {
+		os.WriteFile(path, []byte(bad), 0o644)
+		if _, err := LoadFile(path); err == nil {
+			t.Errorf("LoadFile() accepted %q", bad)
+		}
+	}
+
+	os.WriteFile(path, []byte("review_prompt: \"/review-pr {{.PR}}\"\nrepos:\n  mono:\n    full_name: o/mono\n    feature_prompt: \"Plan {{.Branch}}\"\n"), 0o644)
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if got := cfg.RepoFeaturePrompt("mono"); got != "Plan {{.Branch}}" {
+		t.Errorf("RepoFeaturePrompt(mono) = %q", got)
+	}
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/mgreau/zen/internal/state"
+	"gopkg.in/yaml.v3"
+)
+
+// ParseRemoteURL extracts the GitHub owner/repo from a git remote URL in
+// any of the forms git accepts for GitHub: scp-like SSH
+// (git@github.com:owner/repo.git), ssh://, https:// and git:// URLs.
+func ParseRemoteURL(remote string) (string, error) {
+	remote = strings.TrimSpace(remote)
+	var path string
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		path = u.Path
+	} else if host, p, ok := strings.Cut(remote, ":"); ok && host != "" && !strings.Contains(host, "/") {
+		path = p // scp-like: [user@]host:owner/repo
+	} else {
+		return "", fmt.Errorf("unrecognized remote URL %q", remote)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	owner, repo, ok := strings.Cut(path, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", fmt.Errorf("remote URL %q is not an owner/repo path", remote)
+	}
+	return owner + "/" + repo, nil
+}
+
+// AddRepo appends a repo entry with the given full_name and base_path to
+// the config file at Path(), keeping the rest of the file (including
+// comments) as is. Fails if the short name is already configured.
+func AddRepo(short, fullName, basePath string) error {
+	configPath := Path()
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("config file not found: %s\nRun 'zen setup' to create it", configPath)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing %s: %w", configPath, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("parsing %s: top level is not a mapping", configPath)
+	}
+
+	repos := mappingValue(root, "repos")
+	switch {
+	case repos == nil:
+		repos = &yaml.Node{Kind: yaml.MappingNode}
+		root.Content = append(root.Content, scalarNode("repos"), repos)
+	case repos.Kind == yaml.ScalarNode && repos.Tag == "!!null": // "repos:" with no entries
+		*repos = yaml.Node{Kind: yaml.MappingNode}
+	case repos.Kind != yaml.MappingNode:
+		return fmt.Errorf("parsing %s: repos is not a mapping of short names to repos", configPath)
+	}
+	for i := 0; i < len(repos.Content); i += 2 {
+		if repos.Content[i].Value == short {
+			return fmt.Errorf("repo %q is already configured in %s", short, configPath)
+		}
+	}
+
+	entry := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		scalarNode("full_name"), scalarNode(fullName),
+		scalarNode("base_path"), scalarNode(basePath),
+	}}
+	repos.Content = append(repos.Content, scalarNode(short), entry)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("encoding %s: %w", configPath, err)
+	}
+	enc.Close()
+
+	perm := os.FileMode(0o644)
+	if info, err := os.Stat(configPath); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := state.WriteFile(configPath, buf.Bytes(), perm); err != nil {
+		return fmt.Errorf("writing %s: %w", configPath, err)
+	}
+	return nil
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+}
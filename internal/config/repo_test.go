@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		remote  string
+		want    string
+		wantErr bool
+	}{
+		{"git@github.com:chainguard-dev/mono.git", "chainguard-dev/mono", false},
+		{"git@github.com:chainguard-dev/mono", "chainguard-dev/mono", false},
+		{"ssh://git@github.com/wolfi-dev/os.git", "wolfi-dev/os", false},
+		{"https://github.com/wolfi-dev/os.git", "wolfi-dev/os", false},
+		{"https://github.com/wolfi-dev/os/", "wolfi-dev/os", false},
+		{"git://github.com/wolfi-dev/os", "wolfi-dev/os", false},
+		{"github.com:wolfi-dev/os.git\n", "wolfi-dev/os", false},
+		{"/local/path/repo", "", true},
+		{"https://github.com/wolfi-dev", "", true},
+		{"https://example.com/a/b/c", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRemoteURL(tt.remote)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRemoteURL(%q) error = %v, wantErr %v", tt.remote, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRemoteURL(%q) = %q, want %q", tt.remote, got, tt.want)
+		}
+	}
+}
+
+func TestAddRepo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("ZEN_CONFIG", path)
+	os.WriteFile(path, []byte(`# my zen config
+repos:
+  mono:
+    full_name: chainguard-dev/mono
+    base_path: ~/git/mono # main repo
+authors:
+  - alice
+`), 0o644)
+
+	if err := AddRepo("os", "wolfi-dev/os", "~/git/os"); err != nil {
+		t.Fatalf("AddRepo() error: %v", err)
+	}
+	if err := AddRepo("mono", "x/mono", "/tmp"); err == nil {
+		t.Error("AddRepo() should fail for an existing short name")
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{"# my zen config", "# main repo"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("comment %q lost:\n%s", want, data)
+		}
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := cfg.RepoFullName("os"); got != "wolfi-dev/os" {
+		t.Errorf("RepoFullName(os) = %q, want wolfi-dev/os", got)
+	}
+	if got := cfg.RepoFullName("mono"); got != "chainguard-dev/mono" {
+		t.Errorf("RepoFullName(mono) = %q, want chainguard-dev/mono", got)
+	}
+	if len(cfg.Authors) != 1 {
+		t.Errorf("Authors = %v, want [alice]", cfg.Authors)
+	}
+}
+
+func TestAddRepoEmptyRepos(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("ZEN_CONFIG", path)
+	os.WriteFile(path, []byte("repos:\nauthors: [alice]\n"), 0o644)
+
+	if err := AddRepo("os", "wolfi-dev/os", "/tmp/os"); err != nil {
+		t.Fatalf("AddRepo() error: %v", err)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := cfg.RepoBasePath("os"); got != "/tmp/os" {
+		t.Errorf("RepoBasePath(os) = %q, want /tmp/os", got)
+	}
+}
+
+func TestAddRepoReposNotMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("ZEN_CONFIG", path)
+	orig := "repos:\n  - mono\n"
+	os.WriteFile(path, []byte(orig), 0o600)
+
+	if err := AddRepo("os", "wolfi-dev/os", "/tmp/os"); err == nil {
+		t.Error("AddRepo() with a list under repos should fail")
+	}
+	if data, _ := os.ReadFile(path); string(data) != orig {
+		t.Errorf("config rewritten after a failed AddRepo():\n%s", data)
+	}
+
+	os.WriteFile(path, []byte("repos:\n"), 0o600)
+	if err := AddRepo("os", "wolfi-dev/os", "/tmp/os"); err != nil {
+		t.Fatalf("AddRepo() error: %v", err)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("config mode after AddRepo() = %v, want 0600", info.Mode().Perm())
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDelay coalesces the burst of events an editor save produces
+// (truncate + write, or write to a temp file + rename) into one reload.
+const reloadDelay = 200 * time.Millisecond
+
+// Watch reloads the config file at Path() whenever it changes and passes
+// the new config to onReload, until ctx is done; onReload is not called
+// once ctx is done, and should not block past it. A config that fails to
+// load is passed to onError and otherwise ignored, so a half-written edit
+// never replaces a working config.
+//
+// The directory is watched rather than the file, so that editors that
+// replace the file on save keep being picked up.
+func Watch(ctx context.Context, onReload func(*Config), onError func(error)) error {
+	path := Path()
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watching config: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("watching %s: %w", filepath.Dir(path), err)
+	}
+
+	go func() {
+		defer watcher.Close()
+		timer := time.NewTimer(reloadDelay)
+		timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(ev.Name) != filepath.Clean(path) || ev.Op == fsnotify.Chmod {
+					continue
+				}
+				timer.Reset(reloadDelay)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				if onError != nil {
+					onError(err)
+				}
+			case <-timer.C:
+				cfg, err := LoadFile(path)
+				if err != nil {
+					if onError != nil {
+						onError(err)
+					}
+					continue
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				onReload(cfg)
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	path := filepath.Join(tmpDir, "config.yaml")
+	os.WriteFile(path, []byte("authors: [alice]\n"), 0o644)
+	t.Setenv("ZEN_CONFIG", path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloaded := make(chan *Config, 4)
+	failed := make(chan error, 4)
+	if err := Watch(ctx, func(c *Config) { reloaded <- c }, func(err error) { failed <- err }); err != nil {
+		t.Fatalf("Watch() error: %v", err)
+	}
+
+	// Replace the file the way editors do: write a temp file, rename it over
+	tmp := path + ".swp"
+	os.WriteFile(tmp, []byte("authors: [bob]\n"), 0o644)
+	os.Rename(tmp, path)
+
+	select {
+	case c := <-reloaded:
+		if len(c.Authors) != 1 || c.Authors[0] != "bob" {
+			t.Errorf("reloaded Authors = %v, want [bob]", c.Authors)
+		}
+	case err := <-failed:
+		t.Fatalf("reload failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("config change was not picked up")
+	}
+
+	// An invalid edit is reported and does not replace the config
+	os.WriteFile(path, []byte("authors: [\n"), 0o644)
+	select {
+	case c := <-reloaded:
+		t.Errorf("reloaded an invalid config: %+v", c)
+	case <-failed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("invalid config was not reported")
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SpawnWindow limits when the daemon creates worktrees for new review
+// requests, e.g. weekdays 08:00–18:00. PRs that come in outside the window
+// stay queued until it opens. Times are local; a window whose end is before
+// its start runs past midnight, and Days then names the day it starts on.
+// An empty window (no start or end) is always open.
+type SpawnWindow struct {
+	Days  []string `yaml:"days"`  // "mon".."sun", default every day
+	Start string   `yaml:"start"` // "HH:MM"
+	End   string   `yaml:"end"`   // "HH:MM"
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Enabled reports whether the window restricts anything.
+func (w SpawnWindow) Enabled() bool {
+	return w.Start != "" || w.End != ""
+}
+
+func (w SpawnWindow) validate() error {
+	if !w.Enabled() {
+		if len(w.Days) > 0 {
+			return fmt.Errorf("watch.spawn_window: days needs start and end")
+		}
+		return nil
+	}
+	for _, s := range []string{w.Start, w.End} {
+		if _, err := parseClock(s); err != nil {
+			return fmt.Errorf("watch.spawn_window: %w", err)
+		}
+	}
+	if w.Start == w.End {
+		return fmt.Errorf("watch.spawn_window: start and end are both %s", w.Start)
+	}
+	for _, d := range w.Days {
+		if _, ok := weekdayNames[strings.ToLower(d)]; !ok {
+			return fmt.Errorf("watch.spawn_window: invalid day %q (use mon, tue, ... sun)", d)
+		}
+	}
+	return nil
+}
+
+// parseClock parses "HH:MM" into minutes since midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (use HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// onDay reports whether the window opens on the given weekday.
+func (w SpawnWindow) onDay(d time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, name := range w.Days {
+		if weekdayNames[strings.ToLower(name)] == d {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether t falls inside the window. A disabled or invalid
+// window always contains t.
+func (w SpawnWindow) Contains(t time.Time) bool {
+	if !w.Enabled() {
+		return true
+	}
+	start, err1 := parseClock(w.Start)
+	end, err2 := parseClock(w.End)
+	if err1 != nil || err2 != nil {
+		return true
+	}
+	now := t.Hour()*60 + t.Minute()
+	if start < end {
+		return w.onDay(t.Weekday()) && now >= start && now < end
+	}
+	// Overnight: the evening part belongs to today, the morning part to
+	// the window that opened yesterday
+	if now >= start {
+		return w.onDay(t.Weekday())
+	}
+	return now < end && w.onDay(t.AddDate(0, 0, -1).Weekday())
+}
+
+// NextOpen returns when the window next opens after t, or t itself when t
+// is inside the window.
+func (w SpawnWindow) NextOpen(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+	start, _ := parseClock(w.Start)
+	for i := 0; i <= 7; i++ {
+		open := time.Date(t.Year(), t.Month(), t.Day()+i, start/60, start%60, 0, 0, t.Location())
+		if open.After(t) && w.onDay(open.Weekday()) {
+			return open
+		}
+	}
+	return t
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpawnWindowContains(t *testing.T) {
+	weekdays := SpawnWindow{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "08:00", End: "18:00"}
+	overnight := SpawnWindow{Days: []string{"Fri"}, Start: "22:00", End: "06:00"}
+
+	// 2026-03-13 is a Friday
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 3, day, hour, min, 0, 0, time.Local) }
+	tests := []struct {
+		name string
+		w    SpawnWindow
+		t    time.Time
+		want bool
+	}{
+		{"disabled", SpawnWindow{}, at(14, 3, 0), true},
+		{"weekday inside", weekdays, at(13, 9, 30), true},
+		{"weekday at end", weekdays, at(13, 18, 0), false},
+		{"weekday before start", weekdays, at(13, 7, 59), false},
+		{"weekend", weekdays, at(14, 10, 0), false},
+		{"overnight evening", overnight, at(13, 23, 0), true},
+		{"overnight next morning", overnight, at(14, 5, 0), true},
+		{"overnight morning of start day", overnight, at(13, 5, 0), false},
+	}
+	for _, tt := range tests {
+		if got := tt.w.Contains(tt.t); got != tt.want {
+			t.Errorf("%s: Contains(%s) = %v, want %v", tt.name, tt.t.Format("Mon 15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestSpawnWindowNextOpen(t *testing.T) {
+	w := SpawnWindow{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "08:00", End: "18:00"}
+
+	// Friday evening opens Monday morning
+	fri := time.Date(2026, 3, 13, 19, 0, 0, 0, time.Local)
+	if got, want := w.NextOpen(fri), time.Date(2026, 3, 16, 8, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("NextOpen(Fri 19:00) = %s, want %s", got, want)
+	}
+	// Inside the window it is open now
+	tue := time.Date(2026, 3, 17, 10, 0, 0, 0, time.Local)
+	if got := w.NextOpen(tue); !got.Equal(tue) {
+		t.Errorf("NextOpen(Tue 10:00) = %s, want now", got)
+	}
+}
+
+func TestSpawnWindowValidate(t *testing.T) {
+	for _, w := range []SpawnWindow{
+		{Start: "8am", End: "18:00"},
+		{Start: "08:00"},
+		{Start: "08:00", End: "08:00"},
+		{Start: "08:00", End: "18:00", Days: []string{"monday"}},
+		{Days: []string{"mon"}},
+	} {
+		if err := w.validate(); err == nil {
+			t.Errorf("validate(%+v) = nil, want error", w)
+		}
+	}
+	if err := (SpawnWindow{Start: "22:00", End: "06:00", Days: []string{"Sat", "sun"}}).validate(); err != nil {
+		t.Errorf("validate() = %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// guidelineFiles lists the repo docs checked for review guidelines, in
+// order of preference: dedicated review docs win over contributing docs.
+var guidelineFiles = []string{
+	"REVIEWING.md",
+	".github/REVIEWING.md",
+	"docs/REVIEWING.md",
+	"CONTRIBUTING.md",
+	".github/CONTRIBUTING.md",
+	"docs/CONTRIBUTING.md",
+}
+
+// maxGuidelineLines caps the condensed guidelines so they don't drown out
+// the PR context in CLAUDE.local.md.
+const maxGuidelineLines = 40
+
+// relevantHeading matches section titles worth keeping from long docs.
+var relevantHeading = regexp.MustCompile(`(?i)review|pull request|\bprs?\b|style|convention|guideline|test|commit|code quality|checklist`)
+
+var (
+	imageOrBadge = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	htmlComment  = regexp.MustCompile(`(?s)<!--.*?-->`)
+	headingLine  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+)
+
+// LoadReviewGuidelines looks for a review or contributing doc in the
+// worktree and returns its path (relative to the worktree) and a condensed
+// version of its content. Returns empty strings when none is found.
+func LoadReviewGuidelines(worktreePath string) (source, guidelines string) {
+	for _, name := range guidelineFiles {
+		data, err := os.ReadFile(filepath.Join(worktreePath, name))
+		if err != nil {
+			continue
+		}
+		condensed := CondenseGuidelines(string(data), name)
+		if condensed != "" {
+			return name, condensed
+		}
+	}
+	return "", ""
+}
+
+// CondenseGuidelines reduces a markdown doc to the parts useful for review:
+// headings become bold labels, code blocks, images and HTML comments are
+// dropped, and for contributing docs only review-relevant sections are kept. The
+// result is capped at maxGuidelineLines with a pointer to the full file.
+func CondenseGuidelines(md, source string) string {
+	md = htmlComment.ReplaceAllString(md, "")
+
+	type section struct {
+		title    string
+		relevant bool
+		lines    []string
+	}
+	var sections []section
+	var cur section // preamble before the first heading
+	inFence := false
+
+	for _, line := range strings.Split(md, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := headingLine.FindStringSubmatch(trimmed); m != nil {
+			sections = append(sections, cur)
+			cur = section{title: m[2], relevant: relevantHeading.MatchString(m[2])}
+			continue
+		}
+		line = strings.TrimRight(imageOrBadge.ReplaceAllString(line, ""), " \t")
+		if strings.TrimSpace(line) == "" {
+			if n := len(cur.lines); n > 0 && cur.lines[n-1] != "" {
+				cur.lines = append(cur.lines, "")
+			}
+			continue
+		}
+		cur.lines = append(cur.lines, line)
+	}
+	sections = append(sections, cur)
+
+	// A REVIEWING doc is relevant as a whole. For CONTRIBUTING docs, only
+	// filter when some section is clearly about review, style or tests;
+	// otherwise the whole doc is the best we have.
+	filter := false
+	if !strings.Contains(filepath.Base(source), "REVIEWING") {
+		for _, s := range sections {
+			if s.relevant && len(s.lines) > 0 {
+				filter = true
+				break
+			}
+		}
+	}
+
+	var out []string
+	for _, s := range sections {
+		if filter && !s.relevant {
+			continue
+		}
+		body := trimBlank(s.lines)
+		if len(body) == 0 {
+			continue
+		}
+		if s.title != "" {
+			if len(out) > 0 {
+				out = append(out, "")
+			}
+			out = append(out, "**"+s.title+"**", "")
+		}
+		out = append(out, body...)
+	}
+
+	if len(out) > maxGuidelineLines {
+		out = append(trimBlank(out[:maxGuidelineLines]), "", fmt.Sprintf("_(truncated — see `%s` for the full guidelines)_", source))
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// trimBlank removes leading and trailing empty lines.
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
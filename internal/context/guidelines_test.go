@@ -0,0 +1,110 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const contributingMD = `# Contributing
+
+![build](https://example.com/badge.svg)
+Thanks for your interest!
+
+## Setting up
+
+` + "```" + `
+make dev
+` + "```" + `
+
+## Code Style
+
+- Run gofmt.
+- Wrap errors with %w.
+
+<!-- maintainers only -->
+## Pull Requests
+
+1. One logical change per PR.
+`
+
+func TestCondenseGuidelines_FiltersContributing(t *testing.T) {
+	got := CondenseGuidelines(contributingMD, "CONTRIBUTING.md")
+
+	for _, want := range []string{"**Code Style**", "- Run gofmt.", "**Pull Requests**", "1. One logical change per PR."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("condensed output missing %q:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"Setting up", "make dev", "badge.svg", "Thanks for your interest", "maintainers only", "## "} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("condensed output should not contain %q:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestCondenseGuidelines_KeepsWholeReviewingDoc(t *testing.T) {
+	md := "# Reviewing\n\n## Security\n\n- Check authz on new endpoints.\n\n## Tests\n\n- Table-driven tests.\n"
+	got := CondenseGuidelines(md, "REVIEWING.md")
+	if !strings.Contains(got, "Check authz") || !strings.Contains(got, "Table-driven") {
+		t.Errorf("REVIEWING.md sections should all be kept:\n%s", got)
+	}
+}
+
+func TestCondenseGuidelines_Truncates(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("# Reviewing\n\n")
+	for i := 0; i < 100; i++ {
+		b.WriteString("- rule\n")
+	}
+	got := CondenseGuidelines(b.String(), "REVIEWING.md")
+	if n := strings.Count(got, "- rule"); n >= 100 {
+		t.Errorf("expected truncation, got %d rules", n)
+	}
+	if !strings.Contains(got, "see `REVIEWING.md`") {
+		t.Error("truncated output should point at the full file")
+	}
+}
+
+func TestLoadReviewGuidelines(t *testing.T) {
+	dir := t.TempDir()
+	if src, _ := LoadReviewGuidelines(dir); src != "" {
+		t.Errorf("LoadReviewGuidelines() on empty dir = %q, want none", src)
+	}
+
+	os.WriteFile(filepath.Join(dir, "CONTRIBUTING.md"), []byte(contributingMD), 0o644)
+	os.MkdirAll(filepath.Join(dir, ".github"), 0o755)
+	os.WriteFile(filepath.Join(dir, ".github", "REVIEWING.md"), []byte("- Be kind.\n"), 0o644)
+
+	src, text := LoadReviewGuidelines(dir)
+	if src != ".github/REVIEWING.md" {
+		t.Errorf("source = %q, want .github/REVIEWING.md (review docs win)", src)
+	}
+	if text != "- Be kind." {
+		t.Errorf("guidelines = %q, want %q", text, "- Be kind.")
+	}
+}
+
+func TestRenderClaudeMD_Guidelines(t *testing.T) {
+	prCtx := PRContext{
+		Number:           7,
+		Title:            "Tweak",
+		ChangedFiles:     []string{"a.go"},
+		Guidelines:       "- Run gofmt.",
+		GuidelinesSource: "CONTRIBUTING.md",
+	}
+	out, err := RenderClaudeMD(prCtx)
+	if err != nil {
+		t.Fatalf("RenderClaudeMD() error: %v", err)
+	}
+	if !strings.Contains(out, "### Project Guidelines") || !strings.Contains(out, "`CONTRIBUTING.md`") || !strings.Contains(out, "- Run gofmt.") {
+		t.Errorf("output missing project guidelines:\n%s", out)
+	}
+
+	prCtx.Guidelines = ""
+	out, _ = RenderClaudeMD(prCtx)
+	if strings.Contains(out, "Project Guidelines") {
+		t.Error("output should omit guidelines section when none were found")
+	}
+}
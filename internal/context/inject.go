@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"text/template"
 
 	"github.com/mgreau/zen/internal/github"
@@ -23,6 +25,23 @@ type PRContext struct {
 	IsFork      bool
 	Body        string
 	ChangedFiles []string
+
+	// Guidelines is a condensed copy of the repo's REVIEWING.md or
+	// CONTRIBUTING.md, read from GuidelinesSource.
+	Guidelines       string
+	GuidelinesSource string
+
+	// Updates lists commits pushed since the context was first injected,
+	// oldest first.
+	Updates []ContextUpdate
+
+	// Playbook names the review playbook (see SetPlaybook), whose
+	// instructions are added at the end.
+	Playbook             string
+	PlaybookInstructions string
+
+	// Notes are the sections added with SetNote, rendered last.
+	Notes []Note
 }
 
 const claudeMDTemplate = `# PR Review: #{{.Number}} — {{.Title}}
@@ -45,7 +64,23 @@ const claudeMDTemplate = `# PR Review: #{{.Number}} — {{.Title}}
 ## Changed Files
 
 {{range .ChangedFiles}}- ` + "`{{.}}`" + `
-{{end}}
+{{end}}{{if .Updates}}
+## Updates Since Review Started
+
+The PR received new commits after this review began. Re-read these files
+before relying on anything you concluded about them earlier.
+{{range .Updates}}
+### {{.At.Format "2006-01-02 15:04"}} — ` + "`{{short .From}}`" + ` → ` + "`{{short .To}}`" + `
+{{if .Files}}
+Changed by the new commits:
+{{range .Files}}- ` + "`{{.}}`" + `
+{{end}}{{end}}{{if .Added}}
+Now part of the PR:
+{{range .Added}}- ` + "`{{.}}`" + `
+{{end}}{{end}}{{if .Removed}}
+No longer part of the PR:
+{{range .Removed}}- ` + "`{{.}}`" + `
+{{end}}{{end}}{{end}}{{end}}
 ## Review Instructions
 
 You are reviewing PR #{{.Number}}. Focus on:
@@ -54,11 +89,39 @@ You are reviewing PR #{{.Number}}. Focus on:
 2. **Security** — Any injection, auth bypass, or data exposure risks?
 3. **Tests** — Are changes adequately tested?
 4. **Style** — Does it follow existing patterns in the codebase?
+{{if .Guidelines}}
+### Project Guidelines
 
+Condensed from ` + "`{{.GuidelinesSource}}`" + ` in this repository. Apply them in your review.
+
+{{.Guidelines}}
+{{end}}
 Start by reading the changed files listed above, then provide your review.
+{{- if .Playbook}}
+
+## ` + playbookHeading + `
+
+{{playbook .Playbook .PlaybookInstructions}}
+{{- end}}
+{{- range .Notes}}
+
+## {{.Heading}}
+
+{{.Body}}
+{{- end}}
 `
 
-var tmpl = template.Must(template.New("claude-md").Parse(claudeMDTemplate))
+var tmpl = template.Must(template.New("claude-md").Funcs(template.FuncMap{
+	"short":    shortSHA,
+	"playbook": playbookBody,
+}).Parse(claudeMDTemplate))
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
 
 // InjectPRContext fetches PR metadata from GitHub and writes a CLAUDE.md
 // file in the given worktree directory.
@@ -78,7 +141,37 @@ func InjectPRContext(ctx context.Context, worktreePath string, fullRepo string,
 		return fmt.Errorf("fetching PR files: %w", err)
 	}
 
-	prCtx := PRContext{
+	rec := injectedRecord(worktreePath, fullRepo, prNumber, details, files)
+	if err := writeContext(worktreePath, rec, details); err != nil {
+		return err
+	}
+
+	// Remember the head so RefreshPRContext can tell when new commits land
+	if err := saveRecord(worktreePath, rec); err != nil {
+		ui.LogDebug(fmt.Sprintf("Saving context state for %s: %v", worktreePath, err))
+	}
+	return nil
+}
+
+// injectedRecord returns the context record InjectPRContext writes for the
+// worktree. A playbook and notes added before the context is rewritten are
+// kept; ForgetContext drops them once the worktree is removed, so a new
+// checkout at the same path starts without them.
+func injectedRecord(worktreePath, fullRepo string, prNumber int, details *github.PRDetails, files []string) contextRecord {
+	prev, _ := getRecord(worktreePath)
+	return contextRecord{
+		FullRepo:             fullRepo,
+		PRNumber:             prNumber,
+		HeadSHA:              details.HeadSHA,
+		Files:                files,
+		Playbook:             prev.Playbook,
+		PlaybookInstructions: prev.PlaybookInstructions,
+		Notes:                prev.Notes,
+	}
+}
+
+func newPRContext(details *github.PRDetails, files []string) PRContext {
+	return PRContext{
 		Number:       details.Number,
 		Title:        details.Title,
 		Author:       details.Author,
@@ -89,8 +182,6 @@ func InjectPRContext(ctx context.Context, worktreePath string, fullRepo string,
 		Body:         details.Body,
 		ChangedFiles: files,
 	}
-
-	return WriteClaudeMD(worktreePath, prCtx)
 }
 
 // WriteClaudeMD renders the template and writes PR review context to the
@@ -119,3 +210,70 @@ func RenderClaudeMD(prCtx PRContext) (string, error) {
 	}
 	return buf.String(), nil
 }
+
+// SetNote writes a "## <heading>" section at the end of the worktree's
+// CLAUDE.local.md, replacing an earlier section with the same heading so
+// repeated notes don't pile up. The note is recorded, so it survives
+// InjectPRContext and RefreshPRContext rewriting the file.
+func SetNote(dir, heading, body string) error {
+	body = strings.TrimRight(body, "\n")
+	recordsMu.Lock()
+	records := loadRecords()
+	rec := records[dir]
+	rec.Notes = slices.DeleteFunc(rec.Notes, func(n Note) bool { return n.Heading == heading })
+	rec.Notes = append(rec.Notes, Note{Heading: heading, Body: body})
+	records[dir] = rec
+	err := saveRecords(records)
+	recordsMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("saving note: %w", err)
+	}
+	return writeNote(dir, heading, body)
+}
+
+// writeNote writes the "## <heading>" section of SetNote without
+// recording it.
+func writeNote(dir, heading, body string) error {
+	outPath := filepath.Join(dir, "CLAUDE.local.md")
+	data, err := os.ReadFile(outPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading %s: %w", outPath, err)
+	}
+	content := removeSection(string(data), heading)
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if content != "" {
+		content += "\n"
+	}
+	content += "## " + heading + "\n\n" + strings.TrimRight(body, "\n") + "\n"
+
+	if err := os.WriteFile(outPath, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", outPath, err)
+	}
+	return nil
+}
+
+// removeSection drops the "## <heading>" section, up to the next level-2
+// heading or the end of the document, along with the blank lines before it.
+func removeSection(doc, heading string) string {
+	marker := "## " + heading + "\n"
+	start := strings.Index(doc, marker)
+	if start < 0 || (start > 0 && doc[start-1] != '\n') {
+		return doc
+	}
+	before := strings.TrimRight(doc[:start], "\n")
+	if before != "" {
+		before += "\n"
+	}
+	rest := doc[start+len(marker):]
+	next := strings.Index(rest, "\n## ")
+	switch {
+	case next < 0:
+		return before
+	case before == "":
+		return rest[next+1:]
+	}
+	return before + "\n" + rest[next+1:]
+}
@@ -108,3 +108,36 @@ func TestWriteClaudeMD(t *testing.T) {
 		t.Error("CLAUDE.local.md missing expected content")
 	}
 }
+
+func TestSetNote(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.local.md")
+	os.WriteFile(path, []byte("# PR Review\n\n## Changed Files\n\n- `a.go`\n"), 0o644)
+
+	if err := SetNote(dir, "What Changed", "first note\n"); err != nil {
+		t.Fatalf("SetNote: %v", err)
+	}
+	if err := SetNote(dir, "What Changed", "second note"); err != nil {
+		t.Fatalf("SetNote: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	want := "# PR Review\n\n## Changed Files\n\n- `a.go`\n\n## What Changed\n\nsecond note\n"
+	if string(data) != want {
+		t.Errorf("CLAUDE.local.md =\n%q\nwant\n%q", data, want)
+	}
+}
+
+func TestRemoveSection(t *testing.T) {
+	doc := "# T\n\n## A\n\na\n\n## B\n\nb\n"
+	if got, want := removeSection(doc, "A"), "# T\n\n## B\n\nb\n"; got != want {
+		t.Errorf("removeSection(A) = %q, want %q", got, want)
+	}
+	if got, want := removeSection(doc, "B"), "# T\n\n## A\n\na\n"; got != want {
+		t.Errorf("removeSection(B) = %q, want %q", got, want)
+	}
+	if got := removeSection(doc, "C"); got != doc {
+		t.Errorf("removeSection(C) changed the document: %q", got)
+	}
+}
@@ -0,0 +1,155 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// NotesFile is the review notes file in each review worktree, kept out of
+// git by config.DefaultGitExclude.
+const NotesFile = "NOTES.zen.md"
+
+// NotesPath returns the path of the notes file of the worktree at dir.
+func NotesPath(dir string) string {
+	return filepath.Join(dir, NotesFile)
+}
+
+// NotesTitle is the title of the notes file of PR prNumber's review.
+func NotesTitle(prNumber int) string {
+	return fmt.Sprintf("Review notes: PR #%d", prNumber)
+}
+
+// NotesArchiveDir holds the notes of removed worktrees, one
+// <worktree-name>.md file each. Being in the state directory, they are
+// included in zen export.
+func NotesArchiveDir() string {
+	return filepath.Join(config.StateDir(), "notes")
+}
+
+// ArchivedNotesPath returns where the notes of the worktree named name
+// are archived when it is removed.
+func ArchivedNotesPath(name string) string {
+	return filepath.Join(NotesArchiveDir(), name+".md")
+}
+
+// EnsureNotes creates the notes file of the worktree at dir when missing:
+// from the archived notes of a previous worktree of the same name, so a
+// PR checked out again keeps its findings, or else with just a title.
+// Reports whether it created the file.
+func EnsureNotes(dir, title string) (bool, error) {
+	path := NotesPath(dir)
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	}
+	data, err := os.ReadFile(ArchivedNotesPath(filepath.Base(dir)))
+	if err != nil {
+		data = []byte("# " + title + "\n")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return false, fmt.Errorf("writing %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// ArchiveNotes copies the notes file of the worktree at dir to the notes
+// archive before the worktree is removed, and returns the archived path.
+// Missing notes, or notes with nothing but their title, are not archived
+// and return "".
+func ArchiveNotes(dir string) (string, error) {
+	data, err := os.ReadFile(NotesPath(dir))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading notes: %w", err)
+	}
+	if NotesBody(string(data)) == "" {
+		return "", nil
+	}
+	path := ArchivedNotesPath(filepath.Base(dir))
+	if err := state.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("archiving notes: %w", err)
+	}
+	return path, nil
+}
+
+// NotesBody returns notes without their leading "# " title line, trimmed.
+func NotesBody(notes string) string {
+	notes = strings.TrimSpace(notes)
+	if strings.HasPrefix(notes, "# ") {
+		_, notes, _ = strings.Cut(notes, "\n")
+	}
+	return strings.TrimSpace(notes)
+}
+
+// AppendNote appends section to the worktree's notes file, creating it
+// with a title when missing.
+func AppendNote(dir, title, section string) error {
+	path := NotesPath(dir)
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	content := string(data)
+	if strings.TrimSpace(content) == "" {
+		content = "# " + title + "\n"
+	}
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += "\n" + strings.TrimRight(section, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// Capture is the recorded run of a command in a worktree.
+type Capture struct {
+	Command  string
+	Started  time.Time
+	Duration time.Duration
+	ExitCode int
+	Output   string
+}
+
+// ansiEscape matches terminal color and cursor sequences.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// FormatCapture renders the body of a notes section for c: its result,
+// then the last maxLines lines of its output, without terminal escapes.
+// maxLines <= 0 keeps all of it.
+func FormatCapture(c Capture, maxLines int) string {
+	result := "Passed"
+	if c.ExitCode != 0 {
+		result = fmt.Sprintf("Failed (exit %d)", c.ExitCode)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s on %s after %s.\n\n", result, c.Started.Format("2006-01-02 15:04 MST"), c.Duration.Round(time.Second/10))
+
+	output := strings.TrimRight(ansiEscape.ReplaceAllString(strings.ReplaceAll(c.Output, "\r\n", "\n"), ""), "\n")
+	if output == "" {
+		b.WriteString("_No output._\n")
+		return b.String()
+	}
+	lines := strings.Split(output, "\n")
+	if maxLines > 0 && len(lines) > maxLines {
+		fmt.Fprintf(&b, "_Last %d of %d lines:_\n\n", maxLines, len(lines))
+		lines = lines[len(lines)-maxLines:]
+	}
+	// A longer fence than any in the output keeps it in one block
+	fence := "```"
+	for strings.Contains(output, fence) {
+		fence += "`"
+	}
+	b.WriteString(fence + "\n" + strings.Join(lines, "\n") + "\n" + fence + "\n")
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatCapture(t *testing.T) {
+	started := time.Date(2026, 3, 2, 14, 5, 0, 0, time.UTC)
+	got := FormatCapture(Capture{
+		Command:  "go test ./...",
+		Started:  started,
+		Duration: 12340 * time.Millisecond,
+		Output:   "\x1b[32mok\x1b[0m  pkg/a\r\nFAIL pkg/b\nsee ```x```\n",
+		ExitCode: 1,
+	}, 2)
+	for _, want := range []string{
+		"Failed (exit 1) on 2026-03-02 14:05 UTC after 12.3s.\n",
+		"_Last 2 of 3 lines:_",
+		"````\nFAIL pkg/b\nsee ```x```\n````\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatCapture() missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "\x1b") || strings.Contains(got, "pkg/a") {
+		t.Errorf("FormatCapture() kept escapes or dropped lines:\n%s", got)
+	}
+
+	got = FormatCapture(Capture{Command: "make", Started: started}, 0)
+	if !strings.HasPrefix(got, "Passed on ") || !strings.Contains(got, "_No output._") {
+		t.Errorf("FormatCapture() of a silent success:\n%s", got)
+	}
+}
+
+func TestAppendNote(t *testing.T) {
+	dir := t.TempDir()
+	if err := AppendNote(dir, "Review notes: PR #42", "## first\n\nbody\n"); err != nil {
+		t.Fatalf("AppendNote() error: %v", err)
+	}
+	if err := AppendNote(dir, "Review notes: PR #42", "## second\n"); err != nil {
+		t.Fatalf("AppendNote() error: %v", err)
+	}
+	data, _ := os.ReadFile(NotesPath(dir))
+	want := "# Review notes: PR #42\n\n## first\n\nbody\n\n## second\n"
+	if string(data) != want {
+		t.Errorf("notes = %q; want %q", data, want)
+	}
+}
+
+func TestNotesArchive(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := filepath.Join(t.TempDir(), "mono-pr-42")
+	os.MkdirAll(dir, 0o755)
+
+	if created, err := EnsureNotes(dir, NotesTitle(42)); err != nil || !created {
+		t.Fatalf("EnsureNotes() = %v, %v; want true", created, err)
+	}
+	if created, _ := EnsureNotes(dir, NotesTitle(42)); created {
+		t.Error("EnsureNotes() recreated existing notes")
+	}
+
+	// Notes with only their title are not worth archiving
+	if path, err := ArchiveNotes(dir); err != nil || path != "" {
+		t.Errorf("ArchiveNotes() of empty notes = %q, %v; want nothing", path, err)
+	}
+
+	AppendNote(dir, NotesTitle(42), "Retry loop never backs off.\n")
+	path, err := ArchiveNotes(dir)
+	if err != nil || path != ArchivedNotesPath("mono-pr-42") {
+		t.Fatalf("ArchiveNotes() = %q, %v; want %q", path, err, ArchivedNotesPath("mono-pr-42"))
+	}
+
+	// A new checkout of the PR gets the archived notes back
+	os.RemoveAll(dir)
+	os.MkdirAll(dir, 0o755)
+	EnsureNotes(dir, NotesTitle(42))
+	data, _ := os.ReadFile(NotesPath(dir))
+	want := "# Review notes: PR #42\n\nRetry loop never backs off.\n"
+	if string(data) != want {
+		t.Errorf("restored notes = %q; want %q", data, want)
+	}
+}
+
+func TestNotesBody(t *testing.T) {
+	for in, want := range map[string]string{
+		"":                          "",
+		"# Review notes: PR #42\n":  "",
+		"# Title\n\n## run\n\nok\n": "## run\n\nok",
+		"no title\n":                "no title",
+	} {
+		if got := NotesBody(in); got != want {
+			t.Errorf("NotesBody(%q) = %q; want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package context
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/mgreau/zen/internal/ui"
+)
+
+// PatchPrompt is the initial prompt of a session reviewing a patch
+// worktree, whose CLAUDE.local.md is written by WritePatchContext.
+const PatchPrompt = "Review the patch described in CLAUDE.local.md"
+
+// PatchContext holds the data for the CLAUDE.local.md of a patch review
+// worktree.
+type PatchContext struct {
+	Source  string // file path or URL the patch came from
+	Subject string // from the patch's Subject: header, if any
+	Author  string // from the patch's From: header, if any
+	Message string // commit message body of a git format-patch patch
+	Base    string // ref the patch was applied on, e.g. origin/main
+	BaseSHA string
+	Files   []string
+
+	Guidelines       string
+	GuidelinesSource string
+}
+
+const patchMDTemplate = `# Patch Review{{if .Subject}}: {{.Subject}}{{end}}
+
+## Patch Info
+
+| Field | Value |
+|-------|-------|
+| **Source** | {{.Source}} |
+{{- if .Author}}
+| **Author** | {{.Author}} |
+{{- end}}
+| **Base** | ` + "`{{.Base}}`" + ` ({{short .BaseSHA}}) |
+
+The patch is applied to the index on top of the base and not committed:
+` + "`git diff --cached`" + ` shows it in full.
+{{if .Message}}
+## Description
+
+{{.Message}}
+{{end}}
+## Changed Files
+
+{{range .Files}}- ` + "`{{.}}`" + `
+{{else}}_No files listed._
+{{end}}
+## Review Instructions
+
+This change was shared as a patch, not a pull request, so there is no PR
+to look up and nowhere to post comments: write your review here. Focus on:
+
+1. **Correctness** — Does the code do what the patch claims?
+2. **Security** — Any injection, auth bypass, or data exposure risks?
+3. **Tests** — Are changes adequately tested?
+4. **Style** — Does it follow existing patterns in the codebase?
+{{if .Guidelines}}
+### Project Guidelines
+
+Condensed from ` + "`{{.GuidelinesSource}}`" + ` in this repository. Apply them in your review.
+
+{{.Guidelines}}
+{{end}}
+Start by reading the changed files listed above, then provide your review.
+`
+
+var patchTmpl = template.Must(template.New("patch-md").Funcs(template.FuncMap{
+	"short": shortSHA,
+}).Parse(patchMDTemplate))
+
+// WritePatchContext renders pc into dir's CLAUDE.local.md, along with the
+// repo's review guidelines when it has any.
+func WritePatchContext(dir string, pc PatchContext) error {
+	pc.GuidelinesSource, pc.Guidelines = LoadReviewGuidelines(dir)
+
+	var buf bytes.Buffer
+	if err := patchTmpl.Execute(&buf, pc); err != nil {
+		return fmt.Errorf("rendering template: %w", err)
+	}
+
+	outPath := filepath.Join(dir, "CLAUDE.local.md")
+	if err := os.WriteFile(outPath, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", outPath, err)
+	}
+
+	ui.LogDebug(fmt.Sprintf("Wrote patch context to %s", outPath))
+	return nil
+}
@@ -0,0 +1,47 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWritePatchContext(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "REVIEWING.md"), []byte("Keep functions small.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pc := PatchContext{
+		Source:  "https://gist.github.com/jane/0123456789abcdef0123",
+		Subject: "Fix the loader",
+		Author:  "Jane Doe <jane@example.com>",
+		Base:    "origin/main",
+		BaseSHA: "0123456789abcdef",
+		Files:   []string{"pkg/loader.go"},
+	}
+	if err := WritePatchContext(dir, pc); err != nil {
+		t.Fatalf("WritePatchContext() error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "CLAUDE.local.md"))
+	if err != nil {
+		t.Fatalf("reading CLAUDE.local.md: %v", err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		"# Patch Review: Fix the loader",
+		"| **Author** | Jane Doe <jane@example.com> |",
+		"`origin/main` (0123456)",
+		"- `pkg/loader.go`",
+		"Keep functions small.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("CLAUDE.local.md missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "## Description") {
+		t.Error("CLAUDE.local.md has a Description section without a message")
+	}
+}
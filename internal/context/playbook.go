@@ -0,0 +1,36 @@
+package context
+
+import "strings"
+
+// playbookHeading is the CLAUDE.local.md section holding the review
+// playbook's instructions.
+const playbookHeading = "Review Playbook"
+
+// playbookBody renders the Review Playbook section of CLAUDE.local.md.
+func playbookBody(name, instructions string) string {
+	return "This review follows the **" + name + "** playbook.\n\n" + strings.TrimSpace(instructions)
+}
+
+// SetPlaybook records the review playbook of a PR worktree and writes its
+// instructions to CLAUDE.local.md, replacing an earlier playbook's. The
+// playbook is kept when the PR context is rewritten.
+func SetPlaybook(worktreePath, name, instructions string) error {
+	recordsMu.Lock()
+	records := loadRecords()
+	rec := records[worktreePath]
+	rec.Playbook, rec.PlaybookInstructions = name, instructions
+	records[worktreePath] = rec
+	err := saveRecords(records)
+	recordsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	// Recorded as the playbook, which the template renders on its own
+	return writeNote(worktreePath, playbookHeading, playbookBody(name, instructions))
+}
+
+// RecordedPlaybook returns the review playbook set for a worktree, or "".
+func RecordedPlaybook(worktreePath string) string {
+	rec, _ := getRecord(worktreePath)
+	return rec.Playbook
+}
@@ -0,0 +1,47 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetPlaybook(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+
+	prCtx := PRContext{Number: 7, Title: "Add login", ChangedFiles: []string{"auth.go"}}
+	if err := WriteClaudeMD(dir, prCtx); err != nil {
+		t.Fatal(err)
+	}
+	saveRecord(dir, contextRecord{FullRepo: "org/repo", PRNumber: 7, HeadSHA: "abc"})
+
+	if err := SetPlaybook(dir, "security", "Look for SQL built from input."); err != nil {
+		t.Fatalf("SetPlaybook() error: %v", err)
+	}
+	if err := SetPlaybook(dir, "perf", "Look for N+1 queries."); err != nil {
+		t.Fatalf("SetPlaybook() error: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "CLAUDE.local.md"))
+	doc := string(data)
+	if strings.Count(doc, "## Review Playbook") != 1 || strings.Contains(doc, "SQL built") || !strings.Contains(doc, "Look for N+1 queries.") {
+		t.Errorf("CLAUDE.local.md after two playbooks:\n%s", doc)
+	}
+	if got := RecordedPlaybook(dir); got != "perf" {
+		t.Errorf("RecordedPlaybook() = %q, want perf", got)
+	}
+	if rec, _ := getRecord(dir); rec.HeadSHA != "abc" {
+		t.Errorf("SetPlaybook() lost the context record: %+v", rec)
+	}
+
+	// A rewritten context renders the same section
+	prCtx.Playbook, prCtx.PlaybookInstructions = "perf", "Look for N+1 queries."
+	rendered, err := RenderClaudeMD(prCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendered != doc {
+		t.Errorf("RenderClaudeMD() with a playbook =\n%s\nwant\n%s", rendered, doc)
+	}
+}
@@ -0,0 +1,183 @@
+package context
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// ContextUpdate describes new commits pushed to a PR after its context
+// was first injected.
+type ContextUpdate struct {
+	At   time.Time `json:"at"`
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	// Files are the paths touched by the new commits.
+	Files []string `json:"files,omitempty"`
+	// Added and Removed are paths that joined or left the PR's file list.
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// contextRecord is what was last written to a worktree's CLAUDE.local.md.
+type contextRecord struct {
+	FullRepo string          `json:"full_repo"`
+	PRNumber int             `json:"pr_number"`
+	HeadSHA  string          `json:"head_sha"`
+	Files    []string        `json:"files"`
+	Updates  []ContextUpdate `json:"updates,omitempty"`
+
+	Playbook             string `json:"playbook,omitempty"`
+	PlaybookInstructions string `json:"playbook_instructions,omitempty"`
+	Notes                []Note `json:"notes,omitempty"`
+}
+
+// Note is a section added to CLAUDE.local.md with SetNote, e.g. the diff
+// since the last review. Notes are recorded so a rewritten context keeps
+// them.
+type Note struct {
+	Heading string `json:"heading"`
+	Body    string `json:"body"`
+}
+
+var recordsMu sync.Mutex
+
+func recordsPath() string {
+	return filepath.Join(config.StateDir(), "pr_context.json")
+}
+
+func loadRecords() map[string]contextRecord {
+	records := make(map[string]contextRecord)
+	data, err := os.ReadFile(recordsPath())
+	if err != nil {
+		return records
+	}
+	json.Unmarshal(data, &records)
+	return records
+}
+
+func saveRecords(records map[string]contextRecord) error {
+	return state.WriteJSON(recordsPath(), records)
+}
+
+func saveRecord(worktreePath string, rec contextRecord) error {
+	recordsMu.Lock()
+	defer recordsMu.Unlock()
+	records := loadRecords()
+	records[worktreePath] = rec
+	return saveRecords(records)
+}
+
+func getRecord(worktreePath string) (contextRecord, bool) {
+	recordsMu.Lock()
+	defer recordsMu.Unlock()
+	rec, ok := loadRecords()[worktreePath]
+	return rec, ok
+}
+
+// ForgetContext drops the recorded context state for a worktree, e.g.
+// after the worktree was removed.
+func ForgetContext(worktreePath string) {
+	recordsMu.Lock()
+	defer recordsMu.Unlock()
+	records := loadRecords()
+	if _, ok := records[worktreePath]; !ok {
+		return
+	}
+	delete(records, worktreePath)
+	saveRecords(records)
+}
+
+// RefreshPRContext regenerates CLAUDE.local.md when the PR has new commits
+// since the context was last written, appending an update that lists the
+// files the new commits touched. Returns the new update, or nil when the
+// PR head is unchanged. With force the file is rewritten even when nothing
+// changed. Worktrees injected before head tracking existed get a fresh
+// context and a baseline, and nil is returned.
+func RefreshPRContext(ctx context.Context, client *github.Client, worktreePath, fullRepo string, prNumber int, force bool) (*ContextUpdate, error) {
+	details, err := client.GetPRDetails(ctx, fullRepo, prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("fetching PR details: %w", err)
+	}
+
+	rec, ok := getRecord(worktreePath)
+	if ok && rec.HeadSHA == details.HeadSHA && !force {
+		return nil, nil
+	}
+
+	files, err := client.GetPRFiles(ctx, fullRepo, prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("fetching PR files: %w", err)
+	}
+
+	var update *ContextUpdate
+	updates := rec.Updates
+	if ok && rec.HeadSHA != "" && rec.HeadSHA != details.HeadSHA {
+		update = &ContextUpdate{
+			At:   time.Now(),
+			From: rec.HeadSHA,
+			To:   details.HeadSHA,
+		}
+		update.Added, update.Removed = diffFiles(rec.Files, files)
+		// A force-push can make the old head unreachable; the file list
+		// diff still tells the session what moved.
+		if changed, err := client.CompareFiles(ctx, fullRepo, rec.HeadSHA, details.HeadSHA); err == nil {
+			update.Files = changed
+		}
+		updates = append(updates, *update)
+	}
+
+	rec.FullRepo, rec.PRNumber, rec.Updates = fullRepo, prNumber, updates
+	rec.HeadSHA, rec.Files = details.HeadSHA, files
+	if err := writeContext(worktreePath, rec, details); err != nil {
+		return nil, err
+	}
+	if err := saveRecord(worktreePath, rec); err != nil {
+		return update, fmt.Errorf("saving context state: %w", err)
+	}
+	return update, nil
+}
+
+// writeContext writes the worktree's CLAUDE.local.md for the PR details
+// and the files, updates, playbook and notes recorded in rec.
+func writeContext(worktreePath string, rec contextRecord, details *github.PRDetails) error {
+	prCtx := newPRContext(details, rec.Files)
+	prCtx.GuidelinesSource, prCtx.Guidelines = LoadReviewGuidelines(worktreePath)
+	prCtx.Updates = rec.Updates
+	prCtx.Playbook, prCtx.PlaybookInstructions = rec.Playbook, rec.PlaybookInstructions
+	prCtx.Notes = rec.Notes
+	return WriteClaudeMD(worktreePath, prCtx)
+}
+
+// diffFiles returns the paths in next that are not in prev (added) and
+// the paths in prev that are not in next (removed), both sorted.
+func diffFiles(prev, next []string) (added, removed []string) {
+	prevSet := make(map[string]bool, len(prev))
+	for _, f := range prev {
+		prevSet[f] = true
+	}
+	nextSet := make(map[string]bool, len(next))
+	for _, f := range next {
+		nextSet[f] = true
+		if !prevSet[f] {
+			added = append(added, f)
+		}
+	}
+	for _, f := range prev {
+		if !nextSet[f] {
+			removed = append(removed, f)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
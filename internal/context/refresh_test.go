@@ -0,0 +1,181 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mgreau/zen/internal/github"
+)
+
+func TestDiffFiles(t *testing.T) {
+	added, removed := diffFiles(
+		[]string{"a.go", "b.go", "c.go"},
+		[]string{"c.go", "d.go", "a.go"},
+	)
+	if !reflect.DeepEqual(added, []string{"d.go"}) {
+		t.Errorf("added = %v, want [d.go]", added)
+	}
+	if !reflect.DeepEqual(removed, []string{"b.go"}) {
+		t.Errorf("removed = %v, want [b.go]", removed)
+	}
+
+	added, removed = diffFiles([]string{"a.go"}, []string{"a.go"})
+	if added != nil || removed != nil {
+		t.Errorf("unchanged lists: added = %v, removed = %v, want nil", added, removed)
+	}
+}
+
+func TestRenderClaudeMDUpdates(t *testing.T) {
+	prCtx := PRContext{
+		Number:       7,
+		Title:        "Fix cache",
+		ChangedFiles: []string{"cache.go"},
+		Updates: []ContextUpdate{{
+			At:      time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC),
+			From:    "1111111aaaaaaa",
+			To:      "2222222bbbbbbb",
+			Files:   []string{"cache.go"},
+			Added:   []string{"cache_test.go"},
+			Removed: []string{"old.go"},
+		}},
+	}
+
+	out, err := RenderClaudeMD(prCtx)
+	if err != nil {
+		t.Fatalf("RenderClaudeMD() error: %v", err)
+	}
+	for _, want := range []string{
+		"## Updates Since Review Started",
+		"### 2026-03-02 14:30 — `1111111` → `2222222`",
+		"Changed by the new commits:\n- `cache.go`",
+		"Now part of the PR:\n- `cache_test.go`",
+		"No longer part of the PR:\n- `old.go`",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "## Updates") > strings.Index(out, "## Review Instructions") {
+		t.Error("updates should come before the review instructions")
+	}
+
+	prCtx.Updates = nil
+	out, err = RenderClaudeMD(prCtx)
+	if err != nil {
+		t.Fatalf("RenderClaudeMD() error: %v", err)
+	}
+	if strings.Contains(out, "Updates Since Review Started") {
+		t.Error("updates section rendered without updates")
+	}
+}
+
+func TestContextRecords(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, ok := getRecord("/wt/repo-pr-1"); ok {
+		t.Fatal("expected no record in a fresh state dir")
+	}
+	rec := contextRecord{FullRepo: "org/repo", PRNumber: 1, HeadSHA: "abc", Files: []string{"a.go"}}
+	if err := saveRecord("/wt/repo-pr-1", rec); err != nil {
+		t.Fatalf("saveRecord: %v", err)
+	}
+	got, ok := getRecord("/wt/repo-pr-1")
+	if !ok || !reflect.DeepEqual(got, rec) {
+		t.Errorf("getRecord = %+v, %v; want %+v", got, ok, rec)
+	}
+
+	ForgetContext("/wt/repo-pr-1")
+	if _, ok := getRecord("/wt/repo-pr-1"); ok {
+		t.Error("record still present after ForgetContext")
+	}
+}
+
+func TestRewriteKeepsNotes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+
+	details := &github.PRDetails{Number: 7, Title: "Fix cache", HeadSHA: "aaa"}
+	rec := contextRecord{FullRepo: "org/repo", PRNumber: 7, HeadSHA: "aaa", Files: []string{"cache.go"}}
+	if err := writeContext(dir, rec, details); err != nil {
+		t.Fatal(err)
+	}
+	saveRecord(dir, rec)
+	if err := SetNote(dir, "What Changed Since Your Last Review", "- `cache.go`"); err != nil {
+		t.Fatalf("SetNote() error: %v", err)
+	}
+	if err := SetNote(dir, "Output of `go test`", "ok"); err != nil {
+		t.Fatalf("SetNote() error: %v", err)
+	}
+	noted, _ := os.ReadFile(filepath.Join(dir, "CLAUDE.local.md"))
+
+	// What RefreshPRContext writes once the head moved
+	rec, _ = getRecord(dir)
+	if rec.HeadSHA != "aaa" || len(rec.Notes) != 2 {
+		t.Fatalf("record after SetNote = %+v", rec)
+	}
+	details.HeadSHA = "bbb"
+	rec.Updates = []ContextUpdate{{From: "aaa", To: "bbb"}}
+	if err := writeContext(dir, rec, details); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "CLAUDE.local.md"))
+	doc := string(data)
+	if !strings.Contains(doc, "## Updates Since Review Started") {
+		t.Errorf("context not rewritten:\n%s", doc)
+	}
+	notes := "\n## What Changed Since Your Last Review\n\n- `cache.go`\n\n## Output of `go test`\n\nok\n"
+	if !strings.HasSuffix(doc, notes) {
+		t.Errorf("rewritten context lost the notes:\n%s", doc)
+	}
+	if !strings.HasSuffix(string(noted), notes) {
+		t.Errorf("SetNote() and the template render notes differently:\n%s", noted)
+	}
+}
+
+func TestRecreatedWorktreeStartsFresh(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := filepath.Join(t.TempDir(), "repo-pr-42")
+	os.MkdirAll(dir, 0o755)
+
+	details := &github.PRDetails{Number: 42, Title: "Fix cache", HeadSHA: "aaa"}
+	rec := injectedRecord(dir, "org/repo", 42, details, []string{"cache.go"})
+	if err := writeContext(dir, rec, details); err != nil {
+		t.Fatal(err)
+	}
+	saveRecord(dir, rec)
+	if err := SetNote(dir, "What Changed Since Your Last Review", "- `cache.go`"); err != nil {
+		t.Fatalf("SetNote() error: %v", err)
+	}
+	if err := SetPlaybook(dir, "perf", "Look for N+1 queries."); err != nil {
+		t.Fatalf("SetPlaybook() error: %v", err)
+	}
+
+	// Re-injecting the live worktree keeps its notes and playbook
+	if rec := injectedRecord(dir, "org/repo", 42, details, nil); len(rec.Notes) != 1 || rec.Playbook != "perf" {
+		t.Fatalf("re-injected record = %+v, want the note and playbook kept", rec)
+	}
+
+	// What zen review delete does, then checking the PR out again
+	os.RemoveAll(dir)
+	ForgetContext(dir)
+	os.MkdirAll(dir, 0o755)
+	details.HeadSHA = "bbb"
+	rec = injectedRecord(dir, "org/repo", 42, details, []string{"cache.go"})
+	if len(rec.Notes) != 0 || rec.Playbook != "" {
+		t.Fatalf("record of the new checkout = %+v, want no notes or playbook", rec)
+	}
+	if err := writeContext(dir, rec, details); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "CLAUDE.local.md"))
+	if doc := string(data); strings.Contains(doc, "Since Your Last Review") || strings.Contains(doc, "N+1") {
+		t.Errorf("new checkout's context kept the deleted worktree's notes:\n%s", doc)
+	}
+	if n := len(loadRecords()); n != 0 {
+		t.Errorf("%d context records before the new checkout is saved, want 0", n)
+	}
+}
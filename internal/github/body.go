@@ -0,0 +1,46 @@
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultPRTemplate is the body template used by zen pr create when the
+// config has no pr_template.
+const DefaultPRTemplate = `{{if .Summary}}## Summary
+
+{{.Summary}}
+
+{{end}}## Commits
+
+{{range .Commits}}- {{.}}
+{{end}}`
+
+// PRBodyData is the data available to PR body templates.
+type PRBodyData struct {
+	Title   string
+	Branch  string
+	Base    string
+	Commits []string // "<short sha> <subject>", oldest first
+	Files   []string // changed paths
+	Summary string   // Claude-generated summary of the diff, "" unless requested
+}
+
+// RenderPRBody executes the text/template tmpl (DefaultPRTemplate when
+// empty) with data.
+func RenderPRBody(tmpl string, data PRBodyData) (string, error) {
+	if strings.TrimSpace(tmpl) == "" {
+		tmpl = DefaultPRTemplate
+	}
+	t, err := template.New("pr").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parsing PR template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering PR template: %w", err)
+	}
+	return strings.TrimSpace(buf.String()) + "\n", nil
+}
@@ -0,0 +1,45 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderPRBody(t *testing.T) {
+	data := PRBodyData{
+		Title:   "Add retries",
+		Branch:  "mgreau/retries",
+		Base:    "main",
+		Commits: []string{"abc1234 Add retry loop", "def5678 Test retries"},
+		Files:   []string{"cmd/watch.go"},
+	}
+
+	body, err := RenderPRBody("", data)
+	if err != nil {
+		t.Fatalf("RenderPRBody() error: %v", err)
+	}
+	if strings.Contains(body, "## Summary") {
+		t.Errorf("default body without summary should have no Summary section:\n%s", body)
+	}
+	if !strings.Contains(body, "- abc1234 Add retry loop\n- def5678 Test retries\n") {
+		t.Errorf("default body should list commits:\n%s", body)
+	}
+
+	data.Summary = "Retries failed setups."
+	body, _ = RenderPRBody("", data)
+	if !strings.HasPrefix(body, "## Summary\n\nRetries failed setups.\n") {
+		t.Errorf("default body should start with the summary:\n%s", body)
+	}
+
+	body, err = RenderPRBody("{{.Branch}} → {{.Base}}, {{len .Files}} file(s)", data)
+	if err != nil {
+		t.Fatalf("RenderPRBody() custom error: %v", err)
+	}
+	if body != "mgreau/retries → main, 1 file(s)\n" {
+		t.Errorf("custom body = %q", body)
+	}
+
+	if _, err := RenderPRBody("{{.Nope", data); err == nil {
+		t.Error("RenderPRBody() should fail on a malformed template")
+	}
+}
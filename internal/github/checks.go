@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v75/github"
+)
+
+// Check states, normalized across check runs and commit statuses.
+const (
+	CheckPending = "pending"
+	CheckPassed  = "passed"
+	CheckFailed  = "failed"
+)
+
+// Check is one CI result on a PR's head commit: a check run (GitHub
+// Actions, apps) or a legacy commit status.
+type Check struct {
+	Name        string    `json:"name"`
+	State       string    `json:"state"`      // pending, passed or failed
+	Conclusion  string    `json:"conclusion"` // raw GitHub conclusion/state, e.g. "timed_out"
+	StartedAt   time.Time `json:"started_at,omitzero"`
+	CompletedAt time.Time `json:"completed_at,omitzero"`
+	URL         string    `json:"url"`
+}
+
+// Duration returns how long the check ran, or has been running so far.
+func (c Check) Duration() time.Duration {
+	if c.StartedAt.IsZero() {
+		return 0
+	}
+	end := c.CompletedAt
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return end.Sub(c.StartedAt)
+}
+
+// checkRunState maps a check run's status and conclusion to a Check state.
+func checkRunState(status, conclusion string) string {
+	if status != "completed" {
+		return CheckPending
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return CheckPassed
+	default: // failure, cancelled, timed_out, action_required, stale, ...
+		return CheckFailed
+	}
+}
+
+// statusState maps a commit status state to a Check state.
+func statusState(state string) string {
+	switch state {
+	case "success":
+		return CheckPassed
+	case "pending", "":
+		return CheckPending
+	default: // failure, error
+		return CheckFailed
+	}
+}
+
+// SummarizeChecks counts checks by state.
+func SummarizeChecks(checks []Check) (pending, passed, failed int) {
+	for _, c := range checks {
+		switch c.State {
+		case CheckPending:
+			pending++
+		case CheckPassed:
+			passed++
+		default:
+			failed++
+		}
+	}
+	return pending, passed, failed
+}
+
+// GetPRChecks returns the check runs and commit statuses on the PR's head
+// commit, along with the head SHA.
+func (c *Client) GetPRChecks(ctx context.Context, fullRepo string, prNumber int) (string, []Check, error) {
+	owner, repo := splitRepo(fullRepo)
+	pr, _, err := c.gh.PullRequests.Get(ctx, owner, repo, prNumber)
+	if err != nil {
+		return "", nil, fmt.Errorf("fetching PR #%d: %w", prNumber, err)
+	}
+	sha := pr.GetHead().GetSHA()
+	checks, err := c.listChecks(ctx, owner, repo, sha)
+	if err != nil {
+		return "", nil, err
+	}
+	return sha, checks, nil
+}
+
+// listChecks returns the check runs and commit statuses on a commit.
+func (c *Client) listChecks(ctx context.Context, owner, repo, sha string) ([]Check, error) {
+	var checks []Check
+	opts := &gh.ListCheckRunsOptions{ListOptions: gh.ListOptions{PerPage: 100}}
+	for {
+		runs, resp, err := c.gh.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, opts)
+		if err != nil {
+			return nil, fmt.Errorf("listing check runs: %w", err)
+		}
+		for _, r := range runs.CheckRuns {
+			checks = append(checks, Check{
+				Name:        r.GetName(),
+				State:       checkRunState(r.GetStatus(), r.GetConclusion()),
+				Conclusion:  strings.ToLower(r.GetConclusion()),
+				StartedAt:   r.GetStartedAt().Time,
+				CompletedAt: r.GetCompletedAt().Time,
+				URL:         r.GetHTMLURL(),
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	status, _, err := c.gh.Repositories.GetCombinedStatus(ctx, owner, repo, sha, &gh.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("fetching commit statuses: %w", err)
+	}
+	for _, s := range status.Statuses {
+		ch := Check{
+			Name:       s.GetContext(),
+			State:      statusState(s.GetState()),
+			Conclusion: s.GetState(),
+			StartedAt:  s.GetCreatedAt().Time,
+			URL:        s.GetTargetURL(),
+		}
+		if ch.State != CheckPending {
+			ch.CompletedAt = s.GetUpdatedAt().Time
+		}
+		checks = append(checks, ch)
+	}
+	return checks, nil
+}
+
+// PRActivity is a snapshot of a PR's state, head commit, comments and CI,
+// compared between polls to report what changed on a watched PR.
+type PRActivity struct {
+	Title         string `json:"title"`
+	State         string `json:"state"` // OPEN, CLOSED or MERGED
+	HeadSHA       string `json:"head_sha"`
+	Comments      int    `json:"comments"` // conversation and review comments
+	ChecksPending int    `json:"checks_pending"`
+	ChecksPassed  int    `json:"checks_passed"`
+	ChecksFailed  int    `json:"checks_failed"`
+}
+
+// ChecksDone reports whether the head commit has checks and all of them
+// have completed.
+func (a *PRActivity) ChecksDone() bool {
+	return a.ChecksPending == 0 && a.ChecksPassed+a.ChecksFailed > 0
+}
+
+// GetPRActivity fetches a PR's activity snapshot. Checks are only listed
+// while the PR is open.
+func (c *Client) GetPRActivity(ctx context.Context, fullRepo string, prNumber int) (*PRActivity, error) {
+	owner, repo := splitRepo(fullRepo)
+	pr, _, err := c.gh.PullRequests.Get(ctx, owner, repo, prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("fetching PR #%d: %w", prNumber, err)
+	}
+	a := &PRActivity{
+		Title:    pr.GetTitle(),
+		State:    strings.ToUpper(pr.GetState()),
+		HeadSHA:  pr.GetHead().GetSHA(),
+		Comments: pr.GetComments() + pr.GetReviewComments(),
+	}
+	if pr.GetMerged() {
+		a.State = "MERGED"
+	}
+	if a.State != "OPEN" {
+		return a, nil
+	}
+	checks, err := c.listChecks(ctx, owner, repo, a.HeadSHA)
+	if err != nil {
+		return nil, err
+	}
+	a.ChecksPending, a.ChecksPassed, a.ChecksFailed = SummarizeChecks(checks)
+	return a, nil
+}
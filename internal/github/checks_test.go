@@ -0,0 +1,51 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckRunState(t *testing.T) {
+	tests := []struct {
+		status, conclusion, want string
+	}{
+		{"queued", "", CheckPending},
+		{"in_progress", "", CheckPending},
+		{"completed", "success", CheckPassed},
+		{"completed", "skipped", CheckPassed},
+		{"completed", "failure", CheckFailed},
+		{"completed", "timed_out", CheckFailed},
+	}
+	for _, tt := range tests {
+		if got := checkRunState(tt.status, tt.conclusion); got != tt.want {
+			t.Errorf("checkRunState(%q, %q) = %q, want %q", tt.status, tt.conclusion, got, tt.want)
+		}
+	}
+}
+
+func TestStatusState(t *testing.T) {
+	for state, want := range map[string]string{"success": CheckPassed, "pending": CheckPending, "error": CheckFailed, "failure": CheckFailed} {
+		if got := statusState(state); got != want {
+			t.Errorf("statusState(%q) = %q, want %q", state, got, want)
+		}
+	}
+}
+
+func TestSummarizeChecks(t *testing.T) {
+	checks := []Check{{State: CheckPassed}, {State: CheckPending}, {State: CheckFailed}, {State: CheckPassed}}
+	pending, passed, failed := SummarizeChecks(checks)
+	if pending != 1 || passed != 2 || failed != 1 {
+		t.Errorf("SummarizeChecks() = %d, %d, %d; want 1, 2, 1", pending, passed, failed)
+	}
+}
+
+func TestCheckDuration(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	c := Check{StartedAt: start, CompletedAt: start.Add(90 * time.Second)}
+	if got := c.Duration(); got != 90*time.Second {
+		t.Errorf("Duration() = %s, want 1m30s", got)
+	}
+	if got := (Check{}).Duration(); got != 0 {
+		t.Errorf("Duration() without start = %s, want 0", got)
+	}
+}
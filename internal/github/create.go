@@ -0,0 +1,49 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	gh "github.com/google/go-github/v75/github"
+)
+
+// NewPR describes a pull request to open.
+type NewPR struct {
+	Title string
+	Head  string // branch to merge from
+	Base  string // branch to merge into
+	Body  string
+	Draft bool
+	// MaintainerCanModify lets the base repo's maintainers push to the
+	// head branch of a cross-fork PR.
+	MaintainerCanModify bool
+}
+
+// CreatePR opens a pull request and returns its details.
+func (c *Client) CreatePR(ctx context.Context, fullRepo string, p NewPR) (*PRDetails, error) {
+	owner, repo := splitRepo(fullRepo)
+	pr, _, err := c.gh.PullRequests.Create(ctx, owner, repo, &gh.NewPullRequest{
+		Title: gh.Ptr(p.Title),
+		Head:  gh.Ptr(p.Head),
+		Base:  gh.Ptr(p.Base),
+		Body:  gh.Ptr(p.Body),
+		Draft: gh.Ptr(p.Draft),
+
+		MaintainerCanModify: gh.Ptr(p.MaintainerCanModify),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating PR for %s: %w", p.Head, err)
+	}
+	return &PRDetails{
+		Number:      pr.GetNumber(),
+		Title:       pr.GetTitle(),
+		Author:      pr.GetUser().GetLogin(),
+		State:       pr.GetState(),
+		HeadRefName: pr.GetHead().GetRef(),
+		BaseRefName: pr.GetBase().GetRef(),
+		Body:        pr.GetBody(),
+		CreatedAt:   pr.GetCreatedAt().Format("2006-01-02T15:04:05Z"),
+		URL:         pr.GetHTMLURL(),
+		HeadSHA:     pr.GetHead().GetSHA(),
+	}, nil
+}
@@ -0,0 +1,132 @@
+package github
+
+import (
+	"context"
+	"path"
+	"sort"
+
+	gh "github.com/google/go-github/v75/github"
+)
+
+// FileStat is one file changed by a PR.
+type FileStat struct {
+	Path      string `json:"path"`
+	Status    string `json:"status"` // added, removed, modified, renamed, ...
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// DirStat totals the changes of a PR's files in one directory.
+type DirStat struct {
+	Dir       string     `json:"dir"`
+	Additions int        `json:"additions"`
+	Deletions int        `json:"deletions"`
+	Files     []FileStat `json:"files"`
+}
+
+// GetPRFileStats returns the files changed by a PR with their line counts.
+func (c *Client) GetPRFileStats(ctx context.Context, fullRepo string, prNumber int) ([]FileStat, error) {
+	owner, repo := splitRepo(fullRepo)
+	var stats []FileStat
+	opts := &gh.ListOptions{PerPage: 100}
+
+	for {
+		files, resp, err := c.gh.PullRequests.ListFiles(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			stats = append(stats, FileStat{
+				Path:      f.GetFilename(),
+				Status:    f.GetStatus(),
+				Additions: f.GetAdditions(),
+				Deletions: f.GetDeletions(),
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return stats, nil
+}
+
+// GroupByDir groups files by their directory, largest change first. Files
+// at the repository root are grouped under ".".
+func GroupByDir(files []FileStat) []DirStat {
+	byDir := make(map[string]*DirStat)
+	var dirs []*DirStat
+	for _, f := range files {
+		dir := path.Dir(f.Path)
+		d, ok := byDir[dir]
+		if !ok {
+			d = &DirStat{Dir: dir}
+			byDir[dir] = d
+			dirs = append(dirs, d)
+		}
+		d.Additions += f.Additions
+		d.Deletions += f.Deletions
+		d.Files = append(d.Files, f)
+	}
+
+	sort.SliceStable(dirs, func(i, j int) bool {
+		ci := dirs[i].Additions + dirs[i].Deletions
+		cj := dirs[j].Additions + dirs[j].Deletions
+		if ci != cj {
+			return ci > cj
+		}
+		return dirs[i].Dir < dirs[j].Dir
+	})
+	result := make([]DirStat, 0, len(dirs))
+	for _, d := range dirs {
+		sort.Slice(d.Files, func(i, j int) bool { return d.Files[i].Path < d.Files[j].Path })
+		result = append(result, *d)
+	}
+	return result
+}
+
+// Reviewers lists who was asked to review a PR and who already did.
+type Reviewers struct {
+	// Requested are pending review requests: user logins and org/team slugs.
+	Requested []string `json:"requested"`
+	// Reviews maps each reviewer's login to their latest review state
+	// (APPROVED, CHANGES_REQUESTED, COMMENTED, ...).
+	Reviews map[string]string `json:"reviews"`
+}
+
+// GetReviewers returns the pending review requests and submitted reviews
+// on a PR.
+func (c *Client) GetReviewers(ctx context.Context, fullRepo string, prNumber int) (*Reviewers, error) {
+	owner, repo := splitRepo(fullRepo)
+	requested, _, err := c.gh.PullRequests.ListReviewers(ctx, owner, repo, prNumber, nil)
+	if err != nil {
+		return nil, err
+	}
+	r := &Reviewers{Requested: []string{}, Reviews: map[string]string{}}
+	for _, u := range requested.Users {
+		r.Requested = append(r.Requested, u.GetLogin())
+	}
+	for _, t := range requested.Teams {
+		r.Requested = append(r.Requested, owner+"/"+t.GetSlug())
+	}
+
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		reviews, resp, err := c.gh.PullRequests.ListReviews(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, rv := range reviews {
+			// Comments after an approval don't withdraw it
+			if rv.GetState() == "COMMENTED" && r.Reviews[rv.GetUser().GetLogin()] != "" {
+				continue
+			}
+			r.Reviews[rv.GetUser().GetLogin()] = rv.GetState()
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return r, nil
+}
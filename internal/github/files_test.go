@@ -0,0 +1,41 @@
+package github
+
+import "testing"
+
+func TestGroupByDir(t *testing.T) {
+	files := []FileStat{
+		{Path: "README.md", Additions: 1},
+		{Path: "cmd/watch.go", Additions: 10, Deletions: 2},
+		{Path: "internal/journal/journal.go", Additions: 120},
+		{Path: "cmd/status.go", Additions: 30},
+		{Path: "internal/journal/forecast.go", Additions: 80},
+	}
+
+	dirs := GroupByDir(files)
+	if len(dirs) != 3 {
+		t.Fatalf("GroupByDir() = %d dirs, want 3: %+v", len(dirs), dirs)
+	}
+	want := []struct {
+		dir       string
+		add, del  int
+		firstFile string
+		fileCount int
+	}{
+		{"internal/journal", 200, 0, "internal/journal/forecast.go", 2},
+		{"cmd", 40, 2, "cmd/status.go", 2},
+		{".", 1, 0, "README.md", 1},
+	}
+	for i, w := range want {
+		d := dirs[i]
+		if d.Dir != w.dir || d.Additions != w.add || d.Deletions != w.del {
+			t.Errorf("dirs[%d] = %s +%d -%d, want %s +%d -%d", i, d.Dir, d.Additions, d.Deletions, w.dir, w.add, w.del)
+		}
+		if len(d.Files) != w.fileCount || d.Files[0].Path != w.firstFile {
+			t.Errorf("dirs[%d] files = %+v, want %d starting with %s", i, d.Files, w.fileCount, w.firstFile)
+		}
+	}
+
+	if got := GroupByDir(nil); len(got) != 0 {
+		t.Errorf("GroupByDir(nil) = %+v, want empty", got)
+	}
+}
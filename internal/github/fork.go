@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v75/github"
+)
+
+// ForkAuto asks EnsureFork for the authenticated user's fork, creating it
+// when the user has none.
+const ForkAuto = "auto"
+
+// forkReadyTimeout bounds the wait for GitHub to finish creating a fork.
+const forkReadyTimeout = 2 * time.Minute
+
+// EnsureFork returns the full name (owner/name) of the fork of fullRepo to
+// push branches to. fork is either ForkAuto, for the authenticated user's
+// fork, created when missing, or an explicit owner/name, which must be an
+// existing fork of fullRepo.
+func (c *Client) EnsureFork(ctx context.Context, fullRepo, fork string) (string, error) {
+	if fork != ForkAuto {
+		owner, name := splitRepo(fork)
+		r, _, err := c.gh.Repositories.Get(ctx, owner, name)
+		if err != nil {
+			return "", fmt.Errorf("looking up fork %s: %w", fork, err)
+		}
+		if !isForkOf(r, fullRepo) {
+			return "", fmt.Errorf("%s is not a fork of %s", fork, fullRepo)
+		}
+		return r.GetFullName(), nil
+	}
+
+	user, _, err := c.gh.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("fetching current user: %w", err)
+	}
+	login := user.GetLogin()
+	_, name := splitRepo(fullRepo)
+	if r, _, err := c.gh.Repositories.Get(ctx, login, name); err == nil && isForkOf(r, fullRepo) {
+		return r.GetFullName(), nil
+	}
+
+	// GitHub returns the existing fork if the user has one under another
+	// name, so creating is also how a renamed fork is found.
+	owner, _ := splitRepo(fullRepo)
+	r, _, err := c.gh.Repositories.CreateFork(ctx, owner, name, nil)
+	var accepted *gh.AcceptedError
+	if err != nil && !errors.As(err, &accepted) {
+		return "", fmt.Errorf("forking %s: %w", fullRepo, err)
+	}
+	full := r.GetFullName()
+	if full == "" {
+		return "", fmt.Errorf("forking %s: GitHub returned no fork name", fullRepo)
+	}
+	return full, c.waitForRepo(ctx, full)
+}
+
+// waitForRepo polls until the repo fullRepo is reachable, which for a new
+// fork takes a few seconds to a few minutes.
+func (c *Client) waitForRepo(ctx context.Context, fullRepo string) error {
+	owner, name := splitRepo(fullRepo)
+	deadline := time.Now().Add(forkReadyTimeout)
+	for {
+		_, resp, err := c.gh.Repositories.Get(ctx, owner, name)
+		if err == nil {
+			return nil
+		}
+		if resp == nil || resp.StatusCode != http.StatusNotFound || time.Now().After(deadline) {
+			return fmt.Errorf("waiting for fork %s: %w", fullRepo, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// isForkOf reports whether r is a fork of fullRepo, directly or through
+// another fork.
+func isForkOf(r *gh.Repository, fullRepo string) bool {
+	if !r.GetFork() {
+		return false
+	}
+	return strings.EqualFold(r.GetParent().GetFullName(), fullRepo) ||
+		strings.EqualFold(r.GetSource().GetFullName(), fullRepo)
+}
+
+// ForkURL returns the clone URL of fork using the same transport as
+// originURL: SSH when origin is an SSH remote, HTTPS otherwise.
+func ForkURL(originURL, fork string) string {
+	if strings.HasPrefix(originURL, "git@") || strings.HasPrefix(originURL, "ssh://") {
+		return "git@github.com:" + fork + ".git"
+	}
+	return "https://github.com/" + fork + ".git"
+}
+
+// RemoteOwner returns the owner of a GitHub remote URL, e.g. "mgreau" for
+// git@github.com:mgreau/zen.git or https://github.com/mgreau/zen.
+func RemoteOwner(url string) string {
+	rest := url
+	for _, prefix := range []string{"git@github.com:", "ssh://git@github.com/", "https://github.com/", "http://github.com/"} {
+		if r, ok := strings.CutPrefix(url, prefix); ok {
+			rest = r
+			break
+		}
+	}
+	if rest == url {
+		return ""
+	}
+	owner, _, ok := strings.Cut(rest, "/")
+	if !ok {
+		return ""
+	}
+	return owner
+}
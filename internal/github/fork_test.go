@@ -0,0 +1,49 @@
+package github
+
+import (
+	"testing"
+
+	gh "github.com/google/go-github/v75/github"
+)
+
+func TestForkURL(t *testing.T) {
+	tests := map[string]string{
+		"git@github.com:org/zen.git":       "git@github.com:me/zen.git",
+		"ssh://git@github.com/org/zen.git": "git@github.com:me/zen.git",
+		"https://github.com/org/zen.git":   "https://github.com/me/zen.git",
+	}
+	for origin, want := range tests {
+		if got := ForkURL(origin, "me/zen"); got != want {
+			t.Errorf("ForkURL(%q) = %q, want %q", origin, got, want)
+		}
+	}
+}
+
+func TestRemoteOwner(t *testing.T) {
+	tests := map[string]string{
+		"git@github.com:mgreau/zen.git":       "mgreau",
+		"ssh://git@github.com/mgreau/zen.git": "mgreau",
+		"https://github.com/mgreau/zen":       "mgreau",
+		"/tmp/fork.git":                       "",
+		"git@gitlab.com:mgreau/zen.git":       "",
+	}
+	for url, want := range tests {
+		if got := RemoteOwner(url); got != want {
+			t.Errorf("RemoteOwner(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestIsForkOf(t *testing.T) {
+	parent := &gh.Repository{FullName: gh.Ptr("Org/zen")}
+	fork := &gh.Repository{Fork: gh.Ptr(true), Parent: parent, Source: parent}
+	if !isForkOf(fork, "org/zen") {
+		t.Error("isForkOf() = false for a fork of org/zen")
+	}
+	if isForkOf(fork, "org/other") {
+		t.Error("isForkOf() = true for another repo")
+	}
+	if isForkOf(&gh.Repository{FullName: gh.Ptr("me/zen")}, "org/zen") {
+		t.Error("isForkOf() = true for a repo that is not a fork")
+	}
+}
@@ -0,0 +1,28 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// GistFile is one file of a gist.
+type GistFile struct {
+	Name    string
+	Content string
+}
+
+// GetGistFiles returns the files of the gist with the given ID, sorted by
+// name. Secret gists are readable with the ID alone.
+func (c *Client) GetGistFiles(ctx context.Context, id string) ([]GistFile, error) {
+	g, _, err := c.gh.Gists.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("fetching gist %s: %w", id, err)
+	}
+	var files []GistFile
+	for name, f := range g.Files {
+		files = append(files, GistFile{Name: string(name), Content: f.GetContent()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files, nil
+}
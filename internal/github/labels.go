@@ -0,0 +1,59 @@
+package github
+
+import (
+	"encoding/json"
+	"strings"
+
+	gh "github.com/google/go-github/v75/github"
+)
+
+// Label is a PR label.
+type Label struct {
+	Name  string `json:"name"`
+	Color string `json:"color"` // hex RGB without '#', e.g. "d73a4a"
+}
+
+// Labels are the labels of a PR. They decode from a GraphQL connection
+// ({"nodes": [...]}) as well as from a plain list, and encode as a list.
+type Labels []Label
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *Labels) UnmarshalJSON(data []byte) error {
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+		var conn struct {
+			Nodes []Label `json:"nodes"`
+		}
+		if err := json.Unmarshal(data, &conn); err != nil {
+			return err
+		}
+		*l = conn.Nodes
+		return nil
+	}
+	var list []Label
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// Names returns the label names.
+func (l Labels) Names() []string {
+	names := make([]string, len(l))
+	for i, label := range l {
+		names[i] = label.Name
+	}
+	return names
+}
+
+// restLabels converts go-github labels.
+func restLabels(labels []*gh.Label) Labels {
+	if len(labels) == 0 {
+		return nil
+	}
+	out := make(Labels, len(labels))
+	for i, l := range labels {
+		out[i] = Label{Name: l.GetName(), Color: l.GetColor()}
+	}
+	return out
+}
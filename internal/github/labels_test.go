@@ -0,0 +1,55 @@
+package github
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLabels_UnmarshalJSON(t *testing.T) {
+	want := Labels{{Name: "hold", Color: "d73a4a"}, {Name: "urgent", Color: "ffffff"}}
+	tests := map[string]string{
+		"connection": `{"nodes": [{"name": "hold", "color": "d73a4a"}, {"name": "urgent", "color": "ffffff"}]}`,
+		"list":       `[{"name": "hold", "color": "d73a4a"}, {"name": "urgent", "color": "ffffff"}]`,
+	}
+	for name, in := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got Labels
+			if err := json.Unmarshal([]byte(in), &got); err != nil {
+				t.Fatalf("Unmarshal() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestLabels_roundTrip(t *testing.T) {
+	// Caches store labels as a list and must read them back
+	rr := ReviewRequest{Number: 1, Labels: Labels{{Name: "hold"}}}
+	data, err := json.Marshal(rr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got ReviewRequest
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal(%s) error: %v", data, err)
+	}
+	if names := got.Labels.Names(); len(names) != 1 || names[0] != "hold" {
+		t.Errorf("Labels = %v, want [hold]", names)
+	}
+}
+
+func TestParseSearchPage_labels(t *testing.T) {
+	out := []byte(`{"data":{"search":{"nodes": [
+    {"number": 7, "labels": {"nodes": [{"name": "no-ai-review", "color": "0e8a16"}]}}
+  ]}}}`)
+	page, err := parseSearchPage[ReviewRequest](out)
+	if err != nil {
+		t.Fatalf("parseSearchPage() error: %v", err)
+	}
+	if got := page.Nodes[0].Labels; len(got) != 1 || got[0].Name != "no-ai-review" || got[0].Color != "0e8a16" {
+		t.Errorf("Labels = %+v, want [no-ai-review]", got)
+	}
+}
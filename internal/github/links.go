@@ -0,0 +1,57 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PRRef identifies a PR in any repo.
+type PRRef struct {
+	Repo   string `json:"repo"` // owner/name
+	Number int    `json:"number"`
+}
+
+// String returns the ref as owner/name#number.
+func (r PRRef) String() string {
+	return fmt.Sprintf("%s#%d", r.Repo, r.Number)
+}
+
+// linkKeyword starts the part of a line that names paired PRs, e.g.
+// "Depends on org/api#12" or "Companion: https://github.com/org/web/pull/7".
+var linkKeyword = regexp.MustCompile(`(?i)\b(depends[ -]on|companion( pr)?( of| to)?|paired with)\b`)
+
+// prRefPattern matches a PR URL, owner/name#N or #N.
+var prRefPattern = regexp.MustCompile(`https?://[^/\s]+/([\w.-]+/[\w.-]+)/pull/(\d+)|([\w.-]+/[\w.-]+)?#(\d+)`)
+
+// LinkedPRs returns the PRs a PR body pairs it with on "Depends on",
+// "Companion" or "Paired with" lines, in order and without repeats. A bare
+// #N refers to a PR of repo (owner/name).
+func LinkedPRs(body, repo string) []PRRef {
+	var refs []PRRef
+	seen := make(map[PRRef]bool)
+	for _, line := range strings.Split(body, "\n") {
+		loc := linkKeyword.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+		for _, m := range prRefPattern.FindAllStringSubmatch(line[loc[1]:], -1) {
+			ref := PRRef{Repo: m[1]}
+			num := m[2]
+			if ref.Repo == "" {
+				ref.Repo, num = m[3], m[4]
+			}
+			if ref.Repo == "" {
+				ref.Repo = repo
+			}
+			ref.Number, _ = strconv.Atoi(num)
+			if ref.Number == 0 || seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
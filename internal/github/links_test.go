@@ -0,0 +1,24 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinkedPRs(t *testing.T) {
+	body := "Adds the login endpoint.\n\n" +
+		"Depends on org/api#12 and https://github.com/org/web/pull/7\n" +
+		"**Companion PR:** #40\n" +
+		"Fixes #3\n" +
+		"Depends-On: org/api#12\n"
+	want := []PRRef{{"org/api", 12}, {"org/web", 7}, {"org/mono", 40}}
+	if got := LinkedPRs(body, "org/mono"); !reflect.DeepEqual(got, want) {
+		t.Errorf("LinkedPRs() = %v, want %v", got, want)
+	}
+	if got := LinkedPRs("Closes #3, see org/api#12", "org/mono"); got != nil {
+		t.Errorf("LinkedPRs() without a link keyword = %v, want none", got)
+	}
+	if got := (PRRef{"org/api", 12}).String(); got != "org/api#12" {
+		t.Errorf("String() = %q", got)
+	}
+}
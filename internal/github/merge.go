@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gh "github.com/google/go-github/v75/github"
+)
+
+// Merge methods.
+const (
+	MergeCommit = "merge"
+	MergeSquash = "squash"
+	MergeRebase = "rebase"
+)
+
+// MergeState is what decides whether a PR can be merged safely: its
+// reviews, the CI checks of its head commit and whether it merges cleanly.
+type MergeState struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Author  string `json:"author"`
+	State   string `json:"state"` // OPEN, CLOSED or MERGED
+	Draft   bool   `json:"draft,omitempty"`
+	HeadRef string `json:"head_ref"`
+	HeadSHA string `json:"head_sha"`
+	// Mergeable is nil while GitHub is still computing it.
+	Mergeable *bool `json:"mergeable"`
+	// MergeableState is GitHub's mergeable_state, e.g. "clean", "dirty",
+	// "behind" or "blocked".
+	MergeableState   string   `json:"mergeable_state"`
+	Approvals        []string `json:"approvals"`         // reviewers whose latest review approves
+	ChangesRequested []string `json:"changes_requested"` // reviewers whose latest review requests changes
+	Checks           []Check  `json:"checks"`
+}
+
+// GetMergeState looks up a PR, its reviews and the checks of its head
+// commit.
+func (c *Client) GetMergeState(ctx context.Context, fullRepo string, prNumber int) (*MergeState, error) {
+	owner, repo := splitRepo(fullRepo)
+	pr, _, err := c.gh.PullRequests.Get(ctx, owner, repo, prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("fetching PR #%d: %w", prNumber, err)
+	}
+	s := &MergeState{
+		Number:         pr.GetNumber(),
+		Title:          pr.GetTitle(),
+		Author:         pr.GetUser().GetLogin(),
+		State:          strings.ToUpper(pr.GetState()),
+		Draft:          pr.GetDraft(),
+		HeadRef:        pr.GetHead().GetRef(),
+		HeadSHA:        pr.GetHead().GetSHA(),
+		Mergeable:      pr.Mergeable,
+		MergeableState: pr.GetMergeableState(),
+	}
+	if pr.GetMerged() {
+		s.State = "MERGED"
+	}
+
+	var reviews []*gh.PullRequestReview
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := c.gh.PullRequests.ListReviews(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("fetching reviews of PR #%d: %w", prNumber, err)
+		}
+		reviews = append(reviews, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	s.Approvals, s.ChangesRequested = reviewVerdicts(reviews)
+
+	if s.Checks, err = c.listChecks(ctx, owner, repo, s.HeadSHA); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// reviewVerdicts returns the reviewers whose latest approval or change
+// request, in review order, approves or requests changes. Comments don't
+// change a reviewer's verdict, and a dismissal clears it.
+func reviewVerdicts(reviews []*gh.PullRequestReview) (approvals, changesRequested []string) {
+	var order []string
+	latest := make(map[string]string)
+	for _, r := range reviews {
+		login := r.GetUser().GetLogin()
+		switch state := r.GetState(); state {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			if _, ok := latest[login]; !ok {
+				order = append(order, login)
+			}
+			latest[login] = state
+		}
+	}
+	for _, login := range order {
+		switch latest[login] {
+		case "APPROVED":
+			approvals = append(approvals, login)
+		case "CHANGES_REQUESTED":
+			changesRequested = append(changesRequested, login)
+		}
+	}
+	return approvals, changesRequested
+}
+
+// Problems lists why the PR should not be merged by login, e.g. failing
+// checks or merge conflicts; none means it is safe to merge.
+func (s *MergeState) Problems(login string) []string {
+	var problems []string
+	if s.State != "OPEN" {
+		return []string{fmt.Sprintf("PR is %s", strings.ToLower(s.State))}
+	}
+	if login != "" && !strings.EqualFold(s.Author, login) {
+		problems = append(problems, fmt.Sprintf("PR is by @%s, not you", s.Author))
+	}
+	if s.Draft {
+		problems = append(problems, "PR is a draft")
+	}
+	if len(s.ChangesRequested) > 0 {
+		problems = append(problems, "changes requested by @"+strings.Join(s.ChangesRequested, ", @"))
+	}
+	if len(s.Approvals) == 0 {
+		problems = append(problems, "not approved")
+	}
+
+	var failed, pending []string
+	for _, c := range s.Checks {
+		switch c.State {
+		case CheckFailed:
+			failed = append(failed, c.Name)
+		case CheckPending:
+			pending = append(pending, c.Name)
+		}
+	}
+	if len(failed) > 0 {
+		problems = append(problems, fmt.Sprintf("%d check(s) failed: %s", len(failed), strings.Join(failed, ", ")))
+	}
+	if len(pending) > 0 {
+		problems = append(problems, fmt.Sprintf("%d check(s) still running: %s", len(pending), strings.Join(pending, ", ")))
+	}
+
+	switch {
+	case s.Mergeable == nil:
+		problems = append(problems, "GitHub is still computing whether it merges cleanly, retry in a moment")
+	case !*s.Mergeable || s.MergeableState == "dirty":
+		problems = append(problems, "has merge conflicts")
+	case s.MergeableState == "behind":
+		problems = append(problems, "branch is behind its base, update it first")
+	case s.MergeableState == "blocked" && len(problems) == 0:
+		problems = append(problems, "blocked by branch protection, e.g. a required review")
+	}
+	return problems
+}
+
+// MergePR merges a PR with method (merge, squash or rebase), provided its
+// head is still sha, and returns the merge commit.
+func (c *Client) MergePR(ctx context.Context, fullRepo string, prNumber int, method, sha string) (string, error) {
+	owner, repo := splitRepo(fullRepo)
+	res, _, err := c.gh.PullRequests.Merge(ctx, owner, repo, prNumber, "", &gh.PullRequestOptions{
+		MergeMethod: method,
+		SHA:         sha,
+	})
+	if err != nil {
+		return "", fmt.Errorf("merging PR #%d: %w", prNumber, err)
+	}
+	if !res.GetMerged() {
+		return "", fmt.Errorf("merging PR #%d: %s", prNumber, res.GetMessage())
+	}
+	return res.GetSHA(), nil
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	gh "github.com/google/go-github/v75/github"
+)
+
+func TestReviewVerdicts(t *testing.T) {
+	review := func(login, state string) *gh.PullRequestReview {
+		return &gh.PullRequestReview{User: &gh.User{Login: gh.Ptr(login)}, State: gh.Ptr(state)}
+	}
+	approvals, changes := reviewVerdicts([]*gh.PullRequestReview{
+		review("alice", "CHANGES_REQUESTED"),
+		review("bob", "APPROVED"),
+		review("alice", "APPROVED"),
+		review("alice", "COMMENTED"), // doesn't undo alice's approval
+		review("carol", "CHANGES_REQUESTED"),
+		review("dave", "APPROVED"),
+		review("dave", "DISMISSED"),
+	})
+	if strings.Join(approvals, ",") != "alice,bob" {
+		t.Errorf("approvals = %v, want [alice bob]", approvals)
+	}
+	if strings.Join(changes, ",") != "carol" {
+		t.Errorf("changes requested = %v, want [carol]", changes)
+	}
+}
+
+func TestMergeStateProblems(t *testing.T) {
+	yes, no := true, false
+	ready := func() *MergeState {
+		return &MergeState{
+			Author:         "me",
+			State:          "OPEN",
+			Mergeable:      &yes,
+			MergeableState: "clean",
+			Approvals:      []string{"alice"},
+			Checks:         []Check{{Name: "build", State: CheckPassed}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		change func(s *MergeState)
+		want   string
+	}{
+		{"ready", func(s *MergeState) {}, ""},
+		{"not mine", func(s *MergeState) { s.Author = "bob" }, "PR is by @bob, not you"},
+		{"merged", func(s *MergeState) { s.State = "MERGED" }, "PR is merged"},
+		{"draft", func(s *MergeState) { s.Draft = true }, "PR is a draft"},
+		{"not approved", func(s *MergeState) { s.Approvals = nil }, "not approved"},
+		{"changes requested", func(s *MergeState) { s.ChangesRequested = []string{"carol"} }, "changes requested by @carol"},
+		{"failed check", func(s *MergeState) { s.Checks[0].State = CheckFailed }, "1 check(s) failed: build"},
+		{"running check", func(s *MergeState) { s.Checks[0].State = CheckPending }, "1 check(s) still running: build"},
+		{"unknown mergeability", func(s *MergeState) { s.Mergeable = nil }, "GitHub is still computing whether it merges cleanly, retry in a moment"},
+		{"conflicts", func(s *MergeState) { s.Mergeable, s.MergeableState = &no, "dirty" }, "has merge conflicts"},
+		{"behind", func(s *MergeState) { s.MergeableState = "behind" }, "branch is behind its base, update it first"},
+		{"blocked", func(s *MergeState) { s.MergeableState = "blocked" }, "blocked by branch protection, e.g. a required review"},
+	}
+	for _, tt := range tests {
+		s := ready()
+		tt.change(s)
+		got := strings.Join(s.Problems("ME"), "; ")
+		if got != tt.want {
+			t.Errorf("%s: Problems() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// Blocked is only reported when nothing else explains it
+	s := ready()
+	s.Approvals, s.MergeableState = nil, "blocked"
+	if got := s.Problems("me"); len(got) != 1 || got[0] != "not approved" {
+		t.Errorf("Problems() = %v, want [not approved]", got)
+	}
+}
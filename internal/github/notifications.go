@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v75/github"
+)
+
+// NotificationReasons are the notification reasons listed by default: the
+// ones that ask something of you rather than report activity.
+var NotificationReasons = []string{"review_requested", "mention", "assign"}
+
+// Notification is a GitHub notification thread about a PR or issue.
+type Notification struct {
+	ID        string    `json:"id"`
+	Repo      string    `json:"repo"` // owner/name
+	Number    int       `json:"number"`
+	Kind      string    `json:"kind"` // subject type, e.g. "PullRequest" or "Issue"
+	Title     string    `json:"title"`
+	Reason    string    `json:"reason"`
+	Unread    bool      `json:"unread"`
+	UpdatedAt time.Time `json:"updated_at"`
+	URL       string    `json:"url"`
+}
+
+// IsPR reports whether the notification is about a pull request.
+func (n Notification) IsPR() bool {
+	return n.Kind == "PullRequest"
+}
+
+// ListNotifications returns the user's notifications, read or not, about
+// PRs and issues in repos (owner/name) whose reason is one of reasons.
+// Notifications already marked done are not returned by GitHub.
+func (c *Client) ListNotifications(ctx context.Context, repos, reasons []string) ([]Notification, error) {
+	var all []*gh.Notification
+	opts := &gh.NotificationListOptions{All: true, ListOptions: gh.ListOptions{PerPage: 50}}
+	for {
+		page, resp, err := c.gh.Activity.ListNotifications(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("listing notifications: %w", err)
+		}
+		all = append(all, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return filterNotifications(all, repos, reasons), nil
+}
+
+// filterNotifications converts the notifications about numbered PRs and
+// issues in repos with one of reasons, keeping GitHub's newest-first order.
+func filterNotifications(ns []*gh.Notification, repos, reasons []string) []Notification {
+	var out []Notification
+	for _, n := range ns {
+		repo := n.GetRepository().GetFullName()
+		if !slices.Contains(repos, repo) || !slices.Contains(reasons, n.GetReason()) {
+			continue
+		}
+		number := subjectNumber(n.GetSubject().GetURL())
+		if number == 0 {
+			continue // releases, check suites, discussions, ...
+		}
+		kind := n.GetSubject().GetType()
+		out = append(out, Notification{
+			ID:        n.GetID(),
+			Repo:      repo,
+			Number:    number,
+			Kind:      kind,
+			Title:     n.GetSubject().GetTitle(),
+			Reason:    n.GetReason(),
+			Unread:    n.GetUnread(),
+			UpdatedAt: n.GetUpdatedAt().Time,
+			URL:       subjectHTMLURL(repo, kind, number),
+		})
+	}
+	return out
+}
+
+// subjectNumber extracts the PR or issue number from a notification
+// subject's API URL, e.g. https://api.github.com/repos/o/r/pulls/42.
+// It returns 0 for other subjects.
+func subjectNumber(apiURL string) int {
+	switch path.Base(path.Dir(apiURL)) {
+	case "pulls", "issues":
+	default:
+		return 0
+	}
+	n, err := strconv.Atoi(path.Base(apiURL))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// subjectHTMLURL returns the github.com page of a PR or issue.
+func subjectHTMLURL(repo, kind string, number int) string {
+	seg := "issues"
+	if kind == "PullRequest" {
+		seg = "pull"
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/%d", repo, seg, number)
+}
+
+// MarkNotificationDone marks a notification thread as done, removing it
+// from the GitHub inbox.
+func (c *Client) MarkNotificationDone(ctx context.Context, id string) error {
+	threadID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid notification id %q", id)
+	}
+	if _, err := c.gh.Activity.MarkThreadDone(ctx, threadID); err != nil {
+		return fmt.Errorf("marking notification %s done: %w", id, err)
+	}
+	return nil
+}
+
+// HasReviewed reports whether login submitted a review (not a pending
+// draft) on the PR.
+func (c *Client) HasReviewed(ctx context.Context, fullRepo string, prNumber int, login string) (bool, error) {
+	owner, repo := splitRepo(fullRepo)
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		reviews, resp, err := c.gh.PullRequests.ListReviews(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return false, fmt.Errorf("listing reviews of #%d: %w", prNumber, err)
+		}
+		for _, rv := range reviews {
+			if strings.EqualFold(rv.GetUser().GetLogin(), login) && rv.GetState() != "PENDING" {
+				return true, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return false, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"testing"
+
+	gh "github.com/google/go-github/v75/github"
+)
+
+func TestSubjectNumber(t *testing.T) {
+	tests := map[string]int{
+		"https://api.github.com/repos/acme/app/pulls/42":        42,
+		"https://api.github.com/repos/acme/app/issues/7":        7,
+		"https://api.github.com/repos/acme/app/releases/123456": 0,
+		"https://api.github.com/repos/acme/app/pulls/abc":       0,
+		"": 0,
+	}
+	for url, want := range tests {
+		if got := subjectNumber(url); got != want {
+			t.Errorf("subjectNumber(%q) = %d, want %d", url, got, want)
+		}
+	}
+}
+
+func TestFilterNotifications(t *testing.T) {
+	n := func(id, repo, reason, typ, url string) *gh.Notification {
+		return &gh.Notification{
+			ID:         gh.Ptr(id),
+			Repository: &gh.Repository{FullName: gh.Ptr(repo)},
+			Reason:     gh.Ptr(reason),
+			Subject:    &gh.NotificationSubject{Type: gh.Ptr(typ), URL: gh.Ptr(url), Title: gh.Ptr("t" + id)},
+		}
+	}
+	ns := []*gh.Notification{
+		n("1", "acme/app", "review_requested", "PullRequest", "https://api.github.com/repos/acme/app/pulls/42"),
+		n("2", "acme/app", "subscribed", "PullRequest", "https://api.github.com/repos/acme/app/pulls/43"),
+		n("3", "other/repo", "mention", "Issue", "https://api.github.com/repos/other/repo/issues/1"),
+		n("4", "acme/app", "mention", "Issue", "https://api.github.com/repos/acme/app/issues/9"),
+		n("5", "acme/app", "mention", "Release", "https://api.github.com/repos/acme/app/releases/5"),
+	}
+
+	got := filterNotifications(ns, []string{"acme/app"}, NotificationReasons)
+	if len(got) != 2 {
+		t.Fatalf("filterNotifications() = %+v, want 2 notifications", got)
+	}
+	if got[0].ID != "1" || got[0].Number != 42 || !got[0].IsPR() || got[0].URL != "https://github.com/acme/app/pull/42" {
+		t.Errorf("got[0] = %+v", got[0])
+	}
+	if got[1].ID != "4" || got[1].IsPR() || got[1].URL != "https://github.com/acme/app/issues/9" {
+		t.Errorf("got[1] = %+v", got[1])
+	}
+}
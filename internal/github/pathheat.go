@@ -0,0 +1,134 @@
+package github
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// PRFiles is the changed files of a PR, for PathHeatMap.
+type PRFiles struct {
+	Number int
+	Author string
+	Merged bool
+	Files  []string
+}
+
+// PathHeat is the activity in one path over a set of PRs.
+type PathHeat struct {
+	Path    string        `json:"path"`
+	PRs     int           `json:"prs"`
+	Open    int           `json:"open"`
+	Merged  int           `json:"merged"`
+	Files   int           `json:"files"` // changed files, summed over the PRs
+	Authors []AuthorCount `json:"authors"`
+}
+
+// AuthorCount is how many PRs an author opened in a path.
+type AuthorCount struct {
+	Login string `json:"login"`
+	PRs   int    `json:"prs"`
+}
+
+// MatchesWatchPath reports whether file is under the watched path wp.
+func MatchesWatchPath(file, wp string) bool {
+	return strings.HasPrefix(file, wp)
+}
+
+// PathHeatMap tallies the PRs changing each of watchPaths, in config order
+// and including paths no PR touched, and the directories outside them,
+// cut to depth segments, that PRs changed, busiest first. Authors are
+// listed by number of PRs, then name.
+func PathHeatMap(prs []PRFiles, watchPaths []string, depth int) (watched, unwatched []PathHeat) {
+	type tally struct {
+		heat    PathHeat
+		authors map[string]int
+	}
+	tallies := make(map[string]*tally)
+	get := func(p string) *tally {
+		t, ok := tallies[p]
+		if !ok {
+			t = &tally{heat: PathHeat{Path: p}, authors: make(map[string]int)}
+			tallies[p] = t
+		}
+		return t
+	}
+	for _, wp := range watchPaths {
+		get(wp)
+	}
+	var others []string
+
+	for _, pr := range prs {
+		files := make(map[string]int)
+		for _, f := range pr.Files {
+			key := ""
+			for _, wp := range watchPaths {
+				if MatchesWatchPath(f, wp) {
+					key = wp
+					files[key]++
+				}
+			}
+			if key != "" {
+				continue
+			}
+			key = dirAtDepth(f, depth)
+			if _, ok := tallies[key]; !ok {
+				others = append(others, key)
+			}
+			get(key)
+			files[key]++
+		}
+		for p, n := range files {
+			t := tallies[p]
+			t.heat.PRs++
+			if pr.Merged {
+				t.heat.Merged++
+			} else {
+				t.heat.Open++
+			}
+			t.heat.Files += n
+			t.authors[pr.Author]++
+		}
+	}
+
+	finish := func(t *tally) PathHeat {
+		h := t.heat
+		h.Authors = []AuthorCount{}
+		for login, n := range t.authors {
+			h.Authors = append(h.Authors, AuthorCount{Login: login, PRs: n})
+		}
+		sort.Slice(h.Authors, func(i, j int) bool {
+			if h.Authors[i].PRs != h.Authors[j].PRs {
+				return h.Authors[i].PRs > h.Authors[j].PRs
+			}
+			return h.Authors[i].Login < h.Authors[j].Login
+		})
+		return h
+	}
+	for _, wp := range watchPaths {
+		watched = append(watched, finish(tallies[wp]))
+	}
+	for _, p := range others {
+		unwatched = append(unwatched, finish(tallies[p]))
+	}
+	sort.SliceStable(unwatched, func(i, j int) bool {
+		if unwatched[i].PRs != unwatched[j].PRs {
+			return unwatched[i].PRs > unwatched[j].PRs
+		}
+		if unwatched[i].Files != unwatched[j].Files {
+			return unwatched[i].Files > unwatched[j].Files
+		}
+		return unwatched[i].Path < unwatched[j].Path
+	})
+	return watched, unwatched
+}
+
+// dirAtDepth returns the first depth directories of file, or its whole
+// directory when shallower. Files at the repository root give ".".
+func dirAtDepth(file string, depth int) string {
+	parts := strings.Split(path.Dir(file), "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
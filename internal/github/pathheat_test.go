@@ -0,0 +1,56 @@
+package github
+
+import "testing"
+
+func TestPathHeatMap(t *testing.T) {
+	prs := []PRFiles{
+		{Number: 1, Author: "alice", Merged: true, Files: []string{"pkg/auth/login.go", "pkg/auth/token.go", "README.md"}},
+		{Number: 2, Author: "bob", Files: []string{"pkg/auth/login.go", "images/base/Dockerfile"}},
+		{Number: 3, Author: "bob", Merged: true, Files: []string{"images/base/Dockerfile", "images/base/test.sh", "images/go/Dockerfile"}},
+		{Number: 4, Author: "alice", Files: []string{"docs/intro.md"}},
+	}
+	watched, unwatched := PathHeatMap(prs, []string{"pkg/auth", "infra"}, 2)
+
+	if len(watched) != 2 {
+		t.Fatalf("watched = %+v, want pkg/auth and infra", watched)
+	}
+	auth := watched[0]
+	if auth.Path != "pkg/auth" || auth.PRs != 2 || auth.Open != 1 || auth.Merged != 1 || auth.Files != 3 {
+		t.Errorf("pkg/auth = %+v, want 2 PRs (1 open, 1 merged) changing 3 files", auth)
+	}
+	if len(auth.Authors) != 2 || auth.Authors[0] != (AuthorCount{"alice", 1}) || auth.Authors[1] != (AuthorCount{"bob", 1}) {
+		t.Errorf("pkg/auth authors = %+v, want alice then bob", auth.Authors)
+	}
+	if infra := watched[1]; infra.Path != "infra" || infra.PRs != 0 || len(infra.Authors) != 0 {
+		t.Errorf("infra = %+v, want no activity", infra)
+	}
+
+	var got []string
+	for _, h := range unwatched {
+		got = append(got, h.Path)
+	}
+	want := []string{"images/base", ".", "docs", "images/go"}
+	if len(got) != len(want) {
+		t.Fatalf("unwatched = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unwatched = %v, want %v", got, want)
+		}
+	}
+	if base := unwatched[0]; base.PRs != 2 || base.Files != 3 || base.Authors[0] != (AuthorCount{"bob", 2}) {
+		t.Errorf("images/base = %+v, want 2 PRs by bob changing 3 files", base)
+	}
+}
+
+func TestDirAtDepth(t *testing.T) {
+	for file, want := range map[string]string{
+		"a/b/c/d.go": "a/b",
+		"a/b.go":     "a",
+		"main.go":    ".",
+	} {
+		if got := dirAtDepth(file, 2); got != want {
+			t.Errorf("dirAtDepth(%q, 2) = %q, want %q", file, got, want)
+		}
+	}
+}
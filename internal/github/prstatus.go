@@ -0,0 +1,147 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+)
+
+// prStatusBatch is the most PRs looked up in one GraphQL request.
+const prStatusBatch = 100
+
+// PRStatus is the current state of a PR.
+type PRStatus struct {
+	Number   int       `json:"number"`
+	Title    string    `json:"title"`
+	Author   string    `json:"author"`
+	State    string    `json:"state"` // OPEN, CLOSED or MERGED
+	MergedAt time.Time `json:"merged_at,omitzero"`
+	Labels   Labels    `json:"labels,omitempty"`
+
+	// MyReview is the viewer's latest review of the PR, nil when they
+	// haven't reviewed it.
+	MyReview *ReviewOutcome `json:"my_review,omitempty"`
+}
+
+// ReviewOutcome is a review submitted on a PR.
+type ReviewOutcome struct {
+	State       string    `json:"state"` // APPROVED, CHANGES_REQUESTED, COMMENTED or DISMISSED
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// PRStatuses looks up the state, title, labels and the viewer's review of
+// PRs of one repo (owner/name) with one GraphQL request per 100 PRs,
+// instead of one REST call each. PRs that don't exist are left out of the result.
+func PRStatuses(ctx context.Context, fullRepo string, numbers []int) (map[int]PRStatus, error) {
+	statuses := make(map[int]PRStatus, len(numbers))
+	numbers = slices.Compact(slices.Sorted(slices.Values(numbers)))
+	for start := 0; start < len(numbers); start += prStatusBatch {
+		batch := numbers[start:min(start+prStatusBatch, len(numbers))]
+		if err := prStatusesBatch(ctx, fullRepo, batch, statuses); err != nil {
+			return nil, err
+		}
+	}
+	return statuses, nil
+}
+
+func prStatusesBatch(ctx context.Context, fullRepo string, numbers []int, into map[int]PRStatus) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	owner, name := splitRepo(fullRepo)
+	// gh exits non-zero when a PR doesn't exist (a NOT_FOUND error in the
+	// response), but still prints the data of the others.
+	out, err := exec.CommandContext(ctx, "gh", "api", "graphql",
+		"-f", "query="+prStatusQuery(numbers),
+		"-f", "owner="+owner,
+		"-f", "name="+name,
+	).Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("PR status lookup timed out after %s", apiTimeout)
+		}
+		if _, ok := err.(*exec.ExitError); !ok || len(out) == 0 {
+			return fmt.Errorf("GraphQL query failed: %s", ghError(err))
+		}
+	}
+	if perr := parsePRStatuses(out, len(numbers), into); perr != nil {
+		if err != nil {
+			return fmt.Errorf("GraphQL query failed: %s", ghError(err))
+		}
+		return perr
+	}
+	return nil
+}
+
+// prStatusQuery builds a GraphQL query looking up each of numbers in the
+// repo $owner/$name, aliased p0..p(n-1).
+func prStatusQuery(numbers []int) string {
+	var fields strings.Builder
+	for i, n := range numbers {
+		fmt.Fprintf(&fields, "    p%d: pullRequest(number: %d) { ...pr }\n", i, n)
+	}
+	return fmt.Sprintf(`query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+%s  }
+}
+fragment pr on PullRequest {
+  number
+  title
+  state
+  mergedAt
+  author { login }
+  labels(first: 20) { nodes { name color } }
+  latestReviews(first: 50) { nodes { state submittedAt viewerDidAuthor } }
+}`, fields.String())
+}
+
+// parsePRStatuses decodes a prStatusQuery response for n PRs into into.
+// PRs that don't exist come back as null and are skipped.
+func parsePRStatuses(out []byte, n int, into map[int]PRStatus) error {
+	var result struct {
+		Data *struct {
+			Repository map[string]*struct {
+				Number   int        `json:"number"`
+				Title    string     `json:"title"`
+				State    string     `json:"state"`
+				MergedAt *time.Time `json:"mergedAt"`
+				Author   AuthorInfo `json:"author"`
+				Labels   Labels     `json:"labels"`
+				Reviews  struct {
+					Nodes []struct {
+						State           string    `json:"state"`
+						SubmittedAt     time.Time `json:"submittedAt"`
+						ViewerDidAuthor bool      `json:"viewerDidAuthor"`
+					} `json:"nodes"`
+				} `json:"latestReviews"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return fmt.Errorf("parsing GraphQL response: %w", err)
+	}
+	if result.Data == nil || result.Data.Repository == nil {
+		return fmt.Errorf("GraphQL response has no data")
+	}
+	for i := range n {
+		pr := result.Data.Repository[fmt.Sprintf("p%d", i)]
+		if pr == nil {
+			continue
+		}
+		s := PRStatus{Number: pr.Number, Title: pr.Title, Author: pr.Author.Login, State: pr.State, Labels: pr.Labels}
+		if pr.MergedAt != nil {
+			s.MergedAt = *pr.MergedAt
+		}
+		for _, rv := range pr.Reviews.Nodes {
+			if rv.ViewerDidAuthor && rv.State != "PENDING" {
+				s.MyReview = &ReviewOutcome{State: rv.State, SubmittedAt: rv.SubmittedAt}
+			}
+		}
+		into[pr.Number] = s
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package github
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ParsePRURL extracts the owner/repo and number from a GitHub PR URL such
+// as https://github.com/owner/repo/pull/123, including links to a tab,
+// commit or comment of the PR (/files, #discussion_r1). The scheme may be
+// omitted. ok is false for anything else, including bare numbers.
+func ParsePRURL(s string) (fullRepo string, number int, ok bool) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return "", 0, false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] == "" || parts[1] == "" || (parts[2] != "pull" && parts[2] != "pulls") {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(parts[3])
+	if err != nil || n <= 0 {
+		return "", 0, false
+	}
+	return parts[0] + "/" + parts[1], n, true
+}
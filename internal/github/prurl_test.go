@@ -0,0 +1,36 @@
+package github
+
+import "testing"
+
+func TestParsePRURL(t *testing.T) {
+	tests := map[string]struct {
+		repo   string
+		number int
+	}{
+		"https://github.com/org/repo/pull/123":                 {"org/repo", 123},
+		"https://github.com/org/repo/pull/123/files":           {"org/repo", 123},
+		"https://github.com/org/repo/pull/123#discussion_r456": {"org/repo", 123},
+		"https://github.com/org/repo/pull/123?w=1":             {"org/repo", 123},
+		"github.com/org/repo/pull/7/":                          {"org/repo", 7},
+		" https://github.example.com/org/repo/pull/9 ":         {"org/repo", 9},
+	}
+	for in, want := range tests {
+		repo, n, ok := ParsePRURL(in)
+		if !ok || repo != want.repo || n != want.number {
+			t.Errorf("ParsePRURL(%q) = %q, %d, %v; want %q, %d", in, repo, n, ok, want.repo, want.number)
+		}
+	}
+
+	for _, in := range []string{
+		"123",
+		"#123",
+		"https://github.com/org/repo/issues/123",
+		"https://github.com/org/repo/pull/abc",
+		"https://github.com/org/repo/pull/0",
+		"https://github.com/org/repo",
+	} {
+		if repo, n, ok := ParsePRURL(in); ok {
+			t.Errorf("ParsePRURL(%q) = %q, %d; want not ok", in, repo, n)
+		}
+	}
+}
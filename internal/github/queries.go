@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
 )
 
 // withTimeout returns a context with apiTimeout applied, unless the caller
@@ -33,6 +36,13 @@ type ReviewRequest struct {
 	Repository RepoInfo   `json:"repository"`
 	CreatedAt  string     `json:"createdAt"`
 	URL        string     `json:"url"`
+	Team       string     `json:"team,omitempty"` // org/team the review was requested from, if any
+	Labels     Labels     `json:"labels,omitempty"`
+	HeadSHA    string     `json:"headRefOid,omitempty"` // head commit, set by ListOpenPRs and ListRecentPRs
+	Body       string     `json:"body,omitempty"`       // description, set by PR searches
+	// Rereview is set on PRs you already reviewed that still need review,
+	// rather than ones with a pending request for your review.
+	Rereview bool `json:"rereview,omitempty"`
 }
 
 // AuthorInfo holds author login info.
@@ -72,25 +82,15 @@ func GetCurrentUser(ctx context.Context) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
-// GetReviewRequests fetches PRs where the user is a requested reviewer,
-// including re-reviews. Uses GraphQL via `gh api graphql`.
-func GetReviewRequests(ctx context.Context, repoFilter string) ([]ReviewRequest, error) {
-	ctx, cancel := withTimeout(ctx)
-	defer cancel()
-	query := `query($q1: String!, $q2: String!) {
-  requested: search(query: $q1, type: ISSUE, first: 50) {
-    nodes {
-      ... on PullRequest {
-        number
-        title
-        author { login }
-        repository { name nameWithOwner }
-        createdAt
-        url
-      }
-    }
-  }
-  rereview: search(query: $q2, type: ISSUE, first: 50) {
+// searchPageSize is the page size for GraphQL search (GitHub allows up to 100).
+const searchPageSize = 50
+
+// prSearchQuery pages through a PR search. reviewDecision is only populated
+// when the caller's node type has the field.
+const prSearchQuery = `query($q: String!, $first: Int!, $after: String) {
+  search(query: $q, type: ISSUE, first: $first, after: $after) {
+    issueCount
+    pageInfo { hasNextPage endCursor }
     nodes {
       ... on PullRequest {
         number
@@ -99,83 +99,185 @@ func GetReviewRequests(ctx context.Context, repoFilter string) ([]ReviewRequest,
         repository { name nameWithOwner }
         createdAt
         url
+        body
+        reviewDecision
+        labels(first: 20) { nodes { name color } }
       }
     }
   }
 }`
 
-	repoClause := ""
-	if repoFilter != "" {
-		repoClause = " repo:" + repoFilter
+// searchPage is one page of a GraphQL PR search.
+type searchPage[T any] struct {
+	IssueCount int `json:"issueCount"`
+	PageInfo   struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	Nodes []T `json:"nodes"`
+}
+
+// parseSearchPage decodes a `gh api graphql` search response.
+func parseSearchPage[T any](out []byte) (*searchPage[T], error) {
+	var result struct {
+		Data struct {
+			Search searchPage[T] `json:"search"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("parsing GraphQL response: %w", err)
 	}
+	return &result.Data.Search, nil
+}
 
-	q1 := fmt.Sprintf("is:pr is:open review-requested:@me%s", repoClause)
-	q2 := fmt.Sprintf("is:pr is:open reviewed-by:@me review:required%s", repoClause)
+// runSearchPage runs one page of a GraphQL search. Each page gets its own
+// apiTimeout, so a long result set is not cut short by a single deadline.
+// A timeout fails the whole search rather than returning a partial list,
+// and says how many results had been fetched.
+func runSearchPage(ctx context.Context, args []string, what string, fetched int) ([]byte, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "gh", "api", "graphql",
-		"-f", "query="+query,
-		"-f", "q1="+q1,
-		"-f", "q2="+q2,
-	)
-	out, err := cmd.Output()
+	out, err := exec.CommandContext(ctx, "gh", args...).Output()
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return nil, fmt.Errorf("review requests query timed out after %s", apiTimeout)
+			if fetched > 0 {
+				return nil, fmt.Errorf("%s query timed out after %s, with %d results fetched", what, apiTimeout, fetched)
+			}
+			return nil, fmt.Errorf("%s query timed out after %s", what, apiTimeout)
 		}
 		return nil, fmt.Errorf("GraphQL query failed: %s", ghError(err))
 	}
+	return out, nil
+}
 
-	var result struct {
-		Data struct {
-			Requested struct {
-				Nodes []ReviewRequest `json:"nodes"`
-			} `json:"requested"`
-			Rereview struct {
-				Nodes []ReviewRequest `json:"nodes"`
-			} `json:"rereview"`
-		} `json:"data"`
+// searchPRs runs a GraphQL PR search, following the cursor until limit nodes
+// have been fetched or the results run out. It returns the nodes and the
+// total number of matches reported by GitHub, which may exceed limit.
+func searchPRs[T any](ctx context.Context, q string, limit int, what string) ([]T, int, error) {
+	if limit <= 0 {
+		limit = config.DefaultSearchLimit
 	}
-	if err := json.Unmarshal(out, &result); err != nil {
-		return nil, fmt.Errorf("parsing GraphQL response: %w", err)
+
+	var nodes []T
+	total := 0
+	after := ""
+	for len(nodes) < limit {
+		first := min(searchPageSize, limit-len(nodes))
+		args := []string{"api", "graphql",
+			"-f", "query=" + prSearchQuery,
+			"-f", "q=" + q,
+			"-F", fmt.Sprintf("first=%d", first),
+		}
+		if after != "" {
+			args = append(args, "-f", "after="+after)
+		}
+		out, err := runSearchPage(ctx, args, what, len(nodes))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		page, err := parseSearchPage[T](out)
+		if err != nil {
+			return nil, 0, err
+		}
+		total = page.IssueCount
+		nodes = append(nodes, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			break
+		}
+		after = page.PageInfo.EndCursor
+	}
+	return nodes, total, nil
+}
+
+// GetReviewRequests fetches PRs where the user is a requested reviewer,
+// including re-reviews, paging through up to limit results per search (a
+// non-positive limit uses config.DefaultSearchLimit). It also returns the total
+// number of matching PRs, which exceeds len(results) when capped.
+func GetReviewRequests(ctx context.Context, repoFilter string, limit int) ([]ReviewRequest, int, error) {
+	repoClause := ""
+	if repoFilter != "" {
+		repoClause = " repo:" + repoFilter
+	}
+
+	q1 := fmt.Sprintf("is:pr is:open review-requested:@me%s", repoClause)
+	q2 := fmt.Sprintf("is:pr is:open reviewed-by:@me review:required%s", repoClause)
+
+	requested, requestedTotal, err := searchPRs[ReviewRequest](ctx, q1, limit, "review requests")
+	if err != nil {
+		return nil, 0, err
+	}
+	rereview, rereviewTotal, err := searchPRs[ReviewRequest](ctx, q2, limit, "review requests")
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// Merge and deduplicate
 	seen := make(map[int]bool)
 	var merged []ReviewRequest
-	for _, lists := range [][]ReviewRequest{result.Data.Requested.Nodes, result.Data.Rereview.Nodes} {
+	dupes := 0
+	for i, lists := range [][]ReviewRequest{requested, rereview} {
 		for _, rr := range lists {
 			if rr.Number == 0 {
 				continue
 			}
-			if !seen[rr.Number] {
-				seen[rr.Number] = true
-				merged = append(merged, rr)
+			if seen[rr.Number] {
+				dupes++
+				continue
 			}
+			seen[rr.Number] = true
+			rr.Rereview = i == 1
+			merged = append(merged, rr)
 		}
 	}
-	return merged, nil
+
+	// Exact when everything was fetched; otherwise overlap among unfetched
+	// results is unknown and the total is an upper bound.
+	total := max(requestedTotal+rereviewTotal-dupes, len(merged))
+	return merged, total, nil
 }
 
-// GetApprovedUnmerged fetches the user's own PRs that are approved but not yet merged.
-func GetApprovedUnmerged(ctx context.Context, repoFilter string) ([]ApprovedPR, error) {
-	ctx, cancel := withTimeout(ctx)
-	defer cancel()
-	query := `query($q: String!) {
-  search(query: $q, type: ISSUE, first: 50) {
-    nodes {
-      ... on PullRequest {
-        number
-        title
-        author { login }
-        repository { name nameWithOwner }
-        createdAt
-        url
-        reviewDecision
-      }
-    }
-  }
-}`
+// GetTeamReviewRequests fetches open PRs whose review was requested from any
+// of the given teams ("org/team"), paging through up to limit results per
+// team. A PR requested from several teams is returned once, tagged with the
+// first matching team. It also returns the total number of matching PRs.
+func GetTeamReviewRequests(ctx context.Context, repoFilter string, teams []string, limit int) ([]ReviewRequest, int, error) {
+	repoClause := ""
+	if repoFilter != "" {
+		repoClause = " repo:" + repoFilter
+	}
+
+	seen := make(map[int]bool)
+	var merged []ReviewRequest
+	total, dupes := 0, 0
+	for _, team := range teams {
+		q := fmt.Sprintf("is:pr is:open team-review-requested:%s%s", team, repoClause)
+		nodes, n, err := searchPRs[ReviewRequest](ctx, q, limit, "team review requests")
+		if err != nil {
+			return nil, 0, err
+		}
+		total += n
+		for _, rr := range nodes {
+			if rr.Number == 0 {
+				continue
+			}
+			if seen[rr.Number] {
+				dupes++
+				continue
+			}
+			seen[rr.Number] = true
+			rr.Team = team
+			merged = append(merged, rr)
+		}
+	}
+	return merged, max(total-dupes, len(merged)), nil
+}
 
+// GetApprovedUnmerged fetches the user's own PRs that are approved but not
+// yet merged, paging through up to limit results. It also returns the total
+// number of matching PRs.
+func GetApprovedUnmerged(ctx context.Context, repoFilter string, limit int) ([]ApprovedPR, int, error) {
 	repoClause := ""
 	if repoFilter != "" {
 		repoClause = " repo:" + repoFilter
@@ -183,52 +285,170 @@ func GetApprovedUnmerged(ctx context.Context, repoFilter string) ([]ApprovedPR,
 
 	q := fmt.Sprintf("is:pr is:open author:@me review:approved%s", repoClause)
 
-	cmd := exec.CommandContext(ctx, "gh", "api", "graphql",
-		"-f", "query="+query,
-		"-f", "q="+q,
-	)
-	out, err := cmd.Output()
+	nodes, total, err := searchPRs[ApprovedPR](ctx, q, limit, "approved PRs")
 	if err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
-			return nil, fmt.Errorf("approved PRs query timed out after %s", apiTimeout)
+		return nil, 0, err
+	}
+
+	var filtered []ApprovedPR
+	for _, pr := range nodes {
+		if pr.Number != 0 {
+			filtered = append(filtered, pr)
 		}
-		return nil, fmt.Errorf("GraphQL query failed: %s", ghError(err))
 	}
+	return filtered, total, nil
+}
+
+// PRMatch is a PR found by FindPR in one of the searched repos.
+type PRMatch struct {
+	Repo            string `json:"repo"` // owner/name
+	Number          int    `json:"number"`
+	Title           string `json:"title"`
+	Author          string `json:"author"`
+	State           string `json:"state"`
+	ViewerRequested bool   `json:"viewer_requested"` // the current user is a requested reviewer
+}
+
+// findPRQuery builds a single GraphQL query looking up PR $number in each
+// of n repos, aliased r0..r(n-1), along with the viewer's login.
+func findPRQuery(n int) string {
+	var vars, fields strings.Builder
+	vars.WriteString("$number: Int!")
+	for i := range n {
+		fmt.Fprintf(&vars, ", $o%d: String!, $n%d: String!", i, i)
+		fmt.Fprintf(&fields, "  r%d: repository(owner: $o%d, name: $n%d) { pullRequest(number: $number) { ...pr } }\n", i, i, i)
+	}
+	return fmt.Sprintf(`query(%s) {
+  viewer { login }
+%s}
+fragment pr on PullRequest {
+  number
+  title
+  state
+  author { login }
+  reviewRequests(first: 50) { nodes { requestedReviewer { ... on User { login } } } }
+}`, vars.String(), fields.String())
+}
 
+// parseFindPR decodes a findPRQuery response. Repos where the PR does not
+// exist come back as null (with a NOT_FOUND error) and are skipped.
+func parseFindPR(out []byte, repos []string) ([]PRMatch, error) {
 	var result struct {
-		Data struct {
-			Search struct {
-				Nodes []ApprovedPR `json:"nodes"`
-			} `json:"search"`
-		} `json:"data"`
+		Data map[string]json.RawMessage `json:"data"`
 	}
 	if err := json.Unmarshal(out, &result); err != nil {
 		return nil, fmt.Errorf("parsing GraphQL response: %w", err)
 	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("GraphQL response has no data")
+	}
 
-	var filtered []ApprovedPR
-	for _, pr := range result.Data.Search.Nodes {
-		if pr.Number != 0 {
-			filtered = append(filtered, pr)
+	var viewer struct {
+		Login string `json:"login"`
+	}
+	json.Unmarshal(result.Data["viewer"], &viewer)
+
+	var matches []PRMatch
+	for i, repo := range repos {
+		var r struct {
+			PullRequest *struct {
+				Number int        `json:"number"`
+				Title  string     `json:"title"`
+				State  string     `json:"state"`
+				Author AuthorInfo `json:"author"`
+				Review struct {
+					Nodes []struct {
+						RequestedReviewer AuthorInfo `json:"requestedReviewer"`
+					} `json:"nodes"`
+				} `json:"reviewRequests"`
+			} `json:"pullRequest"`
+		}
+		raw, ok := result.Data[fmt.Sprintf("r%d", i)]
+		if !ok || json.Unmarshal(raw, &r) != nil || r.PullRequest == nil {
+			continue
+		}
+		pr := r.PullRequest
+		m := PRMatch{Repo: repo, Number: pr.Number, Title: pr.Title, Author: pr.Author.Login, State: pr.State}
+		for _, n := range pr.Review.Nodes {
+			if viewer.Login != "" && n.RequestedReviewer.Login == viewer.Login {
+				m.ViewerRequested = true
+			}
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+// FindPR looks up PR number in each of the given repos (owner/name) with a
+// single GraphQL request and returns the repos where it exists.
+func FindPR(ctx context.Context, repos []string, number int) ([]PRMatch, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	args := []string{"api", "graphql",
+		"-f", "query=" + findPRQuery(len(repos)),
+		"-F", fmt.Sprintf("number=%d", number),
+	}
+	for i, repo := range repos {
+		owner, name := splitRepo(repo)
+		args = append(args, "-f", fmt.Sprintf("o%d=%s", i, owner), "-f", fmt.Sprintf("n%d=%s", i, name))
+	}
+
+	// gh exits non-zero when any repo lacks the PR (a NOT_FOUND error in
+	// the response), but still prints the partial data, which is what we want.
+	out, err := exec.CommandContext(ctx, "gh", args...).Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("PR lookup timed out after %s", apiTimeout)
+		}
+		if _, ok := err.(*exec.ExitError); !ok || len(out) == 0 {
+			return nil, fmt.Errorf("GraphQL query failed: %s", ghError(err))
 		}
 	}
-	return filtered, nil
+	matches, perr := parseFindPR(out, repos)
+	if perr != nil && err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %s", ghError(err))
+	}
+	return matches, perr
 }
 
 // ListOpenPRs lists open PRs for a repository using `gh pr list`.
 func ListOpenPRs(ctx context.Context, fullRepo string, limit int) ([]ReviewRequest, error) {
+	return listPRs(ctx, fullRepo, "open", "", limit)
+}
+
+// ListRecentPRs lists the PRs of a repository that are open and were
+// updated since the given time, and those merged since then, up to limit
+// of each, using `gh pr list`.
+func ListRecentPRs(ctx context.Context, fullRepo string, since time.Time, limit int) (open, merged []ReviewRequest, err error) {
+	day := since.UTC().Format("2006-01-02")
+	if open, err = listPRs(ctx, fullRepo, "open", "updated:>="+day, limit); err != nil {
+		return nil, nil, err
+	}
+	if merged, err = listPRs(ctx, fullRepo, "merged", "merged:>="+day, limit); err != nil {
+		return nil, nil, err
+	}
+	return open, merged, nil
+}
+
+// listPRs lists the PRs of a repository in state (open, merged, ...)
+// matching search, if not empty, using `gh pr list`.
+func listPRs(ctx context.Context, fullRepo, state, search string, limit int) ([]ReviewRequest, error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
-	cmd := exec.CommandContext(ctx, "gh", "pr", "list",
+	args := []string{"pr", "list",
 		"-R", fullRepo,
-		"--state", "open",
+		"--state", state,
 		"--limit", fmt.Sprintf("%d", limit),
-		"--json", "number,title,author,createdAt,url",
-	)
-	out, err := cmd.Output()
+		"--json", "number,title,author,createdAt,url,headRefOid",
+	}
+	if search != "" {
+		args = append(args, "--search", search)
+	}
+	out, err := exec.CommandContext(ctx, "gh", args...).Output()
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return nil, fmt.Errorf("listing open PRs timed out after %s", apiTimeout)
+			return nil, fmt.Errorf("listing %s PRs timed out after %s", state, apiTimeout)
 		}
 		return nil, err
 	}
@@ -239,8 +459,9 @@ func ListOpenPRs(ctx context.Context, fullRepo string, limit int) ([]ReviewReque
 		Author    struct {
 			Login string `json:"login"`
 		} `json:"author"`
-		CreatedAt string `json:"createdAt"`
-		URL       string `json:"url"`
+		CreatedAt  string `json:"createdAt"`
+		URL        string `json:"url"`
+		HeadRefOid string `json:"headRefOid"`
 	}
 	if err := json.Unmarshal(out, &prs); err != nil {
 		return nil, err
@@ -263,6 +484,7 @@ func ListOpenPRs(ctx context.Context, fullRepo string, limit int) ([]ReviewReque
 			},
 			CreatedAt: pr.CreatedAt,
 			URL:       pr.URL,
+			HeadSHA:   pr.HeadRefOid,
 		})
 	}
 	return result, nil
@@ -55,7 +55,20 @@ func TestGetReviewRequests_timeoutError(t *testing.T) {
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
 	defer cancel()
 
-	_, err := GetReviewRequests(ctx, "")
+	_, _, err := GetReviewRequests(ctx, "", 0)
+	if err == nil {
+		t.Fatal("expected error from expired context")
+	}
+	if !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error message, got: %s", err)
+	}
+}
+
+func TestGetTeamReviewRequests_timeoutError(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, _, err := GetTeamReviewRequests(ctx, "", []string{"org/team"}, 0)
 	if err == nil {
 		t.Fatal("expected error from expired context")
 	}
@@ -68,7 +81,7 @@ func TestGetApprovedUnmerged_timeoutError(t *testing.T) {
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
 	defer cancel()
 
-	_, err := GetApprovedUnmerged(ctx, "")
+	_, _, err := GetApprovedUnmerged(ctx, "", 0)
 	if err == nil {
 		t.Fatal("expected error from expired context")
 	}
@@ -89,3 +102,112 @@ func TestListOpenPRs_timeoutError(t *testing.T) {
 		t.Fatalf("expected timeout error message, got: %s", err)
 	}
 }
+
+func TestParseSearchPage(t *testing.T) {
+	out := []byte(`{"data":{"search":{
+  "issueCount": 137,
+  "pageInfo": {"hasNextPage": true, "endCursor": "Y3Vyc29yOjUw"},
+  "nodes": [
+    {"number": 42, "title": "Add feature", "author": {"login": "alice"}, "repository": {"name": "app", "nameWithOwner": "org/app"}},
+    {}
+  ]
+}}}`)
+
+	page, err := parseSearchPage[ReviewRequest](out)
+	if err != nil {
+		t.Fatalf("parseSearchPage() error: %v", err)
+	}
+	if page.IssueCount != 137 {
+		t.Errorf("IssueCount = %d, want 137", page.IssueCount)
+	}
+	if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor != "Y3Vyc29yOjUw" {
+		t.Errorf("PageInfo = %+v, want next page with cursor", page.PageInfo)
+	}
+	if len(page.Nodes) != 2 || page.Nodes[0].Number != 42 || page.Nodes[0].Author.Login != "alice" {
+		t.Errorf("Nodes = %+v, want PR #42 by alice first", page.Nodes)
+	}
+}
+
+func TestParseSearchPage_invalidJSON(t *testing.T) {
+	if _, err := parseSearchPage[ApprovedPR]([]byte("not json")); err == nil {
+		t.Fatal("expected error for invalid JSON")
+	}
+}
+
+func TestFindPRQuery(t *testing.T) {
+	q := findPRQuery(2)
+	for _, want := range []string{"$o1: String!", "r0: repository(owner: $o0, name: $n0)", "r1: repository", "viewer { login }"} {
+		if !strings.Contains(q, want) {
+			t.Errorf("findPRQuery(2) missing %q:\n%s", want, q)
+		}
+	}
+}
+
+func TestParseFindPR(t *testing.T) {
+	out := []byte(`{"data":{"viewer":{"login":"me"},
+		"r0":{"pullRequest":null},
+		"r1":{"pullRequest":{"number":7,"title":"Fix","state":"OPEN","author":{"login":"bob"},
+			"reviewRequests":{"nodes":[{"requestedReviewer":{"login":"me"}},{"requestedReviewer":{}}]}}},
+		"r2":{"pullRequest":{"number":7,"title":"Other","state":"MERGED","author":{"login":"eve"},"reviewRequests":{"nodes":[]}}}},
+		"errors":[{"type":"NOT_FOUND","path":["r0","pullRequest"]}]}`)
+
+	matches, err := parseFindPR(out, []string{"o/a", "o/b", "o/c"})
+	if err != nil {
+		t.Fatalf("parseFindPR() error: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("parseFindPR() = %+v, want 2 matches", matches)
+	}
+	if m := matches[0]; m.Repo != "o/b" || m.Title != "Fix" || m.Author != "bob" || !m.ViewerRequested {
+		t.Errorf("matches[0] = %+v, want o/b requested from viewer", m)
+	}
+	if m := matches[1]; m.Repo != "o/c" || m.ViewerRequested {
+		t.Errorf("matches[1] = %+v, want o/c not requested from viewer", m)
+	}
+
+	if _, err := parseFindPR([]byte(`{"errors":[{"message":"bad"}]}`), []string{"o/a"}); err == nil {
+		t.Error("parseFindPR() should fail without data")
+	}
+}
+
+func TestPRStatusQuery(t *testing.T) {
+	q := prStatusQuery([]int{42, 7})
+	for _, want := range []string{"repository(owner: $owner, name: $name)", "p0: pullRequest(number: 42)", "p1: pullRequest(number: 7)", "mergedAt", "labels(first: 20)", "viewerDidAuthor"} {
+		if !strings.Contains(q, want) {
+			t.Errorf("prStatusQuery() missing %q:\n%s", want, q)
+		}
+	}
+}
+
+func TestParsePRStatuses(t *testing.T) {
+	out := []byte(`{"data":{"repository":{
+		"p0":{"number":42,"title":"Fix","state":"MERGED","mergedAt":"2026-03-01T10:00:00Z","author":{"login":"bob"},
+			"labels":{"nodes":[{"name":"hold","color":"ededed"}]},
+			"latestReviews":{"nodes":[{"state":"CHANGES_REQUESTED","submittedAt":"2026-02-27T09:00:00Z","viewerDidAuthor":false},
+				{"state":"APPROVED","submittedAt":"2026-02-28T09:00:00Z","viewerDidAuthor":true}]}},
+		"p1":null,
+		"p2":{"number":9,"title":"WIP","state":"OPEN","mergedAt":null,"author":{"login":"eve"},"labels":{"nodes":[]}}}},
+		"errors":[{"type":"NOT_FOUND","path":["repository","p1"]}]}`)
+
+	got := make(map[int]PRStatus)
+	if err := parsePRStatuses(out, 3, got); err != nil {
+		t.Fatalf("parsePRStatuses() error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("parsePRStatuses() = %+v, want 2 PRs", got)
+	}
+	merged := got[42]
+	if merged.State != "MERGED" || merged.Author != "bob" || merged.MergedAt.IsZero() || len(merged.Labels) != 1 || merged.Labels[0].Name != "hold" {
+		t.Errorf("PR 42 = %+v, want merged by bob with a hold label", merged)
+	}
+	if r := merged.MyReview; r == nil || r.State != "APPROVED" || r.SubmittedAt.IsZero() {
+		t.Errorf("PR 42 review = %+v, want the viewer's approval", r)
+	}
+	if open := got[9]; open.State != "OPEN" || !open.MergedAt.IsZero() || open.MyReview != nil {
+		t.Errorf("PR 9 = %+v, want open and not merged", open)
+	}
+
+	if err := parsePRStatuses([]byte(`{"errors":[{"message":"bad"}]}`), 1, got); err == nil {
+		t.Error("parsePRStatuses() should fail without data")
+	}
+}
@@ -20,6 +20,9 @@ type PRDetails struct {
 	CreatedAt   string `json:"created_at"`
 	URL         string `json:"url"`
 	IsFork      bool   `json:"is_fork"`
+	HeadSHA     string `json:"head_sha"`
+	Merged      bool   `json:"merged"`
+	Labels      Labels `json:"labels,omitempty"`
 }
 
 // GetPRDetails fetches details for a specific PR.
@@ -41,6 +44,9 @@ func (c *Client) GetPRDetails(ctx context.Context, fullRepo string, prNumber int
 		CreatedAt:   pr.GetCreatedAt().Format("2006-01-02T15:04:05Z"),
 		URL:         pr.GetHTMLURL(),
 		IsFork:      pr.GetHead().GetRepo().GetFork(),
+		HeadSHA:     pr.GetHead().GetSHA(),
+		Merged:      pr.GetMerged(),
+		Labels:      restLabels(pr.Labels),
 	}, nil
 }
 
@@ -100,6 +106,20 @@ func (c *Client) GetPRFiles(ctx context.Context, fullRepo string, prNumber int)
 	return allFiles, nil
 }
 
+// CompareFiles returns the paths of files changed between two commits.
+func (c *Client) CompareFiles(ctx context.Context, fullRepo, base, head string) ([]string, error) {
+	owner, repo := splitRepo(fullRepo)
+	cmp, _, err := c.gh.Repositories.CompareCommits(ctx, owner, repo, base, head, &gh.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("comparing %s...%s: %w", base, head, err)
+	}
+	files := make([]string, 0, len(cmp.Files))
+	for _, f := range cmp.Files {
+		files = append(files, f.GetFilename())
+	}
+	return files, nil
+}
+
 // GetReviewStatus returns the user's latest review state on a PR.
 func (c *Client) GetReviewStatus(ctx context.Context, fullRepo string, prNumber int) (string, error) {
 	owner, repo := splitRepo(fullRepo)
@@ -125,12 +145,17 @@ func (c *Client) GetReviewStatus(ctx context.Context, fullRepo string, prNumber
 }
 
 // GetPRStateByBranch looks up PRs by head branch name and returns the state
-// ("MERGED", "CLOSED", "OPEN") and PR number of the first match.
+// ("MERGED", "CLOSED", "OPEN") and PR number of the first match. headOwner
+// is the owner of the repo holding the branch: a fork's owner for
+// cross-fork PRs, "" for branches of fullRepo itself.
 // Returns ("", 0, nil) if no PR found for that branch.
-func (c *Client) GetPRStateByBranch(ctx context.Context, fullRepo, branch string) (string, int, error) {
+func (c *Client) GetPRStateByBranch(ctx context.Context, fullRepo, headOwner, branch string) (string, int, error) {
 	owner, repo := splitRepo(fullRepo)
+	if headOwner == "" {
+		headOwner = owner
+	}
 	prs, _, err := c.gh.PullRequests.List(ctx, owner, repo, &gh.PullRequestListOptions{
-		Head:  owner + ":" + branch,
+		Head:  headOwner + ":" + branch,
 		State: "all",
 	})
 	if err != nil {
@@ -0,0 +1,36 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	gh "github.com/google/go-github/v75/github"
+)
+
+// TeamMembers returns the logins of the members of a team given as
+// "org/team-slug", including members of its child teams.
+func (c *Client) TeamMembers(ctx context.Context, team string) ([]string, error) {
+	org, slug, ok := strings.Cut(team, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid team %q: must be \"org/team\"", team)
+	}
+	opts := &gh.TeamListTeamMembersOptions{ListOptions: gh.ListOptions{PerPage: 100}}
+	var logins []string
+	for {
+		users, resp, err := c.gh.Teams.ListTeamMembersBySlug(ctx, org, slug, opts)
+		if err != nil {
+			return nil, fmt.Errorf("listing members of %s: %w", team, err)
+		}
+		for _, u := range users {
+			logins = append(logins, u.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	sort.Strings(logins)
+	return logins, nil
+}
@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+)
+
+// Thread kinds, as reported in Thread.Kind.
+const (
+	ThreadIssue      = "issue"
+	ThreadDiscussion = "discussion"
+)
+
+// Why a thread is listed, as reported in Thread.Reason.
+const (
+	ReasonAssigned  = "assigned"
+	ReasonMentioned = "mentioned"
+	ReasonInvolved  = "involved" // discussions: authored, commented on or mentioned in
+)
+
+// Thread is an open issue or discussion that involves the user.
+type Thread struct {
+	Kind       string     `json:"kind"`
+	Number     int        `json:"number"`
+	Title      string     `json:"title"`
+	Author     AuthorInfo `json:"author"`
+	Repository RepoInfo   `json:"repository"`
+	UpdatedAt  string     `json:"updatedAt"`
+	URL        string     `json:"url"`
+	Reason     string     `json:"reason"`
+}
+
+// threadSearchQuery pages through an issue or discussion search; $type is
+// ISSUE or DISCUSSION.
+const threadSearchQuery = `query($q: String!, $type: SearchType!, $first: Int!, $after: String) {
+  search(query: $q, type: $type, first: $first, after: $after) {
+    issueCount
+    pageInfo { hasNextPage endCursor }
+    nodes {
+      ... on Issue {
+        number
+        title
+        author { login }
+        repository { name nameWithOwner }
+        updatedAt
+        url
+      }
+      ... on Discussion {
+        number
+        title
+        author { login }
+        repository { name nameWithOwner }
+        updatedAt
+        url
+      }
+    }
+  }
+}`
+
+// searchThreads runs one issue or discussion search, following the cursor
+// until limit nodes have been fetched or the results run out.
+func searchThreads(ctx context.Context, searchType, q string, limit int, what string) ([]Thread, error) {
+	if limit <= 0 {
+		limit = config.DefaultSearchLimit
+	}
+
+	var nodes []Thread
+	after := ""
+	for len(nodes) < limit {
+		first := min(searchPageSize, limit-len(nodes))
+		args := []string{"api", "graphql",
+			"-f", "query=" + threadSearchQuery,
+			"-f", "q=" + q,
+			"-f", "type=" + searchType,
+			"-F", fmt.Sprintf("first=%d", first),
+		}
+		if after != "" {
+			args = append(args, "-f", "after="+after)
+		}
+		out, err := runSearchPage(ctx, args, what, len(nodes))
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := parseSearchPage[Thread](out)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			break
+		}
+		after = page.PageInfo.EndCursor
+	}
+	return nodes, nil
+}
+
+// threadSearches returns the searches for open threads of kind in
+// repoFilter updated since the given time, each with the reason it lists
+// threads for. Issues are listed when assigned to or mentioning the user;
+// discussions, which have no assignees, whenever they involve the user.
+func threadSearches(kind, repoFilter string, since time.Time) []struct{ q, reason string } {
+	clause := " updated:>=" + since.UTC().Format("2006-01-02")
+	if repoFilter != "" {
+		clause += " repo:" + repoFilter
+	}
+	if kind == ThreadDiscussion {
+		return []struct{ q, reason string }{
+			{"is:open involves:@me" + clause, ReasonInvolved},
+		}
+	}
+	return []struct{ q, reason string }{
+		{"is:issue is:open assignee:@me" + clause, ReasonAssigned},
+		{"is:issue is:open mentions:@me" + clause, ReasonMentioned},
+	}
+}
+
+// GetThreads fetches open issues (kind ThreadIssue) or discussions (kind
+// ThreadDiscussion) involving the user that were updated since the given
+// time, most recently updated first. An issue both assigned to and
+// mentioning the user is listed once, as assigned.
+func GetThreads(ctx context.Context, kind, repoFilter string, since time.Time, limit int) ([]Thread, error) {
+	searchType := "ISSUE"
+	if kind == ThreadDiscussion {
+		searchType = "DISCUSSION"
+	}
+
+	type key struct {
+		repo   string
+		number int
+	}
+	seen := make(map[key]bool)
+	var merged []Thread
+	for _, s := range threadSearches(kind, repoFilter, since) {
+		nodes, err := searchThreads(ctx, searchType, s.q, limit, kind+"s")
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range nodes {
+			k := key{t.Repository.NameWithOwner, t.Number}
+			if t.Number == 0 || seen[k] {
+				continue
+			}
+			seen[k] = true
+			t.Kind = kind
+			t.Reason = s.reason
+			merged = append(merged, t)
+		}
+	}
+	sortThreads(merged)
+	return merged, nil
+}
+
+// sortThreads orders threads most recently updated first. UpdatedAt is
+// RFC 3339 in UTC, so it sorts as a string.
+func sortThreads(threads []Thread) {
+	sort.SliceStable(threads, func(i, j int) bool {
+		return threads[i].UpdatedAt > threads[j].UpdatedAt
+	})
+}
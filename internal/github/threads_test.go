@@ -0,0 +1,55 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestThreadSearches(t *testing.T) {
+	since := time.Date(2026, 3, 9, 23, 0, 0, 0, time.UTC)
+
+	issues := threadSearches(ThreadIssue, "acme/app", since)
+	if len(issues) != 2 {
+		t.Fatalf("issue searches = %d, want 2", len(issues))
+	}
+	if want := "is:issue is:open assignee:@me updated:>=2026-03-09 repo:acme/app"; issues[0].q != want || issues[0].reason != ReasonAssigned {
+		t.Errorf("issues[0] = %+v, want %q assigned", issues[0], want)
+	}
+	if !strings.Contains(issues[1].q, "mentions:@me") || issues[1].reason != ReasonMentioned {
+		t.Errorf("issues[1] = %+v, want a mentions search", issues[1])
+	}
+
+	discussions := threadSearches(ThreadDiscussion, "", since)
+	if len(discussions) != 1 || discussions[0].q != "is:open involves:@me updated:>=2026-03-09" {
+		t.Errorf("discussion searches = %+v", discussions)
+	}
+}
+
+func TestSortThreads(t *testing.T) {
+	threads := []Thread{
+		{Number: 1, UpdatedAt: "2026-03-01T10:00:00Z"},
+		{Number: 2, UpdatedAt: "2026-03-05T10:00:00Z"},
+		{Number: 3, UpdatedAt: "2026-03-03T10:00:00Z"},
+	}
+	sortThreads(threads)
+	for i, want := range []int{2, 3, 1} {
+		if threads[i].Number != want {
+			t.Fatalf("sorted = %+v, want numbers 2, 3, 1", threads)
+		}
+	}
+}
+
+func TestGetThreads_timeoutError(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, err := GetThreads(ctx, ThreadIssue, "", time.Now(), 0)
+	if err == nil {
+		t.Fatal("expected error from expired context")
+	}
+	if !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error message, got: %s", err)
+	}
+}
@@ -0,0 +1,311 @@
+// Package hooks relays watch daemon events (a worktree is ready, a setup
+// failed, a PR was merged) to the URLs and scripts configured in hooks:,
+// so zen can drive external automations.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/secrets"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// Event types, as listed in config.HookEvents.
+const (
+	WorktreeReady = "worktree_ready"
+	SetupFailed   = "setup_failed"
+	PRMerged      = "pr_merged"
+)
+
+// Event is the payload delivered to hooks.
+type Event struct {
+	Event    string    `json:"event"`
+	Time     time.Time `json:"time"`
+	Repo     string    `json:"repo"`
+	FullName string    `json:"full_name,omitempty"`
+	PR       int       `json:"pr"`
+	Title    string    `json:"title,omitempty"`
+	Author   string    `json:"author,omitempty"`
+	Worktree string    `json:"worktree,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// env returns the ZEN_* environment variables describing e for a hook
+// command.
+func (e Event) env() []string {
+	return []string{
+		"ZEN_EVENT=" + e.Event,
+		"ZEN_REPO=" + e.Repo,
+		"ZEN_FULL_NAME=" + e.FullName,
+		"ZEN_PR=" + strconv.Itoa(e.PR),
+		"ZEN_TITLE=" + e.Title,
+		"ZEN_AUTHOR=" + e.Author,
+		"ZEN_WORKTREE=" + e.Worktree,
+		"ZEN_ERROR=" + e.Error,
+	}
+}
+
+// delivery is one event for one hook.
+type delivery struct {
+	hook  config.Hook
+	event Event
+}
+
+// queueSize bounds the deliveries waiting to run; more are dropped
+// rather than blocking the daemon.
+const queueSize = 256
+
+// Runner delivers events to hooks one after another in the background.
+type Runner struct {
+	logf func(format string, args ...any)
+
+	mu    sync.Mutex
+	hooks []config.Hook
+
+	ch   chan delivery
+	done chan struct{}
+	stop sync.Once
+}
+
+// NewRunner returns a runner logging failed deliveries with logf. Start
+// it with Run.
+func NewRunner(hooks []config.Hook, logf func(format string, args ...any)) *Runner {
+	return &Runner{
+		logf:  logf,
+		hooks: hooks,
+		ch:    make(chan delivery, queueSize),
+		done:  make(chan struct{}),
+	}
+}
+
+// Configure replaces the hooks events are delivered to, e.g. after a
+// config reload.
+func (r *Runner) Configure(hooks []config.Hook) {
+	r.mu.Lock()
+	r.hooks = hooks
+	r.mu.Unlock()
+}
+
+// Fire queues e for every hook that handles it, without blocking.
+func (r *Runner) Fire(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	r.mu.Lock()
+	hooks := r.hooks
+	r.mu.Unlock()
+	for _, h := range hooks {
+		if !h.Handles(e.Event) {
+			continue
+		}
+		select {
+		case r.ch <- delivery{hook: h, event: e}:
+		default:
+			r.logf("Hook queue full, dropped %s for %s PR #%d", e.Event, e.Repo, e.PR)
+		}
+	}
+}
+
+// Run delivers queued events until Stop is called, then delivers what is
+// still queued and returns.
+func (r *Runner) Run() {
+	for {
+		select {
+		case d := <-r.ch:
+			r.deliver(d)
+		case <-r.done:
+			for {
+				select {
+				case d := <-r.ch:
+					r.deliver(d)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// Stop makes Run deliver what is queued and return.
+func (r *Runner) Stop() {
+	r.stop.Do(func() { close(r.done) })
+}
+
+func (r *Runner) deliver(d delivery) {
+	if err := Deliver(context.Background(), d.hook, d.event); err != nil {
+		r.logf("Hook %s failed for %s PR #%d: %v", d.hook.Target(), d.event.Repo, d.event.PR, err)
+	}
+}
+
+// Deliver sends e to h right away: POSTs it to h.URL, or runs h.Command
+// with the event in ZEN_* variables and as JSON on stdin. Secret
+// references in the hook are resolved first; errors name the reference,
+// never the secret.
+func Deliver(ctx context.Context, h config.Hook, e Event) error {
+	ctx, cancel := context.WithTimeout(ctx, h.TimeoutDuration())
+	defer cancel()
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	if h.URL != "" {
+		target, err := secrets.Resolve(ctx, h.URL)
+		if err != nil {
+			return err
+		}
+		headers, err := secrets.ResolveMap(ctx, h.Headers)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("invalid url %s", h.Target())
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "zen")
+		req.Header.Set("X-Zen-Event", e.Event)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			// *url.Error repeats the URL, which may be the secret
+			var uerr *url.Error
+			if errors.As(err, &uerr) {
+				err = uerr.Err
+			}
+			return fmt.Errorf("POST %s: %w", h.Target(), err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("POST %s: %s", h.Target(), resp.Status)
+		}
+		return nil
+	}
+
+	env, err := secrets.ResolveMap(ctx, h.Env)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", h.Command)
+	cmd.Env = append(os.Environ(), e.env()...)
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	if e.Worktree != "" {
+		if _, err := os.Stat(e.Worktree); err == nil {
+			cmd.Dir = e.Worktree
+		}
+	}
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", h.TimeoutDuration())
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+var (
+	runnerMu sync.Mutex
+	runner   *Runner
+)
+
+// Start delivers the events fired in this process to hooks in the
+// background until the returned function is called, which delivers what
+// is still queued before returning. Without Start, Fire does nothing.
+func Start(hooks []config.Hook, logf func(format string, args ...any)) (stop func()) {
+	r := NewRunner(hooks, logf)
+	finished := make(chan struct{})
+	go func() {
+		r.Run()
+		close(finished)
+	}()
+	runnerMu.Lock()
+	runner = r
+	runnerMu.Unlock()
+	return func() {
+		runnerMu.Lock()
+		runner = nil
+		runnerMu.Unlock()
+		r.Stop()
+		<-finished
+	}
+}
+
+// Configure replaces the hooks of the running Runner, if any.
+func Configure(hooks []config.Hook) {
+	if r := current(); r != nil {
+		r.Configure(hooks)
+	}
+}
+
+// Fire delivers e through the running Runner, if any.
+func Fire(e Event) {
+	if r := current(); r != nil {
+		r.Fire(e)
+	}
+}
+
+func current() *Runner {
+	runnerMu.Lock()
+	defer runnerMu.Unlock()
+	return runner
+}
+
+// onceTTL is how long FireOnce remembers an event.
+const onceTTL = 30 * 24 * time.Hour
+
+var onceMu sync.Mutex
+
+func firedPath() string {
+	return filepath.Join(config.StateDir(), "hooks_fired.json")
+}
+
+// FireOnce fires e unless an event of the same type for the same PR was
+// fired in the last 30 days, also across daemon restarts. It suits events
+// such as pr_merged that the daemon observes on every scan.
+func FireOnce(e Event) {
+	if current() == nil {
+		return
+	}
+	key := fmt.Sprintf("%s %s:%d", e.Event, e.Repo, e.PR)
+	now := time.Now()
+
+	onceMu.Lock()
+	fired := make(map[string]time.Time)
+	if data, err := os.ReadFile(firedPath()); err == nil {
+		json.Unmarshal(data, &fired)
+	}
+	for k, t := range fired {
+		if now.Sub(t) > onceTTL {
+			delete(fired, k)
+		}
+	}
+	_, seen := fired[key]
+	if !seen {
+		fired[key] = now
+	}
+	state.WriteJSON(firedPath(), fired)
+	onceMu.Unlock()
+
+	if !seen {
+		Fire(e)
+	}
+}
@@ -0,0 +1,134 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mgreau/zen/internal/config"
+)
+
+func TestDeliverURL(t *testing.T) {
+	var got Event
+	var header string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Zen-Event")
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	e := Event{Event: WorktreeReady, Repo: "mono", PR: 42, Worktree: "/tmp/mono-pr-42"}
+	if err := Deliver(context.Background(), config.Hook{URL: srv.URL}, e); err != nil {
+		t.Fatalf("Deliver() error: %v", err)
+	}
+	if got.Event != WorktreeReady || got.PR != 42 || got.Worktree != e.Worktree || header != WorktreeReady {
+		t.Errorf("server got %+v (X-Zen-Event %q); want %+v", got, header, e)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	if err := Deliver(context.Background(), config.Hook{URL: failing.URL}, e); err == nil || !strings.Contains(err.Error(), "502") {
+		t.Errorf("Deliver() to a failing URL = %v; want a 502 error", err)
+	}
+}
+
+func TestDeliverSecrets(t *testing.T) {
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+	t.Setenv("ZEN_TEST_HOOK_URL", srv.URL+"/T0/secret-path")
+	t.Setenv("ZEN_TEST_HOOK_TOKEN", "Bearer s3cret")
+
+	h := config.Hook{URL: "env://ZEN_TEST_HOOK_URL", Headers: map[string]string{"Authorization": "env://ZEN_TEST_HOOK_TOKEN"}}
+	e := Event{Event: PRMerged, Repo: "mono", PR: 9}
+	if err := Deliver(context.Background(), h, e); err != nil {
+		t.Fatalf("Deliver() error: %v", err)
+	}
+	if auth != "Bearer s3cret" {
+		t.Errorf("Authorization = %q; want the resolved secret", auth)
+	}
+
+	// Failures name the reference, not the resolved URL
+	srv.Close()
+	err := Deliver(context.Background(), h, e)
+	if err == nil || strings.Contains(err.Error(), "secret-path") || !strings.Contains(err.Error(), "env://ZEN_TEST_HOOK_URL") {
+		t.Errorf("Deliver() to a closed server = %v; want an error naming only the reference", err)
+	}
+	if err := Deliver(context.Background(), config.Hook{URL: "env://ZEN_TEST_UNSET"}, e); err == nil {
+		t.Error("Deliver() with an unset secret: want an error")
+	}
+
+	out := filepath.Join(t.TempDir(), "out")
+	cmd := config.Hook{Command: `printf %s "$TOKEN" > ` + out, Env: map[string]string{"TOKEN": "env://ZEN_TEST_HOOK_TOKEN"}}
+	if err := Deliver(context.Background(), cmd, e); err != nil {
+		t.Fatalf("Deliver() command error: %v", err)
+	}
+	if data, _ := os.ReadFile(out); string(data) != "Bearer s3cret" {
+		t.Errorf("command saw TOKEN=%q; want the resolved secret", data)
+	}
+}
+
+func TestDeliverCommand(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	h := config.Hook{Command: `printf '%s %s %s ' "$ZEN_EVENT" "$ZEN_REPO" "$ZEN_PR" > ` + out + ` && cat >> ` + out}
+	e := Event{Event: SetupFailed, Repo: "mono", PR: 7, Error: "fetch failed"}
+	if err := Deliver(context.Background(), h, e); err != nil {
+		t.Fatalf("Deliver() error: %v", err)
+	}
+	data, _ := os.ReadFile(out)
+	prefix, payload, _ := strings.Cut(string(data), "7 ")
+	if prefix != "setup_failed mono " {
+		t.Errorf("env = %q; want %q", prefix, "setup_failed mono ")
+	}
+	var got Event
+	if err := json.Unmarshal([]byte(payload), &got); err != nil || got.Error != "fetch failed" {
+		t.Errorf("stdin = %q (%v); want the event as JSON", payload, err)
+	}
+
+	if err := Deliver(context.Background(), config.Hook{Command: "echo nope >&2; exit 3"}, e); err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Errorf("Deliver() of a failing command = %v; want its output in the error", err)
+	}
+}
+
+func TestFireOnce(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var mu sync.Mutex
+	var got []Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e Event
+		json.NewDecoder(r.Body).Decode(&e)
+		mu.Lock()
+		got = append(got, e)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	// Without Start nothing is delivered or remembered
+	FireOnce(Event{Event: PRMerged, Repo: "mono", PR: 1})
+
+	stop := Start([]config.Hook{{URL: srv.URL, Events: []string{PRMerged}}}, t.Logf)
+	FireOnce(Event{Event: PRMerged, Repo: "mono", PR: 1})
+	FireOnce(Event{Event: PRMerged, Repo: "mono", PR: 1})
+	FireOnce(Event{Event: PRMerged, Repo: "mono", PR: 2})
+	Fire(Event{Event: WorktreeReady, Repo: "mono", PR: 3}) // not in the hook's events
+	stop()
+
+	var prs []int
+	for _, e := range got {
+		prs = append(prs, e.PR)
+	}
+	if len(prs) != 2 || prs[0] != 1 || prs[1] != 2 {
+		t.Errorf("delivered PRs %v; want [1 2]", prs)
+	}
+}
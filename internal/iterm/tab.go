@@ -79,3 +79,41 @@ func OpenTabWithClaude(workDir, initialPrompt, claudeBin, model string) error {
 	cmd += fmt.Sprintf(" %q", initialPrompt)
 	return OpenTab(workDir, cmd)
 }
+
+// OpenSplit opens a new iTerm2 tab split in two panes, side by side or
+// stacked, running first in the left or top pane and second in the other.
+func OpenSplit(workDir, first, second string, sideBySide bool) error {
+	c := palette[rand.Intn(len(palette))]
+	colorCmd := fmt.Sprintf(
+		`printf '\e]6;1;bg;red;brightness;%d\a\e]6;1;bg;green;brightness;%d\a\e]6;1;bg;blue;brightness;%d\a'`,
+		c[0], c[1], c[2],
+	)
+	// iTerm2 names splits by the divider: a vertical one puts panes side by side
+	split := "split horizontally"
+	if sideBySide {
+		split = "split vertically"
+	}
+	script := `tell application "iTerm2"
+    activate
+    tell current window
+        create tab with default profile
+        tell current session of current tab
+            write text (system attribute "ZEN_ITERM_CMD")
+            set other to (` + split + ` with default profile)
+        end tell
+        tell other
+            write text (system attribute "ZEN_ITERM_CMD2")
+        end tell
+    end tell
+end tell`
+
+	cmd := exec.Command("osascript", "-e", script)
+	cmd.Env = append(os.Environ(),
+		"ZEN_ITERM_CMD="+fmt.Sprintf("cd %q && %s && %s", workDir, colorCmd, first),
+		"ZEN_ITERM_CMD2="+fmt.Sprintf("cd %q && %s", workDir, second))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("osascript: %w: %s", err, string(out))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package journal
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Week summarizes the review activity of the last 7 days.
+type Week struct {
+	Since     time.Time `json:"since"`
+	Received  int       `json:"received"`
+	Completed int       `json:"completed"`
+	Dropped   int       `json:"dropped"`
+	// MedianTurnaround is the median time from request to review over the
+	// reviews completed this week whose request is in the journal.
+	MedianTurnaround time.Duration `json:"median_turnaround_ns,omitempty"`
+	Pending          int           `json:"pending"`
+	// NextWeek projects Pending a week from now if requests keep arriving
+	// and getting reviewed at this week's pace.
+	NextWeek int `json:"next_week"`
+	// ClearIn is how long clearing Pending takes at this week's pace, 0
+	// when there is no backlog or it is not shrinking.
+	ClearIn time.Duration `json:"clear_in_ns,omitempty"`
+}
+
+// Lookback is how far back callers should Read events for Summarize, so
+// requests made before the week can be matched with this week's reviews.
+const Lookback = 30 * 24 * time.Hour
+
+// Summarize computes the Week ending at now from the journal events and
+// the number of review requests currently pending.
+func Summarize(events []Event, pending int, now time.Time) Week {
+	w := Week{Since: now.Add(-7 * 24 * time.Hour), Pending: pending}
+
+	requestedAt := make(map[string]time.Time)
+	var turnarounds []time.Duration
+	for _, ev := range events {
+		key := fmt.Sprintf("%s#%d", ev.Repo, ev.PRNumber)
+		inWeek := !ev.Time.Before(w.Since) && !ev.Time.After(now)
+		switch ev.Kind {
+		case ReviewRequested:
+			requestedAt[key] = ev.Time
+			if inWeek {
+				w.Received++
+			}
+		case ReviewCompleted:
+			if inWeek {
+				w.Completed++
+				if at, ok := requestedAt[key]; ok && ev.Time.After(at) {
+					turnarounds = append(turnarounds, ev.Time.Sub(at))
+				}
+			}
+			delete(requestedAt, key)
+		case RequestDropped:
+			if inWeek {
+				w.Dropped++
+			}
+			delete(requestedAt, key)
+		}
+	}
+
+	if n := len(turnarounds); n > 0 {
+		sort.Slice(turnarounds, func(i, j int) bool { return turnarounds[i] < turnarounds[j] })
+		if n%2 == 1 {
+			w.MedianTurnaround = turnarounds[n/2]
+		} else {
+			w.MedianTurnaround = (turnarounds[n/2-1] + turnarounds[n/2]) / 2
+		}
+	}
+
+	// Requests that go away without a review also shrink the backlog
+	resolved := w.Completed + w.Dropped
+	w.NextWeek = max(0, pending+w.Received-resolved)
+	if net := resolved - w.Received; pending > 0 && net > 0 {
+		w.ClearIn = time.Duration(float64(pending) / float64(net) * float64(7*24*time.Hour))
+	}
+	return w
+}
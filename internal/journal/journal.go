@@ -0,0 +1,127 @@
+// Package journal records review activity seen by the watch daemon in an
+// append-only event journal and summarizes it for zen status.
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// Event kinds.
+const (
+	ReviewRequested = "review_requested"
+	ReviewCompleted = "review_completed"
+	// RequestDropped is a request that went away without a review from
+	// you, e.g. the PR was closed or you were removed as a reviewer.
+	RequestDropped = "request_dropped"
+)
+
+// retention bounds how far back the journal is kept.
+const retention = 90 * 24 * time.Hour
+
+// pruneSize is the file size above which Append drops expired events.
+const pruneSize = 1 << 20
+
+// Event is one entry in the journal.
+type Event struct {
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`
+	Repo     string    `json:"repo"` // owner/name
+	PRNumber int       `json:"pr_number"`
+	Title    string    `json:"title,omitempty"`
+	Author   string    `json:"author,omitempty"`
+}
+
+var mu sync.Mutex
+
+// Path returns the journal file path.
+func Path() string {
+	return filepath.Join(config.StateDir(), "journal.jsonl")
+}
+
+// Append adds an event to the journal (best-effort), setting its time if
+// unset.
+func Append(ev Event) error {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	line, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err := os.MkdirAll(config.StateDir(), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(Path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(Path()); err == nil && info.Size() > pruneSize {
+		prune(time.Now().Add(-retention))
+	}
+	return nil
+}
+
+// prune rewrites the journal without events older than cutoff. Callers
+// hold mu.
+func prune(cutoff time.Time) {
+	events, err := read(cutoff)
+	if err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, ev := range events {
+		enc.Encode(ev)
+	}
+	state.WriteFile(Path(), buf.Bytes(), 0o644)
+}
+
+// Read returns the events recorded at or after since, oldest first.
+// A missing journal yields no events.
+func Read(since time.Time) ([]Event, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return read(since)
+}
+
+func read(since time.Time) ([]Event, error) {
+	f, err := os.Open(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var ev Event
+		if json.Unmarshal(scanner.Bytes(), &ev) != nil {
+			continue
+		}
+		if !ev.Time.Before(since) {
+			events = append(events, ev)
+		}
+	}
+	return events, scanner.Err()
+}
@@ -0,0 +1,72 @@
+package journal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if events, err := Read(time.Time{}); err != nil || len(events) != 0 {
+		t.Fatalf("Read() on a missing journal = %v, %v", events, err)
+	}
+
+	now := time.Now()
+	Append(Event{Time: now.Add(-48 * time.Hour), Kind: ReviewRequested, Repo: "o/mono", PRNumber: 1})
+	Append(Event{Kind: ReviewCompleted, Repo: "o/mono", PRNumber: 1})
+
+	all, err := Read(time.Time{})
+	if err != nil || len(all) != 2 {
+		t.Fatalf("Read() = %+v, %v; want 2 events", all, err)
+	}
+	if all[1].Time.IsZero() {
+		t.Error("Append() should set the time of events without one")
+	}
+	if recent, _ := Read(now.Add(-time.Hour)); len(recent) != 1 || recent[0].Kind != ReviewCompleted {
+		t.Errorf("Read(since 1h) = %+v; want only the completion", recent)
+	}
+
+	mu.Lock()
+	prune(now.Add(-time.Hour))
+	mu.Unlock()
+	if all, _ := Read(time.Time{}); len(all) != 1 {
+		t.Errorf("after prune Read() = %+v; want 1 event", all)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	ev := func(ago time.Duration, kind string, pr int) Event {
+		return Event{Time: now.Add(-ago), Kind: kind, Repo: "o/mono", PRNumber: pr}
+	}
+	events := []Event{
+		ev(10*day, ReviewRequested, 1), // requested before the week
+		ev(6*day, ReviewCompleted, 1),  // 4d turnaround
+		ev(5*day, ReviewRequested, 2),
+		ev(5*day-2*time.Hour, ReviewCompleted, 2), // 2h
+		ev(3*day, ReviewRequested, 3),
+		ev(2*day, ReviewCompleted, 3), // 1d
+		ev(2*day, ReviewRequested, 4),
+		ev(1*day, RequestDropped, 4),
+		ev(1*day, ReviewCompleted, 99), // request not in the journal
+	}
+
+	w := Summarize(events, 2, now)
+	if w.Received != 3 || w.Completed != 4 || w.Dropped != 1 {
+		t.Errorf("Summarize() counts = %d received, %d completed, %d dropped; want 3, 4, 1", w.Received, w.Completed, w.Dropped)
+	}
+	if w.MedianTurnaround != day {
+		t.Errorf("MedianTurnaround = %s, want 24h", w.MedianTurnaround)
+	}
+	// 5 resolved vs 3 received: the backlog of 2 shrinks by 2 a week
+	if w.NextWeek != 0 || w.ClearIn != 7*day {
+		t.Errorf("NextWeek = %d, ClearIn = %s; want 0 and 168h", w.NextWeek, w.ClearIn)
+	}
+
+	w = Summarize([]Event{ev(day, ReviewRequested, 5), ev(day, ReviewRequested, 6)}, 4, now)
+	if w.NextWeek != 6 || w.ClearIn != 0 || w.MedianTurnaround != 0 {
+		t.Errorf("growing backlog: NextWeek = %d, ClearIn = %s, median = %s; want 6, 0, 0", w.NextWeek, w.ClearIn, w.MedianTurnaround)
+	}
+}
@@ -0,0 +1,200 @@
+// Package launchd runs the watch daemon as a macOS LaunchAgent, so it
+// starts at login and is restarted by launchd when it crashes.
+package launchd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/mgreau/zen/internal/state"
+)
+
+// Label identifies zen's LaunchAgent.
+const Label = "com.github.mgreau.zen.watch"
+
+// ErrUnsupported is returned off macOS.
+var ErrUnsupported = errors.New("launchd is only available on macOS")
+
+// Agent describes the LaunchAgent running the watch daemon.
+type Agent struct {
+	Program string            // zen binary, run as `zen watch daemon`
+	WorkDir string            // working directory of the daemon
+	LogPath string            // stdout and stderr
+	Env     map[string]string // environment, e.g. PATH so git, gh and claude are found
+}
+
+const plistTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>{{xml .Label}}</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>{{xml .Program}}</string>
+		<string>watch</string>
+		<string>daemon</string>
+	</array>
+{{- if .Env}}
+	<key>EnvironmentVariables</key>
+	<dict>
+{{- range .Env}}
+		<key>{{xml .Key}}</key>
+		<string>{{xml .Value}}</string>
+{{- end}}
+	</dict>
+{{- end}}
+	<key>WorkingDirectory</key>
+	<string>{{xml .WorkDir}}</string>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<dict>
+		<key>SuccessfulExit</key>
+		<false/>
+	</dict>
+	<key>ThrottleInterval</key>
+	<integer>30</integer>
+	<key>StandardOutPath</key>
+	<string>{{xml .LogPath}}</string>
+	<key>StandardErrorPath</key>
+	<string>{{xml .LogPath}}</string>
+</dict>
+</plist>
+`
+
+var plistTmpl = template.Must(template.New("plist").Funcs(template.FuncMap{
+	"xml": func(s string) string {
+		var buf bytes.Buffer
+		xml.EscapeText(&buf, []byte(s))
+		return buf.String()
+	},
+}).Parse(plistTemplate))
+
+type envVar struct{ Key, Value string }
+
+// Plist renders the agent's property list. launchd restarts the daemon
+// when it exits with an error, but not after zen watch stop.
+func (a Agent) Plist() ([]byte, error) {
+	env := make([]envVar, 0, len(a.Env))
+	for k, v := range a.Env {
+		env = append(env, envVar{k, v})
+	}
+	sort.Slice(env, func(i, j int) bool { return env[i].Key < env[j].Key })
+
+	var buf bytes.Buffer
+	err := plistTmpl.Execute(&buf, struct {
+		Agent
+		Label string
+		Env   []envVar
+	}{a, Label, env})
+	return buf.Bytes(), err
+}
+
+// PlistPath returns where the agent's property list is installed.
+func PlistPath() string {
+	return filepath.Join(os.Getenv("HOME"), "Library", "LaunchAgents", Label+".plist")
+}
+
+// Installed reports whether the agent's property list is installed.
+func Installed() bool {
+	_, err := os.Stat(PlistPath())
+	return err == nil
+}
+
+// service is the agent in the user's GUI domain, as launchctl names it.
+func service() string {
+	return fmt.Sprintf("gui/%d/%s", os.Getuid(), Label)
+}
+
+func launchctl(args ...string) (string, error) {
+	out, err := exec.Command("launchctl", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("launchctl %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// Install writes the agent's property list and loads it, which starts
+// the daemon. An agent already loaded is reloaded with the new list.
+func Install(a Agent) error {
+	if runtime.GOOS != "darwin" {
+		return ErrUnsupported
+	}
+	data, err := a.Plist()
+	if err != nil {
+		return err
+	}
+	if err := state.WriteFile(PlistPath(), data, 0o644); err != nil {
+		return err
+	}
+	if loaded, _ := Status(); loaded {
+		if _, err := launchctl("bootout", service()); err != nil {
+			return err
+		}
+	}
+	_, err = launchctl("bootstrap", fmt.Sprintf("gui/%d", os.Getuid()), PlistPath())
+	return err
+}
+
+// Uninstall unloads the agent, which stops the daemon, and removes its
+// property list. Returns false when it was not installed.
+func Uninstall() (bool, error) {
+	if runtime.GOOS != "darwin" {
+		return false, ErrUnsupported
+	}
+	loaded, _ := Status()
+	if !loaded && !Installed() {
+		return false, nil
+	}
+	if loaded {
+		if _, err := launchctl("bootout", service()); err != nil {
+			return true, err
+		}
+	}
+	if err := os.Remove(PlistPath()); err != nil && !os.IsNotExist(err) {
+		return true, err
+	}
+	return true, nil
+}
+
+// Start asks launchd to start the loaded agent, if it is not running.
+func Start() error {
+	_, err := launchctl("kickstart", service())
+	return err
+}
+
+// Status reports whether the agent is loaded and the PID of its daemon,
+// 0 when it is not running.
+func Status() (loaded bool, pid int) {
+	if runtime.GOOS != "darwin" {
+		return false, 0
+	}
+	out, err := exec.Command("launchctl", "print", service()).Output()
+	if err != nil {
+		return false, 0
+	}
+	return true, parsePID(string(out))
+}
+
+// parsePID returns the "pid = N" of launchctl print output, or 0.
+func parsePID(out string) int {
+	for _, line := range strings.Split(out, "\n") {
+		k, v, ok := strings.Cut(strings.TrimSpace(line), " = ")
+		if ok && k == "pid" {
+			pid, _ := strconv.Atoi(v)
+			return pid
+		}
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+package launchd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlist(t *testing.T) {
+	a := Agent{
+		Program: "/opt/homebrew/bin/zen",
+		WorkDir: "/Users/jane",
+		LogPath: "/Users/jane/.zen/state/watch.log",
+		Env:     map[string]string{"PATH": "/opt/homebrew/bin:/usr/bin", "ZEN_CONFIG": "/Users/jane/a&b.yaml"},
+	}
+	data, err := a.Plist()
+	if err != nil {
+		t.Fatalf("Plist() error: %v", err)
+	}
+	plist := string(data)
+	for _, want := range []string{
+		"<string>" + Label + "</string>",
+		"<string>/opt/homebrew/bin/zen</string>\n\t\t<string>watch</string>\n\t\t<string>daemon</string>",
+		"<key>PATH</key>\n\t\t<string>/opt/homebrew/bin:/usr/bin</string>\n\t\t<key>ZEN_CONFIG</key>",
+		"<string>/Users/jane/a&amp;b.yaml</string>",
+		"<key>StandardErrorPath</key>\n\t<string>/Users/jane/.zen/state/watch.log</string>",
+		"<key>SuccessfulExit</key>\n\t\t<false/>",
+	} {
+		if !strings.Contains(plist, want) {
+			t.Errorf("Plist() is missing %q:\n%s", want, plist)
+		}
+	}
+
+	data, _ = Agent{Program: "zen"}.Plist()
+	if strings.Contains(string(data), "EnvironmentVariables") {
+		t.Error("Plist() without Env has EnvironmentVariables")
+	}
+}
+
+func TestParsePID(t *testing.T) {
+	out := "gui/501/" + Label + " = {\n\tactive count = 1\n\tstate = running\n\tpid = 4242\n\tprogram = /opt/homebrew/bin/zen\n}\n"
+	if got := parsePID(out); got != 4242 {
+		t.Errorf("parsePID() = %d, want 4242", got)
+	}
+	if got := parsePID("gui/501/x = {\n\tstate = not running\n}\n"); got != 0 {
+		t.Errorf("parsePID() of a stopped agent = %d, want 0", got)
+	}
+}
@@ -0,0 +1,389 @@
+// Package localapi serves a small JSON API on localhost so editor
+// extensions and launcher scripts can list worktrees, create reviews and
+// resume sessions without shelling out to zen and parsing its output.
+package localapi
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/review"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/state"
+	"github.com/mgreau/zen/internal/worktree"
+)
+
+// DefaultAddr is where the API listens unless told otherwise.
+const DefaultAddr = "127.0.0.1:7667"
+
+// ResumeFunc opens a Claude session for wt in a terminal, resuming the most
+// recent one if any. model overrides the configured model when set.
+type ResumeFunc func(ctx context.Context, wt worktree.Worktree, model string) (*ResumeResult, error)
+
+// ResumeResult describes the session opened by a ResumeFunc.
+type ResumeResult struct {
+	Worktree   string `json:"worktree_path"`
+	Name       string `json:"name"`
+	Terminal   string `json:"terminal"`
+	SessionID  string `json:"session_id,omitempty"` // empty for a new session
+	NewSession bool   `json:"new_session"`
+}
+
+// Server is the local API. Requests other than /v1/health must carry the
+// token from TokenPath as a bearer token, and a loopback Host header so a
+// web page can't reach the API through DNS rebinding.
+type Server struct {
+	cfgMu   sync.RWMutex
+	cfg     *config.Config
+	token   string
+	version string
+	resume  ResumeFunc
+}
+
+// New returns a server using cfg, authenticating requests with token.
+func New(cfg *config.Config, token, version string, resume ResumeFunc) *Server {
+	return &Server{cfg: cfg, token: token, version: version, resume: resume}
+}
+
+// SetConfig replaces the config used by subsequent requests.
+func (s *Server) SetConfig(cfg *config.Config) {
+	s.cfgMu.Lock()
+	defer s.cfgMu.Unlock()
+	s.cfg = cfg
+}
+
+func (s *Server) config() *config.Config {
+	s.cfgMu.RLock()
+	defer s.cfgMu.RUnlock()
+	return s.cfg
+}
+
+// TokenPath is the file holding the API token. Clients read it to
+// authenticate.
+func TokenPath() string {
+	return filepath.Join(config.StateDir(), "local_api.token")
+}
+
+// LoadOrCreateToken returns the API token, generating and saving a random
+// one, readable only by the user, on first use.
+func LoadOrCreateToken() (string, error) {
+	if data, err := os.ReadFile(TokenPath()); err == nil {
+		if token := strings.TrimSpace(string(data)); token != "" {
+			return token, nil
+		}
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+	if err := state.WriteFile(TokenPath(), []byte(token+"\n"), 0o600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// CheckAddr refuses listen addresses that are not on the loopback
+// interface: the API can create worktrees and open terminals.
+func CheckAddr(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	if !isLoopback(host) {
+		return fmt.Errorf("refusing to listen on %s: the local API only binds to loopback (127.0.0.1, ::1 or localhost)", addr)
+	}
+	return nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Serve listens on addr until ctx is cancelled.
+func (s *Server) Serve(ctx context.Context, addr string) error {
+	if err := CheckAddr(addr); err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Handler returns the API's routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/health", s.handleHealth)
+	mux.Handle("GET /v1/worktrees", s.auth(s.handleWorktrees))
+	mux.Handle("POST /v1/reviews", s.auth(s.handleCreateReview))
+	mux.Handle("POST /v1/sessions/resume", s.auth(s.handleResume))
+	return s.checkHost(mux)
+}
+
+// checkHost rejects requests whose Host header isn't a loopback name,
+// which is what a DNS rebinding attack from a web page would send.
+func (s *Server) checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if !isLoopback(strings.Trim(host, "[]")) {
+			writeError(w, http.StatusForbidden, "host %q not allowed", r.Host)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) auth(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "missing or wrong token -- send Authorization: Bearer <contents of %s>", TokenPath())
+			return
+		}
+		next(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok", "version": s.version})
+}
+
+// WorktreeEntry is a worktree as listed by GET /v1/worktrees.
+type WorktreeEntry struct {
+	worktree.Worktree
+	HasSession bool `json:"has_active_session"`
+}
+
+func (s *Server) handleWorktrees(w http.ResponseWriter, r *http.Request) {
+	cfg := s.config()
+	var wts []worktree.Worktree
+	if spec := r.URL.Query().Get("repo"); spec != "" {
+		repos, err := cfg.ResolveRepos(spec)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		for _, repo := range repos {
+			rw, err := worktree.ListForRepo(cfg, repo)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "listing worktrees: %v", err)
+				return
+			}
+			wts = append(wts, rw...)
+		}
+	} else {
+		var err error
+		if wts, err = worktree.ListAll(cfg); err != nil {
+			writeError(w, http.StatusInternalServerError, "listing worktrees: %v", err)
+			return
+		}
+	}
+
+	entries := make([]WorktreeEntry, 0, len(wts))
+	for _, wt := range wts {
+		entries = append(entries, WorktreeEntry{Worktree: wt, HasSession: session.HasActiveSession(wt.Path)})
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// modelRe matches the Claude model names a request may ask for, e.g.
+// "opus" or "claude-sonnet-4-5[1m]". The model ends up in the command
+// line of a terminal tab, so nothing a shell interprets is allowed.
+var modelRe = regexp.MustCompile(`^[A-Za-z0-9._:\[\]-]+$`)
+
+// validModel reports whether model is empty or a plausible model name.
+func validModel(model string) bool {
+	return model == "" || modelRe.MatchString(model)
+}
+
+// ReviewRequest is the body of POST /v1/reviews.
+type ReviewRequest struct {
+	Repo     string `json:"repo"` // short name or @group; detected when empty
+	PRNumber int    `json:"pr_number"`
+	Sparse   *bool  `json:"sparse"` // default from the repo's sparse setting
+	Full     bool   `json:"full"`
+	Open     bool   `json:"open"` // also open a Claude session
+	Model    string `json:"model"`
+}
+
+// ReviewResponse is returned by POST /v1/reviews.
+type ReviewResponse struct {
+	*review.Result
+	Session *ResumeResult `json:"session,omitempty"`
+}
+
+func (s *Server) handleCreateReview(w http.ResponseWriter, r *http.Request) {
+	var req ReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: %v", err)
+		return
+	}
+	if req.PRNumber <= 0 {
+		writeError(w, http.StatusBadRequest, "pr_number is required")
+		return
+	}
+	if !validModel(req.Model) {
+		writeError(w, http.StatusBadRequest, "invalid model %q", req.Model)
+		return
+	}
+
+	cfg := s.config()
+	ctx := r.Context()
+	repo := req.Repo
+	if repo == "" || config.IsGroupRef(repo) {
+		detected, err := review.DetectRepo(ctx, cfg, req.PRNumber, repo)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		repo = detected
+	}
+	sparse := cfg.RepoSparse(repo)
+	if req.Sparse != nil {
+		sparse = *req.Sparse
+	}
+
+	result, err := review.CreateWorktree(ctx, cfg, repo, req.PRNumber, review.Options{Sparse: sparse, Full: req.Full, ReadOnly: cfg.RepoReadOnly(repo)}, nil)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	resp := ReviewResponse{Result: result}
+	if req.Open {
+		wt, err := findWorktree(cfg, result.WorktreePath)
+		if err == nil {
+			resp.Session, err = s.resume(ctx, *wt, req.Model)
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "worktree created at %s, but opening a session failed: %v", result.WorktreePath, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// ResumeRequest is the body of POST /v1/sessions/resume. It names the
+// worktree either by path or by PR number (and repo when ambiguous).
+type ResumeRequest struct {
+	Path     string `json:"path"`
+	Repo     string `json:"repo"`
+	PRNumber int    `json:"pr_number"`
+	Model    string `json:"model"`
+}
+
+func (s *Server) handleResume(w http.ResponseWriter, r *http.Request) {
+	var req ResumeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: %v", err)
+		return
+	}
+	if req.Path == "" && req.PRNumber <= 0 {
+		writeError(w, http.StatusBadRequest, "path or pr_number is required")
+		return
+	}
+	if !validModel(req.Model) {
+		writeError(w, http.StatusBadRequest, "invalid model %q", req.Model)
+		return
+	}
+
+	cfg := s.config()
+	var (
+		wt  *worktree.Worktree
+		err error
+	)
+	if req.Path != "" {
+		wt, err = findWorktree(cfg, req.Path)
+	} else {
+		wt, err = findPRWorktree(cfg, req.Repo, req.PRNumber)
+	}
+	if err != nil {
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	}
+
+	res, err := s.resume(r.Context(), *wt, req.Model)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// findWorktree returns the zen worktree at path.
+func findWorktree(cfg *config.Config, path string) (*worktree.Worktree, error) {
+	wts, err := worktree.ListAll(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("listing worktrees: %w", err)
+	}
+	clean := filepath.Clean(path)
+	for _, wt := range wts {
+		if filepath.Clean(wt.Path) == clean {
+			return &wt, nil
+		}
+	}
+	return nil, fmt.Errorf("no zen worktree at %s", path)
+}
+
+// findPRWorktree returns the main review worktree of a PR, in repo when set.
+func findPRWorktree(cfg *config.Config, repo string, prNumber int) (*worktree.Worktree, error) {
+	wts, err := worktree.ListAll(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("listing worktrees: %w", err)
+	}
+	var matches []worktree.Worktree
+	for _, wt := range wts {
+		if wt.Type == worktree.TypePRReview && wt.PRNumber == prNumber && wt.Suffix == "" && (repo == "" || wt.Repo == repo) {
+			matches = append(matches, wt)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no review worktree for PR #%d", prNumber)
+	case 1:
+		return &matches[0], nil
+	}
+	return nil, fmt.Errorf("PR #%d has review worktrees in several repos -- pass repo", prNumber)
+}
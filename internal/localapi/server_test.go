@@ -0,0 +1,149 @@
+package localapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/worktree"
+)
+
+func testServer(t *testing.T) *Server {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	resume := func(ctx context.Context, wt worktree.Worktree, model string) (*ResumeResult, error) {
+		return &ResumeResult{Worktree: wt.Path, Name: wt.Name}, nil
+	}
+	return New(&config.Config{Repos: map[string]config.RepoConfig{}}, "secret", "v1.2.3", resume)
+}
+
+func do(t *testing.T, h http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, "http://127.0.0.1:7667"+path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHealthNeedsNoToken(t *testing.T) {
+	rec := do(t, testServer(t).Handler(), "GET", "/v1/health", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var got map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("parsing body: %v", err)
+	}
+	if got["version"] != "v1.2.3" {
+		t.Errorf("version = %q, want v1.2.3", got["version"])
+	}
+}
+
+func TestAuth(t *testing.T) {
+	h := testServer(t).Handler()
+	for _, token := range []string{"", "wrong"} {
+		if rec := do(t, h, "GET", "/v1/worktrees", token, ""); rec.Code != http.StatusUnauthorized {
+			t.Errorf("token %q: status = %d, want 401", token, rec.Code)
+		}
+	}
+	rec := do(t, h, "GET", "/v1/worktrees", "secret", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != "[]" {
+		t.Errorf("body = %s, want []", body)
+	}
+}
+
+func TestRejectsForeignHost(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://evil.example.com:7667/v1/health", nil)
+	rec := httptest.NewRecorder()
+	testServer(t).Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", rec.Code)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	h := testServer(t).Handler()
+	tests := []struct {
+		path, body string
+		want       int
+	}{
+		{"/v1/reviews", `{}`, http.StatusBadRequest},
+		{"/v1/reviews", `not json`, http.StatusBadRequest},
+		{"/v1/sessions/resume", `{}`, http.StatusBadRequest},
+		{"/v1/sessions/resume", `{"pr_number": 42}`, http.StatusNotFound},
+		{"/v1/reviews", `{"pr_number": 42, "model": "opus; rm -rf ~"}`, http.StatusBadRequest},
+		{"/v1/sessions/resume", `{"pr_number": 42, "model": "$(id)"}`, http.StatusBadRequest},
+		{"/v1/sessions/resume", `{"path": "/tmp/x", "model": "sonnet 'x'"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := do(t, h, "POST", tt.path, "secret", tt.body)
+		if rec.Code != tt.want {
+			t.Errorf("POST %s %s: status = %d, want %d", tt.path, tt.body, rec.Code, tt.want)
+		}
+		if !strings.Contains(rec.Body.String(), `"error"`) {
+			t.Errorf("POST %s %s: body %s has no error", tt.path, tt.body, rec.Body)
+		}
+	}
+}
+
+func TestCheckAddr(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1:7667", "localhost:0", "[::1]:7667"} {
+		if err := CheckAddr(addr); err != nil {
+			t.Errorf("CheckAddr(%q) = %v, want nil", addr, err)
+		}
+	}
+	for _, addr := range []string{"0.0.0.0:7667", ":7667", "192.168.1.5:7667", "nohost"} {
+		if err := CheckAddr(addr); err == nil {
+			t.Errorf("CheckAddr(%q) = nil, want error", addr)
+		}
+	}
+}
+
+func TestLoadOrCreateToken(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	first, err := LoadOrCreateToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 64 {
+		t.Errorf("token length = %d, want 64", len(first))
+	}
+	second, err := LoadOrCreateToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second != first {
+		t.Errorf("second call returned a new token")
+	}
+	info, err := os.Stat(TokenPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("token file mode = %o, want 600", perm)
+	}
+}
+
+func TestValidModel(t *testing.T) {
+	for _, m := range []string{"", "opus", "claude-sonnet-4-5", "claude-opus-4-1[1m]", "us.anthropic.claude:0"} {
+		if !validModel(m) {
+			t.Errorf("validModel(%q) = false", m)
+		}
+	}
+	for _, m := range []string{"opus; id", "$(id)", "a b", "`id`", "x|y", "x\ny"} {
+		if validModel(m) {
+			t.Errorf("validModel(%q) = true", m)
+		}
+	}
+}
@@ -3,7 +3,10 @@
 package coordmcp
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"sync"
 
 	mcpgo "github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
@@ -12,6 +15,7 @@ import (
 
 // Server wraps an MCP server with access to zen's configuration.
 type Server struct {
+	cfgMu  sync.RWMutex
 	cfg    *config.Config
 	server *mcpserver.MCPServer
 }
@@ -30,8 +34,33 @@ func New(cfg *config.Config) *Server {
 	return s
 }
 
-// Run starts the MCP server on stdio.
+// SetConfig replaces the config used by subsequent tool calls.
+func (s *Server) SetConfig(cfg *config.Config) {
+	s.cfgMu.Lock()
+	defer s.cfgMu.Unlock()
+	s.cfg = cfg
+}
+
+// config returns the current config.
+func (s *Server) config() *config.Config {
+	s.cfgMu.RLock()
+	defer s.cfgMu.RUnlock()
+	return s.cfg
+}
+
+// Run starts the MCP server on stdio. Edits to the config file take effect
+// on the next tool call, so long-lived Claude sessions never see a stale
+// repo list.
 func (s *Server) Run() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// stdout carries the protocol, so reload errors go to stderr
+	if err := config.Watch(ctx, s.SetConfig, func(err error) {
+		fmt.Fprintf(os.Stderr, "zen mcp: config reload: %v\n", err)
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "zen mcp: %v\n", err)
+	}
+
 	if err := mcpserver.ServeStdio(s.server); err != nil {
 		return fmt.Errorf("MCP server error: %w", err)
 	}
@@ -43,7 +72,7 @@ func (s *Server) registerTools() {
 	s.server.AddTool(
 		mcpgo.NewTool("zen_inbox",
 			mcpgo.WithDescription("Fetch pending PR review requests from GitHub"),
-			mcpgo.WithString("repo", mcpgo.Description("Short repo name filter (e.g. 'mono')")),
+			mcpgo.WithString("repo", mcpgo.Description("Short repo name or @group filter (e.g. 'mono', '@images')")),
 			mcpgo.WithReadOnlyHintAnnotation(true),
 			mcpgo.WithDestructiveHintAnnotation(false),
 			mcpgo.WithOpenWorldHintAnnotation(true),
@@ -54,7 +83,7 @@ func (s *Server) registerTools() {
 	s.server.AddTool(
 		mcpgo.NewTool("zen_worktree_list",
 			mcpgo.WithDescription("List git worktrees across configured repositories"),
-			mcpgo.WithString("repo", mcpgo.Description("Short repo name filter (e.g. 'mono')")),
+			mcpgo.WithString("repo", mcpgo.Description("Short repo name or @group filter (e.g. 'mono', '@images')")),
 			mcpgo.WithReadOnlyHintAnnotation(true),
 			mcpgo.WithDestructiveHintAnnotation(false),
 			mcpgo.WithOpenWorldHintAnnotation(false),
@@ -100,7 +129,7 @@ func (s *Server) registerTools() {
 	s.server.AddTool(
 		mcpgo.NewTool("zen_who_am_i",
 			mcpgo.WithDescription("Summary of work done: merged PRs deployed to main, in-progress branches, and PR reviews for a given time period"),
-			mcpgo.WithString("repo", mcpgo.Description("Short repo name filter (e.g. 'mono')")),
+			mcpgo.WithString("repo", mcpgo.Description("Short repo name or @group filter (e.g. 'mono', '@images')")),
 			mcpgo.WithString("period", mcpgo.Description("Time period (e.g. '1d', '7d', '30d'). Default: '7d'")),
 			mcpgo.WithBoolean("merged_only", mcpgo.Description("Only show merged & deployed PRs with full descriptions")),
 			mcpgo.WithReadOnlyHintAnnotation(true),
@@ -124,7 +153,9 @@ func (s *Server) registerTools() {
 		mcpgo.NewTool("zen_review",
 			mcpgo.WithDescription("Create a worktree for a PR number (fetches branch, creates worktree, injects context)"),
 			mcpgo.WithNumber("pr_number", mcpgo.Description("Pull request number"), mcpgo.Required()),
-			mcpgo.WithString("repo", mcpgo.Description("Short repo name (auto-detected if omitted)")),
+			mcpgo.WithString("repo", mcpgo.Description("Short repo name, or @group to limit auto-detection (auto-detected if omitted)")),
+			mcpgo.WithBoolean("sparse", mcpgo.Description("Sparse-checkout only the PR's changed dirs (defaults to the repo's sparse setting)")),
+			mcpgo.WithBoolean("full", mcpgo.Description("Fetch full history, ignoring the repo's fetch_depth and fetch_filter")),
 			mcpgo.WithReadOnlyHintAnnotation(false),
 			mcpgo.WithDestructiveHintAnnotation(false),
 			mcpgo.WithOpenWorldHintAnnotation(true),
@@ -132,6 +163,18 @@ func (s *Server) registerTools() {
 		s.handleReview,
 	)
 
+	s.server.AddTool(
+		mcpgo.NewTool("zen_review_setup",
+			mcpgo.WithDescription("Prepare review worktrees for a list of PRs: queued for the watch daemon when it is running, else set up right away"),
+			mcpgo.WithArray("pr_numbers", mcpgo.Description("Pull request numbers"), mcpgo.WithNumberItems(), mcpgo.Required()),
+			mcpgo.WithString("repo", mcpgo.Description("Short repo name, or @group to limit auto-detection (auto-detected if omitted)")),
+			mcpgo.WithReadOnlyHintAnnotation(false),
+			mcpgo.WithDestructiveHintAnnotation(false),
+			mcpgo.WithOpenWorldHintAnnotation(true),
+		),
+		s.handleReviewSetup,
+	)
+
 	s.server.AddTool(
 		mcpgo.NewTool("zen_review_resume",
 			mcpgo.WithDescription("Get resume info (worktree path and sessions) for an existing PR review worktree"),
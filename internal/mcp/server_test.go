@@ -3,10 +3,16 @@ package coordmcp
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	mcpgo "github.com/mark3labs/mcp-go/mcp"
 	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/prcache"
+	"github.com/mgreau/zen/internal/reconciler"
+	"github.com/mgreau/zen/internal/state"
 )
 
 func testConfig() *config.Config {
@@ -199,6 +205,39 @@ func TestHandleReviewResumeNoWorktree(t *testing.T) {
 	}
 }
 
+func TestHandleReviewSetupQueuesForDaemon(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	srv := New(testConfig())
+	ctx := context.Background()
+
+	result, err := srv.handleReviewSetup(ctx, makeRequest(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected tool error for missing pr_numbers")
+	}
+
+	// A running daemon gets the PRs queued
+	state.WriteFile(filepath.Join(config.StateDir(), "watch.pid"), []byte(strconv.Itoa(os.Getpid())), 0o644)
+	prcache.Set("mono", 7, "Fix auth", "alice")
+	result, err = srv.handleReviewSetup(ctx, makeRequest(map[string]any{"repo": "mono", "pr_numbers": []any{7}}))
+	if err != nil || result.IsError {
+		t.Fatalf("handleReviewSetup() = %v, %v", result.Content, err)
+	}
+	var entries []reviewSetupEntry
+	if err := json.Unmarshal([]byte(mcpgo.GetTextFromContent(result.Content[0])), &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Status != "queued" || entries[0].Repo != "mono" {
+		t.Errorf("entries = %+v, want PR 7 queued", entries)
+	}
+	reqs := reconciler.TakeSetupRequests()
+	if len(reqs) != 1 || reqs[0].Key != "mono:7" || reqs[0].Title != "Fix auth" || reqs[0].Author != "alice" {
+		t.Errorf("setup requests = %+v, want mono:7 with its cached title", reqs)
+	}
+}
+
 func TestJsonResult(t *testing.T) {
 	type testData struct {
 		Name  string `json:"name"`
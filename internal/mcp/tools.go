@@ -12,10 +12,13 @@ import (
 	"time"
 
 	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mgreau/zen/internal/config"
 	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/prcache"
 	"github.com/mgreau/zen/internal/reconciler"
 	"github.com/mgreau/zen/internal/review"
 	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/ui"
 	"github.com/mgreau/zen/internal/worktree"
 )
 
@@ -30,15 +33,29 @@ func jsonResult(v any) (*mcpgo.CallToolResult, error) {
 
 // handleInbox fetches pending PR review requests from GitHub.
 func (s *Server) handleInbox(ctx context.Context, req mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+	cfg := s.config()
 	repoShort := req.GetString("repo", "")
-	repoFilter := ""
+
+	// No filter means a single query across all repos.
+	repoFilters := []string{""}
 	if repoShort != "" {
-		repoFilter = s.cfg.RepoFullName(repoShort)
+		repos, err := cfg.ResolveRepos(repoShort)
+		if err != nil {
+			return mcpgo.NewToolResultError(err.Error()), nil
+		}
+		repoFilters = repoFilters[:0]
+		for _, r := range repos {
+			repoFilters = append(repoFilters, cfg.RepoFullName(r))
+		}
 	}
 
-	reviews, err := ghpkg.GetReviewRequests(ctx, repoFilter)
-	if err != nil {
-		return mcpgo.NewToolResultError("failed to fetch review requests: " + err.Error()), nil
+	var reviews []ghpkg.ReviewRequest
+	for _, repoFilter := range repoFilters {
+		rr, _, err := ghpkg.GetReviewRequests(ctx, repoFilter, cfg.GetSearchLimit())
+		if err != nil {
+			return mcpgo.NewToolResultError("failed to fetch review requests: " + err.Error()), nil
+		}
+		reviews = append(reviews, rr...)
 	}
 	if reviews == nil {
 		reviews = []ghpkg.ReviewRequest{}
@@ -48,17 +65,28 @@ func (s *Server) handleInbox(ctx context.Context, req mcpgo.CallToolRequest) (*m
 
 // handleWorktreeList lists git worktrees across configured repositories.
 func (s *Server) handleWorktreeList(ctx context.Context, req mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+	cfg := s.config()
 	repoShort := req.GetString("repo", "")
 
 	var wts []worktree.Worktree
-	var err error
 	if repoShort != "" {
-		wts, err = worktree.ListForRepo(s.cfg, repoShort)
+		repos, err := cfg.ResolveRepos(repoShort)
+		if err != nil {
+			return mcpgo.NewToolResultError(err.Error()), nil
+		}
+		for _, r := range repos {
+			rw, err := worktree.ListForRepo(cfg, r)
+			if err != nil {
+				return mcpgo.NewToolResultError("failed to list worktrees: " + err.Error()), nil
+			}
+			wts = append(wts, rw...)
+		}
 	} else {
-		wts, err = worktree.ListAll(s.cfg)
-	}
-	if err != nil {
-		return mcpgo.NewToolResultError("failed to list worktrees: " + err.Error()), nil
+		var err error
+		wts, err = worktree.ListAll(cfg)
+		if err != nil {
+			return mcpgo.NewToolResultError("failed to list worktrees: " + err.Error()), nil
+		}
 	}
 	if wts == nil {
 		wts = []worktree.Worktree{}
@@ -77,7 +105,7 @@ func (s *Server) handlePRDetails(ctx context.Context, req mcpgo.CallToolRequest)
 		return mcpgo.NewToolResultError(err.Error()), nil
 	}
 
-	fullRepo := s.cfg.RepoFullName(repoShort)
+	fullRepo := s.config().RepoFullName(repoShort)
 	client, err := ghpkg.NewClient(ctx)
 	if err != nil {
 		return mcpgo.NewToolResultError("failed to create GitHub client: " + err.Error()), nil
@@ -101,7 +129,7 @@ func (s *Server) handlePRFiles(ctx context.Context, req mcpgo.CallToolRequest) (
 		return mcpgo.NewToolResultError(err.Error()), nil
 	}
 
-	fullRepo := s.cfg.RepoFullName(repoShort)
+	fullRepo := s.config().RepoFullName(repoShort)
 	client, err := ghpkg.NewClient(ctx)
 	if err != nil {
 		return mcpgo.NewToolResultError("failed to create GitHub client: " + err.Error()), nil
@@ -132,13 +160,14 @@ type agentStatusEntry struct {
 // handleAgentStatus lists Claude sessions across worktrees.
 // Uses cached session snapshot when available, falls back to real-time scanning.
 func (s *Server) handleAgentStatus(ctx context.Context, req mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+	cfg := s.config()
 	runningOnly := req.GetBool("running_only", false)
 
 	var entries []agentStatusEntry
 
 	// Try cached snapshot first — only use if it contains paths matching our config
 	snapshot, _ := reconciler.ReadSessionSnapshot()
-	basePaths := s.cfg.AllBasePaths()
+	basePaths := cfg.AllBasePaths()
 	if reconciler.IsSnapshotFresh(snapshot, 60*time.Second) && reconciler.SnapshotMatchesConfig(snapshot, basePaths) {
 		for _, ss := range snapshot.Sessions {
 			if runningOnly && ss.Status == "stopped" {
@@ -157,7 +186,7 @@ func (s *Server) handleAgentStatus(ctx context.Context, req mcpgo.CallToolReques
 		}
 	} else {
 		// Fall back to real-time scanning
-		wts, err := worktree.ListAll(s.cfg)
+		wts, err := worktree.ListAll(cfg)
 		if err != nil {
 			return mcpgo.NewToolResultError("failed to list worktrees: " + err.Error()), nil
 		}
@@ -211,14 +240,15 @@ type repoEntry struct {
 
 // handleReview creates a worktree for a PR number.
 func (s *Server) handleReview(ctx context.Context, req mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+	cfg := s.config()
 	prNumber, err := req.RequireInt("pr_number")
 	if err != nil {
 		return mcpgo.NewToolResultError(err.Error()), nil
 	}
 
 	repoShort := req.GetString("repo", "")
-	if repoShort == "" {
-		detected, err := review.DetectRepo(ctx, s.cfg, prNumber)
+	if repoShort == "" || config.IsGroupRef(repoShort) {
+		detected, err := review.DetectRepo(ctx, cfg, prNumber, repoShort)
 		if err != nil {
 			return mcpgo.NewToolResultError(err.Error()), nil
 		}
@@ -226,7 +256,11 @@ func (s *Server) handleReview(ctx context.Context, req mcpgo.CallToolRequest) (*
 	}
 
 	// Pass nil logger -- MCP must not write to stdout
-	result, err := review.CreateWorktree(ctx, s.cfg, repoShort, prNumber, nil)
+	result, err := review.CreateWorktree(ctx, cfg, repoShort, prNumber, review.Options{
+		Sparse:   req.GetBool("sparse", cfg.RepoSparse(repoShort)),
+		Full:     req.GetBool("full", false),
+		ReadOnly: cfg.RepoReadOnly(repoShort),
+	}, nil)
 	if err != nil {
 		return mcpgo.NewToolResultError(err.Error()), nil
 	}
@@ -248,7 +282,7 @@ func (s *Server) handleReviewResume(ctx context.Context, req mcpgo.CallToolReque
 		return mcpgo.NewToolResultError(err.Error()), nil
 	}
 
-	wts, err := worktree.ListAll(s.cfg)
+	wts, err := worktree.ListAll(s.config())
 	if err != nil {
 		return mcpgo.NewToolResultError("failed to list worktrees: " + err.Error()), nil
 	}
@@ -270,10 +304,87 @@ func (s *Server) handleReviewResume(ctx context.Context, req mcpgo.CallToolReque
 	return mcpgo.NewToolResultError(fmt.Sprintf("no PR review worktree for #%d", prNumber)), nil
 }
 
+// reviewSetupEntry holds the outcome of zen_review_setup for one PR.
+type reviewSetupEntry struct {
+	PRNumber     int    `json:"pr_number"`
+	Repo         string `json:"repo,omitempty"`
+	Status       string `json:"status"` // "queued", "ready" or "error"
+	WorktreePath string `json:"worktree_path,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// handleReviewSetup prepares the review worktrees of PRs: it queues them
+// for the watch daemon when it is running, else sets them up right away.
+func (s *Server) handleReviewSetup(ctx context.Context, req mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+	cfg := s.config()
+	prNumbers, err := req.RequireIntSlice("pr_numbers")
+	if err != nil {
+		return mcpgo.NewToolResultError(err.Error()), nil
+	}
+	if len(prNumbers) == 0 {
+		return mcpgo.NewToolResultError("pr_numbers is empty"), nil
+	}
+	repoSpec := req.GetString("repo", "")
+	daemon := reconciler.DaemonRunning()
+
+	entries := make([]reviewSetupEntry, 0, len(prNumbers))
+	for _, n := range prNumbers {
+		e := reviewSetupEntry{PRNumber: n}
+		if err := s.setupPR(ctx, cfg, repoSpec, daemon, &e); err != nil {
+			e.Status, e.Error = "error", err.Error()
+		}
+		entries = append(entries, e)
+	}
+	return jsonResult(entries)
+}
+
+// setupPR queues or runs the setup of e's PR, filling in e.
+func (s *Server) setupPR(ctx context.Context, cfg *config.Config, repoSpec string, daemon bool, e *reviewSetupEntry) error {
+	repo := repoSpec
+	if repo == "" || config.IsGroupRef(repo) {
+		detected, err := review.DetectRepo(ctx, cfg, e.PRNumber, repo)
+		if err != nil {
+			return err
+		}
+		repo = detected
+	}
+	if cfg.RepoBasePath(repo) == "" {
+		return fmt.Errorf("unknown repo %q", repo)
+	}
+	e.Repo = repo
+
+	title, author := "", ""
+	if meta, ok := prcache.Get(repo, e.PRNumber); ok && meta.Title != "" {
+		title, author = meta.Title, meta.Author
+	} else if client, err := ghpkg.NewClient(ctx); err == nil {
+		if details, err := client.GetPRDetails(ctx, cfg.RepoFullName(repo), e.PRNumber); err == nil {
+			title, author = details.Title, details.Author
+		}
+	}
+
+	if daemon {
+		if err := reconciler.RequestSetup(reconciler.SetupRequest{Repo: repo, PRNumber: e.PRNumber, Title: title, Author: author}); err != nil {
+			return err
+		}
+		e.Status = "queued"
+		return nil
+	}
+
+	// Steps are discarded -- MCP must not write to stdout
+	steps := ui.NewStepLogger(func(string) {})
+	path, err := reconciler.NewSetupReconciler(cfg).EnsurePR(ctx, repo, e.PRNumber, title, author, steps)
+	if err != nil {
+		return err
+	}
+	reconciler.ClearSetupFailure(reconciler.MakePRKey(repo, e.PRNumber))
+	e.Status, e.WorktreePath = "ready", path
+	return nil
+}
+
 // handleConfigRepos lists configured repositories.
 func (s *Server) handleConfigRepos(ctx context.Context, req mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
 	var repos []repoEntry
-	for name, rc := range s.cfg.Repos {
+	for name, rc := range s.config().Repos {
 		repos = append(repos, repoEntry{
 			ShortName: name,
 			FullName:  rc.FullName,
@@ -323,6 +434,7 @@ type whoAmISummary struct {
 
 // handleWhoAmI returns a summary of work done across repos.
 func (s *Server) handleWhoAmI(ctx context.Context, req mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+	cfg := s.config()
 	repoFilter := req.GetString("repo", "")
 	period := req.GetString("period", "7d")
 	mergedOnly := req.GetBool("merged_only", false)
@@ -333,18 +445,22 @@ func (s *Server) handleWhoAmI(ctx context.Context, req mcpgo.CallToolRequest) (*
 	}
 
 	// Determine repos
-	repos := s.cfg.RepoNames()
-	if repoFilter != "" {
-		if s.cfg.RepoBasePath(repoFilter) == "" {
-			return mcpgo.NewToolResultError(fmt.Sprintf("unknown repo %q", repoFilter)), nil
+	repos, err := cfg.ResolveRepos(repoFilter)
+	if err != nil {
+		return mcpgo.NewToolResultError(err.Error()), nil
+	}
+	repoSet := make(map[string]bool, len(repos))
+	for _, r := range repos {
+		if cfg.RepoBasePath(r) == "" {
+			return mcpgo.NewToolResultError(fmt.Sprintf("unknown repo %q", r)), nil
 		}
-		repos = []string{repoFilter}
+		repoSet[r] = true
 	}
 
 	// Merged commits
 	var merged []whoAmIMergedEntry
 	for _, repo := range repos {
-		basePath := s.cfg.RepoBasePath(repo)
+		basePath := cfg.RepoBasePath(repo)
 		originPath := filepath.Join(basePath, repo)
 		entries := whoamiMergedCommits(originPath, since, mergedOnly)
 		for i := range entries {
@@ -368,14 +484,14 @@ func (s *Server) handleWhoAmI(ctx context.Context, req mcpgo.CallToolRequest) (*
 	}
 
 	// In-progress worktrees
-	wts, err := worktree.ListAll(s.cfg)
+	wts, err := worktree.ListAll(cfg)
 	if err != nil {
 		return mcpgo.NewToolResultError("failed to list worktrees: " + err.Error()), nil
 	}
 
 	var inProgress, prReviews []whoAmIWorktreeEntry
 	for _, wt := range wts {
-		if repoFilter != "" && wt.Repo != repoFilter {
+		if !repoSet[wt.Repo] {
 			continue
 		}
 
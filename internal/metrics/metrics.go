@@ -0,0 +1,186 @@
+// Package metrics records how long zen commands take, when the user opts in
+// with metrics: true. Records stay in a local file and are only read back by
+// zen stats --cli; nothing is sent anywhere.
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// retention bounds how far back records are kept.
+const retention = 90 * 24 * time.Hour
+
+// pruneSize is the file size above which Append drops expired records.
+const pruneSize = 1 << 20
+
+// Record is one command execution.
+type Record struct {
+	Time       time.Time `json:"time"`
+	Command    string    `json:"command"` // e.g. "review resume"
+	DurationMS int64     `json:"duration_ms"`
+	Failed     bool      `json:"failed,omitempty"`
+}
+
+var mu sync.Mutex
+
+// Path returns the metrics file path.
+func Path() string {
+	return filepath.Join(config.StateDir(), "metrics.jsonl")
+}
+
+// Append adds a record (best-effort), setting its time if unset.
+func Append(r Record) error {
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err := os.MkdirAll(config.StateDir(), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(Path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(Path()); err == nil && info.Size() > pruneSize {
+		prune(time.Now().Add(-retention))
+	}
+	return nil
+}
+
+// prune rewrites the file without records older than cutoff. Callers
+// hold mu.
+func prune(cutoff time.Time) {
+	records, err := read(cutoff)
+	if err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		enc.Encode(r)
+	}
+	state.WriteFile(Path(), buf.Bytes(), 0o644)
+}
+
+// Read returns the records made at or after since, oldest first. A missing
+// file yields no records.
+func Read(since time.Time) ([]Record, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return read(since)
+}
+
+func read(since time.Time) ([]Record, error) {
+	f, err := os.Open(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		if json.Unmarshal(scanner.Bytes(), &r) != nil {
+			continue
+		}
+		if !r.Time.Before(since) {
+			records = append(records, r)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// Clear removes all records.
+func Clear() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if err := os.Remove(Path()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// CommandStats summarizes the executions of one command.
+type CommandStats struct {
+	Command  string        `json:"command"`
+	Count    int           `json:"count"`
+	Failures int           `json:"failures"`
+	Mean     time.Duration `json:"mean_ns"`
+	P50      time.Duration `json:"p50_ns"`
+	P95      time.Duration `json:"p95_ns"`
+	Max      time.Duration `json:"max_ns"`
+	Total    time.Duration `json:"total_ns"`
+	LastRun  time.Time     `json:"last_run"`
+}
+
+// Summarize groups records by command, slowest median first.
+func Summarize(records []Record) []CommandStats {
+	byCmd := make(map[string][]Record)
+	for _, r := range records {
+		byCmd[r.Command] = append(byCmd[r.Command], r)
+	}
+
+	stats := make([]CommandStats, 0, len(byCmd))
+	for name, rs := range byCmd {
+		s := CommandStats{Command: name, Count: len(rs)}
+		durations := make([]time.Duration, len(rs))
+		for i, r := range rs {
+			durations[i] = time.Duration(r.DurationMS) * time.Millisecond
+			s.Total += durations[i]
+			if r.Failed {
+				s.Failures++
+			}
+			if r.Time.After(s.LastRun) {
+				s.LastRun = r.Time
+			}
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		s.Mean = s.Total / time.Duration(len(durations))
+		s.P50 = Percentile(durations, 50)
+		s.P95 = Percentile(durations, 95)
+		s.Max = durations[len(durations)-1]
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].P50 != stats[j].P50 {
+			return stats[i].P50 > stats[j].P50
+		}
+		return stats[i].Command < stats[j].Command
+	})
+	return stats
+}
+
+// Percentile returns the nearest-rank p-th percentile of sorted durations.
+func Percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
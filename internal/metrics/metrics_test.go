@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAppendReadClear(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if records, err := Read(time.Time{}); err != nil || len(records) != 0 {
+		t.Fatalf("Read() on a missing file = %v, %v", records, err)
+	}
+
+	now := time.Now()
+	Append(Record{Time: now.Add(-48 * time.Hour), Command: "inbox", DurationMS: 900})
+	Append(Record{Command: "status", DurationMS: 120, Failed: true})
+
+	all, err := Read(time.Time{})
+	if err != nil || len(all) != 2 {
+		t.Fatalf("Read() = %+v, %v; want 2 records", all, err)
+	}
+	if all[1].Time.IsZero() {
+		t.Error("Append() should set the time of records without one")
+	}
+	if recent, _ := Read(now.Add(-time.Hour)); len(recent) != 1 || recent[0].Command != "status" {
+		t.Errorf("Read(since 1h) = %+v; want only status", recent)
+	}
+
+	if err := Clear(); err != nil {
+		t.Fatalf("Clear() = %v", err)
+	}
+	if all, _ := Read(time.Time{}); len(all) != 0 {
+		t.Errorf("after Clear() Read() = %+v; want none", all)
+	}
+	if err := Clear(); err != nil {
+		t.Errorf("Clear() on a missing file = %v", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	var records []Record
+	for i := 1; i <= 20; i++ {
+		records = append(records, Record{Command: "inbox", DurationMS: int64(i * 100)})
+	}
+	records = append(records,
+		Record{Command: "status", DurationMS: 50},
+		Record{Command: "status", DurationMS: 150, Failed: true},
+	)
+
+	stats := Summarize(records)
+	if len(stats) != 2 || stats[0].Command != "inbox" {
+		t.Fatalf("Summarize() = %+v; want inbox first", stats)
+	}
+	in := stats[0]
+	if in.Count != 20 || in.P50 != time.Second || in.P95 != 1900*time.Millisecond || in.Max != 2*time.Second {
+		t.Errorf("inbox = %+v; want 20 runs, p50 1s, p95 1.9s, max 2s", in)
+	}
+	if in.Mean != 1050*time.Millisecond {
+		t.Errorf("inbox mean = %s, want 1.05s", in.Mean)
+	}
+	st := stats[1]
+	if st.Count != 2 || st.Failures != 1 || st.P50 != 50*time.Millisecond {
+		t.Errorf("status = %+v; want 2 runs, 1 failure, p50 50ms", st)
+	}
+}
@@ -0,0 +1,238 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Notification is one notification to deliver.
+type Notification struct {
+	Title    string
+	Message  string
+	Subtitle string
+	Action   string // command run when clicked, with terminal-notifier
+	PR       int    // PR the notification is about, if any
+
+	// Group batches notifications: those of one group that arrive within
+	// the batch window are delivered as the single notification Batch
+	// returns for them.
+	Group string
+	Batch func(ns []Notification) Notification
+}
+
+// key identifies repeats of a notification.
+func (n Notification) key() string {
+	return n.Title + "\x00" + n.Message + "\x00" + n.Subtitle
+}
+
+// Dispatcher delivers notifications in the background, so callers such as
+// the watch daemon's loop never wait on osascript. Notifications are
+// collected for a batch window before delivery, then batched by group
+// and delivered one after another; repeats of a notification delivered
+// within the dedupe window are dropped.
+type Dispatcher struct {
+	batchWindow  time.Duration
+	dedupeWindow time.Duration
+	deliver      func(Notification) error
+
+	ch   chan Notification
+	done chan struct{}
+	stop sync.Once
+
+	sent map[string]time.Time // by key; only touched by run
+}
+
+// queueSize bounds the notifications waiting for a batch; more are
+// dropped rather than blocking the sender.
+const queueSize = 256
+
+// NewDispatcher returns a dispatcher delivering with deliver. Start it
+// with Run.
+func NewDispatcher(batchWindow, dedupeWindow time.Duration, deliver func(Notification) error) *Dispatcher {
+	return &Dispatcher{
+		batchWindow:  batchWindow,
+		dedupeWindow: dedupeWindow,
+		deliver:      deliver,
+		ch:           make(chan Notification, queueSize),
+		done:         make(chan struct{}),
+		sent:         make(map[string]time.Time),
+	}
+}
+
+// Notify queues n for delivery without blocking. It reports false when
+// the queue is full and n was dropped.
+func (d *Dispatcher) Notify(n Notification) bool {
+	select {
+	case d.ch <- n:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run delivers queued notifications until Stop is called, then delivers
+// what is still queued and returns.
+func (d *Dispatcher) Run() {
+	var pending []Notification
+	var timer <-chan time.Time
+	for {
+		select {
+		case n := <-d.ch:
+			pending = append(pending, n)
+			if timer == nil {
+				timer = time.After(d.batchWindow)
+			}
+		case <-timer:
+			d.flush(pending, time.Now())
+			pending, timer = nil, nil
+		case <-d.done:
+			for {
+				select {
+				case n := <-d.ch:
+					pending = append(pending, n)
+				default:
+					d.flush(pending, time.Now())
+					return
+				}
+			}
+		}
+	}
+}
+
+// Stop makes Run deliver what is queued and return.
+func (d *Dispatcher) Stop() {
+	d.stop.Do(func() { close(d.done) })
+}
+
+// flush delivers a batch: repeats are dropped, groups merged.
+func (d *Dispatcher) flush(pending []Notification, now time.Time) {
+	for _, n := range d.prepare(pending, now) {
+		if err := d.deliver(n); err != nil {
+			fmt.Printf("[%s] Notification %q failed: %v\n", now.Format(time.RFC3339), n.Title, err)
+		}
+	}
+}
+
+// prepare drops the repeats in pending and merges each group with more
+// than one notification, keeping the order of first arrival.
+func (d *Dispatcher) prepare(pending []Notification, now time.Time) []Notification {
+	for k, t := range d.sent {
+		if now.Sub(t) >= d.dedupeWindow {
+			delete(d.sent, k)
+		}
+	}
+
+	var out []Notification
+	groups := make(map[string]int) // group -> index in out
+	members := make(map[string][]Notification)
+	for _, n := range pending {
+		k := n.key()
+		if _, dup := d.sent[k]; dup {
+			continue
+		}
+		d.sent[k] = now
+		if n.Group == "" || n.Batch == nil {
+			out = append(out, n)
+			continue
+		}
+		if _, ok := groups[n.Group]; !ok {
+			groups[n.Group] = len(out)
+			out = append(out, n)
+		}
+		members[n.Group] = append(members[n.Group], n)
+	}
+	for g, i := range groups {
+		if ns := members[g]; len(ns) > 1 {
+			out[i] = ns[0].Batch(ns)
+		}
+	}
+	return out
+}
+
+// deliver sends n right away.
+func deliver(n Notification) error {
+	if n.Action != "" {
+		return SendWithAction(n.Title, n.Message, n.Subtitle, n.Action)
+	}
+	return Send(n.Title, n.Message, n.Subtitle)
+}
+
+var (
+	dispatcherMu sync.Mutex
+	dispatcher   *Dispatcher
+)
+
+// Start routes the notifications of this process through a background
+// Dispatcher until the returned function is called, which delivers what
+// is still queued before returning. Without Start, notifications are
+// delivered synchronously.
+func Start(batchWindow, dedupeWindow time.Duration) (stop func()) {
+	d := NewDispatcher(batchWindow, dedupeWindow, deliver)
+	finished := make(chan struct{})
+	go func() {
+		d.Run()
+		close(finished)
+	}()
+	dispatcherMu.Lock()
+	dispatcher = d
+	dispatcherMu.Unlock()
+	return func() {
+		dispatcherMu.Lock()
+		dispatcher = nil
+		dispatcherMu.Unlock()
+		d.Stop()
+		<-finished
+	}
+}
+
+// muted is set while notifications are turned off.
+var muted atomic.Bool
+
+// Mute drops the notifications of this process while on, such as those of
+// a daemon with watch.notify set to false.
+func Mute(on bool) {
+	muted.Store(on)
+}
+
+// post delivers n through the running Dispatcher, or right away when
+// there is none.
+func post(n Notification) error {
+	if muted.Load() {
+		return nil
+	}
+	dispatcherMu.Lock()
+	d := dispatcher
+	dispatcherMu.Unlock()
+	if d == nil {
+		return deliver(n)
+	}
+	if !d.Notify(n) {
+		return fmt.Errorf("notification queue full, dropped %q", n.Title)
+	}
+	return nil
+}
+
+func prNumbers(ns []Notification) []int {
+	numbers := make([]int, len(ns))
+	for i, n := range ns {
+		numbers[i] = n.PR
+	}
+	return numbers
+}
+
+// prList joins PR numbers for a batched notification, e.g. "#12, #15 and
+// 3 more".
+func prList(numbers []int) string {
+	const shown = 5
+	var parts []string
+	for i, n := range numbers {
+		if i == shown {
+			return strings.Join(parts, ", ") + fmt.Sprintf(" and %d more", len(numbers)-shown)
+		}
+		parts = append(parts, fmt.Sprintf("#%d", n))
+	}
+	return strings.Join(parts, ", ")
+}
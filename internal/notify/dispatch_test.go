@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func review(pr int, repo string) Notification {
+	return Notification{
+		Title:   "New PR Review Request",
+		Message: fmt.Sprintf("PR #%d", pr),
+		PR:      pr,
+		Group:   "review:" + repo,
+		Batch: func(ns []Notification) Notification {
+			return Notification{Title: fmt.Sprintf("%d in %s", len(ns), repo), Message: prList(prNumbers(ns))}
+		},
+	}
+}
+
+func titles(ns []Notification) []string {
+	var out []string
+	for _, n := range ns {
+		out = append(out, n.Title)
+	}
+	return out
+}
+
+func TestDispatcherPrepare(t *testing.T) {
+	d := NewDispatcher(0, time.Hour, nil)
+	now := time.Now()
+
+	waiting := Notification{Title: "Claude is waiting", Message: "app-pr-1 needs your input"}
+	got := d.prepare([]Notification{
+		review(1, "mono"), waiting, review(2, "mono"), review(3, "app"), review(2, "mono"), review(4, "mono"),
+	}, now)
+	want := []string{"3 in mono", "Claude is waiting", "New PR Review Request"}
+	if !slices.Equal(titles(got), want) {
+		t.Fatalf("prepare() = %q, want %q", titles(got), want)
+	}
+	if got[0].Message != "#1, #2, #4" {
+		t.Errorf("batched message = %q, want #1, #2, #4", got[0].Message)
+	}
+
+	// Repeats within the dedupe window are dropped, later ones delivered
+	if got := d.prepare([]Notification{waiting, review(5, "mono")}, now.Add(time.Minute)); !slices.Equal(titles(got), []string{"New PR Review Request"}) {
+		t.Errorf("prepare() within the window = %q, want only the new request", titles(got))
+	}
+	if got := d.prepare([]Notification{waiting}, now.Add(2*time.Hour)); len(got) != 1 {
+		t.Errorf("prepare() after the window = %q, want the repeat delivered", titles(got))
+	}
+}
+
+func TestDispatcherRun(t *testing.T) {
+	var mu sync.Mutex
+	var delivered []string
+	d := NewDispatcher(time.Hour, time.Hour, func(n Notification) error {
+		mu.Lock()
+		defer mu.Unlock()
+		delivered = append(delivered, n.Title)
+		return nil
+	})
+	finished := make(chan struct{})
+	go func() {
+		d.Run()
+		close(finished)
+	}()
+
+	for pr := 1; pr <= 15; pr++ {
+		if !d.Notify(review(pr, "mono")) {
+			t.Fatalf("Notify(%d) dropped", pr)
+		}
+	}
+	// Stop delivers what is queued without waiting for the batch window
+	d.Stop()
+	<-finished
+	if !slices.Equal(delivered, []string{"15 in mono"}) {
+		t.Errorf("delivered %q, want one batched notification", delivered)
+	}
+}
+
+func TestDispatcherNotifyFull(t *testing.T) {
+	d := NewDispatcher(0, 0, nil) // not running, so nothing drains the queue
+	for i := range queueSize {
+		if !d.Notify(Notification{Title: fmt.Sprint(i)}) {
+			t.Fatalf("Notify(%d) dropped before the queue was full", i)
+		}
+	}
+	if d.Notify(Notification{Title: "one too many"}) {
+		t.Error("Notify() should drop instead of blocking when the queue is full")
+	}
+}
+
+func TestPRList(t *testing.T) {
+	if got := prList([]int{1, 2}); got != "#1, #2" {
+		t.Errorf("prList() = %q", got)
+	}
+	if got := prList([]int{1, 2, 3, 4, 5, 6, 7}); got != "#1, #2, #3, #4, #5 and 2 more" {
+		t.Errorf("prList() = %q", got)
+	}
+}
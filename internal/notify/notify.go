@@ -54,52 +54,134 @@ func SendWithAction(title, message, subtitle, executeOnClick string) error {
 	return Send(title, message, subtitle)
 }
 
-
-// PRReview notifies about a new PR review request.
+// PRReview notifies about a new PR review request. Requests for one repo
+// arriving together are batched into one notification.
 func PRReview(prNumber int, prTitle, author, repo string) error {
-	return Send(
-		"New PR Review Request",
-		fmt.Sprintf("PR #%d: %s", prNumber, prTitle),
-		fmt.Sprintf("by %s in %s", author, repo),
-	)
+	return post(Notification{
+		Title:    "New PR Review Request",
+		Message:  fmt.Sprintf("PR #%d: %s", prNumber, prTitle),
+		Subtitle: fmt.Sprintf("by %s in %s", author, repo),
+		PR:       prNumber,
+		Group:    "review:" + repo,
+		Batch: func(ns []Notification) Notification {
+			return Notification{
+				Title:    fmt.Sprintf("%d New PR Review Requests", len(ns)),
+				Message:  fmt.Sprintf("%d new review requests in %s: %s", len(ns), repo, prList(prNumbers(ns))),
+				Subtitle: "Run: zen inbox",
+			}
+		},
+	})
 }
 
 // WorktreeReady notifies that a worktree is ready for review.
 // Clicking opens a terminal tab in the worktree (requires terminal-notifier).
+// Worktrees ready together are batched into one notification.
 func WorktreeReady(prNumber int, worktreePath string) error {
-	return SendWithAction(
-		"Worktree Ready — click to review",
-		fmt.Sprintf("PR #%d", prNumber),
-		"",
-		fmt.Sprintf("%s review resume %d", zenBin(), prNumber),
-	)
+	return post(Notification{
+		Title:   "Worktree Ready — click to review",
+		Message: fmt.Sprintf("PR #%d", prNumber),
+		Action:  fmt.Sprintf("%s review resume %d", zenBin(), prNumber),
+		PR:      prNumber,
+		Group:   "ready",
+		Batch: func(ns []Notification) Notification {
+			return Notification{
+				Title:    fmt.Sprintf("%d Worktrees Ready", len(ns)),
+				Message:  prList(prNumbers(ns)),
+				Subtitle: "Run: zen status",
+			}
+		},
+	})
+}
+
+// SetupFailed notifies that the daemon gave up setting up a PR review
+// worktree. Clicking retries the setup (requires terminal-notifier).
+func SetupFailed(prNumber int, repo, key, errMsg string) error {
+	if len(errMsg) > 120 {
+		errMsg = errMsg[:117] + "..."
+	}
+	return post(Notification{
+		Title:   "Worktree Setup Failed",
+		Message: fmt.Sprintf("%s PR #%d: %s", repo, prNumber, errMsg),
+		Action:  fmt.Sprintf("%s watch retry %s", zenBin(), key),
+		PR:      prNumber,
+	})
 }
 
 // PRMerged notifies about a PR merge.
 func PRMerged(prNumber int, prTitle string) error {
-	return Send(
-		"PR Merged",
-		fmt.Sprintf("PR #%d: %s", prNumber, prTitle),
-		"Worktree can be cleaned up",
-	)
+	return post(Notification{
+		Title:    "PR Merged",
+		Message:  fmt.Sprintf("PR #%d: %s", prNumber, prTitle),
+		Subtitle: "Worktree can be cleaned up",
+		PR:       prNumber,
+	})
+}
+
+// ChecksFinished notifies that all CI checks on a PR have completed.
+func ChecksFinished(prNumber int, repo string, passed, failed int) error {
+	title := "Checks passed"
+	if failed > 0 {
+		title = "Checks failed"
+	}
+	return post(Notification{
+		Title:    title,
+		Message:  fmt.Sprintf("PR #%d: %d passed, %d failed", prNumber, passed, failed),
+		Subtitle: repo,
+		PR:       prNumber,
+	})
+}
+
+// PRWatchEvent notifies about activity on a PR watched with zen review
+// watch. Clicking opens the PR review (requires terminal-notifier).
+func PRWatchEvent(prNumber int, repo, title, event string) error {
+	return post(Notification{
+		Title:    fmt.Sprintf("PR #%d: %s", prNumber, event),
+		Message:  title,
+		Subtitle: repo,
+		Action:   fmt.Sprintf("%s review %d --repo %s", zenBin(), prNumber, repo),
+		PR:       prNumber,
+	})
+}
+
+// ContextRefreshed notifies that a PR under review received new commits
+// and its worktree's CLAUDE.local.md was regenerated.
+func ContextRefreshed(prNumber int, worktreeName string, changedFiles int) error {
+	return post(Notification{
+		Title:    "PR Updated",
+		Message:  fmt.Sprintf("PR #%d: %d file(s) changed by new commits", prNumber, changedFiles),
+		Subtitle: fmt.Sprintf("Context refreshed in %s", worktreeName),
+		PR:       prNumber,
+	})
 }
 
 // StaleWorktrees notifies about stale worktrees found.
 func StaleWorktrees(count int) error {
-	return Send(
-		"Stale Worktrees Found",
-		fmt.Sprintf("%d worktrees can be cleaned up", count),
-		"Run: zen cleanup",
-	)
+	return post(Notification{
+		Title:    "Stale Worktrees Found",
+		Message:  fmt.Sprintf("%d worktrees can be cleaned up", count),
+		Subtitle: "Run: zen cleanup",
+	})
+}
+
+// CleanupSummary notifies with the week's background cleanup activity.
+func CleanupSummary(deleted, skipped, failed int) error {
+	msg := fmt.Sprintf("%d worktree(s) deleted", deleted)
+	if skipped > 0 {
+		msg += fmt.Sprintf(", %d skipped", skipped)
+	}
+	if failed > 0 {
+		msg += fmt.Sprintf(", %d failed", failed)
+	}
+	return post(Notification{Title: "Weekly Cleanup Summary", Message: msg, Subtitle: "Details: zen cleanup log"})
 }
 
 // SessionWaiting notifies that a Claude session is waiting for user input.
 func SessionWaiting(worktreeName, model, resumeCmd string) error {
-	return Send(
-		"Claude is waiting",
-		fmt.Sprintf("%s needs your input", worktreeName),
-		model,
-	)
+	return post(Notification{
+		Title:    "Claude is waiting",
+		Message:  fmt.Sprintf("%s needs your input", worktreeName),
+		Subtitle: model,
+	})
 }
 
 // Digest sends a periodic summary notification. Only sends if there is something actionable.
@@ -118,5 +200,5 @@ func Digest(waitingSessions, pendingReviews, featureWork int) error {
 	if featureWork > 0 {
 		subtitle = fmt.Sprintf("%d feature branch(es) active", featureWork)
 	}
-	return Send("zen digest", strings.Join(parts, " • "), subtitle)
+	return post(Notification{Title: "zen digest", Message: strings.Join(parts, " • "), Subtitle: subtitle})
 }
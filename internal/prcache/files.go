@@ -0,0 +1,99 @@
+package prcache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// filesTTL bounds how long a file list is kept without being used, so
+// closed PRs drop out of the cache.
+const filesTTL = 14 * 24 * time.Hour
+
+// filesEntry is the changed files of a PR at one head commit.
+type filesEntry struct {
+	Head  string    `json:"head"`
+	Files []string  `json:"files"`
+	Used  time.Time `json:"used"`
+}
+
+func filesFile() string {
+	return filepath.Join(config.StateDir(), "pr_files.json")
+}
+
+// FileCache holds the changed files of PRs keyed by their head commit, so
+// scans of open PRs (zen inbox, zen review deps) only fetch the file lists
+// of PRs that got new commits. It is safe for concurrent use.
+type FileCache struct {
+	mu      sync.Mutex
+	entries map[string]filesEntry // by Key(fullRepo, pr)
+	dirty   bool
+}
+
+// LoadFiles reads the file list cache from disk. It starts empty on any
+// error.
+func LoadFiles() *FileCache {
+	c := &FileCache{entries: make(map[string]filesEntry)}
+	if data, err := os.ReadFile(filesFile()); err == nil {
+		if json.Unmarshal(data, &c.entries) != nil {
+			c.entries = make(map[string]filesEntry)
+		}
+	}
+	return c
+}
+
+// Get returns the cached files of PR pr of fullRepo when they were fetched
+// at head. An empty head never matches.
+func (c *FileCache) Get(fullRepo string, pr int, head string) ([]string, bool) {
+	if head == "" {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := Key(fullRepo, pr)
+	e, ok := c.entries[key]
+	if !ok || e.Head != head {
+		return nil, false
+	}
+	if time.Since(e.Used) > time.Hour {
+		e.Used = time.Now()
+		c.entries[key] = e
+		c.dirty = true
+	}
+	return slices.Clone(e.Files), true
+}
+
+// Put caches the files of PR pr of fullRepo at head, replacing those of an
+// earlier head.
+func (c *FileCache) Put(fullRepo string, pr int, head string, files []string) {
+	if head == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[Key(fullRepo, pr)] = filesEntry{Head: head, Files: slices.Clone(files), Used: time.Now()}
+	c.dirty = true
+}
+
+// Save writes the cache to disk when it changed, dropping entries unused
+// for filesTTL (best-effort).
+func (c *FileCache) Save() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return
+	}
+	for k, e := range c.entries {
+		if time.Since(e.Used) > filesTTL {
+			delete(c.entries, k)
+		}
+	}
+	state.WriteJSON(filesFile(), c.entries)
+	c.dirty = false
+}
@@ -0,0 +1,38 @@
+package prcache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFileCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	c := LoadFiles()
+	c.Put("org/mono", 1, "abc", []string{"a.go", "b.go"})
+	c.Put("org/mono", 2, "", []string{"ignored.go"})
+	c.Save()
+
+	c = LoadFiles()
+	if files, ok := c.Get("org/mono", 1, "abc"); !ok || len(files) != 2 {
+		t.Errorf("Get(head abc) = %v, %v; want the cached files", files, ok)
+	}
+	if _, ok := c.Get("org/mono", 1, "def"); ok {
+		t.Error("Get() after the head moved should miss")
+	}
+	if _, ok := c.Get("org/mono", 2, ""); ok {
+		t.Error("Get() without a head should miss")
+	}
+
+	c.Put("org/mono", 1, "def", []string{"c.go"})
+	c.entries[Key("org/mono", 3)] = filesEntry{Head: "old", Files: []string{"x.go"}, Used: time.Now().Add(-filesTTL - time.Hour)}
+	c.Save()
+
+	c = LoadFiles()
+	if files, ok := c.Get("org/mono", 1, "def"); !ok || len(files) != 1 || files[0] != "c.go" {
+		t.Errorf("Get(head def) = %v, %v; want the files of the new head", files, ok)
+	}
+	if _, ok := c.entries[Key("org/mono", 3)]; ok {
+		t.Error("Save() should drop entries unused for filesTTL")
+	}
+}
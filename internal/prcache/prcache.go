@@ -5,14 +5,26 @@ import (
 	"os"
 	"path/filepath"
 	"fmt"
+	"slices"
 
 	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/state"
 )
 
 // PRMeta holds cached PR metadata for display purposes.
 type PRMeta struct {
-	Title  string `json:"title"`
-	Author string `json:"author"`
+	Title  string        `json:"title"`
+	Author string        `json:"author"`
+	Labels github.Labels `json:"labels,omitempty"`
+
+	// Review is your latest review of the PR, once seen on GitHub.
+	Review *github.ReviewOutcome `json:"review,omitempty"`
+}
+
+// Key returns the cache key of a PR.
+func Key(repo string, pr int) string {
+	return fmt.Sprintf("%s/%d", repo, pr)
 }
 
 func cacheFile() string {
@@ -34,12 +46,7 @@ func Load() map[string]PRMeta {
 
 // Save writes the PR cache to disk (best-effort).
 func Save(cache map[string]PRMeta) {
-	data, err := json.MarshalIndent(cache, "", "  ")
-	if err != nil {
-		return
-	}
-	os.MkdirAll(filepath.Dir(cacheFile()), 0o755)
-	os.WriteFile(cacheFile(), data, 0o644)
+	state.WriteJSON(cacheFile(), cache)
 }
 
 // Get looks up PR metadata by repo short name and PR number.
@@ -50,10 +57,62 @@ func Get(repo string, pr int) (PRMeta, bool) {
 	return meta, ok
 }
 
-// Set stores PR metadata for the given repo and PR number.
+// Set stores PR metadata for the given repo and PR number, keeping any
+// cached labels and review.
 func Set(repo string, pr int, title, author string) {
 	cache := Load()
 	key := fmt.Sprintf("%s/%d", repo, pr)
-	cache[key] = PRMeta{Title: title, Author: author}
+	cache[key] = PRMeta{Title: title, Author: author, Labels: cache[key].Labels, Review: cache[key].Review}
 	Save(cache)
 }
+
+// SetLabels stores the labels of the given repo and PR number.
+func SetLabels(repo string, pr int, labels github.Labels) {
+	cache := Load()
+	key := Key(repo, pr)
+	meta := cache[key]
+	meta.Labels = labels
+	cache[key] = meta
+	Save(cache)
+}
+
+// UpdateLabels refreshes the labels of cached PRs from labels, keyed by
+// Key. PRs not in the cache are left out; the cache is only written when
+// a label changed.
+func UpdateLabels(labels map[string]github.Labels) {
+	cache := Load()
+	changed := false
+	for key, l := range labels {
+		meta, ok := cache[key]
+		if !ok || slices.Equal(meta.Labels, l) {
+			continue
+		}
+		meta.Labels = l
+		cache[key] = meta
+		changed = true
+	}
+	if changed {
+		Save(cache)
+	}
+}
+
+// UpdateReviews records your latest review of PRs, keyed by Key. Unlike
+// labels, reviews are recorded for PRs not in the cache yet. A recorded
+// review is kept when GitHub no longer reports one. The cache is only
+// written when a review changed.
+func UpdateReviews(reviews map[string]github.ReviewOutcome) {
+	cache := Load()
+	changed := false
+	for key, r := range reviews {
+		meta := cache[key]
+		if meta.Review != nil && meta.Review.State == r.State && meta.Review.SubmittedAt.Equal(r.SubmittedAt) {
+			continue
+		}
+		meta.Review = &r
+		cache[key] = meta
+		changed = true
+	}
+	if changed {
+		Save(cache)
+	}
+}
@@ -0,0 +1,54 @@
+package prcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mgreau/zen/internal/github"
+)
+
+func TestLabels(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	hold := github.Labels{{Name: "hold", Color: "d73a4a"}}
+	SetLabels("mono", 1, hold)
+	Set("mono", 1, "Fix auth", "alice")
+	meta, ok := Get("mono", 1)
+	if !ok || meta.Title != "Fix auth" || len(meta.Labels) != 1 {
+		t.Fatalf("Get() = %+v, %v; want title and labels kept", meta, ok)
+	}
+
+	UpdateLabels(map[string]github.Labels{
+		Key("mono", 1): nil,
+		Key("mono", 2): hold,
+	})
+	if meta, _ := Get("mono", 1); len(meta.Labels) != 0 || meta.Title != "Fix auth" {
+		t.Errorf("Get(1) = %+v, want labels cleared", meta)
+	}
+	if _, ok := Get("mono", 2); ok {
+		t.Error("UpdateLabels() should not add uncached PRs")
+	}
+}
+
+func TestReviews(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	Set("mono", 1, "Fix auth", "alice")
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	UpdateReviews(map[string]github.ReviewOutcome{
+		Key("mono", 1): {State: "APPROVED", SubmittedAt: at},
+		Key("mono", 2): {State: "CHANGES_REQUESTED", SubmittedAt: at},
+	})
+	meta, _ := Get("mono", 1)
+	if meta.Review == nil || meta.Review.State != "APPROVED" || meta.Title != "Fix auth" {
+		t.Errorf("Get(1) = %+v, want the approval recorded", meta)
+	}
+	if meta, ok := Get("mono", 2); !ok || meta.Review == nil {
+		t.Errorf("Get(2) = %+v, %v; want the review of an uncached PR recorded", meta, ok)
+	}
+
+	Set("mono", 1, "Fix auth v2", "alice")
+	if meta, _ := Get("mono", 1); meta.Review == nil {
+		t.Error("Set() dropped the recorded review")
+	}
+}
@@ -0,0 +1,63 @@
+package prcache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// repoHintTTL bounds how long a remembered PR→repo mapping is trusted.
+// PR numbers are reused across repos, so old hints are likelier to be wrong.
+const repoHintTTL = 30 * 24 * time.Hour
+
+// repoHint records which repo a PR number last resolved to.
+type repoHint struct {
+	Repo string    `json:"repo"`
+	Seen time.Time `json:"seen"`
+}
+
+func repoHintsFile() string {
+	return filepath.Join(config.StateDir(), "pr_repos.json")
+}
+
+func loadRepoHints() map[string]repoHint {
+	hints := make(map[string]repoHint)
+	data, err := os.ReadFile(repoHintsFile())
+	if err != nil {
+		return hints
+	}
+	if err := json.Unmarshal(data, &hints); err != nil {
+		return make(map[string]repoHint)
+	}
+	return hints
+}
+
+// RememberRepo records that PR number pr belongs to the repo with the given
+// short name, so later lookups can skip asking GitHub (best-effort).
+func RememberRepo(pr int, repo string) {
+	hints := loadRepoHints()
+	for k, h := range hints {
+		if time.Since(h.Seen) > repoHintTTL {
+			delete(hints, k)
+		}
+	}
+	hints[strconv.Itoa(pr)] = repoHint{Repo: repo, Seen: time.Now()}
+
+	state.WriteJSON(repoHintsFile(), hints)
+}
+
+// LookupRepo returns the repo PR number pr was last resolved to, if that
+// happened within repoHintTTL and the repo is one of candidates.
+func LookupRepo(pr int, candidates []string) (string, bool) {
+	h, ok := loadRepoHints()[strconv.Itoa(pr)]
+	if !ok || time.Since(h.Seen) > repoHintTTL || !slices.Contains(candidates, h.Repo) {
+		return "", false
+	}
+	return h.Repo, true
+}
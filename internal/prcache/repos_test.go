@@ -0,0 +1,47 @@
+package prcache
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRememberAndLookupRepo(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, ok := LookupRepo(42, []string{"mono"}); ok {
+		t.Fatal("LookupRepo() hit on an empty cache")
+	}
+
+	RememberRepo(42, "mono")
+	if repo, ok := LookupRepo(42, []string{"app", "mono"}); !ok || repo != "mono" {
+		t.Errorf("LookupRepo(42) = %q, %v; want mono, true", repo, ok)
+	}
+	if _, ok := LookupRepo(42, []string{"app"}); ok {
+		t.Error("LookupRepo() should ignore a repo outside the candidates")
+	}
+	if _, ok := LookupRepo(43, []string{"mono"}); ok {
+		t.Error("LookupRepo(43) should miss")
+	}
+}
+
+func TestLookupRepoExpired(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	RememberRepo(1, "mono")
+	hints := loadRepoHints()
+	hints["1"] = repoHint{Repo: "mono", Seen: time.Now().Add(-repoHintTTL - time.Hour)}
+	data, _ := json.Marshal(hints)
+	os.WriteFile(repoHintsFile(), data, 0o644)
+
+	if _, ok := LookupRepo(1, []string{"mono"}); ok {
+		t.Error("LookupRepo() should ignore an expired hint")
+	}
+
+	// Remembering another PR prunes the expired one
+	RememberRepo(2, "mono")
+	if _, ok := loadRepoHints()["1"]; ok {
+		t.Error("RememberRepo() should prune expired hints")
+	}
+}
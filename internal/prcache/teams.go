@@ -0,0 +1,58 @@
+package prcache
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// teamTTL is how long a team's member list is used before it is fetched
+// again.
+const teamTTL = 24 * time.Hour
+
+// teamEntry is the member list of a team as last fetched.
+type teamEntry struct {
+	Members []string  `json:"members"`
+	Fetched time.Time `json:"fetched"`
+}
+
+func teamsFile() string {
+	return filepath.Join(config.StateDir(), "team_members.json")
+}
+
+func loadTeams() map[string]teamEntry {
+	teams := make(map[string]teamEntry)
+	data, err := os.ReadFile(teamsFile())
+	if err != nil {
+		return teams
+	}
+	if err := json.Unmarshal(data, &teams); err != nil {
+		return make(map[string]teamEntry)
+	}
+	return teams
+}
+
+// TeamMembers returns the members of team ("org/team"), fetching them
+// with fetch when the cached list is older than a day. When fetching
+// fails, the cached list is returned with the error, however old.
+func TeamMembers(ctx context.Context, team string, fetch func(context.Context, string) ([]string, error)) ([]string, error) {
+	teams := loadTeams()
+	cached, ok := teams[team]
+	if ok && time.Since(cached.Fetched) < teamTTL {
+		return slices.Clone(cached.Members), nil
+	}
+
+	members, err := fetch(ctx, team)
+	if err != nil {
+		return slices.Clone(cached.Members), err
+	}
+	teams[team] = teamEntry{Members: members, Fetched: time.Now()}
+	state.WriteJSON(teamsFile(), teams)
+	return slices.Clone(members), nil
+}
@@ -0,0 +1,45 @@
+package prcache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mgreau/zen/internal/state"
+)
+
+func TestTeamMembers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ctx := context.Background()
+
+	calls := 0
+	fetch := func(_ context.Context, team string) ([]string, error) {
+		calls++
+		return []string{"alice", "bob"}, nil
+	}
+	for range 2 {
+		members, err := TeamMembers(ctx, "org/core", fetch)
+		if err != nil || !slices.Equal(members, []string{"alice", "bob"}) {
+			t.Fatalf("TeamMembers() = %v, %v", members, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetched %d times; want 1, then the cache", calls)
+	}
+
+	// A day later the list is fetched again; a failure keeps the old one
+	teams := loadTeams()
+	teams["org/core"] = teamEntry{Members: []string{"alice"}, Fetched: time.Now().Add(-teamTTL - time.Minute)}
+	state.WriteJSON(teamsFile(), teams)
+	failing := func(context.Context, string) ([]string, error) { return nil, errors.New("offline") }
+	members, err := TeamMembers(ctx, "org/core", failing)
+	if err == nil || !slices.Equal(members, []string{"alice"}) {
+		t.Errorf("TeamMembers() offline = %v, %v; want the stale list and an error", members, err)
+	}
+	members, err = TeamMembers(ctx, "org/core", fetch)
+	if err != nil || len(members) != 2 || calls != 2 {
+		t.Errorf("TeamMembers() after expiry = %v, %v (%d fetches)", members, err, calls)
+	}
+}
@@ -4,23 +4,45 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
 
 	"chainguard.dev/driftlessaf/workqueue"
 	"github.com/mgreau/zen/internal/config"
-	ghpkg "github.com/mgreau/zen/internal/github"
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/hooks"
 	wt "github.com/mgreau/zen/internal/worktree"
 )
 
 // CleanupReconciler removes worktrees for merged PRs.
 type CleanupReconciler struct {
 	cfg *config.Config
+
+	// Feature worktrees to clean up with a PR, from cleanup requests
+	featuresMu sync.Mutex
+	features   map[string][]string
 }
 
 // NewCleanupReconciler creates a new CleanupReconciler.
 func NewCleanupReconciler(cfg *config.Config) *CleanupReconciler {
-	return &CleanupReconciler{cfg: cfg}
+	return &CleanupReconciler{cfg: cfg, features: make(map[string][]string)}
+}
+
+// StoreRequest stores the feature worktrees of a cleanup request, to
+// clean up when its key is reconciled.
+func (r *CleanupReconciler) StoreRequest(req CleanupRequest) {
+	r.featuresMu.Lock()
+	defer r.featuresMu.Unlock()
+	r.features[req.Key] = req.Paths
+}
+
+func (r *CleanupReconciler) takeFeatures(key string) []string {
+	r.featuresMu.Lock()
+	defer r.featuresMu.Unlock()
+	paths := r.features[key]
+	delete(r.features, key)
+	return paths
 }
 
 // SetConfig updates the config used by this reconciler.
@@ -45,66 +67,189 @@ func (r *CleanupReconciler) Reconcile(ctx context.Context, key string, _ workque
 		)
 	}
 
-	worktreeName := fmt.Sprintf("%s-pr-%d", repo, prNumber)
-	worktreePath := filepath.Join(basePath, worktreeName)
 	originPath := filepath.Join(basePath, repo)
 
-	// Remove worktree (retryable on failure)
-	if err := removeWorktree(originPath, worktreePath); err != nil {
-		return fmt.Errorf("removeWorktree: %w", err)
+	// The PR's main review worktree and any extra checkouts (--name)
+	checkouts := []wt.Worktree{{
+		Path:   filepath.Join(basePath, wt.PRName(repo, prNumber, "")),
+		Branch: wt.PRBranch(prNumber, ""),
+		Type:   wt.TypePRReview,
+	}}
+	wts, _ := wt.ListForRepo(r.cfg, repo)
+	for _, w := range wts {
+		if w.Type == wt.TypePRReview && w.PRNumber == prNumber && w.Suffix != "" {
+			checkouts = append(checkouts, w)
+		}
+	}
+	// Requested feature worktrees, which may hold work that was never
+	// pushed: those are kept
+	for _, path := range r.takeFeatures(key) {
+		for _, w := range wts {
+			if w.Path != path || w.Type != wt.TypeFeature {
+				continue
+			}
+			if reason := unpushedWork(w.Path); reason != "" {
+				RecordCleanup(CleanupEvent{Repo: repo, PRNumber: prNumber, Path: w.Path, Action: CleanupSkipped, Reason: "PR merged, but " + reason})
+				continue
+			}
+			checkouts = append(checkouts, w)
+		}
+	}
+
+	for _, c := range checkouts {
+		ev := CleanupEvent{Repo: repo, PRNumber: prNumber, Path: c.Path}
+
+		// Remove worktree (retryable on failure)
+		removed, err := removeWorktree(originPath, c.Path)
+		if err != nil {
+			ev.Action, ev.Reason = CleanupFailed, err.Error()
+			RecordCleanup(ev)
+			return fmt.Errorf("removeWorktree: %w", err)
+		}
+		if removed {
+			ev.Action, ev.Reason = CleanupDeleted, "PR merged"
+			RecordCleanup(ev)
+		}
+		// A feature branch without unpushed work is on the remote
+		if !r.cfg.KeepBranches {
+			if err := wt.DeleteBranch(originPath, c.Branch, true); err != nil {
+				logf("Could not delete branch %s for %s: %v", c.Branch, label, err)
+			}
+		}
+		ctxpkg.ForgetContext(c.Path)
 	}
 
 	logf("Cleanup complete for %s", label)
 	return nil
 }
 
-func removeWorktree(originPath, worktreePath string) error {
+// removeWorktree force-removes the worktree, reporting whether it existed.
+func removeWorktree(originPath, worktreePath string) (bool, error) {
 	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
-		return nil // already removed
+		return false, nil // already removed
 	}
 
-	removeCmd := exec.Command("git", "worktree", "remove", worktreePath, "--force")
-	removeCmd.Dir = originPath
-	if out, err := removeCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("git worktree remove: %w: %s", err, string(out))
+	if _, err := ctxpkg.ArchiveNotes(worktreePath); err != nil {
+		logf("Could not archive the notes of %s: %v", worktreePath, err)
 	}
-	return nil
+	if out, err := wt.Remove(originPath, worktreePath, "cleanup: PR merged"); err != nil {
+		return false, fmt.Errorf("git worktree remove: %w: %s", err, out)
+	}
+	return true, nil
 }
 
-// ScanMergedPRs finds worktrees for merged PRs older than the given age
-// and queues them for cleanup.
-func ScanMergedPRs(ctx context.Context, cfg *config.Config, queue workqueue.Interface, cleanupAfterDays int) {
-	wts, err := wt.ListAll(cfg)
+// unpushedWork describes the work in the checkout at path that only
+// exists there: uncommitted changes or commits on no remote branch. Empty
+// means the checkout can be removed without losing anything.
+func unpushedWork(path string) string {
+	changes, err := wt.UncommittedChanges(path)
 	if err != nil {
-		logf("Error listing worktrees for cleanup scan: %v", err)
-		return
+		return fmt.Sprintf("its status is unknown: %v", err)
+	}
+	if len(changes) > 0 {
+		return fmt.Sprintf("it has %d uncommitted change(s)", len(changes))
+	}
+	out, err := wt.Git(context.Background(), 0, path, "rev-list", "HEAD", "--not", "--remotes")
+	if err != nil {
+		return fmt.Sprintf("its unpushed commits are unknown: %v", err)
+	}
+	if out != "" {
+		return fmt.Sprintf("it has %d unpushed commit(s)", len(strings.Fields(out)))
 	}
+	return ""
+}
 
-	ghClient, err := ghpkg.NewClient(ctx)
+// ScanMergedPRs finds worktrees for merged PRs inactive for longer than
+// cleanup_after_days, or cleanup_by_review for your review of the PR, and
+// queues them for cleanup.
+func ScanMergedPRs(ctx context.Context, cfg *config.Config, queue workqueue.Interface) {
+	wts, err := wt.ListAll(cfg)
 	if err != nil {
-		logf("Error creating GitHub client for cleanup scan: %v", err)
+		logf("Error listing worktrees for cleanup scan: %v", err)
 		return
 	}
 
+	// A PR's checkouts are cleaned up together, once none of them has
+	// been active for cleanup_after_days
+	var keys []string
+	byPR := make(map[string][]wt.Worktree)
 	for _, w := range wts {
 		if w.Type != wt.TypePRReview || w.PRNumber == 0 {
 			continue
 		}
-		fullRepo := cfg.RepoFullName(w.Repo)
-		state, err := ghClient.GetPRState(ctx, fullRepo, w.PRNumber)
-		if err != nil {
-			continue // skip on API error, try next cycle
+		key := MakePRKey(w.Repo, w.PRNumber)
+		if _, ok := byPR[key]; !ok {
+			keys = append(keys, key)
 		}
-		if state != "MERGED" {
+		byPR[key] = append(byPR[key], w)
+	}
+
+	// One GraphQL request per repo; PRs that couldn't be looked up are
+	// tried again next cycle
+	statuses, err := FetchPRStatuses(ctx, cfg, wts)
+	if err != nil {
+		logf("Error looking up PRs for cleanup scan: %v", err)
+	}
+
+	for _, key := range keys {
+		group := byPR[key]
+		first := group[0]
+		skip := func(w wt.Worktree, reason string) {
+			RecordCleanup(CleanupEvent{Repo: w.Repo, PRNumber: w.PRNumber, Path: w.Path, Action: CleanupSkipped, Reason: reason})
+		}
+		status, ok := statuses[key]
+		if !ok || status.State != "MERGED" {
 			continue
 		}
-		age, err := wt.AgeDays(w.Path)
-		if err != nil || age < cleanupAfterDays {
+		hooks.FireOnce(hooks.Event{
+			Event: hooks.PRMerged, Repo: first.Repo, FullName: cfg.RepoFullName(first.Repo), PR: first.PRNumber,
+			Title: status.Title, Author: status.Author, Worktree: first.Path,
+		})
+		if !cfg.Watch.AutoCleanupEnabled() {
+			for _, w := range group {
+				skip(w, "PR merged, but watch.auto_cleanup is off")
+			}
+			continue
+		}
+		if cfg.Labels.IsHold(status.Labels.Names()) {
+			for _, w := range group {
+				skip(w, "PR merged, but kept by its hold label")
+			}
+			continue
+		}
+		outcome := config.ReviewOutcomeKey("")
+		if r := MyReview(first.Repo, first.PRNumber, status); r != nil {
+			outcome = config.ReviewOutcomeKey(r.State)
+		}
+		cleanupAfterDays, keep := cfg.Watch.CleanupAfterDaysFor(outcome)
+		if keep {
+			for _, w := range group {
+				skip(w, "PR merged, but kept by cleanup_by_review."+outcome)
+			}
+			continue
+		}
+		setting := "cleanup_after_days"
+		if _, ok := cfg.Watch.CleanupByReview[outcome]; ok {
+			setting = "cleanup_by_review." + outcome
+		}
+		ready := true
+		for _, w := range group {
+			age, err := wt.AgeDays(w.Path)
+			if err != nil {
+				skip(w, fmt.Sprintf("PR merged, but last activity is unknown: %v", err))
+				ready = false
+				continue
+			}
+			if age < cleanupAfterDays {
+				skip(w, fmt.Sprintf("PR merged, waiting for %s (%d)", setting, cleanupAfterDays))
+				ready = false
+			}
+		}
+		if !ready {
 			continue
 		}
-		key := MakePRKey(w.Repo, w.PRNumber)
 		if err := queue.Queue(ctx, key, workqueue.Options{}); err != nil {
-			logf("Error queuing cleanup for %s PR #%d: %v", w.Repo, w.PRNumber, err)
+			logf("Error queuing cleanup for %s PR #%d: %v", first.Repo, first.PRNumber, err)
 		}
 	}
 }
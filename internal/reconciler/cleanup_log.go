@@ -0,0 +1,187 @@
+package reconciler
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/notify"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// Cleanup log actions.
+const (
+	CleanupDeleted = "deleted"
+	CleanupSkipped = "skipped"
+	CleanupFailed  = "failed"
+)
+
+// cleanupSummaryInterval is how often the daemon sends a cleanup summary.
+const cleanupSummaryInterval = 7 * 24 * time.Hour
+
+// cleanupLogRetention bounds how far back the cleanup log is kept.
+const cleanupLogRetention = 90 * 24 * time.Hour
+
+// CleanupEvent is one entry in the background cleanup log.
+type CleanupEvent struct {
+	Time     time.Time `json:"time"`
+	Repo     string    `json:"repo"`
+	PRNumber int       `json:"pr_number"`
+	Path     string    `json:"path"`
+	Action   string    `json:"action"` // deleted, skipped or failed
+	Reason   string    `json:"reason"`
+}
+
+var (
+	cleanupLogMu sync.Mutex
+	// lastSkip remembers the last skip reason per worktree so the hourly
+	// scan logs a skip once rather than on every pass.
+	lastSkip = make(map[string]string)
+)
+
+func cleanupLogPath() string {
+	return filepath.Join(config.StateDir(), "cleanup_log.jsonl")
+}
+
+func cleanupSummaryPath() string {
+	return filepath.Join(config.StateDir(), "cleanup_summary")
+}
+
+// RecordCleanup appends an event to the cleanup log (best-effort). Skips
+// are only recorded when the reason for a worktree changes.
+func RecordCleanup(ev CleanupEvent) {
+	cleanupLogMu.Lock()
+	defer cleanupLogMu.Unlock()
+
+	if ev.Action == CleanupSkipped {
+		if lastSkip[ev.Path] == ev.Reason {
+			return
+		}
+		lastSkip[ev.Path] = ev.Reason
+	} else {
+		delete(lastSkip, ev.Path)
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	os.MkdirAll(config.StateDir(), 0o755)
+	f, err := os.OpenFile(cleanupLogPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		logf("Warning: writing cleanup log: %v", err)
+		return
+	}
+	defer f.Close()
+	f.Write(append(data, '\n'))
+}
+
+// ReadCleanupLog returns cleanup events at or after since, oldest first.
+// A missing log yields no events.
+func ReadCleanupLog(since time.Time) ([]CleanupEvent, error) {
+	f, err := os.Open(cleanupLogPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []CleanupEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var ev CleanupEvent
+		if json.Unmarshal([]byte(line), &ev) != nil {
+			continue
+		}
+		if !ev.Time.Before(since) {
+			events = append(events, ev)
+		}
+	}
+	return events, scanner.Err()
+}
+
+// CountCleanup tallies events by action.
+func CountCleanup(events []CleanupEvent) (deleted, skipped, failed int) {
+	for _, ev := range events {
+		switch ev.Action {
+		case CleanupDeleted:
+			deleted++
+		case CleanupSkipped:
+			skipped++
+		case CleanupFailed:
+			failed++
+		}
+	}
+	return deleted, skipped, failed
+}
+
+// MaybeSendCleanupSummary sends a notification summarizing the cleanup log
+// since the last summary, at most once per cleanupSummaryInterval, and
+// prunes log entries older than cleanupLogRetention. The first call only
+// starts the clock.
+func MaybeSendCleanupSummary() {
+	now := time.Now()
+	var last time.Time
+	if data, err := os.ReadFile(cleanupSummaryPath()); err == nil {
+		last, _ = time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	}
+	if !last.IsZero() && now.Sub(last) < cleanupSummaryInterval {
+		return
+	}
+	if err := state.WriteFile(cleanupSummaryPath(), []byte(now.Format(time.RFC3339)+"\n"), 0o644); err != nil {
+		logf("Warning: writing cleanup summary time: %v", err)
+		return
+	}
+	if last.IsZero() {
+		return
+	}
+
+	events, err := ReadCleanupLog(last)
+	if err != nil {
+		logf("Warning: reading cleanup log: %v", err)
+		return
+	}
+	deleted, skipped, failed := CountCleanup(events)
+	logf("Cleanup summary since %s: %d deleted, %d skipped, %d failed", last.Format(time.RFC3339), deleted, skipped, failed)
+	if deleted+skipped+failed > 0 {
+		if err := notify.CleanupSummary(deleted, skipped, failed); err != nil {
+			logf("Cleanup summary notify error: %v", err)
+		}
+	}
+	pruneCleanupLog(now.Add(-cleanupLogRetention))
+}
+
+// pruneCleanupLog drops log entries older than cutoff.
+func pruneCleanupLog(cutoff time.Time) {
+	cleanupLogMu.Lock()
+	defer cleanupLogMu.Unlock()
+
+	events, err := ReadCleanupLog(cutoff)
+	if err != nil {
+		return
+	}
+	var b strings.Builder
+	for _, ev := range events {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			continue
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	state.WriteFile(cleanupLogPath(), []byte(b.String()), 0o644)
+}
@@ -0,0 +1,55 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordCleanupDedupesSkips(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	skip := CleanupEvent{Repo: "mono", PRNumber: 1, Path: "/tmp/mono-pr-1", Action: CleanupSkipped, Reason: "waiting"}
+	RecordCleanup(skip)
+	RecordCleanup(skip) // same reason: not recorded again
+	skip.Reason = "other"
+	RecordCleanup(skip)
+	RecordCleanup(CleanupEvent{Repo: "mono", PRNumber: 1, Path: "/tmp/mono-pr-1", Action: CleanupDeleted, Reason: "PR merged"})
+
+	events, err := ReadCleanupLog(time.Time{})
+	if err != nil {
+		t.Fatalf("ReadCleanupLog() error: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3: %+v", len(events), events)
+	}
+	deleted, skipped, failed := CountCleanup(events)
+	if deleted != 1 || skipped != 2 || failed != 0 {
+		t.Errorf("CountCleanup() = %d, %d, %d; want 1, 2, 0", deleted, skipped, failed)
+	}
+}
+
+func TestReadCleanupLogSince(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if events, err := ReadCleanupLog(time.Time{}); err != nil || events != nil {
+		t.Fatalf("ReadCleanupLog() on missing log = %v, %v; want nil, nil", events, err)
+	}
+
+	now := time.Now()
+	RecordCleanup(CleanupEvent{Time: now.Add(-10 * 24 * time.Hour), Path: "/a", Action: CleanupDeleted})
+	RecordCleanup(CleanupEvent{Time: now, Path: "/b", Action: CleanupFailed, Reason: "boom"})
+
+	events, err := ReadCleanupLog(now.Add(-7 * 24 * time.Hour))
+	if err != nil {
+		t.Fatalf("ReadCleanupLog() error: %v", err)
+	}
+	if len(events) != 1 || events[0].Path != "/b" {
+		t.Errorf("ReadCleanupLog(7d) = %+v; want only /b", events)
+	}
+
+	pruneCleanupLog(now.Add(-7 * 24 * time.Hour))
+	events, _ = ReadCleanupLog(time.Time{})
+	if len(events) != 1 {
+		t.Errorf("after prune got %d events, want 1", len(events))
+	}
+}
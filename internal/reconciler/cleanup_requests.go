@@ -0,0 +1,73 @@
+package reconciler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// CleanupRequest asks the daemon to clean up the worktrees of a PR right
+// away, e.g. after zen pr merge merged it, instead of waiting for the
+// cleanup scan and cleanup_after_days.
+type CleanupRequest struct {
+	Key      string `json:"key"` // repo:number
+	Repo     string `json:"repo"`
+	PRNumber int    `json:"pr_number"`
+	// Paths are feature worktrees the PR was opened from, removed along
+	// with its review worktrees unless they hold unpushed work.
+	Paths       []string  `json:"paths,omitempty"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// cleanupRequestsDir holds one file per pending request, like
+// setupRequestsDir.
+func cleanupRequestsDir() string {
+	return filepath.Join(config.StateDir(), "cleanup_requests")
+}
+
+// RequestCleanup records a cleanup request for the daemon to queue at its
+// next dispatch. A pending request for the same PR is replaced.
+func RequestCleanup(r CleanupRequest) error {
+	r.Key = MakePRKey(r.Repo, r.PRNumber)
+	if r.RequestedAt.IsZero() {
+		r.RequestedAt = time.Now()
+	}
+	name := fmt.Sprintf("%s-%d.json", r.Repo, r.PRNumber)
+	return state.WriteJSON(filepath.Join(cleanupRequestsDir(), name), r)
+}
+
+// TakeCleanupRequests returns the pending cleanup requests, oldest first,
+// and removes them.
+func TakeCleanupRequests() []CleanupRequest {
+	entries, err := os.ReadDir(cleanupRequestsDir())
+	if err != nil {
+		return nil
+	}
+	var out []CleanupRequest
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(cleanupRequestsDir(), e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		os.Remove(path)
+		var r CleanupRequest
+		if json.Unmarshal(data, &r) != nil || r.Repo == "" || r.PRNumber == 0 {
+			logf("Dropping malformed cleanup request %s", e.Name())
+			continue
+		}
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RequestedAt.Before(out[j].RequestedAt) })
+	return out
+}
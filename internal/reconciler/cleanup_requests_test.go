@@ -0,0 +1,94 @@
+package reconciler
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"chainguard.dev/driftlessaf/workqueue"
+	"github.com/mgreau/zen/internal/config"
+)
+
+func TestCleanupRequests(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if got := TakeCleanupRequests(); len(got) != 0 {
+		t.Fatalf("TakeCleanupRequests() on empty state = %+v", got)
+	}
+
+	now := time.Now()
+	RequestCleanup(CleanupRequest{Repo: "mono", PRNumber: 2, RequestedAt: now})
+	RequestCleanup(CleanupRequest{Repo: "infra", PRNumber: 9, RequestedAt: now.Add(-time.Minute)})
+	RequestCleanup(CleanupRequest{Repo: "mono", PRNumber: 2, Paths: []string{"/src/mono-auth"}, RequestedAt: now})
+
+	got := TakeCleanupRequests()
+	if len(got) != 2 || got[0].Key != "infra:9" || got[1].Key != "mono:2" || len(got[1].Paths) != 1 {
+		t.Fatalf("TakeCleanupRequests() = %+v; want infra:9 then the latest mono:2", got)
+	}
+	if got := TakeCleanupRequests(); len(got) != 0 {
+		t.Errorf("TakeCleanupRequests() should remove the requests, got %+v", got)
+	}
+}
+
+func TestCleanupReconcile_RequestedFeatures(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	base := t.TempDir()
+	remote := filepath.Join(base, "remote.git")
+	origin := filepath.Join(base, "mono")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run(base, "init", "-q", "--bare", "-b", "main", remote)
+	run(base, "clone", "-q", remote, origin)
+	os.WriteFile(filepath.Join(origin, "a.txt"), []byte("a\n"), 0o644)
+	run(origin, "add", "a.txt")
+	run(origin, "commit", "-q", "-m", "init")
+	run(origin, "push", "-q", "origin", "HEAD:main")
+
+	// shipped was pushed for the PR; wip has a commit that never was
+	shipped := filepath.Join(base, "mono-shipped")
+	wip := filepath.Join(base, "mono-wip")
+	run(origin, "worktree", "add", "-q", "-b", "shipped", shipped)
+	os.WriteFile(filepath.Join(shipped, "b.txt"), []byte("b\n"), 0o644)
+	run(shipped, "add", "b.txt")
+	run(shipped, "commit", "-q", "-m", "ship")
+	run(shipped, "push", "-q", "origin", "shipped")
+	run(origin, "worktree", "add", "-q", "-b", "wip", wip)
+	os.WriteFile(filepath.Join(wip, "c.txt"), []byte("c\n"), 0o644)
+	run(wip, "add", "c.txt")
+	run(wip, "commit", "-q", "-m", "not pushed")
+
+	cfg := &config.Config{Repos: map[string]config.RepoConfig{
+		"mono": {FullName: "acme/mono", BasePath: base},
+	}}
+	rec := NewCleanupReconciler(cfg)
+	rec.StoreRequest(CleanupRequest{Key: "mono:5", Repo: "mono", PRNumber: 5, Paths: []string{shipped, wip}})
+	if err := rec.Reconcile(context.Background(), "mono:5", workqueue.Options{}); err != nil {
+		t.Fatalf("Reconcile() error: %v", err)
+	}
+
+	if _, err := os.Stat(shipped); !os.IsNotExist(err) {
+		t.Errorf("pushed feature worktree was not removed: %v", err)
+	}
+	if err := exec.Command("git", "-C", origin, "rev-parse", "--verify", "--quiet", "refs/heads/shipped").Run(); err == nil {
+		t.Error("pushed feature branch was not deleted")
+	}
+	if _, err := os.Stat(filepath.Join(wip, "c.txt")); err != nil {
+		t.Errorf("feature worktree with unpushed work was removed: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package reconciler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/mgreau/zen/internal/config"
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/notify"
+	wt "github.com/mgreau/zen/internal/worktree"
+	"golang.org/x/sync/errgroup"
+)
+
+var contextsRefreshing atomic.Bool
+
+// RefreshContextsAsync runs RefreshContexts in the background, unless the
+// previous run is still going, so the poll loop doesn't wait on GitHub.
+func RefreshContextsAsync(ctx context.Context, cfg *config.Config) {
+	if !contextsRefreshing.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer contextsRefreshing.Store(false)
+		RefreshContexts(ctx, cfg)
+	}()
+}
+
+// RefreshContexts regenerates CLAUDE.local.md in every PR review worktree
+// whose PR received new commits, so long-running review sessions learn that
+// the code changed underneath them. Merged and closed PRs, which only wait
+// for cleanup, are skipped: their states are looked up first, with one
+// request per repo.
+func RefreshContexts(ctx context.Context, cfg *config.Config) {
+	wts, err := wt.ListAll(cfg)
+	if err != nil {
+		logf("Error listing worktrees for context refresh: %v", err)
+		return
+	}
+
+	var injected []wt.Worktree
+	for _, w := range wts {
+		if w.Type != wt.TypePRReview || w.PRNumber == 0 {
+			continue
+		}
+		// Only refresh contexts zen injected; a missing file means setup
+		// has not finished or the user removed it on purpose.
+		if _, err := os.Stat(filepath.Join(w.Path, "CLAUDE.local.md")); err != nil {
+			continue
+		}
+		injected = append(injected, w)
+	}
+	if len(injected) == 0 {
+		return
+	}
+
+	statuses, err := FetchPRStatuses(ctx, cfg, injected)
+	if err != nil {
+		logf("Context refresh: looking up PR states: %v", err)
+	}
+	var open []wt.Worktree
+	for _, w := range injected {
+		if statuses[MakePRKey(w.Repo, w.PRNumber)].State == "OPEN" {
+			open = append(open, w)
+		}
+	}
+	if len(open) == 0 {
+		return
+	}
+
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		logf("Context refresh: creating GitHub client: %v", err)
+		return
+	}
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(5)
+	for _, w := range open {
+		g.Go(func() error {
+			update, err := ctxpkg.RefreshPRContext(gctx, client, w.Path, cfg.RepoFullName(w.Repo), w.PRNumber, false)
+			if err != nil {
+				logf("Context refresh failed for %s: %v", w.Name, err)
+				return nil
+			}
+			if update == nil {
+				return nil
+			}
+			logf("Context refreshed for %s: %d file(s) changed by new commits", w.Name, len(update.Files))
+			if err := notify.ContextRefreshed(w.PRNumber, w.Name, len(update.Files)); err != nil {
+				logf("Warning: notification failed for %s: %v", w.Name, err)
+			}
+			return nil
+		})
+	}
+	g.Wait()
+}
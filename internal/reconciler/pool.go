@@ -0,0 +1,140 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/ui"
+	wt "github.com/mgreau/zen/internal/worktree"
+)
+
+// PoolResult describes what FillPool changed in a repo's worktree pool.
+type PoolResult struct {
+	Repo      string `json:"repo"`
+	Size      int    `json:"size"`
+	Ready     int    `json:"ready"`
+	Added     int    `json:"added"`
+	Refreshed int    `json:"refreshed"`
+	Removed   int    `json:"removed"`
+}
+
+// FillPool brings repo's pool of blank worktrees to its configured
+// pool_size: extra worktrees are removed, those last checked out more than
+// pool_refresh ago are moved to the latest default branch of the repo's
+// upstream remote, and missing ones are created. The clone's lock is held per git operation rather than for
+// the whole run, so PR setups can claim worktrees in between.
+func FillPool(ctx context.Context, cfg *config.Config, repo string, steps *ui.Steps) (*PoolResult, error) {
+	basePath := cfg.RepoBasePath(repo)
+	if basePath == "" {
+		return nil, fmt.Errorf("unknown repo %q", repo)
+	}
+	originPath := filepath.Join(basePath, repo)
+	mu := wt.RepoLock(originPath)
+	res := &PoolResult{Repo: repo, Size: cfg.RepoPoolSize(repo)}
+
+	pooled, err := wt.Pooled(basePath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(pooled) > res.Size {
+		last := pooled[len(pooled)-1]
+		mu.Lock()
+		err := wt.RemovePooled(originPath, last)
+		mu.Unlock()
+		if err != nil {
+			return res, err
+		}
+		pooled = pooled[:len(pooled)-1]
+		res.Removed++
+	}
+
+	var stale []string
+	for _, path := range pooled {
+		if at, err := wt.PooledAt(path); err != nil || time.Since(at) > cfg.RepoPoolRefresh(repo) {
+			stale = append(stale, path)
+		}
+	}
+	if len(stale) == 0 && len(pooled) == res.Size {
+		res.Ready = len(pooled)
+		return res, nil
+	}
+
+	base := wt.RemoteDefaultBranch(originPath, cfg.RepoUpstream(repo))
+	remote, branch, _ := strings.Cut(base, "/")
+	steps.Step(fmt.Sprintf("git fetch %s %s", remote, branch))
+	mu.Lock()
+	err = wt.FetchPoolBase(ctx, cfg.RepoGitTimeout(repo), originPath, base)
+	mu.Unlock()
+	steps.Done(err)
+	if err != nil {
+		return res, err
+	}
+
+	for _, path := range stale {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		steps.Step(fmt.Sprintf("refresh %s", filepath.Base(path)))
+		mu.Lock()
+		err := wt.RefreshPooled(path, base)
+		mu.Unlock()
+		steps.Done(err)
+		if err != nil {
+			return res, err
+		}
+		res.Refreshed++
+	}
+
+	for n := len(pooled); n < res.Size; n++ {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		steps.Step(fmt.Sprintf("add pooled worktree %d/%d", n+1, res.Size))
+		mu.Lock()
+		_, err := wt.AddPooled(originPath, basePath, repo, base)
+		mu.Unlock()
+		steps.Done(err)
+		if err != nil {
+			return res, err
+		}
+		res.Added++
+	}
+
+	pooled, _ = wt.Pooled(basePath, repo)
+	res.Ready = len(pooled)
+	return res, nil
+}
+
+var poolsFilling atomic.Bool
+
+// FillPoolsAsync runs FillPool for every repo with a pool_size in the
+// background, unless the previous run is still going.
+func FillPoolsAsync(ctx context.Context, cfg *config.Config) {
+	if !poolsFilling.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer poolsFilling.Store(false)
+		for _, repo := range cfg.RepoNames() {
+			if cfg.RepoPoolSize(repo) == 0 {
+				continue
+			}
+			steps := ui.NewStepLogger(func(line string) { logf("%s pool: %s", repo, line) })
+			res, err := FillPool(ctx, cfg, repo, steps)
+			if err != nil {
+				logf("Worktree pool for %s: %v", repo, err)
+				continue
+			}
+			if res.Added+res.Refreshed+res.Removed > 0 {
+				logf("Worktree pool for %s: %d/%d ready (added %d, refreshed %d, removed %d)",
+					repo, res.Ready, res.Size, res.Added, res.Refreshed, res.Removed)
+			}
+		}
+	}()
+}
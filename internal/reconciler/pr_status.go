@@ -0,0 +1,64 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mgreau/zen/internal/config"
+	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/prcache"
+	wt "github.com/mgreau/zen/internal/worktree"
+)
+
+// FetchPRStatuses looks up the PRs of the PR review worktrees in wts on
+// GitHub, with one GraphQL request per repo (and 100 PRs), keyed by
+// MakePRKey. Cached labels of the PRs are refreshed and your reviews of
+// them recorded along the way. PRs of repos whose lookup failed are
+// missing from the result; the errors are returned joined.
+func FetchPRStatuses(ctx context.Context, cfg *config.Config, wts []wt.Worktree) (map[string]ghpkg.PRStatus, error) {
+	var repos []string
+	byRepo := make(map[string][]int)
+	for _, w := range wts {
+		if w.Type != wt.TypePRReview || w.PRNumber == 0 {
+			continue
+		}
+		if _, ok := byRepo[w.Repo]; !ok {
+			repos = append(repos, w.Repo)
+		}
+		byRepo[w.Repo] = append(byRepo[w.Repo], w.PRNumber)
+	}
+
+	statuses := make(map[string]ghpkg.PRStatus)
+	labels := make(map[string]ghpkg.Labels)
+	reviews := make(map[string]ghpkg.ReviewOutcome)
+	var errs []error
+	for _, repo := range repos {
+		found, err := ghpkg.PRStatuses(ctx, cfg.RepoFullName(repo), byRepo[repo])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", repo, err))
+			continue
+		}
+		for n, s := range found {
+			statuses[MakePRKey(repo, n)] = s
+			labels[prcache.Key(repo, n)] = s.Labels
+			if s.MyReview != nil {
+				reviews[prcache.Key(repo, n)] = *s.MyReview
+			}
+		}
+	}
+	prcache.UpdateLabels(labels)
+	prcache.UpdateReviews(reviews)
+	return statuses, errors.Join(errs...)
+}
+
+// MyReview returns your latest review of a PR: the one in status when
+// GitHub reported it, else the one recorded in the PR cache. Nil when you
+// haven't reviewed the PR.
+func MyReview(repo string, pr int, status ghpkg.PRStatus) *ghpkg.ReviewOutcome {
+	if status.MyReview != nil {
+		return status.MyReview
+	}
+	meta, _ := prcache.Get(repo, pr)
+	return meta.Review
+}
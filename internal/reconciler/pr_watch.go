@@ -0,0 +1,191 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/hooks"
+	"github.com/mgreau/zen/internal/notify"
+	"github.com/mgreau/zen/internal/state"
+	"golang.org/x/sync/errgroup"
+)
+
+// WatchedPR is a PR subscribed to with zen review watch. The daemon polls it
+// and notifies on new commits, new comments, finished CI and merge.
+type WatchedPR struct {
+	Key      string    `json:"key"` // repo:number
+	Repo     string    `json:"repo"`
+	PRNumber int       `json:"pr_number"`
+	Since    time.Time `json:"since"`
+
+	// Last is the activity seen at the previous poll, the baseline for
+	// the next one.
+	Last *github.PRActivity `json:"last,omitempty"`
+}
+
+var watchedPRsMu sync.Mutex
+
+func watchedPRsPath() string {
+	return filepath.Join(config.StateDir(), "watched_prs.json")
+}
+
+func loadWatchedPRs() map[string]WatchedPR {
+	watched := make(map[string]WatchedPR)
+	data, err := os.ReadFile(watchedPRsPath())
+	if err != nil {
+		return watched
+	}
+	json.Unmarshal(data, &watched)
+	return watched
+}
+
+func saveWatchedPRs(watched map[string]WatchedPR) error {
+	return state.WriteJSON(watchedPRsPath(), watched)
+}
+
+// WatchedPRs returns the watched PRs, oldest watch first.
+func WatchedPRs() []WatchedPR {
+	watchedPRsMu.Lock()
+	defer watchedPRsMu.Unlock()
+	watched := loadWatchedPRs()
+	out := make([]WatchedPR, 0, len(watched))
+	for _, w := range watched {
+		out = append(out, w)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Since.Before(out[j].Since) })
+	return out
+}
+
+// WatchPR starts watching a PR, replacing any existing watch of it.
+func WatchPR(w WatchedPR) error {
+	watchedPRsMu.Lock()
+	defer watchedPRsMu.Unlock()
+	watched := loadWatchedPRs()
+	w.Key = MakePRKey(w.Repo, w.PRNumber)
+	if w.Since.IsZero() {
+		w.Since = time.Now()
+	}
+	watched[w.Key] = w
+	return saveWatchedPRs(watched)
+}
+
+// UnwatchPR stops watching a PR. It reports whether the PR was watched.
+func UnwatchPR(repo string, prNumber int) (bool, error) {
+	watchedPRsMu.Lock()
+	defer watchedPRsMu.Unlock()
+	watched := loadWatchedPRs()
+	key := MakePRKey(repo, prNumber)
+	if _, ok := watched[key]; !ok {
+		return false, nil
+	}
+	delete(watched, key)
+	return true, saveWatchedPRs(watched)
+}
+
+// CheckWatchedPRs polls every watched PR, notifies about what changed since
+// the previous poll and records the new baseline. Merged and closed PRs are
+// unwatched after their notification.
+func CheckWatchedPRs(ctx context.Context, cfg *config.Config) {
+	watched := WatchedPRs()
+	if len(watched) == 0 {
+		return
+	}
+	client, err := github.NewClient(ctx)
+	if err != nil {
+		logf("PR watch: creating GitHub client: %v", err)
+		return
+	}
+
+	current := make([]*github.PRActivity, len(watched))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(5)
+	for i, w := range watched {
+		g.Go(func() error {
+			a, err := client.GetPRActivity(gctx, cfg.RepoFullName(w.Repo), w.PRNumber)
+			if err != nil {
+				logf("PR watch: %s PR #%d: %v", w.Repo, w.PRNumber, err)
+				return nil
+			}
+			current[i] = a
+			return nil
+		})
+	}
+	g.Wait()
+
+	watchedPRsMu.Lock()
+	defer watchedPRsMu.Unlock()
+	// Reload so watches added or removed during the poll are kept
+	stored := loadWatchedPRs()
+	for i, w := range watched {
+		cur := current[i]
+		if cur == nil {
+			continue
+		}
+		if _, ok := stored[w.Key]; !ok {
+			continue
+		}
+		for _, ev := range prWatchEvents(w.Last, cur) {
+			logf("PR watch: %s PR #%d: %s", w.Repo, w.PRNumber, ev)
+			if err := notify.PRWatchEvent(w.PRNumber, w.Repo, cur.Title, ev); err != nil {
+				logf("Warning: notification failed for %s: %v", w.Key, err)
+			}
+		}
+		if cur.State == "MERGED" {
+			hooks.FireOnce(hooks.Event{
+				Event: hooks.PRMerged, Repo: w.Repo, FullName: cfg.RepoFullName(w.Repo), PR: w.PRNumber, Title: cur.Title,
+			})
+		}
+		if cur.State != "OPEN" {
+			logf("PR watch: %s PR #%d is %s, no longer watching", w.Repo, w.PRNumber, cur.State)
+			delete(stored, w.Key)
+			continue
+		}
+		w.Last = cur
+		stored[w.Key] = w
+	}
+	if err := saveWatchedPRs(stored); err != nil {
+		logf("PR watch: saving state: %v", err)
+	}
+}
+
+// prWatchEvents describes what changed between two activity snapshots of a
+// PR. Without a previous snapshot there is nothing to compare, so nothing
+// is reported.
+func prWatchEvents(prev, cur *github.PRActivity) []string {
+	if prev == nil {
+		return nil
+	}
+	var events []string
+	if cur.HeadSHA != prev.HeadSHA {
+		events = append(events, "New commits pushed")
+	}
+	if n := cur.Comments - prev.Comments; n > 0 {
+		events = append(events, fmt.Sprintf("%d new comment(s)", n))
+	}
+	// Report CI once per head commit, when its last check completes
+	wasDone := prev.ChecksDone() && prev.HeadSHA == cur.HeadSHA
+	if cur.ChecksDone() && !wasDone {
+		if cur.ChecksFailed > 0 {
+			events = append(events, fmt.Sprintf("Checks failed (%d of %d)", cur.ChecksFailed, cur.ChecksFailed+cur.ChecksPassed))
+		} else {
+			events = append(events, fmt.Sprintf("Checks passed (%d)", cur.ChecksPassed))
+		}
+	}
+	if cur.State != prev.State {
+		switch cur.State {
+		case "MERGED":
+			events = append(events, "Merged")
+		case "CLOSED":
+			events = append(events, "Closed without merging")
+		}
+	}
+	return events
+}
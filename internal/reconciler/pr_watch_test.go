@@ -0,0 +1,88 @@
+package reconciler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mgreau/zen/internal/github"
+)
+
+func TestWatchedPRs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if got := WatchedPRs(); len(got) != 0 {
+		t.Fatalf("WatchedPRs() on empty state = %+v", got)
+	}
+
+	WatchPR(WatchedPR{Repo: "mono", PRNumber: 1})
+	WatchPR(WatchedPR{Repo: "mono", PRNumber: 2, Last: &github.PRActivity{HeadSHA: "abc"}})
+
+	got := WatchedPRs()
+	if len(got) != 2 || got[0].Key != "mono:1" || got[1].Key != "mono:2" {
+		t.Fatalf("WatchedPRs() = %+v; want mono:1 then mono:2", got)
+	}
+	if got[1].Last == nil || got[1].Last.HeadSHA != "abc" {
+		t.Errorf("mono:2 baseline = %+v; want head abc", got[1].Last)
+	}
+
+	if ok, err := UnwatchPR("mono", 1); !ok || err != nil {
+		t.Errorf("UnwatchPR(mono, 1) = %v, %v; want true", ok, err)
+	}
+	if ok, _ := UnwatchPR("mono", 404); ok {
+		t.Error("UnwatchPR(mono, 404) should report an unwatched PR")
+	}
+	if got := WatchedPRs(); len(got) != 1 || got[0].Key != "mono:2" {
+		t.Errorf("WatchedPRs() after unwatch = %+v; want only mono:2", got)
+	}
+}
+
+func TestPRWatchEvents(t *testing.T) {
+	base := github.PRActivity{State: "OPEN", HeadSHA: "a1", Comments: 2, ChecksPending: 3}
+
+	tests := []struct {
+		name string
+		prev *github.PRActivity
+		cur  github.PRActivity
+		want []string
+	}{
+		{"no baseline", nil, base, nil},
+		{"unchanged", &base, base, nil},
+		{
+			"push and comments",
+			&base,
+			github.PRActivity{State: "OPEN", HeadSHA: "b2", Comments: 5, ChecksPending: 1},
+			[]string{"New commits pushed", "3 new comment(s)"},
+		},
+		{
+			"checks passed",
+			&base,
+			github.PRActivity{State: "OPEN", HeadSHA: "a1", Comments: 2, ChecksPassed: 3},
+			[]string{"Checks passed (3)"},
+		},
+		{
+			"checks already reported",
+			&github.PRActivity{State: "OPEN", HeadSHA: "a1", ChecksPassed: 2, ChecksFailed: 1},
+			github.PRActivity{State: "OPEN", HeadSHA: "a1", ChecksPassed: 2, ChecksFailed: 1},
+			nil,
+		},
+		{
+			"checks done on a new push",
+			&github.PRActivity{State: "OPEN", HeadSHA: "a1", ChecksPassed: 3},
+			github.PRActivity{State: "OPEN", HeadSHA: "b2", ChecksPassed: 2, ChecksFailed: 1},
+			[]string{"New commits pushed", "Checks failed (1 of 3)"},
+		},
+		{
+			"merged",
+			&base,
+			github.PRActivity{State: "MERGED", HeadSHA: "a1", Comments: 2},
+			[]string{"Merged"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prWatchEvents(tt.prev, &tt.cur); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("prWatchEvents() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"chainguard.dev/driftlessaf/workqueue"
+	"chainguard.dev/driftlessaf/workqueue/dispatcher"
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// Queue entry states.
+const (
+	QueueQueued     = "queued"
+	QueueInProgress = "in_progress"
+	QueueRetrying   = "retrying" // failed, waiting for its next attempt
+	QueueFailed     = "failed"   // gave up: out of retries or non-retriable
+)
+
+// failedEntryTTL is how long keys that were given up on stay visible.
+const failedEntryTTL = 24 * time.Hour
+
+// QueueEntry describes one key in a daemon workqueue.
+type QueueEntry struct {
+	Queue       string    `json:"queue"` // "setup" or "cleanup"
+	Key         string    `json:"key"`
+	State       string    `json:"state"`
+	Attempts    int       `json:"attempts"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastAttempt time.Time `json:"last_attempt,omitzero"`
+	NextRetry   time.Time `json:"next_retry,omitzero"`
+}
+
+// QueueState is the snapshot the daemon writes for zen watch queue.
+type QueueState struct {
+	UpdatedAt time.Time    `json:"updated_at"`
+	Entries   []QueueEntry `json:"entries"`
+}
+
+type trackedEntry struct {
+	QueueEntry
+	q workqueue.Interface
+}
+
+// QueueTracker records the keys queued in the daemon's workqueues and the
+// outcome of each attempt, so queued, retrying and failed keys can be
+// reported. The in-memory queues only expose keys that are ready to run.
+type QueueTracker struct {
+	mu       sync.Mutex
+	entries  map[string]*trackedEntry // by queue + "/" + key
+	onFailed map[string]func(QueueEntry)
+}
+
+// NewQueueTracker returns an empty QueueTracker.
+func NewQueueTracker() *QueueTracker {
+	return &QueueTracker{
+		entries:  make(map[string]*trackedEntry),
+		onFailed: make(map[string]func(QueueEntry)),
+	}
+}
+
+// OnFailed registers fn to be called when a key of the named queue is
+// given up on, either out of retries or with a non-retriable error.
+func (t *QueueTracker) OnFailed(name string, fn func(QueueEntry)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onFailed[name] = fn
+}
+
+// Track returns q wrapped so that every key queued through it is recorded
+// under the given queue name.
+func (t *QueueTracker) Track(name string, q workqueue.Interface) workqueue.Interface {
+	return &trackedQueue{Interface: q, t: t, name: name}
+}
+
+type trackedQueue struct {
+	workqueue.Interface
+	t    *QueueTracker
+	name string
+}
+
+func (q *trackedQueue) Queue(ctx context.Context, key string, opts workqueue.Options) error {
+	if err := q.Interface.Queue(ctx, key, opts); err != nil {
+		return err
+	}
+	q.t.mu.Lock()
+	defer q.t.mu.Unlock()
+	id := q.name + "/" + key
+	if e, ok := q.t.entries[id]; ok && e.State != QueueFailed {
+		return nil // already tracked
+	}
+	q.t.entries[id] = &trackedEntry{
+		QueueEntry: QueueEntry{Queue: q.name, Key: key, State: QueueQueued},
+		q:          q.Interface,
+	}
+	return nil
+}
+
+// Wrap returns fn instrumented to record each attempt for keys of the named
+// queue. maxRetries must match the value given to the dispatcher so keys
+// it gives up on are reported as failed.
+func (t *QueueTracker) Wrap(name string, fn dispatcher.Callback, maxRetries int) dispatcher.Callback {
+	return func(ctx context.Context, key string, opts workqueue.Options) error {
+		id := name + "/" + key
+		t.mu.Lock()
+		e, ok := t.entries[id]
+		if !ok {
+			e = &trackedEntry{QueueEntry: QueueEntry{Queue: name, Key: key}}
+			t.entries[id] = e
+		}
+		e.State = QueueInProgress
+		e.Attempts++
+		e.LastAttempt = time.Now()
+		e.NextRetry = time.Time{}
+		t.mu.Unlock()
+
+		err := fn(ctx, key, opts)
+
+		t.mu.Lock()
+		var failed func(QueueEntry)
+		switch {
+		case err == nil:
+			delete(t.entries, id)
+		case workqueue.GetNonRetriableDetails(err) != nil || (maxRetries > 0 && e.Attempts >= maxRetries):
+			e.State = QueueFailed
+			e.LastError = err.Error()
+			failed = t.onFailed[name]
+		default:
+			e.State = QueueRetrying
+			e.LastError = err.Error()
+		}
+		entry := e.QueueEntry
+		t.mu.Unlock()
+
+		if failed != nil {
+			failed(entry)
+		}
+		return err
+	}
+}
+
+// Snapshot refreshes the tracked entries from their queues and returns them
+// ordered by queue and key. Keys that left their queue without failing are
+// dropped, as are failures older than failedEntryTTL.
+func (t *QueueTracker) Snapshot(ctx context.Context) []QueueEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make([]QueueEntry, 0, len(t.entries))
+	for id, e := range t.entries {
+		if e.State == QueueFailed {
+			if time.Since(e.LastAttempt) > failedEntryTTL {
+				delete(t.entries, id)
+				continue
+			}
+			out = append(out, e.QueueEntry)
+			continue
+		}
+		if e.q != nil && e.State != QueueInProgress {
+			ks, err := e.q.Get(ctx, e.Key)
+			if err != nil {
+				delete(t.entries, id) // no longer queued
+				continue
+			}
+			if ks.Status == workqueue.KeyState_IN_PROGRESS {
+				e.State = QueueInProgress
+			}
+			if next := time.Unix(ks.NotBeforeTime, 0); e.State == QueueRetrying && next.After(time.Now()) {
+				e.NextRetry = next
+			}
+		}
+		out = append(out, e.QueueEntry)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Queue != out[j].Queue {
+			return out[i].Queue > out[j].Queue // setup before cleanup
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func queueStatePath() string {
+	return filepath.Join(config.StateDir(), "watch_queue.json")
+}
+
+// Save writes a snapshot of the tracked entries for zen watch queue.
+func (t *QueueTracker) Save(ctx context.Context) {
+	if err := state.WriteJSON(queueStatePath(), QueueState{UpdatedAt: time.Now(), Entries: t.Snapshot(ctx)}); err != nil {
+		logf("Warning: writing queue state: %v", err)
+	}
+}
+
+// LoadQueueState reads the snapshot last written by the daemon.
+func LoadQueueState() (*QueueState, error) {
+	data, err := os.ReadFile(queueStatePath())
+	if err != nil {
+		return nil, err
+	}
+	var s QueueState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
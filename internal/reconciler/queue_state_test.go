@@ -0,0 +1,75 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"chainguard.dev/driftlessaf/workqueue"
+	"chainguard.dev/driftlessaf/workqueue/dispatcher"
+	"chainguard.dev/driftlessaf/workqueue/inmem"
+)
+
+func TestQueueTracker(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ctx := context.Background()
+
+	tracker := NewQueueTracker()
+	q := tracker.Track("setup", inmem.NewWorkQueue(10))
+	for _, key := range []string{"mono:1", "mono:2", "mono:3"} {
+		if err := q.Queue(ctx, key, workqueue.Options{Priority: 1}); err != nil {
+			t.Fatalf("Queue(%q) error: %v", key, err)
+		}
+	}
+
+	snap := tracker.Snapshot(ctx)
+	if len(snap) != 3 || snap[0].State != QueueQueued {
+		t.Fatalf("Snapshot() before dispatch = %+v; want 3 queued keys", snap)
+	}
+
+	var (
+		failedMu sync.Mutex
+		failed   []string
+	)
+	tracker.OnFailed("setup", func(e QueueEntry) {
+		failedMu.Lock()
+		defer failedMu.Unlock()
+		failed = append(failed, e.Key)
+	})
+
+	fn := tracker.Wrap("setup", func(_ context.Context, key string, _ workqueue.Options) error {
+		switch key {
+		case "mono:2":
+			return errors.New("git fetch: boom")
+		case "mono:3":
+			return workqueue.NonRetriableError(errors.New("bad key"), "bad")
+		}
+		return nil
+	}, 5)
+	if err := dispatcher.HandleAsync(ctx, q, 3, 3, fn, 5)(); err != nil {
+		t.Fatalf("HandleAsync() error: %v", err)
+	}
+
+	tracker.Save(ctx)
+	state, err := LoadQueueState()
+	if err != nil {
+		t.Fatalf("LoadQueueState() error: %v", err)
+	}
+	got := make(map[string]QueueEntry)
+	for _, e := range state.Entries {
+		got[e.Key] = e
+	}
+	if _, ok := got["mono:1"]; ok {
+		t.Error("succeeded key mono:1 should be dropped")
+	}
+	if e := got["mono:2"]; e.State != QueueRetrying || e.Attempts != 1 || e.LastError != "git fetch: boom" || e.NextRetry.IsZero() {
+		t.Errorf("mono:2 = %+v; want retrying after 1 attempt with a next retry", e)
+	}
+	if e := got["mono:3"]; e.State != QueueFailed {
+		t.Errorf("mono:3 = %+v; want failed", e)
+	}
+	if len(failed) != 1 || failed[0] != "mono:3" {
+		t.Errorf("OnFailed called for %v; want [mono:3]", failed)
+	}
+}
@@ -0,0 +1,81 @@
+package reconciler
+
+import (
+	"sort"
+	"sync"
+
+	"chainguard.dev/driftlessaf/workqueue"
+	"chainguard.dev/driftlessaf/workqueue/inmem"
+)
+
+// QueueSet holds the setup workqueues for the daemon. In per-repo mode each
+// repository gets its own queue so that a slow repo (e.g. a huge fetch)
+// cannot starve the others of dispatcher slots. In shared mode every repo
+// maps to the same queue.
+type QueueSet struct {
+	mu      sync.Mutex
+	size    int
+	perRepo bool
+	queues  map[string]workqueue.Interface
+	tracker *QueueTracker
+	name    string
+}
+
+// NewQueueSet creates a QueueSet whose queues hold up to size items.
+func NewQueueSet(size int, perRepo bool) *QueueSet {
+	return &QueueSet{
+		size:    size,
+		perRepo: perRepo,
+		queues:  make(map[string]workqueue.Interface),
+	}
+}
+
+// PerRepo reports whether each repository has its own queue.
+func (s *QueueSet) PerRepo() bool {
+	return s.perRepo
+}
+
+// Track records keys queued in this set's queues with t under the given
+// queue name. Call before the first For.
+func (s *QueueSet) Track(t *QueueTracker, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tracker, s.name = t, name
+}
+
+// For returns the queue for the given repo, creating it on first use.
+func (s *QueueSet) For(repo string) workqueue.Interface {
+	if !s.perRepo {
+		repo = ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q, ok := s.queues[repo]
+	if !ok {
+		q = inmem.NewWorkQueue(s.size)
+		if s.tracker != nil {
+			q = s.tracker.Track(s.name, q)
+		}
+		s.queues[repo] = q
+	}
+	return q
+}
+
+// Each calls fn for every queue in repo-name order. In shared mode repo is "".
+func (s *QueueSet) Each(fn func(repo string, q workqueue.Interface)) {
+	s.mu.Lock()
+	repos := make([]string, 0, len(s.queues))
+	for r := range s.queues {
+		repos = append(repos, r)
+	}
+	queues := make(map[string]workqueue.Interface, len(s.queues))
+	for r, q := range s.queues {
+		queues[r] = q
+	}
+	s.mu.Unlock()
+
+	sort.Strings(repos)
+	for _, r := range repos {
+		fn(r, queues[r])
+	}
+}
@@ -0,0 +1,41 @@
+package reconciler
+
+import (
+	"testing"
+
+	"chainguard.dev/driftlessaf/workqueue"
+)
+
+func TestQueueSet_Shared(t *testing.T) {
+	s := NewQueueSet(10, false)
+	if s.For("mono") != s.For("os") {
+		t.Error("shared mode should return the same queue for every repo")
+	}
+
+	var repos []string
+	s.Each(func(repo string, _ workqueue.Interface) {
+		repos = append(repos, repo)
+	})
+	if len(repos) != 1 || repos[0] != "" {
+		t.Errorf("Each() visited %q, want a single shared queue", repos)
+	}
+}
+
+func TestQueueSet_PerRepo(t *testing.T) {
+	s := NewQueueSet(10, true)
+	mono := s.For("mono")
+	if mono == s.For("os") {
+		t.Error("per-repo mode should return distinct queues")
+	}
+	if mono != s.For("mono") {
+		t.Error("For() should return the same queue for the same repo")
+	}
+
+	var repos []string
+	s.Each(func(repo string, _ workqueue.Interface) {
+		repos = append(repos, repo)
+	})
+	if len(repos) != 2 || repos[0] != "mono" || repos[1] != "os" {
+		t.Errorf("Each() visited %q, want [mono os]", repos)
+	}
+}
@@ -95,6 +95,7 @@ func ScanSessions(cfg *config.Config, idleThreshold time.Duration) {
 			OutputTokens: session.FormatTokenCount(tokens.OutputTokens),
 			LastModified: s.Modified,
 			UpdatedAt:    now.Unix(),
+			Tokens:       tokens,
 		})
 	}
 
@@ -0,0 +1,62 @@
+package reconciler
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// sessionGCInterval is how often the daemon collects orphaned sessions.
+const sessionGCInterval = 24 * time.Hour
+
+func sessionGCPath() string {
+	return filepath.Join(config.StateDir(), "session_gc")
+}
+
+// MaybeCollectSessions deletes the Claude sessions of deleted worktrees
+// that have been inactive for watch.session_gc_after_days, at most once per
+// sessionGCInterval. It does nothing when the setting is 0.
+func MaybeCollectSessions(cfg *config.Config) {
+	days := cfg.Watch.SessionGCAfterDays
+	if days <= 0 {
+		return
+	}
+	now := time.Now()
+	if data, err := os.ReadFile(sessionGCPath()); err == nil {
+		if last, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data))); err == nil && now.Sub(last) < sessionGCInterval {
+			return
+		}
+	}
+	if err := state.WriteFile(sessionGCPath(), []byte(now.Format(time.RFC3339)+"\n"), 0o644); err != nil {
+		logf("Warning: writing session GC time: %v", err)
+		return
+	}
+
+	sessions, err := session.StoredSessions(cfg.AllBasePaths())
+	if err != nil {
+		logf("Session GC: listing sessions: %v", err)
+		return
+	}
+	filter := session.GCFilter{Orphaned: true, Before: now.AddDate(0, 0, -days), Both: true}
+	var deleted int
+	var freed int64
+	for _, s := range sessions {
+		if !filter.Match(s) || session.IsProcessRunning(s.ID) {
+			continue
+		}
+		if err := s.Delete(); err != nil {
+			logf("Session GC: deleting %s: %v", s.Path, err)
+			continue
+		}
+		deleted++
+		freed += s.Size
+	}
+	if deleted > 0 {
+		logf("Session GC: deleted %d orphaned session(s), freed %s", deleted, session.FormatSize(freed))
+	}
+}
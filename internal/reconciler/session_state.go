@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/session"
+	"github.com/mgreau/zen/internal/state"
 )
 
 // SessionState holds the cached state of a single Claude session.
@@ -22,6 +24,9 @@ type SessionState struct {
 	OutputTokens string `json:"output_tokens"`
 	LastModified int64  `json:"last_modified_epoch"`
 	UpdatedAt    int64  `json:"updated_at"`
+
+	// Tokens holds the raw counts behind InputTokens and OutputTokens
+	Tokens session.TokenUsage `json:"tokens"`
 }
 
 // SessionSnapshot is the top-level structure written to sessions.json.
@@ -41,11 +46,7 @@ func WriteSessionSnapshot(states []SessionState) error {
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Sessions:  states,
 	}
-	data, err := json.MarshalIndent(snapshot, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(sessionSnapshotPath(), data, 0o644)
+	return state.WriteJSON(sessionSnapshotPath(), snapshot)
 }
 
 // ReadSessionSnapshot reads the cached session snapshot from disk.
@@ -4,17 +4,19 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sync"
 	"time"
 
 	"chainguard.dev/driftlessaf/workqueue"
+	"github.com/mgreau/zen/internal/audit"
 	"github.com/mgreau/zen/internal/config"
 	ctxpkg "github.com/mgreau/zen/internal/context"
 	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/hooks"
 	"github.com/mgreau/zen/internal/notify"
 	"github.com/mgreau/zen/internal/prcache"
+	"github.com/mgreau/zen/internal/ui"
 	wt "github.com/mgreau/zen/internal/worktree"
 )
 
@@ -81,66 +83,195 @@ func (r *SetupReconciler) Reconcile(ctx context.Context, key string, _ workqueue
 
 	label := fmt.Sprintf("%s PR #%d %q", repo, prNumber, pr.Title)
 
+	// Log each git step with its duration, so slow fetches show up in watch.log
+	steps := ui.NewStepLogger(func(line string) { logf("%s: %s", label, line) })
+
+	worktreePath, err := r.EnsurePR(ctx, repo, prNumber, pr.Title, pr.Author.Login, steps)
+	if err != nil {
+		return err
+	}
+
+	prcache.SetLabels(repo, prNumber, pr.Labels)
+	ClearSetupFailure(key)
+	if err := notify.WorktreeReady(prNumber, worktreePath); err != nil {
+		logf("Warning: notification failed for %s: %v", label, err)
+	}
+	hooks.Fire(hooks.Event{
+		Event: hooks.WorktreeReady, Repo: repo, FullName: r.cfg.RepoFullName(repo), PR: prNumber,
+		Title: pr.Title, Author: pr.Author.Login, Worktree: worktreePath,
+	})
+	logf("Setup complete for %s (worktree: %s)", label, worktreePath)
+
+	// Replace the pooled worktree this setup may have claimed
+	if r.cfg.RepoPoolSize(repo) > 0 {
+		FillPoolsAsync(context.WithoutCancel(ctx), r.cfg)
+	}
+	return nil
+}
+
+// EnsurePR runs the setup steps for a PR review worktree: the worktree
+// exists and is checked out, PR context is injected, and PR metadata is
+// cached. Each step is skipped when already done, so this also repairs a
+// worktree whose setup was interrupted. A failed context injection is
+// reported through steps as a warning. Returns the worktree path.
+func (r *SetupReconciler) EnsurePR(ctx context.Context, repo string, prNumber int, title, author string, steps *ui.Steps) (string, error) {
+	basePath := r.cfg.RepoBasePath(repo)
+	if basePath == "" {
+		return "", fmt.Errorf("unknown repo %q", repo)
+	}
 	worktreeName := fmt.Sprintf("%s-pr-%d", repo, prNumber)
 	worktreePath := filepath.Join(basePath, worktreeName)
 	originPath := filepath.Join(basePath, repo)
 	fullRepo := r.cfg.RepoFullName(repo)
 
+	// Repos with sparse: true get a sparse-checkout limited to the PR's dirs
+	var sparseDirs []string
+	sparse := r.cfg.RepoSparse(repo)
+	if _, statErr := os.Stat(worktreePath); sparse && statErr != nil {
+		var err error
+		sparseDirs, err = r.prSparseDirs(ctx, repo, fullRepo, prNumber)
+		if err != nil {
+			return "", fmt.Errorf("sparse dirs: %w", err)
+		}
+	}
+
+	_, statErr := os.Stat(worktreePath)
+	created := statErr != nil
+
 	// Step 1: Ensure worktree exists (retryable on failure)
-	if err := r.ensureWorktree(originPath, worktreePath, worktreeName, prNumber); err != nil {
-		return fmt.Errorf("ensureWorktree: %w", err)
+	if err := r.ensureWorktree(ctx, originPath, worktreePath, worktreeName, prNumber, sparse, sparseDirs, r.cfg.RepoPoolSize(repo) > 0, wt.FetchOptions{
+		Depth:  r.cfg.RepoFetchDepth(repo),
+		Filter: r.cfg.RepoFetchFilter(repo),
+		Remote: r.cfg.RepoUpstream(repo),
+	}, r.cfg.RepoGitTimeout(repo), steps); err != nil {
+		return "", fmt.Errorf("ensureWorktree: %w", err)
+	}
+	if created {
+		audit.Record(audit.Event{Action: audit.WorktreeCreated, Repo: repo, Target: worktreePath, Detail: fmt.Sprintf("PR #%d", prNumber)})
+	}
+
+	// Keep CLAUDE.local.md and the like out of git status, and pull LFS
+	// files and submodules
+	if created {
+		mu := wt.RepoLock(originPath)
+		mu.Lock()
+		err := wt.Exclude(worktreePath, r.cfg.GitExcludes())
+		if err != nil {
+			steps.Info(fmt.Sprintf("Warning: failed to update info/exclude: %v", err))
+		}
+		wt.InitExtras(ctx, r.cfg, repo, worktreePath, steps)
+		mu.Unlock()
+		if _, err := ctxpkg.EnsureNotes(worktreePath, ctxpkg.NotesTitle(prNumber)); err != nil {
+			steps.Info(fmt.Sprintf("Warning: failed to create %s: %v", ctxpkg.NotesFile, err))
+		}
+	}
+
+	// Repos with readonly: true block commits in new worktrees. An existing
+	// one is left alone: it may have been unlocked on purpose.
+	if created && r.cfg.RepoReadOnly(repo) {
+		steps.Step("make read-only")
+		err := wt.LockReview(worktreePath, prNumber, "")
+		steps.Done(err)
+		if err != nil {
+			steps.Info(fmt.Sprintf("Warning: failed to make the worktree read-only: %v", err))
+		}
 	}
 
 	// Step 2: Ensure PR context is injected (non-blocking)
-	if err := r.ensureContextInjected(ctx, worktreePath, fullRepo, prNumber); err != nil {
-		logf("Warning: failed to inject PR context for %s: %v", label, err)
+	if err := r.ensureContextInjected(ctx, worktreePath, fullRepo, prNumber, steps); err != nil {
+		steps.Info(fmt.Sprintf("Warning: failed to inject PR context: %v", err))
 	}
 
 	// Step 3: Cache PR metadata for display commands (non-blocking)
-	prcache.Set(repo, prNumber, pr.Title, pr.Author.Login)
+	prcache.Set(repo, prNumber, title, author)
 
-	if err := notify.WorktreeReady(prNumber, worktreePath); err != nil {
-		logf("Warning: notification failed for %s: %v", label, err)
+	return worktreePath, nil
+}
+
+// prSparseDirs returns the directories to check out for a sparse worktree:
+// the parent dirs of the PR's changed files plus the repo's sparse_include.
+func (r *SetupReconciler) prSparseDirs(ctx context.Context, repo, fullRepo string, prNumber int) ([]string, error) {
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating GitHub client: %w", err)
 	}
-	logf("Setup complete for %s (worktree: %s)", label, worktreePath)
-	return nil
+	files, err := client.GetPRFiles(ctx, fullRepo, prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("fetching PR files: %w", err)
+	}
+	return wt.SparseDirs(files, r.cfg.RepoSparseInclude(repo)), nil
 }
 
-func (r *SetupReconciler) ensureWorktree(originPath, worktreePath, worktreeName string, prNumber int) error {
-	if _, err := os.Stat(worktreePath); err == nil {
+func (r *SetupReconciler) ensureWorktree(ctx context.Context, originPath, worktreePath, worktreeName string, prNumber int, sparse bool, sparseDirs []string, pool bool, fetch wt.FetchOptions, timeout time.Duration, steps *ui.Steps) (err error) {
+	if _, err := os.Stat(worktreePath); err == nil && !wt.NeedsCheckout(worktreePath) {
 		return nil // already exists
 	}
 
-	wt.GitMu.Lock()
-	defer wt.GitMu.Unlock()
+	mu := wt.RepoLock(originPath)
+	mu.Lock()
+	defer mu.Unlock()
+	defer func() { steps.Done(err) }()
 
-	// Re-check after acquiring lock
+	// Re-check after acquiring lock. A worktree added but never checked
+	// out (interrupted setup) only needs the checkout.
 	if _, err := os.Stat(worktreePath); err == nil {
-		return nil
+		if !wt.NeedsCheckout(worktreePath) {
+			return nil
+		}
+		steps.Step("git checkout")
+		_, err := wt.Git(ctx, timeout, worktreePath, "checkout")
+		return err
 	}
 
+	// Drop metadata left by a worktree directory that was deleted by hand,
+	// which would otherwise make git worktree add refuse the path
+	wt.Git(ctx, timeout, originPath, "worktree", "prune")
+
+	steps.Step("git fetch")
 	fetchRef := fmt.Sprintf("+pull/%d/head:pr-%d", prNumber, prNumber)
-	fetchCmd := exec.Command("git", "fetch", "origin", fetchRef)
-	fetchCmd.Dir = originPath
-	if out, err := fetchCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("git fetch: %w: %s", err, string(out))
+	if _, err := wt.Git(ctx, timeout, originPath, wt.FetchArgs(originPath, fetchRef, fetch)...); err != nil {
+		return err
 	}
 
 	branch := fmt.Sprintf("pr-%d", prNumber)
+
+	// A pooled worktree is already checked out at origin/main, so only the
+	// files the PR differs in are rewritten. Sparse worktrees need a fresh add.
+	if pool && !sparse {
+		steps.Step("claim pooled worktree")
+		claimed, err := wt.ClaimPooled(originPath, worktreePath, branch)
+		if err != nil {
+			if claimed {
+				wt.CleanupFailedAdd(originPath, worktreePath, branch)
+			}
+			return fmt.Errorf("claiming pooled worktree: %w", err)
+		}
+		if claimed {
+			return nil
+		}
+		steps.Info("pool is empty")
+	}
+
 	// Use --no-checkout + separate checkout to avoid "Could not write new index file"
 	// on large repos (13K+ files).
-	wtCmd := exec.Command("git", "worktree", "add", "--no-checkout", worktreePath, branch)
-	wtCmd.Dir = originPath
-	if out, err := wtCmd.CombinedOutput(); err != nil {
+	steps.Step("git worktree add")
+	if _, err := wt.Git(ctx, timeout, originPath, "worktree", "add", "--no-checkout", worktreePath, branch); err != nil {
 		wt.CleanupFailedAdd(originPath, worktreePath, branch)
-		return fmt.Errorf("git worktree add: %w: %s", err, string(out))
+		return err
+	}
+
+	if sparse {
+		steps.Step("sparse checkout")
+		if err := wt.ApplySparseCheckout(ctx, timeout, worktreePath, sparseDirs); err != nil {
+			wt.CleanupFailedAdd(originPath, worktreePath, branch)
+			return err
+		}
 	}
 
-	checkoutCmd := exec.Command("git", "checkout")
-	checkoutCmd.Dir = worktreePath
-	if out, err := checkoutCmd.CombinedOutput(); err != nil {
+	steps.Step("git checkout")
+	if _, err := wt.Git(ctx, timeout, worktreePath, "checkout"); err != nil {
 		wt.CleanupFailedAdd(originPath, worktreePath, branch)
-		return fmt.Errorf("git checkout in worktree: %w: %s", err, string(out))
+		return err
 	}
 
 	// Clean stale index.lock (only if holding process is dead)
@@ -150,12 +281,15 @@ func (r *SetupReconciler) ensureWorktree(originPath, worktreePath, worktreeName
 	return nil
 }
 
-func (r *SetupReconciler) ensureContextInjected(ctx context.Context, worktreePath, fullRepo string, prNumber int) error {
+func (r *SetupReconciler) ensureContextInjected(ctx context.Context, worktreePath, fullRepo string, prNumber int, steps *ui.Steps) error {
 	claudeLocal := filepath.Join(worktreePath, "CLAUDE.local.md")
 	if _, err := os.Stat(claudeLocal); err == nil {
 		return nil // already injected
 	}
-	return ctxpkg.InjectPRContext(ctx, worktreePath, fullRepo, prNumber)
+	steps.Step("context inject")
+	err := ctxpkg.InjectPRContext(ctx, worktreePath, fullRepo, prNumber)
+	steps.Done(err)
+	return err
 }
 
 func logf(format string, args ...any) {
@@ -0,0 +1,120 @@
+package reconciler
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/hooks"
+	"github.com/mgreau/zen/internal/notify"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// SetupFailure records a PR whose worktree setup the daemon gave up on.
+type SetupFailure struct {
+	Key      string    `json:"key"` // repo:number
+	Repo     string    `json:"repo"`
+	PRNumber int       `json:"pr_number"`
+	Title    string    `json:"title,omitempty"`
+	Author   string    `json:"author,omitempty"`
+	Error    string    `json:"error"`
+	Attempts int       `json:"attempts"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+var setupFailuresMu sync.Mutex
+
+func setupFailuresPath() string {
+	return filepath.Join(config.StateDir(), "setup_failed.json")
+}
+
+func loadSetupFailures() map[string]SetupFailure {
+	failures := make(map[string]SetupFailure)
+	data, err := os.ReadFile(setupFailuresPath())
+	if err != nil {
+		return failures
+	}
+	json.Unmarshal(data, &failures)
+	return failures
+}
+
+func saveSetupFailures(failures map[string]SetupFailure) error {
+	return state.WriteJSON(setupFailuresPath(), failures)
+}
+
+// SetupFailures returns the PRs whose setup failed, most recent first.
+func SetupFailures() []SetupFailure {
+	setupFailuresMu.Lock()
+	defer setupFailuresMu.Unlock()
+	failures := loadSetupFailures()
+	out := make([]SetupFailure, 0, len(failures))
+	for _, f := range failures {
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].FailedAt.After(out[j].FailedAt) })
+	return out
+}
+
+// GetSetupFailure returns the recorded failure for a PR key.
+func GetSetupFailure(key string) (SetupFailure, bool) {
+	setupFailuresMu.Lock()
+	defer setupFailuresMu.Unlock()
+	f, ok := loadSetupFailures()[key]
+	return f, ok
+}
+
+// RecordSetupFailure marks a PR as "setup failed".
+func RecordSetupFailure(f SetupFailure) error {
+	setupFailuresMu.Lock()
+	defer setupFailuresMu.Unlock()
+	failures := loadSetupFailures()
+	failures[f.Key] = f
+	return saveSetupFailures(failures)
+}
+
+// ClearSetupFailure drops the failure recorded for a PR key, if any.
+func ClearSetupFailure(key string) {
+	setupFailuresMu.Lock()
+	defer setupFailuresMu.Unlock()
+	failures := loadSetupFailures()
+	if _, ok := failures[key]; !ok {
+		return
+	}
+	delete(failures, key)
+	saveSetupFailures(failures)
+}
+
+// SetupFailed is called when the daemon gives up on a setup key: it marks
+// the PR as "setup failed" and sends a notification with the error.
+func (r *SetupReconciler) SetupFailed(e QueueEntry) {
+	repo, prNumber, err := ParsePRKey(e.Key)
+	if err != nil {
+		return
+	}
+	pr, _ := r.getPRData(e.Key)
+	f := SetupFailure{
+		Key:      e.Key,
+		Repo:     repo,
+		PRNumber: prNumber,
+		Title:    pr.Title,
+		Author:   pr.Author.Login,
+		Error:    e.LastError,
+		Attempts: e.Attempts,
+		FailedAt: time.Now(),
+	}
+	logf("Setup failed for %s PR #%d after %d attempt(s): %s", repo, prNumber, e.Attempts, e.LastError)
+	if err := RecordSetupFailure(f); err != nil {
+		logf("Warning: recording setup failure for %s: %v", e.Key, err)
+	}
+	if err := notify.SetupFailed(prNumber, repo, e.Key, e.LastError); err != nil {
+		logf("Warning: notification failed for %s: %v", e.Key, err)
+	}
+	hooks.Fire(hooks.Event{
+		Event: hooks.SetupFailed, Repo: repo, FullName: r.cfg.RepoFullName(repo), PR: prNumber,
+		Title: pr.Title, Author: pr.Author.Login, Error: e.LastError,
+	})
+}
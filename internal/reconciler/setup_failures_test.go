@@ -0,0 +1,35 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetupFailures(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if got := SetupFailures(); len(got) != 0 {
+		t.Fatalf("SetupFailures() on empty state = %+v", got)
+	}
+
+	now := time.Now()
+	RecordSetupFailure(SetupFailure{Key: "mono:1", Repo: "mono", PRNumber: 1, Error: "git fetch: boom", Attempts: 5, FailedAt: now.Add(-time.Hour)})
+	RecordSetupFailure(SetupFailure{Key: "mono:2", Repo: "mono", PRNumber: 2, Error: "unknown repo", Attempts: 1, FailedAt: now})
+
+	got := SetupFailures()
+	if len(got) != 2 || got[0].Key != "mono:2" || got[1].Key != "mono:1" {
+		t.Fatalf("SetupFailures() = %+v; want mono:2 then mono:1", got)
+	}
+	if f, ok := GetSetupFailure("mono:1"); !ok || f.Attempts != 5 || f.Error != "git fetch: boom" {
+		t.Errorf("GetSetupFailure(mono:1) = %+v, %v", f, ok)
+	}
+
+	ClearSetupFailure("mono:1")
+	ClearSetupFailure("mono:404")
+	if _, ok := GetSetupFailure("mono:1"); ok {
+		t.Error("mono:1 should be cleared")
+	}
+	if got := SetupFailures(); len(got) != 1 || got[0].Key != "mono:2" {
+		t.Errorf("SetupFailures() after clear = %+v; want only mono:2", got)
+	}
+}
@@ -0,0 +1,88 @@
+package reconciler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// SetupRequest asks the daemon to set up the review worktree of a PR it
+// wasn't requested for, e.g. one triaged by a Claude session through the
+// zen_review_setup MCP tool.
+type SetupRequest struct {
+	Key         string    `json:"key"` // repo:number
+	Repo        string    `json:"repo"`
+	PRNumber    int       `json:"pr_number"`
+	Title       string    `json:"title,omitempty"`
+	Author      string    `json:"author,omitempty"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// setupRequestsDir holds one file per pending request, so requests made
+// while the daemon takes the pending ones are never lost.
+func setupRequestsDir() string {
+	return filepath.Join(config.StateDir(), "setup_requests")
+}
+
+// RequestSetup records a setup request for the daemon to queue at its
+// next dispatch. A pending request for the same PR is replaced.
+func RequestSetup(r SetupRequest) error {
+	r.Key = MakePRKey(r.Repo, r.PRNumber)
+	if r.RequestedAt.IsZero() {
+		r.RequestedAt = time.Now()
+	}
+	name := fmt.Sprintf("%s-%d.json", r.Repo, r.PRNumber)
+	return state.WriteJSON(filepath.Join(setupRequestsDir(), name), r)
+}
+
+// TakeSetupRequests returns the pending setup requests, oldest first, and
+// removes them.
+func TakeSetupRequests() []SetupRequest {
+	entries, err := os.ReadDir(setupRequestsDir())
+	if err != nil {
+		return nil
+	}
+	var out []SetupRequest
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(setupRequestsDir(), e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		os.Remove(path)
+		var r SetupRequest
+		if json.Unmarshal(data, &r) != nil || r.Repo == "" || r.PRNumber == 0 {
+			logf("Dropping malformed setup request %s", e.Name())
+			continue
+		}
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RequestedAt.Before(out[j].RequestedAt) })
+	return out
+}
+
+// DaemonRunning reports whether the watch daemon is running, from its
+// PID file.
+func DaemonRunning() bool {
+	data, err := os.ReadFile(filepath.Join(config.StateDir(), "watch.pid"))
+	if err != nil {
+		return false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return false
+	}
+	return syscall.Kill(pid, 0) == nil
+}
@@ -0,0 +1,34 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetupRequests(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if got := TakeSetupRequests(); len(got) != 0 {
+		t.Fatalf("TakeSetupRequests() on empty state = %+v", got)
+	}
+
+	now := time.Now()
+	RequestSetup(SetupRequest{Repo: "mono", PRNumber: 2, Title: "Old", RequestedAt: now})
+	RequestSetup(SetupRequest{Repo: "infra", PRNumber: 9, RequestedAt: now.Add(-time.Minute)})
+	RequestSetup(SetupRequest{Repo: "mono", PRNumber: 2, Title: "Fix auth", Author: "alice", RequestedAt: now})
+
+	got := TakeSetupRequests()
+	if len(got) != 2 || got[0].Key != "infra:9" || got[1].Key != "mono:2" || got[1].Title != "Fix auth" {
+		t.Fatalf("TakeSetupRequests() = %+v; want infra:9 then the latest mono:2", got)
+	}
+	if got := TakeSetupRequests(); len(got) != 0 {
+		t.Errorf("TakeSetupRequests() should remove the requests, got %+v", got)
+	}
+}
+
+func TestDaemonRunning(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if DaemonRunning() {
+		t.Error("DaemonRunning() without a PID file = true")
+	}
+}
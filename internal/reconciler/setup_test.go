@@ -2,6 +2,9 @@ package reconciler
 
 import (
 	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"chainguard.dev/driftlessaf/workqueue"
@@ -9,6 +12,7 @@ import (
 	"chainguard.dev/driftlessaf/workqueue/inmem"
 	"github.com/mgreau/zen/internal/config"
 	ghpkg "github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/ui"
 )
 
 func TestMakePRKey(t *testing.T) {
@@ -136,3 +140,48 @@ func TestDispatcherIntegration(t *testing.T) {
 		t.Error("callback was not called")
 	}
 }
+
+func TestEnsurePR_RepairsUncheckedOutWorktree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	base := t.TempDir()
+	origin := filepath.Join(base, "mono")
+	worktreePath := filepath.Join(base, "mono-pr-7")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run(base, "init", "-q", "-b", "main", origin)
+	os.WriteFile(filepath.Join(origin, "a.txt"), []byte("a\n"), 0o644)
+	run(origin, "add", "a.txt")
+	run(origin, "commit", "-q", "-m", "init")
+	run(origin, "branch", "pr-7")
+	// Simulate setup interrupted between worktree add and checkout, after
+	// context was injected
+	run(origin, "worktree", "add", "-q", "--no-checkout", worktreePath, "pr-7")
+	os.WriteFile(filepath.Join(worktreePath, "CLAUDE.local.md"), []byte("ctx\n"), 0o644)
+
+	cfg := &config.Config{Repos: map[string]config.RepoConfig{
+		"mono": {FullName: "acme/mono", BasePath: base},
+	}}
+	got, err := NewSetupReconciler(cfg).EnsurePR(context.Background(), "mono", 7, "Test PR", "alice", ui.NewStepLogger(func(string) {}))
+	if err != nil {
+		t.Fatalf("EnsurePR() error: %v", err)
+	}
+	if got != worktreePath {
+		t.Errorf("EnsurePR() = %q, want %q", got, worktreePath)
+	}
+	if _, err := os.Stat(filepath.Join(worktreePath, "a.txt")); err != nil {
+		t.Errorf("worktree was not checked out: %v", err)
+	}
+}
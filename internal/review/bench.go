@@ -0,0 +1,255 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/github"
+	"github.com/mgreau/zen/internal/metrics"
+	wt "github.com/mgreau/zen/internal/worktree"
+)
+
+// BenchOptions selects what Bench measures.
+type BenchOptions struct {
+	// PR checks out the head of this PR and injects its context; 0 checks
+	// out origin/main and skips context injection.
+	PR     int
+	Trials int  // number of setups, at least 1
+	Sparse bool // sparse-checkout the PR's dirs plus the repo's sparse_include
+	Full   bool // ignore the repo's fetch_depth and fetch_filter
+	// Pool times checking the PR out in a worktree already checked out at
+	// origin/main, as claiming a pooled worktree does. Preparing that
+	// worktree is not timed. Sparse setups don't use the pool.
+	Pool bool
+}
+
+// BenchPhase summarizes the durations of one setup phase across trials.
+type BenchPhase struct {
+	Name string        `json:"name"`
+	P50  time.Duration `json:"p50_ns"`
+	P95  time.Duration `json:"p95_ns"`
+	Min  time.Duration `json:"min_ns"`
+	Max  time.Duration `json:"max_ns"`
+}
+
+// BenchResult holds the setup timings of a repo, phase by phase in setup
+// order, followed by the "total" of each trial.
+type BenchResult struct {
+	Repo        string       `json:"repo"`
+	PR          int          `json:"pr,omitempty"`
+	Trials      int          `json:"trials"`
+	Sparse      bool         `json:"sparse"`
+	Pool        bool         `json:"pool"`
+	FetchDepth  int          `json:"fetch_depth"`
+	FetchFilter string       `json:"fetch_filter,omitempty"`
+	Phases      []BenchPhase `json:"phases"`
+}
+
+// benchRun holds what the trials of a Bench call share.
+type benchRun struct {
+	timeout    time.Duration
+	originPath string
+	fullRepo   string
+	refspec    string // fetched at the start of each trial
+	ref        string // checked out in the worktrees
+	sparseDirs []string
+	fetch      wt.FetchOptions
+	opts       BenchOptions
+	p          Progress
+}
+
+// benchTiming is one timed phase of a trial.
+type benchTiming struct {
+	phase string
+	d     time.Duration
+}
+
+// Bench sets up opts.Trials scratch worktrees of the repo the way review
+// worktrees are set up, timing each phase, and removes them again. The
+// worktrees live under <base_path>/.zen-bench and are not listed as
+// worktrees while it runs.
+func Bench(ctx context.Context, cfg *config.Config, repoShort string, opts BenchOptions, p Progress) (res *BenchResult, err error) {
+	if p == nil {
+		p = noProgress{}
+	}
+	defer func() { p.Done(err) }()
+
+	basePath := cfg.RepoBasePath(repoShort)
+	if basePath == "" {
+		return nil, fmt.Errorf("unknown repo %q -- check %s", repoShort, config.Path())
+	}
+	if opts.Trials < 1 {
+		opts.Trials = 1
+	}
+	fullRepo := cfg.RepoFullName(repoShort)
+	originPath := filepath.Join(basePath, repoShort)
+	timeout := cfg.RepoGitTimeout(repoShort)
+	fetch := wt.FetchOptions{
+		Depth:  cfg.RepoFetchDepth(repoShort),
+		Filter: cfg.RepoFetchFilter(repoShort),
+		Full:   opts.Full,
+		Remote: cfg.RepoUpstream(repoShort),
+	}
+	run := &benchRun{timeout: timeout, originPath: originPath, fullRepo: fullRepo, fetch: fetch, opts: opts, p: p}
+	res = &BenchResult{Repo: repoShort, PR: opts.PR, Trials: opts.Trials, Sparse: opts.Sparse, Pool: opts.Pool}
+	if !opts.Full {
+		res.FetchDepth, res.FetchFilter = fetch.Depth, fetch.Filter
+	}
+
+	run.refspec, run.ref = "main", fetch.Remote+"/main"
+	if opts.PR > 0 {
+		run.ref = fmt.Sprintf("zen-bench-pr-%d", opts.PR)
+		run.refspec = fmt.Sprintf("+pull/%d/head:%s", opts.PR, run.ref)
+		defer wt.Git(context.WithoutCancel(ctx), timeout, originPath, "branch", "-D", run.ref)
+	}
+
+	if opts.Sparse {
+		run.sparseDirs = cfg.RepoSparseInclude(repoShort)
+		if opts.PR > 0 {
+			p.Step(fmt.Sprintf("Fetch PR #%d files for sparse checkout", opts.PR))
+			client, err := github.NewClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("creating GitHub client: %w", err)
+			}
+			files, err := client.GetPRFiles(ctx, fullRepo, opts.PR)
+			if err != nil {
+				return nil, fmt.Errorf("fetching PR files for sparse checkout: %w", err)
+			}
+			run.sparseDirs = wt.SparseDirs(files, run.sparseDirs)
+		}
+	}
+
+	// Leftovers of an interrupted run would make git worktree add fail
+	benchDir := wt.BenchDir(basePath)
+	removeBenchDir(originPath, benchDir)
+	defer removeBenchDir(originPath, benchDir)
+
+	var order []string
+	durations := make(map[string][]time.Duration)
+	for i := 1; i <= opts.Trials; i++ {
+		path := filepath.Join(benchDir, fmt.Sprintf("%s-bench-%d", repoShort, i))
+		prefix := fmt.Sprintf("Trial %d/%d: ", i, opts.Trials)
+		timings, err := run.trial(ctx, path, prefix)
+		if rmErr := removeBenchWorktree(originPath, path); err == nil && rmErr != nil {
+			err = fmt.Errorf("removing %s: %w", path, rmErr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("trial %d: %w", i, err)
+		}
+		var total time.Duration
+		for _, t := range timings {
+			if _, ok := durations[t.phase]; !ok {
+				order = append(order, t.phase)
+			}
+			durations[t.phase] = append(durations[t.phase], t.d)
+			total += t.d
+		}
+		durations["total"] = append(durations["total"], total)
+	}
+
+	for _, name := range append(order, "total") {
+		res.Phases = append(res.Phases, benchPhase(name, durations[name]))
+	}
+	return res, nil
+}
+
+// trial sets up one scratch worktree at path and returns the time each
+// phase took. prefix starts its progress steps.
+func (r *benchRun) trial(ctx context.Context, path, prefix string) ([]benchTiming, error) {
+	originPath, ref, opts, p := r.originPath, r.ref, r.opts, r.p
+	var timings []benchTiming
+	timed := func(phase string, fn func() error) error {
+		p.Step(prefix + phase)
+		start := time.Now()
+		if err := fn(); err != nil {
+			return err
+		}
+		timings = append(timings, benchTiming{phase, time.Since(start)})
+		return nil
+	}
+	git := func(dir string, args ...string) func() error {
+		return func() error {
+			_, err := wt.Git(ctx, r.timeout, dir, args...)
+			return err
+		}
+	}
+
+	if err := timed("git fetch", git(originPath, wt.FetchArgs(originPath, r.refspec, r.fetch)...)); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case opts.Sparse:
+		if err := timed("git worktree add --no-checkout", git(originPath, "worktree", "add", "--no-checkout", "--detach", path, ref)); err != nil {
+			return nil, err
+		}
+		if err := timed(fmt.Sprintf("sparse checkout of %d dir(s)", len(r.sparseDirs)), func() error {
+			if err := wt.ApplySparseCheckout(ctx, r.timeout, path, r.sparseDirs); err != nil {
+				return err
+			}
+			_, err := wt.Git(ctx, r.timeout, path, "checkout", "-q", "--detach", ref)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+	case opts.Pool:
+		p.Step(prefix + "Prepare pooled worktree (not timed)")
+		if err := git(originPath, "worktree", "add", "--detach", path, "origin/main")(); err != nil {
+			return nil, err
+		}
+		if err := timed("checkout in pooled worktree", git(path, "checkout", "-q", "--detach", ref)); err != nil {
+			return nil, err
+		}
+	default:
+		if err := timed("git worktree add", git(originPath, "worktree", "add", "--detach", path, ref)); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.PR > 0 {
+		if err := timed("inject PR context", func() error {
+			return ctxpkg.InjectPRContext(ctx, path, r.fullRepo, opts.PR)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return timings, nil
+}
+
+// benchPhase summarizes the durations of a phase.
+func benchPhase(name string, ds []time.Duration) BenchPhase {
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return BenchPhase{
+		Name: name,
+		P50:  metrics.Percentile(sorted, 50),
+		P95:  metrics.Percentile(sorted, 95),
+		Min:  sorted[0],
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// removeBenchWorktree removes a scratch worktree, if it was added.
+func removeBenchWorktree(originPath, path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	_, err := wt.Git(context.Background(), 0, originPath, "worktree", "remove", "--force", path)
+	return err
+}
+
+// removeBenchDir removes the bench directory with any worktrees left in
+// it and prunes their metadata.
+func removeBenchDir(originPath, benchDir string) {
+	if _, err := os.Stat(benchDir); os.IsNotExist(err) {
+		return
+	}
+	os.RemoveAll(benchDir)
+	wt.Git(context.Background(), 0, originPath, "worktree", "prune")
+}
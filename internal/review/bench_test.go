@@ -0,0 +1,92 @@
+package review
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	wt "github.com/mgreau/zen/internal/worktree"
+)
+
+func TestBenchPhase(t *testing.T) {
+	ds := []time.Duration{5, 1, 4, 2, 3}
+	got := benchPhase("fetch", ds)
+	want := BenchPhase{Name: "fetch", P50: 3, P95: 5, Min: 1, Max: 5}
+	if got != want {
+		t.Errorf("benchPhase() = %+v, want %+v", got, want)
+	}
+	if ds[0] != 5 {
+		t.Error("benchPhase() reordered its argument")
+	}
+}
+
+func TestBench(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	for k, v := range map[string]string{
+		"GIT_AUTHOR_NAME": "t", "GIT_AUTHOR_EMAIL": "t@example.com",
+		"GIT_COMMITTER_NAME": "t", "GIT_COMMITTER_EMAIL": "t@example.com",
+	} {
+		t.Setenv(k, v)
+	}
+
+	base, _ := filepath.EvalSymlinks(t.TempDir())
+	upstream := filepath.Join(base, "upstream")
+	origin := filepath.Join(base, "mono")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	os.MkdirAll(filepath.Join(upstream, "docs"), 0o755)
+	run(upstream, "init", "-q", "-b", "main")
+	os.WriteFile(filepath.Join(upstream, "README"), []byte("hi\n"), 0o644)
+	os.WriteFile(filepath.Join(upstream, "docs", "index.md"), []byte("# docs\n"), 0o644)
+	run(upstream, "add", ".")
+	run(upstream, "commit", "-q", "-m", "init")
+	run(base, "clone", "-q", upstream, origin)
+
+	cfg := &config.Config{Repos: map[string]config.RepoConfig{
+		"mono": {FullName: "o/mono", BasePath: base, SparseInclude: []string{"docs"}},
+	}}
+	tests := []struct {
+		name   string
+		opts   BenchOptions
+		phases []string
+	}{
+		{"default", BenchOptions{Trials: 2}, []string{"git fetch", "git worktree add", "total"}},
+		{"pool", BenchOptions{Trials: 1, Pool: true}, []string{"git fetch", "checkout in pooled worktree", "total"}},
+		{"sparse", BenchOptions{Trials: 1, Sparse: true, Pool: true}, []string{"git fetch", "git worktree add --no-checkout", "sparse checkout of 1 dir(s)", "total"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Bench(context.Background(), cfg, "mono", tt.opts, nil)
+			if err != nil {
+				t.Fatalf("Bench() error: %v", err)
+			}
+			if res.Trials != tt.opts.Trials || len(res.Phases) != len(tt.phases) {
+				t.Fatalf("Bench() = %+v; want %d trial(s) of phases %v", res, tt.opts.Trials, tt.phases)
+			}
+			for i, p := range res.Phases {
+				if p.Name != tt.phases[i] || p.Min <= 0 || p.Min > p.P50 || p.P50 > p.Max {
+					t.Errorf("phase %d = %+v; want %s with ordered timings", i, p, tt.phases[i])
+				}
+			}
+			if _, err := os.Stat(wt.BenchDir(base)); !os.IsNotExist(err) {
+				t.Errorf("Bench() left %s behind", wt.BenchDir(base))
+			}
+			if wts, _ := wt.ListForRepo(cfg, "mono"); len(wts) != 0 {
+				t.Errorf("Bench() left worktrees: %+v", wts)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package review
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mgreau/zen/internal/github"
+)
+
+// File kinds counted by Estimate.
+const (
+	KindCode      = "code"
+	KindTest      = "test"
+	KindDocs      = "docs"
+	KindConfig    = "config"
+	KindGenerated = "generated" // lockfiles, vendored and generated code
+)
+
+// Review sizes, smallest first.
+var sizes = []string{"S", "M", "L", "XL"}
+
+// sizeLimits are the upper bounds, in weighted changed lines, of S, M and L.
+var sizeLimits = []int{100, 400, 1000}
+
+// sizeTimes is the rough time to review a PR of each size.
+var sizeTimes = map[string]string{
+	"S":  "under 15 min",
+	"M":  "15-45 min",
+	"L":  "45-90 min",
+	"XL": "90+ min -- consider asking for a split",
+}
+
+// kindWeights scale changed lines by how much reading they take.
+var kindWeights = map[string]float64{
+	KindCode:      1,
+	KindTest:      0.5,
+	KindDocs:      0.3,
+	KindConfig:    0.5,
+	KindGenerated: 0,
+}
+
+// KindStat totals the changes of one kind of file.
+type KindStat struct {
+	Files int `json:"files"`
+	Lines int `json:"lines"` // additions + deletions
+}
+
+// Estimate is the expected effort to review a PR.
+type Estimate struct {
+	Size      string              `json:"size"` // S, M, L or XL
+	Time      string              `json:"time"`
+	Weighted  int                 `json:"weighted_lines"`
+	Files     int                 `json:"files"`
+	Additions int                 `json:"additions"`
+	Deletions int                 `json:"deletions"`
+	Dirs      int                 `json:"dirs"`
+	Kinds     map[string]KindStat `json:"kinds"`
+	HasTests  bool                `json:"has_tests"`
+	Reasons   []string            `json:"reasons"`
+}
+
+// EstimateEffort sizes a review from the PR's changed files. Lines are
+// weighted by file kind (generated files count for nothing, tests and
+// config for half), then the size is bumped for changes spread over many
+// files or directories and for code changes without test changes.
+func EstimateEffort(files []github.FileStat) *Estimate {
+	e := &Estimate{Kinds: make(map[string]KindStat)}
+	dirs := make(map[string]bool)
+	var weighted float64
+	for _, f := range files {
+		kind := FileKind(f.Path)
+		lines := f.Additions + f.Deletions
+		k := e.Kinds[kind]
+		k.Files++
+		k.Lines += lines
+		e.Kinds[kind] = k
+
+		e.Files++
+		e.Additions += f.Additions
+		e.Deletions += f.Deletions
+		weighted += float64(lines) * kindWeights[kind]
+		if kind != KindGenerated {
+			dirs[path.Dir(f.Path)] = true
+		}
+	}
+	e.Weighted = int(weighted + 0.5)
+	e.Dirs = len(dirs)
+	e.HasTests = e.Kinds[KindTest].Files > 0
+
+	level := len(sizeLimits)
+	for i, limit := range sizeLimits {
+		if e.Weighted <= limit {
+			level = i
+			break
+		}
+	}
+	e.Reasons = append(e.Reasons, fmt.Sprintf("%d weighted changed lines (%s)", e.Weighted, kindSummary(e.Kinds)))
+
+	code := e.Kinds[KindCode]
+	if code.Files > 20 || e.Dirs > 10 {
+		level++
+		e.Reasons = append(e.Reasons, fmt.Sprintf("spread over %d code files in %d directories", code.Files, e.Dirs))
+	}
+	if code.Lines > 100 && !e.HasTests {
+		level++
+		e.Reasons = append(e.Reasons, fmt.Sprintf("%d lines of code changed without test changes -- check coverage yourself", code.Lines))
+	} else if e.HasTests {
+		e.Reasons = append(e.Reasons, fmt.Sprintf("includes test changes in %d file(s)", e.Kinds[KindTest].Files))
+	}
+	if g := e.Kinds[KindGenerated]; g.Files > 0 {
+		e.Reasons = append(e.Reasons, fmt.Sprintf("%d generated or lock file(s), %d lines, not counted", g.Files, g.Lines))
+	}
+
+	e.Size = sizes[min(level, len(sizes)-1)]
+	e.Time = sizeTimes[e.Size]
+	return e
+}
+
+// kindSummary lists the changed lines per kind, e.g. "code 120, test 40".
+func kindSummary(kinds map[string]KindStat) string {
+	var parts []string
+	for _, kind := range []string{KindCode, KindTest, KindDocs, KindConfig, KindGenerated} {
+		if k, ok := kinds[kind]; ok {
+			parts = append(parts, fmt.Sprintf("%s %d", kind, k.Lines))
+		}
+	}
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// generatedFiles are lockfiles and other files nobody reviews line by line.
+var generatedFiles = map[string]bool{
+	"go.sum":            true,
+	"package-lock.json": true,
+	"yarn.lock":         true,
+	"pnpm-lock.yaml":    true,
+	"Cargo.lock":        true,
+	"poetry.lock":       true,
+	"Gemfile.lock":      true,
+	"composer.lock":     true,
+	"uv.lock":           true,
+}
+
+// FileKind classifies a changed file by its path.
+func FileKind(p string) string {
+	base := path.Base(p)
+	lower := strings.ToLower(p)
+	ext := path.Ext(base)
+
+	switch {
+	case generatedFiles[base],
+		strings.HasPrefix(lower, "vendor/"), strings.Contains(lower, "/vendor/"),
+		strings.HasPrefix(lower, "third_party/"), strings.Contains(lower, "/third_party/"),
+		strings.HasSuffix(base, ".pb.go"), strings.HasPrefix(base, "zz_generated"),
+		strings.Contains(base, "_generated."), strings.Contains(base, ".generated."),
+		strings.HasSuffix(base, ".min.js"), ext == ".snap", ext == ".golden":
+		return KindGenerated
+	case strings.HasSuffix(base, "_test.go"), strings.HasPrefix(base, "test_") && ext == ".py",
+		strings.Contains(base, ".test."), strings.Contains(base, ".spec."),
+		strings.HasPrefix(lower, "test/"), strings.HasPrefix(lower, "tests/"),
+		strings.Contains(lower, "/test/"), strings.Contains(lower, "/tests/"),
+		strings.Contains(lower, "/testdata/"), strings.HasPrefix(lower, "testdata/"),
+		strings.Contains(lower, "__tests__/"):
+		return KindTest
+	case ext == ".md", ext == ".rst", ext == ".txt", ext == ".adoc",
+		strings.HasPrefix(lower, "docs/"), strings.Contains(lower, "/docs/"):
+		return KindDocs
+	case ext == ".yaml", ext == ".yml", ext == ".json", ext == ".toml", ext == ".ini",
+		ext == ".cfg", ext == ".conf", ext == ".tf", ext == ".hcl",
+		base == "Dockerfile", base == "Makefile", base == "go.mod", base == ".gitignore":
+		return KindConfig
+	}
+	return KindCode
+}
@@ -0,0 +1,76 @@
+package review
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mgreau/zen/internal/github"
+)
+
+func TestFileKind(t *testing.T) {
+	tests := map[string]string{
+		"pkg/sts/exchange.go":             KindCode,
+		"pkg/sts/exchange_test.go":        KindTest,
+		"web/src/app.spec.ts":             KindTest,
+		"tests/test_api.py":               KindTest,
+		"internal/x/testdata/in.json":     KindTest,
+		"README.md":                       KindDocs,
+		"docs/guide/setup.html":           KindDocs,
+		".github/workflows/ci.yaml":       KindConfig,
+		"go.mod":                          KindConfig,
+		"go.sum":                          KindGenerated,
+		"web/package-lock.json":           KindGenerated,
+		"vendor/github.com/x/y/y.go":      KindGenerated,
+		"api/v1/types.pb.go":              KindGenerated,
+		"api/v1/zz_generated.deepcopy.go": KindGenerated,
+	}
+	for p, want := range tests {
+		if got := FileKind(p); got != want {
+			t.Errorf("FileKind(%q) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestEstimateEffort(t *testing.T) {
+	f := func(p string, add, del int) github.FileStat {
+		return github.FileStat{Path: p, Status: "modified", Additions: add, Deletions: del}
+	}
+
+	tests := []struct {
+		name     string
+		files    []github.FileStat
+		wantSize string
+	}{
+		{"small fix with test", []github.FileStat{f("pkg/a.go", 20, 5), f("pkg/a_test.go", 30, 0)}, "S"},
+		{"lockfile churn is free", []github.FileStat{f("go.mod", 2, 2), f("go.sum", 800, 600)}, "S"},
+		{"medium change", []github.FileStat{f("pkg/a.go", 200, 50), f("pkg/a_test.go", 100, 0)}, "M"},
+		{"code without tests bumps", []github.FileStat{f("pkg/a.go", 200, 50)}, "L"},
+		{"huge change", []github.FileStat{f("pkg/a.go", 1500, 200), f("pkg/a_test.go", 400, 0)}, "XL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := EstimateEffort(tt.files)
+			if e.Size != tt.wantSize {
+				t.Errorf("EstimateEffort() size = %s (weighted %d, reasons %v), want %s", e.Size, e.Weighted, e.Reasons, tt.wantSize)
+			}
+			if e.Time == "" || len(e.Reasons) == 0 {
+				t.Errorf("EstimateEffort() = %+v, want a time and reasons", e)
+			}
+		})
+	}
+}
+
+func TestEstimateEffortSpread(t *testing.T) {
+	var files []github.FileStat
+	for i := range 25 {
+		files = append(files, github.FileStat{Path: fmt.Sprintf("pkg/d%d/f.go", i), Additions: 2})
+	}
+	files = append(files, github.FileStat{Path: "pkg/d0/f_test.go", Additions: 10})
+	e := EstimateEffort(files)
+	if e.Size != "M" {
+		t.Errorf("25 small files in 25 dirs: size = %s (reasons %v), want M", e.Size, e.Reasons)
+	}
+	if e.Dirs != 25 || !e.HasTests {
+		t.Errorf("Dirs = %d, HasTests = %v; want 25, true", e.Dirs, e.HasTests)
+	}
+}
@@ -0,0 +1,78 @@
+package review
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/github"
+)
+
+// maxLinked caps the PRs followed from one PR, in case descriptions
+// reference each other in a long chain.
+const maxLinked = 10
+
+// LinkedPR is a PR paired with another one through "Depends on" or
+// "Companion" references in their descriptions.
+type LinkedPR struct {
+	github.PRRef
+	Short string `json:"short_repo,omitempty"` // configured repo, "" when not configured
+	Title string `json:"title,omitempty"`
+}
+
+// FindLinked follows the references of a PR's description, and those of
+// the PRs they name, and returns the PRs found other than the PR itself.
+// PRs of repos missing from the config are returned without a short name
+// or title, and their references are not followed.
+func FindLinked(ctx context.Context, cfg *config.Config, repo string, prNumber int) ([]LinkedPR, error) {
+	client, err := github.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating GitHub client: %w", err)
+	}
+
+	start := github.PRRef{Repo: cfg.RepoFullName(repo), Number: prNumber}
+	seen := map[github.PRRef]bool{start: true}
+	queue := []github.PRRef{start}
+	var linked []LinkedPR
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		details, err := client.GetPRDetails(ctx, ref.Repo, ref.Number)
+		if err != nil {
+			if ref == start {
+				return nil, err
+			}
+			continue
+		}
+		if ref != start {
+			for i := range linked {
+				if linked[i].PRRef == ref {
+					linked[i].Title = details.Title
+				}
+			}
+		}
+		for _, next := range github.LinkedPRs(details.Body, ref.Repo) {
+			if seen[next] || len(linked) >= maxLinked {
+				continue
+			}
+			seen[next] = true
+			l := LinkedPR{PRRef: next}
+			if short := shortName(cfg, next.Repo); short != "" {
+				l.Short = short
+				queue = append(queue, next)
+			}
+			linked = append(linked, l)
+		}
+	}
+	return linked, nil
+}
+
+// shortName returns the configured repo of owner/name, or "".
+func shortName(cfg *config.Config, fullRepo string) string {
+	for name := range cfg.Repos {
+		if cfg.RepoFullName(name) == fullRepo {
+			return name
+		}
+	}
+	return ""
+}
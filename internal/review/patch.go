@@ -0,0 +1,371 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mgreau/zen/internal/audit"
+	"github.com/mgreau/zen/internal/config"
+	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/github"
+	wt "github.com/mgreau/zen/internal/worktree"
+)
+
+// maxPatchSize caps how much of a patch URL is read.
+const maxPatchSize = 16 << 20
+
+// Patch is a diff shared outside a pull request: a local file, a URL or a
+// GitHub Gist.
+type Patch struct {
+	Source  string
+	Content []byte
+	// Subject, Author and Message come from the headers of a git
+	// format-patch patch and are empty for a plain diff.
+	Subject string
+	Author  string
+	Message string
+	Files   []string
+}
+
+// PatchResult holds the output of a successful patch worktree creation.
+type PatchResult struct {
+	WorktreePath string   `json:"worktree_path"`
+	Name         string   `json:"name"`
+	Branch       string   `json:"branch"`
+	Base         string   `json:"base"`
+	Source       string   `json:"source"`
+	Subject      string   `json:"subject,omitempty"`
+	Files        []string `json:"files"`
+}
+
+// LoadPatch reads a patch from src: a gist URL (gist.github.com/<user>/<id>),
+// any other http(s) URL, or a local file. Of a gist's files, the .patch and
+// .diff ones are used when there are any, else all of them.
+func LoadPatch(ctx context.Context, src string) (*Patch, error) {
+	var content []byte
+	switch {
+	case GistID(src) != "":
+		client, err := github.NewClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("creating GitHub client: %w", err)
+		}
+		files, err := client.GetGistFiles(ctx, GistID(src))
+		if err != nil {
+			return nil, err
+		}
+		content = gistPatch(files)
+	case strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://"):
+		var err error
+		if content, err = fetchPatch(ctx, src); err != nil {
+			return nil, err
+		}
+	default:
+		var err error
+		if content, err = os.ReadFile(src); err != nil {
+			return nil, fmt.Errorf("reading patch: %w", err)
+		}
+	}
+
+	p := ParsePatch(content)
+	p.Source = src
+	if len(p.Files) == 0 {
+		return nil, fmt.Errorf("%s is not a patch: no file changes found", src)
+	}
+	return p, nil
+}
+
+func fetchPatch(ctx context.Context, src string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching patch: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching patch: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching patch: %s returned %s", src, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPatchSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("fetching patch: %w", err)
+	}
+	if len(data) > maxPatchSize {
+		return nil, fmt.Errorf("fetching patch: %s is larger than %d MB", src, maxPatchSize>>20)
+	}
+	return data, nil
+}
+
+// gistPatch joins the patch files of a gist, or all its files when none
+// is named *.patch or *.diff.
+func gistPatch(files []github.GistFile) []byte {
+	var picked []github.GistFile
+	for _, f := range files {
+		if ext := path.Ext(f.Name); ext == ".patch" || ext == ".diff" {
+			picked = append(picked, f)
+		}
+	}
+	if len(picked) == 0 {
+		picked = files
+	}
+	var b strings.Builder
+	for _, f := range picked {
+		b.WriteString(f.Content)
+		if !strings.HasSuffix(f.Content, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return []byte(b.String())
+}
+
+var gistIDPattern = regexp.MustCompile(`^[0-9a-f]{20,}$`)
+
+// GistID returns the ID of a gist page URL such as
+// https://gist.github.com/<user>/<id>, or "" for anything else. Raw gist
+// URLs are plain downloads and return "".
+func GistID(src string) string {
+	u, err := url.Parse(src)
+	if err != nil || u.Host != "gist.github.com" {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) > 2 {
+		return ""
+	}
+	id := strings.TrimSuffix(parts[len(parts)-1], ".git")
+	if !gistIDPattern.MatchString(id) {
+		return ""
+	}
+	return id
+}
+
+var patchSubjectPrefix = regexp.MustCompile(`^\[[^\]]*\]\s*`)
+
+// ParsePatch extracts the headers of a git format-patch patch and the
+// files changed by any unified diff.
+func ParsePatch(content []byte) *Patch {
+	p := &Patch{Content: content}
+	lines := strings.Split(string(content), "\n")
+
+	// Headers, then the commit message up to the "---" line
+	inHeaders, inMessage := true, false
+	var message []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "diff ") {
+			break
+		}
+		switch {
+		case inHeaders && strings.HasPrefix(line, "Subject: ") && p.Subject == "":
+			p.Subject = patchSubjectPrefix.ReplaceAllString(strings.TrimPrefix(line, "Subject: "), "")
+		case inHeaders && strings.HasPrefix(line, "From: ") && p.Author == "":
+			p.Author = strings.TrimPrefix(line, "From: ")
+		case inHeaders && line == "" && p.Subject != "":
+			inHeaders, inMessage = false, true
+		case inMessage && line == "---":
+			inMessage = false
+		case inMessage:
+			message = append(message, line)
+		}
+	}
+	p.Message = strings.TrimSpace(strings.Join(message, "\n"))
+
+	seen := make(map[string]bool)
+	add := func(f string) {
+		if f != "" && f != "/dev/null" && !seen[f] {
+			seen[f] = true
+			p.Files = append(p.Files, f)
+		}
+	}
+	for _, line := range lines {
+		// diff --git a/<path> b/<path>
+		if strings.HasPrefix(line, "diff --git ") {
+			if i := strings.LastIndex(line, " b/"); i >= 0 {
+				add(line[i+3:])
+			}
+		}
+	}
+	if len(p.Files) > 0 {
+		return p
+	}
+
+	// Not a git diff: take the paths of the ---/+++ header pairs
+	var minus string
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			minus = diffPath(line[4:])
+		case strings.HasPrefix(line, "+++ ") && minus != "":
+			if plus := diffPath(line[4:]); plus != "/dev/null" {
+				add(plus)
+			} else {
+				add(minus)
+			}
+			minus = ""
+		}
+	}
+	return p
+}
+
+// diffPath returns the file path of a ---/+++ line, without the a/ or b/
+// prefix and the trailing timestamp of non-git diffs.
+func diffPath(s string) string {
+	s, _, _ = strings.Cut(s, "\t")
+	s = strings.TrimSpace(s)
+	if s == "/dev/null" {
+		return s
+	}
+	if i := strings.Index(s, "/"); i == 1 && (s[0] == 'a' || s[0] == 'b') {
+		return s[2:]
+	}
+	return s
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// PatchName derives a worktree name suffix from a patch source: the gist
+// ID's first 8 characters, or the file name without its extension.
+func PatchName(src string) string {
+	if id := GistID(src); id != "" {
+		return id[:8]
+	}
+	base := src
+	if u, err := url.Parse(src); err == nil && u.Scheme != "" {
+		base = u.Path
+	}
+	base = path.Base(filepath.ToSlash(base))
+	base = strings.TrimSuffix(base, path.Ext(base))
+	name := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(base), "-"), "-_")
+	if len(name) > 40 {
+		name = strings.TrimRight(name[:40], "-_")
+	}
+	if name == "" {
+		return "patch"
+	}
+	return name
+}
+
+// PatchWorktreeName returns the directory name of a patch worktree,
+// <repo>-patch-<name>.
+func PatchWorktreeName(repo, name string) string {
+	return fmt.Sprintf("%s-patch-%s", repo, name)
+}
+
+// CreatePatchWorktree creates a scratch worktree of repoShort on a
+// patch-<name> branch off the default branch of the repo's upstream remote
+// (origin unless configured), applies the patch to its index, and writes
+// CLAUDE.local.md describing the patch. A patch that does not apply
+// removes the worktree again.
+func CreatePatchWorktree(ctx context.Context, cfg *config.Config, repoShort string, patch *Patch, name string, p Progress) (res *PatchResult, err error) {
+	if p == nil {
+		p = noProgress{}
+	}
+	defer func() { p.Done(err) }()
+
+	basePath := cfg.RepoBasePath(repoShort)
+	if basePath == "" {
+		return nil, fmt.Errorf("unknown repo %q -- check %s", repoShort, config.Path())
+	}
+	originPath := filepath.Join(basePath, repoShort)
+	worktreeName := PatchWorktreeName(repoShort, name)
+	worktreePath := filepath.Join(basePath, worktreeName)
+	branch := "patch-" + name
+	if _, err := os.Stat(worktreePath); err == nil {
+		return nil, fmt.Errorf("worktree already exists: %s\n  Resume with: zen work resume %s\n  Or pick another name with --name", worktreePath, branch)
+	}
+
+	mu := wt.RepoLock(originPath)
+	mu.Lock()
+	timeout := cfg.RepoGitTimeout(repoShort)
+	remote := cfg.RepoUpstream(repoShort)
+	base := wt.RemoteDefaultBranch(originPath, remote)
+
+	p.Step(fmt.Sprintf("git fetch %s in %s", base, repoShort))
+	if _, err := wt.Git(ctx, timeout, originPath, "fetch", remote, strings.TrimPrefix(base, remote+"/")); err != nil {
+		mu.Unlock()
+		return nil, err
+	}
+
+	p.Step(fmt.Sprintf("git worktree add %s (branch %s)", worktreeName, branch))
+	if _, err := wt.Git(ctx, timeout, originPath, "worktree", "add", "--no-checkout", worktreePath, "-b", branch, base); err != nil {
+		wt.CleanupFailedAdd(originPath, worktreePath, branch)
+		mu.Unlock()
+		return nil, err
+	}
+	if _, err := wt.Git(ctx, timeout, worktreePath, "checkout"); err != nil {
+		wt.CleanupFailedAdd(originPath, worktreePath, branch)
+		mu.Unlock()
+		return nil, err
+	}
+
+	lockFile := filepath.Join(originPath, ".git", "worktrees", worktreeName, "index.lock")
+	wt.RemoveStaleLock(lockFile, worktreeName)
+	if err := wt.Exclude(worktreePath, cfg.GitExcludes()); err != nil {
+		p.Info(fmt.Sprintf("Warning: failed to update info/exclude: %v", err))
+	}
+	wt.InitExtras(ctx, cfg, repoShort, worktreePath, p)
+	mu.Unlock()
+
+	p.Step(fmt.Sprintf("git apply (%d file(s))", len(patch.Files)))
+	if err := applyPatch(ctx, timeout, worktreePath, patch.Content); err != nil {
+		mu.Lock()
+		wt.CleanupFailedAdd(originPath, worktreePath, branch)
+		mu.Unlock()
+		return nil, fmt.Errorf("patch does not apply on %s: %w", base, err)
+	}
+	baseSHA, _ := wt.Git(ctx, timeout, worktreePath, "rev-parse", "HEAD")
+	audit.Record(audit.Event{Action: audit.WorktreeCreated, Repo: repoShort, Target: worktreePath, Detail: "patch " + patch.Source})
+
+	p.Step("Inject patch context into CLAUDE.local.md")
+	if err := ctxpkg.WritePatchContext(worktreePath, ctxpkg.PatchContext{
+		Source:  patch.Source,
+		Subject: patch.Subject,
+		Author:  patch.Author,
+		Message: patch.Message,
+		Base:    base,
+		BaseSHA: baseSHA,
+		Files:   patch.Files,
+	}); err != nil {
+		p.Info(fmt.Sprintf("Warning: failed to inject context: %v", err))
+	}
+
+	return &PatchResult{
+		WorktreePath: worktreePath,
+		Name:         worktreeName,
+		Branch:       branch,
+		Base:         base,
+		Source:       patch.Source,
+		Subject:      patch.Subject,
+		Files:        patch.Files,
+	}, nil
+}
+
+// applyPatch applies content to the index and working tree at dir,
+// falling back to a three-way merge when the patch's base differs.
+func applyPatch(ctx context.Context, timeout time.Duration, dir string, content []byte) error {
+	f, err := os.CreateTemp("", "zen-*.patch")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	_, err = wt.Git(ctx, timeout, dir, "apply", "--index", "--3way", f.Name())
+	return err
+}
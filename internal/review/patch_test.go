@@ -0,0 +1,111 @@
+package review
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mgreau/zen/internal/github"
+)
+
+const formatPatch = `From 1234567890abcdef Mon Sep 17 00:00:00 2001
+From: Jane Doe <jane@example.com>
+Date: Tue, 3 Mar 2026 10:00:00 +0100
+Subject: [PATCH 1/2] Fix the loader
+
+The loader dropped the last entry.
+
+Signed-off-by: Jane Doe <jane@example.com>
+---
+ pkg/loader.go | 2 +-
+ 1 file changed, 1 insertion(+), 1 deletion(-)
+
+diff --git a/pkg/loader.go b/pkg/loader.go
+index 1111111..2222222 100644
+--- a/pkg/loader.go
++++ b/pkg/loader.go
+@@ -1 +1 @@
+--- dropped
++-- kept
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1 @@
++hi
+`
+
+func TestParsePatchFormatPatch(t *testing.T) {
+	p := ParsePatch([]byte(formatPatch))
+	if p.Subject != "Fix the loader" {
+		t.Errorf("Subject = %q", p.Subject)
+	}
+	if p.Author != "Jane Doe <jane@example.com>" {
+		t.Errorf("Author = %q", p.Author)
+	}
+	if want := "The loader dropped the last entry.\n\nSigned-off-by: Jane Doe <jane@example.com>"; p.Message != want {
+		t.Errorf("Message = %q, want %q", p.Message, want)
+	}
+	if want := []string{"pkg/loader.go", "docs/new.md"}; !slices.Equal(p.Files, want) {
+		t.Errorf("Files = %v, want %v", p.Files, want)
+	}
+}
+
+func TestParsePatchPlainDiff(t *testing.T) {
+	diff := "--- src/old.c\t2026-01-01 10:00:00\n+++ src/old.c\t2026-01-02 10:00:00\n@@ -1 +1 @@\n-a\n+b\n" +
+		"--- a/gone.txt\n+++ /dev/null\n@@ -1 +0,0 @@\n-x\n"
+	p := ParsePatch([]byte(diff))
+	if p.Subject != "" || p.Author != "" {
+		t.Errorf("headers = %q, %q, want none", p.Subject, p.Author)
+	}
+	if want := []string{"src/old.c", "gone.txt"}; !slices.Equal(p.Files, want) {
+		t.Errorf("Files = %v, want %v", p.Files, want)
+	}
+	if p := ParsePatch([]byte("just some notes\n")); len(p.Files) != 0 {
+		t.Errorf("Files of non-patch = %v, want none", p.Files)
+	}
+}
+
+func TestGistID(t *testing.T) {
+	tests := map[string]string{
+		"https://gist.github.com/jane/0123456789abcdef0123":            "0123456789abcdef0123",
+		"https://gist.github.com/0123456789abcdef0123/":                "0123456789abcdef0123",
+		"https://gist.github.com/jane/0123456789abcdef0123/raw/x.pa":   "",
+		"https://gist.githubusercontent.com/jane/0123456789abcdef/raw": "",
+		"https://github.com/jane/0123456789abcdef0123":                 "",
+		"fix.patch": "",
+	}
+	for src, want := range tests {
+		if got := GistID(src); got != want {
+			t.Errorf("GistID(%q) = %q, want %q", src, got, want)
+		}
+	}
+}
+
+func TestPatchName(t *testing.T) {
+	tests := map[string]string{
+		"/tmp/0001-Fix-the-loader.patch":                    "0001-fix-the-loader",
+		"https://example.com/patches/Some%20Fix.diff?x=1":   "some-fix",
+		"https://gist.github.com/jane/0123456789abcdef0123": "01234567",
+		"../x.y/.patch": "patch",
+		"a-very-long-name-that-goes-on-and-on-and-on-forever": "a-very-long-name-that-goes-on-and-on-and",
+	}
+	for src, want := range tests {
+		if got := PatchName(src); got != want {
+			t.Errorf("PatchName(%q) = %q, want %q", src, got, want)
+		}
+	}
+}
+
+func TestGistPatch(t *testing.T) {
+	files := []github.GistFile{
+		{Name: "README.md", Content: "notes"},
+		{Name: "a.patch", Content: "A"},
+		{Name: "b.diff", Content: "B\n"},
+	}
+	if got := string(gistPatch(files)); got != "A\nB\n" {
+		t.Errorf("gistPatch = %q, want only the patch files", got)
+	}
+	if got := string(gistPatch(files[:1])); got != "notes\n" {
+		t.Errorf("gistPatch without patch files = %q, want all files", got)
+	}
+}
@@ -6,54 +6,79 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
-	"time"
 
 	ctxpkg "github.com/mgreau/zen/internal/context"
+	"github.com/mgreau/zen/internal/audit"
 	"github.com/mgreau/zen/internal/config"
 	"github.com/mgreau/zen/internal/github"
 	"github.com/mgreau/zen/internal/prcache"
 	wt "github.com/mgreau/zen/internal/worktree"
 )
 
-// gitTimeout is the maximum time allowed for a single git subprocess.
-const gitTimeout = 2 * time.Minute
-
 // Result holds the output of a successful worktree creation.
 type Result struct {
 	WorktreePath string `json:"worktree_path"`
 	PRNumber     int    `json:"pr_number"`
 	Title        string `json:"title"`
 	Author       string `json:"author"`
+	ReadOnly     bool   `json:"read_only,omitempty"` // commits and pushes are blocked
+}
+
+// Progress receives step-by-step progress during worktree creation. CLI
+// callers pass a *ui.Steps; MCP callers pass nil to avoid stdout pollution.
+type Progress interface {
+	Step(name string) // ends the current step and starts the next
+	Info(msg string)  // reports a message within the current step
+	Done(err error)   // ends the current step
 }
 
-// Logger is called for progress messages. CLI callers pass ui.LogInfo;
-// MCP callers pass nil or a no-op to avoid stdout pollution.
-type Logger func(msg string)
+type noProgress struct{}
 
-func noop(string) {}
+func (noProgress) Step(string) {}
+func (noProgress) Info(string) {}
+func (noProgress) Done(error)  {}
+
+// Options tunes how a review worktree is created.
+type Options struct {
+	// Sparse limits the checkout to the directories touched by the PR plus
+	// the repo's sparse_include dirs.
+	Sparse bool
+	// Full ignores the repo's fetch_depth and fetch_filter and fetches the
+	// PR with complete history.
+	Full bool
+	// Suffix creates an extra checkout of the PR, <repo>-pr-<n>-<suffix>
+	// on branch pr-<n>-<suffix>, next to its main review worktree.
+	Suffix string
+	// ReadOnly blocks commits and pushes from the worktree until
+	// zen review unlock.
+	ReadOnly bool
+}
 
 // CreateWorktree creates a PR review worktree. It fetches the PR branch,
 // creates the git worktree, injects CLAUDE.local.md context, and caches
-// PR metadata. Returns the result or an error.
+// PR metadata. With opts.Sparse the worktree is created with cone-mode
+// sparse-checkout, and with opts.ReadOnly it refuses commits. If a git
+// step fails after the worktree was added, the partial worktree and its
+// branch are removed. Returns the result or an error.
 //
 // If the worktree already exists, returns a Result with the existing path.
 // The caller is responsible for detecting the repo if repoShort is empty.
-func CreateWorktree(ctx context.Context, cfg *config.Config, repoShort string, prNumber int, log Logger) (*Result, error) {
-	if log == nil {
-		log = noop
+func CreateWorktree(ctx context.Context, cfg *config.Config, repoShort string, prNumber int, opts Options, p Progress) (res *Result, err error) {
+	if p == nil {
+		p = noProgress{}
 	}
+	defer func() { p.Done(err) }()
 
 	basePath := cfg.RepoBasePath(repoShort)
 	if basePath == "" {
-		return nil, fmt.Errorf("unknown repo %q -- check ~/.zen/config.yaml", repoShort)
+		return nil, fmt.Errorf("unknown repo %q -- check %s", repoShort, config.Path())
 	}
 	fullRepo := cfg.RepoFullName(repoShort)
 
 	originPath := filepath.Join(basePath, repoShort)
-	worktreeName := fmt.Sprintf("%s-pr-%d", repoShort, prNumber)
+	worktreeName := wt.PRName(repoShort, prNumber, opts.Suffix)
 	worktreePath := filepath.Join(basePath, worktreeName)
 
 	// If worktree already exists, return it
@@ -73,7 +98,7 @@ func CreateWorktree(ctx context.Context, cfg *config.Config, repoShort string, p
 	}
 
 	// Fetch PR details from GitHub
-	log(fmt.Sprintf("Fetching PR #%d from %s...", prNumber, fullRepo))
+	p.Step(fmt.Sprintf("Fetch PR #%d details from %s", prNumber, fullRepo))
 	client, err := github.NewClient(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("creating GitHub client: %w", err)
@@ -83,108 +108,212 @@ func CreateWorktree(ctx context.Context, cfg *config.Config, repoShort string, p
 		return nil, fmt.Errorf("fetching PR details: %w", err)
 	}
 
-	log(fmt.Sprintf("PR #%d: %s (by %s)", prNumber, details.Title, details.Author))
+	p.Info(fmt.Sprintf("PR #%d: %s (by %s)", prNumber, details.Title, details.Author))
+
+	var sparseDirs []string
+	if opts.Sparse {
+		files, err := client.GetPRFiles(ctx, fullRepo, prNumber)
+		if err != nil {
+			return nil, fmt.Errorf("fetching PR files for sparse checkout: %w", err)
+		}
+		sparseDirs = wt.SparseDirs(files, cfg.RepoSparseInclude(repoShort))
+	}
 
 	// Create worktree under lock
-	branchName := fmt.Sprintf("pr-%d", prNumber)
-
-	wt.GitMu.Lock()
-
-	log(fmt.Sprintf("Fetching pull/%d/head...", prNumber))
-	gitCtx, cancel := context.WithTimeout(ctx, gitTimeout)
-	fetchCmd := exec.CommandContext(gitCtx, "git", "fetch", "origin", fmt.Sprintf("+pull/%d/head:%s", prNumber, branchName))
-	fetchCmd.Dir = originPath
-	if out, err := fetchCmd.CombinedOutput(); err != nil {
-		cancel()
-		wt.GitMu.Unlock()
-		if gitCtx.Err() == context.DeadlineExceeded {
-			return nil, fmt.Errorf("git fetch timed out after %s", gitTimeout)
+	branchName := wt.PRBranch(prNumber, opts.Suffix)
+
+	mu := wt.RepoLock(originPath)
+	mu.Lock()
+
+	timeout := cfg.RepoGitTimeout(repoShort)
+
+	p.Step(fmt.Sprintf("git fetch pull/%d/head", prNumber))
+	fetchArgs := wt.FetchArgs(originPath, fmt.Sprintf("+pull/%d/head:%s", prNumber, branchName), wt.FetchOptions{
+		Depth:  cfg.RepoFetchDepth(repoShort),
+		Filter: cfg.RepoFetchFilter(repoShort),
+		Full:   opts.Full,
+		Remote: cfg.RepoUpstream(repoShort),
+	})
+	if _, err := wt.Git(ctx, timeout, originPath, fetchArgs...); err != nil {
+		mu.Unlock()
+		return nil, err
+	}
+
+	// A pooled worktree is already checked out at origin/main, so claiming
+	// one only rewrites the files the PR differs in
+	claimed := false
+	if !opts.Sparse && cfg.RepoPoolSize(repoShort) > 0 {
+		p.Step(fmt.Sprintf("Claim pooled worktree as %s", worktreeName))
+		claimed, err = wt.ClaimPooled(originPath, worktreePath, branchName)
+		if err != nil {
+			if claimed {
+				wt.CleanupFailedAdd(originPath, worktreePath, branchName)
+			}
+			mu.Unlock()
+			return nil, fmt.Errorf("claiming pooled worktree: %w", err)
 		}
-		return nil, fmt.Errorf("git fetch: %w: %s", err, string(out))
-	}
-	cancel()
-
-	log(fmt.Sprintf("Creating worktree %s...", worktreeName))
-	gitCtx, cancel = context.WithTimeout(ctx, gitTimeout)
-	wtCmd := exec.CommandContext(gitCtx, "git", "worktree", "add", worktreePath, branchName)
-	wtCmd.Dir = originPath
-	if out, err := wtCmd.CombinedOutput(); err != nil {
-		cancel()
-		wt.GitMu.Unlock()
-		if gitCtx.Err() == context.DeadlineExceeded {
-			return nil, fmt.Errorf("git worktree add timed out after %s", gitTimeout)
+		if !claimed {
+			p.Info("Pool is empty, adding a new worktree")
+		}
+	}
+
+	if !claimed {
+		p.Step(fmt.Sprintf("git worktree add %s", worktreeName))
+		addArgs := []string{"worktree", "add", worktreePath, branchName}
+		if opts.Sparse {
+			addArgs = []string{"worktree", "add", "--no-checkout", worktreePath, branchName}
+		}
+		if _, err := wt.Git(ctx, timeout, originPath, addArgs...); err != nil {
+			wt.CleanupFailedAdd(originPath, worktreePath, branchName)
+			mu.Unlock()
+			return nil, err
+		}
+	}
+
+	if opts.Sparse {
+		p.Step(fmt.Sprintf("Sparse checkout of %d dir(s)", len(sparseDirs)))
+		if err := wt.ApplySparseCheckout(ctx, timeout, worktreePath, sparseDirs); err != nil {
+			wt.CleanupFailedAdd(originPath, worktreePath, branchName)
+			mu.Unlock()
+			return nil, err
+		}
+		if _, err := wt.Git(ctx, timeout, worktreePath, "checkout", branchName); err != nil {
+			wt.CleanupFailedAdd(originPath, worktreePath, branchName)
+			mu.Unlock()
+			return nil, err
 		}
-		return nil, fmt.Errorf("git worktree add: %w: %s", err, string(out))
 	}
-	cancel()
 
 	// Clean stale index.lock (only if holding process is dead)
 	lockFile := filepath.Join(originPath, ".git", "worktrees", worktreeName, "index.lock")
 	wt.RemoveStaleLock(lockFile, worktreeName)
 
-	wt.GitMu.Unlock()
+	// Keep CLAUDE.local.md and the like out of git status
+	if err := wt.Exclude(worktreePath, cfg.GitExcludes()); err != nil {
+		p.Info(fmt.Sprintf("Warning: failed to update info/exclude: %v", err))
+	}
+
+	wt.InitExtras(ctx, cfg, repoShort, worktreePath, p)
+
+	if _, err := ctxpkg.EnsureNotes(worktreePath, ctxpkg.NotesTitle(prNumber)); err != nil {
+		p.Info(fmt.Sprintf("Warning: failed to create %s: %v", ctxpkg.NotesFile, err))
+	}
+
+	readOnly := false
+	if opts.ReadOnly {
+		p.Step("Make worktree read-only")
+		if err := wt.LockReview(worktreePath, prNumber, opts.Suffix); err != nil {
+			p.Info(fmt.Sprintf("Warning: failed to make the worktree read-only: %v", err))
+		} else {
+			readOnly = true
+		}
+	}
+
+	mu.Unlock()
 
 	// Inject PR context into CLAUDE.local.md
-	log("Injecting PR context into CLAUDE.local.md...")
+	p.Step("Inject PR context into CLAUDE.local.md")
 	if err := ctxpkg.InjectPRContext(ctx, worktreePath, fullRepo, prNumber); err != nil {
-		log(fmt.Sprintf("Warning: failed to inject context: %v", err))
+		p.Info(fmt.Sprintf("Warning: failed to inject context: %v", err))
 	}
 
+	audit.Record(audit.Event{Action: audit.WorktreeCreated, Repo: repoShort, Target: worktreePath, Detail: fmt.Sprintf("PR #%d", prNumber)})
+
 	// Cache PR metadata
 	prcache.Set(repoShort, prNumber, details.Title, details.Author)
+	prcache.SetLabels(repoShort, prNumber, details.Labels)
 
 	return &Result{
 		WorktreePath: worktreePath,
 		PRNumber:     prNumber,
 		Title:        details.Title,
 		Author:       details.Author,
+		ReadOnly:     readOnly,
 	}, nil
 }
 
-// DetectRepo tries each configured repo to find which one contains the
-// given PR number. Returns the repo short name or an error.
+// RepoMatch is a PR found in one of the configured repos.
+type RepoMatch struct {
+	github.PRMatch
+	Short string `json:"repo_short"` // configured repo short name
+}
+
+// FindPR looks up prNumber in the given configured repos (short names) with a
+// single GitHub request and returns the repos where it exists.
+func FindPR(ctx context.Context, cfg *config.Config, prNumber int, repos []string) ([]RepoMatch, error) {
+	fullNames := make([]string, len(repos))
+	short := make(map[string]string, len(repos))
+	for i, r := range repos {
+		fullNames[i] = cfg.RepoFullName(r)
+		short[fullNames[i]] = r
+	}
+	found, err := github.FindPR(ctx, fullNames, prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("looking up PR #%d: %w", prNumber, err)
+	}
+	matches := make([]RepoMatch, len(found))
+	for i, m := range found {
+		matches[i] = RepoMatch{PRMatch: m, Short: short[m.Repo]}
+	}
+	return matches, nil
+}
+
+// DetectRepo finds which configured repo (or which repo of the group named
+// by spec, e.g. "@images") contains the given PR number. A recently resolved
+// PR number is answered from state without asking GitHub; otherwise all repos
+// are checked in one request and the answer is remembered.
+// Returns the repo short name or an error.
 // Unlike the CLI version, this does not prompt interactively -- it returns
 // an error if ambiguous.
-func DetectRepo(ctx context.Context, cfg *config.Config, prNumber int) (string, error) {
-	repos := cfg.RepoNames()
+func DetectRepo(ctx context.Context, cfg *config.Config, prNumber int, spec string) (string, error) {
+	repos, err := cfg.ResolveRepos(spec)
+	if err != nil {
+		return "", err
+	}
 	if len(repos) == 1 {
 		return repos[0], nil
 	}
-
-	client, err := github.NewClient(ctx)
-	if err != nil {
-		return "", fmt.Errorf("creating GitHub client: %w", err)
+	if repo, ok := prcache.LookupRepo(prNumber, repos); ok {
+		return repo, nil
 	}
 
-	var matches []string
-	for _, repo := range repos {
-		fullRepo := cfg.RepoFullName(repo)
-		if _, err := client.GetPRDetails(ctx, fullRepo, prNumber); err == nil {
-			matches = append(matches, repo)
-		}
+	matches, err := FindPR(ctx, cfg, prNumber, repos)
+	if err != nil {
+		return "", err
 	}
 
 	switch len(matches) {
 	case 0:
 		return "", fmt.Errorf("PR #%d not found in any configured repo", prNumber)
 	case 1:
-		return matches[0], nil
+		prcache.RememberRepo(prNumber, matches[0].Short)
+		return matches[0].Short, nil
 	default:
-		// Try reviewer heuristic
-		currentUser, _ := github.GetCurrentUser(ctx)
-		if currentUser != "" {
-			var reviewMatches []string
-			for _, repo := range matches {
-				fullRepo := cfg.RepoFullName(repo)
-				if ok, _ := client.IsRequestedReviewer(ctx, fullRepo, prNumber, currentUser); ok {
-					reviewMatches = append(reviewMatches, repo)
-				}
-			}
-			if len(reviewMatches) == 1 {
-				return reviewMatches[0], nil
-			}
+		// Prefer the one repo where the user is a requested reviewer
+		if m, ok := ViewerRequested(matches); ok {
+			prcache.RememberRepo(prNumber, m.Short)
+			return m.Short, nil
+		}
+		names := make([]string, len(matches))
+		for i, m := range matches {
+			names[i] = m.Short
 		}
 		return "", fmt.Errorf("PR #%d exists in multiple repos (%s) -- specify with repo parameter",
-			prNumber, strings.Join(matches, ", "))
+			prNumber, strings.Join(names, ", "))
+	}
+}
+
+// ViewerRequested returns the match where the current user is a requested
+// reviewer, if there is exactly one.
+func ViewerRequested(matches []RepoMatch) (RepoMatch, bool) {
+	var found []RepoMatch
+	for _, m := range matches {
+		if m.ViewerRequested {
+			found = append(found, m)
+		}
+	}
+	if len(found) == 1 {
+		return found[0], true
 	}
+	return RepoMatch{}, false
 }
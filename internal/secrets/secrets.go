@@ -0,0 +1,125 @@
+// Package secrets resolves credential references in the config, so
+// webhook URLs and tokens don't have to be stored in plaintext YAML:
+//
+//	env://NAME              the environment variable NAME
+//	secret://keychain/NAME  the macOS Keychain generic password NAME
+//
+// Any other value is used as is.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+const (
+	envPrefix      = "env://"
+	keychainPrefix = "secret://keychain/"
+	secretPrefix   = "secret://"
+)
+
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsRef reports whether s is a secret reference rather than a literal
+// value.
+func IsRef(s string) bool {
+	return strings.HasPrefix(s, envPrefix) || strings.HasPrefix(s, secretPrefix)
+}
+
+// Validate checks the syntax of s when it is a reference, without looking
+// the secret up.
+func Validate(s string) error {
+	switch {
+	case strings.HasPrefix(s, envPrefix):
+		if name := strings.TrimPrefix(s, envPrefix); !envName.MatchString(name) {
+			return fmt.Errorf("invalid secret reference %q: %q is not an environment variable name", s, name)
+		}
+	case strings.HasPrefix(s, keychainPrefix):
+		if strings.TrimPrefix(s, keychainPrefix) == "" {
+			return fmt.Errorf("invalid secret reference %q: missing keychain item name", s)
+		}
+	case strings.HasPrefix(s, secretPrefix):
+		store, _, _ := strings.Cut(strings.TrimPrefix(s, secretPrefix), "/")
+		return fmt.Errorf("invalid secret reference %q: unknown store %q, must be keychain", s, store)
+	}
+	return nil
+}
+
+// Resolve returns the value s refers to, or s itself when it is not a
+// reference. A reference to a missing secret is an error, never an empty
+// value.
+func Resolve(ctx context.Context, s string) (string, error) {
+	if err := Validate(s); err != nil {
+		return "", err
+	}
+	switch {
+	case strings.HasPrefix(s, envPrefix):
+		name := strings.TrimPrefix(s, envPrefix)
+		v, ok := os.LookupEnv(name)
+		if !ok || v == "" {
+			return "", fmt.Errorf("%s: environment variable %s is not set", s, name)
+		}
+		return v, nil
+	case strings.HasPrefix(s, keychainPrefix):
+		v, err := keychainLookup(ctx, strings.TrimPrefix(s, keychainPrefix))
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", s, err)
+		}
+		return v, nil
+	}
+	return s, nil
+}
+
+// ResolveMap returns m with every value resolved.
+func ResolveMap(ctx context.Context, m map[string]string) (map[string]string, error) {
+	if len(m) == 0 {
+		return m, nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		r, err := Resolve(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = r
+	}
+	return out, nil
+}
+
+// ErrUnsupported is returned for Keychain secrets off macOS.
+var ErrUnsupported = errors.New("the keychain secret store is only available on macOS")
+
+// keychainLookup reads a Keychain item, swappable in tests.
+var keychainLookup = func(ctx context.Context, name string) (string, error) {
+	if runtime.GOOS != "darwin" {
+		return "", ErrUnsupported
+	}
+	out, err := exec.CommandContext(ctx, "security", "find-generic-password", "-s", name, "-w").Output()
+	if err != nil {
+		return "", fmt.Errorf("keychain item %q not found (add it with zen secret set %s)", name, name)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// Store saves a Keychain item, prompting for its value on the terminal so
+// it never appears in the process list or shell history. An existing item
+// of the same name is updated.
+func Store(name string) error {
+	if runtime.GOOS != "darwin" {
+		return ErrUnsupported
+	}
+	if name == "" {
+		return fmt.Errorf("missing keychain item name")
+	}
+	cmd := exec.Command("security", "add-generic-password", "-U", "-a", "zen", "-s", name, "-w")
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,62 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	t.Setenv("ZEN_TEST_TOKEN", "s3cret")
+	t.Setenv("ZEN_TEST_EMPTY", "")
+	orig := keychainLookup
+	t.Cleanup(func() { keychainLookup = orig })
+	keychainLookup = func(ctx context.Context, name string) (string, error) {
+		if name == "slack-webhook" {
+			return "https://hooks.slack.com/services/T/B/X", nil
+		}
+		return "", fmt.Errorf("keychain item %q not found", name)
+	}
+
+	tests := []struct {
+		in, want, err string
+	}{
+		{in: "https://example.com/hook", want: "https://example.com/hook"},
+		{in: "", want: ""},
+		{in: "env://ZEN_TEST_TOKEN", want: "s3cret"},
+		{in: "env://ZEN_TEST_EMPTY", err: "not set"},
+		{in: "env://ZEN_TEST_MISSING", err: "not set"},
+		{in: "env://not-a-name", err: "not an environment variable name"},
+		{in: "secret://keychain/slack-webhook", want: "https://hooks.slack.com/services/T/B/X"},
+		{in: "secret://keychain/missing", err: "not found"},
+		{in: "secret://keychain/", err: "missing keychain item name"},
+		{in: "secret://vault/token", err: `unknown store "vault"`},
+	}
+	for _, tt := range tests {
+		got, err := Resolve(context.Background(), tt.in)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Resolve(%q) error = %v; want it to contain %q", tt.in, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Resolve(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestResolveMap(t *testing.T) {
+	t.Setenv("ZEN_TEST_TOKEN", "s3cret")
+	got, err := ResolveMap(context.Background(), map[string]string{
+		"Authorization": "env://ZEN_TEST_TOKEN",
+		"X-Team":        "infra",
+	})
+	if err != nil || got["Authorization"] != "s3cret" || got["X-Team"] != "infra" {
+		t.Errorf("ResolveMap() = %v, %v", got, err)
+	}
+	if _, err := ResolveMap(context.Background(), map[string]string{"A": "env://ZEN_TEST_MISSING"}); err == nil {
+		t.Error("ResolveMap() with a missing secret: want an error")
+	}
+}
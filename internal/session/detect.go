@@ -20,13 +20,23 @@ type Session struct {
 	ModHuman string `json:"modified"`
 	Size     int64  `json:"size"`
 	SizeStr  string `json:"size_str"`
+	Name     string `json:"name,omitempty"` // set by ApplyNames
+}
+
+// ClaudeDir returns Claude Code's config directory: $CLAUDE_CONFIG_DIR if
+// set, as Claude Code itself honors it, otherwise ~/.claude.
+func ClaudeDir() string {
+	if dir := os.Getenv("CLAUDE_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.Getenv("HOME"), ".claude")
 }
 
 // FindSessions finds Claude sessions for a worktree path by scanning
 // ~/.claude/projects/<encoded-path>/*.jsonl files.
 func FindSessions(worktreePath string) ([]Session, error) {
 	projectDirName := pathToClaudeProject(worktreePath)
-	claudeDir := filepath.Join(os.Getenv("HOME"), ".claude", "projects", projectDirName)
+	claudeDir := filepath.Join(ClaudeDir(), "projects", projectDirName)
 
 	entries, err := os.ReadDir(claudeDir)
 	if err != nil {
@@ -49,7 +59,7 @@ func FindSessions(worktreePath string) ([]Session, error) {
 			Modified: info.ModTime().Unix(),
 			ModHuman: info.ModTime().Format("2006-01-02 15:04"),
 			Size:     info.Size(),
-			SizeStr:  formatSize(info.Size()),
+			SizeStr:  FormatSize(info.Size()),
 		})
 	}
 
@@ -71,7 +81,7 @@ func HasActiveSession(worktreePath string) bool {
 // ProjectDir returns the Claude projects directory for a worktree path.
 // Returns empty string if the directory doesn't exist.
 func ProjectDir(worktreePath string) string {
-	dir := filepath.Join(os.Getenv("HOME"), ".claude", "projects", pathToClaudeProject(worktreePath))
+	dir := filepath.Join(ClaudeDir(), "projects", pathToClaudeProject(worktreePath))
 	if _, err := os.Stat(dir); err != nil {
 		return ""
 	}
@@ -85,8 +95,11 @@ func pathToClaudeProject(path string) string {
 	return strings.NewReplacer("/", "-", ".", "-").Replace(path)
 }
 
-func formatSize(bytes int64) string {
+// FormatSize formats a byte count for display, e.g. "12MB".
+func FormatSize(bytes int64) string {
 	switch {
+	case bytes > 1<<30:
+		return fmt.Sprintf("%.1fGB", float64(bytes)/(1<<30))
 	case bytes > 1048576:
 		return fmt.Sprintf("%dMB", bytes/1048576)
 	case bytes > 1024:
@@ -138,7 +151,7 @@ const tailSize = 64 * 1024
 // SessionFilePath returns the full filesystem path for a session .jsonl file.
 func SessionFilePath(worktreePath, sessionID string) string {
 	projectDirName := pathToClaudeProject(worktreePath)
-	return filepath.Join(os.Getenv("HOME"), ".claude", "projects", projectDirName, sessionID+".jsonl")
+	return filepath.Join(ClaudeDir(), "projects", projectDirName, sessionID+".jsonl")
 }
 
 // IsProcessRunning checks if a Claude process is running for the given session ID
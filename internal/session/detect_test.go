@@ -35,13 +35,14 @@ func TestFormatSize(t *testing.T) {
 		{500, "500B"},
 		{1025, "1KB"},
 		{1048577, "1MB"},
+		{3 << 29, "1.5GB"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
-			got := formatSize(tt.bytes)
+			got := FormatSize(tt.bytes)
 			if got != tt.want {
-				t.Errorf("formatSize(%d) = %q, want %q", tt.bytes, got, tt.want)
+				t.Errorf("FormatSize(%d) = %q, want %q", tt.bytes, got, tt.want)
 			}
 		})
 	}
@@ -220,3 +221,22 @@ func TestSessionFilePath(t *testing.T) {
 		t.Errorf("SessionFilePath() = %q, want %q", got, want)
 	}
 }
+
+func TestClaudeDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	t.Setenv("CLAUDE_CONFIG_DIR", "")
+	if got, want := ClaudeDir(), filepath.Join(tmpDir, ".claude"); got != want {
+		t.Errorf("ClaudeDir() = %q, want %q", got, want)
+	}
+
+	custom := filepath.Join(tmpDir, "claude-work")
+	t.Setenv("CLAUDE_CONFIG_DIR", custom)
+	if got, want := ClaudeDir(), custom; got != want {
+		t.Errorf("ClaudeDir() with CLAUDE_CONFIG_DIR = %q, want %q", got, want)
+	}
+	if got, want := SessionFilePath("/tmp/wt", "s1"), filepath.Join(custom, "projects", "-tmp-wt", "s1.jsonl"); got != want {
+		t.Errorf("SessionFilePath() = %q, want %q", got, want)
+	}
+}
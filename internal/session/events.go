@@ -0,0 +1,240 @@
+package session
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Event kinds produced by ParseEvents.
+const (
+	EventText       = "text"        // assistant prose
+	EventToolUse    = "tool_use"    // assistant tool call
+	EventToolResult = "tool_result" // result of a tool call
+	EventPrompt     = "prompt"      // user-typed prompt
+)
+
+// Event is one renderable item from a session .jsonl line.
+type Event struct {
+	Time    time.Time   `json:"time"`
+	Kind    string      `json:"kind"`
+	Text    string      `json:"text,omitempty"`
+	Tool    string      `json:"tool,omitempty"`
+	IsError bool        `json:"is_error,omitempty"`
+	Usage   *TokenUsage `json:"usage,omitempty"` // set on the first event of an assistant message
+}
+
+// eventLine is the subset of a session line needed to render events.
+type eventLine struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   *struct {
+		Content json.RawMessage `json:"content"`
+		Usage   *jsonUsage      `json:"usage,omitempty"`
+	} `json:"message,omitempty"`
+}
+
+type contentBlock struct {
+	Type    string          `json:"type"`
+	Text    string          `json:"text"`
+	Name    string          `json:"name"`
+	Input   json.RawMessage `json:"input"`
+	Content json.RawMessage `json:"content"`
+	IsError bool            `json:"is_error"`
+}
+
+// ParseEvents converts one session .jsonl line into zero or more events.
+// Lines other than user and assistant messages yield nothing.
+func ParseEvents(line []byte) []Event {
+	var l eventLine
+	if json.Unmarshal(line, &l) != nil || l.Message == nil {
+		return nil
+	}
+	if l.Type != "user" && l.Type != "assistant" {
+		return nil
+	}
+
+	var blocks []contentBlock
+	var plain string
+	if json.Unmarshal(l.Message.Content, &plain) == nil {
+		blocks = []contentBlock{{Type: "text", Text: plain}}
+	} else if json.Unmarshal(l.Message.Content, &blocks) != nil {
+		return nil
+	}
+
+	var events []Event
+	for _, b := range blocks {
+		ev := Event{Time: l.Timestamp}
+		switch {
+		case b.Type == "text" && l.Type == "assistant":
+			ev.Kind, ev.Text = EventText, strings.TrimSpace(b.Text)
+		case b.Type == "text":
+			ev.Kind, ev.Text = EventPrompt, strings.TrimSpace(b.Text)
+		case b.Type == "tool_use":
+			ev.Kind, ev.Tool, ev.Text = EventToolUse, b.Name, toolSummary(b.Input)
+		case b.Type == "tool_result":
+			ev.Kind, ev.Text, ev.IsError = EventToolResult, resultText(b.Content), b.IsError
+		default:
+			continue
+		}
+		if ev.Kind != EventToolResult && ev.Text == "" && ev.Tool == "" {
+			continue
+		}
+		events = append(events, ev)
+	}
+
+	if u := l.Message.Usage; u != nil && len(events) > 0 {
+		events[0].Usage = &TokenUsage{
+			InputTokens:              u.InputTokens,
+			OutputTokens:             u.OutputTokens,
+			CacheCreationInputTokens: u.CacheCreationInputTokens,
+			CacheReadInputTokens:     u.CacheReadInputTokens,
+		}
+	}
+	return events
+}
+
+// toolSummaryKeys are the tool input fields that best describe a call,
+// in order of preference.
+var toolSummaryKeys = []string{"command", "file_path", "path", "pattern", "url", "query", "description", "prompt"}
+
+// toolSummary picks a one-line description of a tool call from its input.
+func toolSummary(input json.RawMessage) string {
+	var fields map[string]any
+	if json.Unmarshal(input, &fields) != nil {
+		return ""
+	}
+	for _, k := range toolSummaryKeys {
+		if s, ok := fields[k].(string); ok && s != "" {
+			return firstLine(s)
+		}
+	}
+	return ""
+}
+
+// resultText flattens a tool_result content, which is a string or a list of
+// text blocks.
+func resultText(content json.RawMessage) string {
+	var s string
+	if json.Unmarshal(content, &s) == nil {
+		return strings.TrimSpace(s)
+	}
+	var blocks []contentBlock
+	if json.Unmarshal(content, &blocks) != nil {
+		return ""
+	}
+	var parts []string
+	for _, b := range blocks {
+		if b.Type == "text" {
+			parts = append(parts, b.Text)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + " …"
+	}
+	return s
+}
+
+// SearchEvents returns, in order, up to limit prompts and assistant replies
+// in the session file at path whose text contains term, ignoring case.
+// limit <= 0 means no limit.
+func SearchEvents(path, term string, limit int) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	termLower := strings.ToLower(term)
+	var matches []Event
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		// Cheap pre-filter before decoding; JSON escaping can hide a match
+		// only when term contains characters that need escaping.
+		if len(line) > 0 && strings.Contains(strings.ToLower(string(line)), termLower) {
+			for _, ev := range ParseEvents(line) {
+				if (ev.Kind == EventPrompt || ev.Kind == EventText) && strings.Contains(strings.ToLower(ev.Text), termLower) {
+					matches = append(matches, ev)
+					if limit > 0 && len(matches) >= limit {
+						return matches, nil
+					}
+				}
+			}
+		}
+		if err == io.EOF {
+			return matches, nil
+		}
+		if err != nil {
+			return matches, err
+		}
+	}
+}
+
+// tailPollInterval is how often FollowEvents checks the file for new lines.
+const tailPollInterval = 500 * time.Millisecond
+
+// FollowEvents calls fn for every event in the session file, starting with
+// the last `last` events already written (all of them if last < 0), then
+// polls for appended lines until ctx is cancelled.
+func FollowEvents(ctx context.Context, path string, last int, fn func(Event)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	var backlog []Event
+	var partial string
+	readLines := func(emit func(Event)) {
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				// Keep an incomplete trailing line until the rest is written
+				partial += line
+				return
+			}
+			line, partial = partial+line, ""
+			for _, ev := range ParseEvents([]byte(line)) {
+				emit(ev)
+			}
+		}
+	}
+
+	readLines(func(ev Event) { backlog = append(backlog, ev) })
+	if last >= 0 && len(backlog) > last {
+		backlog = backlog[len(backlog)-last:]
+	}
+	for _, ev := range backlog {
+		fn(ev)
+	}
+
+	ticker := time.NewTicker(tailPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// A shrunk file was rewritten; start over from the top
+			if info, err := f.Stat(); err == nil {
+				if pos, err := f.Seek(0, io.SeekCurrent); err == nil && info.Size() < pos {
+					f.Seek(0, io.SeekStart)
+					reader.Reset(f)
+					partial = ""
+				}
+			}
+			readLines(fn)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package session
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseEvents(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []Event
+	}{
+		{
+			name: "assistant text and tool call",
+			line: `{"type":"assistant","message":{"content":[{"type":"text","text":"Looking at the diff."},{"type":"tool_use","name":"Bash","input":{"command":"go test ./...\nsecond"}}],"usage":{"input_tokens":10,"output_tokens":5}}}`,
+			want: []Event{
+				{Kind: EventText, Text: "Looking at the diff.", Usage: &TokenUsage{InputTokens: 10, OutputTokens: 5}},
+				{Kind: EventToolUse, Tool: "Bash", Text: "go test ./... …"},
+			},
+		},
+		{
+			name: "user prompt as string",
+			line: `{"type":"user","message":{"role":"user","content":"review this PR"}}`,
+			want: []Event{{Kind: EventPrompt, Text: "review this PR"}},
+		},
+		{
+			name: "tool result error",
+			line: `{"type":"user","message":{"content":[{"type":"tool_result","content":[{"type":"text","text":"exit 1"}],"is_error":true}]}}`,
+			want: []Event{{Kind: EventToolResult, Text: "exit 1", IsError: true}},
+		},
+		{
+			name: "non-message line",
+			line: `{"type":"summary","summary":"x"}`,
+		},
+		{
+			name: "invalid json",
+			line: `{not json`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseEvents([]byte(tt.line))
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseEvents() = %+v, want %d events", got, len(tt.want))
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				if g.Kind != w.Kind || g.Text != w.Text || g.Tool != w.Tool || g.IsError != w.IsError {
+					t.Errorf("event %d = %+v, want %+v", i, g, w)
+				}
+				if (g.Usage == nil) != (w.Usage == nil) || (g.Usage != nil && *g.Usage != *w.Usage) {
+					t.Errorf("event %d usage = %+v, want %+v", i, g.Usage, w.Usage)
+				}
+			}
+		})
+	}
+}
+
+func TestFollowEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "s.jsonl")
+	os.WriteFile(path, []byte(
+		`{"type":"user","message":{"content":"one"}}`+"\n"+
+			`{"type":"user","message":{"content":"two"}}`+"\n"), 0o644)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got := make(chan string, 10)
+	done := make(chan error)
+	go func() {
+		done <- FollowEvents(ctx, path, 1, func(ev Event) { got <- ev.Text })
+	}()
+
+	expect := func(want string) {
+		t.Helper()
+		select {
+		case text := <-got:
+			if text != want {
+				t.Errorf("event text = %q, want %q", text, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+
+	expect("two")
+
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	f.WriteString(`{"type":"user","message":{"content":"thr`)
+	f.Sync()
+	time.Sleep(2 * tailPollInterval)
+	f.WriteString(`ee"}}` + "\n")
+	f.Close()
+
+	expect("three")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("FollowEvents() error: %v", err)
+	}
+}
+
+func TestSearchEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "s.jsonl")
+	lines := `{"type":"user","message":{"role":"user","content":"Review the Retry logic"}}
+{"type":"assistant","message":{"content":[{"type":"text","text":"The retry loop never backs off."},{"type":"tool_use","name":"Grep","input":{"pattern":"retry"}}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","content":"retry.go:12"}]}}
+{"type":"summary","summary":"retry review"}
+{"type":"assistant","message":{"content":[{"type":"text","text":"Done."}]}}`
+	os.WriteFile(path, []byte(lines), 0o644)
+
+	got, err := SearchEvents(path, "RETRY", 0)
+	if err != nil {
+		t.Fatalf("SearchEvents() error: %v", err)
+	}
+	if len(got) != 2 || got[0].Kind != EventPrompt || got[1].Text != "The retry loop never backs off." {
+		t.Errorf("SearchEvents() = %+v; want the prompt and the assistant reply", got)
+	}
+
+	if got, _ := SearchEvents(path, "retry", 1); len(got) != 1 {
+		t.Errorf("SearchEvents() with limit 1 = %d events", len(got))
+	}
+	if got, _ := SearchEvents(path, "nothing", 0); len(got) != 0 {
+		t.Errorf("SearchEvents(nothing) = %+v", got)
+	}
+}
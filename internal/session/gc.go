@@ -0,0 +1,190 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cwdScanLines bounds how far into a session file StoredSessions looks for
+// the directory the session ran in.
+const cwdScanLines = 200
+
+// StoredSession is a session file in Claude's projects directory, with
+// the directory the session ran in.
+type StoredSession struct {
+	ID      string `json:"id"`
+	Path    string `json:"path"`
+	Project string `json:"project"` // Claude project directory
+	// Dir is the directory the session ran in, from the session file, or
+	// "" when it could not be read.
+	Dir      string    `json:"dir,omitempty"`
+	Modified time.Time `json:"modified"`
+	// Size counts the .jsonl file and the session's own directory of
+	// subagent transcripts and tool results, if any.
+	Size int64 `json:"size"`
+	// Orphaned is set when Dir no longer exists, e.g. the worktree was
+	// deleted.
+	Orphaned bool `json:"orphaned"`
+}
+
+// StoredSessions lists the sessions of the Claude projects for
+// directories under one of roots (e.g. the configured base paths), oldest
+// first.
+func StoredSessions(roots []string) ([]StoredSession, error) {
+	projectsDir := filepath.Join(ClaudeDir(), "projects")
+	entries, err := os.ReadDir(projectsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []StoredSession
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		project := filepath.Join(projectsDir, e.Name())
+		files, err := os.ReadDir(project)
+		if err != nil {
+			continue
+		}
+		// All sessions of a project ran in the same directory, so the
+		// first one that records it answers for the rest
+		dir := ""
+		for _, f := range files {
+			if !f.IsDir() && strings.HasSuffix(f.Name(), ".jsonl") {
+				if dir = sessionDir(filepath.Join(project, f.Name())); dir != "" {
+					break
+				}
+			}
+		}
+		if !underRoot(e.Name(), dir, roots) {
+			continue
+		}
+		orphaned := false
+		if dir != "" {
+			_, err := os.Stat(dir)
+			orphaned = errors.Is(err, os.ErrNotExist)
+		}
+
+		for _, f := range files {
+			if f.IsDir() || !strings.HasSuffix(f.Name(), ".jsonl") {
+				continue
+			}
+			info, err := f.Info()
+			if err != nil {
+				continue
+			}
+			id := strings.TrimSuffix(f.Name(), ".jsonl")
+			sessions = append(sessions, StoredSession{
+				ID:       id,
+				Path:     filepath.Join(project, f.Name()),
+				Project:  project,
+				Dir:      dir,
+				Modified: info.ModTime(),
+				Size:     info.Size() + dirSize(filepath.Join(project, id)),
+				Orphaned: orphaned,
+			})
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Modified.Before(sessions[j].Modified) })
+	return sessions, nil
+}
+
+// underRoot reports whether a project belongs to a directory under one of
+// roots: by the directory its sessions ran in when known, else by its
+// encoded name.
+func underRoot(project, dir string, roots []string) bool {
+	for _, root := range roots {
+		root = filepath.Clean(root)
+		if dir != "" {
+			if dir == root || strings.HasPrefix(dir, root+string(filepath.Separator)) {
+				return true
+			}
+			continue
+		}
+		if enc := pathToClaudeProject(root); project == enc || strings.HasPrefix(project, enc+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// sessionDir returns the working directory recorded in a session file.
+func sessionDir(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	for range cwdScanLines {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 && strings.Contains(string(line), `"cwd"`) {
+			var v struct {
+				Cwd string `json:"cwd"`
+			}
+			if json.Unmarshal(line, &v) == nil && v.Cwd != "" {
+				return v.Cwd
+			}
+		}
+		if err != nil {
+			return ""
+		}
+	}
+	return ""
+}
+
+func dirSize(dir string) int64 {
+	var size int64
+	filepath.WalkDir(dir, func(_ string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// GCFilter selects the sessions to garbage-collect.
+type GCFilter struct {
+	Orphaned bool      // sessions whose directory no longer exists
+	Before   time.Time // sessions last active before this time
+	// Both requires a session to match both Orphaned and Before; by
+	// default matching either is enough.
+	Both bool
+}
+
+// Match reports whether s is selected by f.
+func (f GCFilter) Match(s StoredSession) bool {
+	orphaned := f.Orphaned && s.Orphaned
+	stale := !f.Before.IsZero() && s.Modified.Before(f.Before)
+	if f.Both {
+		return orphaned && stale
+	}
+	return orphaned || stale
+}
+
+// Delete removes the session file and its directory, and the project
+// directory once it is empty.
+func (s StoredSession) Delete() error {
+	if err := os.Remove(s.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(s.Project, s.ID)); err != nil {
+		return err
+	}
+	// Fails, leaving the project, while anything else is in it
+	os.Remove(s.Project)
+	return nil
+}
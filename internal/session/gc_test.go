@@ -0,0 +1,110 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoredSessions(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("CLAUDE_CONFIG_DIR", filepath.Join(tmpDir, ".claude"))
+	base := filepath.Join(tmpDir, "git")
+	live := filepath.Join(base, "app-pr-1")
+	os.MkdirAll(live, 0o755)
+
+	write := func(dir, id, cwd string, age time.Duration) {
+		t.Helper()
+		project := filepath.Join(tmpDir, ".claude", "projects", pathToClaudeProject(dir))
+		os.MkdirAll(project, 0o755)
+		path := filepath.Join(project, id+".jsonl")
+		data := `{"type":"summary"}` + "\n"
+		if cwd != "" {
+			data += `{"type":"user","cwd":"` + cwd + `"}` + "\n"
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mod := time.Now().Add(-age)
+		os.Chtimes(path, mod, mod)
+	}
+	gone := filepath.Join(base, "app-pr-2")
+	write(live, "live-old", live, 40*24*time.Hour)
+	write(live, "live-new", live, time.Hour)
+	write(gone, "gone", gone, 2*time.Hour)
+	write(filepath.Join(tmpDir, "elsewhere"), "other", filepath.Join(tmpDir, "elsewhere"), 90*24*time.Hour)
+	// Sessions without a cwd are matched to roots by project name
+	write(filepath.Join(base, "app-x"), "nocwd", "", time.Hour)
+
+	sessions, err := StoredSessions([]string{base})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]StoredSession)
+	for _, s := range sessions {
+		got[s.ID] = s
+	}
+	if len(got) != 4 {
+		t.Fatalf("StoredSessions() = %v, want the 4 sessions under %s", got, base)
+	}
+	if sessions[0].ID != "live-old" {
+		t.Errorf("first session = %s, want the oldest, live-old", sessions[0].ID)
+	}
+	if !got["gone"].Orphaned || got["live-old"].Orphaned || got["nocwd"].Orphaned {
+		t.Errorf("orphaned: gone=%v live-old=%v nocwd=%v, want only gone",
+			got["gone"].Orphaned, got["live-old"].Orphaned, got["nocwd"].Orphaned)
+	}
+	if got["gone"].Dir != gone {
+		t.Errorf("Dir = %q, want %q", got["gone"].Dir, gone)
+	}
+
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+	tests := []struct {
+		name   string
+		filter GCFilter
+		want   []string
+	}{
+		{"orphaned", GCFilter{Orphaned: true}, []string{"gone"}},
+		{"stale", GCFilter{Before: cutoff}, []string{"live-old"}},
+		{"either", GCFilter{Orphaned: true, Before: cutoff}, []string{"live-old", "gone"}},
+		{"both", GCFilter{Orphaned: true, Before: cutoff, Both: true}, nil},
+	}
+	for _, tt := range tests {
+		var ids []string
+		for _, s := range sessions {
+			if tt.filter.Match(s) {
+				ids = append(ids, s.ID)
+			}
+		}
+		if len(ids) != len(tt.want) || (len(ids) > 0 && (ids[0] != tt.want[0] || ids[len(ids)-1] != tt.want[len(tt.want)-1])) {
+			t.Errorf("%s: matched %v, want %v", tt.name, ids, tt.want)
+		}
+	}
+}
+
+func TestStoredSessionDelete(t *testing.T) {
+	project := t.TempDir()
+	for _, f := range []string{"a.jsonl", "b.jsonl", "a/subagents/x.jsonl"} {
+		os.MkdirAll(filepath.Dir(filepath.Join(project, f)), 0o755)
+		os.WriteFile(filepath.Join(project, f), []byte("{}"), 0o644)
+	}
+	a := StoredSession{ID: "a", Path: filepath.Join(project, "a.jsonl"), Project: project}
+	b := StoredSession{ID: "b", Path: filepath.Join(project, "b.jsonl"), Project: project}
+
+	if err := a.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(project, "a")); !os.IsNotExist(err) {
+		t.Error("session directory a/ was not removed")
+	}
+	if _, err := os.Stat(project); err != nil {
+		t.Error("project removed while b.jsonl is left")
+	}
+	if err := b.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(project); !os.IsNotExist(err) {
+		t.Error("empty project was not removed")
+	}
+}
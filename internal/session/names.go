@@ -0,0 +1,145 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// Bookmark is a friendly name given to a Claude session with zen agent name.
+type Bookmark struct {
+	Name     string    `json:"name"`
+	Worktree string    `json:"worktree"`
+	Named    time.Time `json:"named"`
+}
+
+var namesMu sync.Mutex
+
+func namesPath() string {
+	return filepath.Join(config.StateDir(), "session_names.json")
+}
+
+// Names returns the session bookmarks keyed by session ID.
+func Names() map[string]Bookmark {
+	names := make(map[string]Bookmark)
+	data, err := os.ReadFile(namesPath())
+	if err != nil {
+		return names
+	}
+	if err := json.Unmarshal(data, &names); err != nil {
+		return make(map[string]Bookmark)
+	}
+	return names
+}
+
+// SetName names session id of the worktree at worktreePath. Names are
+// unique within a worktree and can't be plain numbers, which select
+// sessions by position. Bookmarks of session files that no longer exist
+// are dropped.
+func SetName(worktreePath, id, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("session name is empty")
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		return fmt.Errorf("session name %q is a number, which would select a session by position", name)
+	}
+
+	namesMu.Lock()
+	defer namesMu.Unlock()
+	names := Names()
+	for other, b := range names {
+		if other != id && b.Worktree == worktreePath && strings.EqualFold(b.Name, name) {
+			return fmt.Errorf("session %s is already named %q", ShortID(other), b.Name)
+		}
+	}
+	prune(names)
+	names[id] = Bookmark{Name: name, Worktree: worktreePath, Named: time.Now()}
+	return state.WriteJSON(namesPath(), names)
+}
+
+// ClearName removes the name of session id. It reports whether it had one.
+func ClearName(id string) (bool, error) {
+	namesMu.Lock()
+	defer namesMu.Unlock()
+	names := Names()
+	if _, ok := names[id]; !ok {
+		return false, nil
+	}
+	delete(names, id)
+	prune(names)
+	return true, state.WriteJSON(namesPath(), names)
+}
+
+// prune drops the bookmarks of sessions whose file is gone.
+func prune(names map[string]Bookmark) {
+	for id, b := range names {
+		if _, err := os.Stat(SessionFilePath(b.Worktree, id)); os.IsNotExist(err) {
+			delete(names, id)
+		}
+	}
+}
+
+// ApplyNames fills in the Name of each session that has a bookmark.
+func ApplyNames(sessions []Session) {
+	names := Names()
+	for i := range sessions {
+		sessions[i].Name = names[sessions[i].ID].Name
+	}
+}
+
+// Pick selects one of sessions (newest first, with names applied) by ref:
+// empty for the most recent, a 1-based position, a name, or a unique
+// prefix of the session ID. A number is a position when in range.
+func Pick(sessions []Session, ref string) (Session, error) {
+	if len(sessions) == 0 {
+		return Session{}, fmt.Errorf("no sessions")
+	}
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return sessions[0], nil
+	}
+	n, err := strconv.Atoi(ref)
+	if err == nil && n >= 1 && n <= len(sessions) {
+		return sessions[n-1], nil
+	}
+	for _, s := range sessions {
+		if s.Name != "" && strings.EqualFold(s.Name, ref) {
+			return s, nil
+		}
+	}
+	var matches []Session
+	for _, s := range sessions {
+		if strings.HasPrefix(s.ID, ref) {
+			matches = append(matches, s)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		if err == nil {
+			// IDs can start with digits, so only now is it a bad position
+			return Session{}, fmt.Errorf("session index %d out of range (1-%d)", n, len(sessions))
+		}
+		return Session{}, fmt.Errorf("no session named or starting with %q", ref)
+	default:
+		return Session{}, fmt.Errorf("%d sessions start with %q; give more of the ID", len(matches), ref)
+	}
+}
+
+// ShortID returns the first 8 characters of a session ID.
+func ShortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,80 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSessionNames(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("CLAUDE_CONFIG_DIR", filepath.Join(tmpDir, ".claude"))
+	wt := filepath.Join(tmpDir, "git", "app-pr-1")
+	project := filepath.Join(tmpDir, ".claude", "projects", pathToClaudeProject(wt))
+	os.MkdirAll(project, 0o755)
+	for _, id := range []string{"aaaa1111", "aaaa2222", "bbbb3333"} {
+		os.WriteFile(filepath.Join(project, id+".jsonl"), []byte("{}\n"), 0o644)
+	}
+
+	if err := SetName(wt, "aaaa2222", "refactor attempt 2"); err != nil {
+		t.Fatalf("SetName() error: %v", err)
+	}
+	if err := SetName(wt, "bbbb3333", "Refactor Attempt 2"); err == nil {
+		t.Error("SetName() should reject a name already used in the worktree")
+	}
+	if err := SetName(wt, "bbbb3333", "42"); err == nil {
+		t.Error("SetName() should reject a numeric name")
+	}
+	// A bookmark of a deleted session is dropped on the next write
+	os.Remove(filepath.Join(project, "aaaa1111.jsonl"))
+	if err := SetName(wt, "aaaa1111", "gone"); err != nil {
+		t.Fatalf("SetName() error: %v", err)
+	}
+	if err := SetName(wt, "bbbb3333", "baseline"); err != nil {
+		t.Fatalf("SetName() error: %v", err)
+	}
+	if _, ok := Names()["aaaa1111"]; ok {
+		t.Error("bookmark of a deleted session was kept")
+	}
+
+	sessions := []Session{{ID: "bbbb3333"}, {ID: "aaaa2222"}}
+	ApplyNames(sessions)
+	if sessions[1].Name != "refactor attempt 2" {
+		t.Errorf("ApplyNames() name = %q", sessions[1].Name)
+	}
+
+	for ref, want := range map[string]string{
+		"":                   "bbbb3333",
+		"2":                  "aaaa2222",
+		"REFACTOR attempt 2": "aaaa2222",
+		"baseline":           "bbbb3333",
+		"aaaa":               "aaaa2222",
+	} {
+		s, err := Pick(sessions, ref)
+		if err != nil || s.ID != want {
+			t.Errorf("Pick(%q) = %s, %v; want %s", ref, s.ID, err, want)
+		}
+	}
+	for _, ref := range []string{"3", "0", "cccc", "nothing"} {
+		if _, err := Pick(sessions, ref); err == nil {
+			t.Errorf("Pick(%q) should fail", ref)
+		}
+	}
+	digits := []Session{{ID: "1234abcd"}, {ID: "5678abcd"}}
+	if s, err := Pick(digits, "5678"); err != nil || s.ID != "5678abcd" {
+		t.Errorf("Pick(numeric ID prefix) = %s, %v", s.ID, err)
+	}
+
+	sessions = append(sessions, Session{ID: "bbbb4444"})
+	if _, err := Pick(sessions, "bbbb"); err == nil {
+		t.Error("Pick() of an ambiguous prefix should fail")
+	}
+
+	if ok, err := ClearName("aaaa2222"); !ok || err != nil {
+		t.Errorf("ClearName() = %v, %v", ok, err)
+	}
+	if ok, _ := ClearName("aaaa2222"); ok {
+		t.Error("ClearName() twice reported a name")
+	}
+}
@@ -0,0 +1,349 @@
+package session
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Finding kinds.
+const (
+	FindingIssue      = "issue"
+	FindingSuggestion = "suggestion"
+)
+
+// Finding is one issue or suggestion Claude raised in a session.
+type Finding struct {
+	Kind     string `json:"kind"`
+	Severity string `json:"severity,omitempty"` // as stated, e.g. "high" or "nit"
+	Text     string `json:"text"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// FlaggedFile is a file referenced by at least one finding.
+type FlaggedFile struct {
+	Path     string `json:"path"`
+	Findings int    `json:"findings"`
+}
+
+// Report is what a session found, extracted from its transcript.
+type Report struct {
+	SessionID   string        `json:"session_id"`
+	Started     time.Time     `json:"started"`
+	Ended       time.Time     `json:"ended"`
+	Prompts     int           `json:"prompts"`
+	Tokens      TokenUsage    `json:"tokens"`
+	FilesRead   []string      `json:"files_read"`
+	Flagged     []FlaggedFile `json:"files_flagged"`
+	Issues      []Finding     `json:"issues"`
+	Suggestions []Finding     `json:"suggestions"`
+	// Conclusion is Claude's last reply, which for a review usually holds
+	// the verdict.
+	Conclusion string `json:"conclusion,omitempty"`
+	// Notes are the reviewer's own findings from the worktree's
+	// NOTES.zen.md, when asked for.
+	Notes string `json:"notes,omitempty"`
+}
+
+var (
+	headingRe  = regexp.MustCompile(`^(#{1,6}\s+|\*\*[^*]+\*\*:?\s*$)`)
+	bulletRe   = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(.*)$`)
+	severityRe = regexp.MustCompile(`(?i)^(?:\*\*|\[|\()?\s*(critical|blocker|blocking|major|high|medium|moderate|minor|low|nit|nitpick)\b\s*(?:\*\*|\]|\))?\s*[:\-–—]?\s*(?:\*\*)?\s*`)
+	fileRefRe  = regexp.MustCompile("`?((?:[\\w.-]+/)*[\\w-]+\\.[A-Za-z]{1,6})(?::(\\d+))?`?")
+
+	issueWords      = []string{"issue", "bug", "problem", "concern", "blocking", "blocker", "critical", "must fix", "risk"}
+	suggestionWords = []string{"suggestion", "suggest", "nit", "improvement", "recommend", "consider", "optional", "minor", "polish"}
+	issueHints      = []string{"bug", "incorrect", "wrong", "race", "leak", "panic", "nil pointer", "deadlock", "vulnerab", "injection", "breaks", "missing check", "not handled", "unhandled", "off-by-one"}
+	suggestionHints = []string{"consider", "suggest", "could ", "might want", "nit:", "prefer", "would be clearer", "recommend"}
+)
+
+// severityKind maps a stated severity to a finding kind.
+func severityKind(sev string) string {
+	switch strings.ToLower(sev) {
+	case "minor", "low", "nit", "nitpick":
+		return FindingSuggestion
+	default:
+		return FindingIssue
+	}
+}
+
+// sectionKind classifies a heading by its wording, or "" when it names
+// neither issues nor suggestions.
+func sectionKind(heading string) string {
+	h := strings.ToLower(heading)
+	for _, w := range suggestionWords {
+		if strings.Contains(h, w) {
+			return FindingSuggestion
+		}
+	}
+	for _, w := range issueWords {
+		if strings.Contains(h, w) {
+			return FindingIssue
+		}
+	}
+	return ""
+}
+
+// hintKind classifies a bullet outside a recognized section by its wording.
+func hintKind(text string) string {
+	t := strings.ToLower(text)
+	for _, w := range issueHints {
+		if strings.Contains(t, w) {
+			return FindingIssue
+		}
+	}
+	for _, w := range suggestionHints {
+		if strings.Contains(t, w) {
+			return FindingSuggestion
+		}
+	}
+	return ""
+}
+
+// ExtractFindings pulls issues and suggestions out of an assistant reply.
+// Bullets under a heading that names issues or suggestions are taken as
+// such; other bullets only when they state a severity or use wording
+// typical of a review comment.
+func ExtractFindings(text string) []Finding {
+	var findings []Finding
+	section := ""
+	for _, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		m := bulletRe.FindStringSubmatch(line)
+		// A heading, or a line introducing a list, starts a new section
+		if headingRe.MatchString(line) || (m == nil && strings.HasSuffix(line, ":")) {
+			section = sectionKind(line)
+			continue
+		}
+		if m == nil {
+			continue
+		}
+		// Nested bullets belong to their parent finding
+		if indent := len(raw) - len(strings.TrimLeft(raw, " \t")); indent >= 2 {
+			continue
+		}
+
+		item := m[1]
+		f := Finding{}
+		if sm := severityRe.FindStringSubmatch(item); sm != nil {
+			f.Severity = strings.ToLower(sm[1])
+			f.Kind = severityKind(f.Severity)
+			item = item[len(sm[0]):]
+		}
+		if section != "" {
+			f.Kind = section
+		}
+		if f.Kind == "" {
+			f.Kind = hintKind(item)
+		}
+		if f.Kind == "" {
+			continue
+		}
+		f.Text = strings.TrimSpace(item)
+		if fm := fileRefRe.FindStringSubmatch(f.Text); fm != nil && looksLikePath(fm[1]) {
+			f.File = fm[1]
+			f.Line, _ = strconv.Atoi(fm[2])
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// looksLikePath filters file references from version numbers, domains and
+// identifiers such as fmt.Errorf.
+func looksLikePath(s string) bool {
+	if strings.Contains(s, "/") {
+		return true
+	}
+	switch strings.ToLower(filepath.Ext(s)) {
+	case ".go", ".py", ".ts", ".tsx", ".js", ".jsx", ".rs", ".java", ".rb", ".c", ".h", ".cc", ".cpp",
+		".sh", ".yaml", ".yml", ".json", ".toml", ".md", ".tf", ".proto", ".sql", ".mod":
+		return true
+	}
+	return false
+}
+
+// BuildReport reads the session transcript at path and extracts a Report.
+// File paths under root are reported relative to it.
+func BuildReport(path, root string) (*Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := &Report{
+		SessionID:   strings.TrimSuffix(filepath.Base(path), ".jsonl"),
+		FilesRead:   []string{},
+		Flagged:     []FlaggedFile{},
+		Issues:      []Finding{},
+		Suggestions: []Finding{},
+	}
+	read := make(map[string]bool)
+	seen := make(map[string]bool)
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		for _, ev := range ParseEvents(line) {
+			if !ev.Time.IsZero() {
+				if r.Started.IsZero() {
+					r.Started = ev.Time
+				}
+				r.Ended = ev.Time
+			}
+			if ev.Usage != nil {
+				r.Tokens.Add(*ev.Usage)
+			}
+			switch ev.Kind {
+			case EventPrompt:
+				r.Prompts++
+			case EventToolUse:
+				if ev.Tool == "Read" && ev.Text != "" {
+					read[relPath(ev.Text, root)] = true
+				}
+			case EventText:
+				r.Conclusion = ev.Text
+				for _, fd := range ExtractFindings(ev.Text) {
+					// Claude often restates findings in a final summary
+					if key := strings.ToLower(fd.Text); !seen[key] {
+						seen[key] = true
+						if fd.Kind == FindingIssue {
+							r.Issues = append(r.Issues, fd)
+						} else {
+							r.Suggestions = append(r.Suggestions, fd)
+						}
+					}
+				}
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for p := range read {
+		r.FilesRead = append(r.FilesRead, p)
+	}
+	sort.Strings(r.FilesRead)
+
+	counts := make(map[string]int)
+	for _, fd := range append(append([]Finding{}, r.Issues...), r.Suggestions...) {
+		if fd.File != "" {
+			counts[fd.File]++
+		}
+	}
+	for p, n := range counts {
+		r.Flagged = append(r.Flagged, FlaggedFile{Path: p, Findings: n})
+	}
+	sort.Slice(r.Flagged, func(i, j int) bool {
+		if r.Flagged[i].Findings != r.Flagged[j].Findings {
+			return r.Flagged[i].Findings > r.Flagged[j].Findings
+		}
+		return r.Flagged[i].Path < r.Flagged[j].Path
+	})
+	return r, nil
+}
+
+func relPath(p, root string) string {
+	if root != "" {
+		if rel, err := filepath.Rel(root, p); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return p
+}
+
+// Markdown renders the report for a PR comment or an archive. title heads
+// the report, e.g. "Review of PR #42".
+func (r *Report) Markdown(title string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "Session `%s`", r.SessionID)
+	if !r.Started.IsZero() {
+		fmt.Fprintf(&b, ", %s – %s", r.Started.Local().Format("2006-01-02 15:04"), r.Ended.Local().Format("15:04"))
+	}
+	fmt.Fprintf(&b, ". %d issue(s), %d suggestion(s), %d file(s) read.\n", len(r.Issues), len(r.Suggestions), len(r.FilesRead))
+
+	writeFindings := func(heading string, findings []Finding) {
+		if len(findings) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", heading)
+		for _, f := range findings {
+			b.WriteString("- ")
+			if f.Severity != "" {
+				fmt.Fprintf(&b, "**%s**: ", f.Severity)
+			}
+			b.WriteString(f.Text)
+			b.WriteString("\n")
+		}
+	}
+	writeFindings("Issues", r.Issues)
+	writeFindings("Suggestions", r.Suggestions)
+
+	if len(r.Flagged) > 0 {
+		b.WriteString("\n## Files Flagged\n\n")
+		for _, f := range r.Flagged {
+			fmt.Fprintf(&b, "- `%s` (%d)\n", f.Path, f.Findings)
+		}
+	}
+	if r.Conclusion != "" {
+		b.WriteString("\n## Conclusion\n\n")
+		b.WriteString(r.Conclusion)
+		b.WriteString("\n")
+	}
+	if r.Notes != "" {
+		b.WriteString("\n## Reviewer Notes\n\n")
+		b.WriteString(demoteHeadings(r.Notes, 2))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// markdownHeadingRe matches the #s of an ATX heading.
+var markdownHeadingRe = regexp.MustCompile(`^(#{1,6})\s`)
+
+// demoteHeadings nests the Markdown headings of md n levels deeper, up to
+// level 6, leaving fenced code blocks alone.
+func demoteHeadings(md string, n int) string {
+	lines := strings.Split(md, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			for _, c := range trimmed[3:] {
+				if c != rune(fence[0]) {
+					break
+				}
+				fence += string(c)
+			}
+			continue
+		}
+		if m := markdownHeadingRe.FindStringSubmatch(line); m != nil {
+			level := min(len(m[1])+n, 6)
+			lines[i] = strings.Repeat("#", level) + line[len(m[1]):]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
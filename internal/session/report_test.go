@@ -0,0 +1,92 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExtractFindings(t *testing.T) {
+	text := "## Summary\n" +
+		"- Adds retries to the setup loop\n" +
+		"\n" +
+		"## Issues\n" +
+		"1. **High**: `internal/reconciler/setup.go:42` leaks the lock on error\n" +
+		"   - nested detail that is not a finding\n" +
+		"2. Missing test for the retry path\n" +
+		"\n" +
+		"### Nits\n" +
+		"- Rename `x` to `attempts` in watch.go\n" +
+		"\n" +
+		"Other notes:\n" +
+		"- [minor] the log line could include the PR number\n" +
+		"- This uses fmt.Errorf consistently\n"
+
+	got := ExtractFindings(text)
+	want := []Finding{
+		{Kind: FindingIssue, Severity: "high", Text: "`internal/reconciler/setup.go:42` leaks the lock on error", File: "internal/reconciler/setup.go", Line: 42},
+		{Kind: FindingIssue, Text: "Missing test for the retry path"},
+		{Kind: FindingSuggestion, Text: "Rename `x` to `attempts` in watch.go", File: "watch.go"},
+		{Kind: FindingSuggestion, Severity: "minor", Text: "the log line could include the PR number"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ExtractFindings() = %d findings, want %d:\n%+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("finding %d = %+v\nwant %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "abc123.jsonl")
+	lines := []string{
+		`{"type":"user","timestamp":"2026-01-02T10:00:00Z","message":{"content":"/review-pr"}}`,
+		`{"type":"assistant","timestamp":"2026-01-02T10:01:00Z","message":{"content":[{"type":"tool_use","name":"Read","input":{"file_path":"/wt/cmd/watch.go"}}],"usage":{"input_tokens":100,"output_tokens":10}}}`,
+		`{"type":"assistant","timestamp":"2026-01-02T10:05:00Z","message":{"content":[{"type":"text","text":"## Issues\n- cmd/watch.go:10 has a race on seenPRs\n\n## Suggestions\n- Consider a table test"}],"usage":{"input_tokens":200,"output_tokens":50}}}`,
+		`{"type":"assistant","timestamp":"2026-01-02T10:06:00Z","message":{"content":[{"type":"text","text":"**Issues**\n- cmd/watch.go:10 has a race on seenPRs\n\nLooks good otherwise."}]}}`,
+	}
+	os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+
+	r, err := BuildReport(path, "/wt")
+	if err != nil {
+		t.Fatalf("BuildReport() error: %v", err)
+	}
+	if r.SessionID != "abc123" || r.Prompts != 1 {
+		t.Errorf("SessionID, Prompts = %q, %d; want abc123, 1", r.SessionID, r.Prompts)
+	}
+	if r.Tokens.InputTokens != 300 || r.Tokens.OutputTokens != 60 {
+		t.Errorf("Tokens = %+v, want 300/60", r.Tokens)
+	}
+	if len(r.FilesRead) != 1 || r.FilesRead[0] != "cmd/watch.go" {
+		t.Errorf("FilesRead = %v, want [cmd/watch.go]", r.FilesRead)
+	}
+	if len(r.Issues) != 1 || len(r.Suggestions) != 1 {
+		t.Fatalf("Issues, Suggestions = %+v, %+v; want 1 each (restated issue deduplicated)", r.Issues, r.Suggestions)
+	}
+	if len(r.Flagged) != 1 || r.Flagged[0] != (FlaggedFile{Path: "cmd/watch.go", Findings: 1}) {
+		t.Errorf("Flagged = %+v", r.Flagged)
+	}
+	if !strings.HasPrefix(r.Conclusion, "**Issues**") {
+		t.Errorf("Conclusion = %q, want the last reply", r.Conclusion)
+	}
+
+	md := r.Markdown("Review of PR #42")
+	for _, s := range []string{"# Review of PR #42", "## Issues\n\n- cmd/watch.go:10 has a race", "## Suggestions", "- `cmd/watch.go` (1)"} {
+		if !strings.Contains(md, s) {
+			t.Errorf("Markdown() missing %q:\n%s", s, md)
+		}
+	}
+}
+
+func TestReportMarkdownNotes(t *testing.T) {
+	r := &Report{SessionID: "abc", Notes: "## `go test ./...`\n\n```\n# not a heading\n```\n\n###### deep"}
+	md := r.Markdown("Review of PR #42")
+	want := "\n## Reviewer Notes\n\n#### `go test ./...`\n\n```\n# not a heading\n```\n\n###### deep\n"
+	if !strings.HasSuffix(md, want) {
+		t.Errorf("Markdown() = %q; want suffix %q", md, want)
+	}
+}
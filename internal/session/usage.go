@@ -0,0 +1,60 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// Add adds o's counts to u.
+func (u *TokenUsage) Add(o TokenUsage) {
+	u.InputTokens += o.InputTokens
+	u.OutputTokens += o.OutputTokens
+	u.CacheCreationInputTokens += o.CacheCreationInputTokens
+	u.CacheReadInputTokens += o.CacheReadInputTokens
+}
+
+// ModelFamily maps a model identifier to its family ("opus", "sonnet",
+// "haiku"), or to the shortened identifier for any other model.
+func ModelFamily(model string) string {
+	for _, family := range []string{"opus", "sonnet", "haiku"} {
+		if strings.Contains(model, family) {
+			return family
+		}
+	}
+	return ShortenModel(model)
+}
+
+// UsageByModel reads a whole session file and sums token usage per model
+// family, so sessions that switched models mid-way are split correctly.
+func UsageByModel(path string) (map[string]TokenUsage, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	usage := make(map[string]TokenUsage)
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		var jl jsonLine
+		if len(line) > 0 && json.Unmarshal(line, &jl) == nil && jl.Message != nil &&
+			jl.Message.Model != "" && jl.Message.Usage != nil {
+			family := ModelFamily(jl.Message.Model)
+			u := usage[family]
+			u.Add(TokenUsage{
+				InputTokens:              jl.Message.Usage.InputTokens,
+				OutputTokens:             jl.Message.Usage.OutputTokens,
+				CacheCreationInputTokens: jl.Message.Usage.CacheCreationInputTokens,
+				CacheReadInputTokens:     jl.Message.Usage.CacheReadInputTokens,
+			})
+			usage[family] = u
+		}
+		if err != nil {
+			break
+		}
+	}
+	return usage, nil
+}
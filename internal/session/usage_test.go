@@ -0,0 +1,48 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestModelFamily(t *testing.T) {
+	tests := []struct {
+		model string
+		want  string
+	}{
+		{"claude-opus-4-6", "opus"},
+		{"claude-sonnet-4-5-20250929", "sonnet"},
+		{"claude-3-5-haiku-20241022", "haiku"},
+		{"claude-foo-1", "foo-1"},
+	}
+	for _, tt := range tests {
+		if got := ModelFamily(tt.model); got != tt.want {
+			t.Errorf("ModelFamily(%q) = %q, want %q", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestUsageByModel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "s.jsonl")
+	content := `{"type":"system"}
+{"message":{"model":"claude-opus-4-6","usage":{"input_tokens":1000,"output_tokens":200,"cache_read_input_tokens":300}}}
+{"message":{"role":"user","content":"hi"}}
+{"message":{"model":"claude-sonnet-4-5-20250929","usage":{"input_tokens":50,"output_tokens":10}}}
+{"message":{"model":"claude-opus-4-6","usage":{"input_tokens":2000,"output_tokens":400}}}`
+	os.WriteFile(path, []byte(content), 0o644)
+
+	usage, err := UsageByModel(path)
+	if err != nil {
+		t.Fatalf("UsageByModel() error: %v", err)
+	}
+	if len(usage) != 2 {
+		t.Fatalf("got %d models, want 2: %v", len(usage), usage)
+	}
+	if u := usage["opus"]; u.InputTokens != 3000 || u.OutputTokens != 600 || u.CacheReadInputTokens != 300 {
+		t.Errorf("opus usage = %+v", u)
+	}
+	if u := usage["sonnet"]; u.InputTokens != 50 || u.OutputTokens != 10 {
+		t.Errorf("sonnet usage = %+v", u)
+	}
+}
@@ -0,0 +1,64 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "state.json")
+
+	if err := WriteFile(path, []byte("first"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	if err := WriteFile(path, []byte("second"), 0o600); err != nil {
+		t.Fatalf("WriteFile() overwrite error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "second" {
+		t.Errorf("content = %q, %v; want %q", data, err, "second")
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the state file (temp files left behind?)", len(entries))
+	}
+}
+
+func TestWriteFileFailureKeepsOld(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	if err := WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A directory in the way makes the rename fail
+	blocked := filepath.Join(dir, "blocked")
+	os.MkdirAll(filepath.Join(blocked, "child"), 0o755)
+	if err := WriteFile(blocked, []byte("new"), 0o644); err == nil {
+		t.Fatal("WriteFile() over a non-empty directory should fail")
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("directory has %d entries after a failed write, want 2 (temp file left behind?)", len(entries))
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Errorf("unrelated state file changed: %q", data)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "s.json")
+	if err := WriteJSON(path, map[string]int{"a": 1}); err != nil {
+		t.Fatalf("WriteJSON() error: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "{\n  \"a\": 1\n}" {
+		t.Errorf("WriteJSON() wrote %q", data)
+	}
+}
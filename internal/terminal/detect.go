@@ -0,0 +1,112 @@
+package terminal
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Types lists the terminal types accepted by NewTerminal.
+var Types = []string{"auto", "iterm", "ghostty", "terminal", "tmux", "exec", "print"}
+
+// termPrograms maps $TERM_PROGRAM values and macOS application names to
+// terminal types.
+var termPrograms = map[string]string{
+	"iTerm.app":      "iterm",
+	"iTerm2":         "iterm",
+	"ghostty":        "ghostty",
+	"Ghostty":        "ghostty",
+	"Apple_Terminal": "terminal",
+	"Terminal":       "terminal",
+}
+
+// macApps are the installed-app fallbacks, in order of preference.
+var macApps = []struct{ app, terminalType string }{
+	{"iTerm.app", "iterm"},
+	{"Ghostty.app", "ghostty"},
+}
+
+// probes are the environment lookups Detect relies on, swappable in tests.
+type probes struct {
+	getenv    func(string) string
+	frontmost func() string
+	installed func(app string) bool
+	tty       func() bool
+	goos      string
+}
+
+// Detect picks the terminal to open sessions in: tmux when zen runs inside
+// a tmux session, then the terminal zen was started from ($TERM_PROGRAM),
+// then the frontmost application, then iTerm2 or Ghostty if installed, and
+// finally Terminal.app on macOS. Over SSH and off macOS, where no tabs can
+// be opened, sessions run in the current terminal ("exec"), or their
+// command is printed ("print") when zen is not attached to a terminal.
+func Detect() (string, error) {
+	return detect(probes{
+		getenv:    os.Getenv,
+		frontmost: frontmostApp,
+		installed: appInstalled,
+		tty:       isTTY,
+		goos:      runtime.GOOS,
+	})
+}
+
+func detect(p probes) (string, error) {
+	if p.getenv("TMUX") != "" {
+		return "tmux", nil
+	}
+	if t, ok := termPrograms[p.getenv("TERM_PROGRAM")]; ok {
+		return t, nil
+	}
+	if p.goos != "darwin" || p.getenv("SSH_CONNECTION") != "" {
+		if p.tty() {
+			return "exec", nil
+		}
+		return "print", nil
+	}
+	if t, ok := termPrograms[p.frontmost()]; ok {
+		return t, nil
+	}
+	for _, a := range macApps {
+		if p.installed(a.app) {
+			return a.terminalType, nil
+		}
+	}
+	return "terminal", nil
+}
+
+// isTTY reports whether stdin and stdout are both terminals, so a session
+// can run in place.
+func isTTY() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// frontmostApp returns the name of the frontmost macOS application, or ""
+// if it cannot be determined.
+func frontmostApp() string {
+	out, err := exec.Command("osascript", "-e",
+		`tell application "System Events" to get name of first application process whose frontmost is true`).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// appInstalled reports whether a macOS application bundle exists in
+// /Applications or ~/Applications.
+func appInstalled(app string) bool {
+	for _, dir := range []string{"/Applications", filepath.Join(os.Getenv("HOME"), "Applications")} {
+		if _, err := os.Stat(filepath.Join(dir, app)); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package terminal
+
+import (
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       map[string]string
+		frontmost string
+		installed []string
+		tty       bool
+		goos      string
+		want      string
+		wantErr   bool
+	}{
+		{"inside tmux", map[string]string{"TMUX": "/tmp/tmux-501/default,1,0", "TERM_PROGRAM": "iTerm.app"}, "", nil, true, "darwin", "tmux", false},
+		{"started from iTerm2", map[string]string{"TERM_PROGRAM": "iTerm.app"}, "Ghostty", nil, true, "darwin", "iterm", false},
+		{"started from Ghostty", map[string]string{"TERM_PROGRAM": "ghostty"}, "", nil, true, "darwin", "ghostty", false},
+		{"started from Terminal.app", map[string]string{"TERM_PROGRAM": "Apple_Terminal"}, "", nil, true, "darwin", "terminal", false},
+		{"frontmost app", map[string]string{"TERM_PROGRAM": "vscode"}, "Ghostty", []string{"iTerm.app"}, true, "darwin", "ghostty", false},
+		{"installed iTerm2 preferred", nil, "Finder", []string{"Ghostty.app", "iTerm.app"}, true, "darwin", "iterm", false},
+		{"installed Ghostty", nil, "", []string{"Ghostty.app"}, true, "darwin", "ghostty", false},
+		{"macOS fallback", nil, "", nil, true, "darwin", "terminal", false},
+		{"macOS over SSH", map[string]string{"SSH_CONNECTION": "10.0.0.2 50000 10.0.0.1 22"}, "Finder", []string{"iTerm.app"}, true, "darwin", "exec", false},
+		{"linux inside tmux", map[string]string{"TMUX": "x"}, "", nil, false, "linux", "tmux", false},
+		{"linux without tmux", nil, "", nil, true, "linux", "exec", false},
+		{"linux without a tty", nil, "", nil, false, "linux", "print", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detect(probes{
+				getenv:    func(k string) string { return tt.env[k] },
+				frontmost: func() string { return tt.frontmost },
+				installed: func(app string) bool {
+					for _, a := range tt.installed {
+						if a == app {
+							return true
+						}
+					}
+					return false
+				},
+				tty:  func() bool { return tt.tty },
+				goos: tt.goos,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("detect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
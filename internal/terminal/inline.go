@@ -0,0 +1,104 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/mgreau/zen/internal/ui"
+)
+
+// ExecTerminal runs sessions in the terminal zen was started from, for
+// Linux consoles and SSH sessions with no tab-capable terminal. zen is
+// replaced by the session via exec(2), so the session owns the terminal.
+type ExecTerminal struct{}
+
+func (t *ExecTerminal) Name() string {
+	return "current terminal"
+}
+
+func (t *ExecTerminal) OpenTab(workDir, command string) error {
+	if err := os.Chdir(workDir); err != nil {
+		return fmt.Errorf("changing to %s: %w", workDir, err)
+	}
+	sh := shell()
+	if err := syscall.Exec(sh, []string{sh, "-c", command}, os.Environ()); err != nil {
+		// exec is unavailable on some platforms; run the session as a child
+		c := exec.Command(sh, "-c", command)
+		c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+		return c.Run()
+	}
+	return nil
+}
+
+func (t *ExecTerminal) OpenTabWithResume(workDir, sessionID, claudeBin, model string) error {
+	return t.OpenTab(workDir, resumeCommand(sessionID, claudeBin, model))
+}
+
+func (t *ExecTerminal) OpenTabWithClaude(workDir, initialPrompt, claudeBin, model string) error {
+	return t.OpenTab(workDir, claudeWithPrompt(initialPrompt, claudeBin, model))
+}
+
+// PrintTerminal prints the command that starts a session instead of
+// running it, like --no-terminal. It is picked when zen has no terminal to
+// run a session in, e.g. when its output is piped.
+type PrintTerminal struct{}
+
+func (t *PrintTerminal) Name() string {
+	return "print"
+}
+
+func (t *PrintTerminal) OpenTab(workDir, command string) error {
+	fmt.Println()
+	fmt.Println(ui.BoldText("Open manually:"))
+	fmt.Printf("  cd %s && %s\n", workDir, command)
+	return nil
+}
+
+func (t *PrintTerminal) OpenTabWithResume(workDir, sessionID, claudeBin, model string) error {
+	return t.OpenTab(workDir, resumeCommand(sessionID, claudeBin, model))
+}
+
+func (t *PrintTerminal) OpenTabWithClaude(workDir, initialPrompt, claudeBin, model string) error {
+	return t.OpenTab(workDir, claudeWithPrompt(initialPrompt, claudeBin, model))
+}
+
+// Inline reports whether t runs or prints sessions in the current terminal
+// rather than opening a new tab or window.
+func Inline(t Terminal) bool {
+	switch t := t.(type) {
+	case *ExecTerminal, *PrintTerminal:
+		return true
+	case *Pair:
+		return Inline(t.Terminal)
+	}
+	return false
+}
+
+// shell returns the user's shell, or /bin/sh.
+func shell() string {
+	if sh := os.Getenv("SHELL"); sh != "" {
+		return sh
+	}
+	return "/bin/sh"
+}
+
+// resumeCommand builds the command line resuming a Claude session.
+func resumeCommand(sessionID, claudeBin, model string) string {
+	cmd := claudeBin
+	if model != "" {
+		cmd += fmt.Sprintf(" --model %s", model)
+	}
+	return cmd + fmt.Sprintf(" --resume %s", sessionID)
+}
+
+// claudeWithPrompt builds the command line starting Claude with an
+// initial prompt.
+func claudeWithPrompt(initialPrompt, claudeBin, model string) string {
+	cmd := claudeBin
+	if model != "" {
+		cmd += fmt.Sprintf(" --model %s", model)
+	}
+	return cmd + fmt.Sprintf(" %q", initialPrompt)
+}
@@ -0,0 +1,91 @@
+package terminal
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Splitter is implemented by terminals that can open a tab split in two
+// panes.
+type Splitter interface {
+	// OpenSplit runs first in the left or top pane and second in the
+	// other one.
+	OpenSplit(workDir, first, second string, sideBySide bool) error
+}
+
+// guiEditors open a window of their own instead of running in a terminal.
+var guiEditors = []string{
+	"code", "code-insiders", "codium", "cursor", "windsurf", "zed", "subl",
+	"idea", "goland", "fleet", "mate", "gvim", "mvim", "open",
+}
+
+// GUIEditor reports whether the editor command opens its own window.
+func GUIEditor(editor string) bool {
+	fields := strings.Fields(editor)
+	return len(fields) > 0 && slices.Contains(guiEditors, filepath.Base(fields[0]))
+}
+
+// Pair opens an editor on the worktree along with each session it opens:
+// GUI editors in their own window, terminal editors in a split pane next
+// to the session where the terminal supports it, else in a second tab.
+type Pair struct {
+	Terminal
+	Editor string // run in the worktree with "." as its argument
+	Layout string // "side-by-side", "stacked" or "tabs"
+}
+
+func (p *Pair) OpenTab(workDir, command string) error {
+	editor := p.Editor + " ."
+	if _, ok := p.Terminal.(*PrintTerminal); ok {
+		if err := p.Terminal.OpenTab(workDir, command); err != nil {
+			return err
+		}
+		return p.Terminal.OpenTab(workDir, editor)
+	}
+	if GUIEditor(p.Editor) {
+		if err := startDetached(workDir, editor); err != nil {
+			return fmt.Errorf("starting %s: %w", p.Editor, err)
+		}
+		return p.Terminal.OpenTab(workDir, command)
+	}
+	if s, ok := p.Terminal.(Splitter); ok && p.Layout != "tabs" {
+		return s.OpenSplit(workDir, command, editor, p.Layout != "stacked")
+	}
+	if err := p.Check(); err != nil {
+		return err
+	}
+	if err := p.Terminal.OpenTab(workDir, command); err != nil {
+		return err
+	}
+	return p.Terminal.OpenTab(workDir, editor)
+}
+
+// Check reports an error when the editor cannot be opened along with a
+// session: a terminal editor when sessions take over this terminal.
+func (p *Pair) Check() error {
+	if _, ok := p.Terminal.(*ExecTerminal); ok && !GUIEditor(p.Editor) {
+		return fmt.Errorf("sessions take over this terminal, leaving no room for %s: pair with a GUI editor or use a terminal with tabs", p.Editor)
+	}
+	return nil
+}
+
+func (p *Pair) OpenTabWithResume(workDir, sessionID, claudeBin, model string) error {
+	return p.OpenTab(workDir, resumeCommand(sessionID, claudeBin, model))
+}
+
+func (p *Pair) OpenTabWithClaude(workDir, initialPrompt, claudeBin, model string) error {
+	return p.OpenTab(workDir, claudeWithPrompt(initialPrompt, claudeBin, model))
+}
+
+// startDetached starts command in workDir without waiting for it.
+func startDetached(workDir, command string) error {
+	c := exec.Command(shell(), "-c", command)
+	c.Dir = workDir
+	if err := c.Start(); err != nil {
+		return err
+	}
+	return c.Process.Release()
+}
@@ -0,0 +1,86 @@
+package terminal
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// recorder is a Terminal that records the commands it is asked to open.
+type recorder struct {
+	PrintTerminal
+	tabs   []string
+	splits []string
+}
+
+func (r *recorder) Name() string { return "recorder" }
+
+func (r *recorder) OpenTab(workDir, command string) error {
+	r.tabs = append(r.tabs, command)
+	return nil
+}
+
+// splitRecorder is a recorder that can split tabs.
+type splitRecorder struct{ recorder }
+
+func (r *splitRecorder) OpenSplit(workDir, first, second string, sideBySide bool) error {
+	layout := "stacked"
+	if sideBySide {
+		layout = "side-by-side"
+	}
+	r.splits = append(r.splits, strings.Join([]string{first, second, layout}, " | "))
+	return nil
+}
+
+func TestGUIEditor(t *testing.T) {
+	for editor, want := range map[string]bool{
+		"code":                     true,
+		"/usr/local/bin/cursor -n": true,
+		"zed":                      true,
+		"nvim":                     false,
+		"emacs -nw":                false,
+		"":                         false,
+	} {
+		if got := GUIEditor(editor); got != want {
+			t.Errorf("GUIEditor(%q) = %v, want %v", editor, got, want)
+		}
+	}
+}
+
+func TestPairTerminalEditor(t *testing.T) {
+	split := &splitRecorder{}
+	p := &Pair{Terminal: split, Editor: "nvim", Layout: "side-by-side"}
+	if err := p.OpenTabWithClaude("/wt", "/review-pr", "claude", ""); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`claude "/review-pr" | nvim . | side-by-side`}
+	if !slices.Equal(split.splits, want) || len(split.tabs) != 0 {
+		t.Errorf("splits = %q, tabs = %q; want %q", split.splits, split.tabs, want)
+	}
+
+	// layout: tabs, or a terminal without splits, opens two tabs
+	split = &splitRecorder{}
+	p = &Pair{Terminal: split, Editor: "nvim", Layout: "tabs"}
+	p.OpenTabWithResume("/wt", "abc", "claude", "opus")
+	tabs := []string{"claude --model opus --resume abc", "nvim ."}
+	if !slices.Equal(split.tabs, tabs) || len(split.splits) != 0 {
+		t.Errorf("tabs = %q, splits = %q; want %q", split.tabs, split.splits, tabs)
+	}
+
+	plain := &recorder{}
+	p = &Pair{Terminal: plain, Editor: "nvim", Layout: "side-by-side"}
+	p.OpenTab("/wt", "claude")
+	if !slices.Equal(plain.tabs, []string{"claude", "nvim ."}) {
+		t.Errorf("tabs = %q, want claude then the editor", plain.tabs)
+	}
+}
+
+func TestPairExecTerminal(t *testing.T) {
+	p := &Pair{Terminal: &ExecTerminal{}, Editor: "nvim"}
+	if err := p.OpenTab(t.TempDir(), "true"); err == nil {
+		t.Error("OpenTab() should refuse a terminal editor in the current terminal")
+	}
+	if !Inline(p) {
+		t.Error("Inline() should see through Pair")
+	}
+}
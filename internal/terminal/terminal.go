@@ -5,6 +5,8 @@ import (
 
 	"github.com/mgreau/zen/internal/ghostty"
 	"github.com/mgreau/zen/internal/iterm"
+	"github.com/mgreau/zen/internal/terminalapp"
+	"github.com/mgreau/zen/internal/tmux"
 )
 
 // Terminal represents a terminal emulator that can open tabs/windows.
@@ -16,12 +18,28 @@ type Terminal interface {
 }
 
 // NewTerminal creates a new terminal instance based on the terminal type.
+// "auto" detects the terminal at runtime (see Detect).
 func NewTerminal(terminalType string) (Terminal, error) {
+	if terminalType == "auto" {
+		detected, err := Detect()
+		if err != nil {
+			return nil, err
+		}
+		terminalType = detected
+	}
 	switch terminalType {
 	case "iterm":
 		return &ITermTerminal{}, nil
 	case "ghostty":
 		return &GhosttyTerminal{}, nil
+	case "terminal":
+		return &TerminalAppTerminal{}, nil
+	case "tmux":
+		return &TmuxTerminal{}, nil
+	case "exec":
+		return &ExecTerminal{}, nil
+	case "print":
+		return &PrintTerminal{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported terminal type: %s", terminalType)
 	}
@@ -46,6 +64,10 @@ func (t *ITermTerminal) OpenTabWithClaude(workDir, initialPrompt, claudeBin, mod
 	return iterm.OpenTabWithClaude(workDir, initialPrompt, claudeBin, model)
 }
 
+func (t *ITermTerminal) OpenSplit(workDir, first, second string, sideBySide bool) error {
+	return iterm.OpenSplit(workDir, first, second, sideBySide)
+}
+
 // GhosttyTerminal wraps the Ghostty functions.
 type GhosttyTerminal struct{}
 
@@ -63,4 +85,45 @@ func (t *GhosttyTerminal) OpenTabWithResume(workDir, sessionID, claudeBin, model
 
 func (t *GhosttyTerminal) OpenTabWithClaude(workDir, initialPrompt, claudeBin, model string) error {
 	return ghostty.OpenTabWithClaude(workDir, initialPrompt, claudeBin, model)
-}
\ No newline at end of file
+}
+// TerminalAppTerminal wraps the macOS Terminal.app functions.
+type TerminalAppTerminal struct{}
+
+func (t *TerminalAppTerminal) Name() string {
+	return "Terminal.app"
+}
+
+func (t *TerminalAppTerminal) OpenTab(workDir, command string) error {
+	return terminalapp.OpenTab(workDir, command)
+}
+
+func (t *TerminalAppTerminal) OpenTabWithResume(workDir, sessionID, claudeBin, model string) error {
+	return terminalapp.OpenTabWithResume(workDir, sessionID, claudeBin, model)
+}
+
+func (t *TerminalAppTerminal) OpenTabWithClaude(workDir, initialPrompt, claudeBin, model string) error {
+	return terminalapp.OpenTabWithClaude(workDir, initialPrompt, claudeBin, model)
+}
+
+// TmuxTerminal opens windows in the tmux session zen runs in.
+type TmuxTerminal struct{}
+
+func (t *TmuxTerminal) Name() string {
+	return "tmux"
+}
+
+func (t *TmuxTerminal) OpenTab(workDir, command string) error {
+	return tmux.OpenTab(workDir, command)
+}
+
+func (t *TmuxTerminal) OpenTabWithResume(workDir, sessionID, claudeBin, model string) error {
+	return tmux.OpenTabWithResume(workDir, sessionID, claudeBin, model)
+}
+
+func (t *TmuxTerminal) OpenTabWithClaude(workDir, initialPrompt, claudeBin, model string) error {
+	return tmux.OpenTabWithClaude(workDir, initialPrompt, claudeBin, model)
+}
+
+func (t *TmuxTerminal) OpenSplit(workDir, first, second string, sideBySide bool) error {
+	return tmux.OpenSplit(workDir, first, second, sideBySide)
+}
@@ -13,6 +13,10 @@ func TestNewTerminal(t *testing.T) {
 	}{
 		{"iterm explicit", "iterm", "iTerm2", false},
 		{"ghostty", "ghostty", "Ghostty", false},
+		{"terminal.app", "terminal", "Terminal.app", false},
+		{"tmux", "tmux", "tmux", false},
+		{"exec", "exec", "current terminal", false},
+		{"print", "print", "print", false},
 		{"empty is invalid", "", "", true},
 		{"invalid terminal", "invalid", "", true},
 	}
@@ -0,0 +1,48 @@
+package terminalapp
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// OpenTab opens a new Terminal.app window and runs the given command.
+// Terminal.app's AppleScript dictionary has no command for creating tabs,
+// so each session gets its own window.
+func OpenTab(workDir, command string) error {
+	fullCmd := fmt.Sprintf("cd %q && %s", workDir, command)
+
+	// Pass the command via env var to avoid AppleScript string escaping issues.
+	script := `tell application "Terminal"
+    activate
+    do script (system attribute "ZEN_TERMINAL_CMD")
+end tell`
+
+	cmd := exec.Command("osascript", "-e", script)
+	cmd.Env = append(os.Environ(), "ZEN_TERMINAL_CMD="+fullCmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("osascript: %w: %s", err, string(out))
+	}
+	return nil
+}
+
+// OpenTabWithResume opens a new Terminal.app window to resume a Claude session.
+func OpenTabWithResume(workDir, sessionID, claudeBin, model string) error {
+	cmd := claudeBin
+	if model != "" {
+		cmd += fmt.Sprintf(" --model %s", model)
+	}
+	cmd += fmt.Sprintf(" --resume %s", sessionID)
+	return OpenTab(workDir, cmd)
+}
+
+// OpenTabWithClaude opens a new Terminal.app window with Claude and an initial prompt.
+func OpenTabWithClaude(workDir, initialPrompt, claudeBin, model string) error {
+	cmd := claudeBin
+	if model != "" {
+		cmd += fmt.Sprintf(" --model %s", model)
+	}
+	cmd += fmt.Sprintf(" %q", initialPrompt)
+	return OpenTab(workDir, cmd)
+}
@@ -0,0 +1,77 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// OpenTab opens a new window in the current tmux session and runs the given
+// command in it. The command is typed into the window's shell rather than
+// passed to new-window, so the window stays open after the command exits.
+func OpenTab(workDir, command string) error {
+	windowID, err := newWindow(workDir)
+	if err != nil {
+		return err
+	}
+	return sendKeys(windowID, command)
+}
+
+// OpenSplit opens a new window in the current tmux session split in two
+// panes, side by side or stacked, running first in the left or top pane
+// and second in the other.
+func OpenSplit(workDir, first, second string, sideBySide bool) error {
+	windowID, err := newWindow(workDir)
+	if err != nil {
+		return err
+	}
+	if err := sendKeys(windowID, first); err != nil {
+		return err
+	}
+	dir := "-v"
+	if sideBySide {
+		dir = "-h"
+	}
+	out, err := exec.Command("tmux", "split-window", dir, "-t", windowID, "-P", "-F", "#{pane_id}", "-c", workDir).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("tmux split-window: %w: %s", err, string(out))
+	}
+	return sendKeys(strings.TrimSpace(string(out)), second)
+}
+
+// newWindow opens a window in workDir and returns its ID.
+func newWindow(workDir string) (string, error) {
+	out, err := exec.Command("tmux", "new-window", "-P", "-F", "#{window_id}", "-c", workDir).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("tmux new-window: %w: %s", err, string(out))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// sendKeys types command into the target window or pane and runs it.
+func sendKeys(target, command string) error {
+	if out, err := exec.Command("tmux", "send-keys", "-t", target, command, "Enter").CombinedOutput(); err != nil {
+		return fmt.Errorf("tmux send-keys: %w: %s", err, string(out))
+	}
+	return nil
+}
+
+// OpenTabWithResume opens a new tmux window to resume a Claude session.
+func OpenTabWithResume(workDir, sessionID, claudeBin, model string) error {
+	cmd := claudeBin
+	if model != "" {
+		cmd += fmt.Sprintf(" --model %s", model)
+	}
+	cmd += fmt.Sprintf(" --resume %s", sessionID)
+	return OpenTab(workDir, cmd)
+}
+
+// OpenTabWithClaude opens a new tmux window with Claude and an initial prompt.
+func OpenTabWithClaude(workDir, initialPrompt, claudeBin, model string) error {
+	cmd := claudeBin
+	if model != "" {
+		cmd += fmt.Sprintf(" --model %s", model)
+	}
+	cmd += fmt.Sprintf(" %q", initialPrompt)
+	return OpenTab(workDir, cmd)
+}
@@ -5,7 +5,7 @@ import (
 	"os"
 )
 
-// ANSI color codes
+// ANSI color codes of the default theme
 const (
 	Red    = "\033[0;31m"
 	Green  = "\033[0;32m"
@@ -21,30 +21,45 @@ var colorsEnabled = true
 
 func init() {
 	// Disable colors if not a terminal or NO_COLOR is set
-	if os.Getenv("NO_COLOR") != "" {
+	if os.Getenv("NO_COLOR") != "" || !isTerminal(os.Stdout) {
 		colorsEnabled = false
 	}
 }
 
+// isTerminal reports whether f is attached to a terminal (character device),
+// so output piped to a file or another program stays free of ANSI codes.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 // SetColorsEnabled controls whether ANSI codes are emitted.
 func SetColorsEnabled(enabled bool) {
 	colorsEnabled = enabled
 }
 
+// ColorsEnabled reports whether ANSI codes are emitted.
+func ColorsEnabled() bool {
+	return colorsEnabled
+}
+
 func wrap(code, s string) string {
-	if !colorsEnabled {
+	if !colorsEnabled || code == "" {
 		return s
 	}
 	return code + s + Reset
 }
 
-func RedText(s string) string    { return wrap(Red, s) }
-func GreenText(s string) string  { return wrap(Green, s) }
-func YellowText(s string) string { return wrap(Yellow, s) }
-func BlueText(s string) string   { return wrap(Blue, s) }
-func CyanText(s string) string   { return wrap(Cyan, s) }
-func BoldText(s string) string   { return wrap(Bold, s) }
-func DimText(s string) string    { return wrap(Dim, s) }
+func RedText(s string) string    { return wrap(theme.Red, s) }
+func GreenText(s string) string  { return wrap(theme.Green, s) }
+func YellowText(s string) string { return wrap(theme.Yellow, s) }
+func BlueText(s string) string   { return wrap(theme.Blue, s) }
+func CyanText(s string) string   { return wrap(theme.Cyan, s) }
+func BoldText(s string) string   { return wrap(theme.Bold, s) }
+func DimText(s string) string    { return wrap(theme.Dim, s) }
 
 func LogInfo(msg string)    { fmt.Fprintf(os.Stderr, "%s %s\n", BlueText("[INFO]"), msg) }
 func LogSuccess(msg string) { fmt.Fprintf(os.Stderr, "%s %s\n", GreenText("[OK]"), msg) }
@@ -58,3 +73,26 @@ func LogDebug(msg string) {
 		fmt.Fprintf(os.Stderr, "%s %s\n", DimText("[DEBUG]"), msg)
 	}
 }
+
+// LabelChip renders a GitHub label as its name on the label's color,
+// given as hex RGB (e.g. "d73a4a"), in black or white text, whichever
+// reads better. Without colors, or with an invalid color, it returns the
+// name.
+func LabelChip(name, hexColor string) string {
+	if !colorsEnabled {
+		return name
+	}
+	var r, g, b uint8
+	if len(hexColor) != 6 {
+		return name
+	}
+	if _, err := fmt.Sscanf(hexColor, "%02x%02x%02x", &r, &g, &b); err != nil {
+		return name
+	}
+	// Perceived brightness, ITU-R BT.601 weights
+	fg := "38;2;255;255;255"
+	if 299*int(r)+587*int(g)+114*int(b) > 128000 {
+		fg = "38;2;0;0;0"
+	}
+	return fmt.Sprintf("\033[48;2;%d;%d;%d;%sm %s %s", r, g, b, fg, name, Reset)
+}
@@ -52,3 +52,26 @@ func TestAllColorFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelChip(t *testing.T) {
+	SetColorsEnabled(true)
+	tests := []struct {
+		name, color, want string
+	}{
+		{"bug", "d73a4a", "\033[48;2;215;58;74;38;2;255;255;255m bug \033[0m"},
+		{"docs", "fef2c0", "\033[48;2;254;242;192;38;2;0;0;0m docs \033[0m"},
+		{"odd", "zzzzzz", "odd"},
+		{"none", "", "none"},
+	}
+	for _, tt := range tests {
+		if got := LabelChip(tt.name, tt.color); got != tt.want {
+			t.Errorf("LabelChip(%q, %q) = %q, want %q", tt.name, tt.color, got, tt.want)
+		}
+	}
+
+	SetColorsEnabled(false)
+	defer SetColorsEnabled(true)
+	if got := LabelChip("bug", "d73a4a"); got != "bug" {
+		t.Errorf("with colors disabled, LabelChip() = %q, want %q", got, "bug")
+	}
+}
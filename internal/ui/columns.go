@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Column is one column of a Table.
+type Column struct {
+	Key    string // name used to pick columns (columns: config, --columns)
+	Header string
+	// Max caps the column's width; longer cells are truncated. 0 means
+	// no cap.
+	Max int
+	// Flex columns (titles) shrink, down to Min, so the row fits the
+	// terminal; the others keep the width of their widest cell.
+	Flex bool
+	Min  int
+	// Optional columns are left out when no row has content in them.
+	Optional bool
+}
+
+// Table buffers rows and prints them with each column as wide as its
+// widest cell, shrinking Flex columns to fit the terminal.
+type Table struct {
+	Indent string
+	cols   []Column
+	pick   []int // indexes into cols, in display order
+	rows   [][]string
+}
+
+// NewTable returns a table of the columns named by keys, in that order.
+// Keys naming no column of cols are skipped, so one list can serve tables
+// with different columns. When keys is empty or names none of cols, all
+// columns are shown.
+func NewTable(cols []Column, keys []string) *Table {
+	t := &Table{Indent: "  ", cols: cols}
+	for _, k := range keys {
+		for i, c := range cols {
+			if c.Key == k {
+				t.pick = append(t.pick, i)
+				break
+			}
+		}
+	}
+	if len(t.pick) == 0 {
+		for i := range cols {
+			t.pick = append(t.pick, i)
+		}
+	}
+	return t
+}
+
+// Row adds a row. cells are given for every column passed to NewTable, in
+// that order, and may carry color codes.
+func (t *Table) Row(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// Print writes the header, the underline and the rows to stdout. Columns
+// with no header and no content, and Optional columns with no content,
+// are left out.
+func (t *Table) Print() {
+	widths := t.layout(TermWidth())
+	var shown []int // indexes into t.pick
+	for n, w := range widths {
+		if w > 0 {
+			shown = append(shown, n)
+		}
+	}
+	var b strings.Builder
+	line := func(cell func(n int) string) {
+		b.WriteString(t.Indent)
+		for k, n := range shown {
+			if k > 0 {
+				b.WriteString("  ")
+			}
+			b.WriteString(fit(cell(n), widths[n], k == len(shown)-1))
+		}
+		b.WriteString("\n")
+	}
+	line(func(n int) string { return t.cols[t.pick[n]].Header })
+	line(func(n int) string { return strings.Repeat("─", widths[n]) })
+	for _, r := range t.rows {
+		line(func(n int) string {
+			if i := t.pick[n]; i < len(r) {
+				return r[i]
+			}
+			return ""
+		})
+	}
+	fmt.Print(b.String())
+}
+
+// layout returns the width of each picked column for a terminal of width
+// columns (0 when unknown, which leaves Flex columns unshrunk).
+func (t *Table) layout(width int) []int {
+	widths := make([]int, len(t.pick))
+	for n, i := range t.pick {
+		c := t.cols[i]
+		w, content := VisibleLen(c.Header), 0
+		for _, r := range t.rows {
+			if i < len(r) {
+				content = max(content, VisibleLen(r[i]))
+			}
+		}
+		if c.Optional && content == 0 {
+			continue
+		}
+		w = max(w, content)
+		if c.Max > 0 {
+			w = min(w, c.Max)
+		}
+		widths[n] = w
+	}
+	if width <= 0 {
+		return widths
+	}
+
+	total := len(t.Indent) + 2*(len(widths)-1)
+	var flex []int
+	for n, i := range t.pick {
+		total += widths[n]
+		if t.cols[i].Flex {
+			flex = append(flex, n)
+		}
+	}
+	// Take the overflow from the widest Flex column first, down to Min
+	for over := total - width; over > 0 && len(flex) > 0; {
+		widest := flex[0]
+		for _, n := range flex[1:] {
+			if widths[n] > widths[widest] {
+				widest = n
+			}
+		}
+		floor := max(t.cols[t.pick[widest]].Min, VisibleLen(t.cols[t.pick[widest]].Header))
+		if widths[widest] <= floor {
+			break
+		}
+		widths[widest]--
+		over--
+	}
+	return widths
+}
+
+// fit pads s to width, or truncates it with "..." when longer. The last
+// column is not padded.
+func fit(s string, width int, last bool) string {
+	n := VisibleLen(s)
+	if n > width {
+		r := []rune(stripANSI(s))
+		if width <= 3 {
+			return string(r[:width])
+		}
+		return string(r[:width-3]) + "..."
+	}
+	if last {
+		return s
+	}
+	return s + strings.Repeat(" ", width-n)
+}
+
+var ansiRE = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func stripANSI(s string) string {
+	return ansiRE.ReplaceAllString(s, "")
+}
+
+// VisibleLen returns the number of characters s takes on screen, ignoring
+// color codes.
+func VisibleLen(s string) int {
+	return utf8.RuneCountInString(stripANSI(s))
+}
+
+// TermWidth returns the width of the terminal on stdout: $COLUMNS when
+// set, else the size reported by the terminal. It returns 0 when stdout
+// is not a terminal, so piped output is not cut.
+func TermWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if !isTerminal(os.Stdout) {
+		return 0
+	}
+	return termWidth(os.Stdout)
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+var testColumns = []Column{
+	{Key: "pr", Header: "PR"},
+	{Key: "title", Header: "Title", Flex: true, Min: 10},
+	{Key: "link", Header: "Link"},
+}
+
+func TestNewTablePicksColumns(t *testing.T) {
+	tests := []struct {
+		keys []string
+		want []int
+	}{
+		{nil, []int{0, 1, 2}},
+		{[]string{"link", "pr"}, []int{2, 0}},
+		{[]string{"team", "title"}, []int{1}}, // team is not in this table
+		{[]string{"team"}, []int{0, 1, 2}},
+	}
+	for _, tt := range tests {
+		if got := NewTable(testColumns, tt.keys).pick; !slices.Equal(got, tt.want) {
+			t.Errorf("NewTable(%v).pick = %v, want %v", tt.keys, got, tt.want)
+		}
+	}
+}
+
+func TestTableLayout(t *testing.T) {
+	tbl := NewTable(testColumns, nil)
+	tbl.Row(CyanText("#1234"), "A fairly long pull request title that goes on", "https://github.com/o/r/pull/1234")
+
+	if got := tbl.layout(0); !slices.Equal(got, []int{5, 45, 32}) {
+		t.Errorf("layout(0) = %v, want natural widths [5 45 32]", got)
+	}
+	// 2 indent + 5 + 2 + title + 2 + 32 = 70
+	if got := tbl.layout(70); !slices.Equal(got, []int{5, 27, 32}) {
+		t.Errorf("layout(70) = %v, want title shrunk to 27", got)
+	}
+	if got := tbl.layout(20); !slices.Equal(got, []int{5, 10, 32}) {
+		t.Errorf("layout(20) = %v, want title at its minimum of 10", got)
+	}
+}
+
+func TestTableMax(t *testing.T) {
+	tbl := NewTable([]Column{{Key: "author", Header: "Author", Max: 8}}, nil)
+	tbl.Row("someone-with-a-long-login")
+	if got := tbl.layout(0); !slices.Equal(got, []int{8}) {
+		t.Errorf("layout(0) = %v, want [8]", got)
+	}
+}
+
+func TestTableOptional(t *testing.T) {
+	cols := []Column{{Key: "pr", Header: "PR"}, {Key: "labels", Header: "Labels", Optional: true}}
+	tbl := NewTable(cols, nil)
+	tbl.Row("#1", "")
+	if got := tbl.layout(0); !slices.Equal(got, []int{2, 0}) {
+		t.Errorf("layout(0) = %v, want empty optional column left out", got)
+	}
+	tbl.Row("#2", "hold")
+	if got := tbl.layout(0); !slices.Equal(got, []int{2, 6}) {
+		t.Errorf("layout(0) = %v, want optional column shown", got)
+	}
+}
+
+func TestFit(t *testing.T) {
+	defer SetColorsEnabled(colorsEnabled)
+	SetColorsEnabled(true)
+
+	tests := []struct {
+		s     string
+		width int
+		last  bool
+		want  string
+	}{
+		{"abc", 5, false, "abc  "},
+		{"abc", 5, true, "abc"},
+		{"abcdefgh", 6, false, "abc..."},
+		{GreenText("ok"), 4, false, GreenText("ok") + "  "},
+		{GreenText("reviewed"), 6, false, "rev..."},
+		{"héllo wörld", 8, false, "héllo..."},
+	}
+	for _, tt := range tests {
+		if got := fit(tt.s, tt.width, tt.last); got != tt.want {
+			t.Errorf("fit(%q, %d, %v) = %q, want %q", tt.s, tt.width, tt.last, got, tt.want)
+		}
+	}
+}
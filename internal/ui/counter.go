@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Counter shows the progress of a batch of concurrent work, such as
+// "Scanning 30 PRs... 12/30", updated in place on stderr. Off a terminal
+// it prints nothing, so logs and pipes aren't filled with updates.
+type Counter struct {
+	mu    sync.Mutex
+	w     io.Writer
+	live  bool
+	label string
+	done  int
+	total int
+}
+
+// NewCounter returns a Counter for total items, showing label and 0/total
+// right away.
+func NewCounter(label string, total int) *Counter {
+	c := &Counter{w: os.Stderr, live: isTerminal(os.Stderr), label: label, total: total}
+	c.print()
+	return c
+}
+
+// Add records n more finished items. It is safe for concurrent use.
+func (c *Counter) Add(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.done += n
+	c.print()
+}
+
+// Count returns the number of finished items.
+func (c *Counter) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done
+}
+
+// Clear erases the counter line.
+func (c *Counter) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.live {
+		fmt.Fprint(c.w, "\r\033[K")
+	}
+}
+
+func (c *Counter) print() {
+	if c.live {
+		fmt.Fprintf(c.w, "\r\033[K  %s %s", DimText(c.label), fmt.Sprintf("%d/%d", c.done, c.total))
+	}
+}
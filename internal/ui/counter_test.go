@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+	SetColorsEnabled(false)
+	defer SetColorsEnabled(true)
+
+	var out strings.Builder
+	c := &Counter{w: &out, live: true, label: "Scanning 5 PRs...", total: 5}
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Add(1)
+		}()
+	}
+	wg.Wait()
+	if c.Count() != 3 {
+		t.Errorf("Count() = %d, want 3", c.Count())
+	}
+	if !strings.HasSuffix(out.String(), "Scanning 5 PRs... 3/5") {
+		t.Errorf("output = %q, want it to end with the 3/5 count", out.String())
+	}
+	c.Clear()
+	if !strings.HasSuffix(out.String(), "\r\033[K") {
+		t.Errorf("Clear() didn't erase the line: %q", out.String())
+	}
+
+	// Off a terminal nothing is printed
+	out.Reset()
+	c = &Counter{w: &out, label: "Scanning", total: 2}
+	c.Add(2)
+	c.Clear()
+	if out.Len() != 0 || c.Count() != 2 {
+		t.Errorf("plain counter printed %q, count %d", out.String(), c.Count())
+	}
+}
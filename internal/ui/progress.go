@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Steps renders a sequence of named, timed steps such as
+// "fetch → worktree add → context inject". On a terminal the current step
+// shows a spinner and elapsed time in place; otherwise each step is printed
+// as a plain line when it starts and finishes.
+type Steps struct {
+	mu    sync.Mutex
+	w     io.Writer
+	live  bool
+	emit  func(line string) // plain-mode output; nil when live
+	quiet bool              // only report finished steps
+	name  string
+	start time.Time
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// NewSteps returns a Steps writing to stderr, animated when stderr is a
+// terminal and colors are enabled.
+func NewSteps() *Steps {
+	live := colorsEnabled && isTerminal(os.Stderr)
+	s := &Steps{w: os.Stderr, live: live}
+	if !live {
+		s.emit = func(line string) { fmt.Fprintln(os.Stderr, line) }
+	}
+	return s
+}
+
+// NewStepLogger returns a Steps that reports each finished step, with its
+// duration, through logf -- for daemon logs, where spinners make no sense.
+func NewStepLogger(logf func(string)) *Steps {
+	return &Steps{emit: logf, quiet: true}
+}
+
+// Step ends the current step successfully and starts a new one.
+func (s *Steps) Step(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finishLocked(nil)
+	s.name = name
+	s.start = time.Now()
+	if !s.live {
+		if !s.quiet {
+			s.emit("→ " + name)
+		}
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.spin(s.stop, s.done)
+}
+
+// Info prints a message without ending the current step.
+func (s *Steps) Info(msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.live {
+		fmt.Fprintf(s.w, "\r\033[K  %s\n", msg)
+		return
+	}
+	s.emit("  " + msg)
+}
+
+// Done ends the current step, marking it failed when err is non-nil.
+func (s *Steps) Done(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finishLocked(err)
+}
+
+func (s *Steps) finishLocked(err error) {
+	if s.name == "" {
+		return
+	}
+	if s.live {
+		close(s.stop)
+		s.mu.Unlock()
+		<-s.done
+		s.mu.Lock()
+	}
+
+	elapsed := FormatElapsed(time.Since(s.start))
+	var line string
+	if err != nil {
+		line = fmt.Sprintf("%s %s %s", RedText("✗"), s.name, DimText("("+elapsed+")"))
+	} else {
+		line = fmt.Sprintf("%s %s %s", GreenText("✓"), s.name, DimText("("+elapsed+")"))
+	}
+	if s.live {
+		fmt.Fprintf(s.w, "\r\033[K  %s\n", line)
+	} else {
+		s.emit(line)
+	}
+	s.name = ""
+}
+
+func (s *Steps) spin(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for i := 0; ; i++ {
+		s.mu.Lock()
+		fmt.Fprintf(s.w, "\r\033[K  %s %s %s", CyanText(spinnerFrames[i%len(spinnerFrames)]), s.name, DimText(FormatElapsed(time.Since(s.start))))
+		s.mu.Unlock()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// FormatElapsed formats a step duration compactly: "450ms", "3.2s", "1m05s".
+func FormatElapsed(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	default:
+		d = d.Round(time.Second)
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatElapsed(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{450 * time.Millisecond, "450ms"},
+		{3200 * time.Millisecond, "3.2s"},
+		{65 * time.Second, "1m05s"},
+	}
+	for _, tt := range tests {
+		if got := FormatElapsed(tt.d); got != tt.want {
+			t.Errorf("FormatElapsed(%s) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestStepLogger(t *testing.T) {
+	SetColorsEnabled(false)
+	defer SetColorsEnabled(true)
+
+	var lines []string
+	s := NewStepLogger(func(line string) { lines = append(lines, line) })
+	s.Done(nil) // no step yet: nothing to report
+	s.Step("git fetch")
+	s.Info("PR #1: title")
+	s.Step("git worktree add")
+	s.Done(errors.New("boom"))
+	s.Done(nil) // already finished
+
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %q", len(lines), lines)
+	}
+	if lines[0] != "  PR #1: title" {
+		t.Errorf("lines[0] = %q, want the info message", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "✓ git fetch (") {
+		t.Errorf("lines[1] = %q, want a finished git fetch step", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "✗ git worktree add (") {
+		t.Errorf("lines[2] = %q, want a failed git worktree add step", lines[2])
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 // Banner prints a bordered title section.
@@ -80,3 +81,79 @@ func FormatSize(bytes int64) string {
 		return fmt.Sprintf("%dB", bytes)
 	}
 }
+
+// matchIndex returns the byte offset of the first case-insensitive match of
+// term in s, or -1.
+func matchIndex(s, term string) int {
+	lower := strings.ToLower(s)
+	if len(lower) != len(s) || term == "" {
+		return -1 // case folding changed byte offsets
+	}
+	return strings.Index(lower, strings.ToLower(term))
+}
+
+// Snippet returns the line of s containing the first case-insensitive match
+// of term, cut to about width bytes around the match. Returns "" when term
+// does not occur.
+func Snippet(s, term string, width int) string {
+	i := matchIndex(s, term)
+	if i < 0 {
+		return ""
+	}
+	start := strings.LastIndexByte(s[:i], '\n') + 1
+	end := len(s)
+	if j := strings.IndexByte(s[i:], '\n'); j >= 0 {
+		end = i + j
+	}
+	line := strings.TrimSpace(s[start:end])
+	if len(line) <= width {
+		return line
+	}
+	i = matchIndex(line, term)
+	from := max(0, i-(width-len(term))/2)
+	to := min(len(line), from+width)
+	from = max(0, to-width)
+	// Cut at word boundaries, or at least keep multi-byte characters whole
+	if from > 0 {
+		if j := strings.IndexByte(line[from:i], ' '); j >= 0 {
+			from += j + 1
+		}
+	}
+	if to < len(line) {
+		if j := strings.LastIndexByte(line[i+len(term):to], ' '); j >= 0 {
+			to = i + len(term) + j
+		}
+	}
+	for from > 0 && !utf8.RuneStart(line[from]) {
+		from--
+	}
+	for to < len(line) && !utf8.RuneStart(line[to]) {
+		to++
+	}
+	out := line[from:to]
+	if from > 0 {
+		out = "…" + out
+	}
+	if to < len(line) {
+		out += "…"
+	}
+	return out
+}
+
+// Highlight marks every case-insensitive occurrence of term in s.
+func Highlight(s, term string) string {
+	if term == "" || !colorsEnabled {
+		return s
+	}
+	var b strings.Builder
+	for {
+		i := matchIndex(s, term)
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:i])
+		b.WriteString(BoldText(YellowText(s[i : i+len(term)])))
+		s = s[i+len(term):]
+	}
+}
@@ -99,3 +99,36 @@ func TestFormatSize(t *testing.T) {
 		})
 	}
 }
+
+func TestSnippet(t *testing.T) {
+	text := "# PR Context\n\nThis PR adds Retry logic to the setup reconciler so transient git failures recover.\nMore."
+	tests := []struct {
+		term  string
+		width int
+		want  string
+	}{
+		{"context", 80, "# PR Context"},
+		{"retry", 200, "This PR adds Retry logic to the setup reconciler so transient git failures recover."},
+		{"retry", 20, "…adds Retry logic…"},
+		{"recover", 20, "…failures recover."},
+		{"missing", 80, ""},
+	}
+	for _, tt := range tests {
+		if got := Snippet(text, tt.term, tt.width); got != tt.want {
+			t.Errorf("Snippet(%q, %d) = %q, want %q", tt.term, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	SetColorsEnabled(false)
+	if got := Highlight("Fix retry", "RETRY"); got != "Fix retry" {
+		t.Errorf("Highlight() without colors = %q", got)
+	}
+	SetColorsEnabled(true)
+	defer SetColorsEnabled(true)
+	want := "Fix " + BoldText(YellowText("Retry")) + ", " + BoldText(YellowText("retry")) + " again"
+	if got := Highlight("Fix Retry, retry again", "retry"); got != want {
+		t.Errorf("Highlight() = %q, want %q", got, want)
+	}
+}
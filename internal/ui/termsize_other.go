@@ -0,0 +1,9 @@
+//go:build !unix
+
+package ui
+
+import "os"
+
+func termWidth(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Theme maps each text style to the ANSI code used to render it. An empty
+// code renders the text unstyled.
+type Theme struct {
+	Red    string
+	Green  string
+	Yellow string
+	Blue   string
+	Cyan   string
+	Bold   string
+	Dim    string
+}
+
+// themes holds the built-in palettes, selected with `theme:` in config.yaml.
+var themes = map[string]Theme{
+	// default suits dark terminal backgrounds.
+	"default": {
+		Red:    Red,
+		Green:  Green,
+		Yellow: Yellow,
+		Blue:   Blue,
+		Cyan:   Cyan,
+		Bold:   Bold,
+		Dim:    Dim,
+	},
+	// light avoids bright yellow and cyan, which wash out on white.
+	"light": {
+		Red:    "\033[0;31m",
+		Green:  "\033[0;32m",
+		Yellow: "\033[0;33m",
+		Blue:   "\033[0;34m",
+		Cyan:   "\033[0;35m",
+		Bold:   Bold,
+		Dim:    "\033[0;90m",
+	},
+	// high-contrast uses bold bright colors and never dims text.
+	"high-contrast": {
+		Red:    "\033[1;91m",
+		Green:  "\033[1;92m",
+		Yellow: "\033[1;93m",
+		Blue:   "\033[1;94m",
+		Cyan:   "\033[1;96m",
+		Bold:   Bold,
+		Dim:    "",
+	},
+}
+
+var theme = themes["default"]
+
+// SetTheme selects the named built-in theme. An empty name selects "default".
+func SetTheme(name string) error {
+	if name == "" {
+		name = "default"
+	}
+	t, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q: must be one of %s", name, strings.Join(ThemeNames(), ", "))
+	}
+	theme = t
+	return nil
+}
+
+// IsTheme reports whether name is a built-in theme.
+func IsTheme(name string) bool {
+	_, ok := themes[name]
+	return ok
+}
+
+// ThemeNames returns the built-in theme names in sorted order.
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for n := range themes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,43 @@
+package ui
+
+import "testing"
+
+func TestSetTheme(t *testing.T) {
+	SetColorsEnabled(true)
+	defer SetTheme("default")
+
+	if err := SetTheme("light"); err != nil {
+		t.Fatalf("SetTheme(light) error: %v", err)
+	}
+	if got, want := YellowText("x"), "\033[0;33mx"+Reset; got != want {
+		t.Errorf("light YellowText() = %q, want %q", got, want)
+	}
+
+	if err := SetTheme("high-contrast"); err != nil {
+		t.Fatalf("SetTheme(high-contrast) error: %v", err)
+	}
+	if got := DimText("x"); got != "x" {
+		t.Errorf("high-contrast DimText() = %q, want unstyled %q", got, "x")
+	}
+
+	if err := SetTheme(""); err != nil {
+		t.Fatalf("SetTheme(\"\") error: %v", err)
+	}
+	if got, want := RedText("x"), Red+"x"+Reset; got != want {
+		t.Errorf("default RedText() = %q, want %q", got, want)
+	}
+}
+
+func TestSetThemeUnknown(t *testing.T) {
+	if err := SetTheme("neon"); err == nil {
+		t.Error("SetTheme(neon) should fail")
+	}
+	if IsTheme("neon") {
+		t.Error("IsTheme(neon) = true, want false")
+	}
+	for _, name := range ThemeNames() {
+		if !IsTheme(name) {
+			t.Errorf("IsTheme(%q) = false for a listed theme", name)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package worktree
+
+import "path/filepath"
+
+// benchDirName is the directory under a repo's base_path that holds the
+// scratch worktrees of zen bench while it runs.
+const benchDirName = ".zen-bench"
+
+// BenchDir returns the directory holding zen bench worktrees for
+// base_path.
+func BenchDir(basePath string) string {
+	return filepath.Join(basePath, benchDirName)
+}
+
+// IsBench reports whether path is a zen bench worktree.
+func IsBench(path string) bool {
+	return filepath.Base(filepath.Dir(path)) == benchDirName
+}
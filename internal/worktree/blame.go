@@ -0,0 +1,179 @@
+package worktree
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LineRange is an inclusive range of line numbers.
+type LineRange struct {
+	Start, End int
+}
+
+// BlameLine records who last changed one line of a file.
+type BlameLine struct {
+	File  string
+	Name  string
+	Email string
+	Time  time.Time
+}
+
+// AuthorScore ranks an author of the lines a change touches.
+type AuthorScore struct {
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Lines       int       `json:"lines"`
+	Files       int       `json:"files"`
+	LastTouched time.Time `json:"last_touched"`
+	Score       float64   `json:"score"`
+}
+
+// blameHalfLife is how fast authorship fades in RankAuthors: a line last
+// changed this long ago counts half as much as one changed today.
+const blameHalfLife = 180 * 24 * time.Hour
+
+// ChangedLines returns, per file, the lines of base that the diff from base
+// to head rewrites or deletes. A pure insertion counts the line above it,
+// since that is the code the insertion extends. Files added by the diff
+// have no lines in base and are left out.
+func ChangedLines(path, base, head string) (map[string][]LineRange, error) {
+	out, err := git(path, "diff", "-U0", "--no-color", "--no-ext-diff", base, head)
+	if err != nil {
+		return nil, err
+	}
+	return parseHunks(out), nil
+}
+
+// parseHunks extracts the old-side line ranges of a -U0 unified diff.
+func parseHunks(diff string) map[string][]LineRange {
+	ranges := make(map[string][]LineRange)
+	file := ""
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			file = ""
+			if name, ok := strings.CutPrefix(line, "--- a/"); ok {
+				file = name
+			}
+		case strings.HasPrefix(line, "@@ -") && file != "":
+			old, _, _ := strings.Cut(strings.TrimPrefix(line, "@@ -"), " ")
+			startStr, countStr, hasCount := strings.Cut(old, ",")
+			start, err := strconv.Atoi(startStr)
+			if err != nil {
+				continue
+			}
+			count := 1
+			if hasCount {
+				if count, err = strconv.Atoi(countStr); err != nil {
+					continue
+				}
+			}
+			switch {
+			case count > 0:
+				ranges[file] = append(ranges[file], LineRange{start, start + count - 1})
+			case start > 0:
+				// Insertion after line start
+				ranges[file] = append(ranges[file], LineRange{start, start})
+			}
+		}
+	}
+	return ranges
+}
+
+// Blame returns the author of each line of file in the given ranges at rev.
+func Blame(path, rev, file string, ranges []LineRange) ([]BlameLine, error) {
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+	args := []string{"blame", "--line-porcelain", "-w"}
+	for _, r := range ranges {
+		args = append(args, "-L", fmt.Sprintf("%d,%d", r.Start, r.End))
+	}
+	args = append(args, rev, "--", file)
+	out, err := git(path, args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseBlame(out, file), nil
+}
+
+// parseBlame reads `git blame --line-porcelain` output, in which every line
+// of the file comes after a header of "key value" lines.
+func parseBlame(out, file string) []BlameLine {
+	var lines []BlameLine
+	cur := BlameLine{File: file}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			lines = append(lines, cur)
+			cur = BlameLine{File: file}
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			cur.Name = value
+		case "author-mail":
+			cur.Email = strings.Trim(value, "<>")
+		case "author-time":
+			if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+				cur.Time = time.Unix(ts, 0)
+			}
+		}
+	}
+	return lines
+}
+
+// RankAuthors groups blamed lines by author email and ranks the authors by
+// how many of the lines they wrote, each line weighted by its age so that
+// recent authors rank first.
+func RankAuthors(lines []BlameLine, now time.Time) []AuthorScore {
+	byEmail := make(map[string]*AuthorScore)
+	files := make(map[string]map[string]bool)
+	for _, l := range lines {
+		key := strings.ToLower(l.Email)
+		if key == "" || key == "not.committed.yet" {
+			continue
+		}
+		a, ok := byEmail[key]
+		if !ok {
+			a = &AuthorScore{Name: l.Name, Email: l.Email}
+			byEmail[key] = a
+			files[key] = make(map[string]bool)
+		}
+		a.Lines++
+		files[key][l.File] = true
+		if l.Time.After(a.LastTouched) {
+			a.LastTouched = l.Time
+		}
+		age := max(now.Sub(l.Time), 0)
+		a.Score += math.Pow(0.5, float64(age)/float64(blameHalfLife))
+	}
+
+	ranked := make([]AuthorScore, 0, len(byEmail))
+	for key, a := range byEmail {
+		a.Files = len(files[key])
+		a.Score = math.Round(a.Score*100) / 100
+		ranked = append(ranked, *a)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Email < ranked[j].Email
+	})
+	return ranked
+}
+
+// UserEmail returns the git user.email configured for the checkout at path,
+// or "" if unset.
+func UserEmail(path string) string {
+	email, err := git(path, "config", "user.email")
+	if err != nil {
+		return ""
+	}
+	return email
+}
@@ -0,0 +1,85 @@
+package worktree
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseHunks(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -3,2 +3,3 @@ func main() {
+@@ -10 +11 @@ func helper() {
+@@ -20,0 +22,4 @@ func other() {
+diff --git a/new.go b/new.go
+new file mode 100644
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1,5 @@
+diff --git a/top.go b/top.go
+--- a/top.go
++++ b/top.go
+@@ -0,0 +1,2 @@
+`
+	got := parseHunks(diff)
+	want := map[string][]LineRange{
+		"main.go": {{3, 4}, {10, 10}, {20, 20}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseHunks() = %v, want %v", got, want)
+	}
+}
+
+func TestParseBlame(t *testing.T) {
+	out := "abc123 3 3 1\n" +
+		"author Ada\n" +
+		"author-mail <ada@example.com>\n" +
+		"author-time 1700000000\n" +
+		"summary first\n" +
+		"\tline three\n" +
+		"def456 4 4 1\n" +
+		"author Bob\n" +
+		"author-mail <bob@example.com>\n" +
+		"author-time 1710000000\n" +
+		"\tline four\n"
+	got := parseBlame(out, "main.go")
+	want := []BlameLine{
+		{File: "main.go", Name: "Ada", Email: "ada@example.com", Time: time.Unix(1700000000, 0)},
+		{File: "main.go", Name: "Bob", Email: "bob@example.com", Time: time.Unix(1710000000, 0)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBlame() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRankAuthors(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	ago := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	lines := []BlameLine{
+		// Old lines: 3 lines, two years old
+		{File: "a.go", Name: "Old", Email: "old@example.com", Time: ago(730)},
+		{File: "a.go", Name: "Old", Email: "old@example.com", Time: ago(730)},
+		{File: "b.go", Name: "Old", Email: "old@example.com", Time: ago(730)},
+		// Recent lines: 2 lines this month
+		{File: "a.go", Name: "New", Email: "new@example.com", Time: ago(10)},
+		{File: "a.go", Name: "New", Email: "NEW@example.com", Time: ago(5)},
+		{File: "a.go", Name: "Not Committed Yet", Email: "not.committed.yet", Time: now},
+	}
+
+	got := RankAuthors(lines, now)
+	if len(got) != 2 {
+		t.Fatalf("RankAuthors() = %+v; want 2 authors", got)
+	}
+	if got[0].Email != "new@example.com" || got[0].Lines != 2 || got[0].Files != 1 || !got[0].LastTouched.Equal(ago(5)) {
+		t.Errorf("first = %+v; want the recent author with 2 lines in 1 file", got[0])
+	}
+	if got[1].Email != "old@example.com" || got[1].Lines != 3 || got[1].Files != 2 {
+		t.Errorf("second = %+v; want the old author with 3 lines in 2 files", got[1])
+	}
+	if got[1].Score >= 1 {
+		t.Errorf("old author score = %v; want well under one line's weight", got[1].Score)
+	}
+}
@@ -0,0 +1,84 @@
+package worktree
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/mgreau/zen/internal/audit"
+)
+
+// ErrBranchUnmerged is returned by DeleteBranch for a branch with commits
+// that are not on the default branch of origin or another remote.
+var ErrBranchUnmerged = errors.New("branch has commits not on the default branch")
+
+// DefaultBranch returns origin's default branch as a remote-tracking ref,
+// e.g. "origin/main", falling back to origin/main when origin/HEAD is
+// not set.
+func DefaultBranch(originPath string) string {
+	return RemoteDefaultBranch(originPath, "origin")
+}
+
+// RemoteDefaultBranch returns remote's default branch as a remote-tracking
+// ref, e.g. "upstream/main", falling back to <remote>/main when
+// <remote>/HEAD is not set.
+func RemoteDefaultBranch(originPath, remote string) string {
+	if ref, err := git(originPath, "rev-parse", "--abbrev-ref", remote+"/HEAD"); err == nil && ref != remote+"/HEAD" {
+		return ref
+	}
+	return remote + "/main"
+}
+
+// DeleteBranch deletes branch from the clone at originPath once its
+// worktree is gone. With force the branch is deleted as is, which suits
+// pr-N branches that only mirror a PR head. Otherwise it is kept, and
+// ErrBranchUnmerged returned, unless it is merged into the default branch
+// of origin or another remote, e.g. upstream when origin is a fork. A
+// branch that does not exist is not an error.
+func DeleteBranch(originPath, branch string, force bool) error {
+	if branch == "" || branch == "HEAD" {
+		return nil
+	}
+	if _, err := git(originPath, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err != nil {
+		return nil
+	}
+	if !force && !isMerged(originPath, "refs/heads/"+branch) {
+		return ErrBranchUnmerged
+	}
+	if _, err := git(originPath, "branch", "-D", branch); err != nil {
+		return err
+	}
+	audit.Record(audit.Event{Action: audit.BranchDeleted, Repo: filepath.Base(originPath), Target: branch})
+	return nil
+}
+
+// Remove force-removes the worktree at path from the clone at originPath
+// and records it in the audit log with detail, e.g. why cleanup removed
+// it. It returns git's output.
+func Remove(originPath, path, detail string) (string, error) {
+	out, err := git(originPath, "worktree", "remove", path, "--force")
+	if err != nil {
+		return out, err
+	}
+	audit.Record(audit.Event{Action: audit.WorktreeRemoved, Repo: filepath.Base(originPath), Target: path, Detail: detail})
+	return out, nil
+}
+
+// isMerged reports whether ref is merged into the default branch of any
+// remote of the clone at originPath, origin first.
+func isMerged(originPath, ref string) bool {
+	remotes := []string{"origin"}
+	if out, err := git(originPath, "remote"); err == nil {
+		for _, r := range strings.Split(out, "\n") {
+			if r != "" && r != "origin" {
+				remotes = append(remotes, r)
+			}
+		}
+	}
+	for _, remote := range remotes {
+		if _, err := git(originPath, "merge-base", "--is-ancestor", ref, RemoteDefaultBranch(originPath, remote)); err == nil {
+			return true
+		}
+	}
+	return false
+}
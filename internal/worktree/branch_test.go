@@ -0,0 +1,153 @@
+package worktree
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mgreau/zen/internal/audit"
+)
+
+func TestDeleteBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	for k, v := range map[string]string{
+		"GIT_AUTHOR_NAME": "t", "GIT_AUTHOR_EMAIL": "t@example.com",
+		"GIT_COMMITTER_NAME": "t", "GIT_COMMITTER_EMAIL": "t@example.com",
+	} {
+		t.Setenv(k, v)
+	}
+
+	base := t.TempDir()
+	upstream := filepath.Join(base, "upstream")
+	origin := filepath.Join(base, "mono")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		if out, err := git(dir, args...); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	os.MkdirAll(upstream, 0o755)
+	run(upstream, "init", "-q", "-b", "main")
+	os.WriteFile(filepath.Join(upstream, "README"), []byte("hi\n"), 0o644)
+	run(upstream, "add", "README")
+	run(upstream, "commit", "-q", "-m", "init")
+	run(base, "clone", "-q", upstream, origin)
+
+	if got := DefaultBranch(origin); got != "origin/main" {
+		t.Errorf("DefaultBranch() = %q, want origin/main", got)
+	}
+
+	// Merged: no commits beyond origin/main
+	run(origin, "branch", "merged")
+	// Unmerged: one local commit
+	run(origin, "checkout", "-q", "-b", "unmerged")
+	os.WriteFile(filepath.Join(origin, "wip.go"), []byte("package wip\n"), 0o644)
+	run(origin, "add", "wip.go")
+	run(origin, "commit", "-q", "-m", "wip")
+	run(origin, "checkout", "-q", "main")
+
+	if err := DeleteBranch(origin, "merged", false); err != nil {
+		t.Errorf("DeleteBranch(merged) error: %v", err)
+	}
+	if err := DeleteBranch(origin, "unmerged", false); !errors.Is(err, ErrBranchUnmerged) {
+		t.Errorf("DeleteBranch(unmerged) = %v, want ErrBranchUnmerged", err)
+	}
+	if _, err := git(origin, "rev-parse", "--verify", "refs/heads/unmerged"); err != nil {
+		t.Error("unmerged branch was deleted without force")
+	}
+	if err := DeleteBranch(origin, "unmerged", true); err != nil {
+		t.Errorf("DeleteBranch(unmerged, force) error: %v", err)
+	}
+	for _, b := range []string{"merged", "unmerged"} {
+		if _, err := git(origin, "rev-parse", "--verify", "refs/heads/"+b); err == nil {
+			t.Errorf("branch %s still exists", b)
+		}
+	}
+
+	if err := DeleteBranch(origin, "missing", false); err != nil {
+		t.Errorf("DeleteBranch(missing) error: %v", err)
+	}
+
+	// Triangular: merged into another remote's main, not origin's
+	canon := filepath.Join(base, "canon.git")
+	run(base, "clone", "-q", "--bare", upstream, canon)
+	run(origin, "remote", "add", "canon", canon)
+	run(origin, "checkout", "-q", "-b", "landed")
+	os.WriteFile(filepath.Join(origin, "feature.go"), []byte("package feature\n"), 0o644)
+	run(origin, "add", "feature.go")
+	run(origin, "commit", "-q", "-m", "feature")
+	run(origin, "checkout", "-q", "main")
+	run(origin, "push", "-q", "canon", "landed:main")
+	run(origin, "fetch", "-q", "canon")
+	if got := RemoteDefaultBranch(origin, "canon"); got != "canon/main" {
+		t.Errorf("RemoteDefaultBranch(canon) = %q, want canon/main", got)
+	}
+	if err := DeleteBranch(origin, "landed", false); err != nil {
+		t.Errorf("DeleteBranch(landed) error: %v; want it merged via canon/main", err)
+	}
+
+	// Only actual deletions are audited
+	events, _ := audit.Read(time.Time{})
+	var deleted []string
+	for _, ev := range events {
+		if ev.Action == audit.BranchDeleted && ev.Repo == "mono" {
+			deleted = append(deleted, ev.Target)
+		}
+	}
+	if want := []string{"merged", "unmerged", "landed"}; !slices.Equal(deleted, want) {
+		t.Errorf("audited branch deletions = %v, want %v", deleted, want)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	for k, v := range map[string]string{
+		"GIT_AUTHOR_NAME": "t", "GIT_AUTHOR_EMAIL": "t@example.com",
+		"GIT_COMMITTER_NAME": "t", "GIT_COMMITTER_EMAIL": "t@example.com",
+	} {
+		t.Setenv(k, v)
+	}
+
+	base := t.TempDir()
+	origin := filepath.Join(base, "mono")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		if out, err := git(dir, args...); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	os.MkdirAll(origin, 0o755)
+	run(origin, "init", "-q", "-b", "main")
+	os.WriteFile(filepath.Join(origin, "README"), []byte("hi\n"), 0o644)
+	run(origin, "add", "README")
+	run(origin, "commit", "-q", "-m", "init")
+	path := filepath.Join(base, "mono-pr-7")
+	run(origin, "worktree", "add", "-q", "-b", "pr-7", path)
+	os.WriteFile(filepath.Join(path, "wip.go"), []byte("package wip\n"), 0o644)
+
+	if out, err := Remove(origin, path, "cleanup: PR merged"); err != nil {
+		t.Fatalf("Remove() error: %v: %s", err, out)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("worktree with changes still exists: %v", err)
+	}
+	if _, err := Remove(origin, path, ""); err == nil {
+		t.Error("Remove() of a missing worktree should fail")
+	}
+
+	events, _ := audit.Read(time.Time{})
+	if len(events) != 1 || events[0].Action != audit.WorktreeRemoved || events[0].Target != path ||
+		events[0].Repo != "mono" || events[0].Detail != "cleanup: PR merged" {
+		t.Errorf("audit events = %+v, want the one removal", events)
+	}
+}
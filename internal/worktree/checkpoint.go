@@ -0,0 +1,171 @@
+package worktree
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// checkpointRefs is where checkpoints are stored. refs/worktree/ is private
+// to each worktree, so two worktrees of a repo can use the same names.
+const checkpointRefs = "refs/worktree/zen/checkpoints/"
+
+// Checkpoint is a snapshot of a worktree's files, committed on top of the
+// HEAD it was taken from without moving HEAD or touching the index.
+type Checkpoint struct {
+	Name    string    `json:"name"`
+	SHA     string    `json:"sha"`
+	Base    string    `json:"base"` // HEAD when the checkpoint was taken
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+	Files   int       `json:"files"` // paths differing from Base
+}
+
+// CreateCheckpoint snapshots every tracked and untracked (not ignored) file
+// in the worktree at path as a commit whose parent is HEAD, and records it
+// as checkpoint name. The worktree, index and branch are left untouched.
+func CreateCheckpoint(path, name, message string) (*Checkpoint, error) {
+	ref := checkpointRefs + name
+	if _, err := git(path, "check-ref-format", ref); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint name %q", name)
+	}
+	if _, err := git(path, "rev-parse", "--verify", "--quiet", ref); err == nil {
+		return nil, fmt.Errorf("checkpoint %q already exists -- delete it or pick another name", name)
+	}
+	head, err := git(path, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := snapshotTree(path, head)
+	if err != nil {
+		return nil, err
+	}
+	if message == "" {
+		message = "zen checkpoint " + name
+	}
+	sha, err := git(path, "commit-tree", tree, "-p", head, "-m", message)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := git(path, "update-ref", "-m", "zen work checkpoint", ref, sha, ""); err != nil {
+		return nil, err
+	}
+	return readCheckpoint(path, name)
+}
+
+// snapshotTree writes a tree of the worktree's current files, using a
+// throwaway index seeded from head so the real index keeps what is staged.
+func snapshotTree(path, head string) (string, error) {
+	dir, err := os.MkdirTemp("", "zen-checkpoint-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(dir, "index")}
+
+	for _, args := range [][]string{
+		{"read-tree", head},
+		{"add", "--all"},
+	} {
+		if _, err := gitEnv(path, env, args...); err != nil {
+			return "", err
+		}
+	}
+	return gitEnv(path, env, "write-tree")
+}
+
+// gitEnv runs a local git command with extra environment variables.
+func gitEnv(dir string, env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	trimmed := strings.TrimSpace(string(out))
+	if err != nil {
+		return trimmed, fmt.Errorf("git %s: %w: %s", args[0], err, trimmed)
+	}
+	return trimmed, nil
+}
+
+// ListCheckpoints returns the worktree's checkpoints, newest first.
+func ListCheckpoints(path string) ([]Checkpoint, error) {
+	out, err := git(path, "for-each-ref", "--sort=-creatordate", "--format=%(refname)", checkpointRefs)
+	if err != nil {
+		return nil, err
+	}
+	var cps []Checkpoint
+	for _, ref := range strings.Fields(out) {
+		cp, err := readCheckpoint(path, strings.TrimPrefix(ref, checkpointRefs))
+		if err != nil {
+			return nil, err
+		}
+		cps = append(cps, *cp)
+	}
+	return cps, nil
+}
+
+func readCheckpoint(path, name string) (*Checkpoint, error) {
+	ref := checkpointRefs + name
+	out, err := git(path, "log", "-1", "--format=%H%x00%P%x00%ct%x00%s", ref, "--")
+	if err != nil {
+		return nil, fmt.Errorf("no checkpoint %q", name)
+	}
+	fields := strings.SplitN(out, "\x00", 4)
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("unexpected git log output for checkpoint %q: %q", name, out)
+	}
+	unix, _ := strconv.ParseInt(fields[2], 10, 64)
+	cp := &Checkpoint{
+		Name:    name,
+		SHA:     fields[0],
+		Base:    fields[1],
+		Message: fields[3],
+		Time:    time.Unix(unix, 0),
+	}
+	if files, err := git(path, "diff", "--name-only", "--no-renames", cp.Base, cp.SHA); err == nil && files != "" {
+		cp.Files = len(strings.Split(files, "\n"))
+	}
+	return cp, nil
+}
+
+// RestoreCheckpoint puts the worktree back to checkpoint name: the branch is
+// reset to the HEAD the checkpoint was taken from and the files are
+// rewritten to their checkpointed content, left as unstaged changes.
+// Untracked files created since are kept. The current state is first saved
+// as a new checkpoint, whose name is returned, so the restore can be undone.
+func RestoreCheckpoint(path, name string) (string, error) {
+	cp, err := readCheckpoint(path, name)
+	if err != nil {
+		return "", err
+	}
+
+	backup := "before-restore-" + time.Now().Format("20060102-150405")
+	if _, err := CreateCheckpoint(path, backup, "before restoring "+name); err != nil {
+		return "", fmt.Errorf("saving current state: %w", err)
+	}
+
+	for _, args := range [][]string{
+		{"reset", "--quiet", "--hard", cp.Base},
+		{"read-tree", "--reset", "-u", cp.SHA},
+		{"reset", "--quiet"},
+	} {
+		if _, err := git(path, args...); err != nil {
+			return backup, err
+		}
+	}
+	return backup, nil
+}
+
+// DeleteCheckpoint removes checkpoint name.
+func DeleteCheckpoint(path, name string) error {
+	if _, err := readCheckpoint(path, name); err != nil {
+		return err
+	}
+	_, err := git(path, "update-ref", "-d", checkpointRefs+name)
+	return err
+}
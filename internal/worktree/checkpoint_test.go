@@ -0,0 +1,100 @@
+package worktree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	mainPath, wtPath := initSyncRepo(t)
+	t.Setenv("GIT_AUTHOR_NAME", "t")
+	t.Setenv("GIT_AUTHOR_EMAIL", "t@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "t")
+	t.Setenv("GIT_COMMITTER_EMAIL", "t@example.com")
+
+	write := func(name, data string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(wtPath, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(wtPath, name))
+		if err != nil {
+			return "<missing>"
+		}
+		return string(data)
+	}
+
+	// Staged and untracked changes both go into the checkpoint
+	write("a.txt", "good\n")
+	write("notes.txt", "untracked\n")
+	if _, err := git(wtPath, "add", "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	head, _ := git(wtPath, "rev-parse", "HEAD")
+	statusBefore, _ := git(wtPath, "status", "--porcelain")
+
+	cp, err := CreateCheckpoint(wtPath, "good", "")
+	if err != nil {
+		t.Fatalf("CreateCheckpoint() error: %v", err)
+	}
+	if cp.Base != head || cp.Files != 2 || cp.Message != "zen checkpoint good" {
+		t.Errorf("CreateCheckpoint() = %+v, want base %s and 2 files", cp, head)
+	}
+	if after, _ := git(wtPath, "status", "--porcelain"); after != statusBefore {
+		t.Errorf("status changed by checkpoint: %q, want %q", after, statusBefore)
+	}
+	if _, err := CreateCheckpoint(wtPath, "good", ""); err == nil {
+		t.Error("CreateCheckpoint() should refuse an existing name")
+	}
+
+	// The main clone does not see the worktree's checkpoints
+	if cps, _ := ListCheckpoints(mainPath); len(cps) != 0 {
+		t.Errorf("ListCheckpoints(main) = %v, want none", cps)
+	}
+
+	// Claude breaks things and commits
+	write("a.txt", "broken\n")
+	os.Remove(filepath.Join(wtPath, "notes.txt"))
+	if _, err := git(wtPath, "commit", "-q", "-am", "oops"); err != nil {
+		t.Fatal(err)
+	}
+	broken, _ := git(wtPath, "rev-parse", "HEAD")
+
+	backup, err := RestoreCheckpoint(wtPath, "good")
+	if err != nil {
+		t.Fatalf("RestoreCheckpoint() error: %v", err)
+	}
+	if got, _ := git(wtPath, "rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD after restore = %s, want %s", got, head)
+	}
+	if read("a.txt") != "good\n" || read("notes.txt") != "untracked\n" {
+		t.Errorf("files after restore: a.txt=%q notes.txt=%q", read("a.txt"), read("notes.txt"))
+	}
+
+	cps, err := ListCheckpoints(wtPath)
+	if err != nil {
+		t.Fatalf("ListCheckpoints() error: %v", err)
+	}
+	if len(cps) != 2 {
+		t.Fatalf("ListCheckpoints() = %d checkpoints, want 2", len(cps))
+	}
+	var saved *Checkpoint
+	for i := range cps {
+		if cps[i].Name == backup {
+			saved = &cps[i]
+		}
+	}
+	if saved == nil || saved.Base != broken {
+		t.Errorf("backup checkpoint %q = %+v, want base %s", backup, saved, broken)
+	}
+
+	if err := DeleteCheckpoint(wtPath, "good"); err != nil {
+		t.Fatalf("DeleteCheckpoint() error: %v", err)
+	}
+	if err := DeleteCheckpoint(wtPath, "good"); err == nil {
+		t.Error("DeleteCheckpoint() of a missing checkpoint should fail")
+	}
+}
@@ -1,10 +1,8 @@
 package worktree
 
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -29,9 +27,17 @@ type Worktree struct {
 	Type     Type   `json:"type"`
 	PRNumber int    `json:"pr_number,omitempty"`
 	Repo     string `json:"repo"`
+	// OpenedPR is the PR opened from a feature worktree with zen pr create.
+	OpenedPR int `json:"opened_pr,omitempty"`
+	// Suffix names an extra checkout of a PR, e.g. "tests" for
+	// mono-pr-42-tests; it is empty for the PR's main review worktree.
+	Suffix string `json:"suffix,omitempty"`
 }
 
-var prPattern = regexp.MustCompile(`-pr-(\d+)$`)
+var (
+	prPattern     = regexp.MustCompile(`-pr-(\d+)(?:-([A-Za-z0-9][A-Za-z0-9_]*(?:-[A-Za-z0-9_]+)*))?$`)
+	suffixPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_]*(?:-[A-Za-z0-9_]+)*$`)
+)
 
 // Classify determines if a worktree name represents a PR review or feature work.
 func Classify(name string) (Type, int) {
@@ -44,6 +50,43 @@ func Classify(name string) (Type, int) {
 	return TypeFeature, 0
 }
 
+// PRSuffix returns the suffix of an extra PR review worktree name, e.g.
+// "tests" for "mono-pr-42-tests", or "" for anything else.
+func PRSuffix(name string) string {
+	if m := prPattern.FindStringSubmatch(name); m != nil {
+		return m[2]
+	}
+	return ""
+}
+
+// PRName returns the directory name of a PR review worktree: <repo>-pr-<n>,
+// or <repo>-pr-<n>-<suffix> for an extra checkout of the same PR.
+func PRName(repo string, pr int, suffix string) string {
+	if suffix == "" {
+		return fmt.Sprintf("%s-pr-%d", repo, pr)
+	}
+	return fmt.Sprintf("%s-pr-%d-%s", repo, pr, suffix)
+}
+
+// PRBranch returns the local branch a PR review worktree checks out. Each
+// checkout of a PR needs its own branch, since git allows a branch in only
+// one worktree.
+func PRBranch(pr int, suffix string) string {
+	if suffix == "" {
+		return fmt.Sprintf("pr-%d", pr)
+	}
+	return fmt.Sprintf("pr-%d-%s", pr, suffix)
+}
+
+// ValidateSuffix checks a suffix for an extra PR review worktree: letters,
+// digits, underscores and single hyphens.
+func ValidateSuffix(suffix string) error {
+	if !suffixPattern.MatchString(suffix) {
+		return fmt.Errorf("invalid worktree name suffix %q: use letters, digits, '_' and '-'", suffix)
+	}
+	return nil
+}
+
 // ParseRepoFromName extracts the repo short name from a worktree directory name.
 // e.g., "mono-pr-1234" -> "mono"
 func ParseRepoFromName(name string) string {
@@ -64,7 +107,8 @@ func ParseBranchFromName(name string) string {
 	return name[idx+1:]
 }
 
-// ListForRepo lists all worktrees for a given repository using `git worktree list`.
+// ListForRepo lists all worktrees for a given repository using `git worktree
+// list`, cached until the repo's worktrees change.
 func ListForRepo(cfg *config.Config, repo string) ([]Worktree, error) {
 	basePath := cfg.RepoBasePath(repo)
 	if basePath == "" {
@@ -79,39 +123,25 @@ func ListForRepo(cfg *config.Config, repo string) ([]Worktree, error) {
 	// Clean stale locks before git operations
 	CleanStaleLocks(cfg, repo)
 
-	cmd := exec.Command("git", "worktree", "list")
-	cmd.Dir = originPath
-	out, err := cmd.Output()
+	listed, err := gitWorktrees(originPath)
 	if err != nil {
 		ui.LogDebug(fmt.Sprintf("git worktree list failed for %s: %v", repo, err))
 		return nil, nil
 	}
 
-	var worktrees []Worktree
-	scanner := bufio.NewScanner(strings.NewReader(string(out)))
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
+	adopted := LoadMeta()
 
-		parts := strings.Fields(line)
-		if len(parts) < 1 {
-			continue
-		}
-		path := parts[0]
+	var worktrees []Worktree
+	for _, e := range listed {
+		path, branch := e.Path, e.Branch
 
 		// Skip the main worktree
 		if path == originPath {
 			continue
 		}
-
-		// Extract branch from [branch] notation
-		branch := ""
-		if idx := strings.Index(line, "["); idx >= 0 {
-			if end := strings.Index(line[idx:], "]"); end >= 0 {
-				branch = line[idx+1 : idx+end]
-			}
+		// Pooled worktrees are not in use yet, bench ones are scratch
+		if IsPooled(path) || IsBench(path) {
+			continue
 		}
 
 		name := filepath.Base(path)
@@ -126,6 +156,10 @@ func ListForRepo(cfg *config.Config, repo string) ([]Worktree, error) {
 		}
 		if pr > 0 {
 			wt.PRNumber = pr
+			wt.Suffix = PRSuffix(name)
+		}
+		if m, ok := adoptedMeta(adopted, path); ok {
+			m.apply(&wt)
 		}
 		worktrees = append(worktrees, wt)
 	}
@@ -133,6 +167,22 @@ func ListForRepo(cfg *config.Config, repo string) ([]Worktree, error) {
 	return worktrees, nil
 }
 
+// adoptedMeta looks up metadata for a worktree path as listed by git, which
+// may differ from the symlink-resolved path recorded at adoption.
+func adoptedMeta(adopted map[string]Meta, path string) (Meta, bool) {
+	if len(adopted) == 0 {
+		return Meta{}, false
+	}
+	if m, ok := adopted[path]; ok {
+		return m, true
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		m, ok := adopted[resolved]
+		return m, ok
+	}
+	return Meta{}, false
+}
+
 // ListAll lists worktrees across all configured repositories.
 func ListAll(cfg *config.Config) ([]Worktree, error) {
 	var all []Worktree
@@ -15,6 +15,9 @@ func TestClassify(t *testing.T) {
 		{"mono-claude-skills", TypeFeature, 0},
 		{"infra-images-pr-500", TypePRReview, 500},
 		{"solo", TypeFeature, 0},
+		{"mono-pr-42-tests", TypePRReview, 42},
+		{"mono-pr-42-run-tests", TypePRReview, 42},
+		{"mono-pr-42-", TypeFeature, 0},
 	}
 
 	for _, tt := range tests {
@@ -30,6 +33,35 @@ func TestClassify(t *testing.T) {
 	}
 }
 
+func TestPRSuffix(t *testing.T) {
+	tests := map[string]string{
+		"mono-pr-42":           "",
+		"mono-pr-42-tests":     "tests",
+		"mono-pr-42-run-tests": "run-tests",
+		"mono-feature":         "",
+	}
+	for name, want := range tests {
+		if got := PRSuffix(name); got != want {
+			t.Errorf("PRSuffix(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	if got := PRName("mono", 42, "tests"); got != "mono-pr-42-tests" {
+		t.Errorf("PRName() = %q", got)
+	}
+	if got := PRBranch(42, ""); got != "pr-42" {
+		t.Errorf("PRBranch() = %q", got)
+	}
+	for _, bad := range []string{"", "-x", "a--b", "a/b", "x-"} {
+		if ValidateSuffix(bad) == nil {
+			t.Errorf("ValidateSuffix(%q) = nil, want error", bad)
+		}
+	}
+	if err := ValidateSuffix("tests-2"); err != nil {
+		t.Errorf("ValidateSuffix(tests-2) = %v", err)
+	}
+}
+
 func TestParseRepoFromName(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,65 @@
+package worktree
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// excludeHeader marks the patterns added by Exclude.
+const excludeHeader = "# Added by zen"
+
+// Exclude adds patterns to the info/exclude file of the repo of the
+// worktree at path, so files zen writes into worktrees (CLAUDE.local.md
+// and the like) don't show up in git status or get committed by accident.
+// Patterns already listed are skipped. The file is shared by all
+// worktrees of the repo.
+func Exclude(path string, patterns []string) error {
+	file, err := git(path, "rev-parse", "--path-format=absolute", "--git-path", "info/exclude")
+	if err != nil {
+		return err
+	}
+	file = strings.TrimSpace(file)
+	data, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	existing := strings.Split(string(data), "\n")
+	for i := range existing {
+		existing[i] = strings.TrimSpace(existing[i])
+	}
+
+	var missing []string
+	for _, p := range patterns {
+		if p != "" && !slices.Contains(existing, p) && !slices.Contains(missing, p) {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		b.WriteString("\n")
+	}
+	if !slices.Contains(existing, excludeHeader) {
+		b.WriteString(excludeHeader + "\n")
+	}
+	for _, p := range missing {
+		b.WriteString(p + "\n")
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
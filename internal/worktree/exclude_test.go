@@ -0,0 +1,62 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExclude(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	for k, v := range map[string]string{
+		"GIT_AUTHOR_NAME": "t", "GIT_AUTHOR_EMAIL": "t@example.com",
+		"GIT_COMMITTER_NAME": "t", "GIT_COMMITTER_EMAIL": "t@example.com",
+	} {
+		t.Setenv(k, v)
+	}
+
+	base, _ := filepath.EvalSymlinks(t.TempDir())
+	origin := filepath.Join(base, "mono")
+	review := filepath.Join(base, "mono-pr-5")
+	run := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := git(dir, args...)
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+		return out
+	}
+	os.MkdirAll(origin, 0o755)
+	run(origin, "init", "-q", "-b", "main")
+	run(origin, "commit", "-q", "--allow-empty", "-m", "init")
+	run(origin, "worktree", "add", "-q", review, "-b", "pr-5")
+
+	exclude := filepath.Join(origin, ".git", "info", "exclude")
+	os.WriteFile(exclude, []byte("*.log"), 0o644)
+
+	patterns := []string{"CLAUDE.local.md", ".zen/", "*.log"}
+	if err := Exclude(review, patterns); err != nil {
+		t.Fatalf("Exclude() error: %v", err)
+	}
+	// A second run adds nothing
+	if err := Exclude(review, patterns); err != nil {
+		t.Fatalf("Exclude() again: %v", err)
+	}
+	data, _ := os.ReadFile(exclude)
+	if want := "*.log\n# Added by zen\nCLAUDE.local.md\n.zen/\n"; string(data) != want {
+		t.Errorf("info/exclude = %q; want %q", data, want)
+	}
+
+	os.WriteFile(filepath.Join(review, "CLAUDE.local.md"), []byte("context"), 0o644)
+	os.MkdirAll(filepath.Join(review, ".zen"), 0o755)
+	os.WriteFile(filepath.Join(review, ".zen", "notes.md"), []byte("notes"), 0o644)
+	os.WriteFile(filepath.Join(review, "main.go"), []byte("package main"), 0o644)
+	if got := strings.TrimSpace(run(review, "status", "--porcelain")); got != "?? main.go" {
+		t.Errorf("git status = %q; want only main.go untracked", got)
+	}
+}
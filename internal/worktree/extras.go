@@ -0,0 +1,60 @@
+package worktree
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mgreau/zen/internal/config"
+)
+
+// Progress receives the steps of InitExtras, e.g. a *ui.Steps.
+type Progress interface {
+	Step(name string)
+	Info(msg string)
+	Done(err error)
+}
+
+// UsesLFS reports whether the checkout at path tracks files with Git LFS.
+func UsesLFS(path string) bool {
+	data, err := os.ReadFile(filepath.Join(path, ".gitattributes"))
+	return err == nil && bytes.Contains(data, []byte("filter=lfs"))
+}
+
+// HasSubmodules reports whether the checkout at path has submodules.
+func HasSubmodules(path string) bool {
+	_, err := os.Stat(filepath.Join(path, ".gitmodules"))
+	return err == nil
+}
+
+// InitExtras completes a new worktree of repo at path: it pulls the LFS
+// files, which a plain checkout leaves as pointers, and initializes the
+// submodules, each when the repo uses them and the config (lfs,
+// submodules) allows it. Failures are reported as warnings, since the rest
+// of the checkout is usable.
+func InitExtras(ctx context.Context, cfg *config.Config, repo, path string, p Progress) {
+	timeout := cfg.RepoGitTimeout(repo)
+	if cfg.RepoLFS(repo) && UsesLFS(path) {
+		if _, err := exec.LookPath("git-lfs"); err != nil {
+			p.Info("Warning: the repo uses Git LFS but git-lfs is not installed: LFS files are left as pointers")
+		} else {
+			p.Step("git lfs pull")
+			_, err := Git(ctx, timeout, path, "lfs", "pull")
+			p.Done(err)
+			if err != nil {
+				p.Info(fmt.Sprintf("Warning: LFS files not pulled: %v", err))
+			}
+		}
+	}
+	if cfg.RepoSubmodules(repo) && HasSubmodules(path) {
+		p.Step("git submodule update --init --recursive")
+		_, err := Git(ctx, timeout, path, "submodule", "update", "--init", "--recursive")
+		p.Done(err)
+		if err != nil {
+			p.Info(fmt.Sprintf("Warning: submodules not initialized: %v", err))
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package worktree
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/ui"
+)
+
+func TestInitExtrasSubmodules(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	for k, v := range map[string]string{
+		"GIT_AUTHOR_NAME": "t", "GIT_AUTHOR_EMAIL": "t@example.com",
+		"GIT_COMMITTER_NAME": "t", "GIT_COMMITTER_EMAIL": "t@example.com",
+		// Local submodules are refused by default since git 2.38.1
+		"GIT_CONFIG_COUNT": "1", "GIT_CONFIG_KEY_0": "protocol.file.allow", "GIT_CONFIG_VALUE_0": "always",
+	} {
+		t.Setenv(k, v)
+	}
+
+	base := t.TempDir()
+	sub := filepath.Join(base, "lib")
+	origin := filepath.Join(base, "mono")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		if out, err := git(dir, args...); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	for _, dir := range []string{sub, origin} {
+		os.MkdirAll(dir, 0o755)
+		run(dir, "init", "-q", "-b", "main")
+		os.WriteFile(filepath.Join(dir, "README"), []byte("hi\n"), 0o644)
+		run(dir, "add", "README")
+		run(dir, "commit", "-q", "-m", "init")
+	}
+	run(origin, "submodule", "add", "-q", sub, "lib")
+	run(origin, "commit", "-q", "-m", "add lib")
+
+	off := false
+	cfg := &config.Config{Repos: map[string]config.RepoConfig{
+		"mono": {BasePath: base},
+		"off":  {BasePath: base, Submodules: &off},
+	}}
+	steps := ui.NewStepLogger(func(string) {})
+	for repo, want := range map[string]bool{"mono": true, "off": false} {
+		path := filepath.Join(base, repo+"-feature")
+		run(origin, "worktree", "add", "-q", "-b", repo+"-feature", path)
+		if !HasSubmodules(path) {
+			t.Fatalf("HasSubmodules(%s) = false", path)
+		}
+		InitExtras(context.Background(), cfg, repo, path, steps)
+		_, err := os.Stat(filepath.Join(path, "lib", "README"))
+		if got := err == nil; got != want {
+			t.Errorf("%s: submodule checked out = %v, want %v", repo, got, want)
+		}
+	}
+}
+
+func TestUsesLFS(t *testing.T) {
+	dir := t.TempDir()
+	if UsesLFS(dir) {
+		t.Error("UsesLFS() without .gitattributes = true")
+	}
+	os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte("*.go diff=golang\n"), 0o644)
+	if UsesLFS(dir) {
+		t.Error("UsesLFS() without an lfs filter = true")
+	}
+	os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte("*.psd filter=lfs diff=lfs merge=lfs -text\n"), 0o644)
+	if !UsesLFS(dir) {
+		t.Error("UsesLFS() with an lfs filter = false")
+	}
+}
@@ -0,0 +1,45 @@
+package worktree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FetchOptions controls how much history and data a PR fetch downloads.
+type FetchOptions struct {
+	Depth  int    // --depth; 0 fetches full history
+	Filter string // --filter, e.g. "blob:none"; "" fetches all blobs
+	Full   bool   // ignore Depth/Filter and unshallow a shallow clone
+	Remote string // remote to fetch from; "" fetches from origin
+}
+
+// FetchArgs returns the `git fetch` arguments for fetching refspec from
+// opts.Remote (origin by default) into the clone at originPath. With opts.Full, a clone made shallow
+// by earlier depth-limited fetches is unshallowed so history is complete.
+func FetchArgs(originPath, refspec string, opts FetchOptions) []string {
+	args := []string{"fetch"}
+	switch {
+	case opts.Full:
+		if IsShallow(originPath) {
+			args = append(args, "--unshallow")
+		}
+	default:
+		if opts.Depth > 0 {
+			args = append(args, fmt.Sprintf("--depth=%d", opts.Depth))
+		}
+		if opts.Filter != "" {
+			args = append(args, "--filter="+opts.Filter)
+		}
+	}
+	remote := opts.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+	return append(args, remote, refspec)
+}
+
+// IsShallow reports whether the repository at path is a shallow clone.
+func IsShallow(path string) bool {
+	out, err := git(path, "rev-parse", "--is-shallow-repository")
+	return err == nil && strings.TrimSpace(out) == "true"
+}
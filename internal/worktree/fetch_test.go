@@ -0,0 +1,37 @@
+package worktree
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestFetchArgs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+
+	tests := []struct {
+		name string
+		opts FetchOptions
+		want []string
+	}{
+		{"default", FetchOptions{}, []string{"fetch", "origin", "+pull/1/head:pr-1"}},
+		{"depth and filter", FetchOptions{Depth: 50, Filter: "blob:none"},
+			[]string{"fetch", "--depth=50", "--filter=blob:none", "origin", "+pull/1/head:pr-1"}},
+		{"full on a non-shallow clone", FetchOptions{Depth: 50, Filter: "blob:none", Full: true},
+			[]string{"fetch", "origin", "+pull/1/head:pr-1"}},
+		{"upstream remote", FetchOptions{Remote: "upstream"}, []string{"fetch", "upstream", "+pull/1/head:pr-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FetchArgs(dir, "+pull/1/head:pr-1", tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FetchArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
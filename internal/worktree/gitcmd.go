@@ -0,0 +1,76 @@
+package worktree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+)
+
+// gitWaitDelay is how long Git waits, after killing git, for helpers it
+// started (e.g. git-remote-https) to release its output.
+var gitWaitDelay = 5 * time.Second
+
+// TimeoutError is returned by Git when a command ran past its timeout and
+// was killed.
+type TimeoutError struct {
+	Op      string // git subcommand, e.g. "fetch"
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("git %s timed out after %s and was killed (network stalled?) -- retry, or raise the repo's git_timeout if it is just slow", e.Op, e.Timeout)
+}
+
+// IsTimeout reports whether err comes from a git command killed for
+// running past its timeout.
+func IsTimeout(err error) bool {
+	var te *TimeoutError
+	return errors.As(err, &te)
+}
+
+// Git runs git with args in dir and returns its trimmed combined output.
+// The command is killed when timeout elapses (DefaultGitTimeout when 0) or
+// ctx is cancelled, so a fetch over a stalled network cannot hang its
+// caller, or everyone waiting on its clone's RepoLock, forever.
+func Git(ctx context.Context, timeout time.Duration, dir string, args ...string) (string, error) {
+	if timeout <= 0 {
+		timeout = config.DefaultGitTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.WaitDelay = gitWaitDelay
+	out, err := cmd.CombinedOutput()
+	trimmed := strings.TrimSpace(string(out))
+	if err == nil {
+		return trimmed, nil
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return trimmed, &TimeoutError{Op: gitOp(args), Timeout: timeout}
+	case ctx.Err() != nil:
+		return trimmed, fmt.Errorf("git %s: %w", gitOp(args), ctx.Err())
+	}
+	return trimmed, fmt.Errorf("git %s: %w: %s", gitOp(args), err, trimmed)
+}
+
+// gitOp returns the subcommand in git args, skipping global options such
+// as "-C <dir>".
+func gitOp(args []string) string {
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "-C" || args[i] == "-c":
+			i++
+		case !strings.HasPrefix(args[i], "-"):
+			return args[i]
+		}
+	}
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,40 @@
+package worktree
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGitTimeout(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	defer func(d time.Duration) { gitWaitDelay = d }(gitWaitDelay)
+	gitWaitDelay = 100 * time.Millisecond
+
+	if out, err := Git(context.Background(), time.Minute, t.TempDir(), "--version"); err != nil || !strings.HasPrefix(out, "git version") {
+		t.Fatalf("Git(--version) = %q, %v", out, err)
+	}
+
+	// The alias's sleep outlives the killed git and keeps its output open
+	start := time.Now()
+	_, err := Git(context.Background(), 200*time.Millisecond, t.TempDir(), "-c", "alias.hang=!sleep 30", "hang")
+	if !IsTimeout(err) {
+		t.Fatalf("Git(hang) error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Git(hang) returned after %s, want shortly after the timeout", elapsed)
+	}
+	if !strings.Contains(err.Error(), "git hang timed out") || !strings.Contains(err.Error(), "git_timeout") {
+		t.Errorf("timeout error %q should point at git_timeout", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Git(ctx, time.Minute, t.TempDir(), "--version"); err == nil || IsTimeout(err) {
+		t.Errorf("Git(cancelled) error = %v, want a cancellation error", err)
+	}
+}
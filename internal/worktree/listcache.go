@@ -0,0 +1,241 @@
+package worktree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// gitEntry is a worktree as listed by git worktree list.
+type gitEntry struct {
+	Path   string `json:"path"`
+	Branch string `json:"branch,omitempty"`
+}
+
+// listCacheEntry is the cached listing of one clone. Stamp fingerprints
+// the clone's .git/worktrees when the listing was taken.
+type listCacheEntry struct {
+	Stamp   string     `json:"stamp"`
+	Entries []gitEntry `json:"entries"`
+
+	gen uint64 // watch generation the entry was taken at, see WatchLists
+}
+
+var (
+	listCacheMu     sync.Mutex
+	listCacheLoaded bool
+	listCache       = make(map[string]listCacheEntry) // by clone path
+
+	// Clones whose .git/worktrees WatchLists watches, with a generation
+	// bumped on every change. A cached listing taken at the current
+	// generation is used without checking the stamp.
+	listWatched = make(map[string]uint64)
+)
+
+func listCachePath() string {
+	return filepath.Join(config.StateDir(), "worktree_list.json")
+}
+
+// loadListCacheLocked reads the cache file once per process.
+func loadListCacheLocked() {
+	if listCacheLoaded {
+		return
+	}
+	listCacheLoaded = true
+	data, err := os.ReadFile(listCachePath())
+	if err != nil {
+		return
+	}
+	json.Unmarshal(data, &listCache)
+}
+
+// listStamp fingerprints the worktree admin dirs of a clone: git adds and
+// removes them with the worktrees, and rewrites their HEAD on checkout and
+// gitdir on move. ok is false when the clone's .git is not a directory.
+func listStamp(originPath string) (stamp string, ok bool) {
+	gitDir := filepath.Join(originPath, ".git")
+	if fi, err := os.Stat(gitDir); err != nil || !fi.IsDir() {
+		return "", false
+	}
+	dir := filepath.Join(gitDir, "worktrees")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "none", true
+	}
+	h := fnv.New64a()
+	for _, e := range entries {
+		fmt.Fprint(h, e.Name())
+		for _, f := range []string{"HEAD", "gitdir"} {
+			if fi, err := os.Stat(filepath.Join(dir, e.Name(), f)); err == nil {
+				fmt.Fprintf(h, " %s:%d", f, fi.ModTime().UnixNano())
+			}
+		}
+		fmt.Fprintln(h)
+	}
+	return strconv.FormatUint(h.Sum64(), 16), true
+}
+
+// gitWorktrees lists the worktrees of the clone at originPath, including
+// the clone itself. The listing is cached in the state dir and reused
+// until the clone's worktrees change, which saves running git in every
+// command.
+func gitWorktrees(originPath string) ([]gitEntry, error) {
+	listCacheMu.Lock()
+	loadListCacheLocked()
+	cached, hit := listCache[originPath]
+	gen, watched := listWatched[originPath]
+	listCacheMu.Unlock()
+	if hit && watched && cached.gen == gen {
+		return cached.Entries, nil
+	}
+
+	stamp, cacheable := listStamp(originPath)
+	if hit && cacheable && cached.Stamp == stamp {
+		if watched {
+			listCacheMu.Lock()
+			cached.gen = gen
+			listCache[originPath] = cached
+			listCacheMu.Unlock()
+		}
+		return cached.Entries, nil
+	}
+
+	cmd := exec.Command("git", "worktree", "list", "--porcelain")
+	cmd.Dir = originPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	entries := parsePorcelain(string(out))
+	if !cacheable {
+		return entries, nil
+	}
+
+	listCacheMu.Lock()
+	defer listCacheMu.Unlock()
+	listCache[originPath] = listCacheEntry{Stamp: stamp, Entries: entries, gen: gen}
+	state.WriteJSON(listCachePath(), listCache)
+	return entries, nil
+}
+
+// parsePorcelain parses git worktree list --porcelain: one block per
+// worktree, with a "worktree <path>" line and a "branch refs/heads/<name>"
+// line unless HEAD is detached.
+func parsePorcelain(out string) []gitEntry {
+	var entries []gitEntry
+	for _, line := range strings.Split(out, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "worktree":
+			entries = append(entries, gitEntry{Path: value})
+		case "branch":
+			if len(entries) > 0 {
+				entries[len(entries)-1].Branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		}
+	}
+	return entries
+}
+
+// WatchLists watches the worktree admin dirs of every configured clone
+// until ctx is done, so long-running processes such as the daemon reuse
+// cached listings without checking them on every call. Clones added to
+// the config later are checked as usual.
+func WatchLists(ctx context.Context, cfg *config.Config) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watching worktrees: %w", err)
+	}
+
+	// Watched dirs by clone: .git, for .git/worktrees to appear, then
+	// .git/worktrees and each worktree's admin dir
+	owners := make(map[string]string)
+	watch := func(dir, originPath string) {
+		if watcher.Add(dir) == nil {
+			owners[dir] = originPath
+		}
+	}
+	listCacheMu.Lock()
+	for _, repo := range cfg.RepoNames() {
+		basePath := cfg.RepoBasePath(repo)
+		if basePath == "" {
+			continue
+		}
+		originPath := filepath.Join(basePath, repo)
+		gitDir := filepath.Join(originPath, ".git")
+		if fi, err := os.Stat(gitDir); err != nil || !fi.IsDir() {
+			continue
+		}
+		watch(gitDir, originPath)
+		worktreesDir := filepath.Join(gitDir, "worktrees")
+		watch(worktreesDir, originPath)
+		entries, _ := os.ReadDir(worktreesDir)
+		for _, e := range entries {
+			if e.IsDir() {
+				watch(filepath.Join(worktreesDir, e.Name()), originPath)
+			}
+		}
+		listWatched[originPath]++
+	}
+	listCacheMu.Unlock()
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				listCacheMu.Lock()
+				clear(listWatched)
+				listCacheMu.Unlock()
+				return
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				dir := filepath.Dir(ev.Name)
+				originPath, ok := owners[dir]
+				if !ok {
+					continue
+				}
+				// In .git, only the worktrees dir matters
+				if filepath.Base(dir) == ".git" {
+					if filepath.Base(ev.Name) != "worktrees" {
+						continue
+					}
+					watch(ev.Name, originPath)
+				} else if filepath.Base(dir) == "worktrees" {
+					if ev.Has(fsnotify.Create) {
+						watch(ev.Name, originPath)
+					}
+				} else if name := filepath.Base(ev.Name); name != "HEAD" && name != "gitdir" {
+					continue // e.g. the worktree's index
+				}
+				listCacheMu.Lock()
+				listWatched[originPath]++
+				listCacheMu.Unlock()
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				// Events may have been dropped
+				listCacheMu.Lock()
+				for originPath := range listWatched {
+					listWatched[originPath]++
+				}
+				listCacheMu.Unlock()
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,86 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParsePorcelain(t *testing.T) {
+	out := "worktree /src/mono\nHEAD 1111\nbranch refs/heads/main\n\n" +
+		"worktree /src/mono pr 5\nHEAD 2222\nbranch refs/heads/pr-5\nlocked\n\n" +
+		"worktree /src/mono-scratch\nHEAD 3333\ndetached\n\n"
+	want := []gitEntry{{"/src/mono", "main"}, {"/src/mono pr 5", "pr-5"}, {"/src/mono-scratch", ""}}
+	if got := parsePorcelain(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePorcelain() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGitWorktreesCache(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	for k, v := range map[string]string{
+		"GIT_AUTHOR_NAME": "t", "GIT_AUTHOR_EMAIL": "t@example.com",
+		"GIT_COMMITTER_NAME": "t", "GIT_COMMITTER_EMAIL": "t@example.com",
+	} {
+		t.Setenv(k, v)
+	}
+
+	base, _ := filepath.EvalSymlinks(t.TempDir())
+	origin := filepath.Join(base, "mono")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		if out, err := git(dir, args...); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	os.MkdirAll(origin, 0o755)
+	run(origin, "init", "-q", "-b", "main")
+	os.WriteFile(filepath.Join(origin, "README"), []byte("hi\n"), 0o644)
+	run(origin, "add", "README")
+	run(origin, "commit", "-q", "-m", "init")
+
+	list := func() []gitEntry {
+		t.Helper()
+		entries, err := gitWorktrees(origin)
+		if err != nil {
+			t.Fatalf("gitWorktrees() error: %v", err)
+		}
+		return entries
+	}
+	if got := list(); len(got) != 1 || got[0].Path != origin {
+		t.Fatalf("gitWorktrees() = %+v, want only the clone", got)
+	}
+
+	// Adding a worktree changes the stamp
+	wtPath := filepath.Join(base, "mono-feature")
+	run(origin, "worktree", "add", "-q", "-b", "feature", wtPath)
+	if got := list(); len(got) != 2 || got[1].Path != wtPath || got[1].Branch != "feature" {
+		t.Fatalf("gitWorktrees() after worktree add = %+v", got)
+	}
+	if _, err := os.Stat(listCachePath()); err != nil {
+		t.Errorf("the listing was not cached: %v", err)
+	}
+
+	// So does a checkout in the worktree; mtimes may be coarse
+	time.Sleep(10 * time.Millisecond)
+	run(wtPath, "checkout", "-q", "-b", "other")
+	if got := list(); got[1].Branch != "other" {
+		t.Errorf("gitWorktrees() after checkout = %+v, want branch other", got)
+	}
+
+	// An unchanged clone is served from the cache
+	listCacheMu.Lock()
+	entry := listCache[origin]
+	entry.Entries = append(entry.Entries, gitEntry{Path: "/cached"})
+	listCache[origin] = entry
+	listCacheMu.Unlock()
+	if got := list(); len(got) != 3 {
+		t.Errorf("gitWorktrees() of an unchanged clone = %+v, want the cached listing", got)
+	}
+}
@@ -14,8 +14,66 @@ import (
 	"github.com/mgreau/zen/internal/ui"
 )
 
-// GitMu serializes git worktree operations to prevent concurrent index.lock conflicts.
-var GitMu sync.Mutex
+// RepoMutex serializes git worktree operations on one origin clone to
+// prevent concurrent index.lock and ref lock conflicts. It excludes other
+// goroutines and, through a flock on <clone>/.git/zen.lock, other zen
+// processes such as the daemon. Operations on different clones run in
+// parallel.
+type RepoMutex struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+}
+
+var (
+	repoLocksMu sync.Mutex
+	repoLocks   = make(map[string]*RepoMutex)
+)
+
+// RepoLock returns the lock of the origin clone at originPath.
+func RepoLock(originPath string) *RepoMutex {
+	key := filepath.Clean(originPath)
+	repoLocksMu.Lock()
+	defer repoLocksMu.Unlock()
+	m, ok := repoLocks[key]
+	if !ok {
+		m = &RepoMutex{path: filepath.Join(key, ".git", "zen.lock")}
+		repoLocks[key] = m
+	}
+	return m
+}
+
+// Lock blocks until no other goroutine or zen process holds the lock. When
+// the lock file cannot be opened (no clone yet), only goroutines of this
+// process are excluded.
+func (m *RepoMutex) Lock() {
+	m.mu.Lock()
+	f, err := os.OpenFile(m.path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return
+	}
+	for {
+		err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			break
+		}
+	}
+	if err != nil {
+		f.Close()
+		return
+	}
+	m.f = f
+}
+
+// Unlock releases the lock.
+func (m *RepoMutex) Unlock() {
+	if m.f != nil {
+		syscall.Flock(int(m.f.Fd()), syscall.LOCK_UN)
+		m.f.Close()
+		m.f = nil
+	}
+	m.mu.Unlock()
+}
 
 // CleanStaleLocks removes stale index.lock files from worktrees of the given repo.
 // A lock is considered stale if the PID inside it is no longer running.
@@ -80,6 +138,17 @@ func CleanupFailedAdd(originPath, worktreePath, branch string) {
 	delCmd.CombinedOutput()
 }
 
+// NeedsCheckout reports whether the worktree at path was added with
+// --no-checkout and never checked out, e.g. because setup was interrupted:
+// HEAD resolves but the index is empty.
+func NeedsCheckout(path string) bool {
+	if _, err := git(path, "rev-parse", "--verify", "HEAD"); err != nil {
+		return false
+	}
+	out, err := git(path, "ls-files")
+	return err == nil && out == ""
+}
+
 // execCommand is a variable for testing.
 var execCommand = exec.Command
 
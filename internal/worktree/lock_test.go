@@ -0,0 +1,69 @@
+package worktree
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRepoLock(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	for _, p := range []string{a, b} {
+		if err := os.MkdirAll(filepath.Join(p, ".git"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if RepoLock(a) != RepoLock(a+"/") {
+		t.Error("RepoLock() returned two locks for one clone")
+	}
+
+	// Clones lock independently
+	RepoLock(a).Lock()
+	locked := make(chan struct{})
+	go func() {
+		RepoLock(b).Lock()
+		RepoLock(b).Unlock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("locking b waited on a")
+	}
+
+	// Other processes are excluded by the flock
+	f, err := os.Open(filepath.Join(a, ".git", "zen.lock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != syscall.EWOULDBLOCK {
+		t.Errorf("flock of a held lock = %v; want EWOULDBLOCK", err)
+	}
+
+	// A second locker of a waits for the first
+	locked = make(chan struct{})
+	go func() {
+		RepoLock(a).Lock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		t.Fatal("a was locked twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+	RepoLock(a).Unlock()
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a was not locked after Unlock")
+	}
+	RepoLock(a).Unlock()
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		t.Errorf("flock after Unlock = %v", err)
+	}
+}
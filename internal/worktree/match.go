@@ -0,0 +1,64 @@
+package worktree
+
+import (
+	"sort"
+	"strings"
+)
+
+// Match returns the worktrees whose name or branch matches query, best
+// first: an exact name, then names containing query, then branches
+// containing it, then names containing its characters in order (e.g.
+// "authfx" for "mono-auth-fix"). Case is ignored, and among equal matches
+// shorter names come first.
+func Match(wts []Worktree, query string) []Worktree {
+	q := strings.ToLower(query)
+	if q == "" {
+		return nil
+	}
+	type match struct {
+		wt   Worktree
+		rank int
+	}
+	var matches []match
+	for _, w := range wts {
+		name, branch := strings.ToLower(w.Name), strings.ToLower(w.Branch)
+		rank := -1
+		switch {
+		case name == q:
+			rank = 0
+		case strings.Contains(name, q):
+			rank = 1
+		case branch != "" && strings.Contains(branch, q):
+			rank = 2
+		case isSubsequence(q, name):
+			rank = 3
+		}
+		if rank >= 0 {
+			matches = append(matches, match{w, rank})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return len(matches[i].wt.Name) < len(matches[j].wt.Name)
+	})
+	out := make([]Worktree, len(matches))
+	for i, m := range matches {
+		out[i] = m.wt
+	}
+	return out
+}
+
+// isSubsequence reports whether the characters of sub appear in s in order.
+func isSubsequence(sub, s string) bool {
+	for _, r := range s {
+		if sub == "" {
+			break
+		}
+		if strings.HasPrefix(sub, string(r)) {
+			sub = sub[len(string(r)):]
+		}
+	}
+	return sub == ""
+}
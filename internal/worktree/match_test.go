@@ -0,0 +1,52 @@
+package worktree
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	wts := []Worktree{
+		{Name: "mono-auth-fix-followup", Branch: "auth-fix-2"},
+		{Name: "mono-auth-fix", Branch: "auth-fix"},
+		{Name: "mono-pr-42", Branch: "pr-42"},
+		{Name: "mono-cache", Branch: "jane/lru-eviction"},
+		{Name: "infra-pr-7", Branch: "pr-7"},
+	}
+	names := func(ws []Worktree) []string {
+		var out []string
+		for _, w := range ws {
+			out = append(out, w.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"mono-pr-42", []string{"mono-pr-42"}},
+		{"AUTH-FIX", []string{"mono-auth-fix", "mono-auth-fix-followup"}},
+		{"lru", []string{"mono-cache"}},
+		{"authfx", []string{"mono-auth-fix", "mono-auth-fix-followup"}},
+		{"pr-7", []string{"infra-pr-7"}},
+		{"zzz", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got := names(Match(wts, tt.query))
+		if len(got) != len(tt.want) {
+			t.Errorf("Match(%q) = %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Match(%q) = %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+
+	// An exact name beats longer names containing it
+	exact := append(wts, Worktree{Name: "mono"})
+	if got := names(Match(exact, "mono")); got[0] != "mono" {
+		t.Errorf("Match(mono) = %v, want the exact name first", got)
+	}
+}
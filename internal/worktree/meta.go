@@ -0,0 +1,167 @@
+package worktree
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mgreau/zen/internal/config"
+	"github.com/mgreau/zen/internal/state"
+)
+
+// Meta records how an adopted worktree should be classified when its
+// directory name doesn't follow zen's <repo>-pr-<n> / <repo>-<branch> scheme.
+// For feature worktrees PRNumber is the PR opened from the branch.
+type Meta struct {
+	Type     Type `json:"type"`
+	PRNumber int  `json:"pr_number,omitempty"`
+}
+
+func metaFile() string {
+	return filepath.Join(config.StateDir(), "worktrees.json")
+}
+
+// LoadMeta reads adopted worktree metadata keyed by absolute path.
+// Returns an empty map on any error.
+func LoadMeta() map[string]Meta {
+	meta := make(map[string]Meta)
+	data, err := os.ReadFile(metaFile())
+	if err != nil {
+		return meta
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return make(map[string]Meta)
+	}
+	return meta
+}
+
+// SetMeta records metadata for the worktree at path, dropping entries for
+// worktrees that no longer exist.
+func SetMeta(path string, m Meta) error {
+	meta := LoadMeta()
+	for p := range meta {
+		if _, err := os.Stat(p); err != nil {
+			delete(meta, p)
+		}
+	}
+	meta[path] = m
+
+	return state.WriteJSON(metaFile(), meta)
+}
+
+// apply overrides name-based classification with adopted metadata.
+func (m Meta) apply(wt *Worktree) {
+	wt.Type = m.Type
+	wt.PRNumber = 0
+	wt.Suffix = ""
+	if m.Type == TypePRReview {
+		wt.PRNumber = m.PRNumber
+	} else {
+		wt.OpenedPR = m.PRNumber
+	}
+}
+
+// RecordOpenedPR records that PR prNumber was opened from the feature
+// worktree at path, keeping any adoption metadata.
+func RecordOpenedPR(path string, prNumber int) error {
+	m, ok := adoptedMeta(LoadMeta(), path)
+	if !ok {
+		m = Meta{Type: TypeFeature}
+	}
+	if m.Type != TypeFeature {
+		return fmt.Errorf("%s is not a feature worktree", path)
+	}
+	m.PRNumber = prNumber
+	return SetMeta(path, m)
+}
+
+// Adoption describes an existing worktree being registered with zen.
+type Adoption struct {
+	Path   string // absolute worktree path
+	Repo   string // configured repo short name
+	Branch string // checked-out branch, "" when detached
+}
+
+// FindAdoption checks that path is a linked worktree of one of the
+// configured repos' origin clones and returns what it belongs to. repo, if
+// non-empty, restricts the search to that repo.
+func FindAdoption(cfg *config.Config, path, repo string) (*Adoption, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return nil, fmt.Errorf("worktree path: %w", err)
+	}
+
+	commonDir, err := git(abs, "rev-parse", "--path-format=absolute", "--git-common-dir")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a git checkout: %w", abs, err)
+	}
+	topLevel, err := git(abs, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	if topLevel != abs {
+		return nil, fmt.Errorf("%s is inside worktree %s -- pass the worktree root", abs, topLevel)
+	}
+
+	candidates := cfg.RepoNames()
+	if repo != "" {
+		candidates = []string{repo}
+	}
+	for _, r := range candidates {
+		originPath := filepath.Join(cfg.RepoBasePath(r), r)
+		originGit := filepath.Join(originPath, ".git")
+		if resolved, err := filepath.EvalSymlinks(originGit); err == nil {
+			originGit = resolved
+		}
+		if filepath.Clean(commonDir) != originGit {
+			continue
+		}
+		if resolved, err := filepath.EvalSymlinks(originPath); err == nil && resolved == abs {
+			return nil, fmt.Errorf("%s is the main clone of %s, not a worktree", abs, r)
+		}
+		branch, _ := git(abs, "symbolic-ref", "--quiet", "--short", "HEAD")
+		return &Adoption{Path: abs, Repo: r, Branch: strings.TrimSpace(branch)}, nil
+	}
+	if repo != "" {
+		return nil, fmt.Errorf("%s is not a worktree of repo %q (%s)", abs, repo, filepath.Join(cfg.RepoBasePath(repo), repo))
+	}
+	return nil, fmt.Errorf("%s is not a worktree of any configured repo -- create it from a repo's origin clone or check %s", abs, config.Path())
+}
+
+// CanonicalName returns the zen worktree directory name for the adoption:
+// <repo>-pr-<n> for PR reviews, <repo>-<branch> for features (without the
+// branch prefix, slashes replaced).
+func (a *Adoption) CanonicalName(cfg *config.Config, pr int) string {
+	if pr > 0 {
+		return fmt.Sprintf("%s-pr-%d", a.Repo, pr)
+	}
+	branch := a.Branch
+	if prefix := cfg.GetBranchPrefix(); prefix != "" {
+		branch = strings.TrimPrefix(branch, prefix+"/")
+	}
+	return fmt.Sprintf("%s-%s", a.Repo, strings.ReplaceAll(branch, "/", "-"))
+}
+
+// Move relocates the worktree with `git worktree move`, updating a.Path.
+func (a *Adoption) Move(cfg *config.Config, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("%s already exists", dest)
+	}
+	originPath := filepath.Join(cfg.RepoBasePath(a.Repo), a.Repo)
+	mu := RepoLock(originPath)
+	mu.Lock()
+	defer mu.Unlock()
+	if _, err := git(originPath, "worktree", "move", a.Path, dest); err != nil {
+		return err
+	}
+	a.Path = dest
+	return nil
+}
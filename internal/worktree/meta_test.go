@@ -0,0 +1,136 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/mgreau/zen/internal/config"
+)
+
+func TestAdoptWorktree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	base, _ := filepath.EvalSymlinks(t.TempDir())
+	origin := filepath.Join(base, "mono")
+	external := filepath.Join(base, "elsewhere", "review-stuff")
+
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	os.MkdirAll(origin, 0o755)
+	run(origin, "init", "-q", "-b", "main")
+	run(origin, "commit", "-q", "--allow-empty", "-m", "init")
+	run(origin, "worktree", "add", "-q", "-b", "pr-77", external)
+
+	cfg := &config.Config{Repos: map[string]config.RepoConfig{"mono": {FullName: "o/mono", BasePath: base}}}
+
+	if _, err := FindAdoption(cfg, origin, ""); err == nil {
+		t.Error("FindAdoption() should refuse the main clone")
+	}
+	if _, err := FindAdoption(cfg, t.TempDir(), ""); err == nil {
+		t.Error("FindAdoption() should refuse a non-git dir")
+	}
+
+	a, err := FindAdoption(cfg, external, "")
+	if err != nil {
+		t.Fatalf("FindAdoption() error: %v", err)
+	}
+	if a.Repo != "mono" || a.Branch != "pr-77" || a.Path != external {
+		t.Errorf("FindAdoption() = %+v, want mono worktree on pr-77", a)
+	}
+
+	// Without metadata, the external name classifies it as feature work
+	wts, _ := ListForRepo(cfg, "mono")
+	if len(wts) != 1 || wts[0].Type != TypeFeature {
+		t.Fatalf("ListForRepo() before adopt = %+v, want one feature worktree", wts)
+	}
+
+	if err := SetMeta(a.Path, Meta{Type: TypePRReview, PRNumber: 77}); err != nil {
+		t.Fatalf("SetMeta() error: %v", err)
+	}
+	wts, _ = ListForRepo(cfg, "mono")
+	if len(wts) != 1 || wts[0].Type != TypePRReview || wts[0].PRNumber != 77 {
+		t.Errorf("ListForRepo() after adopt = %+v, want PR review #77", wts)
+	}
+
+	if got := a.CanonicalName(cfg, 77); got != "mono-pr-77" {
+		t.Errorf("CanonicalName() = %q, want mono-pr-77", got)
+	}
+	dest := filepath.Join(base, "mono-pr-77")
+	if err := a.Move(cfg, dest); err != nil {
+		t.Fatalf("Move() error: %v", err)
+	}
+	wts, _ = ListForRepo(cfg, "mono")
+	if len(wts) != 1 || wts[0].Path != dest || wts[0].PRNumber != 77 {
+		t.Errorf("ListForRepo() after move = %+v, want PR review at %s", wts, dest)
+	}
+}
+
+func TestRecordOpenedPR(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	base, _ := filepath.EvalSymlinks(t.TempDir())
+	origin := filepath.Join(base, "mono")
+	feature := filepath.Join(base, "mono-retries")
+	review := filepath.Join(base, "mono-pr-9")
+
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	os.MkdirAll(origin, 0o755)
+	run(origin, "init", "-q", "-b", "main")
+	run(origin, "commit", "-q", "--allow-empty", "-m", "init")
+	run(origin, "worktree", "add", "-q", "-b", "retries", feature)
+	run(origin, "worktree", "add", "-q", "-b", "pr-9", review)
+
+	cfg := &config.Config{Repos: map[string]config.RepoConfig{"mono": {FullName: "o/mono", BasePath: base}}}
+
+	if err := RecordOpenedPR(feature, 12); err != nil {
+		t.Fatalf("RecordOpenedPR() error: %v", err)
+	}
+	if err := SetMeta(review, Meta{Type: TypePRReview, PRNumber: 9}); err != nil {
+		t.Fatalf("SetMeta() error: %v", err)
+	}
+	if err := RecordOpenedPR(review, 13); err == nil {
+		t.Error("RecordOpenedPR() should refuse a PR review worktree")
+	}
+
+	wts, _ := ListForRepo(cfg, "mono")
+	for _, w := range wts {
+		switch w.Path {
+		case feature:
+			if w.Type != TypeFeature || w.OpenedPR != 12 || w.PRNumber != 0 {
+				t.Errorf("feature worktree = %+v, want feature with opened PR #12", w)
+			}
+		case review:
+			if w.Type != TypePRReview || w.PRNumber != 9 || w.OpenedPR != 0 {
+				t.Errorf("review worktree = %+v, want PR review #9", w)
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package worktree
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// poolDirName is the directory under a repo's base_path that holds pooled
+// worktrees: blank checkouts of the default branch of the remote PRs come
+// from, waiting to be claimed by a PR.
+const poolDirName = ".zen-pool"
+
+// PoolDir returns the directory holding pooled worktrees for base_path.
+func PoolDir(basePath string) string {
+	return filepath.Join(basePath, poolDirName)
+}
+
+// IsPooled reports whether path is a pooled worktree rather than one in use.
+func IsPooled(path string) bool {
+	return filepath.Base(filepath.Dir(path)) == poolDirName
+}
+
+// Pooled returns the pooled worktrees of repo, oldest first.
+func Pooled(basePath, repo string) ([]string, error) {
+	entries, err := os.ReadDir(PoolDir(basePath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, e := range entries {
+		if e.IsDir() && strings.HasPrefix(e.Name(), repo+"-pool-") {
+			paths = append(paths, filepath.Join(PoolDir(basePath), e.Name()))
+		}
+	}
+	sort.Strings(paths) // names end in a creation timestamp
+	return paths, nil
+}
+
+// AddPooled creates a pooled worktree of originPath checked out at base,
+// a remote-tracking branch such as origin/main (detached), and returns its
+// path. Callers hold RepoLock(originPath).
+func AddPooled(originPath, basePath, repo, base string) (string, error) {
+	if err := os.MkdirAll(PoolDir(basePath), 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(PoolDir(basePath), fmt.Sprintf("%s-pool-%d", repo, time.Now().UnixNano()))
+	// --no-checkout + separate checkout, as for review worktrees, avoids
+	// "Could not write new index file" on large repos
+	if _, err := git(originPath, "worktree", "add", "--no-checkout", "--detach", path, base); err != nil {
+		return "", err
+	}
+	if _, err := git(path, "checkout", "-q"); err != nil {
+		RemovePooled(originPath, path)
+		return "", err
+	}
+	return path, nil
+}
+
+// FetchPoolBase fetches base, the remote-tracking branch pooled worktrees
+// are checked out at (see RemoteDefaultBranch), giving up after timeout.
+// Callers hold RepoLock(originPath).
+func FetchPoolBase(ctx context.Context, timeout time.Duration, originPath, base string) error {
+	remote, branch, _ := strings.Cut(base, "/")
+	_, err := Git(ctx, timeout, originPath, "fetch", "--quiet", remote, branch)
+	return err
+}
+
+// RefreshPooled moves a pooled worktree to the current base. Callers
+// fetch it with FetchPoolBase first and hold the clone's RepoLock.
+func RefreshPooled(path, base string) error {
+	_, err := git(path, "checkout", "-q", "--detach", base)
+	return err
+}
+
+// RemovePooled deletes a pooled worktree. Callers hold
+// RepoLock(originPath).
+func RemovePooled(originPath, path string) error {
+	_, err := git(originPath, "worktree", "remove", "--force", path)
+	return err
+}
+
+// PooledAt returns when the pooled worktree at path was last checked out,
+// read from its HEAD reflog.
+func PooledAt(path string) (time.Time, error) {
+	out, err := git(path, "log", "-g", "-1", "--date=unix", "--format=%gd", "HEAD")
+	if err != nil {
+		return time.Time{}, err
+	}
+	ts, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(out, "HEAD@{"), "}"), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing reflog entry %q: %w", out, err)
+	}
+	return time.Unix(ts, 0), nil
+}
+
+// ClaimPooled turns one of the pooled worktrees of the origin clone at
+// originPath (<base_path>/<repo>) into dest with branch
+// checked out, which only rewrites the files that differ from the pool's
+// base instead of checking out the whole tree. Returns false when the pool is
+// empty. Pooled worktrees with local changes are discarded. If the checkout
+// fails, dest is left for the caller to clean up as after a failed
+// worktree add. Callers hold RepoLock(originPath) and have fetched
+// branch.
+func ClaimPooled(originPath, dest, branch string) (bool, error) {
+	pooled, err := Pooled(filepath.Dir(originPath), filepath.Base(originPath))
+	if err != nil {
+		return false, err
+	}
+	for _, path := range pooled {
+		if dirty, err := UncommittedChanges(path); err != nil || len(dirty) > 0 {
+			RemovePooled(originPath, path)
+			continue
+		}
+		if _, err := git(originPath, "worktree", "move", path, dest); err != nil {
+			return false, err
+		}
+		if _, err := git(dest, "checkout", "-q", branch); err != nil {
+			return true, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,149 @@
+package worktree
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mgreau/zen/internal/config"
+)
+
+func TestWorktreePool(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	for k, v := range map[string]string{
+		"GIT_AUTHOR_NAME": "t", "GIT_AUTHOR_EMAIL": "t@example.com",
+		"GIT_COMMITTER_NAME": "t", "GIT_COMMITTER_EMAIL": "t@example.com",
+	} {
+		t.Setenv(k, v)
+	}
+
+	base, _ := filepath.EvalSymlinks(t.TempDir())
+	upstream := filepath.Join(base, "upstream")
+	origin := filepath.Join(base, "mono")
+
+	run := func(dir string, args ...string) {
+		t.Helper()
+		if out, err := git(dir, args...); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	os.MkdirAll(upstream, 0o755)
+	run(upstream, "init", "-q", "-b", "main")
+	os.WriteFile(filepath.Join(upstream, "README"), []byte("hi\n"), 0o644)
+	run(upstream, "add", "README")
+	run(upstream, "commit", "-q", "-m", "init")
+	run(upstream, "checkout", "-q", "-b", "pr-5")
+	os.WriteFile(filepath.Join(upstream, "fix.go"), []byte("package fix\n"), 0o644)
+	run(upstream, "add", "fix.go")
+	run(upstream, "commit", "-q", "-m", "fix")
+	run(upstream, "checkout", "-q", "main")
+	run(base, "clone", "-q", upstream, origin)
+	run(origin, "fetch", "-q", "origin", "pr-5:pr-5")
+
+	if claimed, err := ClaimPooled(origin, filepath.Join(base, "mono-pr-5"), "pr-5"); claimed || err != nil {
+		t.Fatalf("ClaimPooled() on an empty pool = %v, %v; want false, nil", claimed, err)
+	}
+
+	var paths []string
+	for range 2 {
+		path, err := AddPooled(origin, base, "mono", "origin/main")
+		if err != nil {
+			t.Fatalf("AddPooled() error: %v", err)
+		}
+		if !IsPooled(path) {
+			t.Errorf("IsPooled(%s) = false", path)
+		}
+		paths = append(paths, path)
+	}
+	if got, _ := Pooled(base, "mono"); len(got) != 2 || got[0] != paths[0] {
+		t.Fatalf("Pooled() = %v; want %v", got, paths)
+	}
+	if at, err := PooledAt(paths[0]); err != nil || time.Since(at) > time.Minute {
+		t.Errorf("PooledAt() = %v, %v; want about now", at, err)
+	}
+
+	cfg := &config.Config{Repos: map[string]config.RepoConfig{"mono": {FullName: "o/mono", BasePath: base}}}
+	if wts, _ := ListForRepo(cfg, "mono"); len(wts) != 0 {
+		t.Errorf("ListForRepo() = %+v; pooled worktrees should not be listed", wts)
+	}
+
+	// A dirty pooled worktree is discarded rather than claimed
+	os.WriteFile(filepath.Join(paths[0], "scratch"), []byte("x"), 0o644)
+
+	dest := filepath.Join(base, "mono-pr-5")
+	claimed, err := ClaimPooled(origin, dest, "pr-5")
+	if !claimed || err != nil {
+		t.Fatalf("ClaimPooled() = %v, %v; want true, nil", claimed, err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "fix.go")); err != nil {
+		t.Errorf("claimed worktree should have the PR branch checked out: %v", err)
+	}
+	if got, _ := Pooled(base, "mono"); len(got) != 0 {
+		t.Errorf("Pooled() after claim = %v; want empty", got)
+	}
+	wts, _ := ListForRepo(cfg, "mono")
+	if len(wts) != 1 || wts[0].Path != dest || wts[0].Branch != "pr-5" || wts[0].PRNumber != 5 {
+		t.Errorf("ListForRepo() after claim = %+v; want PR review #5 at %s", wts, dest)
+	}
+}
+
+func TestWorktreePoolBase(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	for k, v := range map[string]string{
+		"GIT_AUTHOR_NAME": "t", "GIT_AUTHOR_EMAIL": "t@example.com",
+		"GIT_COMMITTER_NAME": "t", "GIT_COMMITTER_EMAIL": "t@example.com",
+	} {
+		t.Setenv(k, v)
+	}
+
+	base, _ := filepath.EvalSymlinks(t.TempDir())
+	upstream := filepath.Join(base, "upstream")
+	origin := filepath.Join(base, "mono")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		if out, err := git(dir, args...); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	// The repo PRs come from is a second remote whose default branch is
+	// trunk, not main
+	os.MkdirAll(upstream, 0o755)
+	run(upstream, "init", "-q", "-b", "trunk")
+	os.WriteFile(filepath.Join(upstream, "README"), []byte("hi\n"), 0o644)
+	run(upstream, "add", "README")
+	run(upstream, "commit", "-q", "-m", "init")
+	run(base, "clone", "-q", upstream, origin)
+	run(origin, "remote", "add", "upstream", upstream)
+	run(origin, "fetch", "-q", "upstream")
+	run(origin, "remote", "set-head", "upstream", "trunk")
+
+	poolBase := RemoteDefaultBranch(origin, "upstream")
+	if poolBase != "upstream/trunk" {
+		t.Fatalf("RemoteDefaultBranch() = %q, want upstream/trunk", poolBase)
+	}
+	os.WriteFile(filepath.Join(upstream, "new.go"), []byte("package x\n"), 0o644)
+	run(upstream, "add", "new.go")
+	run(upstream, "commit", "-q", "-m", "new")
+	if err := FetchPoolBase(context.Background(), time.Minute, origin, poolBase); err != nil {
+		t.Fatalf("FetchPoolBase() error: %v", err)
+	}
+	path, err := AddPooled(origin, base, "mono", poolBase)
+	if err != nil {
+		t.Fatalf("AddPooled() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(path, "new.go")); err != nil {
+		t.Errorf("pooled worktree is not at the fetched upstream/trunk: %v", err)
+	}
+	if err := RefreshPooled(path, poolBase); err != nil {
+		t.Errorf("RefreshPooled() error: %v", err)
+	}
+}
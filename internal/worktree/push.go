@@ -0,0 +1,55 @@
+package worktree
+
+// ForkRemote is the remote zen adds for the user's fork in contributor
+// mode.
+const ForkRemote = "fork"
+
+// PushBranch pushes branch from the checkout at path to its push remote
+// (origin unless SetForkRemote pointed it at a fork) and sets it as the
+// branch's upstream.
+func PushBranch(path, branch string) error {
+	_, err := git(path, "push", "--quiet", "-u", PushRemote(path, branch), branch)
+	return err
+}
+
+// PushRemote returns the remote branch is pushed to: its pushRemote,
+// else origin.
+func PushRemote(path, branch string) string {
+	if remote, err := git(path, "config", "--get", "branch."+branch+".pushRemote"); err == nil && remote != "" {
+		return remote
+	}
+	return "origin"
+}
+
+// RemoteURL returns the URL of remote in the checkout at path.
+func RemoteURL(path, remote string) (string, error) {
+	return git(path, "remote", "get-url", remote)
+}
+
+// SetForkRemote points the ForkRemote remote at url, adding it if needed,
+// and makes it the push remote of branch so pushes go to the fork while
+// fetches still come from origin.
+func SetForkRemote(path, url, branch string) error {
+	if current, err := RemoteURL(path, ForkRemote); err != nil {
+		if _, err := git(path, "remote", "add", ForkRemote, url); err != nil {
+			return err
+		}
+	} else if current != url {
+		if _, err := git(path, "remote", "set-url", ForkRemote, url); err != nil {
+			return err
+		}
+	}
+	return SetPushRemote(path, branch, ForkRemote)
+}
+
+// SetPushRemote makes remote the push remote of branch, e.g. origin for a
+// branch that tracks upstream/main in a triangular workflow.
+func SetPushRemote(path, branch, remote string) error {
+	_, err := git(path, "config", "branch."+branch+".pushRemote", remote)
+	return err
+}
+
+// MergeBase returns the best common ancestor of a and b.
+func MergeBase(path, a, b string) (string, error) {
+	return git(path, "merge-base", a, b)
+}
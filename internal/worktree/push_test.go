@@ -0,0 +1,42 @@
+package worktree
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestPushBranchToFork(t *testing.T) {
+	mainPath, wtPath := initSyncRepo(t)
+	if got := PushRemote(wtPath, "feature"); got != "origin" {
+		t.Fatalf("PushRemote() = %q before SetForkRemote, want origin", got)
+	}
+
+	fork := filepath.Join(t.TempDir(), "fork.git")
+	if out, err := exec.Command("git", "init", "-q", "--bare", fork).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+	if err := SetForkRemote(wtPath, "/nowhere.git", "feature"); err != nil {
+		t.Fatalf("SetForkRemote() error: %v", err)
+	}
+	// A second call moves the remote to the new URL.
+	if err := SetForkRemote(wtPath, fork, "feature"); err != nil {
+		t.Fatalf("SetForkRemote() again error: %v", err)
+	}
+	if got, _ := RemoteURL(mainPath, ForkRemote); got != fork {
+		t.Errorf("fork remote URL = %q, want %q", got, fork)
+	}
+	if got := PushRemote(wtPath, "feature"); got != ForkRemote {
+		t.Errorf("PushRemote() = %q, want %q", got, ForkRemote)
+	}
+
+	if err := PushBranch(wtPath, "feature"); err != nil {
+		t.Fatalf("PushBranch() error: %v", err)
+	}
+	if _, err := git(fork, "rev-parse", "--verify", "refs/heads/feature"); err != nil {
+		t.Errorf("feature was not pushed to the fork: %v", err)
+	}
+	if _, err := git(mainPath, "ls-remote", "--exit-code", "origin", "refs/heads/feature"); err == nil {
+		t.Errorf("feature was pushed to origin")
+	}
+}
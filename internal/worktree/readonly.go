@@ -0,0 +1,79 @@
+package worktree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// readOnlyHooksDir holds the hooks of a read-only worktree, in the
+// worktree's own git directory so they go away with the worktree.
+const readOnlyHooksDir = "zen-readonly-hooks"
+
+// readOnlyHooks refuse commits, merge commits and pushes.
+var readOnlyHooks = []string{"pre-commit", "pre-merge-commit", "pre-push"}
+
+// Lock makes the worktree at path read-only: commits and pushes from it
+// fail with a message that ends with unlockHint, e.g. the command that
+// unlocks it. It points the worktree's core.hooksPath at hooks that
+// refuse, so the repo's own hooks don't run there while it is locked, and
+// enables extensions.worktreeConfig in the repo to keep the setting to
+// this worktree. --no-verify still gets past the hooks.
+func Lock(path, unlockHint string) error {
+	gitDir, err := git(path, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return err
+	}
+	hooks := filepath.Join(gitDir, readOnlyHooksDir)
+	if err := os.MkdirAll(hooks, 0o755); err != nil {
+		return err
+	}
+	script := fmt.Sprintf("#!/bin/sh\necho \"zen: %s is a read-only PR review worktree.\" >&2\necho %s >&2\nexit 1\n",
+		filepath.Base(path), shellQuote(unlockHint))
+	for _, name := range readOnlyHooks {
+		if err := os.WriteFile(filepath.Join(hooks, name), []byte(script), 0o755); err != nil {
+			return err
+		}
+	}
+	if _, err := git(path, "config", "extensions.worktreeConfig", "true"); err != nil {
+		return err
+	}
+	_, err = git(path, "config", "--worktree", "core.hooksPath", hooks)
+	return err
+}
+
+// LockReview makes a review worktree of PR pr read-only, pointing at
+// zen review unlock to undo it. suffix names an extra checkout of the PR.
+func LockReview(path string, pr int, suffix string) error {
+	hint := fmt.Sprintf("To commit or push from it, run: zen review unlock %d", pr)
+	if suffix != "" {
+		hint += " --name " + suffix
+	}
+	return Lock(path, hint)
+}
+
+// Unlock undoes Lock. Unlocking a worktree that isn't locked does
+// nothing.
+func Unlock(path string) error {
+	if !IsLocked(path) {
+		return nil
+	}
+	hooks, _ := git(path, "config", "--worktree", "--get", "core.hooksPath")
+	if _, err := git(path, "config", "--worktree", "--unset", "core.hooksPath"); err != nil {
+		return err
+	}
+	return os.RemoveAll(hooks)
+}
+
+// IsLocked reports whether the worktree at path was made read-only by
+// Lock.
+func IsLocked(path string) bool {
+	hooks, err := git(path, "config", "--worktree", "--get", "core.hooksPath")
+	return err == nil && filepath.Base(strings.TrimSpace(hooks)) == readOnlyHooksDir
+}
+
+// shellQuote single-quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,70 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLock(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	for k, v := range map[string]string{
+		"GIT_AUTHOR_NAME": "t", "GIT_AUTHOR_EMAIL": "t@example.com",
+		"GIT_COMMITTER_NAME": "t", "GIT_COMMITTER_EMAIL": "t@example.com",
+	} {
+		t.Setenv(k, v)
+	}
+
+	base, _ := filepath.EvalSymlinks(t.TempDir())
+	origin := filepath.Join(base, "mono")
+	review := filepath.Join(base, "mono-pr-5")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		if out, err := git(dir, args...); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	os.MkdirAll(origin, 0o755)
+	run(origin, "init", "-q", "-b", "main")
+	run(origin, "commit", "-q", "--allow-empty", "-m", "init")
+	run(origin, "worktree", "add", "-q", review, "-b", "pr-5")
+
+	if IsLocked(review) {
+		t.Fatal("IsLocked() = true before Lock")
+	}
+	if err := Lock(review, "Run 'zen review unlock 5' first"); err != nil {
+		t.Fatalf("Lock() error: %v", err)
+	}
+	if !IsLocked(review) {
+		t.Error("IsLocked() = false after Lock")
+	}
+	out, err := git(review, "commit", "--allow-empty", "-m", "oops")
+	if err == nil {
+		t.Fatal("commit in a locked worktree succeeded")
+	}
+	if !strings.Contains(out, "mono-pr-5 is a read-only") || !strings.Contains(out, "Run 'zen review unlock 5' first") {
+		t.Errorf("commit output = %q; want the read-only message and unlock hint", out)
+	}
+
+	// Other worktrees of the repo are not affected
+	run(origin, "commit", "-q", "--allow-empty", "-m", "main")
+	if IsLocked(origin) {
+		t.Error("IsLocked(origin) = true; Lock should only affect its worktree")
+	}
+
+	if err := Unlock(review); err != nil {
+		t.Fatalf("Unlock() error: %v", err)
+	}
+	if IsLocked(review) {
+		t.Error("IsLocked() = true after Unlock")
+	}
+	run(review, "commit", "-q", "--allow-empty", "-m", "suggestion")
+	if err := Unlock(review); err != nil {
+		t.Errorf("Unlock() of an unlocked worktree: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package worktree
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeadAt returns the commit HEAD pointed to at time t in the checkout at
+// path, read from the checkout's HEAD reflog. When t predates the reflog the
+// oldest entry is returned.
+func HeadAt(path string, t time.Time) (string, error) {
+	out, err := git(path, "log", "-g", "--date=unix", "--format=%H %gd", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	oldest := ""
+	// Entries are newest first: "<sha> HEAD@{<unix time>}"
+	for _, line := range strings.Split(out, "\n") {
+		sha, sel, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		oldest = sha
+		ts, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(sel, "HEAD@{"), "}"), 10, 64)
+		if err != nil {
+			continue
+		}
+		if !time.Unix(ts, 0).After(t) {
+			return sha, nil
+		}
+	}
+	if oldest == "" {
+		return "", fmt.Errorf("no HEAD reflog in %s", path)
+	}
+	return oldest, nil
+}
+
+// FetchPRHead fetches the current head of a GitHub PR from remote into the
+// checkout at path and returns its SHA. The worktree's branch is left alone.
+func FetchPRHead(path, remote string, prNumber int) (string, error) {
+	if _, err := git(path, "fetch", "--quiet", remote, fmt.Sprintf("pull/%d/head", prNumber)); err != nil {
+		return "", err
+	}
+	return git(path, "rev-parse", "FETCH_HEAD")
+}
+
+// CommitsBetween returns "<short sha> <subject>" for each commit reachable
+// from head but not from base, oldest first.
+func CommitsBetween(path, base, head string) ([]string, error) {
+	out, err := git(path, "log", "--reverse", "--format=%h %s", base+".."+head)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// FilesBetween returns `git diff --name-status` lines for the changes from
+// base to head, e.g. "M\tcmd/root.go".
+func FilesBetween(path, base, head string) ([]string, error) {
+	out, err := git(path, "diff", "--name-status", base, head)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
@@ -0,0 +1,85 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeadAtAndCommitsBetween(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+
+	// commitAt commits file at the given unix time; the reflog entry gets
+	// the committer date, so HEAD's history is deterministic.
+	commitAt := func(ts int64, file string) string {
+		t.Helper()
+		os.WriteFile(filepath.Join(dir, file), []byte(file+"\n"), 0o644)
+		for _, args := range [][]string{{"add", file}, {"commit", "-q", "-m", "add " + file}} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = dir
+			date := time.Unix(ts, 0).Format(time.RFC3339)
+			cmd.Env = append(os.Environ(),
+				"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+				"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com",
+				"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v: %v: %s", args, err, out)
+			}
+		}
+		sha, _ := git(dir, "rev-parse", "HEAD")
+		return sha
+	}
+
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+	first := commitAt(1_700_000_000, "a.txt")
+	second := commitAt(1_700_001_000, "b.txt")
+	third := commitAt(1_700_002_000, "c.txt")
+
+	tests := []struct {
+		at   int64
+		want string
+	}{
+		{1_700_000_500, first},
+		{1_700_001_000, second},
+		{1_700_001_999, second},
+		{1_800_000_000, third},
+		{1_600_000_000, first}, // before the reflog: oldest entry
+	}
+	for _, tt := range tests {
+		got, err := HeadAt(dir, time.Unix(tt.at, 0))
+		if err != nil {
+			t.Fatalf("HeadAt(%d): %v", tt.at, err)
+		}
+		if got != tt.want {
+			t.Errorf("HeadAt(%d) = %s, want %s", tt.at, shortSHA(got), shortSHA(tt.want))
+		}
+	}
+
+	commits, err := CommitsBetween(dir, first, third)
+	if err != nil {
+		t.Fatalf("CommitsBetween: %v", err)
+	}
+	if len(commits) != 2 || !strings.HasSuffix(commits[0], "add b.txt") || !strings.HasSuffix(commits[1], "add c.txt") {
+		t.Errorf("CommitsBetween = %v, want [add b.txt, add c.txt] oldest first", commits)
+	}
+
+	files, err := FilesBetween(dir, first, third)
+	if err != nil {
+		t.Fatalf("FilesBetween: %v", err)
+	}
+	if strings.Join(files, ",") != "A\tb.txt,A\tc.txt" {
+		t.Errorf("FilesBetween = %q", files)
+	}
+
+	if commits, _ := CommitsBetween(dir, third, third); commits != nil {
+		t.Errorf("CommitsBetween(same) = %v, want nil", commits)
+	}
+}
@@ -0,0 +1,44 @@
+package worktree
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SparseDirs returns the sorted, de-duplicated directories needed to check
+// out the given files in cone mode, plus the always-include dirs. Files at
+// the repository root are always present in cone mode and add nothing.
+func SparseDirs(files, include []string) []string {
+	seen := make(map[string]bool)
+	for _, f := range files {
+		dir := path.Dir(f)
+		if dir == "." || dir == "/" {
+			continue
+		}
+		seen[dir] = true
+	}
+	for _, d := range include {
+		d = strings.Trim(d, "/")
+		if d != "" {
+			seen[d] = true
+		}
+	}
+
+	dirs := make([]string, 0, len(seen))
+	for d := range seen {
+		dirs = append(dirs, d)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// ApplySparseCheckout enables cone-mode sparse-checkout limited to dirs in a
+// worktree created with --no-checkout. The caller runs `git checkout` after.
+func ApplySparseCheckout(ctx context.Context, timeout time.Duration, worktreePath string, dirs []string) error {
+	args := append([]string{"sparse-checkout", "set", "--cone"}, dirs...)
+	_, err := Git(ctx, timeout, worktreePath, args...)
+	return err
+}
@@ -0,0 +1,41 @@
+package worktree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSparseDirs(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string
+		include []string
+		want    []string
+	}{
+		{
+			name:  "dedupes parent dirs",
+			files: []string{"pkg/sts/a.go", "pkg/sts/b.go", "cmd/main.go"},
+			want:  []string{"cmd", "pkg/sts"},
+		},
+		{
+			name:  "root files add nothing",
+			files: []string{"README.md", "go.mod"},
+			want:  []string{},
+		},
+		{
+			name:    "always-include dirs are merged and trimmed",
+			files:   []string{"pkg/sts/a.go"},
+			include: []string{"/hack/", "pkg/sts", ""},
+			want:    []string{"hack", "pkg/sts"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SparseDirs(tt.files, tt.include)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SparseDirs(%v, %v) = %v, want %v", tt.files, tt.include, got, tt.want)
+			}
+		})
+	}
+}
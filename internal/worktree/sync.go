@@ -0,0 +1,121 @@
+package worktree
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// git runs a git command in dir and returns its trimmed combined output.
+// It is killed after DefaultGitTimeout, see Git.
+func git(dir string, args ...string) (string, error) {
+	return Git(context.Background(), 0, dir, args...)
+}
+
+// UncommittedChanges returns `git status --porcelain` lines for path,
+// including untracked files. Empty means the checkout is clean.
+func UncommittedChanges(path string) ([]string, error) {
+	out, err := git(path, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// LocalCommits returns, oldest first, the commits on src's HEAD that are
+// neither in dst's HEAD nor on any branch of origin or the upstream remote
+// -- i.e. work that only exists in the src checkout and would be lost by
+// resetting it.
+func LocalCommits(src, dst, upstream string) ([]string, error) {
+	dstHead, err := git(dst, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"rev-list", "--reverse", "HEAD", "--not", dstHead, "--remotes=origin"}
+	if upstream != "" && upstream != "origin" {
+		args = append(args, "--remotes="+upstream)
+	}
+	out, err := git(src, args...)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Fields(out), nil
+}
+
+// SyncResult describes what MoveWork transferred.
+type SyncResult struct {
+	Commits   []string // commits cherry-picked onto dst
+	Changes   int      // uncommitted paths moved to dst
+	StashKept string   // stash commit left behind when applying to dst conflicted
+}
+
+// MoveWork moves in-progress work from the src checkout to the dst
+// checkout of the same repository. The given commits (from LocalCommits) are
+// cherry-picked onto dst, then uncommitted and untracked changes in src are
+// stashed and applied in dst. Worktrees share refs/stash, so the stash made
+// in src is visible in dst.
+//
+// dst must be clean. If cherry-picking fails, dst is restored and src keeps
+// its changes. If applying the stash conflicts, the stash is kept and its
+// SHA returned in StashKept so the user can resolve and drop it. The commits
+// stay on src's branch; callers should tell the user how to remove them.
+func MoveWork(src, dst string, commits []string, label string) (*SyncResult, error) {
+	if dirty, err := UncommittedChanges(dst); err != nil {
+		return nil, err
+	} else if len(dirty) > 0 {
+		return nil, fmt.Errorf("%s has uncommitted changes -- commit or stash them first", dst)
+	}
+
+	changes, err := UncommittedChanges(src)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SyncResult{}
+
+	if len(commits) > 0 {
+		args := append([]string{"cherry-pick"}, commits...)
+		if _, err := git(dst, args...); err != nil {
+			git(dst, "cherry-pick", "--abort")
+			return nil, err
+		}
+		res.Commits = commits
+	}
+
+	if len(changes) == 0 {
+		return res, nil
+	}
+
+	if _, err := git(src, "stash", "push", "--include-untracked", "-m", "zen work sync: "+label); err != nil {
+		return res, err
+	}
+	stash, err := git(src, "rev-parse", "stash@{0}")
+	if err != nil {
+		return res, err
+	}
+
+	if _, err := git(dst, "stash", "apply", stash); err != nil {
+		res.StashKept = stash
+		return res, fmt.Errorf("applying changes in %s conflicted; they are kept in stash %s: %w", dst, shortSHA(stash), err)
+	}
+	res.Changes = len(changes)
+
+	// Drop the stash only if it is still on top (nobody stashed meanwhile)
+	if top, err := git(src, "rev-parse", "stash@{0}"); err == nil && top == stash {
+		git(src, "stash", "drop", "stash@{0}")
+	}
+	return res, nil
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 10 {
+		return sha[:10]
+	}
+	return sha
+}
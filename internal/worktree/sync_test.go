@@ -0,0 +1,113 @@
+package worktree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mgreau/zen/internal/gittest"
+)
+
+// initSyncRepo creates a clone-like layout: an origin bare repo, a main
+// checkout with origin/main, and a feature worktree branched from it.
+func initSyncRepo(t *testing.T) (mainPath, wtPath string) {
+	t.Helper()
+	dir := gittest.Setup(t)
+	bare := filepath.Join(dir, "origin.git")
+	mainPath = filepath.Join(dir, "repo")
+	wtPath = filepath.Join(dir, "repo-feature")
+
+	gittest.Run(t, dir, "init", "-q", "--bare", "-b", "main", bare)
+	gittest.Run(t, dir, "clone", "-q", bare, mainPath)
+	gittest.Run(t, mainPath, "checkout", "-q", "-b", "main")
+	os.WriteFile(filepath.Join(mainPath, "a.txt"), []byte("a\n"), 0o644)
+	gittest.Run(t, mainPath, "add", "a.txt")
+	gittest.Run(t, mainPath, "commit", "-q", "-m", "init")
+	gittest.Run(t, mainPath, "push", "-q", "origin", "main")
+	gittest.Run(t, mainPath, "worktree", "add", "-q", wtPath, "-b", "feature", "origin/main")
+
+	// A local-only commit and uncommitted work in the main checkout
+	os.WriteFile(filepath.Join(mainPath, "b.txt"), []byte("b\n"), 0o644)
+	gittest.Run(t, mainPath, "add", "b.txt")
+	gittest.Run(t, mainPath, "commit", "-q", "-m", "local commit")
+	os.WriteFile(filepath.Join(mainPath, "a.txt"), []byte("a changed\n"), 0o644)
+	os.WriteFile(filepath.Join(mainPath, "new.txt"), []byte("untracked\n"), 0o644)
+	return mainPath, wtPath
+}
+
+func TestMoveWork(t *testing.T) {
+	mainPath, wtPath := initSyncRepo(t)
+
+	commits, err := LocalCommits(mainPath, wtPath, "origin")
+	if err != nil {
+		t.Fatalf("LocalCommits() error: %v", err)
+	}
+	if len(commits) != 1 {
+		t.Fatalf("LocalCommits() = %v, want 1 local commit", commits)
+	}
+
+	res, err := MoveWork(mainPath, wtPath, commits, "feature")
+	if err != nil {
+		t.Fatalf("MoveWork() error: %v", err)
+	}
+	if len(res.Commits) != 1 || res.Changes != 2 || res.StashKept != "" {
+		t.Errorf("MoveWork() = %+v, want 1 commit and 2 changes moved", res)
+	}
+
+	for name, want := range map[string]string{"a.txt": "a changed\n", "b.txt": "b\n", "new.txt": "untracked\n"} {
+		data, err := os.ReadFile(filepath.Join(wtPath, name))
+		if err != nil || string(data) != want {
+			t.Errorf("worktree %s = %q, %v; want %q", name, data, err, want)
+		}
+	}
+
+	if dirty, _ := UncommittedChanges(mainPath); len(dirty) != 0 {
+		t.Errorf("main checkout still has changes: %v", dirty)
+	}
+	if out, _ := git(mainPath, "stash", "list"); out != "" {
+		t.Errorf("stash should be dropped after a clean apply, got %q", out)
+	}
+}
+
+func TestLocalCommits_upstream(t *testing.T) {
+	mainPath, wtPath := initSyncRepo(t)
+
+	// In a fork setup the local commit may already be on the upstream remote
+	upstream := filepath.Join(filepath.Dir(mainPath), "upstream.git")
+	gittest.Run(t, mainPath, "init", "-q", "--bare", upstream)
+	gittest.Run(t, mainPath, "remote", "add", "upstream", upstream)
+	gittest.Run(t, mainPath, "push", "-q", "upstream", "main")
+	gittest.Run(t, mainPath, "fetch", "-q", "upstream")
+
+	if commits, err := LocalCommits(mainPath, wtPath, "origin"); err != nil || len(commits) != 1 {
+		t.Errorf("LocalCommits(origin) = %v, %v; want the local commit", commits, err)
+	}
+	if commits, err := LocalCommits(mainPath, wtPath, "upstream"); err != nil || len(commits) != 0 {
+		t.Errorf("LocalCommits(upstream) = %v, %v; want none, the commit is on upstream", commits, err)
+	}
+}
+
+func TestMoveWork_dirtyDestination(t *testing.T) {
+	mainPath, wtPath := initSyncRepo(t)
+	os.WriteFile(filepath.Join(wtPath, "wip.txt"), []byte("wip\n"), 0o644)
+
+	if _, err := MoveWork(mainPath, wtPath, nil, "feature"); err == nil {
+		t.Fatal("MoveWork() should refuse a dirty destination")
+	}
+	if dirty, _ := UncommittedChanges(mainPath); len(dirty) == 0 {
+		t.Error("source changes should be untouched when the move is refused")
+	}
+}
+
+func TestNeedsCheckout(t *testing.T) {
+	mainPath, wtPath := initSyncRepo(t)
+	if NeedsCheckout(wtPath) {
+		t.Error("NeedsCheckout() = true for a checked-out worktree")
+	}
+
+	bare := filepath.Join(filepath.Dir(mainPath), "repo-no-checkout")
+	gittest.Run(t, mainPath, "worktree", "add", "--no-checkout", "-q", "--detach", bare, "main")
+	if !NeedsCheckout(bare) {
+		t.Error("NeedsCheckout() = false for a --no-checkout worktree")
+	}
+}
@@ -18,4 +18,5 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	os.Exit(cmd.ExitCode())
 }
@@ -0,0 +1,98 @@
+package zen
+
+import (
+	"context"
+	"fmt"
+
+	ghpkg "github.com/mgreau/zen/internal/github"
+)
+
+// PR is an open pull request returned by a search.
+type PR struct {
+	// Repo is the GitHub owner/repo.
+	Repo      string `json:"repo"`
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	Author    string `json:"author"`
+	URL       string `json:"url"`
+	CreatedAt string `json:"created_at"`
+}
+
+// PRDetails is the full metadata of a single pull request.
+type PRDetails struct {
+	Repo       string `json:"repo"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	Author     string `json:"author"`
+	State      string `json:"state"`
+	URL        string `json:"url"`
+	Body       string `json:"body"`
+	HeadBranch string `json:"head_branch"`
+	BaseBranch string `json:"base_branch"`
+	HeadSHA    string `json:"head_sha"`
+	IsFork     bool   `json:"is_fork"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// ReviewRequests returns open PRs awaiting the authenticated user's review,
+// including re-review requests. repo is a short name or owner/repo; empty
+// searches every repository. Requires the GitHub CLI to be logged in.
+func (c *Client) ReviewRequests(ctx context.Context, repo string) ([]PR, error) {
+	filter := ""
+	if repo != "" {
+		filter = c.fullName(repo)
+	}
+	reviews, _, err := ghpkg.GetReviewRequests(ctx, filter, c.cfg.GetSearchLimit())
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]PR, 0, len(reviews))
+	for _, r := range reviews {
+		prs = append(prs, PR{
+			Repo:      r.Repository.NameWithOwner,
+			Number:    r.Number,
+			Title:     r.Title,
+			Author:    r.Author.Login,
+			URL:       r.URL,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+	return prs, nil
+}
+
+// PR fetches a pull request's metadata. repo is a short name or owner/repo.
+func (c *Client) PR(ctx context.Context, repo string, number int) (*PRDetails, error) {
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating GitHub client: %w", err)
+	}
+	full := c.fullName(repo)
+	d, err := client.GetPRDetails(ctx, full, number)
+	if err != nil {
+		return nil, err
+	}
+	return &PRDetails{
+		Repo:       full,
+		Number:     d.Number,
+		Title:      d.Title,
+		Author:     d.Author,
+		State:      d.State,
+		URL:        d.URL,
+		Body:       d.Body,
+		HeadBranch: d.HeadRefName,
+		BaseBranch: d.BaseRefName,
+		HeadSHA:    d.HeadSHA,
+		IsFork:     d.IsFork,
+		CreatedAt:  d.CreatedAt,
+	}, nil
+}
+
+// PRFiles returns the paths changed by a pull request. repo is a short
+// name or owner/repo.
+func (c *Client) PRFiles(ctx context.Context, repo string, number int) ([]string, error) {
+	client, err := ghpkg.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating GitHub client: %w", err)
+	}
+	return client.GetPRFiles(ctx, c.fullName(repo), number)
+}
@@ -0,0 +1,77 @@
+package zen
+
+import (
+	"time"
+
+	"github.com/mgreau/zen/internal/session"
+)
+
+// Session is a Claude Code session recorded for a worktree.
+type Session struct {
+	ID string `json:"id"`
+	// Path is the session's .jsonl transcript.
+	Path     string    `json:"path"`
+	Modified time.Time `json:"modified"`
+	Size     int64     `json:"size"`
+}
+
+// TokenUsage counts the tokens a session consumed.
+type TokenUsage struct {
+	Input         int64 `json:"input"`
+	Output        int64 `json:"output"`
+	CacheCreation int64 `json:"cache_creation"`
+	CacheRead     int64 `json:"cache_read"`
+}
+
+// Total returns the sum of all token counts.
+func (u TokenUsage) Total() int64 {
+	return u.Input + u.Output + u.CacheCreation + u.CacheRead
+}
+
+// SessionUsage is a session's model and token usage.
+type SessionUsage struct {
+	Model  string     `json:"model"`
+	Tokens TokenUsage `json:"tokens"`
+}
+
+// Sessions returns the Claude sessions recorded for a worktree, most
+// recently active first. A worktree without sessions returns none.
+func (c *Client) Sessions(worktreePath string) ([]Session, error) {
+	found, err := session.FindSessions(worktreePath)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Session, 0, len(found))
+	for _, s := range found {
+		out = append(out, Session{
+			ID:       s.ID,
+			Path:     session.SessionFilePath(worktreePath, s.ID),
+			Modified: time.Unix(s.Modified, 0),
+			Size:     s.Size,
+		})
+	}
+	return out, nil
+}
+
+// SessionRunning reports whether a Claude process is running the session.
+func (c *Client) SessionRunning(sessionID string) bool {
+	return session.IsProcessRunning(sessionID)
+}
+
+// SessionUsage reads a session transcript and returns the model it last
+// used and its total token usage.
+func (c *Client) SessionUsage(s Session) (SessionUsage, error) {
+	model, tokens, err := session.ParseSessionDetailFull(s.Path)
+	if err != nil {
+		return SessionUsage{}, err
+	}
+	return SessionUsage{
+		Model: model,
+		Tokens: TokenUsage{
+			Input:         tokens.InputTokens,
+			Output:        tokens.OutputTokens,
+			CacheCreation: tokens.CacheCreationInputTokens,
+			CacheRead:     tokens.CacheReadInputTokens,
+		},
+	}, nil
+}
@@ -0,0 +1,62 @@
+package zen
+
+import (
+	"github.com/mgreau/zen/internal/worktree"
+)
+
+// WorktreeKind tells PR review worktrees from feature work.
+type WorktreeKind string
+
+const (
+	KindPRReview WorktreeKind = "pr-review"
+	KindFeature  WorktreeKind = "feature"
+)
+
+// Worktree is a git worktree managed by zen.
+type Worktree struct {
+	Path   string       `json:"path"`
+	Name   string       `json:"name"`
+	Branch string       `json:"branch"`
+	Kind   WorktreeKind `json:"kind"`
+	// Repo is the short name of the repository the worktree belongs to.
+	Repo string `json:"repo"`
+	// PRNumber is set for PR review worktrees.
+	PRNumber int `json:"pr_number,omitempty"`
+}
+
+// Worktrees lists the worktrees of every configured repository, excluding
+// the main clones.
+func (c *Client) Worktrees() ([]Worktree, error) {
+	wts, err := worktree.ListAll(c.cfg)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Worktree, 0, len(wts))
+	for _, w := range wts {
+		out = append(out, Worktree{
+			Path:     w.Path,
+			Name:     w.Name,
+			Branch:   w.Branch,
+			Kind:     WorktreeKind(w.Type),
+			Repo:     w.Repo,
+			PRNumber: w.PRNumber,
+		})
+	}
+	return out, nil
+}
+
+// PRWorktree returns the review worktree for a PR, or nil if there is none.
+// repo is a short name or owner/repo.
+func (c *Client) PRWorktree(repo string, number int) (*Worktree, error) {
+	wts, err := c.Worktrees()
+	if err != nil {
+		return nil, err
+	}
+	short := c.cfg.RepoShortName(c.fullName(repo))
+	for _, w := range wts {
+		if w.Kind == KindPRReview && w.Repo == short && w.PRNumber == number {
+			return &w, nil
+		}
+	}
+	return nil, nil
+}
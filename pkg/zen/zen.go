@@ -0,0 +1,78 @@
+// Package zen is the Go API for embedding zen in other tools, such as
+// editor plugins or bots. It loads the user's zen configuration, discovers
+// worktrees, queries pull requests and inspects Claude sessions, without
+// shelling out to the zen CLI.
+//
+// The types in this package are stable: fields may be added but are never
+// renamed or removed. Packages under internal/ carry no such promise.
+package zen
+
+import (
+	"sort"
+
+	"github.com/mgreau/zen/internal/config"
+)
+
+// Client gives access to zen's core for one configuration.
+type Client struct {
+	cfg *config.Config
+}
+
+// Load reads the zen configuration the CLI would use: $ZEN_CONFIG if set,
+// otherwise ~/.zen/config.yaml.
+func Load() (*Client, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Client{cfg: cfg}, nil
+}
+
+// LoadFile reads the zen configuration from path.
+func LoadFile(path string) (*Client, error) {
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{cfg: cfg}, nil
+}
+
+// ConfigPath returns the path Load reads the configuration from.
+func ConfigPath() string {
+	return config.Path()
+}
+
+// Repo is a repository configured in zen.
+type Repo struct {
+	// Name is the short name used in worktree names and commands.
+	Name string `json:"name"`
+	// FullName is the GitHub owner/repo.
+	FullName string `json:"full_name"`
+	// BasePath is the directory holding the main clone and its worktrees.
+	BasePath string `json:"base_path"`
+}
+
+// Repos returns the configured repositories, sorted by short name.
+func (c *Client) Repos() []Repo {
+	repos := make([]Repo, 0, len(c.cfg.Repos))
+	for name, r := range c.cfg.Repos {
+		repos = append(repos, Repo{Name: name, FullName: r.FullName, BasePath: r.BasePath})
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Name < repos[j].Name })
+	return repos
+}
+
+// Authors returns the GitHub logins whose PRs zen sets up automatically.
+func (c *Client) Authors() []string {
+	return append([]string(nil), c.cfg.Authors...)
+}
+
+// IsAuthor reports whether login is one of the configured authors.
+func (c *Client) IsAuthor(login string) bool {
+	return c.cfg.IsAuthor(login)
+}
+
+// fullName maps a short name to owner/repo; full names pass through.
+func (c *Client) fullName(repo string) string {
+	return c.cfg.RepoFullName(repo)
+}
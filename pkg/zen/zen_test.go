@@ -0,0 +1,124 @@
+package zen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setup writes a config with two repos under a temp HOME; the "app" repo
+// has a main clone with one PR review worktree.
+func setup(t *testing.T) (client *Client, worktreePath string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	base := filepath.Join(home, "git", "repo-app")
+	clone := filepath.Join(base, "app")
+	worktreePath = filepath.Join(base, "app-pr-42")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	os.MkdirAll(clone, 0o755)
+	run(clone, "init", "-q", "-b", "main")
+	run(clone, "commit", "-q", "--allow-empty", "-m", "init")
+	run(clone, "worktree", "add", "-q", "-b", "pr-42", worktreePath)
+
+	cfgPath := filepath.Join(home, "zen.yaml")
+	os.WriteFile(cfgPath, []byte(`repos:
+  app:
+    full_name: octo/app
+    base_path: `+base+`
+  api:
+    full_name: octo/api
+    base_path: `+filepath.Join(home, "git", "repo-api")+`
+authors: [alice]
+`), 0o644)
+
+	client, err := LoadFile(cfgPath)
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	return client, worktreePath
+}
+
+func TestReposAndAuthors(t *testing.T) {
+	c, _ := setup(t)
+
+	repos := c.Repos()
+	if len(repos) != 2 || repos[0].Name != "api" || repos[1].Name != "app" || repos[1].FullName != "octo/app" {
+		t.Errorf("Repos() = %+v, want api and app sorted by name", repos)
+	}
+	if !c.IsAuthor("alice") || c.IsAuthor("bob") {
+		t.Error("IsAuthor should match configured authors only")
+	}
+	if got := c.Authors(); len(got) != 1 || got[0] != "alice" {
+		t.Errorf("Authors() = %v, want [alice]", got)
+	}
+}
+
+func TestWorktrees(t *testing.T) {
+	c, wtPath := setup(t)
+
+	wts, err := c.Worktrees()
+	if err != nil {
+		t.Fatalf("Worktrees: %v", err)
+	}
+	if len(wts) != 1 {
+		t.Fatalf("Worktrees() = %+v, want one PR worktree", wts)
+	}
+	w := wts[0]
+	if w.Kind != KindPRReview || w.PRNumber != 42 || w.Repo != "app" || w.Branch != "pr-42" {
+		t.Errorf("worktree = %+v", w)
+	}
+
+	for _, repo := range []string{"app", "octo/app"} {
+		got, err := c.PRWorktree(repo, 42)
+		if err != nil || got == nil || !strings.HasSuffix(got.Path, filepath.Base(wtPath)) {
+			t.Errorf("PRWorktree(%q, 42) = %+v, %v", repo, got, err)
+		}
+	}
+	if got, _ := c.PRWorktree("app", 7); got != nil {
+		t.Errorf("PRWorktree(app, 7) = %+v, want nil", got)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	c, wtPath := setup(t)
+
+	if got, err := c.Sessions(wtPath); err != nil || len(got) != 0 {
+		t.Fatalf("Sessions() before any session = %v, %v", got, err)
+	}
+
+	projectDir := filepath.Join(os.Getenv("HOME"), ".claude", "projects",
+		strings.NewReplacer("/", "-", ".", "-").Replace(wtPath))
+	os.MkdirAll(projectDir, 0o755)
+	os.WriteFile(filepath.Join(projectDir, "abc.jsonl"), []byte(
+		`{"message":{"model":"claude-opus-4-6","usage":{"input_tokens":100,"output_tokens":20,"cache_read_input_tokens":5}}}`+"\n"+
+			`{"message":{"model":"claude-opus-4-6","usage":{"input_tokens":200,"output_tokens":40}}}`+"\n"), 0o644)
+
+	sessions, err := c.Sessions(wtPath)
+	if err != nil || len(sessions) != 1 || sessions[0].ID != "abc" {
+		t.Fatalf("Sessions() = %+v, %v", sessions, err)
+	}
+	usage, err := c.SessionUsage(sessions[0])
+	if err != nil {
+		t.Fatalf("SessionUsage: %v", err)
+	}
+	if usage.Model != "claude-opus-4-6" || usage.Tokens.Input != 300 || usage.Tokens.Output != 60 || usage.Tokens.Total() != 365 {
+		t.Errorf("SessionUsage() = %+v", usage)
+	}
+}